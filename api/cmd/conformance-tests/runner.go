@@ -890,22 +890,22 @@ func (r *testRunner) getKubeconfig(log *zap.SugaredLogger, cluster *kubermaticv1
 func (r *testRunner) getCloudConfig(log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (string, error) {
 	log.Debug("Getting cloud-config...")
 
-	var cmData string
+	var cloudConfig []byte
 	err := retryNAttempts(defaultAPIRetries, func(attempt int) error {
-		cm := &corev1.ConfigMap{}
-		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CloudConfigConfigMapName}
-		if err := r.seedClusterClient.Get(context.Background(), name, cm); err != nil {
+		secret := &corev1.Secret{}
+		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CloudConfigSeedSecretName}
+		if err := r.seedClusterClient.Get(context.Background(), name, secret); err != nil {
 			return fmt.Errorf("failed to load cloud-config: %v", err)
 		}
-		cmData = cm.Data["config"]
+		cloudConfig = secret.Data[resources.CloudConfigSeedSecretKey]
 		return nil
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get cloud config ConfigMap: %v", err)
+		return "", fmt.Errorf("failed to get cloud config Secret: %v", err)
 	}
 
 	filename := path.Join(r.homeDir, fmt.Sprintf("%s-cloud-config", cluster.Name))
-	if err := ioutil.WriteFile(filename, []byte(cmData), 0644); err != nil {
+	if err := ioutil.WriteFile(filename, cloudConfig, 0644); err != nil {
 		return "", fmt.Errorf("failed to write cloud config: %v", err)
 	}
 
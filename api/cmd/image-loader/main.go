@@ -35,6 +35,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/docker"
 	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/konnectivity"
 	metricsserver "github.com/kubermatic/kubermatic/api/pkg/resources/metrics-server"
 	ksemver "github.com/kubermatic/kubermatic/api/pkg/semver"
 	kubermaticversion "github.com/kubermatic/kubermatic/api/pkg/version"
@@ -222,6 +223,10 @@ func getImagesFromCreators(templateData *resources.TemplateData) (images []strin
 		images = append(images, getImagesFromPodSpec(daemonSet.Spec.Template.Spec)...)
 	}
 
+	// The template data is for a cluster using OpenVPN, the konnectivity server is only part of the
+	// apiserver deployment of clusters using Konnectivity
+	images = append(images, konnectivity.ServerContainer(templateData).Image)
+
 	return images, nil
 }
 
@@ -239,12 +244,6 @@ func getImagesFromPodSpec(spec corev1.PodSpec) (images []string) {
 
 func getTemplateData(version *kubermaticversion.Version) (*resources.TemplateData, error) {
 	// We need listers and a set of objects to not have our deployment/statefulset creators fail
-	cloudConfigConfigMap := corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      resources.CloudConfigConfigMapName,
-			Namespace: mockNamespaceName,
-		},
-	}
 	prometheusConfigMap := corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      resources.PrometheusConfigConfigMapName,
@@ -270,7 +269,7 @@ func getTemplateData(version *kubermaticversion.Version) (*resources.TemplateDat
 		},
 	}
 	configMapList := &corev1.ConfigMapList{
-		Items: []corev1.ConfigMap{cloudConfigConfigMap, prometheusConfigMap, dnsResolverConfigMap, openvpnClientConfigsConfigMap, auditConfigMap},
+		Items: []corev1.ConfigMap{prometheusConfigMap, dnsResolverConfigMap, openvpnClientConfigsConfigMap, auditConfigMap},
 	}
 	apiServerExternalService := corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -347,6 +346,8 @@ func getTemplateData(version *kubermaticversion.Version) (*resources.TemplateDat
 		resources.KubernetesDashboardKubeconfigSecretName,
 		metricsserver.ServingCertSecretName,
 		resources.UserSSHKeys,
+		resources.CloudConfigSeedSecretName,
+		resources.CloudCredentialsSecretName,
 	})
 	objects := []runtime.Object{configMapList, secretList, serviceList}
 
@@ -387,6 +388,7 @@ func getTemplateData(version *kubermaticversion.Version) (*resources.TemplateDat
 		resources.DefaultKubermaticImage,
 		resources.DefaultDNATControllerImage,
 		false,
+		nil,
 	), nil
 }
 
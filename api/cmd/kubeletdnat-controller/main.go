@@ -49,6 +49,7 @@ func main() {
 	networkFlag := flag.String("node-access-network", "", "The network in CIDR notation to translate to.")
 	chainNameFlag := flag.String("chain-name", "node-access-dnat", "Name of the chain in nat table.")
 	vpnInterfaceFlag := flag.String("vpn-interface", "tun0", "Name of the vpn interface.")
+	backendFlag := flag.String("backend", kubeletdnat.BackendAuto, "Backend used to manage the NAT rules, one of auto, iptables or nftables. auto picks the one in use on the node.")
 	flag.Parse()
 
 	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
@@ -93,7 +94,7 @@ func main() {
 		log.Fatalw("failed to create mgr", zap.Error(err))
 	}
 
-	if err := kubeletdnat.Add(mgr, *chainNameFlag, nodeAccessNetwork, log, *vpnInterfaceFlag); err != nil {
+	if err := kubeletdnat.Add(mgr, *chainNameFlag, nodeAccessNetwork, log, *vpnInterfaceFlag, *backendFlag); err != nil {
 		log.Fatalw("failed to add the kubelet dnat controller", zap.Error(err))
 	}
 
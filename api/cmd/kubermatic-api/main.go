@@ -63,6 +63,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/pprof"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	kubernetesprovider "github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
+	"github.com/kubermatic/kubermatic/api/pkg/secretstore/vault"
 	"github.com/kubermatic/kubermatic/api/pkg/serviceaccount"
 	"github.com/kubermatic/kubermatic/api/pkg/version"
 	kuberneteswatcher "github.com/kubermatic/kubermatic/api/pkg/watcher/kubernetes"
@@ -83,6 +84,8 @@ func main() {
 	klog.InitFlags(nil)
 	pprofOpts := &pprof.Opts{}
 	pprofOpts.AddFlags(flag.CommandLine)
+	vaultOpts := &vault.Opts{}
+	vaultOpts.AddFlags(flag.CommandLine)
 	options, err := newServerRunOptions()
 	if err != nil {
 		fmt.Printf("failed to create server run options due to = %v\n", err)
@@ -103,6 +106,11 @@ func main() {
 
 	cmdutil.Hello(log, "API", options.log.Debug)
 
+	secretStores, err := vaultOpts.SecretStores()
+	if err != nil {
+		log.Fatalw("Failed to set up the Vault secret store", zap.Error(err))
+	}
+
 	if err := clusterv1alpha1.AddToScheme(scheme.Scheme); err != nil {
 		kubermaticlog.Logger.Fatalw("failed to register scheme", zap.Stringer("api", clusterv1alpha1.SchemeGroupVersion), zap.Error(err))
 	}
@@ -110,7 +118,7 @@ func main() {
 		kubermaticlog.Logger.Fatalw("failed to register scheme", zap.Stringer("api", v1beta1.SchemeGroupVersion), zap.Error(err))
 	}
 
-	providers, err := createInitProviders(options)
+	providers, err := createInitProviders(options, secretStores)
 	if err != nil {
 		log.Fatalw("failed to create and initialize providers", "error", err)
 	}
@@ -142,7 +150,7 @@ func main() {
 	log.Fatalw("failed to start API server", "error", http.ListenAndServe(options.listenAddress, handlers.CombinedLoggingHandler(os.Stdout, apiHandler)))
 }
 
-func createInitProviders(options serverRunOptions) (providers, error) {
+func createInitProviders(options serverRunOptions, secretStores *provider.SecretStores) (providers, error) {
 	masterCfg, err := clientcmd.BuildConfigFromFlags("", options.kubeconfig)
 	if err != nil {
 		return providers{}, fmt.Errorf("unable to build client configuration from kubeconfig due to %v", err)
@@ -189,13 +197,17 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 	}
 
 	seedClientGetter := provider.SeedClientGetterFactory(seedKubeconfigGetter)
-	clusterProviderGetter := clusterProviderFactory(mgr.GetRESTMapper(), seedKubeconfigGetter, seedClientGetter, options.workerName, options.featureGates.Enabled(features.OIDCKubeCfgEndpoint))
+	clusterProviderGetter := clusterProviderFactory(mgr.GetRESTMapper(), seedKubeconfigGetter, seedClientGetter, options.workerName, options.featureGates.Enabled(features.OIDCKubeCfgEndpoint), secretStores)
 
 	presetsProvider, err := kubernetesprovider.NewPresetsProvider(context.Background(), mgr.GetClient(), options.presetsFile, options.dynamicPresets)
 	if err != nil {
 		return providers{}, err
 	}
 	admissionPluginProvider := kubernetesprovider.NewAdmissionPluginsProvider(context.Background(), mgr.GetClient())
+	clusterTemplateProvider := kubernetesprovider.NewClusterTemplateProvider(context.Background(), mgr.GetClient())
+	seedProvider := kubernetesprovider.NewSeedProvider(context.Background(), mgr.GetClient(), options.namespace)
+	constraintTemplateProvider := kubernetesprovider.NewConstraintTemplateProvider(context.Background(), mgr.GetClient())
+	constraintProvider := kubernetesprovider.NewConstraintProvider(context.Background(), mgr.GetClient())
 	// Warm up the restMapper cache. Log but ignore errors encountered here, maybe there are stale seeds
 	go func() {
 		seeds, err := seedsGetter()
@@ -255,6 +267,7 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 	if err != nil {
 		return providers{}, fmt.Errorf("failed to create settings watcher due to %v", err)
 	}
+	resourceWatchers := kuberneteswatcher.NewResourceWatchers(seedKubeconfigGetter, clusterProviderGetter)
 
 	return providers{
 		sshKey:                                sshKeyProvider,
@@ -281,6 +294,11 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 		presetProvider:                        presetsProvider,
 		admissionPluginProvider:               admissionPluginProvider,
 		settingsWatcher:                       settingsWatcher,
+		clusterTemplateProvider:               clusterTemplateProvider,
+		seedProvider:                          seedProvider,
+		resourceWatchers:                      resourceWatchers,
+		constraintTemplateProvider:            constraintTemplateProvider,
+		constraintProvider:                    constraintProvider,
 	}, nil
 }
 
@@ -381,6 +399,11 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		prov.adminProvider,
 		prov.admissionPluginProvider,
 		prov.settingsWatcher,
+		prov.clusterTemplateProvider,
+		prov.seedProvider,
+		prov.resourceWatchers,
+		prov.constraintTemplateProvider,
+		prov.constraintProvider,
 	)
 
 	registerMetrics()
@@ -463,7 +486,7 @@ func setSecureHeaders(next http.Handler) http.Handler {
 	})
 }
 
-func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provider.SeedKubeconfigGetter, seedClientGetter provider.SeedClientGetter, workerName string, oidcKubeCfgEndpointEnabled bool) provider.ClusterProviderGetter {
+func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provider.SeedKubeconfigGetter, seedClientGetter provider.SeedClientGetter, workerName string, oidcKubeCfgEndpointEnabled bool, secretStores *provider.SecretStores) provider.ClusterProviderGetter {
 	return func(seed *kubermaticv1.Seed) (provider.ClusterProvider, error) {
 		cfg, err := seedKubeconfigGetter(seed)
 		if err != nil {
@@ -494,6 +517,7 @@ func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provide
 			seedCtrlruntimeClient,
 			kubeClient,
 			oidcKubeCfgEndpointEnabled,
+			secretStores,
 		), nil
 	}
 }
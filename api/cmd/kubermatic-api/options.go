@@ -198,4 +198,9 @@ type providers struct {
 	presetProvider                        provider.PresetProvider
 	admissionPluginProvider               provider.AdmissionPluginsProvider
 	settingsWatcher                       watcher.SettingsWatcher
+	clusterTemplateProvider               provider.ClusterTemplateProvider
+	seedProvider                          provider.SeedProvider
+	resourceWatchers                      watcher.ResourceWatchers
+	constraintTemplateProvider            provider.ConstraintTemplateProvider
+	constraintProvider                    provider.ConstraintProvider
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/signals"
+	configvalidation "github.com/kubermatic/kubermatic/api/pkg/validation/configuration"
 
 	certmanagerv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	"k8s.io/client-go/tools/clientcmd"
@@ -50,6 +51,8 @@ type controllerRunOptions struct {
 	internalAddr string
 	workerCount  int
 	workerName   string
+
+	configValidationHook configvalidation.WebhookOpts
 }
 
 func main() {
@@ -68,6 +71,7 @@ func main() {
 	flag.IntVar(&opt.workerCount, "worker-count", 4, "Number of workers which process reconcilings in parallel.")
 	flag.StringVar(&opt.internalAddr, "internal-address", "127.0.0.1:8085", "The address on which the /metrics endpoint will be served")
 	flag.StringVar(&opt.workerName, "worker-name", "", "The name of the worker that will only processes resources with label=worker-name.")
+	opt.configValidationHook.AddFlags(flag.CommandLine)
 	flag.Parse()
 
 	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format).Named(opt.workerName)
@@ -122,6 +126,18 @@ func main() {
 		log.Fatalw("Failed to construct seedKubeconfigGetter", zap.Error(err))
 	}
 
+	if opt.configValidationHook.CertFile != "" || opt.configValidationHook.KeyFile != "" {
+		server, err := opt.configValidationHook.Server(ctx, log, opt.namespace, mgr.GetClient())
+		if err != nil {
+			log.Fatalw("Failed to create KubermaticConfiguration validation webhook server", zap.Error(err))
+		}
+		if err := mgr.Add(server); err != nil {
+			log.Fatalw("Failed to add KubermaticConfiguration validation webhook server", zap.Error(err))
+		}
+	} else {
+		log.Info("the KubermaticConfiguration validation webhook server can not be started because configuration-admissionwebhook-cert-file and configuration-admissionwebhook-key-file are empty")
+	}
+
 	if err := masterctrl.Add(ctx, mgr, log, opt.namespace, opt.workerCount, opt.workerName); err != nil {
 		log.Fatalw("Failed to add operator-master controller", zap.Error(err))
 	}
@@ -17,22 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 
+	gatekeepersynchronizer "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/gatekeeper-synchronizer"
 	projectlabelsynchronizer "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/project-label-synchronizer"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/rbac"
+	seedhealth "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/seed-health"
+	seedmigration "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/seed-migration"
 	seedproxy "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/seed-proxy"
 	seedsync "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/seed-sync"
 	serviceaccount "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/service-account"
 	userprojectbinding "github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/user-project-binding"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/usersshkeyssynchronizer"
+	backupcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/backup"
 	seedcontrollerlifecycle "github.com/kubermatic/kubermatic/api/pkg/controller/shared/seed-controller-lifecycle"
 	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/prometheus/client_golang/prometheus"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -49,6 +57,8 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	)
 	projectLabelSynchronizerFactory := projectLabelSynchronizerFactoryCreator(ctrlCtx)
 	userSSHKeysSynchronizerFactory := userSSHKeysSynchronizerFactoryCreator(ctrlCtx)
+	gatekeeperSynchronizerFactory := gatekeeperSynchronizerFactoryCreator(ctrlCtx)
+	seedMigrationFactory := seedMigrationFactoryCreator(ctrlCtx)
 
 	if err := seedcontrollerlifecycle.Add(ctrlCtx.ctx,
 		kubermaticlog.Logger,
@@ -58,7 +68,9 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 		ctrlCtx.seedKubeconfigGetter,
 		rbacControllerFactory,
 		projectLabelSynchronizerFactory,
-		userSSHKeysSynchronizerFactory); err != nil {
+		userSSHKeysSynchronizerFactory,
+		gatekeeperSynchronizerFactory,
+		seedMigrationFactory); err != nil {
 		//TODO: Find a better name
 		return fmt.Errorf("failed to create seedcontrollerlifecycle: %v", err)
 	}
@@ -74,6 +86,9 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	if err := seedproxy.Add(ctrlCtx.ctx, ctrlCtx.mgr, 1, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.seedsGetter, ctrlCtx.seedKubeconfigGetter); err != nil {
 		return fmt.Errorf("failed to create seedproxy controller: %v", err)
 	}
+	if err := seedhealth.Add(ctrlCtx.ctx, ctrlCtx.mgr, 1, ctrlCtx.log, ctrlCtx.namespace, ctrlCtx.seedKubeconfigGetter); err != nil {
+		return fmt.Errorf("failed to create seedhealth controller: %v", err)
+	}
 	return nil
 }
 
@@ -122,3 +137,56 @@ func userSSHKeysSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontr
 		)
 	}
 }
+
+func gatekeeperSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, mgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return gatekeepersynchronizer.ControllerName, gatekeepersynchronizer.Add(
+			ctx,
+			mgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.workerName,
+			ctrlCtx.workerCount,
+		)
+	}
+}
+
+func seedMigrationFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, mgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return seedmigration.ControllerName, seedmigration.Add(
+			ctx,
+			mgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.workerCount,
+			ctrlCtx.workerName,
+			ctrlCtx.migrationRestoreContainer,
+			ctrlCtx.migrationEtcdImage,
+		)
+	}
+}
+
+func getRestoreContainerFromFile(path string) (*corev1.Container, error) {
+	fileContents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	container := &corev1.Container{}
+	manifestDecoder := yaml.NewYAMLToJSONDecoder(bytes.NewReader(fileContents))
+	if err := manifestDecoder.Decode(container); err != nil {
+		return nil, err
+	}
+
+	if container.Name == "" {
+		return nil, fmt.Errorf("container must have a name")
+	}
+	if container.Image == "" {
+		return nil, fmt.Errorf("container must have an image")
+	}
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.Name == backupcontroller.SharedVolumeName {
+			return container, nil
+		}
+	}
+	return nil, fmt.Errorf("container does not have a mount for the shared volume %s", backupcontroller.SharedVolumeName)
+}
@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	cmdutil "github.com/kubermatic/kubermatic/api/cmd/util"
+	backupcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/backup"
 	"github.com/kubermatic/kubermatic/api/pkg/leaderelection"
 	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/metrics"
@@ -37,6 +38,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/util/workerlabel"
 	seedvalidation "github.com/kubermatic/kubermatic/api/pkg/validation/seed"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
@@ -60,6 +62,8 @@ type controllerRunOptions struct {
 	seedvalidationHook seedvalidation.WebhookOpts
 
 	workerName string
+
+	migrationRestoreContainerFile string
 }
 
 type controllerContext struct {
@@ -74,6 +78,9 @@ type controllerContext struct {
 	seedKubeconfigGetter    provider.SeedKubeconfigGetter
 	labelSelectorFunc       func(*metav1.ListOptions)
 	namespace               string
+	migrationEtcdImage      string
+	// migrationRestoreContainer is nil if seed migrations are not configured
+	migrationRestoreContainer *corev1.Container
 }
 
 func main() {
@@ -92,6 +99,8 @@ func main() {
 	flag.IntVar(&ctrlCtx.workerCount, "worker-count", 4, "Number of workers which process the clusters in parallel.")
 	flag.StringVar(&runOpts.internalAddr, "internal-address", "127.0.0.1:8085", "The address on which the /metrics endpoint will be served.")
 	flag.StringVar(&ctrlCtx.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources.")
+	flag.StringVar(&runOpts.migrationRestoreContainerFile, "migration-restore-container", "", fmt.Sprintf("Filepath of a container yaml that downloads the etcd snapshot of the cluster named in $CLUSTER from the backup store when migrating a cluster between seeds. It must mount a volume named %s and write the snapshot to snapshot.db in it. Seed migrations fail if this is not set.", backupcontroller.SharedVolumeName))
+	flag.StringVar(&ctrlCtx.migrationEtcdImage, "migration-etcd-image", backupcontroller.DefaultBackupContainerImage, "Docker image used to restore etcd snapshots when migrating a cluster between seeds, must be an etcd v3 image.")
 	addFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -118,6 +127,13 @@ func main() {
 
 	ctrlCtx.workerNamePredicate = workerlabel.Predicates(runOpts.workerName)
 
+	if runOpts.migrationRestoreContainerFile != "" {
+		ctrlCtx.migrationRestoreContainer, err = getRestoreContainerFromFile(runOpts.migrationRestoreContainerFile)
+		if err != nil {
+			log.Fatalw("failed to load the migration restore container", zap.Error(err))
+		}
+	}
+
 	// register the global error metric. Ensures that runtime.HandleError() increases the error metric
 	metrics.RegisterRuntimErrorMetricCounter("kubermatic_master_controller_manager", prometheus.DefaultRegisterer)
 
@@ -26,14 +26,19 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/addon"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/addoninstaller"
 	backupcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/backup"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/certificates"
 	cloudcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/cloud"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/clustercomponentdefaulter"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/encryption"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/eventretention"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/hibernation"
 	kubernetescontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/kubernetes"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/monitoring"
 	openshiftcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/openshift"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/rancher"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/seedresourcesuptodatecondition"
 	updatecontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/update"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/usage"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/features"
 	"github.com/kubermatic/kubermatic/api/pkg/version"
@@ -58,6 +63,11 @@ var AllControllers = map[string]controllerCreator{
 	clustercomponentdefaulter.ControllerName:      createClusterComponentDefaulter,
 	seedresourcesuptodatecondition.ControllerName: createSeedConditionUpToDateController,
 	rancher.ControllerName:                        createRancherController,
+	hibernation.ControllerName:                    createHibernationController,
+	certificates.ControllerName:                   createCertificatesController,
+	encryption.ControllerName:                     createEncryptionController,
+	usage.ControllerName:                          createUsageController,
+	eventretention.ControllerName:                 createEventRetentionController,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -109,6 +119,7 @@ func createCloudController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerCount,
 		ctrlCtx.seedGetter,
 		ctrlCtx.runOptions.workerName,
+		ctrlCtx.secretStores,
 	); err != nil {
 		return fmt.Errorf("failed to add cloud controller to mgr: %v", err)
 	}
@@ -134,7 +145,8 @@ func createOpenshiftController(ctrlCtx *controllerContext) error {
 			EtcdDataCorruptionChecks: ctrlCtx.runOptions.featureGates.Enabled(features.EtcdDataCorruptionChecks),
 			VPA:                      ctrlCtx.runOptions.featureGates.Enabled(features.VerticalPodAutoscaler),
 		},
-		ctrlCtx.runOptions.concurrentClusterUpdate); err != nil {
+		ctrlCtx.runOptions.concurrentClusterUpdate,
+		ctrlCtx.secretStores); err != nil {
 		return fmt.Errorf("failed to add openshift controller to mgr: %v", err)
 	}
 	return nil
@@ -172,6 +184,7 @@ func createKubernetesController(ctrlCtx *controllerContext) error {
 			EtcdDataCorruptionChecks:     ctrlCtx.runOptions.featureGates.Enabled(features.EtcdDataCorruptionChecks),
 			KubernetesOIDCAuthentication: ctrlCtx.runOptions.featureGates.Enabled(features.OpenIDAuthPlugin),
 		},
+		ctrlCtx.secretStores,
 	)
 }
 
@@ -227,6 +240,7 @@ func createMonitoringController(ctrlCtx *controllerContext) error {
 		monitoring.Features{
 			VPA: ctrlCtx.runOptions.featureGates.Enabled(features.VerticalPodAutoscaler),
 		},
+		ctrlCtx.secretStores,
 	)
 }
 
@@ -264,6 +278,55 @@ func createUpdateController(ctrlCtx *controllerContext) error {
 		ctrlCtx.clientProvider, ctrlCtx.log)
 }
 
+func createHibernationController(ctrlCtx *controllerContext) error {
+	return hibernation.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+	)
+}
+
+func createCertificatesController(ctrlCtx *controllerContext) error {
+	return certificates.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+	)
+}
+
+func createEncryptionController(ctrlCtx *controllerContext) error {
+	return encryption.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+	)
+}
+
+func createUsageController(ctrlCtx *controllerContext) error {
+	return usage.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+		ctrlCtx.runOptions.usageSampleInterval,
+	)
+}
+
+func createEventRetentionController(ctrlCtx *controllerContext) error {
+	return eventretention.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+	)
+}
+
 func createAddonController(ctrlCtx *controllerContext) error {
 	return addon.Add(
 		ctrlCtx.mgr,
@@ -281,6 +344,7 @@ func createAddonController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.overwriteRegistry,
 		ctrlCtx.runOptions.nodeLocalDNSCacheEnabled(),
 		ctrlCtx.clientProvider,
+		ctrlCtx.secretStores,
 	)
 }
 
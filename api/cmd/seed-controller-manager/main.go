@@ -38,6 +38,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/metrics"
 	metricserver "github.com/kubermatic/kubermatic/api/pkg/metrics/server"
 	"github.com/kubermatic/kubermatic/api/pkg/pprof"
+	"github.com/kubermatic/kubermatic/api/pkg/secretstore/vault"
 	"github.com/kubermatic/kubermatic/api/pkg/signals"
 	"github.com/kubermatic/kubermatic/api/pkg/util/restmapper"
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
@@ -47,6 +48,7 @@ import (
 	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -60,6 +62,8 @@ func main() {
 	klog.InitFlags(nil)
 	pprofOpts := &pprof.Opts{}
 	pprofOpts.AddFlags(flag.CommandLine)
+	vaultOpts := &vault.Opts{}
+	vaultOpts.AddFlags(flag.CommandLine)
 	logOpts := kubermaticlog.NewDefaultOptions()
 	logOpts.AddFlags(flag.CommandLine)
 	options, err := newControllerRunOptions()
@@ -84,6 +88,11 @@ func main() {
 
 	cmdutil.Hello(log, "Seed Controller-Manager", logOpts.Debug)
 
+	secretStores, err := vaultOpts.SecretStores()
+	if err != nil {
+		log.Fatalw("Failed to set up the Vault secret store", zap.Error(err))
+	}
+
 	config, err := clientcmd.BuildConfigFromFlags(options.masterURL, options.kubeconfig)
 	if err != nil {
 		log.Fatalw("Failed to create a kubernetes config", zap.Error(err))
@@ -108,6 +117,9 @@ func main() {
 	if err := clusterv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
 		kubermaticlog.Logger.Fatalw("failed to register scheme", zap.Stringer("api", clusterv1alpha1.SchemeGroupVersion), zap.Error(err))
 	}
+	if err := metricsv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Fatalw("Failed to register scheme", zap.Stringer("api", metricsv1beta1.SchemeGroupVersion), zap.Error(err))
+	}
 
 	// Check if the CRD for the VerticalPodAutoscaler is registered by allocating an informer
 	if err := mgr.GetAPIReader().List(context.Background(), &autoscalingv1beta2.VerticalPodAutoscalerList{}); err != nil {
@@ -196,6 +208,7 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 		clientProvider:       clientProvider,
 		seedGetter:           seedGetter,
 		dockerPullConfigJSON: dockerPullConfigJSON,
+		secretStores:         secretStores,
 		log:                  log,
 	}
 
@@ -256,7 +269,7 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 
 			return leaderelection.RunAsLeader(leaderCtx, log, config, mgr.GetEventRecorderFor(controllerName), electionName, func(ctx context.Context) error {
 				log.Info("Executing migrations...")
-				if err := seedmigrations.RunAll(leaderCtx, ctrlCtx.mgr.GetConfig(), options.workerName); err != nil {
+				if err := seedmigrations.RunAll(leaderCtx, ctrlCtx.mgr.GetConfig(), options.workerName, ctrlCtx.secretStores); err != nil {
 					return fmt.Errorf("failed to run migrations: %v", err)
 				}
 				log.Info("Migrations executed successfully")
@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -84,6 +85,7 @@ type controllerRunOptions struct {
 	seedValidationHook                               seedvalidation.WebhookOpts
 	concurrentClusterUpdate                          int
 	addonEnforceInterval                             int
+	usageSampleInterval                              time.Duration
 
 	// OIDC configuration
 	oidcCAFile             string
@@ -146,6 +148,7 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.schedulerDefaultReplicas, "scheduler-default-replicas", 1, "The default number of replicas for usercluster schedulers")
 	flag.IntVar(&c.concurrentClusterUpdate, "max-parallel-reconcile", 10, "The default number of resources updates per cluster")
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
+	flag.DurationVar(&c.usageSampleInterval, "usage-sample-interval", 15*time.Minute, "The interval in which the resource usage of the user clusters is sampled for the usage reports")
 	c.seedValidationHook.AddFlags(flag.CommandLine)
 	addFlags(flag.CommandLine)
 	flag.Parse()
@@ -285,6 +288,7 @@ type controllerContext struct {
 	clientProvider       *client.Provider
 	seedGetter           provider.SeedGetter
 	dockerPullConfigJSON []byte
+	secretStores         *provider.SecretStores
 	log                  *zap.SugaredLogger
 }
 
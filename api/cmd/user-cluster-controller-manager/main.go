@@ -34,6 +34,8 @@ import (
 	cmdutil "github.com/kubermatic/kubermatic/api/cmd/util"
 	clusterrolelabeler "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/cluster-role-labeler"
 	containerlinux "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/container-linux"
+	eventexporter "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/event-exporter"
+	gatekeepersyncer "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/gatekeeper-syncer"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/ipam"
 	nodelabeler "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/node-labeler"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/nodecsrapprover"
@@ -57,6 +59,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 	apiregistrationv1beta1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1beta1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -72,6 +75,8 @@ type controllerRunOptions struct {
 	namespace                     string
 	clusterURL                    string
 	openvpnServerPort             int
+	tunnelMode                    string
+	konnectivityServerPort        int
 	overwriteRegistry             string
 	cloudProviderName             string
 	cloudCredentialSecretTemplate string
@@ -82,6 +87,10 @@ type controllerRunOptions struct {
 	updateWindowStart             string
 	updateWindowLength            string
 	dnsClusterIP                  string
+	clusterName                   string
+	eventExportSink               string
+	eventExportURL                string
+	eventExportRetention          time.Duration
 }
 
 func main() {
@@ -101,6 +110,8 @@ func main() {
 	flag.StringVar(&runOp.clusterURL, "cluster-url", "", "Cluster URL")
 	flag.StringVar(&runOp.dnsClusterIP, "dns-cluster-ip", "", "KubeDNS service IP for the cluster")
 	flag.IntVar(&runOp.openvpnServerPort, "openvpn-server-port", 0, "OpenVPN server port")
+	flag.StringVar(&runOp.tunnelMode, "tunnel-mode", string(kubermaticv1.TunnelModeOpenVPN), "How the control plane reaches the nodes, either OpenVPN or Konnectivity")
+	flag.IntVar(&runOp.konnectivityServerPort, "konnectivity-server-port", 0, "Konnectivity server port, required if the tunnel mode is Konnectivity")
 	flag.StringVar(&runOp.overwriteRegistry, "overwrite-registry", "", "registry to use for all images")
 	flag.StringVar(&runOp.cloudProviderName, "cloud-provider-name", "", "Name of the cloudprovider")
 	flag.StringVar(&runOp.cloudCredentialSecretTemplate, "cloud-credential-secret-template", "", "A serialized Kubernetes secret whose Name and Data fields will be used to create a secret for the openshift cloud credentials operator.")
@@ -110,6 +121,10 @@ func main() {
 	flag.StringVar(&runOp.ownerEmail, "owner-email", "", "An email address of the user who created the cluster. Used as default subject for the admin cluster role binding")
 	flag.StringVar(&runOp.updateWindowStart, "update-window-start", "", "The start time of the update window, e.g. 02:00")
 	flag.StringVar(&runOp.updateWindowLength, "update-window-length", "", "The length of the update window, e.g. 1h")
+	flag.StringVar(&runOp.eventExportSink, "event-export-sink", "", "The sink the events of the user cluster are exported to, either seed or http. Events are not exported if unset")
+	flag.StringVar(&runOp.eventExportURL, "event-export-url", "", "The URL the events are posted to if the event export sink is http")
+	flag.DurationVar(&runOp.eventExportRetention, "event-export-retention", eventexporter.DefaultRetention, "How long the events exported to the seed are kept after they occurred the last time")
+	flag.StringVar(&runOp.clusterName, "cluster-name", "", "The name of the cluster, required if the event export sink is seed")
 	flag.Parse()
 
 	rawLog := kubermaticlog.New(logOpts.Debug, logOpts.Format)
@@ -133,8 +148,17 @@ func main() {
 	if err != nil {
 		log.Fatalw("Failed parsing clusterURL", zap.Error(err))
 	}
-	if runOp.openvpnServerPort == 0 {
-		log.Fatal("-openvpn-server-port must be set")
+	switch kubermaticv1.TunnelMode(runOp.tunnelMode) {
+	case kubermaticv1.TunnelModeOpenVPN:
+		if runOp.openvpnServerPort == 0 {
+			log.Fatal("-openvpn-server-port must be set")
+		}
+	case kubermaticv1.TunnelModeKonnectivity:
+		if runOp.konnectivityServerPort == 0 {
+			log.Fatal("-konnectivity-server-port must be set")
+		}
+	default:
+		log.Fatalf("invalid -tunnel-mode %q, must be one of %s or %s", runOp.tunnelMode, kubermaticv1.TunnelModeOpenVPN, kubermaticv1.TunnelModeKonnectivity)
 	}
 
 	var cloudCredentialSecretTemplate *corev1.Secret
@@ -222,6 +246,8 @@ func main() {
 		runOp.cloudProviderName,
 		clusterURL,
 		runOp.openvpnServerPort,
+		kubermaticv1.TunnelMode(runOp.tunnelMode),
+		runOp.konnectivityServerPort,
 		healthHandler.AddReadinessCheck,
 		cloudCredentialSecretTemplate,
 		runOp.openshiftConsoleCallbackURI,
@@ -304,6 +330,39 @@ func main() {
 	}
 	log.Info("Registered ownerbindingcreator controller")
 
+	if err := gatekeepersyncer.Add(log, mgr, seedMgr, runOp.namespace); err != nil {
+		log.Fatalw("Failed to register gatekeepersyncer controller", zap.Error(err))
+	}
+	log.Info("Registered gatekeepersyncer controller")
+
+	if runOp.eventExportSink != "" {
+		var sink eventexporter.Sink
+		switch kubermaticv1.EventExportSinkType(runOp.eventExportSink) {
+		case kubermaticv1.EventExportSinkTypeSeed:
+			if runOp.clusterName == "" {
+				log.Fatal("-cluster-name must be set for the seed event export sink")
+			}
+			// ClusterEvents are cluster-scoped, so they can't be accessed through the cache of the seed manager,
+			// which is restricted to the cluster namespace
+			seedClient, err := ctrlruntimeclient.New(seedConfig, ctrlruntimeclient.Options{})
+			if err != nil {
+				log.Fatalw("Failed to create seed client", zap.Error(err))
+			}
+			sink = eventexporter.NewSeedSink(seedClient, runOp.clusterName, runOp.eventExportRetention)
+		case kubermaticv1.EventExportSinkTypeHTTP:
+			if runOp.eventExportURL == "" {
+				log.Fatal("-event-export-url must be set for the http event export sink")
+			}
+			sink = eventexporter.NewHTTPSink(runOp.eventExportURL)
+		default:
+			log.Fatalf("unknown event export sink %q", runOp.eventExportSink)
+		}
+		if err := eventexporter.Add(ctx, log, mgr, sink); err != nil {
+			log.Fatalw("Failed to register eventexporter controller", zap.Error(err))
+		}
+		log.Info("Registered eventexporter controller")
+	}
+
 	// This group is forever waiting in a goroutine for signals to stop
 	{
 		g.Add(func() error {
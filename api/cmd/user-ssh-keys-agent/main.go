@@ -38,6 +38,9 @@ import (
 )
 
 func main() {
+	var nodeName string
+	flag.StringVar(&nodeName, "node-name", os.Getenv("NODE_NAME"), "The name of the node the agent runs on. Its labels are matched against the node selectors of the keys.")
+
 	logOpts := kubermaticlog.NewDefaultOptions()
 	logOpts.AddFlags(flag.CommandLine)
 	flag.Parse()
@@ -68,7 +71,7 @@ func main() {
 	if err != nil {
 		log.Fatalw("Failed to get users directories", zap.Error(err))
 	}
-	if err := usersshkeys.Add(mgr, log, paths); err != nil {
+	if err := usersshkeys.Add(mgr, log, paths, nodeName); err != nil {
 		log.Fatalw("Failed registering user ssh key controller", zap.Error(err))
 	}
 
@@ -101,9 +101,16 @@ type DatacenterSpec struct {
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
 
+	// MinimumAuditPolicyPreset is the least verbose audit policy preset clusters within the DC may use,
+	// custom audit policies are not allowed if it is set. It only takes effect if EnforceAuditLogging is set.
+	MinimumAuditPolicyPreset kubermaticv1.AuditPolicyPreset `json:"minimumAuditPolicyPreset,omitempty"`
+
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// Placement controls how new clusters in this datacenter are distributed across seeds.
+	Placement *kubermaticv1.DatacenterPlacement `json:"placement,omitempty"`
 }
 
 // DatacenterList represents a list of datacenters
@@ -302,6 +309,14 @@ type CredentialList struct {
 	Names []string `json:"names,omitempty"`
 }
 
+// Preset represents a set of cloud provider credentials which can be used to create clusters.
+// The secret fields of the credentials are never returned, on update empty secret fields keep their current value.
+// swagger:model Preset
+type Preset struct {
+	Name string                  `json:"name"`
+	Spec kubermaticv1.PresetSpec `json:"spec"`
+}
+
 // DigitaloceanSize is the object representing digitalocean sizes.
 // swagger:model DigitaloceanSize
 type DigitaloceanSize struct {
@@ -394,6 +409,13 @@ type SSHKey struct {
 type SSHKeySpec struct {
 	Fingerprint string `json:"fingerprint"`
 	PublicKey   string `json:"publicKey"`
+	// User is the operating system user on the nodes the key is authorized for,
+	// one of root, core, ubuntu or centos. If empty, the key is authorized for all users.
+	User string `json:"user,omitempty"`
+	// ExpiresAt is the point in time after which the key is removed from the nodes
+	ExpiresAt *Time `json:"expiresAt,omitempty"`
+	// NodeSelector restricts the key to the nodes whose labels match all the given labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // User represent an API user
@@ -605,6 +627,37 @@ type CreateClusterSpec struct {
 	NodeDeployment *NodeDeployment `json:"nodeDeployment,omitempty"`
 }
 
+// CloudCredentialsPreflightSpec defines the cloud spec whose credentials should be checked
+// swagger:model CloudCredentialsPreflightSpec
+type CloudCredentialsPreflightSpec struct {
+	Cloud kubermaticv1.CloudSpec `json:"cloud"`
+	// Credential is the name of a preset whose credentials should be checked instead of the ones in the cloud spec
+	Credential string `json:"credential,omitempty"`
+}
+
+// CloudCredentialsReport is the result of checking the credentials and permissions of a cloud spec
+// swagger:model CloudCredentialsReport
+type CloudCredentialsReport struct {
+	// Supported is false if the cloud provider doesn't support pre-flight checks
+	Supported bool `json:"supported"`
+	// Valid is false if the credentials got rejected or a required permission is denied
+	Valid bool `json:"valid"`
+	// Identity is the identity the credentials belong to
+	Identity string `json:"identity,omitempty"`
+	// Message explains why the credentials got rejected
+	Message     string                 `json:"message,omitempty"`
+	Permissions []CloudPermissionCheck `json:"permissions,omitempty"`
+}
+
+// CloudPermissionCheck is the result of checking a single permission
+// swagger:model CloudPermissionCheck
+type CloudPermissionCheck struct {
+	Permission string `json:"permission"`
+	// Result is one of Allowed, Denied or Unknown
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
 const (
 	// OpenShiftClusterType defines the OpenShift cluster type
 	OpenShiftClusterType string = "openshift"
@@ -660,21 +713,49 @@ type ClusterSpec struct {
 
 	// Openshift holds all openshift-specific settings
 	Openshift *kubermaticv1.Openshift `json:"openshift,omitempty"`
+
+	// Hibernation allows scaling the cluster to zero on demand or on a schedule
+	Hibernation *kubermaticv1.ClusterHibernationSpec `json:"hibernation,omitempty"`
+
+	// ClusterAutoscaler holds the scale-down settings of the cluster-autoscaler
+	ClusterAutoscaler *kubermaticv1.ClusterAutoscalerSettings `json:"clusterAutoscaler,omitempty"`
+
+	// DeletionProtection makes requests to delete the cluster or its project fail until it is disabled again
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// FinalBackup configures an etcd snapshot that is taken before the cluster gets deleted
+	FinalBackup *kubermaticv1.FinalBackupSettings `json:"finalBackup,omitempty"`
+
+	// Encryption configures the encryption of the Secrets stored in the etcd of the user cluster
+	Encryption *kubermaticv1.ClusterEncryptionSpec `json:"encryption,omitempty"`
+
+	// EventExport configures the export of the Kubernetes events of the user cluster
+	EventExport *kubermaticv1.EventExportSettings `json:"eventExport,omitempty"`
+
+	// TunnelMode defines how the control plane reaches the nodes, either OpenVPN (default) or Konnectivity
+	TunnelMode kubermaticv1.TunnelMode `json:"tunnelMode,omitempty"`
 }
 
 // MarshalJSON marshals ClusterSpec object into JSON. It is overwritten to control data
 // that will be returned in the API responses (see: PublicCloudSpec struct).
 func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 	ret, err := json.Marshal(struct {
-		Cloud                               PublicCloudSpec                        `json:"cloud"`
-		MachineNetworks                     []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
-		Version                             ksemver.Semver                         `json:"version"`
-		OIDC                                kubermaticv1.OIDCSettings              `json:"oidc"`
-		UpdateWindow                        *kubermaticv1.UpdateWindow             `json:"updateWindow,omitempty"`
-		UsePodSecurityPolicyAdmissionPlugin bool                                   `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
-		UsePodNodeSelectorAdmissionPlugin   bool                                   `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
-		AuditLogging                        *kubermaticv1.AuditLoggingSettings     `json:"auditLogging,omitempty"`
-		AdmissionPlugins                    []string                               `json:"admissionPlugins,omitempty"`
+		Cloud                               PublicCloudSpec                         `json:"cloud"`
+		MachineNetworks                     []kubermaticv1.MachineNetworkingConfig  `json:"machineNetworks,omitempty"`
+		Version                             ksemver.Semver                          `json:"version"`
+		OIDC                                kubermaticv1.OIDCSettings               `json:"oidc"`
+		UpdateWindow                        *kubermaticv1.UpdateWindow              `json:"updateWindow,omitempty"`
+		UsePodSecurityPolicyAdmissionPlugin bool                                    `json:"usePodSecurityPolicyAdmissionPlugin,omitempty"`
+		UsePodNodeSelectorAdmissionPlugin   bool                                    `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
+		AuditLogging                        *kubermaticv1.AuditLoggingSettings      `json:"auditLogging,omitempty"`
+		AdmissionPlugins                    []string                                `json:"admissionPlugins,omitempty"`
+		Hibernation                         *kubermaticv1.ClusterHibernationSpec    `json:"hibernation,omitempty"`
+		ClusterAutoscaler                   *kubermaticv1.ClusterAutoscalerSettings `json:"clusterAutoscaler,omitempty"`
+		DeletionProtection                  bool                                    `json:"deletionProtection,omitempty"`
+		FinalBackup                         *kubermaticv1.FinalBackupSettings       `json:"finalBackup,omitempty"`
+		Encryption                          *kubermaticv1.ClusterEncryptionSpec     `json:"encryption,omitempty"`
+		EventExport                         *kubermaticv1.EventExportSettings       `json:"eventExport,omitempty"`
+		TunnelMode                          kubermaticv1.TunnelMode                 `json:"tunnelMode,omitempty"`
 	}{
 		Cloud: PublicCloudSpec{
 			DatacenterName: cs.Cloud.DatacenterName,
@@ -699,6 +780,13 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		UsePodNodeSelectorAdmissionPlugin:   cs.UsePodNodeSelectorAdmissionPlugin,
 		AuditLogging:                        cs.AuditLogging,
 		AdmissionPlugins:                    cs.AdmissionPlugins,
+		Hibernation:                         cs.Hibernation,
+		ClusterAutoscaler:                   cs.ClusterAutoscaler,
+		DeletionProtection:                  cs.DeletionProtection,
+		FinalBackup:                         cs.FinalBackup,
+		Encryption:                          cs.Encryption,
+		EventExport:                         cs.EventExport,
+		TunnelMode:                          cs.TunnelMode,
 	})
 
 	return ret, err
@@ -879,6 +967,40 @@ type ClusterStatus struct {
 
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url"`
+
+	// Migration reports the progress of moving the control plane to another seed
+	Migration *kubermaticv1.ClusterMigrationStatus `json:"migration,omitempty"`
+
+	// Hibernation reports the progress of hibernating or resuming the cluster
+	Hibernation *kubermaticv1.ClusterHibernationStatus `json:"hibernation,omitempty"`
+
+	// Deletion reports the progress of deleting the cluster, it is only set once the cluster is being deleted
+	Deletion *kubermaticv1.ClusterDeletionStatus `json:"deletion,omitempty"`
+
+	// Encryption reports the encryption keys of the apiserver and the progress of changing them
+	Encryption *kubermaticv1.ClusterEncryptionStatus `json:"encryption,omitempty"`
+
+	// Seed is the name of the seed the cluster was placed on, it is only set when creating
+	// clusters in datacenters with placement settings
+	Seed string `json:"seed,omitempty"`
+}
+
+// ClusterCertificates lists the certificates of the cluster control plane along with the progress
+// of a certificate rotation
+// swagger:model ClusterCertificates
+type ClusterCertificates struct {
+	Certificates []kubermaticv1.ClusterCertificate `json:"certificates"`
+
+	// Rotation is only set once a certificate rotation was requested
+	Rotation *kubermaticv1.ClusterCertificateRotationStatus `json:"rotation,omitempty"`
+}
+
+// ClusterCertificateRotation requests the rotation of the cluster CAs and the service account key
+// swagger:model ClusterCertificateRotation
+type ClusterCertificateRotation struct {
+	// TransitionWindow is the duration the old CAs and service account key stay trusted after
+	// the control plane switched to the new ones, e.g. "24h". Defaults to 24h.
+	TransitionWindow string `json:"transitionWindow,omitempty"`
 }
 
 // ClusterHealth stores health information about the cluster's components.
@@ -926,6 +1048,86 @@ type AddonConfig struct {
 // swagger:model ClusterList
 type ClusterList []Cluster
 
+// ClusterTemplate represents a template for creating clusters
+// swagger:model ClusterTemplate
+type ClusterTemplate struct {
+	ObjectMeta `json:",inline"`
+	// Scope is either "global" or "project", global templates are available in all projects
+	Scope     string `json:"scope"`
+	ProjectID string `json:"projectID,omitempty"`
+
+	// Cluster is used as the base of the clusters created from the template,
+	// the cloud credentials are taken from the preset given in cluster.credential
+	Cluster         Cluster          `json:"cluster"`
+	NodeDeployments []NodeDeployment `json:"nodeDeployments,omitempty"`
+	Addons          []Addon          `json:"addons,omitempty"`
+}
+
+// ClusterTemplateList represents a list of cluster templates
+// swagger:model ClusterTemplateList
+type ClusterTemplateList []ClusterTemplate
+
+// ClusterTemplateInstances defines how many clusters are created from a template
+// swagger:model ClusterTemplateInstances
+type ClusterTemplateInstances struct {
+	Replicas int `json:"replicas"`
+}
+
+// ConstraintTemplate represents an OPA Gatekeeper constraint template
+// swagger:model ConstraintTemplate
+type ConstraintTemplate struct {
+	ObjectMeta `json:",inline"`
+	// Kind is the kind of the constraints created from the template, e.g. K8sRequiredLabels
+	Kind string `json:"kind"`
+	// Parameters is the OpenAPI v3 schema of the parameters accepted by the constraints
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Targets holds the rego policies of the template
+	Targets []kubermaticv1.ConstraintTemplateTarget `json:"targets"`
+}
+
+// ConstraintTemplateList represents a list of constraint templates
+// swagger:model ConstraintTemplateList
+type ConstraintTemplateList []ConstraintTemplate
+
+// Constraint represents an OPA Gatekeeper constraint of a project
+// swagger:model Constraint
+type Constraint struct {
+	ObjectMeta `json:",inline"`
+	ProjectID  string `json:"projectID,omitempty"`
+	// ConstraintTemplate is the name of the template the constraint is created from
+	ConstraintTemplate string `json:"constraintTemplate"`
+	// Clusters restricts the constraint to the given clusters, if empty it applies to all clusters of the project
+	Clusters []string `json:"clusters,omitempty"`
+	// EnforcementAction is either "deny" or "dryrun", defaults to "deny"
+	EnforcementAction string                       `json:"enforcementAction,omitempty"`
+	Match             kubermaticv1.ConstraintMatch `json:"match,omitempty"`
+	// Parameters are passed to the rego policies of the template
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ConstraintList represents a list of constraints
+// swagger:model ConstraintList
+type ConstraintList []Constraint
+
+// ConstraintViolation represents a violation of a constraint reported by the gatekeeper audit controller
+// swagger:model ConstraintViolation
+type ConstraintViolation struct {
+	// Constraint is the name of the violated constraint in the cluster
+	Constraint string `json:"constraint"`
+	// ConstraintKind is the kind of the violated constraint
+	ConstraintKind    string `json:"constraintKind"`
+	EnforcementAction string `json:"enforcementAction,omitempty"`
+	// Kind, Namespace and Name identify the object violating the constraint
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
+// ConstraintViolationList represents a list of constraint violations
+// swagger:model ConstraintViolationList
+type ConstraintViolationList []ConstraintViolation
+
 // Node represents a worker node that is part of a cluster
 // swagger:model Node
 type Node struct {
@@ -1312,14 +1514,37 @@ type NodeDeployment struct {
 type NodeDeploymentSpec struct {
 	// required: true
 	Replicas int32 `json:"replicas,omitempty"`
+	// MinReplicas is the lower bound the cluster-autoscaler may scale the node deployment to.
+	// It must be set together with MaxReplicas.
+	// required: false
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper bound the cluster-autoscaler may scale the node deployment to.
+	// It must be set together with MinReplicas.
+	// required: false
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 	// required: true
 	Template NodeSpec `json:"template"`
+	// Strategy controls how nodes are replaced when the template changes or the node deployment is restarted.
+	// required: false
+	Strategy *NodeDeploymentRolloutStrategy `json:"strategy,omitempty"`
 	// required: false
 	Paused *bool `json:"paused,omitempty"`
 	// required: false
 	DynamicConfig *bool `json:"dynamicConfig,omitempty"`
 }
 
+// NodeDeploymentRolloutStrategy configures the rolling update of a node deployment.
+// Both values can either be an absolute number (e.g. "2") or a percentage of the replicas (e.g. "25%").
+// swagger:model NodeDeploymentRolloutStrategy
+type NodeDeploymentRolloutStrategy struct {
+	// MaxSurge is the maximum number of nodes that can be created above the desired number of replicas.
+	// Defaults to 1.
+	MaxSurge *string `json:"maxSurge,omitempty"`
+	// MaxUnavailable is the maximum number of nodes that can be unavailable during the update.
+	// Defaults to 0.
+	MaxUnavailable *string `json:"maxUnavailable,omitempty"`
+}
+
 // Event is a report of an event somewhere in the cluster.
 // swagger:model Event
 type Event struct {
@@ -1340,8 +1565,18 @@ type Event struct {
 
 	// The number of times this event has occurred.
 	Count int32 `json:"count,omitempty"`
+
+	// Source is usercluster for events that got exported from the user cluster.
+	Source string `json:"source,omitempty"`
 }
 
+const (
+	// EventSourceKubermatic is the source of the events about the Kubermatic objects of a cluster
+	EventSourceKubermatic = "kubermatic"
+	// EventSourceUserCluster is the source of the events that got exported from the user cluster
+	EventSourceUserCluster = "usercluster"
+)
+
 // ObjectReferenceResource contains basic information about referred object.
 type ObjectReferenceResource struct {
 	// Type of the referent.
@@ -1469,6 +1704,51 @@ type GlobalSettings kubermaticv1.SettingSpec
 // swagger:model GlobalCustomLinks
 type GlobalCustomLinks []kubermaticv1.CustomLink
 
+// ProjectUsageReport is the resource usage and the estimated cost of the clusters of a project within a month
+// swagger:model ProjectUsageReport
+type ProjectUsageReport struct {
+	ProjectID string `json:"projectID"`
+	// Month is the month of the report, e.g. 2020-03
+	Month    string               `json:"month"`
+	Clusters []ClusterUsageReport `json:"clusters"`
+	// EstimatedCosts is the estimated cost of all clusters, per currency
+	EstimatedCosts map[string]float64 `json:"estimatedCosts"`
+}
+
+// ClusterUsageReport is the resource usage and the estimated cost of a cluster within a month
+// swagger:model ClusterUsageReport
+type ClusterUsageReport struct {
+	ClusterID   string `json:"clusterID"`
+	ClusterName string `json:"clusterName"`
+	Provider    string `json:"provider"`
+	// Days is the number of days the usage of the cluster was sampled on
+	Days         int     `json:"days"`
+	MaxNodes     int32   `json:"maxNodes"`
+	AverageNodes float64 `json:"averageNodes"`
+	// AverageCPUCapacity is the average CPU capacity of all nodes, in millicores
+	AverageCPUCapacity int64 `json:"averageCPUCapacity"`
+	// AverageMemoryCapacity is the average memory capacity of all nodes, in bytes
+	AverageMemoryCapacity int64 `json:"averageMemoryCapacity"`
+	// AverageCPUUsage is the average CPU usage of all nodes, in millicores
+	AverageCPUUsage int64 `json:"averageCPUUsage"`
+	// AverageMemoryUsage is the average memory usage of all nodes, in bytes
+	AverageMemoryUsage int64           `json:"averageMemoryUsage"`
+	NodeSizes          []NodeSizeUsage `json:"nodeSizes"`
+	EstimatedCost      float64         `json:"estimatedCost"`
+	// Currency is the currency of the price list of the provider, it is empty if there is none
+	Currency string `json:"currency,omitempty"`
+}
+
+// NodeSizeUsage is the time the nodes of a size ran within a month and their estimated cost
+// swagger:model NodeSizeUsage
+type NodeSizeUsage struct {
+	NodeSize  string  `json:"nodeSize"`
+	NodeHours float64 `json:"nodeHours"`
+	// PricePerHour is unset if the price list of the provider does not contain the node size
+	PricePerHour  *float64 `json:"pricePerHour,omitempty"`
+	EstimatedCost float64  `json:"estimatedCost"`
+}
+
 // AdmissionPluginList represents a list of admission plugins
 // swagger:model AdmissionPluginList
 type AdmissionPluginList []string
@@ -1489,6 +1769,58 @@ type Seed struct {
 	Name string `json:"name"`
 
 	SeedSpec `json:"spec"`
+
+	// Utilization shows how loaded the seed currently is
+	Utilization *SeedUtilization `json:"utilization,omitempty"`
+
+	// Status contains the results of the seed health checks, it is empty if the seed was not checked yet
+	Status *kubermaticv1.SeedStatus `json:"status,omitempty"`
+
+	// Bootstrap shows how far the bootstrapping of a newly created seed has progressed
+	Bootstrap *SeedBootstrapStatus `json:"bootstrap,omitempty"`
+}
+
+// SeedBootstrapStatus describes how far the bootstrapping of a seed has progressed
+// swagger:model SeedBootstrapStatus
+type SeedBootstrapStatus struct {
+	// Done is true once all steps are done
+	Done  bool                `json:"done"`
+	Steps []SeedBootstrapStep `json:"steps"`
+}
+
+// SeedBootstrapStep is a single step of bringing up a new seed
+// swagger:model SeedBootstrapStep
+type SeedBootstrapStep struct {
+	// Name is one of SeedSynced, CRDsInstalled, SeedControllerManagerReady or NodeportProxyExposed
+	Name    string `json:"name"`
+	Done    bool   `json:"done"`
+	Message string `json:"message,omitempty"`
+}
+
+// SeedUtilization describes the number of user clusters on a seed and the
+// capacity of its nodes
+// swagger:model SeedUtilization
+type SeedUtilization struct {
+	// Healthy is false when the seed has no ready and schedulable nodes
+	Healthy bool `json:"healthy"`
+	// Clusters is the total number of user clusters on the seed
+	Clusters int `json:"clusters"`
+	// Nodes is the number of ready and schedulable seed nodes
+	Nodes int `json:"nodes"`
+	// AllocatableCPU is the sum of the allocatable CPU of the seed nodes
+	AllocatableCPU string `json:"allocatableCPU"`
+	// AllocatableMemory is the sum of the allocatable memory of the seed nodes
+	AllocatableMemory string `json:"allocatableMemory"`
+	// Datacenters holds the cluster count and limit for every datacenter of the seed
+	Datacenters map[string]DatacenterUtilization `json:"datacenters,omitempty"`
+}
+
+// DatacenterUtilization describes how many clusters of a datacenter run on a seed
+// swagger:model DatacenterUtilization
+type DatacenterUtilization struct {
+	Clusters int `json:"clusters"`
+	// MaxClusters is the limit of clusters on the seed, zero means no limit
+	MaxClusters int `json:"maxClusters,omitempty"`
 }
 
 // The spec for a seed data
@@ -1534,6 +1866,8 @@ const (
 	CredentialsSecretsCleanupFinalizer = "kubermatic.io/cleanup-credentials-secrets"
 	// UserClusterRoleCleanupFinalizer indicates that user cluster role still need cleanup
 	UserClusterRoleCleanupFinalizer = "kubermatic.io/user-cluster-role"
+	// FinalEtcdBackupFinalizer indicates that the final etcd backup of a cluster still needs to be taken
+	FinalEtcdBackupFinalizer = "kubermatic.io/final-etcd-backup"
 )
 
 func ToInternalClusterType(externalClusterType string) kubermaticv1.ClusterType {
@@ -26,6 +26,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -150,3 +151,14 @@ func (p *Provider) GetClient(c *kubermaticv1.Cluster, options ...ConfigOption) (
 
 	return p.restMapperCache.Client(config)
 }
+
+// GetK8sClient returns a kubernetes clientset. It is meant for the few operations the
+// dynamic client can not perform, like creating subresources such as pod evictions.
+func (p *Provider) GetK8sClient(c *kubermaticv1.Cluster, options ...ConfigOption) (kubernetes.Interface, error) {
+	config, err := p.GetClientConfig(c, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
@@ -64,7 +64,9 @@ func (d *Deletion) cleanupImageRegistryConfigs(ctx context.Context, log *zap.Sug
 
 	log.Debugw("Found ImageRegistryConfigs", "num-image-registry-configs", len(imageRegistryConfigs.Items))
 
-	for _, imageRegistry := range imageRegistryConfigs.Items {
+	names := make([]string, len(imageRegistryConfigs.Items))
+	for idx, imageRegistry := range imageRegistryConfigs.Items {
+		names[idx] = imageRegistry.GetName()
 		if err := userClusterClient.Delete(ctx, &imageRegistry); err != nil {
 			return false, fmt.Errorf("failed to delete ImageRegistryConfig %q: %v", imageRegistry.GetName(), err)
 		}
@@ -92,6 +94,7 @@ func (d *Deletion) cleanupImageRegistryConfigs(ctx context.Context, log *zap.Sug
 
 	}
 
+	d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, describeRemaining("ImageRegistryConfig", names))
 	log.Debug("Successfully issued DELETE for all ImageRegistryConfigs")
 	return true, nil
 }
@@ -60,11 +60,14 @@ func (d *Deletion) cleanupCredentialsRequests(ctx context.Context, log *zap.Suga
 
 	log.Debug("Found CredentialsRequests", "num-credentials-requests", len(credentialRequests.Items))
 
-	for _, credentialRequest := range credentialRequests.Items {
+	names := make([]string, len(credentialRequests.Items))
+	for idx, credentialRequest := range credentialRequests.Items {
+		names[idx] = credentialRequest.GetName()
 		if err := userClusterClient.Delete(ctx, &credentialRequest); err != nil {
 			return false, fmt.Errorf("failed to delete CredentialsRequest: %v", err)
 		}
 	}
+	d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, describeRemaining("CredentialsRequest", names))
 
 	log.Debug("Successfully issued DELETE for all CredentialsRequests")
 	return true, nil
@@ -26,6 +26,8 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -33,21 +35,54 @@ const (
 	deletedLBAnnotationName = "kubermatic.io/cleaned-up-loadbalancers"
 )
 
-func New(seedClient controllerruntimeclient.Client, userClusterClientGetter func() (controllerruntimeclient.Client, error)) *Deletion {
+func New(seedClient controllerruntimeclient.Client, recorder record.EventRecorder, userClusterClientGetter func() (controllerruntimeclient.Client, error)) *Deletion {
 	return &Deletion{
 		seedClient:              seedClient,
+		recorder:                recorder,
 		userClusterClientGetter: userClusterClientGetter,
 	}
 }
 
 type Deletion struct {
 	seedClient              controllerruntimeclient.Client
+	recorder                record.EventRecorder
 	userClusterClientGetter func() (controllerruntimeclient.Client, error)
+	// waitingFor collects what the deletion phases are waiting for during a single cleanup
+	waitingFor map[kubermaticv1.ClusterDeletionPhaseName][]string
 }
 
-// CleanupCluster is responsible for cleaning up a cluster.
+// CleanupCluster is responsible for cleaning up a cluster. The progress is recorded in the
+// deletion status of the cluster.
 func (d *Deletion) CleanupCluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
-	log = log.Named("cleanup")
+	d.waitingFor = nil
+	err := d.cleanupCluster(ctx, log.Named("cleanup"), cluster)
+	if statusErr := d.updateDeletionStatus(ctx, cluster, err); statusErr != nil {
+		return utilerrors.NewAggregate([]error{err, fmt.Errorf("failed to update deletion status: %v", statusErr)})
+	}
+	return err
+}
+
+func (d *Deletion) cleanupCluster(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	// Clusters which got deleted without going through the API, e.g. together with their project
+	// or by deleting the Cluster object, are kept until the deletion protection is disabled
+	if cluster.Spec.DeletionProtection {
+		log.Debug("Waiting for the deletion protection to be disabled")
+		for _, phase := range deletionPhases {
+			if len(remainingPhaseFinalizers(cluster, phase.name)) > 0 {
+				d.waitFor(phase.name, "waiting for the deletion protection to be disabled")
+				break
+			}
+		}
+		return nil
+	}
+
+	// Nothing may be removed before the final etcd backup is stored, as the backup is
+	// supposed to reflect the cluster as it was when the deletion was requested
+	if kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer) {
+		log.Debug("Waiting for the final etcd backup to be stored")
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseFinalBackup, "waiting for the final etcd backup to be stored")
+		return nil
+	}
 
 	// Delete Volumes and LB's inside the user cluster
 	if err := d.cleanupInClusterResources(ctx, log, cluster); err != nil {
@@ -157,6 +192,7 @@ func (d *Deletion) cleanupInClusterResources(ctx context.Context, log *zap.Sugar
 	}
 	// Return so we check again later
 	if !lbsAreGone {
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, "waiting for the cloud provider to remove the LoadBalancers")
 		return nil
 	}
 
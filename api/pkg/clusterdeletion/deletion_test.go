@@ -19,6 +19,7 @@ package clusterdeletion
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -137,6 +139,18 @@ func TestNodesRemainUntilInClusterResourcesAreGone(t *testing.T) {
 			cluster: getClusterWithFinalizer(clusterName, kubermaticapiv1.InClusterPVCleanupFinalizer),
 			objects: []runtime.Object{&corev1.PersistentVolume{}},
 		},
+		{
+			name:    "Nodes remain because the final etcd backup was not stored yet",
+			cluster: getClusterWithFinalizer(clusterName, kubermaticapiv1.FinalEtcdBackupFinalizer),
+		},
+		{
+			name: "Nodes remain because the cluster is protected against deletion",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := getClusterWithFinalizer(clusterName, kubermaticapiv1.NodeDeletionFinalizer)
+				cluster.Spec.DeletionProtection = true
+				return cluster
+			}(),
+		},
 		// https://github.com/kubernetes-sigs/controller-runtime/issues/702
 		//	{
 		//		name:    "Nodes remain because credentialRequests finalizer exists",
@@ -165,6 +179,7 @@ func TestNodesRemainUntilInClusterResourcesAreGone(t *testing.T) {
 
 			deletion := &Deletion{
 				seedClient:              seedClient,
+				recorder:                record.NewFakeRecorder(10),
 				userClusterClientGetter: userClusterClientGetter,
 			}
 
@@ -183,6 +198,123 @@ func TestNodesRemainUntilInClusterResourcesAreGone(t *testing.T) {
 	}
 }
 
+func TestDeletionStatus(t *testing.T) {
+	const clusterName = "cluster"
+	testCases := []struct {
+		name           string
+		cluster        *kubermaticv1.Cluster
+		objects        []runtime.Object
+		expectedPhases []kubermaticv1.ClusterDeletionPhase
+		expectedEvents []string
+	}{
+		{
+			name: "In-cluster resources are in progress",
+			cluster: getClusterWithFinalizer(clusterName,
+				kubermaticapiv1.InClusterLBCleanupFinalizer,
+				kubermaticapiv1.NodeDeletionFinalizer,
+				"kubermatic.io/cleanup-aws-tags",
+				kubermaticapiv1.CredentialsSecretsCleanupFinalizer),
+			objects: []runtime.Object{&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lb"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			}},
+			expectedPhases: []kubermaticv1.ClusterDeletionPhase{
+				{Name: kubermaticv1.ClusterDeletionPhaseInClusterResources, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "LoadBalancer service default/lb still exists"},
+				{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusPending},
+				{Name: kubermaticv1.ClusterDeletionPhaseCloudProvider, Status: kubermaticv1.ClusterDeletionPhaseStatusPending},
+				{Name: kubermaticv1.ClusterDeletionPhaseCredentials, Status: kubermaticv1.ClusterDeletionPhaseStatusPending},
+			},
+			expectedEvents: []string{"Normal DeletionPhaseStarted Started cluster deletion phase InClusterResources"},
+		},
+		{
+			name:    "Nodes are waiting for machines",
+			cluster: getClusterWithFinalizer(clusterName, kubermaticapiv1.NodeDeletionFinalizer),
+			objects: []runtime.Object{
+				&clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "machine-a"}},
+				&clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "machine-b"}},
+			},
+			expectedPhases: []kubermaticv1.ClusterDeletionPhase{
+				{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "2 machines remaining"},
+			},
+			expectedEvents: []string{"Normal DeletionPhaseStarted Started cluster deletion phase Nodes"},
+		},
+		{
+			name: "Nodes are waiting for the deletion protection to be disabled",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := getClusterWithFinalizer(clusterName, kubermaticapiv1.NodeDeletionFinalizer)
+				cluster.Spec.DeletionProtection = true
+				return cluster
+			}(),
+			objects: []runtime.Object{
+				&clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "machine-a"}},
+			},
+			expectedPhases: []kubermaticv1.ClusterDeletionPhase{
+				{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "waiting for the deletion protection to be disabled"},
+			},
+			expectedEvents: []string{"Normal DeletionPhaseStarted Started cluster deletion phase Nodes"},
+		},
+		{
+			name: "Cloud provider is waiting for its finalizers after the nodes are gone",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := getClusterWithFinalizer(clusterName, "kubermatic.io/cleanup-aws-tags")
+				cluster.Status.Deletion = &kubermaticv1.ClusterDeletionStatus{
+					Phases: []kubermaticv1.ClusterDeletionPhase{
+						{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "machine machine-a still exists"},
+						{Name: kubermaticv1.ClusterDeletionPhaseCloudProvider, Status: kubermaticv1.ClusterDeletionPhaseStatusPending},
+					},
+				}
+				return cluster
+			}(),
+			expectedPhases: []kubermaticv1.ClusterDeletionPhase{
+				{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusCompleted},
+				{Name: kubermaticv1.ClusterDeletionPhaseCloudProvider, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "waiting for the finalizers kubermatic.io/cleanup-aws-tags to be removed"},
+			},
+			expectedEvents: []string{
+				"Normal DeletionPhaseCompleted Completed cluster deletion phase Nodes",
+				"Normal DeletionPhaseStarted Started cluster deletion phase CloudProvider",
+			},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			userClusterClient := fake.NewFakeClient(tc.objects...)
+			recorder := record.NewFakeRecorder(10)
+			deletion := New(fake.NewFakeClient(tc.cluster), recorder, func() (controllerruntimeclient.Client, error) {
+				return userClusterClient, nil
+			})
+
+			if err := deletion.CleanupCluster(context.Background(), kubermaticlog.Logger, tc.cluster); err != nil {
+				t.Fatalf("Deletion failed: %v", err)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := deletion.seedClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if cluster.Status.Deletion == nil {
+				t.Fatal("expected the deletion status to be set")
+			}
+			phases := cluster.Status.Deletion.Phases
+			for i := range phases {
+				phases[i].LastTransitionTime = metav1.Time{}
+			}
+			if !reflect.DeepEqual(phases, tc.expectedPhases) {
+				t.Errorf("expected phases\n%+v\ngot\n%+v", tc.expectedPhases, phases)
+			}
+
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			if !reflect.DeepEqual(events, tc.expectedEvents) {
+				t.Errorf("expected events %v, got %v", tc.expectedEvents, events)
+			}
+		})
+	}
+}
+
 func getClusterWithFinalizer(name string, finalizers ...string) *kubermaticv1.Cluster {
 	return &kubermaticv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -52,6 +52,7 @@ func (d *Deletion) cleanupLBs(ctx context.Context, log *zap.SugaredLogger, clust
 		return false, fmt.Errorf("failed to list Service's from user cluster: %v", err)
 	}
 
+	var remainingServices []string
 	for _, service := range serviceList.Items {
 		serviceName := fmt.Sprintf("%s/%s", service.Namespace, service.Name)
 		slog := log.With("service", serviceName)
@@ -66,6 +67,10 @@ func (d *Deletion) cleanupLBs(ctx context.Context, log *zap.SugaredLogger, clust
 			return deletedSomeLBs, fmt.Errorf("failed to delete service %q inside user cluster: %v", serviceName, err)
 		}
 		deletedSomeLBs = true
+		remainingServices = append(remainingServices, serviceName)
+	}
+	if len(remainingServices) > 0 {
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, describeRemaining("LoadBalancer service", remainingServices))
 	}
 
 	return deletedSomeLBs, nil
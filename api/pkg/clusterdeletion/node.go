@@ -69,12 +69,15 @@ func (d *Deletion) cleanupNodes(ctx context.Context, cluster *kubermaticv1.Clust
 		return fmt.Errorf("failed to list MachineDeployments: %v", err)
 	}
 	if len(machineDeploymentList.Items) > 0 {
+		names := make([]string, len(machineDeploymentList.Items))
 		// TODO: Use DeleteCollection once https://github.com/kubernetes-sigs/controller-runtime/issues/344 is resolved
-		for _, machineDeployment := range machineDeploymentList.Items {
+		for idx, machineDeployment := range machineDeploymentList.Items {
+			names[idx] = machineDeployment.Name
 			if err := userClusterClient.Delete(ctx, &machineDeployment); err != nil {
 				return fmt.Errorf("failed to delete MachineDeployment %q: %v", machineDeployment.Name, err)
 			}
 		}
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseNodes, describeRemaining("MachineDeployment", names))
 		// Return here to make sure we don't attempt to delete MachineSets until the MachineDeployment is actually gone
 		return nil
 	}
@@ -84,12 +87,15 @@ func (d *Deletion) cleanupNodes(ctx context.Context, cluster *kubermaticv1.Clust
 		return fmt.Errorf("failed to list MachineSets: %v", err)
 	}
 	if len(machineSetList.Items) > 0 {
+		names := make([]string, len(machineSetList.Items))
 		// TODO: Use DeleteCollection once https://github.com/kubernetes-sigs/controller-runtime/issues/344 is resolved
-		for _, machineSet := range machineSetList.Items {
+		for idx, machineSet := range machineSetList.Items {
+			names[idx] = machineSet.Name
 			if err := userClusterClient.Delete(ctx, &machineSet); err != nil {
 				return fmt.Errorf("failed to delete MachineSet %q: %v", machineSet.Name, err)
 			}
 		}
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseNodes, describeRemaining("MachineSet", names))
 		// Return here to make sure we don't attempt to delete Machines until the MachineSet is actually gone
 		return nil
 	}
@@ -99,12 +105,15 @@ func (d *Deletion) cleanupNodes(ctx context.Context, cluster *kubermaticv1.Clust
 		return fmt.Errorf("failed to get Machines: %v", err)
 	}
 	if len(machineList.Items) > 0 {
+		names := make([]string, len(machineList.Items))
 		// TODO: Use DeleteCollection once https://github.com/kubernetes-sigs/controller-runtime/issues/344 is resolved
-		for _, machine := range machineList.Items {
+		for idx, machine := range machineList.Items {
+			names[idx] = machine.Name
 			if err := userClusterClient.Delete(ctx, &machine); err != nil {
 				return fmt.Errorf("failed to delete Machine %q: %v", machine.Name, err)
 			}
 		}
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseNodes, describeRemaining("machine", names))
 
 		return nil
 	}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	kubermaticapiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deletionPhases lists the phases of the cluster deletion in the order they are processed,
+// together with the finalizers guarding them. All finalizers which are not listed belong to
+// the CloudProvider phase.
+var deletionPhases = []struct {
+	name       kubermaticv1.ClusterDeletionPhaseName
+	finalizers []string
+}{
+	{
+		name:       kubermaticv1.ClusterDeletionPhaseFinalBackup,
+		finalizers: []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+	},
+	{
+		name: kubermaticv1.ClusterDeletionPhaseInClusterResources,
+		finalizers: []string{
+			kubermaticapiv1.InClusterLBCleanupFinalizer,
+			kubermaticapiv1.InClusterPVCleanupFinalizer,
+			kubermaticapiv1.InClusterCredentialsRequestsCleanupFinalizer,
+			kubermaticapiv1.InClusterImageRegistryConfigCleanupFinalizer,
+		},
+	},
+	{
+		name:       kubermaticv1.ClusterDeletionPhaseNodes,
+		finalizers: []string{kubermaticapiv1.NodeDeletionFinalizer},
+	},
+	{
+		name: kubermaticv1.ClusterDeletionPhaseCloudProvider,
+	},
+	{
+		name:       kubermaticv1.ClusterDeletionPhaseCredentials,
+		finalizers: []string{kubermaticapiv1.CredentialsSecretsCleanupFinalizer},
+	},
+}
+
+// remainingPhaseFinalizers returns the finalizers of the given phase which are still set on the cluster
+func remainingPhaseFinalizers(cluster *kubermaticv1.Cluster, phaseName kubermaticv1.ClusterDeletionPhaseName) []string {
+	finalizers := sets.NewString(cluster.Finalizers...)
+	if phaseName == kubermaticv1.ClusterDeletionPhaseCloudProvider {
+		for _, phase := range deletionPhases {
+			finalizers.Delete(phase.finalizers...)
+		}
+		return finalizers.List()
+	}
+
+	for _, phase := range deletionPhases {
+		if phase.name == phaseName {
+			return finalizers.Intersection(sets.NewString(phase.finalizers...)).List()
+		}
+	}
+	return nil
+}
+
+// waitFor records what the given phase is waiting for. It is reported as message of the phase.
+func (d *Deletion) waitFor(phase kubermaticv1.ClusterDeletionPhaseName, message string) {
+	if d.waitingFor == nil {
+		d.waitingFor = map[kubermaticv1.ClusterDeletionPhaseName][]string{}
+	}
+	d.waitingFor[phase] = append(d.waitingFor[phase], message)
+}
+
+// describeRemaining returns a short description of the objects of the given kind which still exist
+func describeRemaining(kind string, names []string) string {
+	if len(names) == 1 {
+		return fmt.Sprintf("%s %s still exists", kind, names[0])
+	}
+	return fmt.Sprintf("%d %ss remaining", len(names), kind)
+}
+
+// updateDeletionStatus records the progress of the deletion on the cluster. An event is
+// emitted whenever a phase starts or completes, errors are already reported as event by
+// the calling controllers.
+func (d *Deletion) updateDeletionStatus(ctx context.Context, cluster *kubermaticv1.Cluster, cleanupErr error) error {
+	oldCluster := cluster.DeepCopy()
+	now := metav1.Now()
+
+	if cluster.Status.Deletion == nil {
+		cluster.Status.Deletion = &kubermaticv1.ClusterDeletionStatus{}
+		for _, phase := range deletionPhases {
+			if len(remainingPhaseFinalizers(cluster, phase.name)) > 0 {
+				cluster.Status.Deletion.Phases = append(cluster.Status.Deletion.Phases, kubermaticv1.ClusterDeletionPhase{
+					Name:               phase.name,
+					Status:             kubermaticv1.ClusterDeletionPhaseStatusPending,
+					LastTransitionTime: now,
+				})
+			}
+		}
+	}
+	status := cluster.Status.Deletion
+
+	var foundPhaseInProgress bool
+	for i := range status.Phases {
+		phase := &status.Phases[i]
+		remaining := remainingPhaseFinalizers(cluster, phase.Name)
+
+		newStatus := kubermaticv1.ClusterDeletionPhaseStatusPending
+		var message string
+		switch {
+		case len(remaining) == 0:
+			newStatus = kubermaticv1.ClusterDeletionPhaseStatusCompleted
+		case !foundPhaseInProgress:
+			foundPhaseInProgress = true
+			newStatus = kubermaticv1.ClusterDeletionPhaseStatusInProgress
+			message = strings.Join(d.waitingFor[phase.Name], ", ")
+			if message == "" && phase.Name == kubermaticv1.ClusterDeletionPhaseCloudProvider {
+				message = fmt.Sprintf("waiting for the finalizers %s to be removed", strings.Join(remaining, ", "))
+			}
+			// Not every iteration learns something new about what the phase waits for
+			if message == "" && phase.Status == newStatus {
+				message = phase.Message
+			}
+		}
+
+		if phase.Status != newStatus {
+			phase.LastTransitionTime = now
+			switch newStatus {
+			case kubermaticv1.ClusterDeletionPhaseStatusInProgress:
+				d.recorder.Eventf(cluster, corev1.EventTypeNormal, "DeletionPhaseStarted", "Started cluster deletion phase %s", phase.Name)
+			case kubermaticv1.ClusterDeletionPhaseStatusCompleted:
+				d.recorder.Eventf(cluster, corev1.EventTypeNormal, "DeletionPhaseCompleted", "Completed cluster deletion phase %s", phase.Name)
+			}
+		}
+		phase.Status = newStatus
+		phase.Message = message
+	}
+
+	if cleanupErr != nil {
+		status.LastError = cleanupErr.Error()
+		status.LastErrorTime = &now
+	}
+
+	if reflect.DeepEqual(oldCluster.Status.Deletion, cluster.Status.Deletion) {
+		return nil
+	}
+	// The cluster is gone once the last finalizer got removed
+	return controllerruntimeclient.IgnoreNotFound(d.seedClient.Patch(ctx, cluster, controllerruntimeclient.MergeFrom(oldCluster)))
+}
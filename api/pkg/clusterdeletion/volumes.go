@@ -58,6 +58,21 @@ func (d *Deletion) cleanupVolumes(ctx context.Context, cluster *kubermaticv1.Clu
 		return deletedSomeResource, nil
 	}
 
+	if len(pvcList.Items) > 0 {
+		pvcNames := make([]string, len(pvcList.Items))
+		for idx, pvc := range pvcList.Items {
+			pvcNames[idx] = fmt.Sprintf("%s/%s", pvc.Namespace, pvc.Name)
+		}
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, describeRemaining("PersistentVolumeClaim", pvcNames))
+	}
+	if len(pvList.Items) > 0 {
+		pvNames := make([]string, len(pvList.Items))
+		for idx, pv := range pvList.Items {
+			pvNames[idx] = pv.Name
+		}
+		d.waitFor(kubermaticv1.ClusterDeletionPhaseInClusterResources, describeRemaining("PersistentVolume", pvNames))
+	}
+
 	// Delete all Pods that use PVs. We must keep the remaining pods, otherwise
 	// we end up in a deadlock when CSI is used
 	if err := d.cleanupPVCUsingPods(ctx, userClusterClient); err != nil {
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletdnat
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// BackendAuto picks the backend matching the rules already present on the node
+	BackendAuto = "auto"
+	// BackendIPTables manages the rules with iptables-save and iptables-restore
+	BackendIPTables = "iptables"
+	// BackendNFTables manages the rules in a dedicated nftables table
+	BackendNFTables = "nftables"
+)
+
+// ruleBackend manages the translation rules in the kernel. Rules written with legacy iptables are not
+// evaluated together with nftables rules and vice versa, so the backend must match the one the node uses.
+type ruleBackend interface {
+	// ruleLine renders a translation rule the way the backend lists it.
+	ruleLine(rule *dnatRule) string
+	// listRules returns the translation rules in the kernel and whether the jump into the
+	// translation chain and the masquerade rule for the vpn interface exist.
+	listRules() (rules []string, haveJump bool, haveMasquerade bool, err error)
+	// applyRules atomically replaces the translation rules in the kernel.
+	applyRules(rules []string, haveJump, haveMasquerade bool) error
+}
+
+// commandExecutor runs a command with the given lines as stdin and returns its output.
+type commandExecutor func(stdin []string, name string, args ...string) ([]byte, error)
+
+func execCommand(stdin []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("failed to execute %q: %v (output: %s)", strings.Join(cmd.Args, " "), err, out)
+	}
+	return out, nil
+}
+
+func newRuleBackend(name, chain, vpnInterface string, exec commandExecutor) (ruleBackend, error) {
+	switch name {
+	case BackendIPTables:
+		return &iptablesBackend{chain: chain, vpnInterface: vpnInterface, exec: exec}, nil
+	case BackendNFTables:
+		return &nftablesBackend{chain: chain, vpnInterface: vpnInterface, exec: exec}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule backend %q, must be one of %s, %s or %s", name, BackendAuto, BackendIPTables, BackendNFTables)
+	}
+}
+
+// detectBackend returns the backend in use on the node. iptables is only used if it is not backed by
+// nf_tables itself and either nft is not available or iptables already holds NAT rules.
+func detectBackend(exec commandExecutor) string {
+	if _, err := exec(nil, "nft", "list", "tables"); err != nil {
+		return BackendIPTables
+	}
+
+	if version, err := exec(nil, "iptables", "--version"); err == nil && strings.Contains(string(version), "nf_tables") {
+		return BackendNFTables
+	}
+
+	save, err := exec(nil, "iptables-save", "-t", "nat")
+	if err != nil {
+		return BackendNFTables
+	}
+	for _, line := range strings.Split(string(save), "\n") {
+		if strings.HasPrefix(line, "-A ") {
+			return BackendIPTables
+		}
+	}
+	return BackendNFTables
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletdnat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		outputs  map[string]string
+		expected string
+	}{
+		{
+			name: "nft is not installed",
+			outputs: map[string]string{
+				"iptables --version":   "iptables v1.8.3 (legacy)",
+				"iptables-save -t nat": "*nat\n-A OUTPUT -j node-access-dnat\nCOMMIT\n",
+			},
+			expected: BackendIPTables,
+		},
+		{
+			name: "iptables is backed by nf_tables",
+			outputs: map[string]string{
+				"nft list tables":      "table ip nat\n",
+				"iptables --version":   "iptables v1.8.3 (nf_tables)",
+				"iptables-save -t nat": "*nat\n-A OUTPUT -j node-access-dnat\nCOMMIT\n",
+			},
+			expected: BackendNFTables,
+		},
+		{
+			name: "legacy iptables holds nat rules",
+			outputs: map[string]string{
+				"nft list tables":      "",
+				"iptables --version":   "iptables v1.8.3 (legacy)",
+				"iptables-save -t nat": "*nat\n:PREROUTING ACCEPT [0:0]\n-A POSTROUTING -o tun0 -j MASQUERADE\nCOMMIT\n",
+			},
+			expected: BackendIPTables,
+		},
+		{
+			name: "legacy iptables is empty",
+			outputs: map[string]string{
+				"nft list tables":      "table ip filter\n",
+				"iptables --version":   "iptables v1.8.3 (legacy)",
+				"iptables-save -t nat": "*nat\n:PREROUTING ACCEPT [0:0]\nCOMMIT\n",
+			},
+			expected: BackendNFTables,
+		},
+		{
+			name: "only nft is installed",
+			outputs: map[string]string{
+				"nft list tables": "table ip filter\n",
+			},
+			expected: BackendNFTables,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exec := func(_ []string, name string, args ...string) ([]byte, error) {
+				out, found := test.outputs[strings.Join(append([]string{name}, args...), " ")]
+				if !found {
+					return nil, errors.New("executable file not found in $PATH")
+				}
+				return []byte(out), nil
+			}
+
+			if backend := detectBackend(exec); backend != test.expected {
+				t.Errorf("expected backend %q, got %q", test.expected, backend)
+			}
+		})
+	}
+}
+
+func TestIPTablesRestoreLines(t *testing.T) {
+	backend := &iptablesBackend{chain: "test-chain", vpnInterface: "tun0"}
+	rules := []string{"-A test-chain -d 10.1.1.11/32 -p tcp -m tcp --dport 10250 -j DNAT --to-destination 10.254.1.11:10250"}
+
+	tests := []struct {
+		name           string
+		haveJump       bool
+		haveMasquerade bool
+		expected       []string
+	}{
+		{
+			name: "jump and masquerade get added",
+			expected: []string{
+				"*nat",
+				":test-chain - [0:0]",
+				"-I OUTPUT -j test-chain",
+				"-I POSTROUTING -o tun0 -j MASQUERADE",
+				rules[0],
+				"COMMIT",
+			},
+		},
+		{
+			name:           "existing jump and masquerade are kept",
+			haveJump:       true,
+			haveMasquerade: true,
+			expected: []string{
+				"*nat",
+				":test-chain - [0:0]",
+				rules[0],
+				"COMMIT",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines := backend.restoreLines(rules, test.haveJump, test.haveMasquerade)
+			if strings.Join(lines, "\n") != strings.Join(test.expected, "\n") {
+				t.Errorf("expected restore file\n%s\ngot\n%s", strings.Join(test.expected, "\n"), strings.Join(lines, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletdnat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iptablesBackend manages the translation chain in the nat table with iptables-save and iptables-restore.
+type iptablesBackend struct {
+	chain        string
+	vpnInterface string
+	exec         commandExecutor
+}
+
+func (b *iptablesBackend) ruleLine(rule *dnatRule) string {
+	return rule.RestoreLine(b.chain)
+}
+
+func (b *iptablesBackend) listRules() ([]string, bool, bool, error) {
+	out, err := b.exec(nil, "iptables-save", "-t", "nat")
+	if err != nil {
+		return nil, false, false, err
+	}
+	// filter out everything that's not relevant for us
+	rules, haveJump, haveMasquerade := b.filterDnatRules(strings.Split(string(out), "\n"))
+	return rules, haveJump, haveMasquerade, nil
+}
+
+// applyRules pipes an iptables-save file to stdin of an iptables-restore process
+// for atomically setting new rules.
+func (b *iptablesBackend) applyRules(rules []string, haveJump, haveMasquerade bool) error {
+	if _, err := b.exec(b.restoreLines(rules, haveJump, haveMasquerade), "iptables-restore", "--noflush", "-v", "-T", "nat"); err != nil {
+		return fmt.Errorf("iptables-restore failed: %v", err)
+	}
+	return nil
+}
+
+// restoreLines creates the iptables-save file for the given rules.
+// It replaces a complete chain (removing all pre-existing rules).
+func (b *iptablesBackend) restoreLines(rules []string, haveJump, haveMasquerade bool) []string {
+	restore := []string{
+		"*nat",
+		fmt.Sprintf(":%s - [0:0]", b.chain)}
+
+	if !haveJump {
+		restore = append(restore,
+			fmt.Sprintf("-I OUTPUT -j %s", b.chain))
+	}
+
+	if !haveMasquerade {
+		restore = append(restore,
+			fmt.Sprintf("-I POSTROUTING -o %s -j MASQUERADE", b.vpnInterface))
+	}
+
+	restore = append(restore, rules...)
+	return append(restore, "COMMIT")
+}
+
+// filterDnatRules enumerates through all given rules and returns all
+// rules matching the translation chain. It also returns two booleans to
+// indicate if the jump and the masquerade rule are present.
+func (b *iptablesBackend) filterDnatRules(rules []string) ([]string, bool, bool) {
+	out := []string{}
+	haveJump := false
+	haveMasquerade := false
+
+	rulePrefix := fmt.Sprintf("-A %s ", b.chain)
+	jumpPattern := fmt.Sprintf("-A OUTPUT -j %s", b.chain)
+	masqPattern := fmt.Sprintf("-A POSTROUTING -o %s -j MASQUERADE", b.vpnInterface)
+	for _, rule := range rules {
+		if rule == jumpPattern {
+			haveJump = true
+		}
+		if rule == masqPattern {
+			haveMasquerade = true
+		}
+		if !strings.HasPrefix(rule, rulePrefix) {
+			continue
+		}
+		out = append(out, rule)
+	}
+	return out, haveJump, haveMasquerade
+}
+
+// GetMatchArgs returns iptables arguments to match for the
+// rule's originalTargetAddress and Port.
+func (rule *dnatRule) GetMatchArgs() []string {
+	return []string{
+		"-d", rule.originalTargetAddress + "/32",
+		"-p", "tcp",
+		"-m", "tcp",
+		"--dport", rule.originalTargetPort,
+	}
+}
+
+// GetTargetArgs returns iptables arguments to specify the
+// rule's target after translation.
+func (rule *dnatRule) GetTargetArgs() []string {
+	var target string
+	if len(rule.translatedAddress) > 0 {
+		target = rule.translatedAddress
+	}
+	target += ":"
+	if len(rule.translatedPort) > 0 {
+		target += rule.translatedPort
+	}
+	if len(target) == 0 {
+		return []string{}
+	}
+	return []string{
+		"-j", "DNAT",
+		"--to-destination", target,
+	}
+}
+
+// RestoreLine returns a line of `iptables-save`-file representing
+// the rule.
+func (rule *dnatRule) RestoreLine(chain string) string {
+	args := []string{"-A", chain}
+	args = append(args, rule.GetMatchArgs()...)
+	args = append(args, rule.GetTargetArgs()...)
+	return strings.Join(args, " ")
+}
@@ -19,9 +19,7 @@ package kubeletdnat
 import (
 	"context"
 	"fmt"
-	"io"
 	"net"
-	"os/exec"
 	"sort"
 	"strconv"
 	"strings"
@@ -48,14 +46,13 @@ const (
 	ControllerName = "kubermatic_kubelet_dnat_controller"
 )
 
-// Reconciler updates iptable rules to match node addresses.
+// Reconciler updates NAT rules to match node addresses.
 // Every node address gets a translation to the respective node-access (vpn) address.
 type Reconciler struct {
 	ctrlruntimeclient.Client
 
-	nodeTranslationChainName string
-	nodeAccessNetwork        net.IP
-	vpnInterface             string
+	nodeAccessNetwork net.IP
+	backend           ruleBackend
 
 	log *zap.SugaredLogger
 }
@@ -75,14 +72,23 @@ func Add(
 	nodeTranslationChainName string,
 	nodeAccessNetwork net.IP,
 	log *zap.SugaredLogger,
-	vpnInterface string) error {
+	vpnInterface string,
+	backendName string) error {
+
+	if backendName == BackendAuto {
+		backendName = detectBackend(execCommand)
+	}
+	backend, err := newRuleBackend(backendName, nodeTranslationChainName, vpnInterface, execCommand)
+	if err != nil {
+		return err
+	}
+	log.Infow("Using rule backend", "backend", backendName)
 
 	reconciler := &Reconciler{
-		Client:                   mgr.GetClient(),
-		nodeTranslationChainName: nodeTranslationChainName,
-		nodeAccessNetwork:        nodeAccessNetwork,
-		vpnInterface:             vpnInterface,
-		log:                      log,
+		Client:            mgr.GetClient(),
+		nodeAccessNetwork: nodeAccessNetwork,
+		backend:           backend,
+		log:               log,
 	}
 
 	ctrlOptions := controller.Options{
@@ -140,7 +146,7 @@ func (r *Reconciler) getDesiredRules(nodes []corev1.Node) []string {
 			continue
 		}
 		for _, rule := range nodeRules {
-			rules = append(rules, rule.RestoreLine(r.nodeTranslationChainName))
+			rules = append(rules, r.backend.ruleLine(rule))
 		}
 	}
 	sort.Strings(rules)
@@ -159,19 +165,17 @@ func (r *Reconciler) syncDnatRules(ctx context.Context) error {
 	// Create the set of rules from all listed nodes.
 	desiredRules := r.getDesiredRules(nodeList.Items)
 
-	// Get the actual state (current iptable rules)
-	allActualRules, err := execSave()
+	// Get the actual state (current rules in the kernel)
+	actualRules, haveJump, haveMasquerade, err := r.backend.listRules()
 	if err != nil {
-		return fmt.Errorf("failed to read iptable rules: %v", err)
+		return fmt.Errorf("failed to read rules: %v", err)
 	}
-	// filter out everything that's not relevant for us
-	actualRules, haveJump, haveMasquerade := r.filterDnatRules(allActualRules, r.nodeTranslationChainName)
 
 	if !equality.Semantic.DeepEqual(actualRules, desiredRules) || !haveJump || !haveMasquerade {
 		// Need to update chain in kernel.
-		r.log.Debugw("Updating iptables chain in kernel", "rules-count", len(desiredRules))
-		if err := r.applyDNATRules(desiredRules, haveJump, haveMasquerade); err != nil {
-			return fmt.Errorf("failed to apply iptable rules: %v", err)
+		r.log.Debugw("Updating translation chain in kernel", "rules-count", len(desiredRules))
+		if err := r.backend.applyRules(desiredRules, haveJump, haveMasquerade); err != nil {
+			return fmt.Errorf("failed to apply rules: %v", err)
 		}
 	}
 
@@ -241,126 +245,3 @@ func (r *Reconciler) getRulesForNode(node corev1.Node) ([]*dnatRule, error) {
 	}
 	return rules, nil
 }
-
-// applyRules creates a iptables-save file and pipes it to stdin of
-// a iptables-restore process for atomically setting new rules.
-// This function replaces a complete chain (removing all pre-existing rules).
-func (r *Reconciler) applyDNATRules(rules []string, haveJump, haveMasquerade bool) error {
-	restore := []string{
-		"*nat",
-		fmt.Sprintf(":%s - [0:0]", r.nodeTranslationChainName)}
-
-	if !haveJump {
-		restore = append(restore,
-			fmt.Sprintf("-I OUTPUT -j %s", r.nodeTranslationChainName))
-	}
-
-	if !haveMasquerade {
-		restore = append(restore,
-			fmt.Sprintf("-I POSTROUTING -o %s -j MASQUERADE", r.vpnInterface))
-	}
-
-	restore = append(restore, rules...)
-	restore = append(restore, "COMMIT")
-
-	return execRestore(restore)
-}
-
-func execSave() ([]string, error) {
-	cmd := exec.Command("iptables-save", []string{"-t", "nat"}...)
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute %q: %v. Output: \n%s", strings.Join(cmd.Args, " "), err, out)
-	}
-	return strings.Split(string(out), "\n"), err
-}
-
-func execRestore(rules []string) error {
-	cmd := exec.Command("iptables-restore", []string{"--noflush", "-v", "-T", "nat"}...)
-
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return err
-	}
-	if _, err := io.WriteString(stdin, strings.Join(rules, "\n")+"\n"); err != nil {
-		return fmt.Errorf("failed to write to iptables-restore stdin: %v", err)
-	}
-	if err := stdin.Close(); err != nil {
-		return fmt.Errorf("failed to close iptables-restore stdin: %v", err)
-	}
-
-	out, err := cmd.CombinedOutput()
-	if err == nil {
-		return nil
-	}
-	if len(out) > 0 {
-		return fmt.Errorf("iptables-restore failed: %v (output: %s)", err, string(out))
-	}
-	return fmt.Errorf("iptables-restore failed: %v", err)
-}
-
-// GetMatchArgs returns iptables arguments to match for the
-// rule's originalTargetAddress and Port.
-func (rule *dnatRule) GetMatchArgs() []string {
-	return []string{
-		"-d", rule.originalTargetAddress + "/32",
-		"-p", "tcp",
-		"-m", "tcp",
-		"--dport", rule.originalTargetPort,
-	}
-}
-
-// GetTargetArgs returns iptables arguments to specify the
-// rule's target after translation.
-func (rule *dnatRule) GetTargetArgs() []string {
-	var target string
-	if len(rule.translatedAddress) > 0 {
-		target = rule.translatedAddress
-	}
-	target += ":"
-	if len(rule.translatedPort) > 0 {
-		target += rule.translatedPort
-	}
-	if len(target) == 0 {
-		return []string{}
-	}
-	return []string{
-		"-j", "DNAT",
-		"--to-destination", target,
-	}
-}
-
-// RestoreLine returns a line of `iptables-save`-file representing
-// the rule.
-func (rule *dnatRule) RestoreLine(chain string) string {
-	args := []string{"-A", chain}
-	args = append(args, rule.GetMatchArgs()...)
-	args = append(args, rule.GetTargetArgs()...)
-	return strings.Join(args, " ")
-}
-
-// filterDnatRules enumerates through all given rules and returns all
-// rules matching the given chain. It also returns two booleans to
-// indicate if the jump and the masquerade rule are present.
-func (r *Reconciler) filterDnatRules(rules []string, chain string) ([]string, bool, bool) {
-	out := []string{}
-	haveJump := false
-	haveMasquerade := false
-
-	rulePrefix := fmt.Sprintf("-A %s ", chain)
-	jumpPattern := fmt.Sprintf("-A OUTPUT -j %s", chain)
-	masqPattern := fmt.Sprintf("-A POSTROUTING -o %s -j MASQUERADE", r.vpnInterface)
-	for _, rule := range rules {
-		if rule == jumpPattern {
-			haveJump = true
-		}
-		if rule == masqPattern {
-			haveMasquerade = true
-		}
-		if !strings.HasPrefix(rule, rulePrefix) {
-			continue
-		}
-		out = append(out, rule)
-	}
-	return out, haveJump, haveMasquerade
-}
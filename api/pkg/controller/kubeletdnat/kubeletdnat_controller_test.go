@@ -30,8 +30,8 @@ func TestRuleGeneration(t *testing.T) {
 		t.Fatal(err)
 	}
 	ctrl := &Reconciler{
-		nodeTranslationChainName: "test-chain",
-		nodeAccessNetwork:        nodeAccessNetwork,
+		nodeAccessNetwork: nodeAccessNetwork,
+		backend:           &iptablesBackend{chain: "test-chain"},
 	}
 
 	nodes := []corev1.Node{
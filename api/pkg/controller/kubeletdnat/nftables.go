@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletdnat
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// nftablesTable is the table holding all rules of the controller. It is owned by the controller
+	// and gets replaced as a whole, so rules of other tools are never touched.
+	nftablesTable = "kubelet-dnat"
+
+	outputChain      = "output"
+	postroutingChain = "postrouting"
+)
+
+// nftablesBackend manages the translation chain in a dedicated nftables table with nft.
+type nftablesBackend struct {
+	chain        string
+	vpnInterface string
+	exec         commandExecutor
+}
+
+func (b *nftablesBackend) ruleLine(rule *dnatRule) string {
+	return fmt.Sprintf("ip daddr %s tcp dport %s dnat to %s:%s",
+		rule.originalTargetAddress, rule.originalTargetPort, rule.translatedAddress, rule.translatedPort)
+}
+
+func (b *nftablesBackend) jumpLine() string {
+	return "jump " + b.chain
+}
+
+func (b *nftablesBackend) masqueradeLine() string {
+	return fmt.Sprintf("oifname %q masquerade", b.vpnInterface)
+}
+
+func (b *nftablesBackend) listRules() ([]string, bool, bool, error) {
+	out, err := b.exec(nil, "nft", "-n", "list", "table", "ip", nftablesTable)
+	if err != nil {
+		// The table gets created on the first sync
+		if strings.Contains(string(out), "No such file or directory") {
+			return []string{}, false, false, nil
+		}
+		return nil, false, false, err
+	}
+	rules, haveJump, haveMasquerade := b.parseTable(string(out))
+	return rules, haveJump, haveMasquerade, nil
+}
+
+// parseTable returns the rules of the translation chain from the output of "nft list table".
+// It also returns two booleans to indicate if the jump and the masquerade rule are present.
+func (b *nftablesBackend) parseTable(table string) ([]string, bool, bool) {
+	rules := []string{}
+	haveJump := false
+	haveMasquerade := false
+
+	chain := ""
+	for _, line := range strings.Split(table, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "chain ") && strings.HasSuffix(line, "{"):
+			chain = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "chain "), "{"))
+		case line == "}":
+			chain = ""
+		case chain == outputChain && line == b.jumpLine():
+			haveJump = true
+		case chain == postroutingChain && line == b.masqueradeLine():
+			haveMasquerade = true
+		case chain == b.chain && strings.HasPrefix(line, "ip daddr "):
+			rules = append(rules, line)
+		}
+	}
+	return rules, haveJump, haveMasquerade
+}
+
+// applyRules replaces the table of the controller in a single nft transaction. The
+// table always gets recreated as a whole, including the jump and the masquerade rule.
+func (b *nftablesBackend) applyRules(rules []string, _, _ bool) error {
+	if _, err := b.exec(b.script(rules), "nft", "-f", "-"); err != nil {
+		return fmt.Errorf("nft failed: %v", err)
+	}
+	return nil
+}
+
+// script creates the nft script which replaces the table of the controller.
+// Declaring the table first makes the deletion succeed if it does not exist yet.
+func (b *nftablesBackend) script(rules []string) []string {
+	script := []string{
+		fmt.Sprintf("table ip %s", nftablesTable),
+		fmt.Sprintf("delete table ip %s", nftablesTable),
+		fmt.Sprintf("table ip %s {", nftablesTable),
+		fmt.Sprintf("\tchain %s {", outputChain),
+		"\t\ttype nat hook output priority -100; policy accept;",
+		"\t\t" + b.jumpLine(),
+		"\t}",
+		fmt.Sprintf("\tchain %s {", postroutingChain),
+		"\t\ttype nat hook postrouting priority 100; policy accept;",
+		"\t\t" + b.masqueradeLine(),
+		"\t}",
+		fmt.Sprintf("\tchain %s {", b.chain),
+	}
+	for _, rule := range rules {
+		script = append(script, "\t\t"+rule)
+	}
+	return append(script, "\t}", "}")
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletdnat
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNFTablesRuleGeneration(t *testing.T) {
+	nodeAccessNetwork, _, err := net.ParseCIDR("10.254.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		addresses     []corev1.NodeAddress
+		kubeletPort   int32
+		expectedRules []string
+	}{
+		{
+			name: "internal and external address",
+			addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.1.1.11"},
+				{Type: corev1.NodeExternalIP, Address: "192.0.2.101"},
+			},
+			expectedRules: []string{
+				"ip daddr 10.1.1.11 tcp dport 10250 dnat to 10.254.1.11:10250",
+				"ip daddr 192.0.2.101 tcp dport 10250 dnat to 10.254.1.11:10250",
+			},
+		},
+		{
+			name: "internal address only on a custom kubelet port",
+			addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "172.16.3.4"},
+			},
+			kubeletPort: 10350,
+			expectedRules: []string{
+				"ip daddr 172.16.3.4 tcp dport 10350 dnat to 10.254.3.4:10350",
+			},
+		},
+		{
+			name: "hostname and external address only",
+			addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node-1"},
+				{Type: corev1.NodeExternalIP, Address: "192.0.2.101"},
+			},
+			expectedRules: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := &Reconciler{
+				nodeAccessNetwork: nodeAccessNetwork,
+				backend:           &nftablesBackend{chain: "test-chain"},
+				log:               kubermaticlog.Logger,
+			}
+			node := corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node"},
+				Status: corev1.NodeStatus{
+					Addresses:       test.addresses,
+					DaemonEndpoints: corev1.NodeDaemonEndpoints{KubeletEndpoint: corev1.DaemonEndpoint{Port: test.kubeletPort}},
+				},
+			}
+
+			if diff := deep.Equal(ctrl.getDesiredRules([]corev1.Node{node}), test.expectedRules); diff != nil {
+				t.Errorf("got unexpected rules, diff to expected: %v", diff)
+			}
+		})
+	}
+}
+
+func TestNFTablesParseTable(t *testing.T) {
+	backend := &nftablesBackend{chain: "test-chain", vpnInterface: "tun0"}
+	table := `table ip kubelet-dnat {
+	chain output {
+		type nat hook output priority -100; policy accept;
+		jump test-chain
+	}
+
+	chain postrouting {
+		type nat hook postrouting priority 100; policy accept;
+		oifname "tun0" masquerade
+	}
+
+	chain test-chain {
+		ip daddr 10.1.1.11 tcp dport 10250 dnat to 10.254.1.11:10250
+		ip daddr 192.0.2.101 tcp dport 10250 dnat to 10.254.1.11:10250
+	}
+}
+`
+
+	rules, haveJump, haveMasquerade := backend.parseTable(table)
+	expectedRules := []string{
+		"ip daddr 10.1.1.11 tcp dport 10250 dnat to 10.254.1.11:10250",
+		"ip daddr 192.0.2.101 tcp dport 10250 dnat to 10.254.1.11:10250",
+	}
+	if diff := deep.Equal(rules, expectedRules); diff != nil {
+		t.Errorf("got unexpected rules, diff to expected: %v", diff)
+	}
+	if !haveJump {
+		t.Error("expected the jump rule to be found")
+	}
+	if !haveMasquerade {
+		t.Error("expected the masquerade rule to be found")
+	}
+
+	backend.vpnInterface = "tun1"
+	if _, _, haveMasquerade := backend.parseTable(table); haveMasquerade {
+		t.Error("expected the masquerade rule of another interface to be ignored")
+	}
+}
+
+func TestNFTablesApplyRules(t *testing.T) {
+	var stdin []string
+	var command []string
+	backend := &nftablesBackend{
+		chain:        "test-chain",
+		vpnInterface: "tun0",
+		exec: func(in []string, name string, args ...string) ([]byte, error) {
+			stdin = in
+			command = append([]string{name}, args...)
+			return nil, nil
+		},
+	}
+
+	if err := backend.applyRules([]string{"ip daddr 10.1.1.11 tcp dport 10250 dnat to 10.254.1.11:10250"}, true, true); err != nil {
+		t.Fatalf("failed to apply rules: %v", err)
+	}
+
+	if diff := deep.Equal(command, []string{"nft", "-f", "-"}); diff != nil {
+		t.Errorf("got unexpected command, diff to expected: %v", diff)
+	}
+	expectedScript := `table ip kubelet-dnat
+delete table ip kubelet-dnat
+table ip kubelet-dnat {
+	chain output {
+		type nat hook output priority -100; policy accept;
+		jump test-chain
+	}
+	chain postrouting {
+		type nat hook postrouting priority 100; policy accept;
+		oifname "tun0" masquerade
+	}
+	chain test-chain {
+		ip daddr 10.1.1.11 tcp dport 10250 dnat to 10.254.1.11:10250
+	}
+}`
+	if script := strings.Join(stdin, "\n"); script != expectedScript {
+		t.Errorf("expected script\n%s\ngot\n%s", expectedScript, script)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeepersynchronizer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	controllerutil "github.com/kubermatic/kubermatic/api/pkg/controller/util"
+	predicateutil "github.com/kubermatic/kubermatic/api/pkg/controller/util/predicate"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+	"github.com/kubermatic/kubermatic/api/pkg/util/workerlabel"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "gatekeeper_synchronizer"
+)
+
+// Reconciler is a controller which is responsible for syncing the gatekeeper policies into the cluster namespaces
+type Reconciler struct {
+	ctx         context.Context
+	client      ctrlruntimeclient.Client
+	log         *zap.SugaredLogger
+	workerName  string
+	seedClients map[string]ctrlruntimeclient.Client
+}
+
+func Add(
+	ctx context.Context,
+	mgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	workerName string,
+	numWorkers int,
+) error {
+	workerSelector, err := workerlabel.LabelSelector(workerName)
+	if err != nil {
+		return fmt.Errorf("failed to build worker-name selector: %v", err)
+	}
+
+	reconciler := &Reconciler{
+		ctx:         ctx,
+		log:         log.Named(ControllerName),
+		workerName:  workerName,
+		client:      mgr.GetClient(),
+		seedClients: map[string]ctrlruntimeclient.Client{},
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %v", err)
+	}
+
+	for seedName, seedManager := range seedManagers {
+		reconciler.seedClients[seedName] = seedManager.GetClient()
+
+		configMapSource := &source.Kind{Type: &corev1.ConfigMap{}}
+		if err := configMapSource.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache into configMapSource: %v", err)
+		}
+		if err := c.Watch(
+			configMapSource,
+			controllerutil.EnqueueClusterForNamespacedObjectWithSeedName(seedManager.GetClient(), seedName, workerSelector),
+			predicateutil.ByName(gatekeeper.PoliciesConfigMapName),
+		); err != nil {
+			return fmt.Errorf("failed to establish watch for configmaps in seed %s: %v", seedName, err)
+		}
+
+		clusterSource := &source.Kind{Type: &kubermaticv1.Cluster{}}
+		if err := clusterSource.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache into clusterSource for seed %s: %v", seedName, err)
+		}
+		if err := c.Watch(
+			clusterSource,
+			controllerutil.EnqueueClusterScopedObjectWithSeedName(seedName),
+			workerlabel.Predicates(workerName),
+		); err != nil {
+			return fmt.Errorf("failed to establish watch for clusters in seed %s: %v", seedName, err)
+		}
+	}
+
+	if err := c.Watch(
+		&source.Kind{Type: &kubermaticv1.ConstraintTemplate{}},
+		enqueueAllClusters(reconciler.seedClients, workerSelector),
+	); err != nil {
+		return fmt.Errorf("failed to create watch for constraint templates: %v", err)
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &kubermaticv1.Constraint{}},
+		enqueueAllClusters(reconciler.seedClients, workerSelector),
+	); err != nil {
+		return fmt.Errorf("failed to create watch for constraints: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	err := r.reconcile(log, request)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("Reconciliation failed", zap.Error(err))
+	}
+	return reconcile.Result{}, err
+}
+
+func (r *Reconciler) reconcile(log *zap.SugaredLogger, request reconcile.Request) error {
+	seedClient, ok := r.seedClients[request.Namespace]
+	if !ok {
+		log.Errorw("Got request for seed we don't have a client for", "seed", request.Namespace)
+		// The clients are inserted during controller initialzation, so there is no point in retrying
+		return nil
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := seedClient.Get(r.ctx, types.NamespacedName{Name: request.Name}, cluster); err != nil {
+		if controllerutil.IsCacheNotStarted(err) {
+			return err
+		}
+
+		if kubeapierrors.IsNotFound(err) {
+			log.Debug("Could not find cluster")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get cluster %s from seed %s: %v", request.Name, request.Namespace, err)
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		log.Debugw(
+			"Skipping because the cluster has a different worker name set",
+			"cluster-worker-name", cluster.Labels[kubermaticv1.WorkerNameLabelKey],
+		)
+		return nil
+	}
+
+	if cluster.Spec.Pause {
+		log.Debug("Skipping cluster reconciling because it was set to paused")
+		return nil
+	}
+
+	// The ConfigMap gets removed together with the cluster namespace
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		return nil
+	}
+
+	templates := &kubermaticv1.ConstraintTemplateList{}
+	if err := r.client.List(r.ctx, templates); err != nil {
+		return fmt.Errorf("failed to list constraint templates: %v", err)
+	}
+
+	constraints := &kubermaticv1.ConstraintList{}
+	if err := r.client.List(r.ctx, constraints); err != nil {
+		return fmt.Errorf("failed to list constraints: %v", err)
+	}
+
+	if err := reconciling.ReconcileConfigMaps(
+		r.ctx,
+		[]reconciling.NamedConfigMapCreatorGetter{gatekeeper.PoliciesConfigMapCreator(templates.Items, buildConstraintsForCluster(cluster, constraints))},
+		cluster.Status.NamespaceName,
+		seedClient,
+	); err != nil {
+		return fmt.Errorf("failed to reconcile gatekeeper policies configmap: %v", err)
+	}
+
+	return nil
+}
+
+func buildConstraintsForCluster(cluster *kubermaticv1.Cluster, list *kubermaticv1.ConstraintList) []kubermaticv1.Constraint {
+	var clusterConstraints []kubermaticv1.Constraint
+	for _, item := range list.Items {
+		if item.DeletionTimestamp == nil && item.AppliesToCluster(cluster) {
+			clusterConstraints = append(clusterConstraints, item)
+		}
+	}
+
+	return clusterConstraints
+}
+
+// enqueueAllClusters enqueues all clusters
+func enqueueAllClusters(clients map[string]ctrlruntimeclient.Client, workerSelector labels.Selector) *handler.EnqueueRequestsFromMapFunc {
+	return &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		var requests []reconcile.Request
+
+		listOpts := &ctrlruntimeclient.ListOptions{
+			LabelSelector: workerSelector,
+		}
+
+		for seedName, client := range clients {
+			clusterList := &kubermaticv1.ClusterList{}
+			if err := client.List(context.Background(), clusterList, listOpts); err != nil {
+				utilruntime.HandleError(fmt.Errorf("failed to list Clusters in seed %s: %v", seedName, err))
+				continue
+			}
+			for _, cluster := range clusterList.Items {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: seedName,
+					Name:      cluster.Name,
+				}})
+			}
+		}
+
+		return requests
+	})}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeepersynchronizer
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func genConstraint(name, projectID string, clusters ...string) *kubermaticv1.Constraint {
+	return &kubermaticv1.Constraint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: projectID},
+		},
+		Spec: kubermaticv1.ConstraintSpec{
+			Name:               name,
+			ConstraintTemplate: "k8srequiredlabels",
+			Clusters:           clusters,
+		},
+	}
+}
+
+func TestReconcilePoliciesConfigMap(t *testing.T) {
+	seedClient := fake.NewFakeClient(
+		&kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "test_cluster_1",
+				Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+			},
+			Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test_cluster_1"},
+		},
+	)
+	reconciler := &Reconciler{
+		ctx: context.Background(),
+		log: kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		client: fake.NewFakeClient(
+			&kubermaticv1.ConstraintTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8srequiredlabels"},
+				Spec:       kubermaticv1.ConstraintTemplateSpec{Kind: "K8sRequiredLabels"},
+			},
+			genConstraint("project-wide", "my-project"),
+			genConstraint("this-cluster", "my-project", "test_cluster_1"),
+			genConstraint("other-cluster", "my-project", "test_cluster_2"),
+			genConstraint("other-project", "other-project"),
+		),
+		seedClients: map[string]ctrlruntimeclient.Client{"seed_test": seedClient},
+	}
+
+	if _, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "seed_test", Name: "test_cluster_1"}}); err != nil {
+		t.Fatalf("failed reconciling test: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := seedClient.Get(context.Background(), types.NamespacedName{Namespace: "cluster-test_cluster_1", Name: gatekeeper.PoliciesConfigMapName}, cm); err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	templates, constraints, err := gatekeeper.PoliciesFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("failed to read configmap: %v", err)
+	}
+
+	if len(templates) != 1 || templates[0].Name != "k8srequiredlabels" {
+		t.Errorf("expected the k8srequiredlabels template, got %+v", templates)
+	}
+	var names []string
+	for _, constraint := range constraints {
+		names = append(names, constraint.Name)
+	}
+	if len(names) != 2 || names[0] != "project-wide" || names[1] != "this-cluster" {
+		t.Errorf("expected constraints [project-wide this-cluster], got %v", names)
+	}
+}
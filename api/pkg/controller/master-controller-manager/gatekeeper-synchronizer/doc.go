@@ -0,0 +1,22 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+The gatekeeper-synchronizer controller is responsible for synchronizing the constraint templates
+and the constraints of a cluster's project into a ConfigMap in the cluster namespace. From there,
+the usercluster controller synchronizes them into the usercluster once the gatekeeper addon is installed.
+*/
+package gatekeepersynchronizer
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedhealth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/kubermatic/kubermatic/api/pkg/controller/util/predicate"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ControllerName is the name of this very controller.
+	ControllerName = "seed-health-controller"
+
+	// checkInterval is the time between two health checks of the same seed.
+	checkInterval = time.Minute
+
+	// certificateExpiryThreshold is the time before expiry from which on
+	// certificates are reported as invalid.
+	certificateExpiryThreshold = 30 * 24 * time.Hour
+)
+
+var (
+	seedConditionMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "master_controller_manager",
+		Name:      "seed_condition",
+		Help:      "The result of a seed health check, 1 if the condition is true and 0 otherwise",
+	}, []string{"seed", "condition"})
+
+	seedCertificateExpiryMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubermatic",
+		Subsystem: "master_controller_manager",
+		Name:      "seed_certificate_expiry_timestamp_seconds",
+		Help:      "The unix timestamp at which the first certificate of the seed expires",
+	}, []string{"seed"})
+)
+
+func init() {
+	prometheus.MustRegister(seedConditionMetric)
+	prometheus.MustRegister(seedCertificateExpiryMetric)
+}
+
+// Add creates a new Seed-Health controller and sets up Watches
+func Add(
+	ctx context.Context,
+	mgr manager.Manager,
+	numWorkers int,
+	log *zap.SugaredLogger,
+	namespace string,
+	seedKubeconfigGetter provider.SeedKubeconfigGetter,
+) error {
+	reconciler := &Reconciler{
+		Client:               mgr.GetClient(),
+		ctx:                  ctx,
+		log:                  log.Named(ControllerName),
+		seedKubeconfigGetter: seedKubeconfigGetter,
+		seedClientGetter:     provider.SeedClientGetterFactory(seedKubeconfigGetter),
+		versionGetter:        serverVersion,
+		certificatesGetter:   seedCertificates,
+	}
+
+	ctrlOptions := controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers}
+	c, err := controller.New(ControllerName, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+
+	// watch all seeds in the given namespace; status updates do not change the generation,
+	// so the periodic checks are not re-triggered by our own status patches
+	if err := c.Watch(
+		&source.Kind{Type: &kubermaticv1.Seed{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.ByNamespace(namespace),
+		ctrlpredicate.GenerationChangedPredicate{},
+	); err != nil {
+		return fmt.Errorf("failed to create watcher: %v", err)
+	}
+
+	return nil
+}
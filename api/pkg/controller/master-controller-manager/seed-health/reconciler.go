@@ -0,0 +1,255 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedhealth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	operatorcommon "github.com/kubermatic/kubermatic/api/pkg/controller/operator/common"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/operator/seed/resources/nodeportproxy"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1/helper"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// conditionTypes are all conditions set by this controller.
+var conditionTypes = []kubermaticv1.SeedConditionType{
+	kubermaticv1.SeedConditionAPIReachable,
+	kubermaticv1.SeedConditionSeedControllerManagerReady,
+	kubermaticv1.SeedConditionNodeportProxyReady,
+	kubermaticv1.SeedConditionCertificatesValid,
+}
+
+// Reconciler checks the health of seed clusters and stores the
+// results in the Seed status.
+type Reconciler struct {
+	ctrlruntimeclient.Client
+
+	seedKubeconfigGetter provider.SeedKubeconfigGetter
+	seedClientGetter     provider.SeedClientGetter
+	versionGetter        func(cfg *rest.Config) (string, error)
+	certificatesGetter   func(cfg *rest.Config) ([]*x509.Certificate, error)
+	log                  *zap.SugaredLogger
+	ctx                  context.Context
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.With("seed", request.Name)
+	logger.Debug("Checking seed health")
+
+	seed := &kubermaticv1.Seed{}
+	if err := r.Get(r.ctx, request.NamespacedName, seed); err != nil {
+		if kerrors.IsNotFound(err) {
+			for _, conditionType := range conditionTypes {
+				seedConditionMetric.DeleteLabelValues(request.Name, string(conditionType))
+			}
+			seedCertificateExpiryMetric.DeleteLabelValues(request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get seed: %v", err)
+	}
+
+	if seed.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	oldSeed := seed.DeepCopy()
+	r.checkSeed(seed, logger)
+	seed.Status.LastHeartbeatTime = metav1.Now()
+
+	if err := r.Status().Patch(r.ctx, seed, ctrlruntimeclient.MergeFrom(oldSeed)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update seed status: %v", err)
+	}
+
+	for _, condition := range seed.Status.Conditions {
+		value := 0.0
+		if condition.Status == corev1.ConditionTrue {
+			value = 1
+		}
+		seedConditionMetric.WithLabelValues(seed.Name, string(condition.Type)).Set(value)
+	}
+
+	return reconcile.Result{RequeueAfter: checkInterval}, nil
+}
+
+// checkSeed runs all health checks and sets the corresponding conditions. Checks
+// that depend on a reachable API server are set to Unknown if it cannot be reached.
+func (r *Reconciler) checkSeed(seed *kubermaticv1.Seed, logger *zap.SugaredLogger) {
+	setUnknown := func(reason string, conditionTypes ...kubermaticv1.SeedConditionType) {
+		for _, conditionType := range conditionTypes {
+			helper.SetSeedCondition(seed, conditionType, corev1.ConditionUnknown, reason, "")
+		}
+	}
+
+	cfg, err := r.seedKubeconfigGetter(seed)
+	if err != nil {
+		logger.Debugw("Failed to get kubeconfig", zap.Error(err))
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionAPIReachable, corev1.ConditionFalse, "KubeconfigUnavailable", err.Error())
+		setUnknown("APIUnreachable", kubermaticv1.SeedConditionSeedControllerManagerReady, kubermaticv1.SeedConditionNodeportProxyReady, kubermaticv1.SeedConditionCertificatesValid)
+		return
+	}
+
+	version, err := r.versionGetter(cfg)
+	if err != nil {
+		logger.Debugw("Failed to reach API server", zap.Error(err))
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionAPIReachable, corev1.ConditionFalse, "Unreachable", err.Error())
+		setUnknown("APIUnreachable", kubermaticv1.SeedConditionSeedControllerManagerReady, kubermaticv1.SeedConditionNodeportProxyReady, kubermaticv1.SeedConditionCertificatesValid)
+		return
+	}
+	seed.Status.KubernetesVersion = version
+	helper.SetSeedCondition(seed, kubermaticv1.SeedConditionAPIReachable, corev1.ConditionTrue, "", "")
+
+	client, err := r.seedClientGetter(seed)
+	if err != nil {
+		setUnknown("ClientUnavailable", kubermaticv1.SeedConditionSeedControllerManagerReady, kubermaticv1.SeedConditionNodeportProxyReady)
+	} else {
+		r.checkDeployment(seed, client, kubermaticv1.SeedConditionSeedControllerManagerReady, operatorcommon.SeedControllerManagerDeploymentName)
+
+		if seed.Spec.NodeportProxy.Disable {
+			helper.SetSeedCondition(seed, kubermaticv1.SeedConditionNodeportProxyReady, corev1.ConditionTrue, "Disabled", "the nodeport-proxy is disabled for this seed")
+		} else {
+			r.checkDeployment(seed, client, kubermaticv1.SeedConditionNodeportProxyReady, nodeportproxy.EnvoyDeploymentName)
+		}
+	}
+
+	r.checkCertificates(seed, cfg)
+}
+
+// checkDeployment sets the given condition depending on whether the deployment
+// in the seed's namespace has available replicas.
+func (r *Reconciler) checkDeployment(seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, conditionType kubermaticv1.SeedConditionType, name string) {
+	deployment := &appsv1.Deployment{}
+	if err := client.Get(r.ctx, types.NamespacedName{Namespace: seed.Namespace, Name: name}, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			helper.SetSeedCondition(seed, conditionType, corev1.ConditionFalse, "NotFound", fmt.Sprintf("deployment %s/%s does not exist", seed.Namespace, name))
+			return
+		}
+		helper.SetSeedCondition(seed, conditionType, corev1.ConditionUnknown, "RequestFailed", err.Error())
+		return
+	}
+
+	if deployment.Status.AvailableReplicas == 0 {
+		helper.SetSeedCondition(seed, conditionType, corev1.ConditionFalse, "NotAvailable", fmt.Sprintf("deployment %s/%s has no available replicas", seed.Namespace, name))
+		return
+	}
+
+	helper.SetSeedCondition(seed, conditionType, corev1.ConditionTrue, "", "")
+}
+
+// checkCertificates reports certificates which are expired or will expire soon.
+func (r *Reconciler) checkCertificates(seed *kubermaticv1.Seed, cfg *rest.Config) {
+	certs, err := r.certificatesGetter(cfg)
+	if err != nil {
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionCertificatesValid, corev1.ConditionUnknown, "RequestFailed", err.Error())
+		return
+	}
+	if len(certs) == 0 {
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionCertificatesValid, corev1.ConditionUnknown, "NoCertificates", "no certificates were found")
+		return
+	}
+
+	first := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+	seedCertificateExpiryMetric.WithLabelValues(seed.Name).Set(float64(first.NotAfter.Unix()))
+
+	now := time.Now()
+	switch {
+	case now.After(first.NotAfter):
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionCertificatesValid, corev1.ConditionFalse, "CertificateExpired",
+			fmt.Sprintf("certificate %q expired at %s", first.Subject.CommonName, first.NotAfter.UTC().Format(time.RFC3339)))
+	case now.Add(certificateExpiryThreshold).After(first.NotAfter):
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionCertificatesValid, corev1.ConditionFalse, "CertificateExpiring",
+			fmt.Sprintf("certificate %q expires at %s", first.Subject.CommonName, first.NotAfter.UTC().Format(time.RFC3339)))
+	default:
+		helper.SetSeedCondition(seed, kubermaticv1.SeedConditionCertificatesValid, corev1.ConditionTrue, "", "")
+	}
+}
+
+// serverVersion returns the version of the seed's API server.
+func serverVersion(cfg *rest.Config) (string, error) {
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+	info, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return info.GitVersion, nil
+}
+
+// seedCertificates returns the client certificate from the kubeconfig and the
+// certificates the API server presents.
+func seedCertificates(cfg *rest.Config) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	if len(cfg.TLSClientConfig.CertData) > 0 {
+		clientCerts, err := certutil.ParseCertsPEM(cfg.TLSClientConfig.CertData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %v", err)
+		}
+		certs = append(certs, clientCerts...)
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TLS config: %v", err)
+	}
+	if tlsConfig == nil {
+		return certs, nil
+	}
+
+	serverURL, _, err := rest.DefaultServerURL(cfg.Host, cfg.APIPath, schema.GroupVersion{}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server address: %v", err)
+	}
+	address := serverURL.Host
+	if serverURL.Port() == "" {
+		address = net.JoinHostPort(address, "443")
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", address, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to API server: %v", err)
+	}
+	defer conn.Close()
+
+	return append(certs, conn.ConnectionState().PeerCertificates...), nil
+}
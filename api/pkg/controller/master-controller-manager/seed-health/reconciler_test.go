@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedhealth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	operatorcommon "github.com/kubermatic/kubermatic/api/pkg/controller/operator/common"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/operator/seed/resources/nodeportproxy"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func genDeployment(name string, availableReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kubermatic",
+		},
+		Status: appsv1.DeploymentStatus{
+			AvailableReplicas: availableReplicas,
+		},
+	}
+}
+
+func TestReconcilingSeedHealth(t *testing.T) {
+	validCert := &x509.Certificate{NotAfter: time.Now().Add(365 * 24 * time.Hour)}
+	expiringCert := &x509.Certificate{NotAfter: time.Now().Add(24 * time.Hour)}
+
+	tests := []struct {
+		name               string
+		nodeportProxyOff   bool
+		versionErr         error
+		certs              []*x509.Certificate
+		seedObjects        []runtime.Object
+		expectedVersion    string
+		expectedConditions map[kubermaticv1.SeedConditionType]corev1.ConditionStatus
+	}{
+		{
+			name:  "Healthy seed",
+			certs: []*x509.Certificate{validCert},
+			seedObjects: []runtime.Object{
+				genDeployment(operatorcommon.SeedControllerManagerDeploymentName, 2),
+				genDeployment(nodeportproxy.EnvoyDeploymentName, 3),
+			},
+			expectedVersion: "v1.17.4",
+			expectedConditions: map[kubermaticv1.SeedConditionType]corev1.ConditionStatus{
+				kubermaticv1.SeedConditionAPIReachable:               corev1.ConditionTrue,
+				kubermaticv1.SeedConditionSeedControllerManagerReady: corev1.ConditionTrue,
+				kubermaticv1.SeedConditionNodeportProxyReady:         corev1.ConditionTrue,
+				kubermaticv1.SeedConditionCertificatesValid:          corev1.ConditionTrue,
+			},
+		},
+		{
+			name:       "Unreachable seed",
+			versionErr: errors.New("connection refused"),
+			expectedConditions: map[kubermaticv1.SeedConditionType]corev1.ConditionStatus{
+				kubermaticv1.SeedConditionAPIReachable:               corev1.ConditionFalse,
+				kubermaticv1.SeedConditionSeedControllerManagerReady: corev1.ConditionUnknown,
+				kubermaticv1.SeedConditionNodeportProxyReady:         corev1.ConditionUnknown,
+				kubermaticv1.SeedConditionCertificatesValid:          corev1.ConditionUnknown,
+			},
+		},
+		{
+			name:  "Missing components and expiring certificates",
+			certs: []*x509.Certificate{validCert, expiringCert},
+			seedObjects: []runtime.Object{
+				genDeployment(operatorcommon.SeedControllerManagerDeploymentName, 0),
+			},
+			expectedVersion: "v1.17.4",
+			expectedConditions: map[kubermaticv1.SeedConditionType]corev1.ConditionStatus{
+				kubermaticv1.SeedConditionAPIReachable:               corev1.ConditionTrue,
+				kubermaticv1.SeedConditionSeedControllerManagerReady: corev1.ConditionFalse,
+				kubermaticv1.SeedConditionNodeportProxyReady:         corev1.ConditionFalse,
+				kubermaticv1.SeedConditionCertificatesValid:          corev1.ConditionFalse,
+			},
+		},
+		{
+			name:             "Disabled nodeport-proxy is not required",
+			nodeportProxyOff: true,
+			certs:            []*x509.Certificate{validCert},
+			seedObjects: []runtime.Object{
+				genDeployment(operatorcommon.SeedControllerManagerDeploymentName, 1),
+			},
+			expectedVersion: "v1.17.4",
+			expectedConditions: map[kubermaticv1.SeedConditionType]corev1.ConditionStatus{
+				kubermaticv1.SeedConditionAPIReachable:               corev1.ConditionTrue,
+				kubermaticv1.SeedConditionSeedControllerManagerReady: corev1.ConditionTrue,
+				kubermaticv1.SeedConditionNodeportProxyReady:         corev1.ConditionTrue,
+				kubermaticv1.SeedConditionCertificatesValid:          corev1.ConditionTrue,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seed := &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-seed",
+					Namespace: "kubermatic",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					NodeportProxy: kubermaticv1.NodeportProxyConfig{
+						Disable: test.nodeportProxyOff,
+					},
+				},
+			}
+			masterClient := ctrlruntimefake.NewFakeClientWithScheme(scheme.Scheme, seed)
+			seedClient := ctrlruntimefake.NewFakeClientWithScheme(scheme.Scheme, test.seedObjects...)
+			ctx := context.Background()
+
+			reconciler := Reconciler{
+				Client: masterClient,
+				log:    zap.NewNop().Sugar(),
+				ctx:    ctx,
+				seedKubeconfigGetter: func(seed *kubermaticv1.Seed) (*rest.Config, error) {
+					return &rest.Config{}, nil
+				},
+				seedClientGetter: func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
+					return seedClient, nil
+				},
+				versionGetter: func(cfg *rest.Config) (string, error) {
+					if test.versionErr != nil {
+						return "", test.versionErr
+					}
+					return "v1.17.4", nil
+				},
+				certificatesGetter: func(cfg *rest.Config) ([]*x509.Certificate, error) {
+					return test.certs, nil
+				},
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kubermatic", Name: "my-seed"}}
+			result, err := reconciler.Reconcile(request)
+			if err != nil {
+				t.Fatalf("reconciling failed: %v", err)
+			}
+			if result.RequeueAfter != checkInterval {
+				t.Errorf("expected the seed to be checked again after %v, got %v", checkInterval, result.RequeueAfter)
+			}
+
+			updated := &kubermaticv1.Seed{}
+			if err := masterClient.Get(ctx, request.NamespacedName, updated); err != nil {
+				t.Fatalf("failed to get seed: %v", err)
+			}
+
+			if updated.Status.KubernetesVersion != test.expectedVersion {
+				t.Errorf("expected version %q, got %q", test.expectedVersion, updated.Status.KubernetesVersion)
+			}
+			if updated.Status.LastHeartbeatTime.IsZero() {
+				t.Error("expected the heartbeat time to be set")
+			}
+			if len(updated.Status.Conditions) != len(test.expectedConditions) {
+				t.Fatalf("expected %d conditions, got %d: %+v", len(test.expectedConditions), len(updated.Status.Conditions), updated.Status.Conditions)
+			}
+			for _, condition := range updated.Status.Conditions {
+				if expected := test.expectedConditions[condition.Type]; condition.Status != expected {
+					t.Errorf("expected condition %s to be %s, got %s (%s)", condition.Type, expected, condition.Status, condition.Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package seedmigration contains a controller that moves the control plane of a user cluster
+from one seed to another. A migration is requested by setting spec.migration on the Cluster
+object in its current seed.
+
+The migration pauses the cluster in the source seed and deploys a kubelet agent DaemonSet into the
+user cluster. It then scales down the apiserver and takes a final etcd snapshot using the cluster's
+backup CronJob. The Cluster object, the secrets holding the cluster's identity (CAs, service account
+key, tokens) and the cloud credentials are copied to the target seed and the snapshot is restored
+into fresh etcd volumes. Once the control plane in the target seed is healthy and has its new
+address, the old address is forwarded to it. The kubelet agent reads the new address from the
+cluster-info ConfigMap and rewrites the kubelet kubeconfigs in place, so the existing nodes are
+kept. Finally, the OpenVPN client is restarted and the source Cluster and its namespace are removed.
+
+If a migration fails before the control plane in the target seed has been started, the target
+cluster is removed and the source cluster is resumed. Later failures leave both clusters in place.
+The kubelet agent does nothing while the address it is configured with is the current one.
+
+Progress is reported in status.migration of both the source and the target Cluster.
+*/
+package seedmigration
@@ -0,0 +1,644 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedmigration
+
+import (
+	"fmt"
+	"strings"
+
+	backupcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/backup"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// openVPNClientDeploymentName is the name of the OpenVPN client Deployment in the user cluster,
+	// it is installed by the openvpn addon
+	openVPNClientDeploymentName = "openvpn-client"
+
+	// kubeletAgentName is the name of the DaemonSet in the user cluster that repoints the kubelets
+	// to the control plane in the target seed
+	kubeletAgentName  = "seed-migration-kubelet-agent"
+	kubeletAgentImage = "quay.io/kubermatic/util:1.3.4"
+
+	migrationJobLabelValue = "etcd-migration"
+	etcdDataVolumeName     = "data"
+)
+
+// copiedSecrets are the secrets that make up the identity of a cluster. Everything else is
+// derived from them by the seed-controller-manager of the target seed.
+var copiedSecrets = []string{
+	resources.CASecretName,
+	resources.FrontProxyCASecretName,
+	resources.ServiceAccountKeySecretName,
+	resources.TokensSecretName,
+	resources.ViewerTokenSecretName,
+	resources.OpenVPNCASecretName,
+}
+
+// scaleDownApiserver scales the apiserver of the source cluster to zero and returns whether
+// all of its pods are gone.
+func (r *reconciler) scaleDownApiserver(m *migration) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: m.source.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := m.sourceClient.Get(r.ctx, key, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get apiserver Deployment: %v", err)
+	}
+
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		oldDeployment := deployment.DeepCopy()
+		deployment.Spec.Replicas = utilpointer.Int32Ptr(0)
+		if err := m.sourceClient.Patch(r.ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+			return false, fmt.Errorf("failed to scale down apiserver: %v", err)
+		}
+		return false, nil
+	}
+
+	return deployment.Status.Replicas == 0, nil
+}
+
+// ensureSnapshotJob creates a one-off Job from the backup CronJob of the source cluster, so the
+// snapshot ends up in the same backup store as the regular backups.
+func (r *reconciler) ensureSnapshotJob(m *migration) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: fmt.Sprintf("etcd-migration-%s", m.source.Name)}
+	err := m.sourceClient.Get(r.ctx, key, job)
+	if err == nil {
+		return job, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get etcd snapshot Job: %v", err)
+	}
+
+	cronJob := &batchv1beta1.CronJob{}
+	cronJobKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backupcontroller.CronJobName(m.source.Name)}
+	if err := m.sourceClient.Get(r.ctx, cronJobKey, cronJob); err != nil {
+		return nil, err
+	}
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			Labels:          map[string]string{resources.AppLabelKey: migrationJobLabelValue},
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(m.source)},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+	job.Spec.BackoffLimit = utilpointer.Int32Ptr(3)
+
+	if err := m.sourceClient.Create(r.ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create etcd snapshot Job: %v", err)
+	}
+	return job, nil
+}
+
+func (r *reconciler) ensureTargetNamespace(m *migration) error {
+	ns := &corev1.Namespace{}
+	if err := m.targetClient.Get(r.ctx, types.NamespacedName{Name: m.target.Status.NamespaceName}, ns); err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace in target seed: %v", err)
+	}
+
+	ns = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            m.target.Status.NamespaceName,
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(m.target)},
+		},
+	}
+	if err := m.targetClient.Create(r.ctx, ns); err != nil {
+		return fmt.Errorf("failed to create namespace in target seed: %v", err)
+	}
+	return nil
+}
+
+// copySecrets copies the secrets of the cluster namespace and the cloud credentials to the
+// target seed. Secrets that do not exist in the source seed are skipped.
+func (r *reconciler) copySecrets(m *migration) error {
+	for _, name := range copiedSecrets {
+		source := types.NamespacedName{Namespace: m.source.Status.NamespaceName, Name: name}
+		target := types.NamespacedName{Namespace: m.target.Status.NamespaceName, Name: name}
+		if err := r.copySecret(m, source, target, true); err != nil {
+			return err
+		}
+	}
+
+	// Clusters with credentials inlined in their spec have no credentials Secret
+	if m.source.GetSecretName() == "" {
+		return nil
+	}
+	credentials := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: m.source.GetSecretName()}
+	return r.copySecret(m, credentials, credentials, false)
+}
+
+func (r *reconciler) copySecret(m *migration, sourceKey, targetKey types.NamespacedName, ownedByCluster bool) error {
+	source := &corev1.Secret{}
+	if err := m.sourceClient.Get(r.ctx, sourceKey, source); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Secret %s: %v", sourceKey, err)
+	}
+
+	target := &corev1.Secret{}
+	if err := m.targetClient.Get(r.ctx, targetKey, target); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Secret %s in target seed: %v", targetKey, err)
+		}
+
+		target = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        targetKey.Name,
+				Namespace:   targetKey.Namespace,
+				Labels:      source.Labels,
+				Annotations: source.Annotations,
+			},
+			Type: source.Type,
+			Data: source.Data,
+		}
+		if ownedByCluster {
+			target.OwnerReferences = []metav1.OwnerReference{resources.GetClusterRef(m.target)}
+		}
+		if err := m.targetClient.Create(r.ctx, target); err != nil {
+			return fmt.Errorf("failed to create Secret %s in target seed: %v", targetKey, err)
+		}
+		return nil
+	}
+
+	oldTarget := target.DeepCopy()
+	target.Data = source.Data
+	if err := m.targetClient.Patch(r.ctx, target, ctrlruntimeclient.MergeFrom(oldTarget)); err != nil {
+		return fmt.Errorf("failed to update Secret %s in target seed: %v", targetKey, err)
+	}
+	return nil
+}
+
+// ensureRestoreJobs restores the etcd snapshot into the volumes of all etcd members, before the
+// etcd StatefulSet is created in the target seed. The StatefulSet adopts the volumes by their name.
+func (r *reconciler) ensureRestoreJobs(m *migration) ([]*batchv1.Job, error) {
+	namespace := m.target.Status.NamespaceName
+
+	// The restore container usually needs credentials for the backup store, which are kept
+	// in the kube-system namespace like the ones of the backup container.
+	for _, name := range referencedSecrets(r.restoreContainer) {
+		key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}
+		if err := r.copySecretWithinTarget(m, key, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	volumeSpec, err := r.etcdVolumeSpec(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*batchv1.Job
+	for i := 0; i < resources.EtcdClusterSize; i++ {
+		member := fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, i)
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("%s-%s", etcdDataVolumeName, member),
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(m.target)},
+			},
+			Spec: *volumeSpec.DeepCopy(),
+		}
+		if err := m.targetClient.Create(r.ctx, pvc); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create etcd volume %s: %v", pvc.Name, err)
+		}
+
+		job := &batchv1.Job{}
+		key := types.NamespacedName{Namespace: namespace, Name: fmt.Sprintf("etcd-restore-%s", member)}
+		if err := m.targetClient.Get(r.ctx, key, job); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get etcd restore Job: %v", err)
+			}
+			job = r.restoreJob(m.target, key, member, pvc.Name)
+			if err := m.targetClient.Create(r.ctx, job); err != nil {
+				return nil, fmt.Errorf("failed to create etcd restore Job: %v", err)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (r *reconciler) copySecretWithinTarget(m *migration, key types.NamespacedName, namespace string) error {
+	source := &corev1.Secret{}
+	if err := m.targetClient.Get(r.ctx, key, source); err != nil {
+		return fmt.Errorf("failed to get Secret %s referenced by the restore container: %v", key, err)
+	}
+	target := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       namespace,
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(m.target)},
+		},
+		Type: source.Type,
+		Data: source.Data,
+	}
+	if err := m.targetClient.Create(r.ctx, target); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create Secret %s/%s: %v", namespace, key.Name, err)
+	}
+	return nil
+}
+
+// etcdVolumeSpec returns the spec of the etcd volumes in the source seed, so the restored
+// members get volumes of the same size.
+func (r *reconciler) etcdVolumeSpec(m *migration) (*corev1.PersistentVolumeClaimSpec, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	key := types.NamespacedName{
+		Namespace: m.source.Status.NamespaceName,
+		Name:      fmt.Sprintf("%s-%s-0", etcdDataVolumeName, resources.EtcdStatefulSetName),
+	}
+	if err := m.sourceClient.Get(r.ctx, key, pvc); err != nil {
+		return nil, fmt.Errorf("failed to get etcd volume in source seed: %v", err)
+	}
+
+	return &corev1.PersistentVolumeClaimSpec{
+		StorageClassName: pvc.Spec.StorageClassName,
+		AccessModes:      pvc.Spec.AccessModes,
+		Resources:        pvc.Spec.Resources,
+	}, nil
+}
+
+func (r *reconciler) restoreJob(cluster *kubermaticv1.Cluster, key types.NamespacedName, member, claimName string) *batchv1.Job {
+	restoreContainer := r.restoreContainer.DeepCopy()
+	restoreContainer.Env = append(restoreContainer.Env, corev1.EnvVar{
+		Name:  "CLUSTER",
+		Value: cluster.Name,
+	})
+
+	image := r.etcdImage
+	if !strings.Contains(image, ":") {
+		image = image + ":" + etcd.ImageTag(cluster)
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            key.Name,
+			Namespace:       key.Namespace,
+			Labels:          map[string]string{resources.AppLabelKey: migrationJobLabelValue},
+			OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(cluster)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: utilpointer.Int32Ptr(3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:  corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{*restoreContainer},
+					Containers: []corev1.Container{
+						{
+							Name:    "etcd-restore",
+							Image:   image,
+							Command: restoreCommand(key.Namespace, cluster.Name, member),
+							Env: []corev1.EnvVar{
+								{
+									Name:  "ETCDCTL_API",
+									Value: "3",
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      backupcontroller.SharedVolumeName,
+									MountPath: "/backup",
+								},
+								{
+									Name:      etcdDataVolumeName,
+									MountPath: "/var/run/etcd",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: backupcontroller.SharedVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: etcdDataVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: claimName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// restoreCommand restores the snapshot into the data directory the etcd-launcher uses for the
+// given member. It does nothing if the data directory exists already.
+func restoreCommand(namespace, token, member string) []string {
+	var initialCluster []string
+	for i := 0; i < resources.EtcdClusterSize; i++ {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s-%d=%s", resources.EtcdStatefulSetName, i, peerURL(fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, i), namespace)))
+	}
+	dataDir := fmt.Sprintf("/var/run/etcd/pod_%s", member)
+
+	script := fmt.Sprintf(`if [ -d %[1]s ]; then
+  echo "Data directory %[1]s exists already, skipping restore"
+  exit 0
+fi
+etcdctl snapshot restore /backup/snapshot.db --name %[2]s --initial-cluster %[3]s --initial-cluster-token %[4]s --initial-advertise-peer-urls %[5]s --data-dir %[1]s`,
+		dataDir, member, strings.Join(initialCluster, ","), token, peerURL(member, namespace))
+
+	return []string{"/bin/sh", "-c", script}
+}
+
+func peerURL(member, namespace string) string {
+	return fmt.Sprintf("http://%s.%s.%s.svc.cluster.local:2380", member, resources.EtcdServiceName, namespace)
+}
+
+// referencedSecrets returns the names of all secrets the container references in its environment.
+func referencedSecrets(container *corev1.Container) []string {
+	var names []string
+	for _, env := range container.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			names = append(names, env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	for _, envFrom := range container.EnvFrom {
+		if envFrom.SecretRef != nil {
+			names = append(names, envFrom.SecretRef.Name)
+		}
+	}
+	return names
+}
+
+// apiserverStopping returns whether the apiserver of the source cluster has already been
+// scaled down.
+func (r *reconciler) apiserverStopping(m *migration) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: m.source.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := m.sourceClient.Get(r.ctx, key, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get apiserver Deployment: %v", err)
+	}
+	return deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0, nil
+}
+
+// ensureKubeletAgent creates the DaemonSet that repoints the kubelets to the new control plane and
+// returns whether it runs on all nodes.
+func (r *reconciler) ensureKubeletAgent(userClusterClient ctrlruntimeclient.Client) (bool, error) {
+	desired := kubeletAgentDaemonSet()
+	daemonSet := &appsv1.DaemonSet{}
+	if err := userClusterClient.Get(r.ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, daemonSet); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get kubelet agent DaemonSet: %v", err)
+		}
+		if err := userClusterClient.Create(r.ctx, desired); err != nil {
+			return false, fmt.Errorf("failed to create kubelet agent DaemonSet: %v", err)
+		}
+		return false, nil
+	}
+
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled, nil
+}
+
+// deleteKubeletAgent removes the DaemonSet that repoints the kubelets.
+func (r *reconciler) deleteKubeletAgent(userClusterClient ctrlruntimeclient.Client) error {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: kubeletAgentName},
+	}
+	if err := userClusterClient.Delete(r.ctx, daemonSet); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete kubelet agent DaemonSet: %v", err)
+	}
+	return nil
+}
+
+// kubeletAgentDaemonSet returns the DaemonSet that rewrites the apiserver address in the kubelet
+// kubeconfigs on every node. It reads the cluster-info ConfigMap through the address the kubelet
+// is configured with and restarts the kubelet if the address published there is a different one.
+// As the apiserver certificate is only valid for the address of the seed it was issued in, the
+// request is sent with the "kubernetes" server name, which the certificates of both seeds contain.
+func kubeletAgentDaemonSet() *appsv1.DaemonSet {
+	labels := map[string]string{resources.AppLabelKey: kubeletAgentName}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kubeletAgentName,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					HostPID:     true,
+					HostNetwork: true,
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{
+						{
+							Name:    kubeletAgentName,
+							Image:   kubeletAgentImage,
+							Command: []string{"/bin/sh", "-c", kubeletAgentScript},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: utilpointer.BoolPtr(true),
+							},
+							VolumeMounts: []corev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// forwardSourceAddress points the apiserver Service of the source cluster to the control plane in
+// the target seed, so nodes that still use the old address reach the new apiserver until the
+// kubelet agent has repointed them. The source cluster is paused, so nothing reverts this.
+func (r *reconciler) forwardSourceAddress(m *migration) error {
+	key := types.NamespacedName{Namespace: m.source.Status.NamespaceName, Name: resources.ApiserverExternalServiceName}
+
+	service := &corev1.Service{}
+	if err := m.sourceClient.Get(r.ctx, key, service); err != nil {
+		return fmt.Errorf("failed to get apiserver Service: %v", err)
+	}
+	if service.Spec.Selector != nil {
+		oldService := service.DeepCopy()
+		// Without a selector, the Endpoints are no longer managed by Kubernetes
+		service.Spec.Selector = nil
+		if err := m.sourceClient.Patch(r.ctx, service, ctrlruntimeclient.MergeFrom(oldService)); err != nil {
+			return fmt.Errorf("failed to remove selector from apiserver Service: %v", err)
+		}
+	}
+
+	subsets := []corev1.EndpointSubset{
+		{
+			Addresses: []corev1.EndpointAddress{{IP: m.target.Address.IP}},
+			Ports: []corev1.EndpointPort{
+				{
+					Name:     "secure",
+					Port:     m.target.Address.Port,
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+
+	endpoints := &corev1.Endpoints{}
+	if err := m.sourceClient.Get(r.ctx, key, endpoints); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get apiserver Endpoints: %v", err)
+		}
+		endpoints = &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Subsets:    subsets,
+		}
+		if err := m.sourceClient.Create(r.ctx, endpoints); err != nil {
+			return fmt.Errorf("failed to create apiserver Endpoints: %v", err)
+		}
+		return nil
+	}
+	if equality.Semantic.DeepEqual(endpoints.Subsets, subsets) {
+		return nil
+	}
+	oldEndpoints := endpoints.DeepCopy()
+	endpoints.Subsets = subsets
+	if err := m.sourceClient.Patch(r.ctx, endpoints, ctrlruntimeclient.MergeFrom(oldEndpoints)); err != nil {
+		return fmt.Errorf("failed to update apiserver Endpoints: %v", err)
+	}
+	return nil
+}
+
+// nodesReconnected returns whether all nodes of the user cluster reported to be ready to the
+// new control plane after the given time.
+func (r *reconciler) nodesReconnected(userClusterClient ctrlruntimeclient.Client, since metav1.Time) (bool, error) {
+	nodes := &corev1.NodeList{}
+	if err := userClusterClient.List(r.ctx, nodes); err != nil {
+		return false, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	for _, node := range nodes.Items {
+		reconnected := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue && condition.LastHeartbeatTime.After(since.Time) {
+				reconnected = true
+			}
+		}
+		if !reconnected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// restartOpenVPNClient restarts the OpenVPN client in the user cluster, so it connects to the
+// OpenVPN server in the target seed. It returns whether the restarted client is available.
+func (r *reconciler) restartOpenVPNClient(userClusterClient ctrlruntimeclient.Client, rollout string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: openVPNClientDeploymentName}
+	if err := userClusterClient.Get(r.ctx, key, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get OpenVPN client Deployment: %v", err)
+	}
+
+	if deployment.Spec.Template.Annotations[MigrationAnnotation] != rollout {
+		oldDeployment := deployment.DeepCopy()
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[MigrationAnnotation] = rollout
+		if err := userClusterClient.Patch(r.ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+			return false, fmt.Errorf("failed to restart OpenVPN client: %v", err)
+		}
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas >= replicas, nil
+}
+
+func jobSucceeded(job *batchv1.Job) bool {
+	return hasJobCondition(job, batchv1.JobComplete)
+}
+
+func jobFailed(job *batchv1.Job) bool {
+	return hasJobCondition(job, batchv1.JobFailed)
+}
+
+func hasJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// kubeletAgentScript rewrites the apiserver address in the kubelet kubeconfigs and restarts the
+// kubelet once the cluster-info ConfigMap publishes a new address.
+const kubeletAgentScript = `set -u
+ca=/host/etc/kubernetes/pki/ca.crt
+while true; do
+  current="$(sed -n 's/^ *server: *//p' /host/etc/kubernetes/kubelet.conf | head -n 1)"
+  desired="$(curl -sf --max-time 10 --cacert "${ca}" --connect-to "kubernetes:443:${current#https://}" \
+    https://kubernetes/api/v1/namespaces/kube-public/configmaps/cluster-info | \
+    grep -o 'server: [^\\"]*' | head -n 1 | cut -d ' ' -f 2)"
+  if [ -n "${desired}" ] && [ "${desired}" != "${current}" ]; then
+    for kubeconfig in /host/etc/kubernetes/kubelet.conf /host/etc/kubernetes/bootstrap-kubelet.conf; do
+      if [ -f "${kubeconfig}" ]; then
+        sed -i "s#^\( *server: *\).*#\1${desired}#" "${kubeconfig}"
+      fi
+    done
+    chroot /host systemctl restart kubelet
+  fi
+  sleep 10
+done
+`
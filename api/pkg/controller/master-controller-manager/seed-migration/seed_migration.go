@@ -0,0 +1,598 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedmigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	controllerutil "github.com/kubermatic/kubermatic/api/pkg/controller/util"
+	predicateutil "github.com/kubermatic/kubermatic/api/pkg/controller/util/predicate"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/util/workerlabel"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ControllerName is the name of this controller
+	ControllerName = "kubermatic_seed_migration_controller"
+
+	// MigrationAnnotation is set on the pod template of the OpenVPN client to restart it once the
+	// control plane has been moved to the target seed.
+	MigrationAnnotation = "kubermatic.io/seed-migration"
+
+	// pollInterval is used to requeue a migration while waiting for a Job or the new control plane.
+	pollInterval = 10 * time.Second
+)
+
+// userClusterClientGetter returns a client for the user cluster, using the admin kubeconfig
+// stored in the given seed.
+type userClusterClientGetter func(seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
+
+type reconciler struct {
+	ctx                     context.Context
+	log                     *zap.SugaredLogger
+	seedClients             map[string]ctrlruntimeclient.Client
+	userClusterClientGetter userClusterClientGetter
+	// restoreContainer downloads the etcd snapshot to the shared volume. If it is nil, migrations
+	// can not be performed.
+	restoreContainer *corev1.Container
+	etcdImage        string
+}
+
+// migration bundles everything a single reconciliation of a migration works on.
+type migration struct {
+	log          *zap.SugaredLogger
+	sourceSeed   string
+	source       *kubermaticv1.Cluster
+	sourceClient ctrlruntimeclient.Client
+	target       *kubermaticv1.Cluster
+	targetClient ctrlruntimeclient.Client
+}
+
+func Add(
+	ctx context.Context,
+	masterManager manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	restoreContainer *corev1.Container,
+	etcdImage string,
+) error {
+	log = log.Named(ControllerName)
+	r := &reconciler{
+		ctx:                     ctx,
+		log:                     log,
+		seedClients:             map[string]ctrlruntimeclient.Client{},
+		userClusterClientGetter: externalUserClusterClient,
+		restoreContainer:        restoreContainer,
+		etcdImage:               etcdImage,
+	}
+
+	ctrlOpts := controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: numWorkers,
+	}
+	c, err := controller.New(ControllerName, masterManager, ctrlOpts)
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %v", err)
+	}
+
+	migratingClusters := predicateutil.Factory(func(m metav1.Object, _ runtime.Object) bool {
+		cluster, ok := m.(*kubermaticv1.Cluster)
+		return ok && cluster.Spec.Migration != nil
+	})
+
+	for seedName, seedManager := range seedManagers {
+		r.seedClients[seedName] = seedManager.GetClient()
+
+		seedClusterWatch := &source.Kind{Type: &kubermaticv1.Cluster{}}
+		if err := seedClusterWatch.InjectCache(seedManager.GetCache()); err != nil {
+			return fmt.Errorf("failed to inject cache for seed %q into watch: %v", seedName, err)
+		}
+		if err := c.Watch(seedClusterWatch, &handler.EnqueueRequestForObject{}, workerlabel.Predicates(workerName), migratingClusters); err != nil {
+			return fmt.Errorf("failed to watch clusters in seed %q: %v", seedName, err)
+		}
+	}
+
+	return nil
+}
+
+func externalUserClusterClient(seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+	provider, err := clusterclient.NewExternal(seedClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user cluster client provider: %v", err)
+	}
+	return provider.GetClient(cluster)
+}
+
+func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Processing")
+
+	result, err := r.reconcile(log, request.Name)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("ReconcilingError", zap.Error(err))
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *reconciler) reconcile(log *zap.SugaredLogger, clusterName string) (*reconcile.Result, error) {
+	m, err := r.getMigration(log, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		log.Debug("Cluster is not being migrated")
+		return nil, nil
+	}
+	if m.source.DeletionTimestamp != nil {
+		log.Debug("Cluster is being deleted, not migrating it")
+		return nil, nil
+	}
+
+	status := m.source.Status.Migration
+	if status != nil && status.IsFinished() {
+		return nil, nil
+	}
+
+	// A migration request that does not match the recorded progress starts a new migration
+	spec := m.source.Spec.Migration
+	if status == nil || status.TargetSeed != spec.TargetSeed || status.TargetDatacenter != spec.TargetDatacenter {
+		return nil, r.initialize(m)
+	}
+
+	if m.targetClient == nil {
+		return nil, r.fail(m, fmt.Sprintf("target seed %q is unknown", spec.TargetSeed))
+	}
+
+	m.log = m.log.With("phase", status.Phase)
+	m.log.Debug("Reconciling migration")
+
+	switch status.Phase {
+	case kubermaticv1.ClusterMigrationPhasePending:
+		return r.reconcilePending(m)
+	case kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd:
+		return r.reconcileSnapshot(m)
+	case kubermaticv1.ClusterMigrationPhaseCopyingResources:
+		return r.reconcileCopy(m)
+	case kubermaticv1.ClusterMigrationPhaseRestoringEtcd:
+		return r.reconcileRestore(m)
+	case kubermaticv1.ClusterMigrationPhaseStartingControlPlane:
+		return r.reconcileControlPlane(m)
+	case kubermaticv1.ClusterMigrationPhaseReconnectingNodes:
+		return r.reconcileNodes(m)
+	case kubermaticv1.ClusterMigrationPhaseCleaningUp:
+		return nil, r.reconcileCleanup(m)
+	default:
+		return nil, r.fail(m, fmt.Sprintf("unknown migration phase %q", status.Phase))
+	}
+}
+
+// getMigration finds the seed that holds the cluster with a pending migration request and the
+// copy of the cluster in the target seed, if it exists already.
+func (r *reconciler) getMigration(log *zap.SugaredLogger, clusterName string) (*migration, error) {
+	var m *migration
+	for seedName, seedClient := range r.seedClients {
+		cluster := &kubermaticv1.Cluster{}
+		if err := seedClient.Get(r.ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get cluster from seed %q: %v", seedName, err)
+		}
+		if cluster.Spec.Migration == nil {
+			continue
+		}
+		m = &migration{
+			log:          log.With("source-seed", seedName, "target-seed", cluster.Spec.Migration.TargetSeed),
+			sourceSeed:   seedName,
+			source:       cluster,
+			sourceClient: seedClient,
+		}
+		break
+	}
+	if m == nil {
+		return nil, nil
+	}
+
+	targetClient, ok := r.seedClients[m.source.Spec.Migration.TargetSeed]
+	if !ok {
+		return m, nil
+	}
+	m.targetClient = targetClient
+
+	target := &kubermaticv1.Cluster{}
+	if err := targetClient.Get(r.ctx, types.NamespacedName{Name: clusterName}, target); err != nil {
+		if kerrors.IsNotFound(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to get cluster from target seed: %v", err)
+	}
+	m.target = target
+
+	return m, nil
+}
+
+func (r *reconciler) initialize(m *migration) error {
+	now := metav1.Now()
+	oldCluster := m.source.DeepCopy()
+	m.source.Status.Migration = &kubermaticv1.ClusterMigrationStatus{
+		SourceSeed:         m.sourceSeed,
+		TargetSeed:         m.source.Spec.Migration.TargetSeed,
+		TargetDatacenter:   m.source.Spec.Migration.TargetDatacenter,
+		Phase:              kubermaticv1.ClusterMigrationPhasePending,
+		StartTime:          now,
+		LastTransitionTime: now,
+	}
+	if err := m.sourceClient.Patch(r.ctx, m.source, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to initialize migration status: %v", err)
+	}
+	m.log.Info("Starting seed migration")
+	return nil
+}
+
+func (r *reconciler) reconcilePending(m *migration) (*reconcile.Result, error) {
+	if r.restoreContainer == nil {
+		return nil, r.fail(m, "no etcd restore container is configured for the master-controller-manager")
+	}
+	if m.target != nil {
+		return nil, r.fail(m, fmt.Sprintf("a cluster named %q already exists in the target seed", m.target.Name))
+	}
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd, "")
+}
+
+func (r *reconciler) reconcileSnapshot(m *migration) (*reconcile.Result, error) {
+	// Pausing the cluster keeps the seed-controller-manager from scaling the apiserver back up,
+	// so no writes happen to etcd after the snapshot has been taken.
+	if !m.source.Spec.Pause {
+		oldCluster := m.source.DeepCopy()
+		m.source.Spec.Pause = true
+		m.source.Spec.PauseReason = fmt.Sprintf("Control plane is being migrated to seed %q", m.source.Spec.Migration.TargetSeed)
+		if err := m.sourceClient.Patch(r.ctx, m.source, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to pause cluster: %v", err)
+		}
+	}
+
+	// The kubelet agent is deployed while the apiserver is still running, so it is part of the
+	// snapshot and already runs on the nodes when the control plane comes up in the target seed.
+	stopping, err := r.apiserverStopping(m)
+	if err != nil {
+		return nil, err
+	}
+	if !stopping {
+		userClusterClient, err := r.userClusterClientGetter(m.sourceClient, m.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+		}
+		ready, err := r.ensureKubeletAgent(userClusterClient)
+		if err != nil {
+			return nil, err
+		}
+		if !ready {
+			return requeue(), r.setMessage(m, "waiting for the kubelet agent to run on all nodes")
+		}
+	}
+
+	stopped, err := r.scaleDownApiserver(m)
+	if err != nil {
+		return nil, err
+	}
+	if !stopped {
+		return requeue(), r.setMessage(m, "waiting for the apiserver to stop")
+	}
+
+	job, err := r.ensureSnapshotJob(m)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, r.fail(m, "the cluster has no etcd backup CronJob to take the snapshot with")
+		}
+		return nil, err
+	}
+	switch {
+	case jobFailed(job):
+		return nil, r.fail(m, fmt.Sprintf("etcd snapshot Job %s/%s failed", job.Namespace, job.Name))
+	case !jobSucceeded(job):
+		return requeue(), r.setMessage(m, "waiting for the etcd snapshot to be stored")
+	}
+
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseCopyingResources, "")
+}
+
+func (r *reconciler) reconcileCopy(m *migration) (*reconcile.Result, error) {
+	if m.target == nil {
+		target := targetCluster(m.source)
+		if err := m.targetClient.Create(r.ctx, target); err != nil {
+			return nil, r.fail(m, fmt.Sprintf("failed to create cluster in target seed: %v", err))
+		}
+		m.target = target
+	}
+
+	if err := r.ensureTargetNamespace(m); err != nil {
+		return nil, r.fail(m, err.Error())
+	}
+	if err := r.copySecrets(m); err != nil {
+		return nil, r.fail(m, err.Error())
+	}
+
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseRestoringEtcd, "")
+}
+
+func (r *reconciler) reconcileRestore(m *migration) (*reconcile.Result, error) {
+	if m.target == nil {
+		return nil, r.fail(m, "the cluster in the target seed has disappeared")
+	}
+
+	jobs, err := r.ensureRestoreJobs(m)
+	if err != nil {
+		return nil, r.fail(m, err.Error())
+	}
+	for _, job := range jobs {
+		if jobFailed(job) {
+			return nil, r.fail(m, fmt.Sprintf("etcd restore Job %s/%s failed", job.Namespace, job.Name))
+		}
+		if !jobSucceeded(job) {
+			return requeue(), r.setMessage(m, fmt.Sprintf("waiting for etcd restore Job %s to complete", job.Name))
+		}
+	}
+
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseStartingControlPlane, "")
+}
+
+func (r *reconciler) reconcileControlPlane(m *migration) (*reconcile.Result, error) {
+	if m.target == nil {
+		return nil, fmt.Errorf("the cluster in the target seed has disappeared")
+	}
+
+	// Unpausing the target cluster makes the seed-controller-manager of the target seed bring up
+	// the control plane and sync the new cluster address.
+	if m.target.Spec.Pause {
+		oldCluster := m.target.DeepCopy()
+		m.target.Spec.Pause = false
+		m.target.Spec.PauseReason = ""
+		if err := m.targetClient.Patch(r.ctx, m.target, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to unpause cluster in target seed: %v", err)
+		}
+	}
+
+	health := m.target.Status.ExtendedHealth
+	if m.target.Address.URL == "" || m.target.Address.IP == "" ||
+		health.Apiserver != kubermaticv1.HealthStatusUp ||
+		health.Etcd != kubermaticv1.HealthStatusUp ||
+		health.Controller != kubermaticv1.HealthStatusUp {
+		return requeue(), r.setMessage(m, "waiting for the control plane in the target seed to become healthy")
+	}
+
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseReconnectingNodes, fmt.Sprintf("control plane is available at %s", m.target.Address.URL))
+}
+
+func (r *reconciler) reconcileNodes(m *migration) (*reconcile.Result, error) {
+	if m.target == nil {
+		return nil, fmt.Errorf("the cluster in the target seed has disappeared")
+	}
+
+	userClusterClient, err := r.userClusterClientGetter(m.targetClient, m.target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	// The kubelets of the existing nodes still point to the old address. It is forwarded to the new
+	// control plane, which lets the kubelet agent learn the new address and repoint the kubelets.
+	if err := r.forwardSourceAddress(m); err != nil {
+		return nil, err
+	}
+	reconnected, err := r.nodesReconnected(userClusterClient, m.source.Status.Migration.LastTransitionTime)
+	if err != nil {
+		return nil, err
+	}
+	if !reconnected {
+		return requeue(), r.setMessage(m, "waiting for all nodes to connect to the control plane in the target seed")
+	}
+	if err := r.deleteKubeletAgent(userClusterClient); err != nil {
+		return nil, err
+	}
+
+	rollout := m.source.Status.Migration.StartTime.UTC().Format(time.RFC3339)
+	connected, err := r.restartOpenVPNClient(userClusterClient, rollout)
+	if err != nil {
+		return nil, err
+	}
+	if !connected {
+		return requeue(), r.setMessage(m, "waiting for the OpenVPN client to reconnect")
+	}
+
+	return nil, r.setPhase(m, kubermaticv1.ClusterMigrationPhaseCleaningUp, "")
+}
+
+func (r *reconciler) reconcileCleanup(m *migration) error {
+	// Record the success on the target first, the source cluster is gone afterwards
+	if m.target != nil {
+		if err := r.patchMigrationStatus(m.targetClient, m.target, kubermaticv1.ClusterMigrationPhaseSucceeded, ""); err != nil {
+			return err
+		}
+	}
+
+	// Finalizers are removed, because they would tear down cloud resources and nodes that are
+	// now owned by the cluster in the target seed.
+	if err := r.deleteCluster(m.sourceClient, m.source); err != nil {
+		return fmt.Errorf("failed to delete cluster in source seed: %v", err)
+	}
+
+	m.log.Info("Seed migration succeeded")
+	return nil
+}
+
+// fail marks the migration as failed. As long as the control plane has not been started in the
+// target seed, everything created there is removed again and the source cluster is resumed.
+// Afterwards, the target cluster may already have accepted writes and nodes may have connected to
+// it, so both clusters are kept as they are and need to be looked at by an administrator.
+func (r *reconciler) fail(m *migration, message string) error {
+	m.log.Infow("Seed migration failed", "reason", message)
+
+	if controlPlaneStarted(m.source.Status.Migration.Phase) {
+		return r.setPhase(m, kubermaticv1.ClusterMigrationPhaseFailed, message)
+	}
+
+	if m.target != nil {
+		if err := r.deleteCluster(m.targetClient, m.target); err != nil {
+			return fmt.Errorf("failed to remove cluster from target seed: %v", err)
+		}
+		m.target = nil
+	}
+
+	if m.source.Spec.Pause {
+		oldCluster := m.source.DeepCopy()
+		m.source.Spec.Pause = false
+		m.source.Spec.PauseReason = ""
+		if err := m.sourceClient.Patch(r.ctx, m.source, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return fmt.Errorf("failed to resume cluster: %v", err)
+		}
+	}
+
+	return r.setPhase(m, kubermaticv1.ClusterMigrationPhaseFailed, message)
+}
+
+// controlPlaneStarted returns whether the control plane in the target seed may have been started
+// in the given phase.
+func controlPlaneStarted(phase kubermaticv1.ClusterMigrationPhase) bool {
+	switch phase {
+	case kubermaticv1.ClusterMigrationPhaseStartingControlPlane,
+		kubermaticv1.ClusterMigrationPhaseReconnectingNodes,
+		kubermaticv1.ClusterMigrationPhaseCleaningUp:
+		return true
+	default:
+		return false
+	}
+}
+
+// setPhase records the new phase on the source cluster and mirrors it to the target cluster.
+func (r *reconciler) setPhase(m *migration, phase kubermaticv1.ClusterMigrationPhase, message string) error {
+	if err := r.patchMigrationStatus(m.sourceClient, m.source, phase, message); err != nil {
+		return err
+	}
+	if m.target != nil {
+		return r.patchMigrationStatus(m.targetClient, m.target, phase, message)
+	}
+	return nil
+}
+
+// setMessage updates the message of the current phase.
+func (r *reconciler) setMessage(m *migration, message string) error {
+	return r.setPhase(m, m.source.Status.Migration.Phase, message)
+}
+
+func (r *reconciler) patchMigrationStatus(client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterMigrationPhase, message string) error {
+	status := cluster.Status.Migration
+	if status == nil {
+		return fmt.Errorf("cluster %q has no migration status", cluster.Name)
+	}
+	if status.Phase == phase && status.Message == message {
+		return nil
+	}
+
+	oldCluster := cluster.DeepCopy()
+	if status.Phase != phase {
+		status.LastTransitionTime = metav1.Now()
+	}
+	status.Phase = phase
+	status.Message = message
+
+	if err := client.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update migration status: %v", err)
+	}
+	return nil
+}
+
+func (r *reconciler) deleteCluster(client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+	if len(cluster.Finalizers) > 0 {
+		oldCluster := cluster.DeepCopy()
+		cluster.Finalizers = nil
+		if err := client.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return fmt.Errorf("failed to remove finalizers: %v", err)
+		}
+	}
+
+	if cluster.Status.NamespaceName != "" {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.Status.NamespaceName}}
+		if err := client.Delete(r.ctx, ns); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete namespace %q: %v", ns.Name, err)
+		}
+	}
+
+	if err := client.Delete(r.ctx, cluster); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// targetCluster returns the copy of the given cluster that is created in the target seed. It is
+// created paused, so its control plane is not started before etcd has been restored.
+func targetCluster(source *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	target := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Labels:      source.Labels,
+			Annotations: source.Annotations,
+			Finalizers:  source.Finalizers,
+		},
+		Spec:   *source.Spec.DeepCopy(),
+		Status: *source.Status.DeepCopy(),
+		// The address is synced by the target seed, only the token identifies the cluster
+		Address: kubermaticv1.ClusterAddress{
+			AdminToken: source.Address.AdminToken,
+		},
+	}
+
+	target.Spec.Cloud.DatacenterName = source.Spec.Migration.TargetDatacenter
+	target.Spec.Migration = nil
+	target.Spec.Pause = true
+	target.Spec.PauseReason = fmt.Sprintf("Control plane is being migrated from seed %q", source.Status.Migration.SourceSeed)
+
+	target.Status.LastUpdated = metav1.Time{}
+	target.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{}
+	target.Status.Conditions = nil
+	target.Status.ErrorReason = nil
+	target.Status.ErrorMessage = nil
+
+	return target
+}
+
+func requeue() *reconcile.Result {
+	return &reconcile.Result{RequeueAfter: pollInterval}
+}
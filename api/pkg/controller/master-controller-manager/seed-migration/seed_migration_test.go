@@ -0,0 +1,497 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seedmigration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterName     = "migrated"
+	clusterNS       = "cluster-migrated"
+	sourceSeedName  = "us-central"
+	targetSeedName  = "europe-west"
+	targetDCName    = "europe-west-dc"
+	s3SecretName    = "s3-credentials"
+	adminTokenValue = "abcdef.0123456789abcdef"
+)
+
+type testEnv struct {
+	r                 *reconciler
+	sourceClient      ctrlruntimeclient.Client
+	targetClient      ctrlruntimeclient.Client
+	userClusterClient ctrlruntimeclient.Client
+}
+
+func newTestEnv(restoreContainer *corev1.Container) *testEnv {
+	env := &testEnv{
+		sourceClient: fakectrlruntimeclient.NewFakeClient(
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       clusterName,
+					Labels:     map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+					Finalizers: []string{"kubermatic.io/cleanup-aws-security-group"},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						DatacenterName: "us-central-dc",
+						Hetzner:        &kubermaticv1.HetznerCloudSpec{},
+					},
+					Version: *semver.NewSemverOrDie("1.17.0"),
+					Migration: &kubermaticv1.ClusterMigrationSpec{
+						TargetSeed:       targetSeedName,
+						TargetDatacenter: targetDCName,
+					},
+				},
+				Address: kubermaticv1.ClusterAddress{
+					URL:        "https://migrated.us-central.example.com:30000",
+					AdminToken: adminTokenValue,
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: clusterNS,
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+				},
+			},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: clusterNS}},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.ApiserverDeploymentName},
+				Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32Ptr(2)},
+				Status:     appsv1.DeploymentStatus{Replicas: 2},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.ApiserverExternalServiceName},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{resources.AppLabelKey: "apiserver"},
+					Ports:    []corev1.ServicePort{{Name: "secure", Port: 30000, NodePort: 30000}},
+				},
+			},
+			&batchv1beta1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "etcd-backup-" + clusterName},
+			},
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: "data-etcd-0"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: utilpointer.StringPtr("kubermatic-fast"),
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.CASecretName},
+				Data:       map[string][]byte{resources.CACertSecretKey: []byte("ca-cert")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.ServiceAccountKeySecretName},
+				Data:       map[string][]byte{resources.ServiceAccountKeySecretKey: []byte("sa-key")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: resources.KubermaticNamespace, Name: "credential-hetzner-" + clusterName},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			},
+		),
+		targetClient: fakectrlruntimeclient.NewFakeClient(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: s3SecretName},
+				Data:       map[string][]byte{"ACCESS_KEY_ID": []byte("key")},
+			},
+		),
+		userClusterClient: fakectrlruntimeclient.NewFakeClient(
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{
+							Type:              corev1.NodeReady,
+							Status:            corev1.ConditionTrue,
+							LastHeartbeatTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+						},
+					},
+				},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: openVPNClientDeploymentName},
+			},
+		),
+	}
+
+	env.r = &reconciler{
+		ctx: context.Background(),
+		log: kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		seedClients: map[string]ctrlruntimeclient.Client{
+			sourceSeedName: env.sourceClient,
+			targetSeedName: env.targetClient,
+		},
+		userClusterClientGetter: func(_ ctrlruntimeclient.Client, _ *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+			return env.userClusterClient, nil
+		},
+		restoreContainer: restoreContainer,
+		etcdImage:        "gcr.io/etcd-development/etcd",
+	}
+	return env
+}
+
+func testRestoreContainer() *corev1.Container {
+	return &corev1.Container{
+		Name:  "download",
+		Image: "kubermatic/s3-downloader",
+		Env: []corev1.EnvVar{
+			{
+				Name: "ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: s3SecretName},
+						Key:                  "ACCESS_KEY_ID",
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "etcd-backup", MountPath: "/backup"}},
+	}
+}
+
+func (e *testEnv) reconcile(t *testing.T) {
+	t.Helper()
+	if _, err := e.r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterName}}); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+}
+
+func (e *testEnv) expectPhase(t *testing.T, client ctrlruntimeclient.Client, expected kubermaticv1.ClusterMigrationPhase) *kubermaticv1.Cluster {
+	t.Helper()
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if cluster.Status.Migration == nil {
+		t.Fatalf("expected migration phase %q, but cluster has no migration status", expected)
+	}
+	if cluster.Status.Migration.Phase != expected {
+		t.Fatalf("expected migration phase %q, got %q (%s)", expected, cluster.Status.Migration.Phase, cluster.Status.Migration.Message)
+	}
+	return cluster
+}
+
+func completeJob(t *testing.T, client ctrlruntimeclient.Client, namespace, name string, conditionType batchv1.JobConditionType) {
+	t.Helper()
+	job := &batchv1.Job{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, job); err != nil {
+		t.Fatalf("failed to get Job %s/%s: %v", namespace, name, err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+	if err := client.Update(context.Background(), job); err != nil {
+		t.Fatalf("failed to update Job %s/%s: %v", namespace, name, err)
+	}
+}
+
+// runUntilRestoring drives the migration up to the point where the etcd restore Jobs have been
+// created in the target seed.
+func (e *testEnv) runUntilRestoring(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	e.reconcile(t)
+	e.reconcile(t)
+	apiserver := &appsv1.Deployment{}
+	if err := e.sourceClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	apiserver.Spec.Replicas = utilpointer.Int32Ptr(0)
+	apiserver.Status.Replicas = 0
+	if err := e.sourceClient.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	e.reconcile(t)
+	completeJob(t, e.sourceClient, metav1.NamespaceSystem, "etcd-migration-"+clusterName, batchv1.JobComplete)
+	e.reconcile(t)
+	e.reconcile(t)
+	e.reconcile(t)
+	e.expectPhase(t, e.targetClient, kubermaticv1.ClusterMigrationPhaseRestoringEtcd)
+}
+
+func TestSeedMigration(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(testRestoreContainer())
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhasePending)
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd)
+
+	// The source cluster gets paused and the kubelet agent is deployed while the apiserver still runs
+	env.reconcile(t)
+	source := env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd)
+	if !source.Spec.Pause {
+		t.Error("expected source cluster to be paused")
+	}
+	agent := &appsv1.DaemonSet{}
+	agentKey := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: kubeletAgentName}
+	if err := env.userClusterClient.Get(ctx, agentKey, agent); err != nil {
+		t.Fatalf("failed to get kubelet agent: %v", err)
+	}
+	agent.Status.DesiredNumberScheduled = 1
+	if err := env.userClusterClient.Update(ctx, agent); err != nil {
+		t.Fatalf("failed to update kubelet agent: %v", err)
+	}
+
+	env.reconcile(t)
+	apiserver := &appsv1.Deployment{}
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	if *apiserver.Spec.Replicas == 0 {
+		t.Fatal("expected apiserver to keep running until the kubelet agent is ready")
+	}
+	agent.Status.NumberReady = 1
+	if err := env.userClusterClient.Update(ctx, agent); err != nil {
+		t.Fatalf("failed to update kubelet agent: %v", err)
+	}
+
+	// The apiserver is stopped before the snapshot is taken
+	env.reconcile(t)
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 0 {
+		t.Fatalf("expected apiserver to be scaled to 0, got %d replicas", *apiserver.Spec.Replicas)
+	}
+	apiserver.Status.Replicas = 0
+	if err := env.sourceClient.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd)
+	completeJob(t, env.sourceClient, metav1.NamespaceSystem, "etcd-migration-"+clusterName, batchv1.JobComplete)
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseCopyingResources)
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseRestoringEtcd)
+	target := env.expectPhase(t, env.targetClient, kubermaticv1.ClusterMigrationPhaseRestoringEtcd)
+	if !target.Spec.Pause {
+		t.Error("expected target cluster to be created paused")
+	}
+	if target.Spec.Migration != nil {
+		t.Error("expected target cluster to have no migration request")
+	}
+	if target.Spec.Cloud.DatacenterName != targetDCName {
+		t.Errorf("expected target cluster to be in datacenter %q, got %q", targetDCName, target.Spec.Cloud.DatacenterName)
+	}
+	if target.Address.URL != "" || target.Address.AdminToken != adminTokenValue {
+		t.Errorf("expected target cluster to only keep the admin token of its address, got %+v", target.Address)
+	}
+	for _, key := range []types.NamespacedName{
+		{Namespace: clusterNS, Name: resources.CASecretName},
+		{Namespace: clusterNS, Name: resources.ServiceAccountKeySecretName},
+		{Namespace: resources.KubermaticNamespace, Name: "credential-hetzner-" + clusterName},
+	} {
+		if err := env.targetClient.Get(ctx, key, &corev1.Secret{}); err != nil {
+			t.Errorf("expected Secret %s to be copied to the target seed: %v", key, err)
+		}
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseRestoringEtcd)
+	if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: s3SecretName}, &corev1.Secret{}); err != nil {
+		t.Errorf("expected Secret referenced by the restore container to be copied: %v", err)
+	}
+	for i := 0; i < resources.EtcdClusterSize; i++ {
+		if err := env.targetClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: fmt.Sprintf("data-etcd-%d", i)}, &corev1.PersistentVolumeClaim{}); err != nil {
+			t.Errorf("expected etcd volume %d to be created: %v", i, err)
+		}
+		completeJob(t, env.targetClient, clusterNS, fmt.Sprintf("etcd-restore-etcd-%d", i), batchv1.JobComplete)
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseStartingControlPlane)
+
+	// The target seed brings up the control plane once the cluster is unpaused
+	env.reconcile(t)
+	target = env.expectPhase(t, env.targetClient, kubermaticv1.ClusterMigrationPhaseStartingControlPlane)
+	if target.Spec.Pause {
+		t.Fatal("expected target cluster to be unpaused")
+	}
+	target.Address.URL = "https://migrated.europe-west.example.com:31000"
+	target.Address.IP = "192.0.2.10"
+	target.Address.Port = 31000
+	target.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+	target.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusUp
+	target.Status.ExtendedHealth.Controller = kubermaticv1.HealthStatusUp
+	if err := env.targetClient.Update(ctx, target); err != nil {
+		t.Fatalf("failed to update target cluster: %v", err)
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseReconnectingNodes)
+
+	// The old address is forwarded to the new control plane until all nodes have been repointed
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseReconnectingNodes)
+	apiserverKey := types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverExternalServiceName}
+	service := &corev1.Service{}
+	if err := env.sourceClient.Get(ctx, apiserverKey, service); err != nil {
+		t.Fatalf("failed to get apiserver Service: %v", err)
+	}
+	if service.Spec.Selector != nil {
+		t.Errorf("expected selector to be removed from the apiserver Service, got %v", service.Spec.Selector)
+	}
+	endpoints := &corev1.Endpoints{}
+	if err := env.sourceClient.Get(ctx, apiserverKey, endpoints); err != nil {
+		t.Fatalf("failed to get apiserver Endpoints: %v", err)
+	}
+	if len(endpoints.Subsets) != 1 || endpoints.Subsets[0].Addresses[0].IP != "192.0.2.10" || endpoints.Subsets[0].Ports[0].Port != 31000 {
+		t.Errorf("expected apiserver Endpoints to point to the target control plane, got %+v", endpoints.Subsets)
+	}
+	if err := env.userClusterClient.Get(ctx, agentKey, &appsv1.DaemonSet{}); err != nil {
+		t.Errorf("expected kubelet agent to be kept while nodes are reconnecting: %v", err)
+	}
+
+	node := &corev1.Node{}
+	if err := env.userClusterClient.Get(ctx, types.NamespacedName{Name: "worker"}, node); err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+	node.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(time.Minute))
+	if err := env.userClusterClient.Update(ctx, node); err != nil {
+		t.Fatalf("failed to update node: %v", err)
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseReconnectingNodes)
+	if err := env.userClusterClient.Get(ctx, agentKey, &appsv1.DaemonSet{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected kubelet agent to be removed, got %v", err)
+	}
+	openvpn := &appsv1.Deployment{}
+	if err := env.userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: openVPNClientDeploymentName}, openvpn); err != nil {
+		t.Fatalf("failed to get OpenVPN client: %v", err)
+	}
+	if _, ok := openvpn.Spec.Template.Annotations[MigrationAnnotation]; !ok {
+		t.Error("expected OpenVPN client to be restarted")
+	}
+	openvpn.Status.UpdatedReplicas = 1
+	openvpn.Status.AvailableReplicas = 1
+	if err := env.userClusterClient.Update(ctx, openvpn); err != nil {
+		t.Fatalf("failed to update OpenVPN client: %v", err)
+	}
+
+	env.reconcile(t)
+	env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseCleaningUp)
+
+	env.reconcile(t)
+	env.expectPhase(t, env.targetClient, kubermaticv1.ClusterMigrationPhaseSucceeded)
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Name: clusterName}, &kubermaticv1.Cluster{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected source cluster to be deleted, got %v", err)
+	}
+	if err := env.sourceClient.Get(ctx, types.NamespacedName{Name: clusterNS}, &corev1.Namespace{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected source namespace to be deleted, got %v", err)
+	}
+
+	// Nothing happens once the migration is done
+	env.reconcile(t)
+	env.expectPhase(t, env.targetClient, kubermaticv1.ClusterMigrationPhaseSucceeded)
+}
+
+func TestSeedMigrationFailure(t *testing.T) {
+	testCases := []struct {
+		name             string
+		restoreContainer *corev1.Container
+		// prepare drives the migration up to the point where it fails
+		prepare func(t *testing.T, env *testEnv)
+		// controlPlaneStarted is set if the migration fails after the target control plane was started
+		controlPlaneStarted bool
+	}{
+		{
+			name:             "Migration fails without a restore container",
+			restoreContainer: nil,
+			prepare:          func(t *testing.T, env *testEnv) {},
+		},
+		{
+			name:             "Failed restore removes the target cluster and resumes the source cluster",
+			restoreContainer: testRestoreContainer(),
+			prepare: func(t *testing.T, env *testEnv) {
+				env.runUntilRestoring(t)
+				completeJob(t, env.targetClient, clusterNS, "etcd-restore-etcd-0", batchv1.JobFailed)
+			},
+		},
+		{
+			name:             "Failure after the control plane was started keeps both clusters",
+			restoreContainer: testRestoreContainer(),
+			prepare: func(t *testing.T, env *testEnv) {
+				env.runUntilRestoring(t)
+				for i := 0; i < resources.EtcdClusterSize; i++ {
+					completeJob(t, env.targetClient, clusterNS, fmt.Sprintf("etcd-restore-etcd-%d", i), batchv1.JobComplete)
+				}
+				env.reconcile(t)
+				env.reconcile(t)
+				env.expectPhase(t, env.targetClient, kubermaticv1.ClusterMigrationPhaseStartingControlPlane)
+				delete(env.r.seedClients, targetSeedName)
+			},
+			controlPlaneStarted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(tc.restoreContainer)
+			env.reconcile(t)
+			tc.prepare(t, env)
+
+			env.reconcile(t)
+			source := env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseFailed)
+			err := env.targetClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, &kubermaticv1.Cluster{})
+			if tc.controlPlaneStarted {
+				if !source.Spec.Pause {
+					t.Error("expected source cluster to stay paused")
+				}
+				if err != nil {
+					t.Errorf("expected cluster in the target seed to be kept, got %v", err)
+				}
+			} else {
+				if source.Spec.Pause {
+					t.Error("expected source cluster to be resumed")
+				}
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("expected no cluster in the target seed, got %v", err)
+				}
+			}
+
+			// A failed migration is not retried
+			env.reconcile(t)
+			env.expectPhase(t, env.sourceClient, kubermaticv1.ClusterMigrationPhaseFailed)
+		})
+	}
+}
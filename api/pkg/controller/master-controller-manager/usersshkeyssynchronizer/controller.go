@@ -29,6 +29,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/kubernetes"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+	"github.com/kubermatic/kubermatic/api/pkg/ssh"
 	"github.com/kubermatic/kubermatic/api/pkg/util/workerlabel"
 
 	corev1 "k8s.io/api/core/v1"
@@ -276,8 +277,18 @@ func updateUserSSHKeysSecrets(list []kubermaticv1.UserSSHKey) reconciling.NamedS
 		return resources.UserSSHKeys, func(existing *corev1.Secret) (secret *corev1.Secret, e error) {
 			existing.Data = map[string][]byte{}
 
+			now := time.Now()
 			for _, key := range list {
-				existing.Data[key.Name] = []byte(key.Spec.PublicKey)
+				// Expired keys are removed by the agents on the nodes as well, this
+				// only makes sure they don't get shipped to the cluster anymore.
+				if key.Spec.IsExpired(now) {
+					continue
+				}
+				data, err := ssh.NewAuthorizedKey(&key).Marshal()
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode ssh key %s: %v", key.Name, err)
+				}
+				existing.Data[key.Name] = data
 			}
 
 			existing.Type = corev1.SecretTypeOpaque
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	operatorv1alpha1 "github.com/kubermatic/kubermatic/api/pkg/crd/operator/v1alpha1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetConfigurationCondition sets or updates the condition of the given type
+// for the given seed. An empty seed name refers to the master cluster.
+func SetConfigurationCondition(
+	status *operatorv1alpha1.KubermaticConfigurationStatus,
+	conditionType operatorv1alpha1.KubermaticConfigurationConditionType,
+	seed string,
+	conditionStatus corev1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	newCondition := operatorv1alpha1.KubermaticConfigurationCondition{
+		Type:               conditionType,
+		Seed:               seed,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	for i, condition := range status.Conditions {
+		if condition.Type != conditionType || condition.Seed != seed {
+			continue
+		}
+		if condition.Status == conditionStatus {
+			newCondition.LastTransitionTime = condition.LastTransitionTime
+		}
+		status.Conditions[i] = newCondition
+		return
+	}
+
+	status.Conditions = append(status.Conditions, newCondition)
+	// Has to be sorted, otherwise we may end up creating updates that just re-arrange them.
+	sort.SliceStable(status.Conditions, func(i, j int) bool {
+		if status.Conditions[i].Seed != status.Conditions[j].Seed {
+			return status.Conditions[i].Seed < status.Conditions[j].Seed
+		}
+		return status.Conditions[i].Type < status.Conditions[j].Type
+	})
+}
+
+// RemoveSeedConditions removes all conditions belonging to the given seed.
+func RemoveSeedConditions(status *operatorv1alpha1.KubermaticConfigurationStatus, seed string) {
+	conditions := status.Conditions[:0]
+	for _, condition := range status.Conditions {
+		if condition.Seed != seed {
+			conditions = append(conditions, condition)
+		}
+	}
+	status.Conditions = conditions
+}
+
+// UpdateConfigurationStatus fetches the current KubermaticConfiguration, applies
+// modify to its status and writes it back if anything changed. Conflicts are
+// retried, as the master and the seed reconcilers update the status concurrently.
+func UpdateConfigurationStatus(
+	ctx context.Context,
+	client ctrlruntimeclient.Client,
+	name types.NamespacedName,
+	modify func(*operatorv1alpha1.KubermaticConfigurationStatus),
+) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config := &operatorv1alpha1.KubermaticConfiguration{}
+		if err := client.Get(ctx, name, config); err != nil {
+			return err
+		}
+
+		oldStatus := config.Status.DeepCopy()
+		modify(&config.Status)
+		if equality.Semantic.DeepEqual(oldStatus, &config.Status) {
+			return nil
+		}
+
+		return client.Status().Update(ctx, config)
+	})
+}
+
+// DeploymentCondition determines the condition status, reason and message
+// for the given Deployment.
+func DeploymentCondition(ctx context.Context, client ctrlruntimeclient.Client, name types.NamespacedName) (corev1.ConditionStatus, string, string) {
+	health, err := resources.HealthyDeployment(ctx, client, name, 1)
+	if err != nil {
+		return corev1.ConditionUnknown, "RequestFailed", err.Error()
+	}
+
+	switch health {
+	case kubermaticv1.HealthStatusUp:
+		return corev1.ConditionTrue, "DeploymentReady", ""
+	case kubermaticv1.HealthStatusProvisioning:
+		return corev1.ConditionFalse, "DeploymentRollingOut", fmt.Sprintf("Deployment %s is rolling out", name)
+	default:
+		return corev1.ConditionFalse, "DeploymentUnavailable", fmt.Sprintf("Deployment %s has no ready replicas", name)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,9 +83,37 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	err = r.reconcile(defaulted, logger)
 	if err != nil {
 		r.recorder.Event(config, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, err
+	if config.DeletionTimestamp == nil {
+		if err := r.reconcileStatus(config, logger); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update status: %v", err)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileStatus reports the readiness of the master components and the
+// reconciled generation in the configuration's status.
+func (r *Reconciler) reconcileStatus(config *operatorv1alpha1.KubermaticConfiguration, logger *zap.SugaredLogger) error {
+	logger.Debug("Reconciling status")
+
+	deployments := map[operatorv1alpha1.KubermaticConfigurationConditionType]string{
+		operatorv1alpha1.ConfigurationConditionAPIReady:                     kubermatic.APIDeploymentName,
+		operatorv1alpha1.ConfigurationConditionUIReady:                      kubermatic.UIDeploymentName,
+		operatorv1alpha1.ConfigurationConditionMasterControllerManagerReady: common.MasterControllerManagerDeploymentName,
+	}
+
+	return common.UpdateConfigurationStatus(r.ctx, r.Client, types.NamespacedName{Namespace: config.Namespace, Name: config.Name}, func(status *operatorv1alpha1.KubermaticConfigurationStatus) {
+		for conditionType, name := range deployments {
+			conditionStatus, reason, message := common.DeploymentCondition(r.ctx, r.Client, types.NamespacedName{Namespace: config.Namespace, Name: name})
+			common.SetConfigurationCondition(status, conditionType, "", conditionStatus, reason, message)
+		}
+
+		status.ObservedGeneration = config.Generation
+	})
 }
 
 func (r *Reconciler) reconcile(config *operatorv1alpha1.KubermaticConfiguration, logger *zap.SugaredLogger) error {
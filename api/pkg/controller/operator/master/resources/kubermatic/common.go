@@ -34,8 +34,8 @@ const (
 	serviceAccountName    = "kubermatic-master"
 	uiConfigConfigMapName = "ui-config"
 	ingressName           = "kubermatic"
-	apiDeploymentName     = "kubermatic-api"
-	uiDeploymentName      = "kubermatic-dashboard"
+	APIDeploymentName     = "kubermatic-api"
+	UIDeploymentName      = "kubermatic-dashboard"
 	apiServiceName        = "kubermatic-api"
 	uiServiceName         = "kubermatic-dashboard"
 	certificateName       = "kubermatic"
@@ -31,13 +31,13 @@ import (
 
 func uiPodLabels() map[string]string {
 	return map[string]string{
-		common.NameLabel: uiDeploymentName,
+		common.NameLabel: UIDeploymentName,
 	}
 }
 
 func UIDeploymentCreator(cfg *operatorv1alpha1.KubermaticConfiguration, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
-		return uiDeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
+		return UIDeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 			d.Spec.Replicas = cfg.Spec.UI.Replicas
 			d.Spec.Selector = &metav1.LabelSelector{
 				MatchLabels: uiPodLabels(),
@@ -90,7 +90,7 @@ func (r *Reconciler) reconcile(log *zap.SugaredLogger, seedName string) error {
 	seed, exists := seeds[seedName]
 	if !exists {
 		log.Debug("ignoring request for non-existing seed")
-		return nil
+		return r.removeSeedStatus(seedName, log)
 	}
 
 	// get pre-constructed seed client
@@ -155,7 +155,11 @@ func (r *Reconciler) reconcile(log *zap.SugaredLogger, seedName string) error {
 
 	// Seed CR inside the seed cluster was deleted
 	if seedCopy.DeletionTimestamp != nil {
-		return r.cleanupDeletedSeed(defaulted, seedCopy, seedClient, log)
+		if err := r.cleanupDeletedSeed(defaulted, seedCopy, seedClient, log); err != nil {
+			return err
+		}
+
+		return r.removeSeedStatus(seedName, log)
 	}
 
 	// make sure to use the seedCopy so the owner ref has the correct UID
@@ -166,9 +170,55 @@ func (r *Reconciler) reconcile(log *zap.SugaredLogger, seedName string) error {
 		return err
 	}
 
+	if err := r.reconcileStatus(&config, seedCopy, seedClient, log); err != nil {
+		return fmt.Errorf("failed to update KubermaticConfiguration status: %v", err)
+	}
+
 	return nil
 }
 
+// reconcileStatus reports the readiness of the components on the given seed
+// in the KubermaticConfiguration's status.
+func (r *Reconciler) reconcileStatus(config *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
+	log.Debug("reconciling status")
+
+	name := types.NamespacedName{Namespace: config.Namespace, Name: config.Name}
+
+	return common.UpdateConfigurationStatus(r.ctx, r.masterClient, name, func(status *operatorv1alpha1.KubermaticConfigurationStatus) {
+		conditionStatus, reason, message := common.DeploymentCondition(r.ctx, client, types.NamespacedName{Namespace: r.namespace, Name: common.SeedControllerManagerDeploymentName})
+		common.SetConfigurationCondition(status, operatorv1alpha1.ConfigurationConditionSeedControllerManagerReady, seed.Name, conditionStatus, reason, message)
+
+		if seed.Spec.NodeportProxy.Disable {
+			common.SetConfigurationCondition(status, operatorv1alpha1.ConfigurationConditionNodeportProxyReady, seed.Name, corev1.ConditionTrue, "Disabled", "")
+		} else {
+			conditionStatus, reason, message = common.DeploymentCondition(r.ctx, client, types.NamespacedName{Namespace: r.namespace, Name: nodeportproxy.EnvoyDeploymentName})
+			common.SetConfigurationCondition(status, operatorv1alpha1.ConfigurationConditionNodeportProxyReady, seed.Name, conditionStatus, reason, message)
+		}
+	})
+}
+
+// removeSeedStatus removes the conditions of a seed that no longer exists
+// from the KubermaticConfiguration's status.
+func (r *Reconciler) removeSeedStatus(seedName string, log *zap.SugaredLogger) error {
+	configList := &operatorv1alpha1.KubermaticConfigurationList{}
+	if err := r.masterClient.List(r.ctx, configList, &ctrlruntimeclient.ListOptions{Namespace: r.namespace}); err != nil {
+		return fmt.Errorf("failed to find KubermaticConfigurations: %v", err)
+	}
+
+	if len(configList.Items) != 1 {
+		return nil
+	}
+
+	log.Debug("removing seed from KubermaticConfiguration status")
+
+	config := configList.Items[0]
+	name := types.NamespacedName{Namespace: config.Namespace, Name: config.Name}
+
+	return common.UpdateConfigurationStatus(r.ctx, r.masterClient, name, func(status *operatorv1alpha1.KubermaticConfigurationStatus) {
+		common.RemoveSeedConditions(status, seedName)
+	})
+}
+
 func (r *Reconciler) cleanupDeletedSeed(cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, client ctrlruntimeclient.Client, log *zap.SugaredLogger) error {
 	if !kubernetes.HasAnyFinalizer(seed, common.CleanupFinalizer) {
 		return nil
@@ -236,6 +236,71 @@ func TestBasicReconciling(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "seed components are reported in the KubermaticConfiguration status",
+			seedToReconcile: "europe",
+			configuration: &operatorv1alpha1.KubermaticConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "kubermatic",
+				},
+				Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+					Ingress: operatorv1alpha1.KubermaticIngressConfiguration{
+						Domain: "example.com",
+					},
+				},
+			},
+			seedsOnMaster: []string{"europe"},
+			syncedSeeds:   sets.NewString("europe"),
+			assertion: func(test *testcase, reconciler *Reconciler) error {
+				if err := reconciler.reconcile(reconciler.log, test.seedToReconcile); err != nil {
+					return fmt.Errorf("reconciliation failed: %v", err)
+				}
+
+				configName := types.NamespacedName{Namespace: "kubermatic", Name: "test"}
+
+				config := &operatorv1alpha1.KubermaticConfiguration{}
+				must(t, reconciler.masterClient.Get(reconciler.ctx, configName, config))
+
+				expected := map[operatorv1alpha1.KubermaticConfigurationConditionType]bool{
+					operatorv1alpha1.ConfigurationConditionSeedControllerManagerReady: false,
+					operatorv1alpha1.ConfigurationConditionNodeportProxyReady:         false,
+				}
+				for _, condition := range config.Status.Conditions {
+					if condition.Seed == "europe" {
+						expected[condition.Type] = true
+					}
+				}
+				for conditionType, found := range expected {
+					if !found {
+						return fmt.Errorf("expected status to contain condition %q for seed europe, got %v", conditionType, config.Status.Conditions)
+					}
+				}
+
+				// deleting the seed should remove its conditions
+				seedClient := reconciler.seedClients["europe"]
+				seedName := types.NamespacedName{Namespace: "kubermatic", Name: "europe"}
+
+				seed := &kubermaticv1.Seed{}
+				must(t, seedClient.Get(reconciler.ctx, seedName, seed))
+				seed.DeletionTimestamp = &now
+				must(t, seedClient.Update(reconciler.ctx, seed))
+
+				if err := reconciler.reconcile(reconciler.log, test.seedToReconcile); err != nil {
+					return fmt.Errorf("reconciliation failed: %v", err)
+				}
+
+				config = &operatorv1alpha1.KubermaticConfiguration{}
+				must(t, reconciler.masterClient.Get(reconciler.ctx, configName, config))
+
+				if length := len(config.Status.Conditions); length > 0 {
+					return fmt.Errorf("status should have no conditions left over, but has %d", length)
+				}
+
+				return nil
+			},
+		},
+
 		{
 			name:            "seeds in other namespaces are ignored",
 			seedToReconcile: "other",
@@ -37,6 +37,7 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1/helper"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/machinecontroller"
 
@@ -87,6 +88,7 @@ type Reconciler struct {
 	recorder                 record.EventRecorder
 	KubeconfigProvider       KubeconfigProvider
 	nodeLocalDNSCacheEnabled bool
+	secretStores             *provider.SecretStores
 }
 
 // Add creates a new Addon controller that is responsible for
@@ -103,6 +105,7 @@ func Add(
 	overwriteRegistey string,
 	nodeLocalDNSCacheEnabled bool,
 	kubeconfigProvider KubeconfigProvider,
+	secretStores *provider.SecretStores,
 ) error {
 	log = log.Named(ControllerName)
 	client := mgr.GetClient()
@@ -119,6 +122,7 @@ func Add(
 		recorder:                 mgr.GetEventRecorderFor(ControllerName),
 		overwriteRegistry:        overwriteRegistey,
 		nodeLocalDNSCacheEnabled: nodeLocalDNSCacheEnabled,
+		secretStores:             secretStores,
 	}
 
 	ctrlOptions := controller.Options{
@@ -229,6 +233,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.IsControlPlaneHibernated() {
+		// The cluster gets enqueued again once it is resumed
+		log.Debug("Skipping because the cluster is hibernated")
+		return nil, nil
+	}
 	if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
 		log.Debug("API server is not running, trying again in 10 seconds")
 		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
@@ -312,7 +321,7 @@ func (r *Reconciler) getAddonManifests(log *zap.SugaredLogger, addon *kubermatic
 		return nil, err
 	}
 
-	credentials, err := resources.GetCredentials(resources.NewCredentialsData(context.Background(), cluster, r.Client))
+	credentials, err := resources.GetCredentials(resources.NewCredentialsData(context.Background(), cluster, r.Client, r.secretStores))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %v", err)
 	}
@@ -41,7 +41,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-const ControllerName = "kubermatic_addoninstaller_controller"
+const (
+	ControllerName = "kubermatic_addoninstaller_controller"
+
+	openVPNAddonName       = "openvpn"
+	konnectivityAddonName  = "konnectivity"
+	metricsServerAddonName = "metrics-server"
+)
 
 type Reconciler struct {
 	log              *zap.SugaredLogger
@@ -150,6 +156,15 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	} else {
 		log = log.With("clustertype", "kubernetes")
 		addonsToInstall = r.kubernetesAddons.DeepCopy()
+		if cluster.IsKonnectivityEnabled() {
+			addonsToInstall = konnectivityAddons(addonsToInstall)
+		}
+	}
+
+	if cluster.IsControlPlaneHibernated() {
+		// The cluster gets enqueued again once it is resumed
+		log.Debug("Skipping because the cluster is hibernated")
+		return nil, nil
 	}
 
 	// Wait until the Apiserver is running to ensure the namespace exists at least.
@@ -162,6 +177,22 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	return nil, r.ensureAddons(ctx, log, cluster, *addonsToInstall)
 }
 
+// konnectivityAddons swaps the OpenVPN client for the Konnectivity agent. Clusters using Konnectivity
+// also run the metrics-server inside the user cluster, as the one in the seed can not reach the nodes.
+func konnectivityAddons(addons *kubermaticv1.AddonList) *kubermaticv1.AddonList {
+	result := &kubermaticv1.AddonList{}
+	for _, addon := range addons.Items {
+		if addon.Name == openVPNAddonName || addon.Name == konnectivityAddonName || addon.Name == metricsServerAddonName {
+			continue
+		}
+		result.Items = append(result.Items, addon)
+	}
+	for _, name := range []string{konnectivityAddonName, metricsServerAddonName} {
+		result.Items = append(result.Items, kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return result
+}
+
 func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) error {
 	ensuredAddonsMap := map[string]struct{}{}
 	for _, addon := range addons.Items {
@@ -158,3 +158,39 @@ func TestCreateAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestKonnectivityAddons(t *testing.T) {
+	tests := []struct {
+		name     string
+		addons   []string
+		expected []string
+	}{
+		{
+			name:     "openvpn gets replaced",
+			addons:   []string{"canal", "openvpn", "kube-proxy"},
+			expected: []string{"canal", "kube-proxy", "konnectivity", "metrics-server"},
+		},
+		{
+			name:     "addons are not duplicated",
+			addons:   []string{"konnectivity", "canal", "metrics-server"},
+			expected: []string{"canal", "konnectivity", "metrics-server"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list := &kubermaticv1.AddonList{}
+			for _, name := range test.addons {
+				list.Items = append(list.Items, kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+
+			var names []string
+			for _, addon := range konnectivityAddons(list).Items {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expected); diff != nil {
+				t.Errorf("got unexpected addons, diff to expected: %v", diff)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"go.uber.org/zap"
 
+	kubermaticapiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1/helper"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
@@ -71,6 +72,14 @@ const (
 	backupCleanupJobLabel = "kubermatic-etcd-backup-cleaner"
 	// clusterEnvVarKey defines the environment variable key for the cluster name
 	clusterEnvVarKey = "CLUSTER"
+	// finalBackupJobLabel defines the label we use on all final backup jobs
+	finalBackupJobLabel = "kubermatic-etcd-final-backup"
+	// finalBackupPrefixAnnotation holds the prefix the final backup got stored with
+	finalBackupPrefixAnnotation = "kubermatic.io/final-backup-prefix"
+	// finalBackupRetentionAnnotation holds how long the final backup is kept after it got stored
+	finalBackupRetentionAnnotation = "kubermatic.io/final-backup-retention"
+	// DefaultFinalBackupRetention is the retention used for final backups which don't specify one
+	DefaultFinalBackupRetention = 7 * 24 * time.Hour
 
 	ControllerName = "kubermatic_backup_controller"
 )
@@ -89,6 +98,7 @@ type Reconciler struct {
 
 	ctrlruntimeclient.Client
 	recorder record.EventRecorder
+	now      func() time.Time
 }
 
 // Add creates a new Backup controller that is responsible for creating backupjobs
@@ -124,6 +134,7 @@ func Add(
 		backupContainerImage: backupContainerImage,
 		Client:               mgr.GetClient(),
 		recorder:             mgr.GetEventRecorderFor(ControllerName),
+		now:                  time.Now,
 	}
 	c, err := controller.New(ControllerName, mgr, controller.Options{
 		Reconciler:              reconciler,
@@ -161,6 +172,15 @@ func Add(
 		return fmt.Errorf("failed to add cleanup jobs runnable to mgr: %v", err)
 	}
 
+	// Remove final backups once their retention passed
+	if err := mgr.Add(&runnableWrapper{
+		f: func(stopCh <-chan struct{}) {
+			wait.Until(reconciler.cleanupFinalBackups, 5*time.Minute, stopCh)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add final backup cleanup runnable to mgr: %v", err)
+	}
+
 	return nil
 }
 
@@ -213,6 +233,65 @@ func (r *Reconciler) cleanupJobs() {
 	}
 }
 
+// cleanupFinalBackups removes the final backups whose retention passed by running
+// the cleanup container for their prefix. The final backup job itself serves as record
+// of the backup, so it gets deleted afterwards.
+func (r *Reconciler) cleanupFinalBackups() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.Named("final_backup_cleanup")
+
+	jobs := &batchv1.JobList{}
+	listOpts := &ctrlruntimeclient.ListOptions{
+		Namespace:     metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(labels.Set{resources.AppLabelKey: finalBackupJobLabel}),
+	}
+	if err := r.List(ctx, jobs, listOpts); err != nil {
+		log.Errorw("failed to list final backup jobs", zap.Error(err))
+		utilruntime.HandleError(fmt.Errorf("failed to list final backup jobs: %v", err))
+		return
+	}
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !finalBackupExpired(job, r.now()) {
+			continue
+		}
+
+		prefix := job.Annotations[finalBackupPrefixAnnotation]
+		cleanupJob := r.backupCleanupJob(fmt.Sprintf("remove-%s", job.Name), prefix)
+		if err := r.Create(ctx, cleanupJob); err != nil && !kerrors.IsAlreadyExists(err) {
+			log.Errorw("Failed to create cleanup job for final backup", zap.Error(err), "prefix", prefix)
+			utilruntime.HandleError(err)
+			continue
+		}
+
+		deletePropagationForeground := metav1.DeletePropagationForeground
+		delOpts := &ctrlruntimeclient.DeleteOptions{
+			PropagationPolicy: &deletePropagationForeground,
+		}
+		if err := r.Delete(ctx, job, delOpts); err != nil && !kerrors.IsNotFound(err) {
+			log.Errorw("Failed to delete final backup job", zap.Error(err), "job_name", job.Name)
+			utilruntime.HandleError(err)
+			continue
+		}
+		log.Infow("Removed expired final backup", "prefix", prefix)
+	}
+}
+
+// finalBackupExpired returns whether the retention of the final backup stored by the given job passed.
+// Jobs which did not succeed have nothing to clean up and are left alone.
+func finalBackupExpired(job *batchv1.Job, now time.Time) bool {
+	if job.Status.Succeeded < 1 || job.Status.CompletionTime == nil {
+		return false
+	}
+	retention, err := time.ParseDuration(job.Annotations[finalBackupRetentionAnnotation])
+	if err != nil {
+		retention = DefaultFinalBackupRetention
+	}
+	return now.After(job.Status.CompletionTime.Add(retention))
+}
+
 func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -228,48 +307,62 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	}
 
 	// Add a wrapping here so we can emit an event on error
-	_, err := kubermaticv1helper.ClusterReconcileWrapper(
+	result, err := kubermaticv1helper.ClusterReconcileWrapper(
 		ctx,
 		r.Client,
 		r.workerName,
 		cluster,
 		kubermaticv1.ClusterConditionBackupControllerReconcilingSuccess,
 		func() (*reconcile.Result, error) {
-			return nil, r.reconcile(ctx, log, cluster)
+			return r.reconcile(ctx, log, cluster)
 		},
 	)
 	if err != nil {
 		log.Errorw("Reconciling failed", zap.Error(err))
 		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "ReconcilingError", "%v", err)
 	}
-	return reconcile.Result{}, err
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	// Cluster got deleted - regardless if the cluster was ever running, we cleanup
 	if cluster.DeletionTimestamp != nil {
+		// The final backup must be stored before anything else of the cluster gets removed
+		if kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer) {
+			return r.reconcileFinalBackup(ctx, log, cluster)
+		}
+
+		// The backups must be kept as long as the cluster is protected against deletion
+		if cluster.Spec.DeletionProtection {
+			log.Debug("Skipping the cleanup of the backups because the cluster is protected against deletion")
+			return nil, nil
+		}
+
 		// Need to cleanup
 		if sets.NewString(cluster.Finalizers...).Has(cleanupFinalizer) {
 			if err := r.Create(ctx, r.cleanupJob(cluster)); err != nil {
 				// Otherwise we end up in a loop when we are able to create the job but not
 				// remove the finalizer.
 				if !kerrors.IsAlreadyExists(err) {
-					return err
+					return nil, err
 				}
 			}
 
 			oldCluster := cluster.DeepCopy()
 			kuberneteshelper.RemoveFinalizer(cluster, cleanupFinalizer)
 			if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
-				return fmt.Errorf("failed to update cluster after removing cleanup finalizer: %v", err)
+				return nil, fmt.Errorf("failed to update cluster after removing cleanup finalizer: %v", err)
 			}
 		}
-		return nil
+		return nil, nil
 	}
 
 	if cluster.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusUp {
 		log.Debug("Skipping because the cluster has no running etcd yet")
-		return nil
+		return nil, nil
 	}
 
 	// Always add the finalizer first
@@ -277,15 +370,106 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		oldCluster := cluster.DeepCopy()
 		kuberneteshelper.AddFinalizer(cluster, cleanupFinalizer)
 		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
-			return fmt.Errorf("failed to update cluster after adding cleanup finalizer: %v", err)
+			return nil, fmt.Errorf("failed to update cluster after adding cleanup finalizer: %v", err)
+		}
+	}
+
+	// The final backup finalizer follows the cluster spec, so disabling the final backup
+	// before deleting the cluster skips it
+	if hasFinalizer := kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer); hasFinalizer != finalBackupEnabled(cluster) {
+		oldCluster := cluster.DeepCopy()
+		if hasFinalizer {
+			kuberneteshelper.RemoveFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer)
+		} else {
+			kuberneteshelper.AddFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer)
+		}
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to update cluster after updating final backup finalizer: %v", err)
 		}
 	}
 
 	if err := r.ensureCronJobSecret(ctx, cluster); err != nil {
-		return fmt.Errorf("failed to create backup secret: %v", err)
+		return nil, fmt.Errorf("failed to create backup secret: %v", err)
+	}
+
+	return nil, reconciling.ReconcileCronJobs(ctx, []reconciling.NamedCronJobCreatorGetter{r.cronjob(cluster)}, metav1.NamespaceSystem, r.Client)
+}
+
+// reconcileFinalBackup takes a last snapshot of a cluster which is being deleted. The snapshot
+// is stored with its own prefix, so it is neither affected by the cleanup of the regular backups
+// nor by the retention of the store container. The job has no owner so it outlives the cluster
+// and the final backup can be found through it until its retention passed.
+func (r *Reconciler) reconcileFinalBackup(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: FinalBackupJobName(cluster.Name)}, job)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get final backup job: %v", err)
+	}
+	jobExists := err == nil
+
+	// The final backup got disabled after the deletion started, which is the way out
+	// of a final backup that can not be taken
+	if !finalBackupEnabled(cluster) {
+		if jobExists && job.Status.Succeeded < 1 {
+			deletePropagationForeground := metav1.DeletePropagationForeground
+			if err := r.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &deletePropagationForeground}); err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to delete final backup job: %v", err)
+			}
+		}
+		return nil, r.removeFinalBackupFinalizer(ctx, cluster)
+	}
+
+	if !jobExists {
+		// Clusters which get deleted while hibernated need their control plane to come back first
+		if cluster.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusUp {
+			log.Debug("Waiting for etcd to become healthy to take the final backup")
+			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		if err := r.ensureCronJobSecret(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to create backup secret: %v", err)
+		}
+		if err := r.Create(ctx, r.finalBackupJob(cluster)); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create final backup job: %v", err)
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "FinalBackupStarted", "Taking the final etcd backup before deleting the cluster")
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
-	return reconciling.ReconcileCronJobs(ctx, []reconciling.NamedCronJobCreatorGetter{r.cronjob(cluster)}, metav1.NamespaceSystem, r.Client)
+	if isJobFailed(job) {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "FinalBackupFailed",
+			"Job %s/%s failed to store the final etcd backup, delete the job to retry or disable the final backup to continue the deletion", job.Namespace, job.Name)
+		return &reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+	if job.Status.Succeeded < 1 {
+		log.Debug("Waiting for the final backup to be stored")
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "FinalBackupStored",
+		"Stored the final etcd backup with the prefix %q, it is retained for %s", job.Annotations[finalBackupPrefixAnnotation], job.Annotations[finalBackupRetentionAnnotation])
+	return nil, r.removeFinalBackupFinalizer(ctx, cluster)
+}
+
+func (r *Reconciler) removeFinalBackupFinalizer(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	oldCluster := cluster.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer)
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster after removing final backup finalizer: %v", err)
+	}
+	return nil
+}
+
+func finalBackupEnabled(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.FinalBackup != nil && cluster.Spec.FinalBackup.Enabled
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *Reconciler) getEtcdSecretName(cluster *kubermaticv1.Cluster) string {
@@ -323,15 +507,20 @@ func (r *Reconciler) ensureCronJobSecret(ctx context.Context, cluster *kubermati
 }
 
 func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
+	return r.backupCleanupJob(fmt.Sprintf("remove-cluster-backups-%s", cluster.Name), cluster.Name)
+}
+
+// backupCleanupJob returns a job which removes all backups stored with the given prefix
+func (r *Reconciler) backupCleanupJob(name, prefix string) *batchv1.Job {
 	cleanupContainer := r.cleanupContainer.DeepCopy()
 	cleanupContainer.Env = append(cleanupContainer.Env, corev1.EnvVar{
 		Name:  clusterEnvVarKey,
-		Value: cluster.Name,
+		Value: prefix,
 	})
 
 	return &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("remove-cluster-backups-%s", cluster.Name),
+			Name:      name,
 			Namespace: metav1.NamespaceSystem,
 			Labels: map[string]string{
 				resources.AppLabelKey: backupCleanupJobLabel,
@@ -364,7 +553,7 @@ func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
 
 func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCronJobCreatorGetter {
 	return func() (string, reconciling.CronJobCreator) {
-		return fmt.Sprintf("%s-%s", cronJobPrefix, cluster.Name), func(cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
+		return CronJobName(cluster.Name), func(cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
 			gv := kubermaticv1.SchemeGroupVersion
 			cronJob.OwnerReferences = []metav1.OwnerReference{
 				*metav1.NewControllerRef(cluster, gv.WithKind(kubermaticv1.ClusterKindName)),
@@ -376,75 +565,10 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 			cronJob.Spec.Suspend = utilpointer.BoolPtr(false)
 			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(0)
 
-			endpoints := etcd.GetClientEndpoints(cluster.Status.NamespaceName)
-			image := r.backupContainerImage
-			if !strings.Contains(image, ":") {
-				image = image + ":" + etcd.ImageTag(cluster)
-			}
-			cronJob.Spec.JobTemplate.Spec.Template.Spec.InitContainers = []corev1.Container{
-				{
-					Name:  "backup-creator",
-					Image: image,
-					Env: []corev1.EnvVar{
-						{
-							Name:  "ETCDCTL_API",
-							Value: "3",
-						},
-						{
-							Name:  "ETCDCTL_DIAL_TIMEOUT",
-							Value: "3s",
-						},
-						{
-							Name:  "ETCDCTL_CACERT",
-							Value: "/etc/etcd/client/ca.crt",
-						},
-						{
-							Name:  "ETCDCTL_CERT",
-							Value: "/etc/etcd/client/backup-etcd-client.crt",
-						},
-						{
-							Name:  "ETCDCTL_KEY",
-							Value: "/etc/etcd/client/backup-etcd-client.key",
-						},
-					},
-					Command: snapshotCommand(endpoints),
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      SharedVolumeName,
-							MountPath: "/backup",
-						},
-						{
-							Name:      r.getEtcdSecretName(cluster),
-							MountPath: "/etc/etcd/client",
-						},
-					},
-				},
-			}
-
-			storeContainer := r.storeContainer.DeepCopy()
-			storeContainer.Env = append(storeContainer.Env, corev1.EnvVar{
-				Name:  clusterEnvVarKey,
-				Value: cluster.Name,
-			})
-
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.InitContainers = []corev1.Container{r.backupCreatorContainer(cluster)}
 			cronJob.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
-			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{*storeContainer}
-			cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes = []corev1.Volume{
-				{
-					Name: SharedVolumeName,
-					VolumeSource: corev1.VolumeSource{
-						EmptyDir: &corev1.EmptyDirVolumeSource{},
-					},
-				},
-				{
-					Name: r.getEtcdSecretName(cluster),
-					VolumeSource: corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: r.getEtcdSecretName(cluster),
-						},
-					},
-				},
-			}
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{r.backupStoreContainer(cluster.Name)}
+			cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes = r.backupVolumes(cluster)
 
 			return cronJob, nil
 		}
@@ -452,6 +576,134 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 
 }
 
+// finalBackupJob returns the job which stores the final backup of the given cluster
+func (r *Reconciler) finalBackupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
+	retention := DefaultFinalBackupRetention
+	if cluster.Spec.FinalBackup != nil && cluster.Spec.FinalBackup.Retention != nil {
+		retention = cluster.Spec.FinalBackup.Retention.Duration
+	}
+	prefix := FinalBackupPrefix(cluster.Name)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FinalBackupJobName(cluster.Name),
+			Namespace: metav1.NamespaceSystem,
+			Labels: map[string]string{
+				resources.AppLabelKey: finalBackupJobLabel,
+			},
+			Annotations: map[string]string{
+				finalBackupPrefixAnnotation:    prefix,
+				finalBackupRetentionAnnotation: retention.String(),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: utilpointer.Int32Ptr(3),
+			Completions:  utilpointer.Int32Ptr(1),
+			Parallelism:  utilpointer.Int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{r.backupCreatorContainer(cluster)},
+					Containers:     []corev1.Container{r.backupStoreContainer(prefix)},
+					RestartPolicy:  corev1.RestartPolicyNever,
+					Volumes:        r.backupVolumes(cluster),
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) backupCreatorContainer(cluster *kubermaticv1.Cluster) corev1.Container {
+	endpoints := etcd.GetClientEndpoints(cluster.Status.NamespaceName)
+	image := r.backupContainerImage
+	if !strings.Contains(image, ":") {
+		image = image + ":" + etcd.ImageTag(cluster)
+	}
+	return corev1.Container{
+		Name:  "backup-creator",
+		Image: image,
+		Env: []corev1.EnvVar{
+			{
+				Name:  "ETCDCTL_API",
+				Value: "3",
+			},
+			{
+				Name:  "ETCDCTL_DIAL_TIMEOUT",
+				Value: "3s",
+			},
+			{
+				Name:  "ETCDCTL_CACERT",
+				Value: "/etc/etcd/client/ca.crt",
+			},
+			{
+				Name:  "ETCDCTL_CERT",
+				Value: "/etc/etcd/client/backup-etcd-client.crt",
+			},
+			{
+				Name:  "ETCDCTL_KEY",
+				Value: "/etc/etcd/client/backup-etcd-client.key",
+			},
+		},
+		Command: snapshotCommand(endpoints),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      SharedVolumeName,
+				MountPath: "/backup",
+			},
+			{
+				Name:      r.getEtcdSecretName(cluster),
+				MountPath: "/etc/etcd/client",
+			},
+		},
+	}
+}
+
+// backupStoreContainer returns the store container which uploads the backup with the given prefix
+func (r *Reconciler) backupStoreContainer(prefix string) corev1.Container {
+	storeContainer := r.storeContainer.DeepCopy()
+	storeContainer.Env = append(storeContainer.Env, corev1.EnvVar{
+		Name:  clusterEnvVarKey,
+		Value: prefix,
+	})
+	return *storeContainer
+}
+
+func (r *Reconciler) backupVolumes(cluster *kubermaticv1.Cluster) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: SharedVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: r.getEtcdSecretName(cluster),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: r.getEtcdSecretName(cluster),
+				},
+			},
+		},
+	}
+}
+
+// CronJobName returns the name of the backup CronJob for the cluster with the given name.
+// The CronJob lives in the kube-system namespace of the seed.
+func CronJobName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", cronJobPrefix, clusterName)
+}
+
+// FinalBackupJobName returns the name of the Job storing the final backup of the cluster with the
+// given name. The Job lives in the kube-system namespace of the seed.
+func FinalBackupJobName(clusterName string) string {
+	return fmt.Sprintf("final-%s-%s", cronJobPrefix, clusterName)
+}
+
+// FinalBackupPrefix returns the prefix the final backup of the cluster with the given name is stored with.
+// It must not start with the cluster name, as the regular backups use that as prefix.
+func FinalBackupPrefix(clusterName string) string {
+	return fmt.Sprintf("final-%s", clusterName)
+}
+
 func parseDuration(interval time.Duration) (string, error) {
 	scheduleString := fmt.Sprintf("@every %vm", interval.Round(time.Minute).Minutes())
 	// We verify the validity of the scheduleString here, because the cronjob controller
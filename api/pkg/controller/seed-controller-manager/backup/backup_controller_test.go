@@ -18,18 +18,26 @@ package backup
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	kubermaticapiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
 	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
 	"github.com/kubermatic/kubermatic/api/pkg/semver"
 
+	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	certutil "k8s.io/client-go/util/cert"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -178,3 +186,253 @@ func TestCleanupJobSpec(t *testing.T) {
 		t.Errorf("expected cleanup job to have exactly one container, got %d", containerLen)
 	}
 }
+
+func TestFinalBackup(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	testCases := []struct {
+		name                  string
+		deleted               bool
+		finalBackup           *kubermaticv1.FinalBackupSettings
+		finalizers            []string
+		existingJob           *batchv1.Job
+		expectFinalizer       bool
+		expectJob             bool
+		expectEventWithPrefix string
+		expectRequeue         bool
+	}{
+		{
+			name:            "Finalizer gets added when the final backup is enabled",
+			finalBackup:     &kubermaticv1.FinalBackupSettings{Enabled: true},
+			expectFinalizer: true,
+		},
+		{
+			name:            "Finalizer gets removed when the final backup is disabled",
+			finalBackup:     &kubermaticv1.FinalBackupSettings{Enabled: false},
+			finalizers:      []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+			expectFinalizer: false,
+		},
+		{
+			name:                  "Final backup job gets created when the cluster is deleted",
+			deleted:               true,
+			finalBackup:           &kubermaticv1.FinalBackupSettings{Enabled: true},
+			finalizers:            []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+			expectFinalizer:       true,
+			expectJob:             true,
+			expectEventWithPrefix: "Normal FinalBackupStarted",
+			expectRequeue:         true,
+		},
+		{
+			name:        "Finalizer remains when the final backup failed",
+			deleted:     true,
+			finalBackup: &kubermaticv1.FinalBackupSettings{Enabled: true},
+			finalizers:  []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+			existingJob: finalBackupJobWithStatus(batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			}),
+			expectFinalizer:       true,
+			expectJob:             true,
+			expectEventWithPrefix: "Warning FinalBackupFailed",
+			expectRequeue:         true,
+		},
+		{
+			name:        "Finalizer gets removed when the final backup was stored",
+			deleted:     true,
+			finalBackup: &kubermaticv1.FinalBackupSettings{Enabled: true},
+			finalizers:  []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+			existingJob: finalBackupJobWithStatus(batchv1.JobStatus{
+				Succeeded:      1,
+				CompletionTime: &deletionTimestamp,
+			}),
+			expectFinalizer:       false,
+			expectJob:             true,
+			expectEventWithPrefix: `Normal FinalBackupStored Stored the final etcd backup with the prefix "final-test-cluster"`,
+		},
+		{
+			name:            "Unfinished final backup gets dropped when the final backup is disabled during the deletion",
+			deleted:         true,
+			finalBackup:     &kubermaticv1.FinalBackupSettings{Enabled: false},
+			finalizers:      []string{kubermaticapiv1.FinalEtcdBackupFinalizer},
+			existingJob:     finalBackupJobWithStatus(batchv1.JobStatus{}),
+			expectFinalizer: false,
+			expectJob:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-cluster",
+					Finalizers: append([]string{cleanupFinalizer}, tc.finalizers...),
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Version:     *semver.NewSemverOrDie("1.16.3"),
+					FinalBackup: tc.finalBackup,
+				},
+				Status: kubermaticv1.ClusterStatus{
+					NamespaceName: "testnamespace",
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Etcd: kubermaticv1.HealthStatusUp,
+					},
+				},
+			}
+			if tc.deleted {
+				cluster.DeletionTimestamp = &deletionTimestamp
+			}
+
+			objects := []runtime.Object{cluster, testCASecret(t, cluster)}
+			if tc.existingJob != nil {
+				objects = append(objects, tc.existingJob)
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &Reconciler{
+				log:                  kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				storeContainer:       testStoreContainer,
+				cleanupContainer:     testCleanupContainer,
+				backupContainerImage: DefaultBackupContainerImage,
+				Client:               ctrlruntimefakeclient.NewFakeClient(objects...),
+				recorder:             recorder,
+				now:                  time.Now,
+			}
+
+			result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}})
+			if err != nil {
+				t.Fatalf("Error syncing cluster: %v", err)
+			}
+			if requeue := result.RequeueAfter > 0; requeue != tc.expectRequeue {
+				t.Errorf("expected requeue to be %t but got result %+v", tc.expectRequeue, result)
+			}
+
+			if err := reconciler.Get(context.Background(), types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if hasFinalizer := kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.FinalEtcdBackupFinalizer); hasFinalizer != tc.expectFinalizer {
+				t.Errorf("expected final backup finalizer to be present: %t, finalizers: %v", tc.expectFinalizer, cluster.Finalizers)
+			}
+
+			job := &batchv1.Job{}
+			err = reconciler.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: FinalBackupJobName(cluster.Name)}, job)
+			if err != nil && !kerrors.IsNotFound(err) {
+				t.Fatalf("failed to get final backup job: %v", err)
+			}
+			if jobExists := err == nil; jobExists != tc.expectJob {
+				t.Fatalf("expected final backup job to exist: %t", tc.expectJob)
+			}
+			if tc.expectJob && tc.existingJob == nil {
+				if len(job.OwnerReferences) != 0 {
+					t.Errorf("expected final backup job to have no owner, got %v", job.OwnerReferences)
+				}
+				env := job.Spec.Template.Spec.Containers[0].Env
+				if prefix := env[len(env)-1]; prefix.Name != clusterEnvVarKey || prefix.Value != "final-test-cluster" {
+					t.Errorf("expected the store container to use the prefix %q, got %v", "final-test-cluster", prefix)
+				}
+				if retention := job.Annotations[finalBackupRetentionAnnotation]; retention != DefaultFinalBackupRetention.String() {
+					t.Errorf("expected retention to default to %s, got %q", DefaultFinalBackupRetention, retention)
+				}
+			}
+
+			if tc.expectEventWithPrefix == "" {
+				if len(recorder.Events) != 0 {
+					t.Errorf("expected no event, got %q", <-recorder.Events)
+				}
+				return
+			}
+			if len(recorder.Events) != 1 {
+				t.Fatalf("expected exactly one event, got %d", len(recorder.Events))
+			}
+			if event := <-recorder.Events; !strings.HasPrefix(event, tc.expectEventWithPrefix) {
+				t.Errorf("expected event to start with %q, got %q", tc.expectEventWithPrefix, event)
+			}
+		})
+	}
+}
+
+func TestCleanupFinalBackups(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiredCompletion := metav1.NewTime(now.Add(-2 * time.Hour))
+	retainedCompletion := metav1.NewTime(now.Add(-30 * time.Minute))
+
+	expiredJob := finalBackupJobWithStatus(batchv1.JobStatus{Succeeded: 1, CompletionTime: &expiredCompletion})
+	expiredJob.Name = FinalBackupJobName("expired")
+	expiredJob.Annotations = map[string]string{
+		finalBackupPrefixAnnotation:    FinalBackupPrefix("expired"),
+		finalBackupRetentionAnnotation: time.Hour.String(),
+	}
+	retainedJob := finalBackupJobWithStatus(batchv1.JobStatus{Succeeded: 1, CompletionTime: &retainedCompletion})
+	retainedJob.Name = FinalBackupJobName("retained")
+	retainedJob.Annotations = map[string]string{
+		finalBackupPrefixAnnotation:    FinalBackupPrefix("retained"),
+		finalBackupRetentionAnnotation: time.Hour.String(),
+	}
+
+	reconciler := &Reconciler{
+		log:              kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		cleanupContainer: testCleanupContainer,
+		Client:           ctrlruntimefakeclient.NewFakeClient(expiredJob, retainedJob),
+		now:              func() time.Time { return now },
+	}
+	reconciler.cleanupFinalBackups()
+
+	jobs := &batchv1.JobList{}
+	if err := reconciler.List(context.Background(), jobs); err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	jobsByName := map[string]batchv1.Job{}
+	for _, job := range jobs.Items {
+		jobsByName[job.Name] = job
+	}
+
+	if _, exists := jobsByName[expiredJob.Name]; exists {
+		t.Errorf("expected the expired final backup job to be deleted")
+	}
+	if _, exists := jobsByName[retainedJob.Name]; !exists {
+		t.Errorf("expected the retained final backup job to remain")
+	}
+	cleanupJob, exists := jobsByName["remove-"+expiredJob.Name]
+	if !exists {
+		t.Fatalf("expected a cleanup job for the expired final backup, got jobs %v", jobsByName)
+	}
+	env := cleanupJob.Spec.Template.Spec.Containers[0].Env
+	if prefix := env[len(env)-1]; prefix.Value != "final-expired" {
+		t.Errorf("expected the cleanup job to remove the prefix %q, got %q", "final-expired", prefix.Value)
+	}
+	if len(jobsByName) != 2 {
+		t.Errorf("expected exactly two jobs, got %d", len(jobsByName))
+	}
+}
+
+func finalBackupJobWithStatus(status batchv1.JobStatus) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FinalBackupJobName("test-cluster"),
+			Namespace: metav1.NamespaceSystem,
+			Labels:    map[string]string{resources.AppLabelKey: finalBackupJobLabel},
+			Annotations: map[string]string{
+				finalBackupPrefixAnnotation:    FinalBackupPrefix("test-cluster"),
+				finalBackupRetentionAnnotation: DefaultFinalBackupRetention.String(),
+			},
+		},
+		Status: status,
+	}
+}
+
+func testCASecret(t *testing.T, cluster *kubermaticv1.Cluster) *corev1.Secret {
+	caKey, err := triple.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("unable to create a private key for the CA: %v", err)
+	}
+	caCert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "foo"}, caKey)
+	if err != nil {
+		t.Fatalf("unable to create a self-signed certificate for a new CA: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Status.NamespaceName,
+			Name:      resources.CASecretName,
+		},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(caCert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(caKey),
+		},
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_certificates_controller"
+
+	// inventoryInterval is the interval in which the certificate inventory gets refreshed
+	inventoryInterval = time.Hour
+
+	// pollInterval is used while waiting for the control plane to pick up the rotated certificates
+	pollInterval = 30 * time.Second
+)
+
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+// Reconciler keeps the certificate inventory of clusters and rotates their CAs
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider userClusterConnectionProvider
+	now                           func() time.Time
+
+	inventoryLock sync.Mutex
+	// inventories tracks when the inventory of a cluster got refreshed, so the
+	// secrets don't get parsed again on every cluster update
+	inventories map[string]inventoryState
+}
+
+type inventoryState struct {
+	refreshed     time.Time
+	rotationPhase kubermaticv1.ClusterCertificateRotationPhase
+}
+
+// Add creates a new certificates controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string, userClusterConnectionProvider userClusterConnectionProvider) error {
+	reconciler := &Reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil {
+		deleteMetrics(cluster.Name, cluster.Status.Certificates)
+		r.forgetInventory(cluster.Name)
+		return nil, nil
+	}
+	// The control plane doesn't exist yet or is scaled down, there is nothing to rotate
+	if cluster.Status.NamespaceName == "" || cluster.IsControlPlaneHibernated() {
+		return nil, nil
+	}
+
+	result := &reconcile.Result{RequeueAfter: inventoryInterval}
+	if !cluster.IsOpenshift() {
+		rotationResult, err := r.reconcileRotation(ctx, log, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rotate certificates: %v", err)
+		}
+		if rotationResult != nil && rotationResult.RequeueAfter < result.RequeueAfter {
+			result = rotationResult
+		}
+	}
+
+	if r.inventoryOutdated(cluster) {
+		if err := r.reconcileInventory(ctx, log, cluster); err != nil {
+			return nil, fmt.Errorf("failed to update the certificate inventory: %v", err)
+		}
+		r.inventoryRefreshed(cluster)
+	}
+
+	return result, nil
+}
+
+// inventoryOutdated returns whether the inventory of the cluster has to be refreshed. This is the case
+// once per inventory interval and whenever a rotation changed the certificates.
+func (r *Reconciler) inventoryOutdated(cluster *kubermaticv1.Cluster) bool {
+	r.inventoryLock.Lock()
+	defer r.inventoryLock.Unlock()
+
+	state, exists := r.inventories[cluster.Name]
+	return !exists || r.now().Sub(state.refreshed) >= inventoryInterval || state.rotationPhase != rotationPhase(cluster)
+}
+
+func (r *Reconciler) inventoryRefreshed(cluster *kubermaticv1.Cluster) {
+	r.inventoryLock.Lock()
+	defer r.inventoryLock.Unlock()
+
+	if r.inventories == nil {
+		r.inventories = map[string]inventoryState{}
+	}
+	r.inventories[cluster.Name] = inventoryState{refreshed: r.now(), rotationPhase: rotationPhase(cluster)}
+}
+
+func (r *Reconciler) forgetInventory(clusterName string) {
+	r.inventoryLock.Lock()
+	defer r.inventoryLock.Unlock()
+
+	delete(r.inventories, clusterName)
+}
+
+func rotationPhase(cluster *kubermaticv1.Cluster) kubermaticv1.ClusterCertificateRotationPhase {
+	if cluster.Status.CertificateRotation == nil {
+		return ""
+	}
+	return cluster.Status.CertificateRotation.Phase
+}
+
+// reconcileInventory lists all certificates stored in the cluster namespace in the cluster status
+func (r *Reconciler) reconcileInventory(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return fmt.Errorf("failed to list secrets: %v", err)
+	}
+
+	certificates := []kubermaticv1.ClusterCertificate{}
+	for _, secret := range secrets.Items {
+		for key, data := range secret.Data {
+			// Bundles contain multiple certificates, which are listed with their own keys
+			if !strings.HasSuffix(key, ".crt") || key == resources.CABundleSecretKey {
+				continue
+			}
+			certs, err := triple.ParseCertsPEM(data)
+			if err != nil || len(certs) == 0 {
+				log.Debugw("Skipping invalid certificate", "secret", secret.Name, "key", key, zap.Error(err))
+				continue
+			}
+			certificates = append(certificates, kubermaticv1.ClusterCertificate{
+				SecretName: secret.Name,
+				Key:        key,
+				CommonName: certs[0].Subject.CommonName,
+				IsCA:       certs[0].IsCA,
+				NotBefore:  metav1.NewTime(certs[0].NotBefore),
+				NotAfter:   metav1.NewTime(certs[0].NotAfter),
+			})
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		if certificates[i].SecretName != certificates[j].SecretName {
+			return certificates[i].SecretName < certificates[j].SecretName
+		}
+		return certificates[i].Key < certificates[j].Key
+	})
+
+	deleteMetrics(cluster.Name, removedCertificates(cluster.Status.Certificates, certificates))
+	for _, certificate := range certificates {
+		certificateExpiry.WithLabelValues(cluster.Name, certificate.SecretName, certificate.Key).Set(float64(certificate.NotAfter.Unix()))
+	}
+
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Certificates = certificates
+	})
+}
+
+// removedCertificates returns the certificates of the old inventory which are not part of the new one
+func removedCertificates(old, new []kubermaticv1.ClusterCertificate) []kubermaticv1.ClusterCertificate {
+	current := map[string]bool{}
+	for _, certificate := range new {
+		current[certificate.SecretName+"/"+certificate.Key] = true
+	}
+
+	var removed []kubermaticv1.ClusterCertificate
+	for _, certificate := range old {
+		if !current[certificate.SecretName+"/"+certificate.Key] {
+			removed = append(removed, certificate)
+		}
+	}
+	return removed
+}
+
+func deleteMetrics(clusterName string, certificates []kubermaticv1.ClusterCertificate) {
+	for _, certificate := range certificates {
+		certificateExpiry.DeleteLabelValues(clusterName, certificate.SecretName, certificate.Key)
+	}
+}
+
+func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	oldCluster := cluster.DeepCopy()
+	modify(cluster)
+	// The semantic comparison is required as the certificate times are in UTC while the
+	// times of the cluster status are in the local time zone
+	if equality.Semantic.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	return r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+}
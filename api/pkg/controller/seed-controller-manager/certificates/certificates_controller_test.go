@@ -0,0 +1,371 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	clusterName = "rotating"
+	clusterNS   = "cluster-rotating"
+)
+
+func genCluster(rotation *kubermaticv1.ClusterCertificateRotationStatus) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName:       clusterNS,
+			CertificateRotation: rotation,
+		},
+	}
+}
+
+func genCASecret(t *testing.T, name, commonName string) *corev1.Secret {
+	ca, err := triple.NewCA(commonName)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: name},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+		},
+	}
+}
+
+func genServiceAccountKeySecret(t *testing.T) *corev1.Secret {
+	privateKey, publicKey, err := apiserver.NewServiceAccountKey()
+	if err != nil {
+		t.Fatalf("failed to create service account key: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.ServiceAccountKeySecretName},
+		Data: map[string][]byte{
+			resources.ServiceAccountKeySecretKey: privateKey,
+			resources.ServiceAccountKeyPublicKey: publicKey,
+		},
+	}
+}
+
+func genDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: utilpointer.Int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    name,
+						Command: []string{"/" + name, "--client-ca-file", "/etc/kubernetes/pki/ca/" + resources.CACertSecretKey},
+					}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeClientProvider) GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+func genServiceAccountTokenSecret(caCert []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "default-token-abcde"},
+		Type:       corev1.SecretTypeServiceAccountToken,
+		Data:       map[string][]byte{corev1.ServiceAccountRootCAKey: caCert},
+	}
+}
+
+type testEnv struct {
+	r                 *Reconciler
+	client            ctrlruntimeclient.Client
+	userClusterClient ctrlruntimeclient.Client
+	now               time.Time
+}
+
+func newTestEnv(t *testing.T, cluster *kubermaticv1.Cluster) *testEnv {
+	caSecret := genCASecret(t, resources.CASecretName, "root-ca.rotating.example.com")
+	env := &testEnv{
+		client: fakectrlruntimeclient.NewFakeClient(
+			cluster,
+			caSecret,
+			genCASecret(t, resources.FrontProxyCASecretName, "front-proxy-ca"),
+			genServiceAccountKeySecret(t),
+			genDeployment(resources.ApiserverDeploymentName),
+			genDeployment(resources.ControllerManagerDeploymentName),
+			genDeployment(resources.SchedulerDeploymentName),
+		),
+		userClusterClient: fakectrlruntimeclient.NewFakeClient(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+			genServiceAccountTokenSecret(caSecret.Data[resources.CACertSecretKey]),
+		),
+		now: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	env.r = &Reconciler{
+		Client:                        env.client,
+		log:                           kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeClientProvider{client: env.userClusterClient},
+		now:                           func() time.Time { return env.now },
+	}
+	return env
+}
+
+func (env *testEnv) cluster(t *testing.T) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{}
+	if err := env.client.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return cluster
+}
+
+func (env *testEnv) secret(t *testing.T, name string) *corev1.Secret {
+	secret := &corev1.Secret{}
+	if err := env.client.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: name}, secret); err != nil {
+		t.Fatalf("failed to get secret %s: %v", name, err)
+	}
+	return secret
+}
+
+func (env *testEnv) reconcile(t *testing.T) {
+	if _, err := env.r.reconcile(context.Background(), env.r.log, env.cluster(t)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+}
+
+// rolloutBundles updates the deployments as the cluster controller would do during a rotation
+func (env *testEnv) rolloutBundles(t *testing.T) {
+	for _, name := range bundleConsumers {
+		deployment := &appsv1.Deployment{}
+		if err := env.client.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: name}, deployment); err != nil {
+			t.Fatalf("failed to get deployment %s: %v", name, err)
+		}
+		deployment.Spec.Template.Spec.Containers[0].Command[2] = "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey
+		if err := env.client.Update(context.Background(), deployment); err != nil {
+			t.Fatalf("failed to update deployment %s: %v", name, err)
+		}
+	}
+}
+
+// rolloutCABundleAgent marks the CA bundle agent as ready on all nodes
+func (env *testEnv) rolloutCABundleAgent(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := env.userClusterClient.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}, daemonSet); err != nil {
+		t.Fatalf("failed to get CA bundle agent: %v", err)
+	}
+	daemonSet.Status.DesiredNumberScheduled = 1
+	daemonSet.Status.NumberReady = 1
+	if err := env.userClusterClient.Update(context.Background(), daemonSet); err != nil {
+		t.Fatalf("failed to update CA bundle agent: %v", err)
+	}
+}
+
+// publishCABundle updates the service account token as the kube-controller-manager would do
+func (env *testEnv) publishCABundle(t *testing.T) {
+	bundle := env.secret(t, resources.CASecretName).Data[resources.CABundleSecretKey]
+	if err := env.userClusterClient.Update(context.Background(), genServiceAccountTokenSecret(bundle)); err != nil {
+		t.Fatalf("failed to update service account token: %v", err)
+	}
+}
+
+func (env *testEnv) expectPhase(t *testing.T, expected kubermaticv1.ClusterCertificateRotationPhase) {
+	if phase := env.cluster(t).Status.CertificateRotation.Phase; phase != expected {
+		t.Fatalf("expected phase %q, got %q", expected, phase)
+	}
+}
+
+func countCerts(t *testing.T, data []byte) int {
+	certs, err := triple.ParseCertsPEM(data)
+	if err != nil {
+		t.Fatalf("failed to parse certificates: %v", err)
+	}
+	return len(certs)
+}
+
+func TestInventory(t *testing.T) {
+	env := newTestEnv(t, genCluster(nil))
+	env.reconcile(t)
+
+	certificates := env.cluster(t).Status.Certificates
+	if len(certificates) != 2 {
+		t.Fatalf("expected 2 certificates in the inventory, got %d: %+v", len(certificates), certificates)
+	}
+	if certificates[0].SecretName != resources.CASecretName || certificates[1].SecretName != resources.FrontProxyCASecretName {
+		t.Errorf("expected the inventory to be sorted by secret name, got %+v", certificates)
+	}
+	if !certificates[0].IsCA || certificates[0].CommonName != "root-ca.rotating.example.com" {
+		t.Errorf("expected the root CA to be listed, got %+v", certificates[0])
+	}
+	if certificates[0].NotAfter.Before(&certificates[0].NotBefore) {
+		t.Errorf("expected the validity to be reported, got %+v", certificates[0])
+	}
+}
+
+func TestInventoryRefreshInterval(t *testing.T) {
+	env := newTestEnv(t, genCluster(nil))
+	env.reconcile(t)
+
+	if err := env.client.Create(context.Background(), genCASecret(t, "new-ca", "new-ca")); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	env.reconcile(t)
+	if certificates := env.cluster(t).Status.Certificates; len(certificates) != 2 {
+		t.Errorf("expected the inventory not to be refreshed within the interval, got %d certificates", len(certificates))
+	}
+
+	env.now = env.now.Add(inventoryInterval)
+	env.reconcile(t)
+	if certificates := env.cluster(t).Status.Certificates; len(certificates) != 3 {
+		t.Errorf("expected the inventory to be refreshed after the interval, got %d certificates", len(certificates))
+	}
+}
+
+func TestRotation(t *testing.T) {
+	env := newTestEnv(t, genCluster(&kubermaticv1.ClusterCertificateRotationStatus{
+		Phase:            kubermaticv1.ClusterCertificateRotationPhasePending,
+		TransitionWindow: metav1.Duration{Duration: 24 * time.Hour},
+	}))
+	oldCA := env.secret(t, resources.CASecretName).Data[resources.CACertSecretKey]
+	oldPublicKey := env.secret(t, resources.ServiceAccountKeySecretName).Data[resources.ServiceAccountKeyPublicKey]
+
+	env.reconcile(t)
+	if phase := env.cluster(t).Status.CertificateRotation.Phase; phase != kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs, phase)
+	}
+	for _, name := range rotatedCASecrets {
+		secret := env.secret(t, name)
+		if _, exists := secret.Data[resources.CANextCertSecretKey]; !exists {
+			t.Errorf("expected a new CA in secret %s", name)
+		}
+		if count := countCerts(t, secret.Data[resources.CABundleSecretKey]); count != 2 {
+			t.Errorf("expected the bundle of secret %s to contain the old and the new CA, got %d certificates", name, count)
+		}
+	}
+	if _, exists := env.secret(t, resources.ServiceAccountKeySecretName).Data[resources.ServiceAccountKeyBundleSecretKey]; !exists {
+		t.Error("expected a service account key bundle")
+	}
+
+	// The new CAs must not be used before the control plane trusts them
+	env.reconcile(t)
+	if phase := env.cluster(t).Status.CertificateRotation.Phase; phase != kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs {
+		t.Fatalf("expected phase %q while the control plane does not trust the bundles, got %q", kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs, phase)
+	}
+
+	env.rolloutBundles(t)
+	env.reconcile(t)
+	env.expectPhase(t, kubermaticv1.ClusterCertificateRotationPhaseDistributingCABundle)
+
+	// The new CAs must not be used before the nodes trust them
+	env.reconcile(t)
+	env.expectPhase(t, kubermaticv1.ClusterCertificateRotationPhaseDistributingCABundle)
+	if string(env.secret(t, resources.CASecretName).Data[resources.CACertSecretKey]) != string(oldCA) {
+		t.Fatal("expected the old CA to be used while the CA bundle gets distributed")
+	}
+	nextCA, err := triple.ParseCertsPEM(env.secret(t, resources.CASecretName).Data[resources.CANextCertSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse the new CA: %v", err)
+	}
+	kubeletCerts := &corev1.Secret{}
+	if err := env.userClusterClient.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}, kubeletCerts); err != nil {
+		t.Fatalf("failed to get kubelet certificates: %v", err)
+	}
+	if !certIssuedBy(kubeletCerts.Data["worker-1.pem"], nextCA[0]) {
+		t.Error("expected a kubelet client certificate issued by the new CA")
+	}
+
+	// The new CAs must not be used before the service account tokens trust them
+	env.rolloutCABundleAgent(t)
+	env.reconcile(t)
+	env.expectPhase(t, kubermaticv1.ClusterCertificateRotationPhaseDistributingCABundle)
+
+	env.publishCABundle(t)
+	env.reconcile(t)
+	env.expectPhase(t, kubermaticv1.ClusterCertificateRotationPhaseTransitioning)
+	if err := env.userClusterClient.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}, &appsv1.DaemonSet{}); err == nil {
+		t.Error("expected the CA bundle agent to be removed after the CAs got switched")
+	}
+	caSecret := env.secret(t, resources.CASecretName)
+	if string(caSecret.Data[resources.CACertSecretKey]) == string(oldCA) {
+		t.Error("expected the CA to be replaced")
+	}
+	if _, exists := caSecret.Data[resources.CANextCertSecretKey]; exists {
+		t.Error("expected the new CA to be removed after it got promoted")
+	}
+	if count := countCerts(t, caSecret.Data[resources.CABundleSecretKey]); count != 2 {
+		t.Errorf("expected the old CA to be trusted during the transition window, got %d certificates", count)
+	}
+	crossSigned, err := triple.ParseCertsPEM(caSecret.Data[resources.CACrossSignedCertSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse the cross-signed CA: %v", err)
+	}
+	oldCACerts, err := triple.ParseCertsPEM(oldCA)
+	if err != nil {
+		t.Fatalf("failed to parse the old CA: %v", err)
+	}
+	if !crossSigned[0].PublicKey.(*rsa.PublicKey).Equal(nextCA[0].PublicKey) || crossSigned[0].CheckSignatureFrom(oldCACerts[0]) != nil {
+		t.Error("expected the new CA to be cross-signed by the old CA")
+	}
+	if string(env.secret(t, resources.ServiceAccountKeySecretName).Data[resources.ServiceAccountKeyPublicKey]) == string(oldPublicKey) {
+		t.Error("expected the service account key to be replaced")
+	}
+
+	// Within the transition window nothing changes
+	env.now = env.now.Add(23 * time.Hour)
+	env.reconcile(t)
+	if phase := env.cluster(t).Status.CertificateRotation.Phase; phase != kubermaticv1.ClusterCertificateRotationPhaseTransitioning {
+		t.Fatalf("expected phase %q within the transition window, got %q", kubermaticv1.ClusterCertificateRotationPhaseTransitioning, phase)
+	}
+
+	env.now = env.now.Add(2 * time.Hour)
+	env.reconcile(t)
+	if phase := env.cluster(t).Status.CertificateRotation.Phase; phase != kubermaticv1.ClusterCertificateRotationPhaseCompleted {
+		t.Fatalf("expected phase %q, got %q", kubermaticv1.ClusterCertificateRotationPhaseCompleted, phase)
+	}
+	caSecret = env.secret(t, resources.CASecretName)
+	if string(caSecret.Data[resources.CABundleSecretKey]) != string(caSecret.Data[resources.CACertSecretKey]) {
+		t.Error("expected the bundle to only contain the new CA after the transition window")
+	}
+	if _, exists := caSecret.Data[resources.CACrossSignedCertSecretKey]; exists {
+		t.Error("expected the cross-signed CA to be removed after the transition window")
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// caBundleAgentName is the name of the DaemonSet, ConfigMap and Secret in the user cluster
+	// which are used to install the CA bundle and new kubelet client certificates on the nodes
+	caBundleAgentName  = "ca-rotation-agent"
+	caBundleAgentImage = "quay.io/kubermatic/util:1.3.4"
+
+	// rootCAConfigMapName is the ConfigMap the kube-controller-manager publishes the root CA in
+	rootCAConfigMapName = "kube-root-ca.crt"
+
+	// kubeletClientCertValidity is the validity of the kubelet client certificates issued by a rotation
+	kubeletClientCertValidity = 365 * 24 * time.Hour
+)
+
+// caBundleAgentScript installs the CA bundle as the CA of the kubelet and replaces the kubelet client
+// certificate with one issued by the new CA, as the old one is not accepted anymore once the rotation
+// completed. The pod becomes ready once the node uses both.
+const caBundleAgentScript = `set -u
+bundle=/etc/ca-rotation/bundle/ca-bundle.crt
+cert="/etc/ca-rotation/kubelet/${NODE_NAME}.pem"
+while true; do
+  if [ -f "${bundle}" ] && [ -f "${cert}" ]; then
+    if ! cmp -s "${bundle}" /host/etc/kubernetes/pki/ca.crt || ! cmp -s "${cert}" /host/var/lib/kubelet/pki/kubelet-client-rotated.pem; then
+      cp "${bundle}" /host/etc/kubernetes/pki/ca.crt
+      sed -i "s#^\( *certificate-authority-data: *\).*#\1$(base64 -w 0 "${bundle}")#" /host/etc/kubernetes/kubelet.conf
+      cp "${cert}" /host/var/lib/kubelet/pki/kubelet-client-rotated.pem
+      ln -sf /var/lib/kubelet/pki/kubelet-client-rotated.pem /host/var/lib/kubelet/pki/kubelet-client-current.pem
+      chroot /host systemctl restart kubelet
+    fi
+    touch /tmp/installed
+  fi
+  sleep 10
+done
+`
+
+// reconcileCABundleDistribution installs the CA bundle on all nodes of the user cluster and returns
+// whether the nodes and the service account tokens trust the new root CA. Until then, the old root CA
+// keeps signing all certificates.
+func (r *Reconciler) reconcileCABundleDistribution(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, string, error) {
+	caSecret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, caSecret); err != nil {
+		return false, "", fmt.Errorf("failed to get CA secret: %v", err)
+	}
+	nextCA, err := triple.ParseRSAKeyPair(caSecret.Data[resources.CANextCertSecretKey], caSecret.Data[resources.CANextKeySecretKey])
+	if err != nil {
+		return false, "", fmt.Errorf("failed to parse the new CA: %v", err)
+	}
+	caBundle := caSecret.Data[resources.CABundleSecretKey]
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := userClusterClient.List(ctx, nodes); err != nil {
+		return false, "", fmt.Errorf("failed to list nodes: %v", err)
+	}
+	if err := r.ensureCABundleAgent(ctx, userClusterClient, caBundle, nextCA, nodes.Items); err != nil {
+		return false, "", err
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}, daemonSet); err != nil {
+		return false, "", fmt.Errorf("failed to get CA bundle agent: %v", err)
+	}
+	if daemonSet.Status.ObservedGeneration < daemonSet.Generation ||
+		daemonSet.Status.DesiredNumberScheduled < int32(len(nodes.Items)) ||
+		daemonSet.Status.NumberReady < daemonSet.Status.DesiredNumberScheduled {
+		return false, "Waiting for the CA bundle to be installed on all nodes", nil
+	}
+
+	distributed, err := serviceAccountsTrust(ctx, userClusterClient, nextCA.Cert)
+	if err != nil {
+		return false, "", err
+	}
+	if !distributed {
+		return false, "Waiting for the CA bundle to be distributed to the service account tokens", nil
+	}
+
+	return true, "", nil
+}
+
+// ensureCABundleAgent creates the DaemonSet which installs the CA bundle on the nodes, along with a
+// client certificate for every kubelet issued by the new CA.
+func (r *Reconciler) ensureCABundleAgent(ctx context.Context, client ctrlruntimeclient.Client, caBundle []byte, nextCA *triple.KeyPair, nodes []corev1.Node) error {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}
+	if err := client.Get(ctx, key, configMap); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get CA bundle ConfigMap: %v", err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{resources.CABundleSecretKey: string(caBundle)},
+		}
+		if err := client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create CA bundle ConfigMap: %v", err)
+		}
+	} else if configMap.Data[resources.CABundleSecretKey] != string(caBundle) {
+		oldConfigMap := configMap.DeepCopy()
+		configMap.Data = map[string]string{resources.CABundleSecretKey: string(caBundle)}
+		if err := client.Patch(ctx, configMap, ctrlruntimeclient.MergeFrom(oldConfigMap)); err != nil {
+			return fmt.Errorf("failed to update CA bundle ConfigMap: %v", err)
+		}
+	}
+
+	// Existing certificates are kept, so the kubelets only get restarted once
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, key, secret); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get kubelet certificates: %v", err)
+		}
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	}
+	oldSecret := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for _, node := range nodes {
+		if certIssuedBy(secret.Data[node.Name+".pem"], nextCA.Cert) {
+			continue
+		}
+		kubeletCert, err := triple.NewClientKeyPairWithValidity(nextCA, "system:node:"+node.Name, []string{"system:nodes"}, kubeletClientCertValidity)
+		if err != nil {
+			return fmt.Errorf("failed to create kubelet client certificate for node %s: %v", node.Name, err)
+		}
+		secret.Data[node.Name+".pem"] = append(triple.EncodeCertPEM(kubeletCert.Cert), triple.EncodePrivateKeyPEM(kubeletCert.Key)...)
+	}
+	if secret.ResourceVersion == "" {
+		if err := client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create kubelet certificates: %v", err)
+		}
+	} else if len(secret.Data) != len(oldSecret.Data) || !secretDataEqual(secret.Data, oldSecret.Data) {
+		if err := client.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret)); err != nil {
+			return fmt.Errorf("failed to update kubelet certificates: %v", err)
+		}
+	}
+
+	if err := client.Get(ctx, key, &appsv1.DaemonSet{}); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get CA bundle agent: %v", err)
+		}
+		if err := client.Create(ctx, caBundleAgentDaemonSet()); err != nil {
+			return fmt.Errorf("failed to create CA bundle agent: %v", err)
+		}
+	}
+	return nil
+}
+
+// removeCABundleAgent removes everything that was created to install the CA bundle on the nodes
+func (r *Reconciler) removeCABundleAgent(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	client, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+	meta := metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: caBundleAgentName}
+	for _, obj := range []runtime.Object{
+		&appsv1.DaemonSet{ObjectMeta: meta},
+		&corev1.ConfigMap{ObjectMeta: meta},
+		&corev1.Secret{ObjectMeta: meta},
+	} {
+		if err := client.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s: %v", caBundleAgentName, err)
+		}
+	}
+	return nil
+}
+
+// serviceAccountsTrust returns whether the service account tokens and the root CA ConfigMap in the
+// kube-system namespace contain the given CA. The kube-controller-manager updates all namespaces at once,
+// so kube-system is representative for the whole cluster.
+func serviceAccountsTrust(ctx context.Context, client ctrlruntimeclient.Client, ca *x509.Certificate) (bool, error) {
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return false, fmt.Errorf("failed to list secrets: %v", err)
+	}
+	for _, secret := range secrets.Items {
+		if secret.Type == corev1.SecretTypeServiceAccountToken && !containsCert(secret.Data[corev1.ServiceAccountRootCAKey], ca) {
+			return false, nil
+		}
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: rootCAConfigMapName}, configMap); err != nil {
+		if kerrors.IsNotFound(err) {
+			// Only published by newer Kubernetes versions
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get %s ConfigMap: %v", rootCAConfigMapName, err)
+	}
+	return containsCert([]byte(configMap.Data[corev1.ServiceAccountRootCAKey]), ca), nil
+}
+
+func caBundleAgentDaemonSet() *appsv1.DaemonSet {
+	labels := map[string]string{resources.AppLabelKey: caBundleAgentName}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caBundleAgentName,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					HostPID:     true,
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{
+						{
+							Name:    caBundleAgentName,
+							Image:   caBundleAgentImage,
+							Command: []string{"/bin/sh", "-c", caBundleAgentScript},
+							Env: []corev1.EnvVar{
+								{
+									Name: "NODE_NAME",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+									},
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: utilpointer.BoolPtr(true),
+							},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									Exec: &corev1.ExecAction{Command: []string{"test", "-f", "/tmp/installed"}},
+								},
+								PeriodSeconds: 10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "host", MountPath: "/host"},
+								{Name: "bundle", MountPath: "/etc/ca-rotation/bundle", ReadOnly: true},
+								{Name: "kubelet", MountPath: "/etc/ca-rotation/kubelet", ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+							},
+						},
+						{
+							Name: "bundle",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: caBundleAgentName},
+								},
+							},
+						},
+						{
+							Name: "kubelet",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: caBundleAgentName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// crossSign signs the given CA with the given signing CA, so clients which only trust the signing CA
+// can verify certificates issued by the CA.
+func crossSign(ca *x509.Certificate, signer *triple.KeyPair) ([]byte, error) {
+	template := *ca
+	template.AuthorityKeyId = signer.Cert.SubjectKeyId
+	if template.NotAfter.After(signer.Cert.NotAfter) {
+		template.NotAfter = signer.Cert.NotAfter
+	}
+	der, err := x509.CreateCertificate(cryptorand.Reader, &template, signer.Cert, ca.PublicKey, signer.Key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: triple.CertificateBlockType, Bytes: der}), nil
+}
+
+// certIssuedBy returns whether the first certificate in the given PEM data got issued by the CA
+func certIssuedBy(data []byte, ca *x509.Certificate) bool {
+	if len(data) == 0 {
+		return false
+	}
+	certs, err := triple.ParseCertsPEM(data)
+	if err != nil || len(certs) == 0 {
+		return false
+	}
+	return certs[0].CheckSignatureFrom(ca) == nil
+}
+
+// containsCert returns whether the PEM encoded certificates contain the given one
+func containsCert(data []byte, cert *x509.Certificate) bool {
+	certs, err := triple.ParseCertsPEM(data)
+	if err != nil {
+		return false
+	}
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+func secretDataEqual(a, b map[string][]byte) bool {
+	for key, value := range a {
+		if !bytes.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package certificates contains a controller that keeps an inventory of the control plane certificates
+of every cluster and rotates the cluster CAs and the service account signing key on request.
+
+The inventory lists every certificate stored in a secret in the cluster namespace along with its
+validity. It is kept in the cluster status and exported as metrics.
+
+A rotation is requested by setting the certificate rotation status of a cluster to Pending. The
+rotation replaces the root CA, the front-proxy CA and the service account key in four steps:
+
+1. New CAs and a new service account key get created. The control plane trusts the CA bundles and the
+service account key bundle, which contain the current and the new ones. All certificates are still
+signed by the current CAs.
+2. Once the control plane got rolled out, a DaemonSet in the user cluster installs the root CA bundle
+on every node and replaces the kubelet client certificates with ones issued by the new root CA. The
+kube-controller-manager publishes the bundle in the service account tokens and the kube-root-ca.crt
+ConfigMap.
+3. Once all nodes and the service account tokens trust the bundle, the new CAs and key replace the
+current ones. All leaf certificates and kubeconfigs get regenerated by the regular reconciling. The
+apiserver serves the new root CA cross-signed by the old one, so kubeconfigs handed out before the
+rotation keep working. The old CAs and key stay trusted for the transition window.
+4. After the transition window the old CAs and key are removed from the bundles. Kubeconfigs handed
+out before the rotation stop working and have to be downloaded again.
+
+The inventory is refreshed once per hour and whenever the rotation moves on to the next step.
+*/
+package certificates
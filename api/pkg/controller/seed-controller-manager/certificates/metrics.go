@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	registerMetrics   sync.Once
+	certificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "kubermatic_cluster",
+			Name:      "certificate_expiry_timestamp_seconds",
+			Help:      "The time the certificates of the cluster control planes expire, as unix timestamp",
+		},
+		[]string{"cluster", "secret", "key"},
+	)
+)
+
+func init() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(certificateExpiry)
+	})
+}
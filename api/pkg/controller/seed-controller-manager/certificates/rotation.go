@@ -0,0 +1,320 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// timeFormat is used to tell until when the old CAs are trusted
+const timeFormat = "2006-01-02 15:04:05 MST"
+
+var (
+	// rotatedCASecrets are the secrets containing the CAs which get replaced by a rotation
+	rotatedCASecrets = []string{resources.CASecretName, resources.FrontProxyCASecretName}
+
+	// bundleConsumers are the deployments which must trust the old and the new CAs before
+	// the new CAs can be used
+	bundleConsumers = []string{
+		resources.ApiserverDeploymentName,
+		resources.ControllerManagerDeploymentName,
+		resources.SchedulerDeploymentName,
+	}
+)
+
+func (r *Reconciler) reconcileRotation(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Status.CertificateRotation == nil {
+		return nil, nil
+	}
+	rotation := cluster.Status.CertificateRotation
+	log = log.With("rotation-phase", rotation.Phase)
+
+	switch rotation.Phase {
+	case kubermaticv1.ClusterCertificateRotationPhasePending:
+		log.Info("Creating new CAs and service account key")
+		if err := r.createNextCertificates(ctx, cluster); err != nil {
+			return nil, err
+		}
+		if err := r.setRotationPhase(ctx, cluster, kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs,
+			"Waiting for the control plane to trust the new CAs"); err != nil {
+			return nil, err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CertificateRotationStarted",
+			"Created new CAs and service account key, the control plane trusts the old and the new ones")
+		return &reconcile.Result{RequeueAfter: pollInterval}, nil
+
+	case kubermaticv1.ClusterCertificateRotationPhaseTrustingNewCAs:
+		trusted, err := r.controlPlaneTrustsBundles(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if !trusted {
+			log.Debug("Waiting for the control plane to trust the new CAs")
+			return &reconcile.Result{RequeueAfter: pollInterval}, nil
+		}
+
+		log.Info("Distributing the CA bundles to the nodes and service account tokens")
+		if err := r.setRotationPhase(ctx, cluster, kubermaticv1.ClusterCertificateRotationPhaseDistributingCABundle,
+			"Waiting for the nodes and service account tokens to trust the new CAs"); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: pollInterval}, nil
+
+	case kubermaticv1.ClusterCertificateRotationPhaseDistributingCABundle:
+		distributed, message, err := r.reconcileCABundleDistribution(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to distribute the CA bundle: %v", err)
+		}
+		if !distributed {
+			log.Debug(message)
+			return &reconcile.Result{RequeueAfter: pollInterval}, nil
+		}
+
+		log.Info("Switching to the new CAs and service account key")
+		if err := r.promoteNextCertificates(ctx, cluster); err != nil {
+			return nil, err
+		}
+		until := r.now().Add(rotation.TransitionWindow.Duration)
+		if err := r.setRotationPhase(ctx, cluster, kubermaticv1.ClusterCertificateRotationPhaseTransitioning,
+			fmt.Sprintf("The old CAs and service account key are trusted until %s", until.UTC().Format(timeFormat))); err != nil {
+			return nil, err
+		}
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "CertificateRotationSwitched",
+			"Switched to the new CAs and service account key, the old ones are trusted until %s", until.UTC().Format(timeFormat))
+		if err := r.removeCABundleAgent(ctx, cluster); err != nil {
+			// Retried once the rotation completed
+			log.Warnw("Failed to remove the CA bundle agent", zap.Error(err))
+		}
+		return &reconcile.Result{RequeueAfter: rotation.TransitionWindow.Duration}, nil
+
+	case kubermaticv1.ClusterCertificateRotationPhaseTransitioning:
+		until := rotation.LastTransitionTime.Add(rotation.TransitionWindow.Duration)
+		if remaining := until.Sub(r.now()); remaining > 0 {
+			return &reconcile.Result{RequeueAfter: remaining}, nil
+		}
+
+		log.Info("Removing the old CAs and service account key")
+		if err := r.removeCABundleAgent(ctx, cluster); err != nil {
+			return nil, err
+		}
+		if err := r.removePreviousCertificates(ctx, cluster); err != nil {
+			return nil, err
+		}
+		if err := r.setRotationPhase(ctx, cluster, kubermaticv1.ClusterCertificateRotationPhaseCompleted,
+			"The old CAs and service account key are not trusted anymore"); err != nil {
+			return nil, err
+		}
+		r.recorder.Event(cluster, corev1.EventTypeNormal, "CertificateRotationCompleted",
+			"The old CAs and service account key are not trusted anymore")
+	}
+
+	return nil, nil
+}
+
+// createNextCertificates creates the new CAs and service account key and adds them to the bundles.
+// Already existing ones are kept, so this can be safely retried.
+func (r *Reconciler) createNextCertificates(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	for _, name := range rotatedCASecrets {
+		if err := r.updateSecret(ctx, cluster, name, func(data map[string][]byte) error {
+			if _, exists := data[resources.CANextCertSecretKey]; !exists {
+				certs, err := triple.ParseCertsPEM(data[resources.CACertSecretKey])
+				if err != nil {
+					return fmt.Errorf("failed to parse the current CA: %v", err)
+				}
+				ca, err := triple.NewCA(certs[0].Subject.CommonName)
+				if err != nil {
+					return fmt.Errorf("failed to create CA: %v", err)
+				}
+				data[resources.CANextCertSecretKey] = triple.EncodeCertPEM(ca.Cert)
+				data[resources.CANextKeySecretKey] = triple.EncodePrivateKeyPEM(ca.Key)
+			}
+			data[resources.CABundleSecretKey] = bundle(data[resources.CACertSecretKey], data[resources.CANextCertSecretKey])
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return r.updateSecret(ctx, cluster, resources.ServiceAccountKeySecretName, func(data map[string][]byte) error {
+		if _, exists := data[resources.ServiceAccountNextKeySecretKey]; !exists {
+			privateKey, publicKey, err := apiserver.NewServiceAccountKey()
+			if err != nil {
+				return fmt.Errorf("failed to create service account key: %v", err)
+			}
+			data[resources.ServiceAccountNextKeySecretKey] = privateKey
+			data[resources.ServiceAccountNextPublicKey] = publicKey
+		}
+		data[resources.ServiceAccountKeyBundleSecretKey] = bundle(data[resources.ServiceAccountKeyPublicKey], data[resources.ServiceAccountNextPublicKey])
+		return nil
+	})
+}
+
+// promoteNextCertificates replaces the current CAs and service account key with the new ones, while
+// keeping the old ones in the bundles. The new root CA gets cross-signed by the old one, so clients
+// which only trust the old root CA can still verify the apiserver during the transition window.
+func (r *Reconciler) promoteNextCertificates(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	for _, name := range rotatedCASecrets {
+		if err := r.updateSecret(ctx, cluster, name, func(data map[string][]byte) error {
+			nextCert, exists := data[resources.CANextCertSecretKey]
+			if !exists {
+				return nil
+			}
+			if name == resources.CASecretName {
+				currentCA, err := triple.ParseRSAKeyPair(data[resources.CACertSecretKey], data[resources.CAKeySecretKey])
+				if err != nil {
+					return fmt.Errorf("failed to parse the current CA: %v", err)
+				}
+				nextCA, err := triple.ParseCertsPEM(nextCert)
+				if err != nil {
+					return fmt.Errorf("failed to parse the new CA: %v", err)
+				}
+				crossSigned, err := crossSign(nextCA[0], currentCA)
+				if err != nil {
+					return fmt.Errorf("failed to cross-sign the new CA: %v", err)
+				}
+				data[resources.CACrossSignedCertSecretKey] = crossSigned
+			}
+			data[resources.CABundleSecretKey] = bundle(nextCert, data[resources.CACertSecretKey])
+			data[resources.CACertSecretKey] = nextCert
+			data[resources.CAKeySecretKey] = data[resources.CANextKeySecretKey]
+			delete(data, resources.CANextCertSecretKey)
+			delete(data, resources.CANextKeySecretKey)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return r.updateSecret(ctx, cluster, resources.ServiceAccountKeySecretName, func(data map[string][]byte) error {
+		nextPublicKey, exists := data[resources.ServiceAccountNextPublicKey]
+		if !exists {
+			return nil
+		}
+		data[resources.ServiceAccountKeyBundleSecretKey] = bundle(nextPublicKey, data[resources.ServiceAccountKeyPublicKey])
+		data[resources.ServiceAccountKeyPublicKey] = nextPublicKey
+		data[resources.ServiceAccountKeySecretKey] = data[resources.ServiceAccountNextKeySecretKey]
+		delete(data, resources.ServiceAccountNextKeySecretKey)
+		delete(data, resources.ServiceAccountNextPublicKey)
+		return nil
+	})
+}
+
+// removePreviousCertificates removes the old CAs and service account key from the bundles
+func (r *Reconciler) removePreviousCertificates(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	for _, name := range rotatedCASecrets {
+		if err := r.updateSecret(ctx, cluster, name, func(data map[string][]byte) error {
+			data[resources.CABundleSecretKey] = data[resources.CACertSecretKey]
+			delete(data, resources.CACrossSignedCertSecretKey)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return r.updateSecret(ctx, cluster, resources.ServiceAccountKeySecretName, func(data map[string][]byte) error {
+		data[resources.ServiceAccountKeyBundleSecretKey] = data[resources.ServiceAccountKeyPublicKey]
+		return nil
+	})
+}
+
+// controlPlaneTrustsBundles checks if all components which verify certificates or service account tokens
+// got rolled out with the bundles
+func (r *Reconciler) controlPlaneTrustsBundles(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	for _, name := range bundleConsumers {
+		nn := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, nn, deployment); err != nil {
+			return false, ctrlruntimeclient.IgnoreNotFound(err)
+		}
+		if !usesBundle(deployment) || deployment.Status.ObservedGeneration < deployment.Generation {
+			return false, nil
+		}
+		status, err := resources.HealthyDeployment(ctx, r, nn, 1)
+		if err != nil {
+			return false, fmt.Errorf("failed to check the health of deployment %s: %v", name, err)
+		}
+		if status != kubermaticv1.HealthStatusUp {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func usesBundle(deployment *appsv1.Deployment) bool {
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, arg := range append(container.Command, container.Args...) {
+			if strings.HasSuffix(arg, "/"+resources.CABundleSecretKey) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Reconciler) updateSecret(ctx context.Context, cluster *kubermaticv1.Cluster, name string, modify func(map[string][]byte) error) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+		return fmt.Errorf("failed to get secret %s: %v", name, err)
+	}
+
+	oldSecret := secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if err := modify(secret.Data); err != nil {
+		return fmt.Errorf("failed to update secret %s: %v", name, err)
+	}
+	if err := r.Patch(ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret)); err != nil {
+		return fmt.Errorf("failed to patch secret %s: %v", name, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setRotationPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterCertificateRotationPhase, message string) error {
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.CertificateRotation.Phase = phase
+		c.Status.CertificateRotation.Message = message
+		c.Status.CertificateRotation.LastTransitionTime = metav1.NewTime(r.now())
+	})
+}
+
+// bundle concatenates the given PEM encoded certificates or keys
+func bundle(pems ...[]byte) []byte {
+	var buf bytes.Buffer
+	for _, pem := range pems {
+		buf.Write(bytes.TrimSpace(pem))
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
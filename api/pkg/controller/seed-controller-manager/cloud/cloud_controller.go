@@ -66,10 +66,11 @@ var _ reconcile.Reconciler = &Reconciler{}
 
 type Reconciler struct {
 	client.Client
-	log        *zap.SugaredLogger
-	recorder   record.EventRecorder
-	seedGetter provider.SeedGetter
-	workerName string
+	log          *zap.SugaredLogger
+	recorder     record.EventRecorder
+	seedGetter   provider.SeedGetter
+	workerName   string
+	secretStores *provider.SecretStores
 }
 
 func Add(
@@ -78,13 +79,15 @@ func Add(
 	numWorkers int,
 	seedGetter provider.SeedGetter,
 	workerName string,
+	secretStores *provider.SecretStores,
 ) error {
 	reconciler := &Reconciler{
-		Client:     mgr.GetClient(),
-		log:        log.Named(ControllerName),
-		recorder:   mgr.GetEventRecorderFor(ControllerName),
-		seedGetter: seedGetter,
-		workerName: workerName,
+		Client:       mgr.GetClient(),
+		log:          log.Named(ControllerName),
+		recorder:     mgr.GetEventRecorderFor(ControllerName),
+		seedGetter:   seedGetter,
+		workerName:   workerName,
+		secretStores: secretStores,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
@@ -149,7 +152,9 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		finalizers := sets.NewString(cluster.Finalizers...)
 		if finalizers.Has(kubermaticapiv1.InClusterLBCleanupFinalizer) ||
 			finalizers.Has(kubermaticapiv1.InClusterPVCleanupFinalizer) ||
-			finalizers.Has(kubermaticapiv1.NodeDeletionFinalizer) {
+			finalizers.Has(kubermaticapiv1.NodeDeletionFinalizer) ||
+			finalizers.Has(kubermaticapiv1.FinalEtcdBackupFinalizer) ||
+			cluster.Spec.DeletionProtection {
 			return &reconcile.Result{RequeueAfter: 5 * time.Second}, nil
 		}
 		if _, err := prov.CleanUpCloudProvider(cluster, r.updateCluster); err != nil {
@@ -249,5 +254,5 @@ func (r *Reconciler) updateCluster(name string, modify func(*kubermaticv1.Cluste
 }
 
 func (r *Reconciler) getGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
-	return provider.SecretKeySelectorValueFuncFactory(context.Background(), r.Client)(configVar, key)
+	return provider.SecretKeySelectorValueFuncFactory(context.Background(), r.Client, r.secretStores)(configVar, key)
 }
@@ -0,0 +1,32 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package encryption contains a controller that manages the keys the apiserver encrypts Secrets with.
+
+Changing the key the apiserver encrypts with is done in several steps, so that apiserver replicas
+running with different configurations can always read all Secrets:
+
+1. The new key is added to the configuration for decrypting only.
+2. The new key is moved to the front of the configuration, so it is used for encrypting.
+3. All Secrets of the user cluster get rewritten, which encrypts them with the new key.
+4. The old keys are removed from the configuration.
+
+The controller waits for the apiserver to be rolled out with the new configuration between each step.
+Enabling encryption replaces the identity provider, disabling it goes back to the identity provider and
+rotating the key replaces the current key.
+*/
+package encryption
@@ -0,0 +1,408 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_encryption_controller"
+
+	// pollInterval is used while waiting for the apiserver to be rolled out
+	pollInterval = 10 * time.Second
+)
+
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+// Reconciler manages the keys the apiserver encrypts Secrets with
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider userClusterConnectionProvider
+	now                           func() time.Time
+}
+
+// Add creates a new encryption controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string,
+	userClusterConnectionProvider userClusterConnectionProvider) error {
+	reconciler := &Reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.IsOpenshift() || cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	// Secrets can only be rewritten while the control plane is running
+	if cluster.Status.NamespaceName == "" || cluster.IsControlPlaneHibernated() {
+		return nil, nil
+	}
+
+	desiredProvider := desiredProvider(cluster)
+	status := cluster.Status.Encryption
+	if status == nil {
+		if desiredProvider == kubermaticv1.ClusterEncryptionProviderIdentity {
+			return nil, r.deleteSecret(ctx, cluster)
+		}
+		// Secrets written so far are stored in plaintext
+		keys := []kubermaticv1.ClusterEncryptionKey{{Provider: kubermaticv1.ClusterEncryptionProviderIdentity}}
+		return r.startKeyChange(ctx, log, cluster, keys, desiredProvider)
+	}
+	log = log.With("encryption-phase", status.Phase)
+
+	switch status.Phase {
+	case kubermaticv1.ClusterEncryptionPhaseActive:
+		if len(status.Keys) > 0 && status.Keys[0].Provider == desiredProvider {
+			return nil, nil
+		}
+		return r.startKeyChange(ctx, log, cluster, status.Keys, desiredProvider)
+
+	case kubermaticv1.ClusterEncryptionPhasePending:
+		return r.startKeyChange(ctx, log, cluster, status.Keys, desiredProvider)
+
+	case kubermaticv1.ClusterEncryptionPhaseAddingKey:
+		if rolledOut, err := r.apiserverRolledOut(ctx, cluster); err != nil || !rolledOut {
+			return &reconcile.Result{RequeueAfter: pollInterval}, err
+		}
+		// The key which got added last is the new one
+		newKey := status.Keys[len(status.Keys)-1]
+		keys := append([]kubermaticv1.ClusterEncryptionKey{newKey}, status.Keys[:len(status.Keys)-1]...)
+		log.Infow("Activating encryption key", "key", newKey.Name)
+		if err := r.updateSecret(ctx, cluster, keys, nil); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: pollInterval}, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseActivatingKey, keys,
+			fmt.Sprintf("Waiting for the apiserver to encrypt Secrets with %s", describeKey(newKey)))
+
+	case kubermaticv1.ClusterEncryptionPhaseActivatingKey:
+		if rolledOut, err := r.apiserverRolledOut(ctx, cluster); err != nil || !rolledOut {
+			return &reconcile.Result{RequeueAfter: pollInterval}, err
+		}
+		return nil, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseRewritingSecrets, status.Keys,
+			fmt.Sprintf("Rewriting all Secrets with %s", describeKey(status.Keys[0])))
+
+	case kubermaticv1.ClusterEncryptionPhaseRewritingSecrets:
+		count, err := r.rewriteSecrets(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		log.Infow("Rewrote Secrets", "count", count)
+
+		keys := []kubermaticv1.ClusterEncryptionKey{status.Keys[0]}
+		// Keeping the identity provider allows reading Secrets which were written before encryption got
+		// enabled, e.g. when restoring an old etcd backup
+		if status.Keys[0].Provider != kubermaticv1.ClusterEncryptionProviderIdentity {
+			keys = append(keys, kubermaticv1.ClusterEncryptionKey{Provider: kubermaticv1.ClusterEncryptionProviderIdentity})
+		}
+		if err := r.updateSecret(ctx, cluster, keys, nil); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: pollInterval}, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseRemovingOldKeys, keys,
+			fmt.Sprintf("Rewrote %d Secrets, waiting for the apiserver to drop the old keys", count))
+
+	case kubermaticv1.ClusterEncryptionPhaseRemovingOldKeys:
+		if rolledOut, err := r.apiserverRolledOut(ctx, cluster); err != nil || !rolledOut {
+			return &reconcile.Result{RequeueAfter: pollInterval}, err
+		}
+		if status.Keys[0].Provider == kubermaticv1.ClusterEncryptionProviderIdentity {
+			log.Info("Encryption disabled")
+			r.recorder.Event(cluster, corev1.EventTypeNormal, "EncryptionDisabled", "All Secrets are stored in plaintext")
+			// The configuration secret gets deleted once the apiserver does not use it anymore
+			return &reconcile.Result{RequeueAfter: pollInterval}, r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+				c.Status.Encryption = nil
+			})
+		}
+		return nil, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseActive, status.Keys,
+			fmt.Sprintf("All Secrets are encrypted with %s", describeKey(status.Keys[0])))
+	}
+
+	return nil, nil
+}
+
+// startKeyChange adds a new key for the given provider for decrypting only. When switching back to the identity
+// provider, which is always part of the configuration, it is used for encrypting right away.
+func (r *Reconciler) startKeyChange(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, keys []kubermaticv1.ClusterEncryptionKey, provider kubermaticv1.ClusterEncryptionProvider) (*reconcile.Result, error) {
+	if provider == kubermaticv1.ClusterEncryptionProviderIdentity {
+		newKeys := []kubermaticv1.ClusterEncryptionKey{{Provider: kubermaticv1.ClusterEncryptionProviderIdentity}}
+		for _, key := range keys {
+			if key.Provider != kubermaticv1.ClusterEncryptionProviderIdentity {
+				newKeys = append(newKeys, key)
+			}
+		}
+		log.Info("Disabling encryption")
+		if err := r.updateSecret(ctx, cluster, newKeys, nil); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: pollInterval}, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseActivatingKey, newKeys,
+			"Waiting for the apiserver to store Secrets in plaintext")
+	}
+
+	material, err := apiserver.NewEncryptionKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryption key: %v", err)
+	}
+	newKey := kubermaticv1.ClusterEncryptionKey{
+		Name:         fmt.Sprintf("key-%d", r.now().Unix()),
+		Provider:     provider,
+		CreationTime: metav1.NewTime(r.now()),
+	}
+	newKeys := append(append([]kubermaticv1.ClusterEncryptionKey{}, keys...), newKey)
+
+	log.Infow("Adding encryption key", "key", newKey.Name, "provider", provider)
+	if err := r.updateSecret(ctx, cluster, newKeys, map[string][]byte{newKey.Name: material}); err != nil {
+		return nil, err
+	}
+	return &reconcile.Result{RequeueAfter: pollInterval}, r.setStatus(ctx, cluster, kubermaticv1.ClusterEncryptionPhaseAddingKey, newKeys,
+		fmt.Sprintf("Waiting for the apiserver to be able to decrypt Secrets with %s", describeKey(newKey)))
+}
+
+// rewriteSecrets updates all Secrets of the user cluster without changing them, which makes the apiserver
+// store them encrypted with the current key
+func (r *Reconciler) rewriteSecrets(ctx context.Context, cluster *kubermaticv1.Cluster) (int, error) {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := userClusterClient.List(ctx, secrets); err != nil {
+		return 0, fmt.Errorf("failed to list secrets: %v", err)
+	}
+
+	count := 0
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if err := userClusterClient.Update(ctx, secret); err != nil {
+			// A secret which was changed in the meantime got stored with the current key already
+			if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+				continue
+			}
+			return count, fmt.Errorf("failed to rewrite secret %s/%s: %v", secret.Namespace, secret.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// apiserverRolledOut checks if the apiserver got rolled out with the current encryption configuration
+func (r *Reconciler) apiserverRolledOut(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EncryptionConfigurationSecretName}, secret); err != nil {
+		return false, fmt.Errorf("failed to get encryption configuration: %v", err)
+	}
+
+	nn := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, nn, deployment); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+	revisionLabel := fmt.Sprintf("%s-secret-revision", resources.EncryptionConfigurationSecretName)
+	if deployment.Spec.Template.Labels[revisionLabel] != secret.ResourceVersion || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, nil
+	}
+
+	status, err := resources.HealthyDeployment(ctx, r, nn, 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the health of the apiserver: %v", err)
+	}
+	return status == kubermaticv1.HealthStatusUp, nil
+}
+
+// updateSecret stores the given keys along with the apiserver configuration. Keys which are not
+// configured anymore are removed from the secret.
+func (r *Reconciler) updateSecret(ctx context.Context, cluster *kubermaticv1.Cluster, keys []kubermaticv1.ClusterEncryptionKey, newMaterial map[string][]byte) error {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EncryptionConfigurationSecretName}, secret)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get encryption configuration: %v", err)
+	}
+	exists := err == nil
+
+	data := map[string][]byte{}
+	for _, key := range keys {
+		if key.Provider == kubermaticv1.ClusterEncryptionProviderIdentity {
+			continue
+		}
+		if material, ok := newMaterial[key.Name]; ok {
+			data[key.Name] = material
+		} else if material, ok := secret.Data[key.Name]; ok {
+			data[key.Name] = material
+		}
+	}
+	config, err := apiserver.EncryptionConfiguration(keys, data)
+	if err != nil {
+		return fmt.Errorf("failed to render encryption configuration: %v", err)
+	}
+	data[resources.EncryptionConfigurationSecretKey] = config
+
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Status.NamespaceName,
+				Name:      resources.EncryptionConfigurationSecretName,
+			},
+			Data: data,
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create encryption configuration: %v", err)
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(secret.Data, data) {
+		return nil
+	}
+	secret.Data = data
+	if err := r.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update encryption configuration: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteSecret(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Status.NamespaceName,
+			Name:      resources.EncryptionConfigurationSecretName,
+		},
+	}
+	if err := r.Delete(ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete encryption configuration: %v", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setStatus(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterEncryptionPhase, keys []kubermaticv1.ClusterEncryptionKey, message string) error {
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		if c.Status.Encryption == nil {
+			c.Status.Encryption = &kubermaticv1.ClusterEncryptionStatus{}
+		}
+		c.Status.Encryption.Phase = phase
+		c.Status.Encryption.Keys = keys
+		c.Status.Encryption.Message = message
+		c.Status.Encryption.LastTransitionTime = metav1.NewTime(r.now())
+	}); err != nil {
+		return err
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Encryption"+string(phase), message)
+	return nil
+}
+
+func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	oldCluster := cluster.DeepCopy()
+	modify(cluster)
+	if reflect.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	return r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+}
+
+// desiredProvider returns the provider the apiserver should encrypt Secrets with
+func desiredProvider(cluster *kubermaticv1.Cluster) kubermaticv1.ClusterEncryptionProvider {
+	if cluster.Spec.Encryption == nil || !cluster.Spec.Encryption.Enabled {
+		return kubermaticv1.ClusterEncryptionProviderIdentity
+	}
+	if cluster.Spec.Encryption.Provider == "" {
+		return kubermaticv1.ClusterEncryptionProviderAESCBC
+	}
+	return cluster.Spec.Encryption.Provider
+}
+
+func describeKey(key kubermaticv1.ClusterEncryptionKey) string {
+	if key.Provider == kubermaticv1.ClusterEncryptionProviderIdentity {
+		return "the identity provider"
+	}
+	return fmt.Sprintf("the %s key %s", key.Provider, key.Name)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	clusterName = "secretive"
+	clusterNS   = "cluster-secretive"
+)
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeClientProvider) GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+type testEnv struct {
+	r                 *Reconciler
+	seedClient        ctrlruntimeclient.Client
+	userClusterClient ctrlruntimeclient.Client
+	now               time.Time
+}
+
+func newTestEnv(encryption *kubermaticv1.ClusterEncryptionSpec) *testEnv {
+	env := &testEnv{
+		seedClient: fakectrlruntimeclient.NewFakeClient(
+			&kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec:       kubermaticv1.ClusterSpec{Encryption: encryption},
+				Status:     kubermaticv1.ClusterStatus{NamespaceName: clusterNS},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.ApiserverDeploymentName},
+				Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32Ptr(2)},
+				Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+			},
+		),
+		userClusterClient: fakectrlruntimeclient.NewFakeClient(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db-password"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "token"}},
+		),
+		now: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	env.r = &Reconciler{
+		Client:                        env.seedClient,
+		log:                           kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		recorder:                      record.NewFakeRecorder(100),
+		userClusterConnectionProvider: &fakeClientProvider{client: env.userClusterClient},
+		now:                           func() time.Time { return env.now },
+	}
+	return env
+}
+
+func (env *testEnv) cluster(t *testing.T) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{}
+	if err := env.seedClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return cluster
+}
+
+func (env *testEnv) updateCluster(t *testing.T, modify func(*kubermaticv1.Cluster)) {
+	cluster := env.cluster(t)
+	modify(cluster)
+	if err := env.seedClient.Update(context.Background(), cluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+}
+
+// rolloutApiserver updates the apiserver deployment like the cluster controller does when the
+// encryption configuration changes.
+func (env *testEnv) rolloutApiserver(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{}
+	if err := env.seedClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.EncryptionConfigurationSecretName}, secret); err != nil {
+		t.Fatalf("failed to get encryption configuration: %v", err)
+	}
+	deployment := &appsv1.Deployment{}
+	if err := env.seedClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverDeploymentName}, deployment); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	deployment.Spec.Template.Labels = map[string]string{
+		fmt.Sprintf("%s-secret-revision", resources.EncryptionConfigurationSecretName): secret.ResourceVersion,
+	}
+	if err := env.seedClient.Update(ctx, deployment); err != nil {
+		t.Fatalf("failed to update deployment: %v", err)
+	}
+}
+
+// reconcileUntil reconciles the cluster, rolling out the apiserver in between, until the
+// encryption status matches.
+func (env *testEnv) reconcileUntil(t *testing.T, done func(*kubermaticv1.ClusterEncryptionStatus) bool) {
+	for i := 0; i < 20; i++ {
+		if _, err := env.r.reconcile(context.Background(), env.r.log, env.cluster(t)); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if done(env.cluster(t).Status.Encryption) {
+			return
+		}
+		env.rolloutApiserver(t)
+	}
+	t.Fatalf("encryption did not reach the expected state, status: %+v", env.cluster(t).Status.Encryption)
+}
+
+func (env *testEnv) configuration(t *testing.T) string {
+	secret := &corev1.Secret{}
+	if err := env.seedClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: resources.EncryptionConfigurationSecretName}, secret); err != nil {
+		t.Fatalf("failed to get encryption configuration: %v", err)
+	}
+	return string(secret.Data[resources.EncryptionConfigurationSecretKey])
+}
+
+func isActive(status *kubermaticv1.ClusterEncryptionStatus) bool {
+	return status != nil && status.Phase == kubermaticv1.ClusterEncryptionPhaseActive
+}
+
+func TestEncryptionLifecycle(t *testing.T) {
+	env := newTestEnv(&kubermaticv1.ClusterEncryptionSpec{Enabled: true})
+
+	// The new key must only be used for encrypting once all apiserver replicas can decrypt with it
+	if _, err := env.r.reconcile(context.Background(), env.r.log, env.cluster(t)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	status := env.cluster(t).Status.Encryption
+	if status == nil || status.Phase != kubermaticv1.ClusterEncryptionPhaseAddingKey {
+		t.Fatalf("expected phase %q, got %+v", kubermaticv1.ClusterEncryptionPhaseAddingKey, status)
+	}
+	if config := env.configuration(t); strings.Index(config, "identity") > strings.Index(config, "aescbc") {
+		t.Errorf("expected the identity provider to be used for encrypting while the key gets added, got:\n%s", config)
+	}
+
+	env.reconcileUntil(t, isActive)
+	keys := env.cluster(t).Status.Encryption.Keys
+	if len(keys) != 2 || keys[0].Provider != kubermaticv1.ClusterEncryptionProviderAESCBC || keys[1].Provider != kubermaticv1.ClusterEncryptionProviderIdentity {
+		t.Fatalf("expected an aescbc key followed by the identity provider, got %+v", keys)
+	}
+	firstKey := keys[0].Name
+
+	// Switching the provider rotates the key
+	env.now = env.now.Add(time.Hour)
+	env.updateCluster(t, func(c *kubermaticv1.Cluster) {
+		c.Spec.Encryption.Provider = kubermaticv1.ClusterEncryptionProviderSecretbox
+	})
+	env.reconcileUntil(t, func(status *kubermaticv1.ClusterEncryptionStatus) bool {
+		return isActive(status) && status.Keys[0].Provider == kubermaticv1.ClusterEncryptionProviderSecretbox
+	})
+	keys = env.cluster(t).Status.Encryption.Keys
+	if len(keys) != 2 || keys[0].Name == firstKey {
+		t.Fatalf("expected the old key to be replaced, got %+v", keys)
+	}
+	if config := env.configuration(t); strings.Contains(config, firstKey) {
+		t.Errorf("expected the old key to be removed from the configuration, got:\n%s", config)
+	}
+
+	env.updateCluster(t, func(c *kubermaticv1.Cluster) {
+		c.Spec.Encryption.Enabled = false
+	})
+	env.reconcileUntil(t, func(status *kubermaticv1.ClusterEncryptionStatus) bool {
+		return status == nil
+	})
+	if _, err := env.r.reconcile(context.Background(), env.r.log, env.cluster(t)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	err := env.seedClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: resources.EncryptionConfigurationSecretName}, &corev1.Secret{})
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("expected the encryption configuration to be deleted, got error %v", err)
+	}
+}
+
+func TestRotationRequest(t *testing.T) {
+	env := newTestEnv(&kubermaticv1.ClusterEncryptionSpec{Enabled: true})
+	env.reconcileUntil(t, isActive)
+	firstKey := env.cluster(t).Status.Encryption.Keys[0].Name
+
+	env.now = env.now.Add(time.Hour)
+	env.updateCluster(t, func(c *kubermaticv1.Cluster) {
+		c.Status.Encryption.Phase = kubermaticv1.ClusterEncryptionPhasePending
+	})
+	env.reconcileUntil(t, isActive)
+
+	keys := env.cluster(t).Status.Encryption.Keys
+	if keys[0].Name == firstKey || keys[0].Provider != kubermaticv1.ClusterEncryptionProviderAESCBC {
+		t.Errorf("expected a new aescbc key, got %+v", keys)
+	}
+}
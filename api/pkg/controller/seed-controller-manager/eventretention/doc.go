@@ -0,0 +1,24 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package eventretention contains a controller that deletes the ClusterEvents exported from the user
+clusters once they expired.
+
+ClusterEvents are not owned by their cluster, so the events of deleted clusters are still available
+until the retention configured in the event export settings of the cluster elapsed.
+*/
+package eventretention
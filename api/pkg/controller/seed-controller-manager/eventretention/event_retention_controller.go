@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventretention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_event_retention_controller"
+)
+
+// Reconciler deletes expired ClusterEvents
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log *zap.SugaredLogger
+	now func() time.Time
+}
+
+// Add creates a new event retention controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int) error {
+	reconciler := &Reconciler{
+		Client: mgr.GetClient(),
+		log:    log.Named(ControllerName),
+		now:    time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.ClusterEvent{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clusterEvent := &kubermaticv1.ClusterEvent{}
+	if err := r.Get(ctx, request.NamespacedName, clusterEvent); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The exporter extends the expiry whenever the event occurs again
+	if remaining := clusterEvent.Spec.ExpiresAt.Sub(r.now()); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	r.log.Debugw("Deleting expired event", "clusterevent", clusterEvent.Name, "cluster", clusterEvent.Spec.Cluster)
+	if err := r.Delete(ctx, clusterEvent); err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, fmt.Errorf("failed to delete expired event: %v", err)
+	}
+	return reconcile.Result{}, nil
+}
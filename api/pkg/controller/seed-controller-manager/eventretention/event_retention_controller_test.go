@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventretention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	name := types.NamespacedName{Name: "abcdefgh.1234"}
+	client := fake.NewFakeClient(&kubermaticv1.ClusterEvent{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name},
+		Spec: kubermaticv1.ClusterEventSpec{
+			Cluster:   "abcdefgh",
+			ExpiresAt: metav1.NewTime(now.Add(time.Hour)),
+		},
+	})
+	r := &Reconciler{
+		Client: client,
+		log:    kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		now:    func() time.Time { return now },
+	}
+
+	result, err := r.Reconcile(reconcile.Request{NamespacedName: name})
+	if err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Errorf("expected the event to be checked again once it expires, got requeue after %v", result.RequeueAfter)
+	}
+	if err := client.Get(context.Background(), name, &kubermaticv1.ClusterEvent{}); err != nil {
+		t.Fatalf("expected the event to be kept until it expires: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := r.Reconcile(reconcile.Request{NamespacedName: name}); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := client.Get(context.Background(), name, &kubermaticv1.ClusterEvent{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected the expired event to be deleted, got %v", err)
+	}
+}
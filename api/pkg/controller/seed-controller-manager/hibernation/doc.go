@@ -0,0 +1,28 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package hibernation contains a controller that scales user clusters to zero and wakes them up again.
+
+Hibernating a cluster first records the replicas of all MachineDeployments in the user cluster and
+scales them to zero. Once all machines are gone, the Deployments in the cluster namespace and the etcd
+StatefulSet are scaled to zero as well. Resuming restores the control plane first and the
+MachineDeployments once the API server is available again. The recorded replicas are kept in the
+cluster status, so a hibernation survives controller restarts.
+
+Hibernation is requested via spec.hibernation.hibernated, either directly or by a schedule.
+*/
+package hibernation
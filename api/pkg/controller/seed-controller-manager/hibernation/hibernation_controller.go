@@ -0,0 +1,528 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_hibernation_controller"
+
+	// machineDeploymentNamespace is the only namespace Kubermatic creates MachineDeployments in
+	machineDeploymentNamespace = metav1.NamespaceSystem
+
+	// pollInterval is used while waiting for pods and machines to come up or go away
+	pollInterval = 10 * time.Second
+)
+
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+// Reconciler hibernates and resumes clusters
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider userClusterConnectionProvider
+	now                           func() time.Time
+}
+
+// Add creates a new hibernation controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string,
+	userClusterConnectionProvider userClusterConnectionProvider) error {
+	reconciler := &Reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.IsOpenshift() {
+		return nil, nil
+	}
+
+	scheduleResult, err := r.reconcileSchedule(ctx, log, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate hibernation schedule: %v", err)
+	}
+
+	// A cluster that gets deleted needs its control plane to clean up the resources in the
+	// user cluster, its machines are deleted anyway.
+	deleting := cluster.DeletionTimestamp != nil
+	hibernate := cluster.IsHibernationRequested() && !deleting
+
+	var phase kubermaticv1.ClusterHibernationPhase
+	if cluster.Status.Hibernation != nil {
+		phase = cluster.Status.Hibernation.Phase
+	}
+	log = log.With("phase", phase)
+
+	var result *reconcile.Result
+	switch phase {
+	case "":
+		if hibernate {
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseHibernatingMachines)
+		}
+	case kubermaticv1.ClusterHibernationPhaseHibernatingMachines:
+		if hibernate {
+			result, err = r.hibernateMachines(ctx, log, cluster)
+		} else {
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseResumingMachines)
+		}
+	case kubermaticv1.ClusterHibernationPhaseHibernatingControlPlane:
+		if hibernate {
+			result, err = r.hibernateControlPlane(ctx, log, cluster)
+		} else {
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseResumingControlPlane)
+		}
+	case kubermaticv1.ClusterHibernationPhaseHibernated:
+		if !hibernate {
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseResumingControlPlane)
+		}
+	case kubermaticv1.ClusterHibernationPhaseResumingControlPlane:
+		if hibernate {
+			// Machines have not been restored yet, so only the control plane needs to go down again
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseHibernatingControlPlane)
+		} else {
+			result, err = r.resumeControlPlane(ctx, log, cluster)
+		}
+	case kubermaticv1.ClusterHibernationPhaseResumingMachines:
+		if hibernate {
+			err = r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseHibernatingMachines)
+		} else if !deleting {
+			err = r.resumeMachines(ctx, log, cluster)
+		}
+	default:
+		return nil, fmt.Errorf("unknown hibernation phase %q", phase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return earliest(result, scheduleResult), nil
+}
+
+// reconcileSchedule flips spec.hibernation.hibernated if a scheduled hibernation or resume
+// happened since the schedule was last evaluated. If both happened, the later one wins.
+func (r *Reconciler) reconcileSchedule(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.Spec.Hibernation == nil || cluster.Spec.Hibernation.Schedule == nil {
+		return nil, nil
+	}
+	hibernateSchedule, err := cron.ParseStandard(cluster.Spec.Hibernation.Schedule.Hibernate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hibernate schedule: %v", err)
+	}
+	resumeSchedule, err := cron.ParseStandard(cluster.Spec.Hibernation.Schedule.Resume)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resume schedule: %v", err)
+	}
+
+	now := r.now()
+	var lastScheduleTime *metav1.Time
+	if cluster.Status.Hibernation != nil {
+		lastScheduleTime = cluster.Status.Hibernation.LastScheduleTime
+	}
+
+	// The first evaluation only records the time, otherwise the cluster would be
+	// hibernated or resumed right away based on events from the past.
+	hibernated := cluster.Spec.Hibernation.Hibernated
+	if lastScheduleTime != nil {
+		lastHibernate := lastActivation(hibernateSchedule, lastScheduleTime.Time, now)
+		lastResume := lastActivation(resumeSchedule, lastScheduleTime.Time, now)
+		switch {
+		case lastHibernate.IsZero() && lastResume.IsZero():
+		case lastHibernate.After(lastResume):
+			hibernated = true
+		default:
+			hibernated = false
+		}
+	}
+
+	if hibernated != cluster.Spec.Hibernation.Hibernated {
+		log.Infow("Hibernation schedule triggered", "hibernated", hibernated)
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "HibernationScheduled", "Schedule set hibernated to %t", hibernated)
+	}
+
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Spec.Hibernation.Hibernated = hibernated
+		if c.Status.Hibernation == nil {
+			c.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{}
+		}
+		c.Status.Hibernation.LastScheduleTime = &metav1.Time{Time: now}
+	}); err != nil {
+		return nil, err
+	}
+
+	next := hibernateSchedule.Next(now)
+	if nextResume := resumeSchedule.Next(now); nextResume.Before(next) {
+		next = nextResume
+	}
+	return &reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// lastActivation returns the last time the schedule fired after since and not after now, or
+// the zero time if it did not.
+func lastActivation(schedule cron.Schedule, since, now time.Time) time.Time {
+	var last time.Time
+	for t := schedule.Next(since); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		last = t
+	}
+	return last
+}
+
+func (r *Reconciler) hibernateMachines(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(machineDeploymentNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+
+	// Record the replicas before scaling anything, a MachineDeployment that already got scaled
+	// down by a previous, interrupted hibernation keeps its original value.
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		for i := range machineDeployments.Items {
+			md := &machineDeployments.Items[i]
+			replicas := machineDeploymentReplicas(md)
+			if _, recorded := c.Status.Hibernation.MachineDeploymentReplicas[md.Name]; recorded || replicas == 0 {
+				continue
+			}
+			if c.Status.Hibernation.MachineDeploymentReplicas == nil {
+				c.Status.Hibernation.MachineDeploymentReplicas = map[string]int32{}
+			}
+			c.Status.Hibernation.MachineDeploymentReplicas[md.Name] = replicas
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record MachineDeployment replicas: %v", err)
+	}
+
+	stopped := true
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if machineDeploymentReplicas(md) != 0 {
+			oldMD := md.DeepCopy()
+			md.Spec.Replicas = utilpointer.Int32Ptr(0)
+			if err := userClusterClient.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return nil, fmt.Errorf("failed to scale down MachineDeployment %q: %v", md.Name, err)
+			}
+			log.Debugw("Scaled down MachineDeployment", "machinedeployment", md.Name)
+		}
+		if md.Status.Replicas != 0 {
+			stopped = false
+		}
+	}
+	if !stopped {
+		log.Debug("Waiting for machines to be deleted")
+		return &reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	return nil, r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseHibernatingControlPlane)
+}
+
+// hibernateControlPlane scales all Deployments in the cluster namespace to zero and the
+// etcd StatefulSet after them, so the API server never runs without its backend.
+func (r *Reconciler) hibernateControlPlane(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list Deployments: %v", err)
+	}
+
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		for _, deployment := range deployments.Items {
+			replicas := replicasOrDefault(deployment.Spec.Replicas)
+			if _, recorded := c.Status.Hibernation.DeploymentReplicas[deployment.Name]; recorded || replicas == 0 {
+				continue
+			}
+			if c.Status.Hibernation.DeploymentReplicas == nil {
+				c.Status.Hibernation.DeploymentReplicas = map[string]int32{}
+			}
+			c.Status.Hibernation.DeploymentReplicas[deployment.Name] = replicas
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record Deployment replicas: %v", err)
+	}
+
+	stopped := true
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if err := r.scaleDeployment(ctx, deployment, 0); err != nil {
+			return nil, err
+		}
+		if deployment.Status.Replicas != 0 {
+			stopped = false
+		}
+	}
+	if !stopped {
+		log.Debug("Waiting for control plane Deployments to be scaled down")
+		return &reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	etcd := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get etcd StatefulSet: %v", err)
+		}
+	} else {
+		if cluster.Status.Hibernation.EtcdReplicas == nil && replicasOrDefault(etcd.Spec.Replicas) != 0 {
+			if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+				c.Status.Hibernation.EtcdReplicas = etcd.Spec.Replicas
+			}); err != nil {
+				return nil, fmt.Errorf("failed to record etcd replicas: %v", err)
+			}
+		}
+		if err := r.scaleStatefulSet(ctx, etcd, 0); err != nil {
+			return nil, err
+		}
+		if etcd.Status.Replicas != 0 {
+			log.Debug("Waiting for etcd to be scaled down")
+			return &reconcile.Result{RequeueAfter: pollInterval}, nil
+		}
+	}
+
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Hibernated", "Cluster has been hibernated")
+	return nil, r.setPhase(ctx, cluster, kubermaticv1.ClusterHibernationPhaseHibernated)
+}
+
+func (r *Reconciler) resumeControlPlane(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	ns := cluster.Status.NamespaceName
+
+	if replicas := cluster.Status.Hibernation.EtcdReplicas; replicas != nil {
+		etcd := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+			return nil, fmt.Errorf("failed to get etcd StatefulSet: %v", err)
+		}
+		if err := r.scaleStatefulSet(ctx, etcd, *replicas); err != nil {
+			return nil, err
+		}
+		if etcd.Status.ReadyReplicas < *replicas {
+			log.Debug("Waiting for etcd to become ready")
+			return &reconcile.Result{RequeueAfter: pollInterval}, nil
+		}
+	}
+
+	for name, replicas := range cluster.Status.Hibernation.DeploymentReplicas {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, deployment); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Deployment %q: %v", name, err)
+		}
+		if err := r.scaleDeployment(ctx, deployment, replicas); err != nil {
+			return nil, err
+		}
+	}
+
+	apiserverHealth, err := resources.HealthyDeployment(ctx, r, types.NamespacedName{Namespace: ns, Name: resources.ApiserverDeploymentName}, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API server health: %v", err)
+	}
+	if apiserverHealth != kubermaticv1.HealthStatusUp {
+		log.Debug("Waiting for the API server to become ready")
+		return &reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	return nil, r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Hibernation.EtcdReplicas = nil
+		c.Status.Hibernation.DeploymentReplicas = nil
+		c.Status.Hibernation.Phase = kubermaticv1.ClusterHibernationPhaseResumingMachines
+		c.Status.Hibernation.LastTransitionTime = metav1.NewTime(r.now())
+	})
+}
+
+func (r *Reconciler) resumeMachines(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) error {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	for name, replicas := range cluster.Status.Hibernation.MachineDeploymentReplicas {
+		md := &clusterv1alpha1.MachineDeployment{}
+		if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: machineDeploymentNamespace, Name: name}, md); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get MachineDeployment %q: %v", name, err)
+		}
+		if machineDeploymentReplicas(md) == 0 {
+			oldMD := md.DeepCopy()
+			md.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+			if err := userClusterClient.Patch(ctx, md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return fmt.Errorf("failed to scale up MachineDeployment %q: %v", name, err)
+			}
+			log.Debugw("Scaled up MachineDeployment", "machinedeployment", name, "replicas", replicas)
+		}
+	}
+
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Resumed", "Cluster has been resumed")
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Hibernation.MachineDeploymentReplicas = nil
+		c.Status.Hibernation.Phase = ""
+		c.Status.Hibernation.LastTransitionTime = metav1.NewTime(r.now())
+	})
+}
+
+func (r *Reconciler) scaleDeployment(ctx context.Context, deployment *appsv1.Deployment, replicas int32) error {
+	if replicasOrDefault(deployment.Spec.Replicas) == replicas {
+		return nil
+	}
+	oldDeployment := deployment.DeepCopy()
+	deployment.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+	if err := r.Patch(ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+		return fmt.Errorf("failed to scale Deployment %q to %d: %v", deployment.Name, replicas, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) scaleStatefulSet(ctx context.Context, statefulSet *appsv1.StatefulSet, replicas int32) error {
+	if replicasOrDefault(statefulSet.Spec.Replicas) == replicas {
+		return nil
+	}
+	oldStatefulSet := statefulSet.DeepCopy()
+	statefulSet.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+	if err := r.Patch(ctx, statefulSet, ctrlruntimeclient.MergeFrom(oldStatefulSet)); err != nil {
+		return fmt.Errorf("failed to scale StatefulSet %q to %d: %v", statefulSet.Name, replicas, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.ClusterHibernationPhase) error {
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		if c.Status.Hibernation == nil {
+			c.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{}
+		}
+		c.Status.Hibernation.Phase = phase
+		c.Status.Hibernation.LastTransitionTime = metav1.NewTime(r.now())
+	})
+}
+
+func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	oldCluster := cluster.DeepCopy()
+	modify(cluster)
+	if reflect.DeepEqual(oldCluster, cluster) {
+		return nil
+	}
+	return r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster))
+}
+
+// machineDeploymentReplicas returns the desired replicas of a MachineDeployment, which
+// defaults to one if unset.
+func machineDeploymentReplicas(md *clusterv1alpha1.MachineDeployment) int32 {
+	if md.Spec.Replicas == nil {
+		return 1
+	}
+	return *md.Spec.Replicas
+}
+
+// replicasOrDefault returns the desired replicas of a Deployment or StatefulSet, which
+// default to one if unset.
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// earliest returns the result that requeues first.
+func earliest(a, b *reconcile.Result) *reconcile.Result {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.RequeueAfter < b.RequeueAfter {
+		return a
+	}
+	return b
+}
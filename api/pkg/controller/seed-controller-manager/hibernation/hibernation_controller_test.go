@@ -0,0 +1,355 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	clusterName = "sleepy"
+	clusterNS   = "cluster-sleepy"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add clusterv1alpha1 scheme: %v", err))
+	}
+}
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeClientProvider) GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+func genCluster(hibernation *kubermaticv1.ClusterHibernationSpec) *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec:       kubermaticv1.ClusterSpec{Hibernation: hibernation},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: clusterNS},
+	}
+}
+
+func genDeployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: name},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32Ptr(replicas)},
+	}
+}
+
+func genMachineDeployment(name string, replicas int32) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: name},
+		Spec:       clusterv1alpha1.MachineDeploymentSpec{Replicas: utilpointer.Int32Ptr(replicas)},
+	}
+}
+
+type testEnv struct {
+	r                 *Reconciler
+	seedClient        ctrlruntimeclient.Client
+	userClusterClient ctrlruntimeclient.Client
+	now               time.Time
+}
+
+func newTestEnv(cluster *kubermaticv1.Cluster) *testEnv {
+	env := &testEnv{
+		seedClient: fakectrlruntimeclient.NewFakeClient(
+			cluster,
+			genDeployment(resources.ApiserverDeploymentName, 2),
+			genDeployment(resources.SchedulerDeploymentName, 1),
+			genDeployment(resources.MetricsServerDeploymentName, 0),
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: clusterNS, Name: resources.EtcdStatefulSetName},
+				Spec:       appsv1.StatefulSetSpec{Replicas: utilpointer.Int32Ptr(3)},
+			},
+		),
+		userClusterClient: fakectrlruntimeclient.NewFakeClient(
+			genMachineDeployment("workers", 3),
+			genMachineDeployment("gpu", 0),
+		),
+		now: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	env.r = &Reconciler{
+		Client:                        env.seedClient,
+		log:                           kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeClientProvider{client: env.userClusterClient},
+		now:                           func() time.Time { return env.now },
+	}
+	return env
+}
+
+func (env *testEnv) cluster(t *testing.T) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{}
+	if err := env.seedClient.Get(context.Background(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return cluster
+}
+
+// reconcileUntil reconciles the cluster until it reaches the given phase.
+func (env *testEnv) reconcileUntil(t *testing.T, phase kubermaticv1.ClusterHibernationPhase) {
+	for i := 0; i < 10; i++ {
+		cluster := env.cluster(t)
+		if _, err := env.r.reconcile(context.Background(), env.r.log, cluster); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if cluster.Status.Hibernation != nil && cluster.Status.Hibernation.Phase == phase {
+			return
+		}
+	}
+	t.Fatalf("cluster did not reach phase %q, status: %+v", phase, env.cluster(t).Status.Hibernation)
+}
+
+func (env *testEnv) deploymentReplicas(t *testing.T, name string) int32 {
+	deployment := &appsv1.Deployment{}
+	if err := env.seedClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: name}, deployment); err != nil {
+		t.Fatalf("failed to get Deployment %q: %v", name, err)
+	}
+	return *deployment.Spec.Replicas
+}
+
+func (env *testEnv) etcdReplicas(t *testing.T) int32 {
+	etcd := &appsv1.StatefulSet{}
+	if err := env.seedClient.Get(context.Background(), types.NamespacedName{Namespace: clusterNS, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		t.Fatalf("failed to get etcd StatefulSet: %v", err)
+	}
+	return *etcd.Spec.Replicas
+}
+
+func (env *testEnv) machineDeploymentReplicas(t *testing.T, name string) int32 {
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := env.userClusterClient.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: name}, md); err != nil {
+		t.Fatalf("failed to get MachineDeployment %q: %v", name, err)
+	}
+	return *md.Spec.Replicas
+}
+
+func TestHibernateAndResume(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(genCluster(&kubermaticv1.ClusterHibernationSpec{Hibernated: true}))
+
+	env.reconcileUntil(t, kubermaticv1.ClusterHibernationPhaseHibernated)
+
+	status := env.cluster(t).Status.Hibernation
+	if status.MachineDeploymentReplicas["workers"] != 3 || len(status.MachineDeploymentReplicas) != 1 {
+		t.Errorf("expected only the replicas of MachineDeployment workers to be recorded, got %v", status.MachineDeploymentReplicas)
+	}
+	if status.DeploymentReplicas[resources.ApiserverDeploymentName] != 2 || len(status.DeploymentReplicas) != 2 {
+		t.Errorf("expected the replicas of the apiserver and scheduler Deployments to be recorded, got %v", status.DeploymentReplicas)
+	}
+	if status.EtcdReplicas == nil || *status.EtcdReplicas != 3 {
+		t.Errorf("expected etcd replicas 3 to be recorded, got %v", status.EtcdReplicas)
+	}
+	if replicas := env.machineDeploymentReplicas(t, "workers"); replicas != 0 {
+		t.Errorf("expected MachineDeployment to be scaled to 0, got %d", replicas)
+	}
+	if replicas := env.deploymentReplicas(t, resources.ApiserverDeploymentName); replicas != 0 {
+		t.Errorf("expected apiserver to be scaled to 0, got %d", replicas)
+	}
+	if replicas := env.etcdReplicas(t); replicas != 0 {
+		t.Errorf("expected etcd to be scaled to 0, got %d", replicas)
+	}
+	if !env.cluster(t).IsControlPlaneHibernated() {
+		t.Error("expected control plane to be hibernated")
+	}
+
+	cluster := env.cluster(t)
+	oldCluster := cluster.DeepCopy()
+	cluster.Spec.Hibernation.Hibernated = false
+	if err := env.seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+
+	env.reconcileUntil(t, kubermaticv1.ClusterHibernationPhaseResumingControlPlane)
+	if _, err := env.r.reconcile(ctx, env.r.log, env.cluster(t)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if replicas := env.etcdReplicas(t); replicas != 3 {
+		t.Errorf("expected etcd to be scaled to 3, got %d", replicas)
+	}
+	if replicas := env.deploymentReplicas(t, resources.ApiserverDeploymentName); replicas != 0 {
+		t.Errorf("expected apiserver to wait for etcd, got %d replicas", replicas)
+	}
+
+	// Simulate the pods coming up
+	etcd := &appsv1.StatefulSet{}
+	if err := env.seedClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	etcd.Status.ReadyReplicas = 3
+	if err := env.seedClient.Update(ctx, etcd); err != nil {
+		t.Fatalf("failed to update etcd: %v", err)
+	}
+	if _, err := env.r.reconcile(ctx, env.r.log, env.cluster(t)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	apiserver := &appsv1.Deployment{}
+	if err := env.seedClient.Get(ctx, types.NamespacedName{Namespace: clusterNS, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 2 {
+		t.Errorf("expected apiserver to be scaled to 2, got %d", *apiserver.Spec.Replicas)
+	}
+	apiserver.Status = appsv1.DeploymentStatus{Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2}
+	if err := env.seedClient.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+
+	env.reconcileUntil(t, "")
+
+	if replicas := env.machineDeploymentReplicas(t, "workers"); replicas != 3 {
+		t.Errorf("expected MachineDeployment workers to be scaled to 3, got %d", replicas)
+	}
+	if replicas := env.machineDeploymentReplicas(t, "gpu"); replicas != 0 {
+		t.Errorf("expected MachineDeployment gpu to stay at 0, got %d", replicas)
+	}
+	if replicas := env.deploymentReplicas(t, resources.SchedulerDeploymentName); replicas != 1 {
+		t.Errorf("expected scheduler to be scaled to 1, got %d", replicas)
+	}
+	if status := env.cluster(t).Status.Hibernation; status.MachineDeploymentReplicas != nil || status.DeploymentReplicas != nil || status.EtcdReplicas != nil {
+		t.Errorf("expected recorded replicas to be cleared, got %+v", status)
+	}
+}
+
+func TestDeletionResumesControlPlane(t *testing.T) {
+	now := metav1.Now()
+	cluster := genCluster(&kubermaticv1.ClusterHibernationSpec{Hibernated: true})
+	cluster.DeletionTimestamp = &now
+	cluster.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{
+		Phase:                     kubermaticv1.ClusterHibernationPhaseHibernated,
+		MachineDeploymentReplicas: map[string]int32{"workers": 3},
+	}
+	env := newTestEnv(cluster)
+
+	env.reconcileUntil(t, kubermaticv1.ClusterHibernationPhaseResumingControlPlane)
+	if !env.cluster(t).IsHibernationRequested() {
+		t.Error("expected the hibernation request to be left untouched")
+	}
+}
+
+func TestReconcileSchedule(t *testing.T) {
+	schedule := &kubermaticv1.ClusterHibernationSchedule{
+		Hibernate: "0 20 * * 1-5",
+		Resume:    "0 7 * * 1-5",
+	}
+	// 2020-06-01 is a Monday
+	monday := func(hour int) time.Time {
+		return time.Date(2020, 6, 1, hour, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name              string
+		hibernated        bool
+		lastScheduleTime  *time.Time
+		now               time.Time
+		expectHibernated  bool
+		expectRequeueTime time.Time
+	}{
+		{
+			name:              "first evaluation does not act on past events",
+			now:               monday(21),
+			expectHibernated:  false,
+			expectRequeueTime: time.Date(2020, 6, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:              "scheduled hibernation",
+			lastScheduleTime:  timePtr(monday(19)),
+			now:               monday(21),
+			expectHibernated:  true,
+			expectRequeueTime: time.Date(2020, 6, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:              "scheduled resume",
+			hibernated:        true,
+			lastScheduleTime:  timePtr(monday(6)),
+			now:               monday(8),
+			expectHibernated:  false,
+			expectRequeueTime: monday(20),
+		},
+		{
+			name:              "manually resumed cluster stays resumed until the next event",
+			lastScheduleTime:  timePtr(monday(20).Add(30 * time.Minute)),
+			now:               monday(21),
+			expectHibernated:  false,
+			expectRequeueTime: time.Date(2020, 6, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:              "latest of the missed events wins",
+			hibernated:        true,
+			lastScheduleTime:  timePtr(time.Date(2020, 5, 29, 21, 0, 0, 0, time.UTC)),
+			now:               monday(12),
+			expectHibernated:  false,
+			expectRequeueTime: monday(20),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := genCluster(&kubermaticv1.ClusterHibernationSpec{Hibernated: tc.hibernated, Schedule: schedule})
+			if tc.lastScheduleTime != nil {
+				cluster.Status.Hibernation = &kubermaticv1.ClusterHibernationStatus{
+					LastScheduleTime: &metav1.Time{Time: *tc.lastScheduleTime},
+				}
+			}
+			env := newTestEnv(cluster)
+			env.now = tc.now
+
+			result, err := env.r.reconcileSchedule(context.Background(), env.r.log, env.cluster(t))
+			if err != nil {
+				t.Fatalf("failed to reconcile schedule: %v", err)
+			}
+
+			cluster = env.cluster(t)
+			if cluster.Spec.Hibernation.Hibernated != tc.expectHibernated {
+				t.Errorf("expected hibernated to be %t", tc.expectHibernated)
+			}
+			if !cluster.Status.Hibernation.LastScheduleTime.Time.Equal(tc.now) {
+				t.Errorf("expected last schedule time to be %v, got %v", tc.now, cluster.Status.Hibernation.LastScheduleTime)
+			}
+			if requeueTime := tc.now.Add(result.RequeueAfter); !requeueTime.Equal(tc.expectRequeueTime) {
+				t.Errorf("expected requeue at %v, got %v", tc.expectRequeueTime, requeueTime)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -96,7 +96,8 @@ type Reconciler struct {
 	oidcIssuerURL      string
 	oidcIssuerClientID string
 
-	features Features
+	features     Features
+	secretStores *provider.SecretStores
 }
 
 // NewController creates a cluster controller.
@@ -126,7 +127,8 @@ func Add(
 	oidcIssuerClientID string,
 	kubermaticImage string,
 	dnatControllerImage string,
-	features Features) error {
+	features Features,
+	secretStores *provider.SecretStores) error {
 
 	reconciler := &Reconciler{
 		log:                     log.Named(ControllerName),
@@ -158,7 +160,8 @@ func Add(
 		oidcIssuerURL:      oidcIssuerURL,
 		oidcIssuerClientID: oidcIssuerClientID,
 
-		features: features,
+		features:     features,
+		secretStores: secretStores,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
@@ -260,7 +263,13 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 			return client, nil
 		}
 		// Always requeue a cluster after we executed the cleanup.
-		return &reconcile.Result{RequeueAfter: 10 * time.Second}, clusterdeletion.New(r.Client, userClusterClientGetter).CleanupCluster(ctx, log, cluster)
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, clusterdeletion.New(r.Client, r.recorder, userClusterClientGetter).CleanupCluster(ctx, log, cluster)
+	}
+
+	// The hibernation controller scales the control plane down, reconciling it would undo that
+	if cluster.IsControlPlaneHibernated() {
+		log.Debug("Skipping because the cluster is hibernated")
+		return nil, nil
 	}
 
 	res, err := r.reconcileCluster(ctx, cluster)
@@ -65,6 +65,22 @@ func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cl
 	}
 	extendedHealth.Etcd = kubermaticv1helper.GetHealthStatus(etcdHealthStatus, cluster)
 
+	if cluster.IsControlPlaneHibernated() {
+		for _, status := range []*kubermaticv1.HealthStatus{
+			&extendedHealth.Apiserver,
+			&extendedHealth.Controller,
+			&extendedHealth.Scheduler,
+			&extendedHealth.MachineController,
+			&extendedHealth.OpenVPN,
+			&extendedHealth.UserClusterControllerManager,
+			&extendedHealth.Etcd,
+		} {
+			if *status != kubermaticv1.HealthStatusUp {
+				*status = kubermaticv1.HealthStatusHibernated
+			}
+		}
+	}
+
 	return extendedHealth, nil
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/resources/controllermanager"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/dns"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/konnectivity"
 	kubernetesdashboard "github.com/kubermatic/kubermatic/api/pkg/resources/kubernetes-dashboard"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/machinecontroller"
 	metricsserver "github.com/kubermatic/kubermatic/api/pkg/resources/metrics-server"
@@ -103,6 +104,11 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return nil
 	}
 
+	// check that the cloud-config is available
+	if err := r.ensureCloudConfig(ctx, cluster, data); err != nil {
+		return err
+	}
+
 	// check that all ConfigMaps are available
 	if err := r.ensureConfigMaps(ctx, cluster, data); err != nil {
 		return err
@@ -113,6 +119,11 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return err
 	}
 
+	// remove the resources of the tunnel mode the cluster does not use (anymore)
+	if err := r.cleanupTunnelResources(ctx, cluster); err != nil {
+		return err
+	}
+
 	// check that all CronJobs are created
 	if err := r.ensureCronJobs(ctx, cluster, data); err != nil {
 		return err
@@ -170,6 +181,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 		r.kubermaticImage,
 		r.dnatControllerImage,
 		supportsFailureDomainZoneAntiAffinity,
+		r.secretStores,
 	), nil
 }
 
@@ -207,11 +219,18 @@ func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedService
 	creators := []reconciling.NamedServiceCreatorGetter{
 		apiserver.InternalServiceCreator(),
 		apiserver.ExternalServiceCreator(data.Cluster().Spec.ExposeStrategy),
-		openvpn.ServiceCreator(data.Cluster().Spec.ExposeStrategy),
 		etcd.ServiceCreator(data),
-		dns.ServiceCreator(),
 		machinecontroller.ServiceCreator(),
-		metricsserver.ServiceCreator(),
+	}
+
+	if data.Cluster().IsKonnectivityEnabled() {
+		creators = append(creators, konnectivity.ServiceCreator(data.Cluster().Spec.ExposeStrategy))
+	} else {
+		creators = append(creators,
+			openvpn.ServiceCreator(data.Cluster().Spec.ExposeStrategy),
+			dns.ServiceCreator(),
+			metricsserver.ServiceCreator(),
+		)
 	}
 
 	if data.Cluster().Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
@@ -231,17 +250,23 @@ func (r *Reconciler) ensureServices(ctx context.Context, c *kubermaticv1.Cluster
 // GetDeploymentCreators returns all DeploymentCreators that are currently in use
 func GetDeploymentCreators(data *resources.TemplateData, enableAPIserverOIDCAuthentication bool) []reconciling.NamedDeploymentCreatorGetter {
 	deployments := []reconciling.NamedDeploymentCreatorGetter{
-		openvpn.DeploymentCreator(data),
-		dns.DeploymentCreator(data),
 		apiserver.DeploymentCreator(data, enableAPIserverOIDCAuthentication),
 		scheduler.DeploymentCreator(data),
 		controllermanager.DeploymentCreator(data),
 		machinecontroller.DeploymentCreator(data),
 		machinecontroller.WebhookDeploymentCreator(data),
-		metricsserver.DeploymentCreator(data),
 		usercluster.DeploymentCreator(data, false),
 		kubernetesdashboard.DeploymentCreator(data),
 	}
+	// With Konnectivity nothing in the seed can reach the user cluster except the apiserver, so the DNS
+	// resolver is not needed and the metrics-server runs inside the user cluster as addon.
+	if !data.Cluster().IsKonnectivityEnabled() {
+		deployments = append(deployments,
+			openvpn.DeploymentCreator(data),
+			dns.DeploymentCreator(data),
+			metricsserver.DeploymentCreator(data),
+		)
+	}
 	if data.Cluster().Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] != "" {
 		deployments = append(deployments, clusterautoscaler.DeploymentCreator(data))
 	}
@@ -261,7 +286,6 @@ func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv
 func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
 	creators := []reconciling.NamedSecretCreatorGetter{
 		certificates.RootCACreator(data),
-		openvpn.CACreator(),
 		certificates.FrontProxyCACreator(),
 		resources.ImagePullSecretCreator(r.dockerPullConfigJSON),
 		apiserver.FrontProxyClientCertificateCreator(data),
@@ -270,18 +294,13 @@ func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconcili
 		apiserver.TLSServingCertificateCreator(data),
 		apiserver.KubeletClientCertificateCreator(data),
 		apiserver.ServiceAccountKeyCreator(),
-		openvpn.TLSServingCertificateCreator(data),
-		openvpn.InternalClientCertificateCreator(data),
 		machinecontroller.TLSServingCertificateCreator(data),
-		metricsserver.TLSServingCertSecretCreator(data.GetRootCA),
 
 		// Kubeconfigs
 		resources.GetInternalKubeconfigCreator(resources.SchedulerKubeconfigSecretName, resources.SchedulerCertUsername, nil, data),
-		resources.GetInternalKubeconfigCreator(resources.KubeletDnatControllerKubeconfigSecretName, resources.KubeletDnatControllerCertUsername, nil, data),
 		resources.GetInternalKubeconfigCreator(resources.MachineControllerKubeconfigSecretName, resources.MachineControllerCertUsername, nil, data),
 		resources.GetInternalKubeconfigCreator(resources.ControllerManagerKubeconfigSecretName, resources.ControllerManagerCertUsername, nil, data),
 		resources.GetInternalKubeconfigCreator(resources.KubeStateMetricsKubeconfigSecretName, resources.KubeStateMetricsCertUsername, nil, data),
-		resources.GetInternalKubeconfigCreator(resources.MetricsServerKubeconfigSecretName, resources.MetricsServerCertUsername, nil, data),
 		resources.GetInternalKubeconfigCreator(resources.InternalUserClusterAdminKubeconfigSecretName, resources.InternalUserClusterAdminKubeconfigCertUsername, []string{"system:masters"}, data),
 		resources.GetInternalKubeconfigCreator(resources.KubernetesDashboardKubeconfigSecretName, resources.KubernetesDashboardCertUsername, nil, data),
 		resources.GetInternalKubeconfigCreator(resources.ClusterAutoscalerKubeconfigSecretName, resources.ClusterAutoscalerCertUsername, nil, data),
@@ -291,6 +310,22 @@ func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconcili
 		resources.ViewerKubeconfigCreator(data),
 	}
 
+	if data.Cluster().IsKonnectivityEnabled() {
+		creators = append(creators,
+			certificates.KonnectivityCACreator(),
+			konnectivity.TLSServingCertificateCreator(data),
+		)
+	} else {
+		creators = append(creators,
+			openvpn.CACreator(),
+			openvpn.TLSServingCertificateCreator(data),
+			openvpn.InternalClientCertificateCreator(data),
+			metricsserver.TLSServingCertSecretCreator(data.GetRootCA),
+			resources.GetInternalKubeconfigCreator(resources.KubeletDnatControllerKubeconfigSecretName, resources.KubeletDnatControllerCertUsername, nil, data),
+			resources.GetInternalKubeconfigCreator(resources.MetricsServerKubeconfigSecretName, resources.MetricsServerCertUsername, nil, data),
+		)
+	}
+
 	if flag := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; flag {
 		creators = append(creators, resources.GetInternalKubeconfigCreator(
 			resources.CloudControllerManagerKubeconfigSecretName, resources.CloudControllerManagerCertUsername, nil, data,
@@ -301,8 +336,16 @@ func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconcili
 		creators = append(creators, apiserver.DexCACertificateCreator(data.GetDexCA))
 	}
 
-	if data.Cluster().Spec.Cloud.GCP != nil {
-		creators = append(creators, resources.ServiceAccountSecretCreator(data))
+	// Credentials kept in a secret store get injected into the control plane pods instead
+	if injector, err := data.CloudCredentialsInjector(); err == nil && injector == nil {
+		creators = append(creators, resources.CloudCredentialsSecretCreator(data))
+		if data.Cluster().Spec.Cloud.GCP != nil {
+			creators = append(creators, resources.ServiceAccountSecretCreator(data))
+		}
+	}
+
+	if auditLogging := data.Cluster().Spec.AuditLogging; auditLogging != nil && auditLogging.Enabled && auditLogging.Webhook != nil {
+		creators = append(creators, apiserver.AuditWebhookConfigSecretCreator(data))
 	}
 
 	return creators
@@ -337,6 +380,13 @@ func (r *Reconciler) ensureRoles(ctx context.Context, c *kubermaticv1.Cluster) e
 		return fmt.Errorf("failed to ensure Roles: %v", err)
 	}
 
+	namedClusterRoleCreatorGetters := []reconciling.NamedClusterRoleCreatorGetter{
+		usercluster.ClusterEventsClusterRoleCreator,
+	}
+	if err := reconciling.ReconcileClusterRoles(ctx, namedClusterRoleCreatorGetters, "", r.Client); err != nil {
+		return fmt.Errorf("failed to ensure ClusterRoles: %v", err)
+	}
+
 	return nil
 }
 
@@ -347,17 +397,53 @@ func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Clu
 	if err := reconciling.ReconcileRoleBindings(ctx, namedRoleBindingCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure RoleBindings: %v", err)
 	}
+
+	// The ClusterRoleBinding is cluster-scoped, so it gets removed through the owner reference
+	// once the cluster got deleted
+	namedClusterRoleBindingCreatorGetters := []reconciling.NamedClusterRoleBindingCreatorGetter{
+		usercluster.ClusterEventsClusterRoleBindingCreator(c),
+	}
+	if err := reconciling.ReconcileClusterRoleBindings(ctx, namedClusterRoleBindingCreatorGetters, "", r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+		return fmt.Errorf("failed to ensure ClusterRoleBindings: %v", err)
+	}
 	return nil
 }
 
 // GetConfigMapCreators returns all ConfigMapCreators that are currently in use
 func GetConfigMapCreators(data *resources.TemplateData) []reconciling.NamedConfigMapCreatorGetter {
-	return []reconciling.NamedConfigMapCreatorGetter{
-		cloudconfig.ConfigMapCreator(data),
-		openvpn.ServerClientConfigsConfigMapCreator(data),
-		dns.ConfigMapCreator(data),
-		apiserver.AuditConfigMapCreator(),
+	creators := []reconciling.NamedConfigMapCreatorGetter{
+		apiserver.AuditConfigMapCreator(data),
+	}
+
+	if data.Cluster().IsKonnectivityEnabled() {
+		creators = append(creators, konnectivity.EgressSelectorConfigMapCreator())
+	} else {
+		creators = append(creators,
+			openvpn.ServerClientConfigsConfigMapCreator(data),
+			dns.ConfigMapCreator(data),
+		)
 	}
+
+	return creators
+}
+
+// ensureCloudConfig reconciles the Secret containing the cloud-config and removes the ConfigMap the
+// cloud-config was kept in before.
+func (r *Reconciler) ensureCloudConfig(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	creators := []reconciling.NamedSecretCreatorGetter{cloudconfig.SecretCreator(data)}
+
+	if err := reconciling.ReconcileSecrets(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+		return fmt.Errorf("failed to ensure that the cloud-config Secret exists: %v", err)
+	}
+
+	legacyConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: c.Status.NamespaceName, Name: resources.CloudConfigSeedSecretName},
+	}
+	if err := r.Delete(ctx, legacyConfigMap); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the cloud-config ConfigMap: %v", err)
+	}
+
+	return nil
 }
 
 func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
@@ -383,12 +469,19 @@ func GetStatefulSetCreators(data *resources.TemplateData, enableDataCorruptionCh
 
 // GetPodDisruptionBudgetCreators returns all PodDisruptionBudgetCreators that are currently in use
 func GetPodDisruptionBudgetCreators(data *resources.TemplateData) []reconciling.NamedPodDisruptionBudgetCreatorGetter {
-	return []reconciling.NamedPodDisruptionBudgetCreatorGetter{
+	creators := []reconciling.NamedPodDisruptionBudgetCreatorGetter{
 		etcd.PodDisruptionBudgetCreator(data),
 		apiserver.PodDisruptionBudgetCreator(),
-		metricsserver.PodDisruptionBudgetCreator(),
-		dns.PodDisruptionBudgetCreator(),
 	}
+
+	if !data.Cluster().IsKonnectivityEnabled() {
+		creators = append(creators,
+			metricsserver.PodDisruptionBudgetCreator(),
+			dns.PodDisruptionBudgetCreator(),
+		)
+	}
+
+	return creators
 }
 
 func (r *Reconciler) ensurePodDisruptionBudgets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
@@ -420,14 +513,18 @@ func (r *Reconciler) ensureCronJobs(ctx context.Context, c *kubermaticv1.Cluster
 
 func (r *Reconciler) ensureVerticalPodAutoscalers(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	controlPlaneDeploymentNames := []string{
-		resources.DNSResolverDeploymentName,
 		resources.MachineControllerDeploymentName,
 		resources.MachineControllerWebhookDeploymentName,
-		resources.OpenVPNServerDeploymentName,
 		resources.ApiserverDeploymentName,
 		resources.ControllerManagerDeploymentName,
 		resources.SchedulerDeploymentName,
-		resources.MetricsServerDeploymentName,
+	}
+	if !c.IsKonnectivityEnabled() {
+		controlPlaneDeploymentNames = append(controlPlaneDeploymentNames,
+			resources.DNSResolverDeploymentName,
+			resources.OpenVPNServerDeploymentName,
+			resources.MetricsServerDeploymentName,
+		)
 	}
 
 	creators, err := resources.GetVerticalPodAutoscalersForAll(ctx, r.Client, controlPlaneDeploymentNames, []string{resources.EtcdStatefulSetName}, c.Status.NamespaceName, r.features.VPA)
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	metricsserver "github.com/kubermatic/kubermatic/api/pkg/resources/metrics-server"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// cleanupTunnelResources deletes the control plane resources of the tunnel mode the cluster does not use.
+// This is how clusters get migrated from OpenVPN to Konnectivity and back: the resources of the new mode
+// get reconciled as usual, the ones of the old mode are removed here.
+func (r *Reconciler) cleanupTunnelResources(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	for _, obj := range unusedTunnelResources(cluster) {
+		if err := r.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %T of the unused tunnel: %v", obj, err)
+		}
+	}
+	return nil
+}
+
+// unusedTunnelResources returns the resources in the cluster namespace which belong to the tunnel mode
+// the cluster does not use.
+func unusedTunnelResources(cluster *kubermaticv1.Cluster) []runtime.Object {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: cluster.Status.NamespaceName, Name: name}
+	}
+
+	if !cluster.IsKonnectivityEnabled() {
+		return []runtime.Object{
+			&corev1.Service{ObjectMeta: meta(resources.KonnectivityServerServiceName)},
+			&corev1.Secret{ObjectMeta: meta(resources.KonnectivityCASecretName)},
+			&corev1.Secret{ObjectMeta: meta(resources.KonnectivityServerCertificatesSecretName)},
+			&corev1.ConfigMap{ObjectMeta: meta(resources.KonnectivityEgressSelectorConfigMapName)},
+		}
+	}
+
+	// The VerticalPodAutoscalers of the deployments get garbage collected, they are owned by the deployments
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: meta(resources.OpenVPNServerDeploymentName)},
+		&appsv1.Deployment{ObjectMeta: meta(resources.DNSResolverDeploymentName)},
+		&appsv1.Deployment{ObjectMeta: meta(resources.MetricsServerDeploymentName)},
+		&corev1.Service{ObjectMeta: meta(resources.OpenVPNServerServiceName)},
+		&corev1.Service{ObjectMeta: meta(resources.DNSResolverServiceName)},
+		&corev1.Service{ObjectMeta: meta(resources.MetricsServerServiceName)},
+		&corev1.ConfigMap{ObjectMeta: meta(resources.OpenVPNClientConfigsConfigMapName)},
+		&corev1.ConfigMap{ObjectMeta: meta(resources.DNSResolverConfigMapName)},
+		&corev1.Secret{ObjectMeta: meta(resources.OpenVPNCASecretName)},
+		&corev1.Secret{ObjectMeta: meta(resources.OpenVPNServerCertificatesSecretName)},
+		&corev1.Secret{ObjectMeta: meta(resources.OpenVPNClientCertificatesSecretName)},
+		&corev1.Secret{ObjectMeta: meta(resources.KubeletDnatControllerKubeconfigSecretName)},
+		&corev1.Secret{ObjectMeta: meta(resources.MetricsServerKubeconfigSecretName)},
+		&corev1.Secret{ObjectMeta: meta(metricsserver.ServingCertSecretName)},
+		&policyv1beta1.PodDisruptionBudget{ObjectMeta: meta(resources.MetricsServerPodDisruptionBudgetName)},
+		&policyv1beta1.PodDisruptionBudget{ObjectMeta: meta(resources.DNSResolverPodDisruptionBudetName)},
+	}
+}
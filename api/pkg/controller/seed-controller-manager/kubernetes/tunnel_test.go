@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCleanupTunnelResources(t *testing.T) {
+	const namespace = "cluster-test"
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name}
+	}
+
+	tests := []struct {
+		name       string
+		tunnelMode kubermaticv1.TunnelMode
+		deleted    runtime.Object
+		kept       runtime.Object
+	}{
+		{
+			name:       "konnectivity resources get removed from openvpn clusters",
+			tunnelMode: kubermaticv1.TunnelModeOpenVPN,
+			deleted:    &corev1.Service{ObjectMeta: meta(resources.KonnectivityServerServiceName)},
+			kept:       &appsv1.Deployment{ObjectMeta: meta(resources.OpenVPNServerDeploymentName)},
+		},
+		{
+			name:       "openvpn resources get removed from konnectivity clusters",
+			tunnelMode: kubermaticv1.TunnelModeKonnectivity,
+			deleted:    &appsv1.Deployment{ObjectMeta: meta(resources.OpenVPNServerDeploymentName)},
+			kept:       &corev1.Service{ObjectMeta: meta(resources.KonnectivityServerServiceName)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Status.NamespaceName = namespace
+			cluster.Spec.ClusterNetwork.TunnelMode = test.tunnelMode
+
+			r := &Reconciler{Client: fake.NewFakeClient(test.deleted.DeepCopyObject(), test.kept.DeepCopyObject())}
+			if err := r.cleanupTunnelResources(context.Background(), cluster); err != nil {
+				t.Fatalf("failed to clean up the tunnel resources: %v", err)
+			}
+
+			deleted := test.deleted.DeepCopyObject()
+			err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: deleted.(metav1.Object).GetName()}, deleted)
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("expected %T of the unused tunnel to be deleted, got err %v", deleted, err)
+			}
+
+			kept := test.kept.DeepCopyObject()
+			if err := r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: kept.(metav1.Object).GetName()}, kept); err != nil {
+				t.Errorf("expected %T of the used tunnel to be kept, got err %v", kept, err)
+			}
+		})
+	}
+}
@@ -86,7 +86,8 @@ type Reconciler struct {
 	monitoringScrapeAnnotationPrefix string
 	concurrentClusterUpdates         int
 
-	features Features
+	features     Features
+	secretStores *provider.SecretStores
 }
 
 // Add creates a new Monitoring controller that is responsible for
@@ -111,6 +112,7 @@ func Add(
 	concurrentClusterUpdates int,
 
 	features Features,
+	secretStores *provider.SecretStores,
 ) error {
 	log = log.Named(ControllerName)
 
@@ -135,7 +137,8 @@ func Add(
 		concurrentClusterUpdates:                         concurrentClusterUpdates,
 		seedGetter:                                       seedGetter,
 
-		features: features,
+		features:     features,
+		secretStores: secretStores,
 	}
 
 	ctrlOptions := controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers}
@@ -229,6 +232,11 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.IsControlPlaneHibernated() {
+		log.Debug("Skipping because the cluster is hibernated")
+		return nil, nil
+	}
+
 	log.Debug("Reconciling cluster now")
 
 	data, err := r.getClusterTemplateData(context.Background(), r.Client, cluster)
@@ -64,6 +64,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, client ctrlrunt
 		"",
 		"",
 		false,
+		r.secretStores,
 	), nil
 }
 
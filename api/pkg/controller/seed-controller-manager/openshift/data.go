@@ -56,6 +56,7 @@ type openshiftData struct {
 	supportsFailureDomainZoneAntiAffinity bool
 	externalURL                           string
 	seed                                  *kubermaticv1.Seed
+	secretStores                          *provider.SecretStores
 }
 
 func (od *openshiftData) DC() *kubermaticv1.Datacenter {
@@ -96,6 +97,11 @@ func (od *openshiftData) GetRootCA() (*triple.KeyPair, error) {
 	return od.GetRootCAWithContext(context.Background())
 }
 
+// GetRootCACrossSignedCert always returns nil, as the CAs of openshift clusters are not rotated
+func (od *openshiftData) GetRootCACrossSignedCert() ([]byte, error) {
+	return nil, nil
+}
+
 func (od *openshiftData) GetRootCAWithContext(ctx context.Context) (*triple.KeyPair, error) {
 	secret := &corev1.Secret{}
 	if err := od.client.Get(ctx, nn(od.cluster.Status.NamespaceName, kubernetesresources.CASecretName), secret); err != nil {
@@ -214,6 +220,11 @@ func (od *openshiftData) NodePortRange(_ context.Context) string {
 	return "30000-32767"
 }
 
+// GetKonnectivityServerPort always fails, Openshift clusters only support the OpenVPN tunnel
+func (od *openshiftData) GetKonnectivityServerPort() (int32, error) {
+	return 0, fmt.Errorf("cluster %s is an Openshift cluster, they do not support Konnectivity", od.cluster.Name)
+}
+
 func (od *openshiftData) GetOpenVPNServerPort() (int32, error) {
 	ctx := context.Background()
 	service := &corev1.Service{}
@@ -256,7 +267,11 @@ func (od *openshiftData) KubermaticAPIImage() string {
 }
 
 func (od *openshiftData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
-	return provider.SecretKeySelectorValueFuncFactory(context.Background(), od.client)(configVar, key)
+	return provider.SecretKeySelectorValueFuncFactory(context.Background(), od.client, od.secretStores)(configVar, key)
+}
+
+func (od *openshiftData) CloudCredentialsInjector() (provider.SecretStoreInjector, error) {
+	return kubernetesresources.CloudCredentialsInjector(od.cluster, od.secretStores)
 }
 
 func (od *openshiftData) DNATControllerImage() string {
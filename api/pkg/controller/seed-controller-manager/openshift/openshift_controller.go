@@ -104,6 +104,7 @@ type Reconciler struct {
 	dnatControllerImage      string
 	features                 Features
 	concurrentClusterUpdates int
+	secretStores             *provider.SecretStores
 }
 
 func Add(
@@ -122,6 +123,7 @@ func Add(
 	dnatControllerImage string,
 	features Features,
 	concurrentClusterUpdates int,
+	secretStores *provider.SecretStores,
 ) error {
 	reconciler := &Reconciler{
 		Client:                   mgr.GetClient(),
@@ -140,6 +142,7 @@ func Add(
 		dnatControllerImage:      dnatControllerImage,
 		features:                 features,
 		concurrentClusterUpdates: concurrentClusterUpdates,
+		secretStores:             secretStores,
 	}
 
 	c, err := controller.New(ControllerName, mgr,
@@ -237,7 +240,7 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 
 		// Always requeue a cluster after we executed the cleanup.
-		return &reconcile.Result{RequeueAfter: 10 * time.Second}, clusterdeletion.New(r.Client, userClusterClientGetter).CleanupCluster(ctx, log, cluster)
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, clusterdeletion.New(r.Client, r.recorder, userClusterClientGetter).CleanupCluster(ctx, log, cluster)
 	}
 
 	if cluster.Spec.Openshift == nil {
@@ -409,8 +412,12 @@ func (r *Reconciler) getAllSecretCreators(ctx context.Context, osData *openshift
 		openshiftresources.ExternalX509KubeconfigCreator(osData),
 		openshiftresources.GetLoopbackKubeconfigCreator(ctx, osData, r.log)}
 
-	if osData.cluster.Spec.Cloud.GCP != nil {
-		creators = append(creators, resources.ServiceAccountSecretCreator(osData))
+	// Credentials kept in a secret store get injected into the control plane pods instead
+	if injector, err := osData.CloudCredentialsInjector(); err == nil && injector == nil {
+		creators = append(creators, resources.CloudCredentialsSecretCreator(osData))
+		if osData.cluster.Spec.Cloud.GCP != nil {
+			creators = append(creators, resources.ServiceAccountSecretCreator(osData))
+		}
 	}
 
 	return creators
@@ -448,12 +455,25 @@ func (r *Reconciler) getAllConfigmapCreators(ctx context.Context, osData *opensh
 		dns.ConfigMapCreator(osData),
 		openshiftresources.OauthConfigMapCreator(osData),
 		openshiftresources.ConsoleConfigCreator(osData),
-		// Put the cloudconfig at the end, it may need data from the cloud controller, this reduces the likelihood
-		// that we instantly rotate the apiserver due to cloudconfig changes
-		cloudconfig.ConfigMapCreator(osData),
 	}
 }
 
+// cloudConfig reconciles the Secret containing the cloud-config and removes the ConfigMap the
+// cloud-config was kept in before.
+func (r *Reconciler) cloudConfig(ctx context.Context, osData *openshiftData) error {
+	ns := osData.Cluster().Status.NamespaceName
+	creators := []reconciling.NamedSecretCreatorGetter{cloudconfig.SecretCreator(osData)}
+	if err := reconciling.ReconcileSecrets(ctx, creators, ns, r.Client); err != nil {
+		return err
+	}
+
+	legacyConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: resources.CloudConfigSeedSecretName}}
+	if err := r.Delete(ctx, legacyConfigMap); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the cloud-config ConfigMap: %v", err)
+	}
+	return nil
+}
+
 func (r *Reconciler) configMaps(ctx context.Context, osData *openshiftData) error {
 	creators := r.getAllConfigmapCreators(ctx, osData)
 	return reconciling.ReconcileConfigMaps(ctx, creators, osData.Cluster().Status.NamespaceName, r.Client)
@@ -685,6 +705,13 @@ func (r *Reconciler) ensureRoles(ctx context.Context, c *kubermaticv1.Cluster) e
 		return fmt.Errorf("failed to ensure Roles: %v", err)
 	}
 
+	namedClusterRoleCreatorGetters := []reconciling.NamedClusterRoleCreatorGetter{
+		usercluster.ClusterEventsClusterRoleCreator,
+	}
+	if err := reconciling.ReconcileClusterRoles(ctx, namedClusterRoleCreatorGetters, "", r.Client); err != nil {
+		return fmt.Errorf("failed to ensure ClusterRoles: %v", err)
+	}
+
 	return nil
 }
 
@@ -695,6 +722,15 @@ func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Clu
 	if err := reconciling.ReconcileRoleBindings(ctx, namedRoleBindingCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
 		return fmt.Errorf("failed to ensure RoleBindings: %v", err)
 	}
+
+	// The ClusterRoleBinding is cluster-scoped, so it gets removed through the owner reference
+	// once the cluster got deleted
+	namedClusterRoleBindingCreatorGetters := []reconciling.NamedClusterRoleBindingCreatorGetter{
+		usercluster.ClusterEventsClusterRoleBindingCreator(c),
+	}
+	if err := reconciling.ReconcileClusterRoleBindings(ctx, namedClusterRoleBindingCreatorGetters, "", r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+		return fmt.Errorf("failed to ensure ClusterRoleBindings: %v", err)
+	}
 	return nil
 }
 
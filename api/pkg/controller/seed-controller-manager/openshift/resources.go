@@ -56,6 +56,7 @@ func (r *Reconciler) getOSData(ctx context.Context, cluster *kubermaticv1.Cluste
 		supportsFailureDomainZoneAntiAffinity: supportsFailureDomainZoneAntiAffinity,
 		externalURL:                           r.externalURL,
 		seed:                                  seed.DeepCopy(),
+		secretStores:                          r.secretStores,
 	}, nil
 }
 
@@ -105,6 +106,10 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cluster *kubermatic
 		return nil
 	}
 
+	if err := r.cloudConfig(ctx, osData); err != nil {
+		return fmt.Errorf("failed to reconcile the cloud-config: %v", err)
+	}
+
 	if err := r.configMaps(ctx, osData); err != nil {
 		return fmt.Errorf("failed to reconcile ConfigMaps: %v", err)
 	}
@@ -25,6 +25,8 @@ import (
 	"github.com/Masterminds/sprig"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 
@@ -46,6 +48,7 @@ type masterConfigData interface {
 	Cluster() *kubermaticv1.Cluster
 	GetApiserverExternalNodePort(context.Context) (int32, error)
 	GetKubernetesCloudProviderName() string
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
 
 type openshiftAPIServerCreatorData interface {
@@ -80,6 +83,11 @@ func OpenshiftKubeAPIServerConfigMapCreator(data masterConfigData) reconciling.N
 				cm.Data = map[string]string{}
 			}
 
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+
 			var podCIDR, serviceCIDR string
 			if len(data.Cluster().Spec.ClusterNetwork.Pods.CIDRBlocks) > 0 {
 				podCIDR = data.Cluster().Spec.ClusterNetwork.Pods.CIDRBlocks[0]
@@ -96,6 +104,7 @@ func OpenshiftKubeAPIServerConfigMapCreator(data masterConfigData) reconciling.N
 				ETCDEndpoints    []string
 				AdvertiseAddress string
 				CloudProvider    string
+				CloudConfigPath  string
 			}{
 				PodCIDR:          podCIDR,
 				ServiceCIDR:      serviceCIDR,
@@ -103,6 +112,7 @@ func OpenshiftKubeAPIServerConfigMapCreator(data masterConfigData) reconciling.N
 				ETCDEndpoints:    etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName),
 				AdvertiseAddress: data.Cluster().Address.IP,
 				CloudProvider:    data.GetKubernetesCloudProviderName(),
+				CloudConfigPath:  resources.CloudConfigPath(injector),
 			}
 			if err := openshiftKubeAPIServerTemplate.Execute(&apiServerConfigBuffer, templateInput); err != nil {
 				return nil, fmt.Errorf("failed to execute template: %v", err)
@@ -208,7 +218,7 @@ apiServerArguments:
   cloud-provider:
   - {{ .CloudProvider}}
   cloud-config:
-  - /etc/kubernetes/cloud/config
+  - {{ .CloudConfigPath }}
 {{- end }}
   enable-aggregator-routing:
   # Thist _must_ stay false, if its true, the kube-apiserver will try to resolve endpoints for
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
@@ -51,4 +52,5 @@ type openshiftData interface {
 	GetOauthExternalNodePort() (int32, error)
 	ExternalURL() string
 	Seed() *kubermaticv1.Seed
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
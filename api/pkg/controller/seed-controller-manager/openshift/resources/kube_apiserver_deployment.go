@@ -23,6 +23,7 @@ import (
 
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd/etcdrunning"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
@@ -128,9 +129,18 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 				{Name: openshiftImagePullSecretName},
 			}
 
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+
 			volumes := getAPIServerVolumes()
+			revisionVolumes := volumes
+			if injector == nil {
+				revisionVolumes = append(volumes, resources.CloudCredentialsRevisionVolume())
+			}
 
-			podLabels, err := data.GetPodTemplateLabelsWithContext(ctx, legacyAppLabelValue, volumes, nil)
+			podLabels, err := data.GetPodTemplateLabelsWithContext(ctx, legacyAppLabelValue, revisionVolumes, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -148,6 +158,13 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 					"prometheus.io/port":                  strconv.Itoa(int(externalNodePort)),
 				},
 			}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range injectionAnnotations {
+				dep.Spec.Template.Annotations[k] = v
+			}
 
 			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName)
 
@@ -157,7 +174,8 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 				return nil, err
 			}
 
-			dep.Spec.Template.Spec.AutomountServiceAccountToken = utilpointer.BoolPtr(false)
+			// The secret store authenticates the injection with the service account token of the pod
+			dep.Spec.Template.Spec.AutomountServiceAccountToken = utilpointer.BoolPtr(injector != nil)
 			dep.Spec.Template.Spec.Volumes = volumes
 			dep.Spec.Template.Spec.InitContainers = []corev1.Container{
 				etcdrunning.Container(etcdEndpoints, data),
@@ -173,11 +191,6 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 				return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
 			}
 
-			envVars, err := apiserver.GetEnvVars(data)
-			if err != nil {
-				return nil, err
-			}
-
 			image, err := hypershiftImage(data.Cluster().Spec.Version.String(), data.ImageRegistry(""))
 			if err != nil {
 				return nil, err
@@ -191,7 +204,7 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 					Image:   image,
 					Command: []string{"hypershift", "openshift-kube-apiserver"},
 					Args:    []string{"--config=/etc/origin/master/master-config.yaml"},
-					Env:     envVars,
+					Env:     apiserver.GetEnvVars(data, injector),
 					Ports: []corev1.ContainerPort{
 						{
 							ContainerPort: externalNodePort,
@@ -268,7 +281,7 @@ func getVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 		{
-			Name:      resources.CloudConfigConfigMapName,
+			Name:      resources.CloudConfigSeedSecretName,
 			MountPath: "/etc/kubernetes/cloud",
 			ReadOnly:  true,
 		},
@@ -357,12 +370,10 @@ func getAPIServerVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: resources.CloudConfigConfigMapName,
+			Name: resources.CloudConfigSeedSecretName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.CloudConfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.CloudConfigSeedSecretName,
 				},
 			},
 		},
@@ -24,6 +24,7 @@ import (
 	"github.com/Masterminds/sprig"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
@@ -62,7 +63,7 @@ extendedArguments:
   cloud-provider:
   - {{ .CloudProvider}}
   cloud-config:
-  - /etc/kubernetes/cloud/config
+  - {{ .CloudConfigPath }}
 {{- end }}
   cluster-cidr:
   - {{ .ClusterCIDR }}
@@ -136,6 +137,7 @@ var (
 type kubeControllerManagerConfigData interface {
 	Cluster() *kubermaticv1.Cluster
 	GetKubernetesCloudProviderName() string
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
 
 func KubeControllerManagerConfigMapCreatorFactory(data kubeControllerManagerConfigData) reconciling.NamedConfigMapCreatorGetter {
@@ -154,6 +156,10 @@ func KubeControllerManagerConfigMapCreatorFactory(data kubeControllerManagerConf
 				if len(data.Cluster().Spec.ClusterNetwork.Services.CIDRBlocks) > 0 {
 					serviceCIDR = data.Cluster().Spec.ClusterNetwork.Services.CIDRBlocks[0]
 				}
+				injector, err := data.CloudCredentialsInjector()
+				if err != nil {
+					return nil, err
+				}
 				configureCloudRoutes := ""
 				configureCloudRoutesBoolPtr := controllermanager.CloudRoutesFlagVal(data.Cluster().Spec.Cloud)
 				if configureCloudRoutesBoolPtr != nil {
@@ -167,6 +173,7 @@ func KubeControllerManagerConfigMapCreatorFactory(data kubeControllerManagerConf
 					ServiceCIDR           string
 					CloudProvider         string
 					ConfigureCloudRoutes  string
+					CloudConfigPath       string
 				}{
 					CACertPath:            kubeControllerManagerCACertPath,
 					CAKeyPath:             kubeControllerManagerCAKeyPath,
@@ -175,6 +182,7 @@ func KubeControllerManagerConfigMapCreatorFactory(data kubeControllerManagerConf
 					ServiceCIDR:           serviceCIDR,
 					CloudProvider:         data.GetKubernetesCloudProviderName(),
 					ConfigureCloudRoutes:  configureCloudRoutes,
+					CloudConfigPath:       resources.CloudConfigPath(injector),
 				}
 
 				templateBuffer := &bytes.Buffer{}
@@ -195,6 +203,8 @@ type kubeControllerManagerData interface {
 	GetPodTemplateLabels(appName string, volumes []corev1.Volume, additionalLabels map[string]string) (map[string]string, error)
 	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 	Seed() *kubermaticv1.Seed
+	DC() *kubermaticv1.Datacenter
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
 
 func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerData) reconciling.NamedDeploymentCreatorGetter {
@@ -212,6 +222,11 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 				dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
 					{Name: openshiftImagePullSecretName},
 				}
+				injector, err := data.CloudCredentialsInjector()
+				if err != nil {
+					return nil, err
+				}
+
 				dep.Spec.Template.Spec.Volumes = kubeControllerManagerVolumes()
 				volumeMounts := []corev1.VolumeMount{
 					{
@@ -240,7 +255,7 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 						ReadOnly:  true,
 					},
 					{
-						Name:      resources.CloudConfigConfigMapName,
+						Name:      resources.CloudConfigSeedSecretName,
 						MountPath: "/etc/kubernetes/cloud",
 						ReadOnly:  true,
 					},
@@ -248,7 +263,7 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 
 				if data.Cluster().Spec.Cloud.VSphere != nil {
 					fakeVMWareUUIDMount := corev1.VolumeMount{
-						Name:      resources.CloudConfigConfigMapName,
+						Name:      resources.CloudConfigSeedSecretName,
 						SubPath:   cloudconfig.FakeVMWareUUIDKeyName,
 						MountPath: "/sys/class/dmi/id/product_serial",
 						ReadOnly:  true,
@@ -267,11 +282,6 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 					return nil, err
 				}
 
-				env, err := controllermanager.GetEnvVars(data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get controller manager env vars: %v", err)
-				}
-
 				openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
 				if err != nil {
 					return nil, fmt.Errorf("failed to get openvpn sidecar: %v", err)
@@ -283,7 +293,7 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 					{
 						Name:    resources.ControllerManagerDeploymentName,
 						Image:   image,
-						Env:     env,
+						Env:     controllermanager.GetEnvVars(data, injector),
 						Command: []string{"hyperkube", kubeControllerManagerContainerName},
 						Args: kubeControllerManagerArgs(
 							"/etc/origin/config.yaml",
@@ -315,11 +325,18 @@ func KubeControllerManagerDeploymentCreatorFactory(data kubeControllerManagerDat
 				if err != nil {
 					return nil, fmt.Errorf("failed to set resource requirements: %v", err)
 				}
-				podLabels, err := data.GetPodTemplateLabels(resources.ControllerManagerDeploymentName, dep.Spec.Template.Spec.Volumes, nil)
+				revisionVolumes := dep.Spec.Template.Spec.Volumes
+				if injector == nil {
+					revisionVolumes = append(revisionVolumes, resources.CloudCredentialsRevisionVolume())
+				}
+				podLabels, err := data.GetPodTemplateLabels(resources.ControllerManagerDeploymentName, revisionVolumes, nil)
 				if err != nil {
 					return nil, err
 				}
 				dep.Spec.Template.Labels = podLabels
+				if dep.Spec.Template.Annotations, err = cloudconfig.CredentialsInjectionAnnotations(data); err != nil {
+					return nil, err
+				}
 				wrappedPodSpec, err := apiserver.IsRunningWrapper(data, dep.Spec.Template.Spec, sets.NewString(resources.ControllerManagerDeploymentName))
 				if err != nil {
 					return nil, fmt.Errorf("failed to add apiserver.IsRunningWrapper: %v", err)
@@ -405,12 +422,10 @@ func kubeControllerManagerVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: resources.CloudConfigConfigMapName,
+			Name: resources.CloudConfigSeedSecretName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.CloudConfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.CloudConfigSeedSecretName,
 				},
 			},
 		},
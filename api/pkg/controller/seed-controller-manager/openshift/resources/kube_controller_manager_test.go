@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	testhelper "github.com/kubermatic/kubermatic/api/pkg/test"
 
 	corev1 "k8s.io/api/core/v1"
@@ -80,3 +81,7 @@ func (f *fakeKubeControllerManagerConfigData) Cluster() *kubermaticv1.Cluster {
 func (f *fakeKubeControllerManagerConfigData) GetKubernetesCloudProviderName() string {
 	return "fake-cloud-provider"
 }
+
+func (f *fakeKubeControllerManagerConfigData) CloudCredentialsInjector() (provider.SecretStoreInjector, error) {
+	return nil, nil
+}
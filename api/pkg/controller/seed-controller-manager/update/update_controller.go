@@ -117,6 +117,12 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 
+	if cluster.IsHibernationRequested() || cluster.IsHibernating() {
+		// Updating a cluster that is scaled down or about to be is not possible. Pending
+		// updates get applied once the cluster has been resumed and is healthy again.
+		return nil, nil
+	}
+
 	if !cluster.Status.ExtendedHealth.AllHealthy() {
 		// Cluster not healthy yet. Nothing to do.
 		// If it gets healthy we'll get notified by the event. No need to requeue
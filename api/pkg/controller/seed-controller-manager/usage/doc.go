@@ -0,0 +1,26 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package usage contains a controller that periodically samples the nodes of every user cluster and
+aggregates their number, size, capacity and CPU and memory usage per day into ClusterUsage objects,
+one per cluster and month.
+
+ClusterUsage objects are not owned by their cluster, so the usage of deleted clusters can still be
+reported. The API uses them together with the price lists of the global settings to produce monthly
+reports per project.
+*/
+package usage
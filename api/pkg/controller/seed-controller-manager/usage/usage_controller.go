@@ -0,0 +1,291 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_usage_controller"
+
+	// unknownNodeSize is used for nodes without an instance type label
+	unknownNodeSize = "unknown"
+)
+
+// nodeSizeLabels are the labels holding the instance type of a node, in order of preference
+var nodeSizeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+// Reconciler samples the resource usage of the user clusters
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider userClusterConnectionProvider
+	sampleInterval                time.Duration
+	now                           func() time.Time
+}
+
+// Add creates a new usage controller
+func Add(mgr manager.Manager, log *zap.SugaredLogger, numWorkers int, workerName string,
+	userClusterConnectionProvider userClusterConnectionProvider, sampleInterval time.Duration) error {
+	reconciler := &Reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		sampleInterval:                sampleInterval,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := r.log.With("cluster", request.Name)
+	log.Debug("Processing")
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	// Nodes can only be sampled while the control plane is running
+	if cluster.Status.NamespaceName == "" || cluster.IsControlPlaneHibernated() || cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return &reconcile.Result{RequeueAfter: r.sampleInterval}, nil
+	}
+
+	now := r.now().UTC()
+	usage, err := r.getOrCreateUsage(ctx, cluster, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// The controller also gets triggered by updates of the cluster, those must not add samples
+	elapsed := r.sampleInterval
+	if !usage.Spec.LastSampleTime.IsZero() {
+		elapsed = now.Sub(usage.Spec.LastSampleTime.Time)
+		if elapsed < r.sampleInterval {
+			return &reconcile.Result{RequeueAfter: r.sampleInterval - elapsed}, nil
+		}
+		// Don't account for the time the controller was not running
+		if elapsed > 2*r.sampleInterval {
+			elapsed = r.sampleInterval
+		}
+	}
+
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+	s, err := takeSample(ctx, log, userClusterClient)
+	if err != nil {
+		return nil, err
+	}
+
+	oldUsage := usage.DeepCopy()
+	addSample(usage, s, now, elapsed)
+	if err := r.Patch(ctx, usage, ctrlruntimeclient.MergeFrom(oldUsage)); err != nil {
+		return nil, fmt.Errorf("failed to update cluster usage: %v", err)
+	}
+	return &reconcile.Result{RequeueAfter: r.sampleInterval}, nil
+}
+
+// getOrCreateUsage returns the ClusterUsage of the cluster for the month of the given time
+func (r *Reconciler) getOrCreateUsage(ctx context.Context, cluster *kubermaticv1.Cluster, now time.Time) (*kubermaticv1.ClusterUsage, error) {
+	month := now.Format(kubermaticv1.ClusterUsageMonthFormat)
+	name := fmt.Sprintf("%s-%s", cluster.Name, month)
+
+	usage := &kubermaticv1.ClusterUsage{}
+	err := r.Get(ctx, types.NamespacedName{Name: name}, usage)
+	if err == nil {
+		return usage, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get cluster usage: %v", err)
+	}
+
+	providerName, err := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloud provider name: %v", err)
+	}
+	usage = &kubermaticv1.ClusterUsage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey:         cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+				kubermaticv1.ClusterUsageMonthLabelKey: month,
+			},
+		},
+		Spec: kubermaticv1.ClusterUsageSpec{
+			Cluster:                  cluster.Name,
+			ClusterHumanReadableName: cluster.Spec.HumanReadableName,
+			ProjectID:                cluster.Labels[kubermaticv1.ProjectIDLabelKey],
+			Provider:                 providerName,
+			Month:                    month,
+		},
+	}
+	if err := r.Create(ctx, usage); err != nil {
+		return nil, fmt.Errorf("failed to create cluster usage: %v", err)
+	}
+	return usage, nil
+}
+
+// sample is the resource usage of a cluster at a point in time
+type sample struct {
+	nodeSizes      map[string]int64
+	nodes          int64
+	cpuCapacity    int64
+	memoryCapacity int64
+	cpuUsage       int64
+	memoryUsage    int64
+}
+
+func takeSample(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client) (*sample, error) {
+	nodes := &corev1.NodeList{}
+	if err := client.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	s := &sample{nodeSizes: map[string]int64{}}
+	for _, node := range nodes.Items {
+		s.nodes++
+		s.nodeSizes[nodeSize(&node)]++
+		s.cpuCapacity += node.Status.Capacity.Cpu().MilliValue()
+		s.memoryCapacity += node.Status.Capacity.Memory().Value()
+	}
+
+	// The usage is only known if the metrics-server is running
+	nodeMetrics := &metricsv1beta1.NodeMetricsList{}
+	if err := client.List(ctx, nodeMetrics); err != nil {
+		log.Debugw("Failed to get node metrics, only sampling the nodes", zap.Error(err))
+		return s, nil
+	}
+	for _, m := range nodeMetrics.Items {
+		s.cpuUsage += m.Usage.Cpu().MilliValue()
+		s.memoryUsage += m.Usage.Memory().Value()
+	}
+	return s, nil
+}
+
+func nodeSize(node *corev1.Node) string {
+	for _, label := range nodeSizeLabels {
+		if size := node.Labels[label]; size != "" {
+			return size
+		}
+	}
+	return unknownNodeSize
+}
+
+// addSample adds the sample to the aggregates of the day it was taken on. The nodes are accounted
+// to have run for the given duration.
+func addSample(usage *kubermaticv1.ClusterUsage, s *sample, now time.Time, elapsed time.Duration) {
+	date := now.Format(kubermaticv1.ClusterUsageDateFormat)
+	var day *kubermaticv1.ClusterUsageDay
+	for i := range usage.Spec.Days {
+		if usage.Spec.Days[i].Date == date {
+			day = &usage.Spec.Days[i]
+			break
+		}
+	}
+	if day == nil {
+		usage.Spec.Days = append(usage.Spec.Days, kubermaticv1.ClusterUsageDay{Date: date})
+		day = &usage.Spec.Days[len(usage.Spec.Days)-1]
+	}
+
+	day.Samples++
+	if int32(s.nodes) > day.MaxNodes {
+		day.MaxNodes = int32(s.nodes)
+	}
+	if len(s.nodeSizes) > 0 && day.NodeSeconds == nil {
+		day.NodeSeconds = map[string]int64{}
+	}
+	for size, count := range s.nodeSizes {
+		day.NodeSeconds[size] += count * int64(elapsed.Seconds())
+	}
+	day.NodesSum += s.nodes
+	day.CPUCapacitySum += s.cpuCapacity
+	day.MemoryCapacitySum += s.memoryCapacity
+	day.CPUUsageSum += s.cpuUsage
+	day.MemoryUsageSum += s.memoryUsage
+
+	usage.Spec.LastSampleTime = metav1.NewTime(now)
+}
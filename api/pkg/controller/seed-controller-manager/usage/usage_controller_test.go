@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const clusterName = "measured"
+
+type fakeClientProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeClientProvider) GetClient(*kubermaticv1.Cluster, ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return p.client, nil
+}
+
+func genNode(name, size string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+	if size != "" {
+		node.Labels["node.kubernetes.io/instance-type"] = size
+	}
+	return node
+}
+
+func genNodeMetrics(name string) *metricsv1beta1.NodeMetrics {
+	return &metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Usage: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	userClusterScheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(userClusterScheme); err != nil {
+		t.Fatalf("failed to register scheme: %v", err)
+	}
+	if err := metricsv1beta1.AddToScheme(userClusterScheme); err != nil {
+		t.Fatalf("failed to register scheme: %v", err)
+	}
+	userClusterClient := fakectrlruntimeclient.NewFakeClientWithScheme(userClusterScheme,
+		genNode("node-1", "m5.large"),
+		genNode("node-2", "m5.large"),
+		genNode("node-3", ""),
+		genNodeMetrics("node-1"),
+		genNodeMetrics("node-2"),
+	)
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"}},
+		Spec: kubermaticv1.ClusterSpec{
+			HumanReadableName: "production",
+			Cloud:             kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName:  "cluster-" + clusterName,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+		},
+	}
+	seedClient := fakectrlruntimeclient.NewFakeClient(cluster)
+
+	start := time.Date(2020, 3, 31, 23, 30, 0, 0, time.UTC)
+	now := start
+	r := &Reconciler{
+		Client:                        seedClient,
+		log:                           kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeClientProvider{client: userClusterClient},
+		sampleInterval:                15 * time.Minute,
+		now:                           func() time.Time { return now },
+	}
+	reconcileAt := func(t *testing.T, at time.Time) time.Duration {
+		now = at
+		result, err := r.reconcile(ctx, r.log, cluster)
+		if err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		return result.RequeueAfter
+	}
+	getUsage := func(t *testing.T, name string) *kubermaticv1.ClusterUsage {
+		usage := &kubermaticv1.ClusterUsage{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Name: name}, usage); err != nil {
+			t.Fatalf("failed to get cluster usage: %v", err)
+		}
+		return usage
+	}
+
+	if requeueAfter := reconcileAt(t, start); requeueAfter != 15*time.Minute {
+		t.Errorf("expected to sample again in 15m, got %v", requeueAfter)
+	}
+	usage := getUsage(t, clusterName+"-2020-03")
+	if usage.Spec.ProjectID != "my-project" || usage.Spec.Provider != "aws" || usage.Labels[kubermaticv1.ClusterUsageMonthLabelKey] != "2020-03" {
+		t.Errorf("unexpected cluster usage spec %+v with labels %v", usage.Spec, usage.Labels)
+	}
+
+	// A reconciliation triggered by an update of the cluster must not add a sample
+	if requeueAfter := reconcileAt(t, start.Add(5*time.Minute)); requeueAfter != 10*time.Minute {
+		t.Errorf("expected to sample again in 10m, got %v", requeueAfter)
+	}
+	reconcileAt(t, start.Add(15*time.Minute))
+
+	usage = getUsage(t, clusterName+"-2020-03")
+	if len(usage.Spec.Days) != 1 {
+		t.Fatalf("expected one day, got %d", len(usage.Spec.Days))
+	}
+	day := usage.Spec.Days[0]
+	if day.Date != "2020-03-31" || day.Samples != 2 || day.MaxNodes != 3 || day.NodesSum != 6 {
+		t.Errorf("unexpected aggregates %+v", day)
+	}
+	if day.NodeSeconds["m5.large"] != 2*2*900 || day.NodeSeconds[unknownNodeSize] != 2*900 {
+		t.Errorf("unexpected node seconds %v", day.NodeSeconds)
+	}
+	if day.CPUCapacitySum != 2*6000 || day.CPUUsageSum != 2*1000 || day.MemoryUsageSum != 2*2*1024*1024*1024 {
+		t.Errorf("unexpected resource sums %+v", day)
+	}
+
+	// Samples of the next month are stored separately
+	reconcileAt(t, start.Add(30*time.Minute))
+	usage = getUsage(t, clusterName+"-2020-04")
+	if len(usage.Spec.Days) != 1 || usage.Spec.Days[0].Date != "2020-04-01" || usage.Spec.Days[0].Samples != 1 {
+		t.Errorf("unexpected days %+v", usage.Spec.Days)
+	}
+}
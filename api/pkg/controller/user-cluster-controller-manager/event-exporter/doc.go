@@ -0,0 +1,27 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package eventexporter contains a controller that forwards the Kubernetes events of the user cluster
+to a sink, so they are still available after the apiserver of the user cluster dropped them.
+
+The seed sink stores the events as cluster-scoped ClusterEvents on the seed, labelled with the
+cluster name, where the cluster events API picks them up. They are independent of the event TTL of
+the apiserver and of the cluster namespace, and are kept for the configured retention after they
+occurred the last time. The seed-controller-manager deletes them once they expired.
+The http sink posts every new or updated event as JSON to the configured URL.
+*/
+package eventexporter
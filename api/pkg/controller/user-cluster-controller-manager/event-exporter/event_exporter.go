@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// This controller forwards the events of the user cluster to a sink
+	controllerName = "event_exporter_controller"
+)
+
+type reconciler struct {
+	ctx    context.Context
+	log    *zap.SugaredLogger
+	client ctrlruntimeclient.Client
+	sink   Sink
+}
+
+// Add creates a new event exporter controller and adds it to the manager.
+func Add(ctx context.Context, log *zap.SugaredLogger, mgr manager.Manager, sink Sink) error {
+	log = log.Named(controllerName)
+
+	r := &reconciler{
+		ctx:    ctx,
+		log:    log,
+		client: mgr.GetClient(),
+		sink:   sink,
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler: r,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err = c.Watch(&source.Kind{Type: &corev1.Event{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to establish watch for the Events %v", err)
+	}
+
+	return nil
+}
+
+func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("Event", request.NamespacedName)
+	log.Debug("Reconciling")
+
+	event := &corev1.Event{}
+	if err := r.client.Get(r.ctx, request.NamespacedName, event); err != nil {
+		if kerrors.IsNotFound(err) {
+			// The event expired, the exported copy is kept
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get event: %v", err)
+	}
+
+	// Failures are not recorded as events, as those would get exported as well
+	if err := r.sink.Export(r.ctx, event); err != nil {
+		log.Errorw("Exporting event failed", zap.Error(err))
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Sink receives the events of the user cluster.
+type Sink interface {
+	Export(ctx context.Context, event *corev1.Event) error
+}
+
+// DefaultRetention is how long the events exported to the seed are kept if no retention is configured
+const DefaultRetention = 30 * 24 * time.Hour
+
+type seedSink struct {
+	client      ctrlruntimeclient.Client
+	clusterName string
+	retention   time.Duration
+}
+
+// NewSeedSink returns a Sink which stores the events as ClusterEvents on the seed. They are kept for the
+// given retention after they occurred the last time, also after the cluster got deleted.
+func NewSeedSink(client ctrlruntimeclient.Client, clusterName string, retention time.Duration) Sink {
+	return &seedSink{client: client, clusterName: clusterName, retention: retention}
+}
+
+func (s *seedSink) Export(ctx context.Context, event *corev1.Event) error {
+	wanted := s.clusterEvent(event)
+
+	existing := &kubermaticv1.ClusterEvent{}
+	if err := s.client.Get(ctx, types.NamespacedName{Name: wanted.Name}, existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get exported event: %v", err)
+		}
+		if err := s.client.Create(ctx, wanted); err != nil {
+			return fmt.Errorf("failed to create exported event: %v", err)
+		}
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, wanted.Spec) {
+		return nil
+	}
+	oldExisting := existing.DeepCopy()
+	existing.Spec = wanted.Spec
+	if err := s.client.Patch(ctx, existing, ctrlruntimeclient.MergeFrom(oldExisting)); err != nil {
+		return fmt.Errorf("failed to update exported event: %v", err)
+	}
+	return nil
+}
+
+// clusterEvent returns the ClusterEvent the event gets stored as
+func (s *seedSink) clusterEvent(event *corev1.Event) *kubermaticv1.ClusterEvent {
+	clusterEvent := &kubermaticv1.ClusterEvent{
+		Spec: kubermaticv1.ClusterEventSpec{
+			Cluster:        s.clusterName,
+			Type:           event.Type,
+			Reason:         event.Reason,
+			Message:        event.Message,
+			InvolvedObject: event.InvolvedObject,
+			Source:         event.Source,
+			FirstTimestamp: event.FirstTimestamp,
+			LastTimestamp:  event.LastTimestamp,
+			Count:          event.Count,
+		},
+	}
+	clusterEvent.Name = exportedEventName(s.clusterName, event)
+	clusterEvent.Labels = map[string]string{kubermaticv1.ClusterEventClusterLabelKey: s.clusterName}
+	// Events created through the events.k8s.io API have no legacy timestamps
+	if clusterEvent.Spec.LastTimestamp.IsZero() {
+		clusterEvent.Spec.LastTimestamp.Time = event.EventTime.Time
+	}
+	if clusterEvent.Spec.FirstTimestamp.IsZero() {
+		clusterEvent.Spec.FirstTimestamp = clusterEvent.Spec.LastTimestamp
+	}
+	clusterEvent.Spec.ExpiresAt = metav1.NewTime(clusterEvent.Spec.LastTimestamp.Add(s.retention))
+	return clusterEvent
+}
+
+// exportedEventName returns a name for the exported event which is unique within the seed
+func exportedEventName(clusterName string, event *corev1.Event) string {
+	return fmt.Sprintf("%s.%x", clusterName, sha1.Sum([]byte(event.Namespace+"/"+event.Name)))
+}
+
+type httpSink struct {
+	client *http.Client
+	url    string
+}
+
+// NewHTTPSink returns a Sink which posts the events as JSON to the given URL.
+func NewHTTPSink(url string) Sink {
+	return &httpSink{
+		client: &http.Client{Timeout: 10 * time.Second},
+		url:    url,
+	}
+}
+
+func (s *httpSink) Export(ctx context.Context, event *corev1.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to send event: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const clusterName = "test"
+
+func genEvent(count int32, lastTimestamp time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-1.15f6e8a2c7b1d3a4",
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "pod-1",
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        "FailedScheduling",
+		Message:       "0/3 nodes are available",
+		Count:         count,
+		LastTimestamp: metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestSeedSink(t *testing.T) {
+	ctx := context.Background()
+	client := fakectrlruntimeclient.NewFakeClient()
+	sink := NewSeedSink(client, clusterName, 24*time.Hour)
+	firstSeen := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	if err := sink.Export(ctx, genEvent(1, firstSeen)); err != nil {
+		t.Fatalf("failed to export event: %v", err)
+	}
+	name := types.NamespacedName{Name: exportedEventName(clusterName, genEvent(1, firstSeen))}
+	exported := &kubermaticv1.ClusterEvent{}
+	if err := client.Get(ctx, name, exported); err != nil {
+		t.Fatalf("failed to get exported event: %v", err)
+	}
+	if exported.Labels[kubermaticv1.ClusterEventClusterLabelKey] != clusterName || exported.Spec.Cluster != clusterName {
+		t.Errorf("expected the exported event to be labelled with the cluster name, got labels %v", exported.Labels)
+	}
+	if exported.Spec.InvolvedObject.Namespace != "default" {
+		t.Errorf("expected the namespace of the involved object to be kept, got %q", exported.Spec.InvolvedObject.Namespace)
+	}
+	if !exported.Spec.ExpiresAt.Time.Equal(firstSeen.Add(24 * time.Hour)) {
+		t.Errorf("expected the exported event to expire after the retention, got %v", exported.Spec.ExpiresAt)
+	}
+
+	lastSeen := firstSeen.Add(time.Hour)
+	if err := sink.Export(ctx, genEvent(5, lastSeen)); err != nil {
+		t.Fatalf("failed to export updated event: %v", err)
+	}
+	if err := client.Get(ctx, name, exported); err != nil {
+		t.Fatalf("failed to get exported event: %v", err)
+	}
+	if exported.Spec.Count != 5 || !exported.Spec.LastTimestamp.Time.Equal(lastSeen) {
+		t.Errorf("expected the exported event to be updated, got count %d and last timestamp %v", exported.Spec.Count, exported.Spec.LastTimestamp)
+	}
+	if !exported.Spec.ExpiresAt.Time.Equal(lastSeen.Add(24 * time.Hour)) {
+		t.Errorf("expected the expiry to be extended when the event occurred again, got %v", exported.Spec.ExpiresAt)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received *corev1.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received = &corev1.Event{}
+		if err := json.NewDecoder(r.Body).Decode(received); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	event := genEvent(1, time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC))
+	if err := NewHTTPSink(server.URL+"/events").Export(context.Background(), event); err != nil {
+		t.Fatalf("failed to export event: %v", err)
+	}
+	if received == nil || received.Name != event.Name || received.Reason != event.Reason {
+		t.Errorf("expected the sink to receive event %q, got %v", event.Name, received)
+	}
+
+	if err := NewHTTPSink(server.URL+"/fail").Export(context.Background(), event); err == nil {
+		t.Error("expected an error if the sink does not accept the event")
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeepersyncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	controllerutil "github.com/kubermatic/kubermatic/api/pkg/controller/util"
+	predicateutil "github.com/kubermatic/kubermatic/api/pkg/controller/util/predicate"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	controllerName = "gatekeeper_syncer"
+
+	// addonRequeueInterval is used while the gatekeeper addon is not installed
+	addonRequeueInterval = time.Minute
+	// crdRequeueInterval is used while gatekeeper has not yet created the CRD for a constraint template
+	crdRequeueInterval = 10 * time.Second
+)
+
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	seedMgr manager.Manager,
+	clusterNamespace string,
+) error {
+	r := &reconciler{
+		ctx:               context.Background(),
+		log:               log.Named(controllerName),
+		userClusterClient: mgr.GetClient(),
+		seedClient:        seedMgr.GetClient(),
+		clusterNamespace:  clusterNamespace,
+	}
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %v", err)
+	}
+
+	seedConfigMapSource := &source.Kind{Type: &corev1.ConfigMap{}}
+	if err := seedConfigMapSource.InjectCache(seedMgr.GetCache()); err != nil {
+		return fmt.Errorf("failed to inject seed cache into watch: %v", err)
+	}
+	if err := c.Watch(
+		seedConfigMapSource,
+		controllerutil.EnqueueConst(""),
+		predicateutil.ByName(gatekeeper.PoliciesConfigMapName),
+	); err != nil {
+		return fmt.Errorf("failed to watch configmaps in seed: %v", err)
+	}
+	if err := c.Watch(
+		&source.Kind{Type: &apiextensionsv1beta1.CustomResourceDefinition{}},
+		controllerutil.EnqueueConst(""),
+		predicateutil.ByName(gatekeeper.ConstraintTemplateCRDName),
+	); err != nil {
+		return fmt.Errorf("failed to watch CustomResourceDefinitions in usercluster: %v", err)
+	}
+
+	return nil
+}
+
+type reconciler struct {
+	ctx               context.Context
+	log               *zap.SugaredLogger
+	userClusterClient ctrlruntimeclient.Client
+	seedClient        ctrlruntimeclient.Client
+	clusterNamespace  string
+}
+
+func (r *reconciler) Reconcile(_ reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconcile()
+	if err != nil {
+		r.log.Errorw("Reconciliation failed", zap.Error(err))
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *reconciler) reconcile() (*reconcile.Result, error) {
+	crd := &apiextensionsv1beta1.CustomResourceDefinition{}
+	if err := r.userClusterClient.Get(r.ctx, types.NamespacedName{Name: gatekeeper.ConstraintTemplateCRDName}, crd); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get CustomResourceDefinition %s: %v", gatekeeper.ConstraintTemplateCRDName, err)
+		}
+		// The CRD is created by the gatekeeper addon, nothing to do as long as it isn't installed
+		r.log.Debug("Gatekeeper is not installed in the user cluster, retrying later")
+		return &reconcile.Result{RequeueAfter: addonRequeueInterval}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.seedClient.Get(r.ctx, types.NamespacedName{Namespace: r.clusterNamespace, Name: gatekeeper.PoliciesConfigMapName}, configMap); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %v", gatekeeper.PoliciesConfigMapName, err)
+		}
+		// Not synced by the master yet, remove everything we created before
+		configMap = &corev1.ConfigMap{}
+	}
+
+	templates, constraints, err := gatekeeper.PoliciesFromConfigMap(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Constraints reference their template by its name, gatekeeper needs its kind
+	templateKinds := map[string]string{}
+	desiredTemplates := sets.NewString()
+	for _, template := range templates {
+		obj, err := gatekeeper.ConstraintTemplateObject(&template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build constraint template %s: %v", template.Name, err)
+		}
+		if err := r.ensureObject(obj); err != nil {
+			if err == errNotManaged {
+				r.log.Warnw("Skipping constraint template because a constraint template of the same name was created in the cluster", "template", template.Name)
+				continue
+			}
+			return nil, fmt.Errorf("failed to ensure constraint template %s: %v", template.Name, err)
+		}
+		templateKinds[template.Name] = template.Spec.Kind
+		desiredTemplates.Insert(obj.GetName())
+	}
+
+	requeue := false
+	desiredConstraints := map[string]sets.String{}
+	for _, constraint := range constraints {
+		kind, ok := templateKinds[constraint.Spec.ConstraintTemplate]
+		if !ok {
+			r.log.Debugw("Skipping constraint because its template does not exist", "constraint", constraint.Name, "template", constraint.Spec.ConstraintTemplate)
+			continue
+		}
+		obj, err := gatekeeper.ConstraintObject(&constraint, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build constraint %s: %v", constraint.Name, err)
+		}
+		if err := r.ensureObject(obj); err != nil {
+			// Gatekeeper creates the CRD for the constraints of a template asynchronously
+			if meta.IsNoMatchError(err) {
+				r.log.Debugw("CRD for constraint does not exist yet, retrying later", "constraint", constraint.Name, "kind", kind)
+				requeue = true
+				continue
+			}
+			if err == errNotManaged {
+				r.log.Warnw("Skipping constraint because a constraint of the same name was created in the cluster", "constraint", constraint.Name, "name", constraint.Spec.Name)
+				continue
+			}
+			return nil, fmt.Errorf("failed to ensure constraint %s: %v", constraint.Name, err)
+		}
+		if desiredConstraints[kind] == nil {
+			desiredConstraints[kind] = sets.NewString()
+		}
+		desiredConstraints[kind].Insert(obj.GetName())
+	}
+
+	if err := r.cleanup(desiredTemplates, desiredConstraints); err != nil {
+		return nil, err
+	}
+
+	if requeue {
+		return &reconcile.Result{RequeueAfter: crdRequeueInterval}, nil
+	}
+	return nil, nil
+}
+
+// errNotManaged is returned by ensureObject if an object of the same name exists which was not created by us
+var errNotManaged = errors.New("object exists but is not managed by Kubermatic")
+
+// ensureObject creates the given object or updates its spec if it differs. Objects without the
+// ManagedByLabel were created by the users of the cluster, they are left untouched.
+func (r *reconciler) ensureObject(desired *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := r.userClusterClient.Get(r.ctx, types.NamespacedName{Name: desired.GetName()}, existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		return r.userClusterClient.Create(r.ctx, desired)
+	}
+
+	if existing.GetLabels()[gatekeeper.ManagedByLabel] != gatekeeper.ManagedByValue {
+		return errNotManaged
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+	return r.userClusterClient.Update(r.ctx, existing)
+}
+
+// cleanup removes all managed constraint templates and constraints which are not desired anymore.
+// Constraints of removed templates are deleted by gatekeeper together with their CRD.
+func (r *reconciler) cleanup(desiredTemplates sets.String, desiredConstraints map[string]sets.String) error {
+	existingTemplates := &unstructured.UnstructuredList{}
+	existingTemplates.SetGroupVersionKind(gatekeeper.ConstraintTemplateGVK.GroupVersion().WithKind(gatekeeper.ConstraintTemplateGVK.Kind + "List"))
+	if err := r.userClusterClient.List(r.ctx, existingTemplates, ctrlruntimeclient.MatchingLabels{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue}); err != nil {
+		return fmt.Errorf("failed to list constraint templates: %v", err)
+	}
+
+	for _, template := range existingTemplates.Items {
+		if !desiredTemplates.Has(template.GetName()) {
+			if err := r.userClusterClient.Delete(r.ctx, &template); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete constraint template %s: %v", template.GetName(), err)
+			}
+			continue
+		}
+
+		kind, _, err := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+		if err != nil || kind == "" {
+			continue
+		}
+		if err := r.cleanupConstraints(gatekeeper.ConstraintGroupVersion.WithKind(kind), desiredConstraints[kind]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *reconciler) cleanupConstraints(gvk schema.GroupVersionKind, desired sets.String) error {
+	existingConstraints := &unstructured.UnstructuredList{}
+	existingConstraints.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.userClusterClient.List(r.ctx, existingConstraints, ctrlruntimeclient.MatchingLabels{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list %s constraints: %v", gvk.Kind, err)
+	}
+
+	for _, constraint := range existingConstraints.Items {
+		if desired.Has(constraint.GetName()) {
+			continue
+		}
+		if err := r.userClusterClient.Delete(r.ctx, &constraint); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s constraint %s: %v", gvk.Kind, constraint.GetName(), err)
+		}
+	}
+
+	return nil
+}
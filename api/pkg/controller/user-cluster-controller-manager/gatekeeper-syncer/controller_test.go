@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeepersyncer
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const clusterNamespace = "cluster-test"
+
+var constraintGVK = gatekeeper.ConstraintGroupVersion.WithKind("K8sRequiredLabels")
+
+func genUnstructured(gvk schema.GroupVersionKind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue})
+	return obj
+}
+
+func genUserClusterScheme(t *testing.T) *runtime.Scheme {
+	// gatekeeper types are only known to the fake client as unstructured objects
+	userClusterScheme := runtime.NewScheme()
+	if err := apiextensionsv1beta1.AddToScheme(userClusterScheme); err != nil {
+		t.Fatalf("failed to register scheme: %v", err)
+	}
+	for _, gvk := range []schema.GroupVersionKind{gatekeeper.ConstraintTemplateGVK, constraintGVK} {
+		userClusterScheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		userClusterScheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return userClusterScheme
+}
+
+func genPoliciesConfigMap(t *testing.T) *corev1.ConfigMap {
+	templates := []kubermaticv1.ConstraintTemplate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8srequiredlabels"},
+			Spec: kubermaticv1.ConstraintTemplateSpec{
+				Kind:    "K8sRequiredLabels",
+				Targets: []kubermaticv1.ConstraintTemplateTarget{{Target: "admission.k8s.gatekeeper.sh", Rego: "package k8srequiredlabels"}},
+			},
+		},
+	}
+	constraints := []kubermaticv1.Constraint{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "abc"},
+			Spec:       kubermaticv1.ConstraintSpec{Name: "must-have-owner", ConstraintTemplate: "k8srequiredlabels", EnforcementAction: "dryrun"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "def"},
+			Spec:       kubermaticv1.ConstraintSpec{Name: "unknown-template", ConstraintTemplate: "k8sallowedrepos"},
+		},
+	}
+
+	name, creator := gatekeeper.PoliciesConfigMapCreator(templates, constraints)()
+	cm, err := creator(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: clusterNamespace, Name: name}})
+	if err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+	return cm
+}
+
+func TestReconcile(t *testing.T) {
+	userClusterClient := fakectrlruntimeclient.NewFakeClientWithScheme(genUserClusterScheme(t),
+		&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: gatekeeper.ConstraintTemplateCRDName}},
+		// not desired anymore, must be removed
+		genUnstructured(gatekeeper.ConstraintTemplateGVK, "k8sremoved", map[string]interface{}{}),
+		genUnstructured(constraintGVK, "removed", map[string]interface{}{}),
+		// not managed by Kubermatic, must be kept
+		func() *unstructured.Unstructured {
+			obj := genUnstructured(constraintGVK, "users-own", map[string]interface{}{})
+			obj.SetLabels(nil)
+			return obj
+		}(),
+	)
+
+	r := &reconciler{
+		ctx:               context.Background(),
+		log:               kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		userClusterClient: userClusterClient,
+		seedClient:        fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, genPoliciesConfigMap(t)),
+		clusterNamespace:  clusterNamespace,
+	}
+
+	if _, err := r.Reconcile(reconcile.Request{}); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	get := func(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		return obj, userClusterClient.Get(context.Background(), types.NamespacedName{Name: name}, obj)
+	}
+
+	template, err := get(gatekeeper.ConstraintTemplateGVK, "k8srequiredlabels")
+	if err != nil {
+		t.Fatalf("failed to get constraint template: %v", err)
+	}
+	if kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind"); kind != "K8sRequiredLabels" {
+		t.Errorf("expected constraint template of kind K8sRequiredLabels, got %q", kind)
+	}
+
+	constraint, err := get(constraintGVK, "must-have-owner")
+	if err != nil {
+		t.Fatalf("failed to get constraint: %v", err)
+	}
+	if action, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction"); action != "dryrun" {
+		t.Errorf("expected enforcementAction dryrun, got %q", action)
+	}
+
+	if _, err := get(gatekeeper.ConstraintTemplateGVK, "k8sremoved"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected constraint template k8sremoved to be deleted, got %v", err)
+	}
+	if _, err := get(constraintGVK, "removed"); !kerrors.IsNotFound(err) {
+		t.Errorf("expected constraint removed to be deleted, got %v", err)
+	}
+	if _, err := get(constraintGVK, "users-own"); err != nil {
+		t.Errorf("expected unmanaged constraint to be kept, got %v", err)
+	}
+}
+
+func TestReconcileDoesNotAdoptUnmanagedObjects(t *testing.T) {
+	// created by a user of the cluster with the name of a Kubermatic constraint
+	usersConstraint := genUnstructured(constraintGVK, "must-have-owner", map[string]interface{}{"enforcementAction": "deny"})
+	usersConstraint.SetLabels(nil)
+
+	userClusterClient := fakectrlruntimeclient.NewFakeClientWithScheme(genUserClusterScheme(t),
+		&apiextensionsv1beta1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: gatekeeper.ConstraintTemplateCRDName}},
+		usersConstraint,
+	)
+
+	r := &reconciler{
+		ctx:               context.Background(),
+		log:               kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		userClusterClient: userClusterClient,
+		seedClient:        fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, genPoliciesConfigMap(t)),
+		clusterNamespace:  clusterNamespace,
+	}
+
+	if _, err := r.Reconcile(reconcile.Request{}); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	constraint := &unstructured.Unstructured{}
+	constraint.SetGroupVersionKind(constraintGVK)
+	if err := userClusterClient.Get(context.Background(), types.NamespacedName{Name: "must-have-owner"}, constraint); err != nil {
+		t.Fatalf("expected unmanaged constraint to be kept, got %v", err)
+	}
+	if _, ok := constraint.GetLabels()[gatekeeper.ManagedByLabel]; ok {
+		t.Errorf("expected unmanaged constraint not to be labeled as managed by Kubermatic")
+	}
+	if action, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction"); action != "deny" {
+		t.Errorf("expected enforcementAction of unmanaged constraint to be kept, got %q", action)
+	}
+}
+
+func TestReconcileWithoutGatekeeper(t *testing.T) {
+	r := &reconciler{
+		ctx:               context.Background(),
+		log:               kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		userClusterClient: fakectrlruntimeclient.NewFakeClientWithScheme(genUserClusterScheme(t)),
+		seedClient:        fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, genPoliciesConfigMap(t)),
+		clusterNamespace:  clusterNamespace,
+	}
+
+	result, err := r.Reconcile(reconcile.Request{})
+	if err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if result.RequeueAfter != addonRequeueInterval {
+		t.Errorf("expected requeue after %v while gatekeeper is not installed, got %v", addonRequeueInterval, result.RequeueAfter)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/heptiolabs/healthcheck"
 	"go.uber.org/zap"
 
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
 
@@ -67,6 +68,8 @@ func Add(
 	cloudProviderName string,
 	clusterURL *url.URL,
 	openvpnServerPort int,
+	tunnelMode kubermaticv1.TunnelMode,
+	konnectivityServerPort int,
 	registerReconciledCheck func(name string, check healthcheck.Check),
 	cloudCredentialSecretTemplate *corev1.Secret,
 	openshiftConsoleCallbackURI string,
@@ -79,6 +82,8 @@ func Add(
 		namespace:                     namespace,
 		clusterURL:                    clusterURL,
 		openvpnServerPort:             openvpnServerPort,
+		tunnelMode:                    tunnelMode,
+		konnectivityServerPort:        konnectivityServerPort,
 		cloudCredentialSecretTemplate: cloudCredentialSecretTemplate,
 		log:                           log,
 		platform:                      cloudProviderName,
@@ -219,6 +224,8 @@ type reconciler struct {
 	namespace                     string
 	clusterURL                    *url.URL
 	openvpnServerPort             int
+	tunnelMode                    kubermaticv1.TunnelMode
+	konnectivityServerPort        int
 	platform                      string
 	cloudCredentialSecretTemplate *corev1.Secret
 	openshiftConsoleCallbackURI   string
@@ -247,6 +254,10 @@ func (r *reconciler) caCert(ctx context.Context) (*triple.KeyPair, error) {
 	return resources.GetClusterRootCA(ctx, r.namespace, r.seedClient)
 }
 
+func (r *reconciler) konnectivityCA(ctx context.Context) (*triple.KeyPair, error) {
+	return resources.GetKonnectivityCA(ctx, r.namespace, r.seedClient)
+}
+
 func (r *reconciler) openVPNCA(ctx context.Context) (*resources.ECDSAKeyPair, error) {
 	return resources.GetOpenVPNCA(ctx, r.namespace, r.seedClient)
 }
@@ -267,16 +278,16 @@ func (r *reconciler) userSSHKeys(ctx context.Context) (map[string][]byte, error)
 }
 
 func (r *reconciler) cloudConfig(ctx context.Context) ([]byte, error) {
-	configmap := &corev1.ConfigMap{}
-	name := types.NamespacedName{Namespace: r.namespace, Name: resources.CloudConfigConfigMapName}
-	if err := r.seedClient.Get(ctx, name, configmap); err != nil {
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: r.namespace, Name: resources.CloudConfigSeedSecretName}
+	if err := r.seedClient.Get(ctx, name, secret); err != nil {
 		return nil, fmt.Errorf("failed to get cloud-config: %v", err)
 	}
-	value, exists := configmap.Data[resources.CloudConfigConfigMapKey]
+	value, exists := secret.Data[resources.CloudConfigSeedSecretKey]
 	if !exists {
-		return nil, fmt.Errorf("cloud-config configmap contains no data for key %s", resources.CloudConfigConfigMapKey)
+		return nil, fmt.Errorf("cloud-config secret contains no data for key %s", resources.CloudConfigSeedSecretKey)
 	}
-	return []byte(value), nil
+	return value, nil
 }
 
 func apiReadingClient(apiReader ctrlruntimeclient.Reader, writer ctrlruntimeclient.Client) ctrlruntimeclient.Client {
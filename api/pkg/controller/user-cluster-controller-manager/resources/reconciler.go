@@ -26,6 +26,7 @@ import (
 	controllermanager "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/controller-manager"
 	coredns "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/core-dns"
 	dnatcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/dnat-controller"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/konnectivity"
 	kubestatemetrics "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/kube-state-metrics"
 	kubernetesdashboard "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/kubernetes-dashboard"
 	machinecontroller "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/machine-controller"
@@ -53,9 +54,19 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get caCert: %v", err)
 	}
-	openVPNCACert, err := r.openVPNCA(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get openVPN CA cert: %v", err)
+	// Only the CA of the tunnel the cluster uses exists
+	var openVPNCACert *resources.ECDSAKeyPair
+	var konnectivityCACert *triple.KeyPair
+	if r.konnectivityEnabled() {
+		konnectivityCACert, err = r.konnectivityCA(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get konnectivity CA cert: %v", err)
+		}
+	} else {
+		openVPNCACert, err = r.openVPNCA(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get openVPN CA cert: %v", err)
+		}
 	}
 	userSSHKeys, err := r.userSSHKeys(ctx)
 	if err != nil {
@@ -66,10 +77,11 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		return fmt.Errorf("failed to get cloudConfig: %v", err)
 	}
 	data := reconcileData{
-		caCert:        caCert,
-		openVPNCACert: openVPNCACert,
-		userSSHKeys:   userSSHKeys,
-		cloudConfig:   cloudConfig,
+		caCert:             caCert,
+		openVPNCACert:      openVPNCACert,
+		konnectivityCACert: konnectivityCACert,
+		userSSHKeys:        userSSHKeys,
+		cloudConfig:        cloudConfig,
 	}
 
 	// Must be first because of openshift
@@ -138,13 +150,20 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if err := r.cleanupTunnelResources(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (r *reconciler) ensureAPIServices(ctx context.Context, data reconcileData) error {
 	caCert := triple.EncodeCertPEM(data.caCert.Cert)
-	creators := []reconciling.NamedAPIServiceCreatorGetter{
-		metricsserver.APIServiceCreator(caCert),
+	var creators []reconciling.NamedAPIServiceCreatorGetter
+	// With Konnectivity the metrics-server runs inside the user cluster and is deployed
+	// as addon, including its APIService and RBAC
+	if !r.konnectivityEnabled() {
+		creators = append(creators, metricsserver.APIServiceCreator(caCert))
 	}
 
 	if r.openshift {
@@ -264,12 +283,14 @@ func (r *reconciler) reconcileRoleBindings(ctx context.Context) error {
 	// kube-system
 	creators := []reconciling.NamedRoleBindingCreatorGetter{
 		machinecontroller.KubeSystemRoleBindingCreator(),
-		metricsserver.RolebindingAuthReaderCreator(),
 		scheduler.RoleBindingAuthDelegator(),
 		controllermanager.RoleBindingAuthDelegator(),
 		clusterautoscaler.KubeSystemRoleBindingCreator(),
 		usersshkeys.RoleBindingCreator(),
 	}
+	if !r.konnectivityEnabled() {
+		creators = append(creators, metricsserver.RolebindingAuthReaderCreator())
+	}
 	if err := reconciling.ReconcileRoleBindings(ctx, creators, metav1.NamespaceSystem, r.Client); err != nil {
 		return fmt.Errorf("failed to reconcile RoleBindings in kube-system Namespace: %v", err)
 	}
@@ -329,9 +350,15 @@ func (r *reconciler) reconcileClusterRoles(ctx context.Context) error {
 		kubestatemetrics.ClusterRoleCreator(),
 		prometheus.ClusterRoleCreator(),
 		machinecontroller.ClusterRoleCreator(),
-		dnatcontroller.ClusterRoleCreator(),
-		metricsserver.ClusterRoleCreator(),
 		clusterautoscaler.ClusterRoleCreator(),
+		usersshkeys.ClusterRoleCreator(),
+	}
+
+	if !r.konnectivityEnabled() {
+		creators = append(creators,
+			dnatcontroller.ClusterRoleCreator(),
+			metricsserver.ClusterRoleCreator(),
+		)
 	}
 
 	if !r.openshift {
@@ -356,14 +383,20 @@ func (r *reconciler) reconcileClusterRoleBindings(ctx context.Context) error {
 		machinecontroller.ClusterRoleBindingCreator(),
 		machinecontroller.NodeBootstrapperClusterRoleBindingCreator(),
 		machinecontroller.NodeSignerClusterRoleBindingCreator(),
-		dnatcontroller.ClusterRoleBindingCreator(),
-		metricsserver.ClusterRoleBindingResourceReaderCreator(),
-		metricsserver.ClusterRoleBindingAuthDelegatorCreator(),
 		scheduler.ClusterRoleBindingAuthDelegatorCreator(),
 		controllermanager.ClusterRoleBindingAuthDelegator(),
 		clusterautoscaler.ClusterRoleBindingCreator(),
 		systembasicuser.ClusterRoleBinding,
 		cloudcontroller.ClusterRoleBindingCreator(),
+		usersshkeys.ClusterRoleBindingCreator(),
+	}
+
+	if !r.konnectivityEnabled() {
+		creators = append(creators,
+			dnatcontroller.ClusterRoleBindingCreator(),
+			metricsserver.ClusterRoleBindingResourceReaderCreator(),
+			metricsserver.ClusterRoleBindingAuthDelegatorCreator(),
+		)
 	}
 
 	if r.openshift {
@@ -411,8 +444,9 @@ func (r *reconciler) reconcileMutatingWebhookConfigurations(
 }
 
 func (r *reconciler) reconcileServices(ctx context.Context) error {
-	creatorsKubeSystem := []reconciling.NamedServiceCreatorGetter{
-		metricsserver.ExternalNameServiceCreator(r.namespace),
+	var creatorsKubeSystem []reconciling.NamedServiceCreatorGetter
+	if !r.konnectivityEnabled() {
+		creatorsKubeSystem = append(creatorsKubeSystem, metricsserver.ExternalNameServiceCreator(r.namespace))
 	}
 
 	if err := reconciling.ReconcileServices(ctx, creatorsKubeSystem, metav1.NamespaceSystem, r.Client); err != nil {
@@ -452,8 +486,11 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 		return fmt.Errorf("failed to reconcile ConfigMaps in kube-public namespace: %v", err)
 	}
 
-	creators = []reconciling.NamedConfigMapCreatorGetter{
-		openvpn.ClientConfigConfigMapCreator(r.clusterURL.Hostname(), r.openvpnServerPort),
+	creators = []reconciling.NamedConfigMapCreatorGetter{}
+	if r.konnectivityEnabled() {
+		creators = append(creators, konnectivity.AgentConfigMapCreator(r.clusterURL.Hostname(), r.konnectivityServerPort))
+	} else {
+		creators = append(creators, openvpn.ClientConfigConfigMapCreator(r.clusterURL.Hostname(), r.openvpnServerPort))
 	}
 	if r.openshift {
 		creators = append(creators, openshift.ControlplaneConfigCreator(r.platform))
@@ -472,10 +509,14 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 
 func (r *reconciler) reconcileSecrets(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedSecretCreatorGetter{
-		openvpn.ClientCertificate(data.openVPNCACert),
 		usersshkeys.SecretCreator(data.userSSHKeys),
 		cloudcontroller.CloudConfig(data.cloudConfig),
 	}
+	if r.konnectivityEnabled() {
+		creators = append(creators, konnectivity.AgentCertificateCreator(data.konnectivityCACert))
+	} else {
+		creators = append(creators, openvpn.ClientCertificate(data.openVPNCACert))
+	}
 	if r.openshift {
 		creators = append(creators, openshift.OAuthBootstrapPasswordCreatorGetter(r.seedClient, r.namespace))
 		if r.cloudCredentialSecretTemplate != nil {
@@ -622,8 +663,9 @@ func (r *reconciler) reconcilePodDisruptionBudgets(ctx context.Context) error {
 }
 
 type reconcileData struct {
-	caCert        *triple.KeyPair
-	openVPNCACert *resources.ECDSAKeyPair
-	userSSHKeys   map[string][]byte
-	cloudConfig   []byte
+	caCert             *triple.KeyPair
+	openVPNCACert      *resources.ECDSAKeyPair
+	konnectivityCACert *triple.KeyPair
+	userSSHKeys        map[string][]byte
+	cloudConfig        []byte
 }
@@ -130,18 +130,18 @@ func TestResourceReconciliationIdempotency(t *testing.T) {
 			Namespace: seedNamespace,
 		},
 	}
-	cloudConfigMap := &corev1.ConfigMap{
+	cloudConfigSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resources.CloudConfigConfigMapName,
+			Name:      resources.CloudConfigSeedSecretName,
 			Namespace: seedNamespace,
 		},
-		Data: map[string]string{resources.CloudConfigConfigMapKey: "some-cloud-config-content"},
+		Data: map[string][]byte{resources.CloudConfigSeedSecretKey: []byte("some-cloud-config-content")},
 	}
 	seedClient := fakectrlruntimeclient.NewFakeClient(
 		caSecret,
 		openVPNCASecret,
 		sshKeySecret,
-		cloudConfigMap,
+		cloudConfigSecret,
 	)
 	r := reconciler{
 		Client:     mgr.GetClient(),
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+)
+
+// AgentCertificateCreator returns a function to create/update the secret with the client certificate
+// the konnectivity agents use to authenticate against the konnectivity server. The certificate must be signed
+// by the konnectivity CA, the server accepts every client certificate of the CA as an agent.
+func AgentCertificateCreator(ca *triple.KeyPair) reconciling.NamedSecretCreatorGetter {
+	return certificates.GetClientCertificateCreator(
+		resources.KonnectivityAgentCertificatesSecretName,
+		"konnectivity-agent",
+		nil,
+		resources.KonnectivityAgentCertSecretKey,
+		resources.KonnectivityAgentKeySecretKey,
+		func() (*triple.KeyPair, error) { return ca, nil })
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"strconv"
+
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	Name = "konnectivity-agent"
+
+	// ProxyServerHostKey is the key of the konnectivity server host in the agent ConfigMap
+	ProxyServerHostKey = "PROXY_SERVER_HOST"
+	// ProxyServerPortKey is the key of the konnectivity server port in the agent ConfigMap
+	ProxyServerPortKey = "PROXY_SERVER_PORT"
+)
+
+// AgentConfigMapCreator returns a ConfigMap containing the address of the konnectivity server.
+// It lives inside the user-cluster and is consumed by the konnectivity agents as environment.
+func AgentConfigMapCreator(hostname string, serverPort int) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.KonnectivityAgentConfigConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Labels = resources.BaseAppLabels(Name, nil)
+
+			cm.Data[ProxyServerHostKey] = hostname
+			cm.Data[ProxyServerPortKey] = strconv.Itoa(serverPort)
+
+			return cm, nil
+		}
+	}
+}
@@ -58,6 +58,17 @@ func DaemonSetCreator() reconciling.NamedDaemonSetCreatorGetter {
 					ImagePullPolicy: corev1.PullAlways,
 					Image:           fmt.Sprintf("quay.io/kubermatic/user-ssh-keys-agent:%s", resources.KUBERMATICCOMMIT),
 					Command:         []string{fmt.Sprintf("/usr/local/bin/%v", daemonSetName)},
+					Env: []corev1.EnvVar{
+						{
+							Name: "NODE_NAME",
+							ValueFrom: &corev1.EnvVarSource{
+								FieldRef: &corev1.ObjectFieldSelector{
+									APIVersion: "v1",
+									FieldPath:  "spec.nodeName",
+								},
+							},
+						},
+					},
 					VolumeMounts: []corev1.VolumeMount{
 						{
 							Name:      "root",
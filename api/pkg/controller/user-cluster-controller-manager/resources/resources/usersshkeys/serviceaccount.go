@@ -21,12 +21,15 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	serviceAccountName = "user-ssh-keys-agent"
-	roleName           = "user-ssh-keys-agent"
-	roleBindingName    = "user-ssh-keys-agent"
+	serviceAccountName     = "user-ssh-keys-agent"
+	roleName               = "user-ssh-keys-agent"
+	roleBindingName        = "user-ssh-keys-agent"
+	clusterRoleName        = "system:kubermatic:user-ssh-keys-agent"
+	clusterRoleBindingName = "system:kubermatic:user-ssh-keys-agent"
 )
 
 func ServiceAccountCreator() reconciling.NamedServiceAccountCreatorGetter {
@@ -75,3 +78,45 @@ func RoleBindingCreator() reconciling.NamedRoleBindingCreatorGetter {
 		}
 	}
 }
+
+// ClusterRoleCreator returns a func to create/update the ClusterRole which allows the agent
+// to read the labels of the node it runs on
+func ClusterRoleCreator() reconciling.NamedClusterRoleCreatorGetter {
+	return func() (string, reconciling.ClusterRoleCreator) {
+		return clusterRoleName, func(cr *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+			cr.Rules = []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"nodes"},
+					Verbs: []string{
+						"get",
+						"list",
+						"watch",
+					},
+				},
+			}
+			return cr, nil
+		}
+	}
+}
+
+// ClusterRoleBindingCreator returns a func to create/update the ClusterRoleBinding for the agent
+func ClusterRoleBindingCreator() reconciling.NamedClusterRoleBindingCreatorGetter {
+	return func() (string, reconciling.ClusterRoleBindingCreator) {
+		return clusterRoleBindingName, func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			crb.RoleRef = rbacv1.RoleRef{
+				Name:     clusterRoleName,
+				Kind:     "ClusterRole",
+				APIGroup: rbacv1.GroupName,
+			}
+			crb.Subjects = []rbacv1.Subject{
+				{
+					Name:      serviceAccountName,
+					Namespace: metav1.NamespaceSystem,
+					Kind:      rbacv1.ServiceAccountKind,
+				},
+			}
+			return crb, nil
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (r *reconciler) konnectivityEnabled() bool {
+	return r.tunnelMode == kubermaticv1.TunnelModeKonnectivity
+}
+
+// cleanupTunnelResources deletes the user cluster resources of the tunnel mode the cluster does not use,
+// they are left over after the cluster got migrated from OpenVPN to Konnectivity or back.
+func (r *reconciler) cleanupTunnelResources(ctx context.Context) error {
+	for _, obj := range r.unusedTunnelResources() {
+		if err := r.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %T of the unused tunnel: %v", obj, err)
+		}
+	}
+
+	// The metrics-server Service is named the same in both modes: an ExternalName Service pointing to the
+	// seed for OpenVPN and the Service of the metrics-server addon for Konnectivity. The one of the
+	// other mode can not be updated in place, so it gets deleted and recreated.
+	service := &corev1.Service{}
+	name := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: resources.MetricsServerExternalNameServiceName}
+	if err := r.Get(ctx, name, service); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the metrics-server Service: %v", err)
+	}
+	if isExternalName := service.Spec.Type == corev1.ServiceTypeExternalName; isExternalName == r.konnectivityEnabled() {
+		if err := r.Delete(ctx, service); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the metrics-server Service of the unused tunnel: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// unusedTunnelResources returns the resources in the user cluster which belong to the tunnel mode
+// the cluster does not use.
+func (r *reconciler) unusedTunnelResources() []runtime.Object {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: name}
+	}
+
+	if r.konnectivityEnabled() {
+		return []runtime.Object{
+			&corev1.ConfigMap{ObjectMeta: meta(resources.OpenVPNClientConfigConfigMapName)},
+			&corev1.Secret{ObjectMeta: meta(resources.OpenVPNClientCertificatesSecretName)},
+		}
+	}
+
+	return []runtime.Object{
+		&corev1.ConfigMap{ObjectMeta: meta(resources.KonnectivityAgentConfigConfigMapName)},
+		&corev1.Secret{ObjectMeta: meta(resources.KonnectivityAgentCertificatesSecretName)},
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"syscall"
+	"time"
 
 	"gopkg.in/fsnotify.v1"
 
@@ -32,6 +34,7 @@ import (
 
 	predicateutil "github.com/kubermatic/kubermatic/api/pkg/controller/util/predicate"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/ssh"
 
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -55,17 +58,22 @@ type Reconciler struct {
 	ctrlruntimeclient.Client
 	log                *zap.SugaredLogger
 	authorizedKeysPath []string
-	events             chan event.GenericEvent
+	// nodeName is the name of the node the agent runs on. Its labels are
+	// matched against the node selectors of the keys.
+	nodeName string
+	events   chan event.GenericEvent
 }
 
 func Add(
 	mgr manager.Manager,
 	log *zap.SugaredLogger,
-	authorizedKeysPaths []string) error {
+	authorizedKeysPaths []string,
+	nodeName string) error {
 	reconciler := &Reconciler{
 		Client:             mgr.GetClient(),
 		log:                log,
 		authorizedKeysPath: authorizedKeysPaths,
+		nodeName:           nodeName,
 		events:             make(chan event.GenericEvent),
 	}
 
@@ -99,6 +107,12 @@ func Add(
 		return fmt.Errorf("failed to create watch for channelSource: %v", err)
 	}
 
+	if nodeName != "" {
+		if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, userSSHKeySecret, predicateutil.ByName(nodeName)); err != nil {
+			return fmt.Errorf("failed to create watcher for nodes: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -112,11 +126,22 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, fmt.Errorf("failed to fetch user ssh keys: %v", err)
 	}
 
-	if err := r.updateAuthorizedKeys(secret.Data); err != nil {
+	nodeLabels, err := r.fetchNodeLabels(ctx)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to fetch node labels: %v", err)
+	}
+
+	nextExpiry, err := r.updateAuthorizedKeys(secret.Data, nodeLabels, time.Now())
+	if err != nil {
 		r.log.Errorw("Failed reconciling user ssh key secret", zap.Error(err))
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile user ssh keys: %v", err)
 	}
 
+	// Come back once the next key expired to remove it from the authorized_keys files.
+	if nextExpiry != nil {
+		return reconcile.Result{RequeueAfter: time.Until(*nextExpiry)}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -169,31 +194,79 @@ func (r *Reconciler) fetchUserSSHKeySecret(ctx context.Context, namespace string
 	return secret, nil
 }
 
-func (r *Reconciler) updateAuthorizedKeys(sshKeys map[string][]byte) error {
-	expectedUserSSHKeys, err := createBuffer(sshKeys)
-	if err != nil {
-		return fmt.Errorf("failed creating user ssh keys buffer: %v", err)
+func (r *Reconciler) fetchNodeLabels(ctx context.Context) (map[string]string, error) {
+	if r.nodeName == "" {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Name: r.nodeName}, node); err != nil {
+		return nil, err
+	}
+
+	return node.Labels, nil
+}
+
+// updateAuthorizedKeys writes the keys which apply to this node into the authorized_keys
+// files of their users. It returns the expiry date of the key which expires next, if any.
+func (r *Reconciler) updateAuthorizedKeys(sshKeys map[string][]byte, nodeLabels map[string]string, now time.Time) (*time.Time, error) {
+	var (
+		authorizedKeys = make(map[string]*ssh.AuthorizedKey, len(sshKeys))
+		nextExpiry     *time.Time
+	)
+
+	for name, data := range sshKeys {
+		key, err := ssh.UnmarshalAuthorizedKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse user ssh key %s: %v", name, err)
+		}
+		if key.Expired(now) {
+			continue
+		}
+		if key.ExpiresAt != nil && (nextExpiry == nil || key.ExpiresAt.Time.Before(*nextExpiry)) {
+			nextExpiry = &key.ExpiresAt.Time
+		}
+		authorizedKeys[name] = key
 	}
 
 	for _, path := range r.authorizedKeysPath {
+		user := userFromPath(path)
+		userSSHKeys := map[string][]byte{}
+		for name, key := range authorizedKeys {
+			if key.AppliesTo(user, nodeLabels) {
+				userSSHKeys[name] = []byte(key.PublicKey)
+			}
+		}
+
+		expectedUserSSHKeys, err := createBuffer(userSSHKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating user ssh keys buffer: %v", err)
+		}
+
 		if err := updateOwnAndPermissions(path); err != nil {
-			return fmt.Errorf("failed updating permissions %s: %v", path, err)
+			return nil, fmt.Errorf("failed updating permissions %s: %v", path, err)
 		}
 
 		actualUserSSHKeys, err := ioutil.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("failed reading file in path %s: %v", path, err)
+			return nil, fmt.Errorf("failed reading file in path %s: %v", path, err)
 		}
 
 		if !bytes.Equal(actualUserSSHKeys, expectedUserSSHKeys.Bytes()) {
 			if err := ioutil.WriteFile(path, expectedUserSSHKeys.Bytes(), 0600); err != nil {
-				return fmt.Errorf("failed to overwrite file in path %s: %v", path, err)
+				return nil, fmt.Errorf("failed to overwrite file in path %s: %v", path, err)
 			}
 			r.log.Infow("File has been updated successfully", "file", path)
 		}
 	}
 
-	return nil
+	return nextExpiry, nil
+}
+
+// userFromPath returns the name of the user owning the given authorized_keys file,
+// which is the name of its home directory, e.g. "root" for /root/.ssh/authorized_keys.
+func userFromPath(path string) string {
+	return filepath.Base(filepath.Dir(filepath.Dir(path)))
 }
 
 // newEventHandler takes a obj->request mapper function and wraps it into an
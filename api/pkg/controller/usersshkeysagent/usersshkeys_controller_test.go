@@ -23,9 +23,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	kubermaticlog "github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/ssh"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -157,6 +159,79 @@ func TestReconcileUserSSHKeys(t *testing.T) {
 	}
 }
 
+func TestUpdateAuthorizedKeysTargeting(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "sshkeys")
+	if err != nil {
+		t.Fatalf("error while creating test base dir: %v", err)
+	}
+	defer func() {
+		if err := cleanupFiles([]string{tmpDir}); err != nil {
+			t.Fatalf("failed to cleanup test files: %v", err)
+		}
+	}()
+
+	var paths []string
+	for _, user := range []string{"root", "ubuntu"} {
+		sshPath := filepath.Join(tmpDir, user, ".ssh")
+		if err := os.MkdirAll(sshPath, 0700); err != nil {
+			t.Fatalf("error while creating .ssh dir: %v", err)
+		}
+		path := filepath.Join(sshPath, "authorized_keys")
+		if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+			t.Fatalf("error while creating authorized_keys file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(now.Add(-time.Hour))
+	expiresSoon := metav1.NewTime(now.Add(time.Hour))
+	expiresLater := metav1.NewTime(now.Add(2 * time.Hour))
+
+	sshKeys := map[string][]byte{}
+	for name, key := range map[string]*ssh.AuthorizedKey{
+		"key-all":          {PublicKey: "ssh-rsa all"},
+		"key-ubuntu":       {PublicKey: "ssh-rsa ubuntu", User: "ubuntu", ExpiresAt: &expiresLater},
+		"key-expired":      {PublicKey: "ssh-rsa expired", ExpiresAt: &expired},
+		"key-workers":      {PublicKey: "ssh-rsa workers", NodeSelector: map[string]string{"nodedeployment": "workers"}, ExpiresAt: &expiresSoon},
+		"key-other-nodes":  {PublicKey: "ssh-rsa other", NodeSelector: map[string]string{"nodedeployment": "other"}},
+		"key-unknown-user": {PublicKey: "ssh-rsa unknown", User: "unknown"},
+	} {
+		data, err := key.Marshal()
+		if err != nil {
+			t.Fatalf("failed to marshal key: %v", err)
+		}
+		sshKeys[name] = data
+	}
+
+	r := Reconciler{
+		log:                kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		authorizedKeysPath: paths,
+	}
+	nextExpiry, err := r.updateAuthorizedKeys(sshKeys, map[string]string{"nodedeployment": "workers"}, now)
+	if err != nil {
+		t.Fatalf("failed to update authorized keys: %v", err)
+	}
+
+	if nextExpiry == nil || !nextExpiry.Equal(expiresSoon.Time) {
+		t.Errorf("expected next expiry to be %v, got %v", expiresSoon.Time, nextExpiry)
+	}
+
+	expectedKeys := map[string]string{
+		paths[0]: "ssh-rsa all\nssh-rsa workers",
+		paths[1]: "ssh-rsa all\nssh-rsa ubuntu\nssh-rsa workers",
+	}
+	for path, expected := range expectedKeys {
+		keys, err := readAuthorizedKeysFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keys != expected {
+			t.Errorf("expected authorized_keys file %s to contain %q, got %q", path, expected, keys)
+		}
+	}
+}
+
 func readAuthorizedKeysFile(path string) (string, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -124,6 +124,125 @@ type ClusterSpec struct {
 	AdmissionPlugins                    []string `json:"admissionPlugins,omitempty"`
 
 	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
+	// Migration requests moving the control plane of this cluster to another seed.
+	// It is set by the API and processed by the master-controller-manager.
+	Migration *ClusterMigrationSpec `json:"migration,omitempty"`
+
+	// Hibernation allows scaling the worker nodes and the control plane of this cluster to zero
+	// and waking it up again, either on demand or on a schedule.
+	Hibernation *ClusterHibernationSpec `json:"hibernation,omitempty"`
+
+	// ClusterAutoscaler configures the cluster-autoscaler. It only takes effect if the autoscaler
+	// is enabled via the kubermatic.io/cluster-autoscaler-enabled annotation.
+	ClusterAutoscaler *ClusterAutoscalerSettings `json:"clusterAutoscaler,omitempty"`
+
+	// DeletionProtection makes API requests to delete the cluster or its project fail until it is disabled again.
+	// A cluster whose object gets deleted nonetheless keeps all its resources, the deletion only proceeds
+	// once the protection got disabled.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// FinalBackup configures an etcd snapshot that is taken when the cluster gets deleted, before
+	// any of its resources are destroyed.
+	FinalBackup *FinalBackupSettings `json:"finalBackup,omitempty"`
+
+	// Encryption configures the encryption of the Secrets stored in the etcd of the user cluster.
+	// Not supported for openshift clusters.
+	Encryption *ClusterEncryptionSpec `json:"encryption,omitempty"`
+
+	// EventExport configures the export of the Kubernetes events of the user cluster, so they are
+	// still available after the apiserver of the user cluster dropped them.
+	EventExport *EventExportSettings `json:"eventExport,omitempty"`
+}
+
+// ClusterEncryptionProvider is the provider the apiserver encrypts Secrets with.
+type ClusterEncryptionProvider string
+
+const (
+	ClusterEncryptionProviderAESCBC    ClusterEncryptionProvider = "aescbc"
+	ClusterEncryptionProviderSecretbox ClusterEncryptionProvider = "secretbox"
+	// ClusterEncryptionProviderIdentity stores Secrets in plaintext. It is only used internally to
+	// read Secrets written before encryption got enabled, or while encryption gets disabled.
+	ClusterEncryptionProviderIdentity ClusterEncryptionProvider = "identity"
+)
+
+// ClusterEncryptionSpec configures the encryption of the Secrets stored in the etcd of the user cluster.
+type ClusterEncryptionSpec struct {
+	// Enabled makes the apiserver encrypt Secrets before storing them. Existing Secrets get rewritten,
+	// so they are encrypted as well.
+	Enabled bool `json:"enabled"`
+	// Provider is the encryption provider, either aescbc or secretbox. Defaults to aescbc.
+	Provider ClusterEncryptionProvider `json:"provider,omitempty"`
+}
+
+// EventExportSinkType is a destination the events of the user cluster are exported to.
+type EventExportSinkType string
+
+const (
+	// EventExportSinkTypeSeed stores the events as ClusterEvents on the seed, where they can be
+	// queried through the cluster events API.
+	EventExportSinkTypeSeed EventExportSinkType = "seed"
+	// EventExportSinkTypeHTTP posts the events as JSON to an HTTP endpoint.
+	EventExportSinkTypeHTTP EventExportSinkType = "http"
+)
+
+// EventExportSettings configures the export of the Kubernetes events of the user cluster.
+type EventExportSettings struct {
+	// Enabled makes the user cluster controller manager forward all events of the user cluster.
+	Enabled bool `json:"enabled"`
+	// Sink is either seed or http. Defaults to seed.
+	Sink EventExportSinkType `json:"sink,omitempty"`
+	// URL is the endpoint the events are posted to, it is required for the http sink.
+	URL string `json:"url,omitempty"`
+	// Retention is how long the events exported to the seed are kept after they occurred the last time,
+	// also after the cluster got deleted. Defaults to 720h.
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+// FinalBackupSettings configures the etcd snapshot taken before a cluster gets deleted.
+type FinalBackupSettings struct {
+	// Enabled makes the deletion of the cluster wait until the final snapshot has been stored.
+	Enabled bool `json:"enabled"`
+	// Retention is how long the final snapshot is kept after the cluster was deleted. Defaults to 168h.
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+// ClusterAutoscalerSettings contains the cluster-wide scale-down settings of the cluster-autoscaler.
+// The node group sizes are configured per MachineDeployment.
+type ClusterAutoscalerSettings struct {
+	// ScaleDownDisabled prevents the autoscaler from removing nodes.
+	ScaleDownDisabled bool `json:"scaleDownDisabled,omitempty"`
+	// ScaleDownUtilizationThreshold is the ratio of requested to allocatable resources below which
+	// a node is considered for scale down, e.g. "0.5". Defaults to "0.7".
+	ScaleDownUtilizationThreshold string `json:"scaleDownUtilizationThreshold,omitempty"`
+	// ScaleDownUnneededTime is how long a node has to be unneeded before it gets removed.
+	ScaleDownUnneededTime *metav1.Duration `json:"scaleDownUnneededTime,omitempty"`
+	// ScaleDownDelayAfterAdd is how long scale down is suspended after a scale up.
+	ScaleDownDelayAfterAdd *metav1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+}
+
+// ClusterHibernationSpec describes whether a cluster should be hibernated.
+type ClusterHibernationSpec struct {
+	// Hibernated requests the cluster to be scaled to zero. Setting it back to false resumes the cluster.
+	Hibernated bool `json:"hibernated"`
+	// Schedule optionally hibernates and resumes the cluster automatically.
+	Schedule *ClusterHibernationSchedule `json:"schedule,omitempty"`
+}
+
+// ClusterHibernationSchedule contains cron expressions (standard 5-field format, e.g. "0 20 * * 1-5")
+// at which the cluster gets hibernated and resumed. The schedule flips Hibernated at the given times,
+// which can still be changed manually in between.
+type ClusterHibernationSchedule struct {
+	Hibernate string `json:"hibernate"`
+	Resume    string `json:"resume"`
+}
+
+// ClusterMigrationSpec describes the seed a cluster control plane should be moved to.
+type ClusterMigrationSpec struct {
+	// TargetSeed is the name of the seed the control plane is moved to.
+	TargetSeed string `json:"targetSeed"`
+	// TargetDatacenter is the datacenter of the target seed the cluster belongs to after the migration.
+	TargetDatacenter string `json:"targetDatacenter"`
 }
 
 const (
@@ -245,6 +364,223 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// Migration reports the progress of moving the control plane to another seed.
+	Migration *ClusterMigrationStatus `json:"migration,omitempty"`
+
+	// Hibernation reports the progress of hibernating or resuming the cluster. A nil value or
+	// an empty phase means the cluster is running.
+	Hibernation *ClusterHibernationStatus `json:"hibernation,omitempty"`
+
+	// Deletion reports the progress of deleting the cluster. It is only set once the cluster
+	// is being deleted.
+	Deletion *ClusterDeletionStatus `json:"deletion,omitempty"`
+
+	// Certificates lists the certificates of the control plane along with their validity.
+	Certificates []ClusterCertificate `json:"certificates,omitempty"`
+
+	// CertificateRotation reports the progress of rotating the CAs and the service account
+	// signing key of the cluster.
+	CertificateRotation *ClusterCertificateRotationStatus `json:"certificateRotation,omitempty"`
+
+	// Encryption reports the encryption keys of the apiserver and the progress of enabling,
+	// disabling or rotating them. It is not set if encryption is disabled.
+	Encryption *ClusterEncryptionStatus `json:"encryption,omitempty"`
+}
+
+// ClusterEncryptionPhase is the phase of changing the encryption keys of a cluster.
+type ClusterEncryptionPhase string
+
+const (
+	// ClusterEncryptionPhasePending means a new key was requested but has not been created yet.
+	ClusterEncryptionPhasePending ClusterEncryptionPhase = "Pending"
+	// ClusterEncryptionPhaseAddingKey means the new key got added to the apiserver configuration for
+	// decrypting only, so all apiserver replicas can read Secrets encrypted with it.
+	ClusterEncryptionPhaseAddingKey ClusterEncryptionPhase = "AddingKey"
+	// ClusterEncryptionPhaseActivatingKey means the new key is used for encrypting.
+	ClusterEncryptionPhaseActivatingKey ClusterEncryptionPhase = "ActivatingKey"
+	// ClusterEncryptionPhaseRewritingSecrets means all Secrets get rewritten to encrypt them with the new key.
+	ClusterEncryptionPhaseRewritingSecrets ClusterEncryptionPhase = "RewritingSecrets"
+	// ClusterEncryptionPhaseRemovingOldKeys means the old keys got removed from the apiserver configuration.
+	ClusterEncryptionPhaseRemovingOldKeys ClusterEncryptionPhase = "RemovingOldKeys"
+	// ClusterEncryptionPhaseActive means all Secrets are encrypted with the current key.
+	ClusterEncryptionPhaseActive ClusterEncryptionPhase = "Active"
+)
+
+// ClusterEncryptionStatus stores the encryption keys of a cluster and the progress of changing them.
+type ClusterEncryptionStatus struct {
+	Phase ClusterEncryptionPhase `json:"phase"`
+	// Keys lists the keys the apiserver is configured with, in the order they are tried. The first key
+	// is used for encrypting.
+	Keys []ClusterEncryptionKey `json:"keys,omitempty"`
+	// LastTransitionTime is the time the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message contains details about the current phase.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterEncryptionKey describes a key of the apiserver encryption configuration. The key itself is
+// stored in a Secret in the cluster namespace.
+type ClusterEncryptionKey struct {
+	// Name is empty for the identity provider.
+	Name     string                    `json:"name,omitempty"`
+	Provider ClusterEncryptionProvider `json:"provider"`
+	// CreationTime is the time the key was created.
+	CreationTime metav1.Time `json:"creationTime,omitempty"`
+}
+
+// ClusterCertificate describes a certificate stored in a secret in the cluster namespace.
+type ClusterCertificate struct {
+	// SecretName is the name of the secret holding the certificate.
+	SecretName string `json:"secretName"`
+	// Key is the key of the certificate within the secret.
+	Key        string      `json:"key"`
+	CommonName string      `json:"commonName"`
+	IsCA       bool        `json:"isCA,omitempty"`
+	NotBefore  metav1.Time `json:"notBefore"`
+	NotAfter   metav1.Time `json:"notAfter"`
+}
+
+// ClusterCertificateRotationPhase is the phase a certificate rotation is in.
+type ClusterCertificateRotationPhase string
+
+const (
+	// ClusterCertificateRotationPhasePending means the rotation was requested but has not started yet.
+	ClusterCertificateRotationPhasePending ClusterCertificateRotationPhase = "Pending"
+	// ClusterCertificateRotationPhaseTrustingNewCAs means the new CAs and service account key got
+	// created and are trusted by the control plane next to the current ones.
+	ClusterCertificateRotationPhaseTrustingNewCAs ClusterCertificateRotationPhase = "TrustingNewCAs"
+	// ClusterCertificateRotationPhaseDistributingCABundle means the bundle of the old and the new root CA
+	// is distributed to the nodes and the service account tokens of the user cluster.
+	ClusterCertificateRotationPhaseDistributingCABundle ClusterCertificateRotationPhase = "DistributingCABundle"
+	// ClusterCertificateRotationPhaseTransitioning means the control plane uses the new CAs and service
+	// account key, while the old ones stay trusted until the transition window ends.
+	ClusterCertificateRotationPhaseTransitioning ClusterCertificateRotationPhase = "Transitioning"
+	// ClusterCertificateRotationPhaseCompleted means the old CAs and service account key are not trusted anymore.
+	ClusterCertificateRotationPhaseCompleted ClusterCertificateRotationPhase = "Completed"
+)
+
+// ClusterCertificateRotationStatus stores the progress of a certificate rotation.
+type ClusterCertificateRotationStatus struct {
+	Phase ClusterCertificateRotationPhase `json:"phase"`
+	// TransitionWindow is how long the old CAs and service account key stay trusted after the
+	// control plane switched to the new ones.
+	TransitionWindow metav1.Duration `json:"transitionWindow"`
+	// RequestTime is the time the rotation was requested.
+	RequestTime metav1.Time `json:"requestTime"`
+	// LastTransitionTime is the time the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message contains details about the current phase, e.g. what the rotation is waiting for.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterDeletionPhaseName is the name of a step of the cluster deletion.
+type ClusterDeletionPhaseName string
+
+const (
+	// ClusterDeletionPhaseFinalBackup stores the final etcd backup.
+	ClusterDeletionPhaseFinalBackup ClusterDeletionPhaseName = "FinalBackup"
+	// ClusterDeletionPhaseInClusterResources removes the LoadBalancers, volumes and other resources
+	// inside the user cluster which are backed by cloud provider resources.
+	ClusterDeletionPhaseInClusterResources ClusterDeletionPhaseName = "InClusterResources"
+	// ClusterDeletionPhaseNodes removes the machines of the cluster.
+	ClusterDeletionPhaseNodes ClusterDeletionPhaseName = "Nodes"
+	// ClusterDeletionPhaseCloudProvider removes the cloud provider resources of the cluster, it also
+	// covers the finalizers of all other controllers.
+	ClusterDeletionPhaseCloudProvider ClusterDeletionPhaseName = "CloudProvider"
+	// ClusterDeletionPhaseCredentials removes the secrets holding the cloud provider credentials.
+	ClusterDeletionPhaseCredentials ClusterDeletionPhaseName = "Credentials"
+)
+
+// ClusterDeletionPhaseStatus is the status of a step of the cluster deletion.
+type ClusterDeletionPhaseStatus string
+
+const (
+	ClusterDeletionPhaseStatusPending    ClusterDeletionPhaseStatus = "Pending"
+	ClusterDeletionPhaseStatusInProgress ClusterDeletionPhaseStatus = "InProgress"
+	ClusterDeletionPhaseStatusCompleted  ClusterDeletionPhaseStatus = "Completed"
+)
+
+// ClusterDeletionStatus stores the progress of a cluster deletion.
+type ClusterDeletionStatus struct {
+	// Phases lists the steps of the deletion in the order they are processed. Steps which had
+	// nothing to clean up when the deletion started are omitted.
+	Phases []ClusterDeletionPhase `json:"phases,omitempty"`
+	// LastError is the last error which occurred while deleting the cluster.
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime is the time LastError occurred.
+	LastErrorTime *metav1.Time `json:"lastErrorTime,omitempty"`
+}
+
+// ClusterDeletionPhase stores the progress of a single step of the cluster deletion.
+type ClusterDeletionPhase struct {
+	Name   ClusterDeletionPhaseName   `json:"name"`
+	Status ClusterDeletionPhaseStatus `json:"status"`
+	// Message describes what the phase is waiting for, e.g. "3 LoadBalancer services remaining".
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time the status of the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ClusterHibernationPhase is the phase a cluster hibernation is in.
+type ClusterHibernationPhase string
+
+const (
+	ClusterHibernationPhaseHibernatingMachines     ClusterHibernationPhase = "HibernatingMachines"
+	ClusterHibernationPhaseHibernatingControlPlane ClusterHibernationPhase = "HibernatingControlPlane"
+	ClusterHibernationPhaseHibernated              ClusterHibernationPhase = "Hibernated"
+	ClusterHibernationPhaseResumingControlPlane    ClusterHibernationPhase = "ResumingControlPlane"
+	ClusterHibernationPhaseResumingMachines        ClusterHibernationPhase = "ResumingMachines"
+)
+
+// ClusterHibernationStatus stores the replica counts needed to resume a cluster.
+type ClusterHibernationStatus struct {
+	Phase ClusterHibernationPhase `json:"phase,omitempty"`
+	// MachineDeploymentReplicas contains the replicas of the MachineDeployments in the user cluster
+	// before they got scaled to zero, keyed by name.
+	MachineDeploymentReplicas map[string]int32 `json:"machineDeploymentReplicas,omitempty"`
+	// DeploymentReplicas contains the replicas of the Deployments in the cluster namespace
+	// before they got scaled to zero, keyed by name.
+	DeploymentReplicas map[string]int32 `json:"deploymentReplicas,omitempty"`
+	// EtcdReplicas contains the replicas of the etcd StatefulSet before it got scaled to zero.
+	EtcdReplicas *int32 `json:"etcdReplicas,omitempty"`
+	// LastTransitionTime is the time the phase last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastScheduleTime is the last time the hibernation schedule was evaluated.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+}
+
+// ClusterMigrationPhase is the phase a seed migration is in.
+type ClusterMigrationPhase string
+
+const (
+	ClusterMigrationPhasePending              ClusterMigrationPhase = "Pending"
+	ClusterMigrationPhaseSnapshottingEtcd     ClusterMigrationPhase = "SnapshottingEtcd"
+	ClusterMigrationPhaseCopyingResources     ClusterMigrationPhase = "CopyingResources"
+	ClusterMigrationPhaseRestoringEtcd        ClusterMigrationPhase = "RestoringEtcd"
+	ClusterMigrationPhaseStartingControlPlane ClusterMigrationPhase = "StartingControlPlane"
+	ClusterMigrationPhaseReconnectingNodes    ClusterMigrationPhase = "ReconnectingNodes"
+	ClusterMigrationPhaseCleaningUp           ClusterMigrationPhase = "CleaningUp"
+	ClusterMigrationPhaseSucceeded            ClusterMigrationPhase = "Succeeded"
+	ClusterMigrationPhaseFailed               ClusterMigrationPhase = "Failed"
+)
+
+// ClusterMigrationStatus stores the progress of a seed migration.
+type ClusterMigrationStatus struct {
+	SourceSeed       string                `json:"sourceSeed"`
+	TargetSeed       string                `json:"targetSeed"`
+	TargetDatacenter string                `json:"targetDatacenter"`
+	Phase            ClusterMigrationPhase `json:"phase"`
+	// Message contains details about the current phase, e.g. what the migration is waiting for
+	// or why it failed.
+	Message            string      `json:"message,omitempty"`
+	StartTime          metav1.Time `json:"startTime,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IsFinished returns true if the migration has either succeeded or failed.
+func (s *ClusterMigrationStatus) IsFinished() bool {
+	return s.Phase == ClusterMigrationPhaseSucceeded || s.Phase == ClusterMigrationPhaseFailed
 }
 
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
@@ -284,6 +620,79 @@ type OIDCSettings struct {
 
 type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
+	// PolicyPreset selects one of the predefined audit policies. Defaults to metadata.
+	// It is ignored if a CustomPolicy is set.
+	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
+	// CustomPolicy is an audit policy (audit.k8s.io/v1 Policy) in YAML.
+	CustomPolicy string `json:"customPolicy,omitempty"`
+	// Log configures the rotation of the audit log file.
+	Log *AuditLogSettings `json:"log,omitempty"`
+	// Webhook additionally sends the audit events to a webhook.
+	Webhook *AuditWebhookSettings `json:"webhook,omitempty"`
+	// Sink configures where the audit log gets shipped to. Defaults to the stdout of the
+	// sidecar, so it is picked up by the log collector of the seed.
+	Sink *AuditLogSink `json:"sink,omitempty"`
+}
+
+// AuditPolicyPreset is a predefined audit policy.
+type AuditPolicyPreset string
+
+const (
+	// AuditPolicyPresetMetadata logs the metadata of all requests.
+	AuditPolicyPresetMetadata AuditPolicyPreset = "metadata"
+	// AuditPolicyPresetRecommended skips noisy requests of system components and logs the metadata of requests to
+	// Secrets, ConfigMaps and TokenReviews, the request body of other read-only requests and the request and
+	// response bodies of all other requests.
+	AuditPolicyPresetRecommended AuditPolicyPreset = "recommended"
+	// AuditPolicyPresetAll logs the request and response bodies of all requests.
+	AuditPolicyPresetAll AuditPolicyPreset = "all"
+)
+
+// AuditLogSettings configures the rotation of the audit log file.
+type AuditLogSettings struct {
+	// MaxAge is the number of days old audit log files are kept. Defaults to 30.
+	MaxAge *int32 `json:"maxAge,omitempty"`
+	// MaxBackups is the number of old audit log files which are kept. Defaults to 3.
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+	// MaxSize is the size in megabytes at which the audit log file gets rotated. Defaults to 100.
+	MaxSize *int32 `json:"maxSize,omitempty"`
+}
+
+// AuditWebhookSettings configures the audit webhook backend of the apiserver.
+type AuditWebhookSettings struct {
+	// URL is the HTTPS endpoint the audit events are posted to.
+	URL string `json:"url"`
+	// CABundle is the PEM encoded CA bundle used to verify the certificate of the webhook. Defaults
+	// to the system trust store.
+	CABundle string `json:"caBundle,omitempty"`
+	// Mode is either batch or blocking. Defaults to batch.
+	Mode AuditWebhookMode `json:"mode,omitempty"`
+}
+
+// AuditWebhookMode is the strategy for sending audit events to the webhook.
+type AuditWebhookMode string
+
+const (
+	// AuditWebhookModeBatch buffers the events and sends them asynchronously.
+	AuditWebhookModeBatch AuditWebhookMode = "batch"
+	// AuditWebhookModeBlocking blocks the apiserver response until the event got sent.
+	AuditWebhookModeBlocking AuditWebhookMode = "blocking"
+)
+
+// AuditLogSinkType is a destination the audit log sidecar ships the audit log to.
+type AuditLogSinkType string
+
+const (
+	AuditLogSinkTypeStdout AuditLogSinkType = "stdout"
+	AuditLogSinkTypeHTTP   AuditLogSinkType = "http"
+)
+
+// AuditLogSink configures where the audit log sidecar ships the audit log to.
+type AuditLogSink struct {
+	// Type is either stdout or http. Defaults to stdout.
+	Type AuditLogSinkType `json:"type,omitempty"`
+	// URL is the endpoint the audit events are posted to as JSON, it is required for the http type.
+	URL string `json:"url,omitempty"`
 }
 
 type ComponentSettings struct {
@@ -324,8 +733,24 @@ type ClusterNetworkingConfig struct {
 	// ProxyMode defines the kube-proxy mode (ipvs/iptables).
 	// Defaults to ipvs.
 	ProxyMode string `json:"proxyMode"`
+
+	// TunnelMode defines how the control plane reaches the nodes of the cluster (OpenVPN/Konnectivity).
+	// Defaults to OpenVPN.
+	TunnelMode TunnelMode `json:"tunnelMode,omitempty"`
 }
 
+// TunnelMode is the tunnel the control plane uses to reach the nodes, pods and services of the user cluster.
+type TunnelMode string
+
+const (
+	// TunnelModeOpenVPN runs an OpenVPN server in the seed and OpenVPN clients as sidecars of the control
+	// plane components and as addon in the user cluster. Kubelets are reached through the kubeletdnat controller.
+	TunnelModeOpenVPN TunnelMode = "OpenVPN"
+	// TunnelModeKonnectivity runs the apiserver network proxy server next to the apiserver and the proxy
+	// agent as addon in the user cluster. The apiserver sends all traffic into the cluster through the proxy.
+	TunnelModeKonnectivity TunnelMode = "Konnectivity"
+)
+
 // MachineNetworkingConfig specifies the networking parameters used for IPAM.
 type MachineNetworkingConfig struct {
 	CIDR       string   `json:"cidr"`
@@ -532,6 +957,9 @@ const (
 	HealthStatusDown         HealthStatus = iota
 	HealthStatusUp           HealthStatus = iota
 	HealthStatusProvisioning HealthStatus = iota
+	// HealthStatusHibernated is used for components that are intentionally scaled to zero
+	// because the cluster is hibernated.
+	HealthStatusHibernated HealthStatus = iota
 )
 
 // ExtendedClusterHealth stores health information of a cluster.
@@ -640,3 +1068,56 @@ func (cluster *Cluster) IsOpenshift() bool {
 func (cluster *Cluster) IsKubernetes() bool {
 	return !cluster.IsOpenshift()
 }
+
+// IsHibernationRequested returns true if the cluster should be hibernated.
+func (cluster *Cluster) IsHibernationRequested() bool {
+	return cluster.Spec.Hibernation != nil && cluster.Spec.Hibernation.Hibernated
+}
+
+// IsHibernating returns true if the cluster is hibernated or in the process of
+// being hibernated or resumed.
+func (cluster *Cluster) IsHibernating() bool {
+	return cluster.Status.Hibernation != nil && cluster.Status.Hibernation.Phase != ""
+}
+
+// IsControlPlaneHibernated returns true if the control plane of the cluster is
+// scaled down or about to be. Controllers that reconcile control plane resources
+// or talk to the user cluster must not do so in this state.
+func (cluster *Cluster) IsControlPlaneHibernated() bool {
+	if cluster.Status.Hibernation == nil {
+		return false
+	}
+	phase := cluster.Status.Hibernation.Phase
+	return phase == ClusterHibernationPhaseHibernatingControlPlane || phase == ClusterHibernationPhaseHibernated
+}
+
+// IsEncryptionEnabled returns true if the apiserver got configured with encryption keys. This differs
+// from the spec while encryption gets enabled or disabled.
+func (cluster *Cluster) IsEncryptionEnabled() bool {
+	return cluster.Status.Encryption != nil && len(cluster.Status.Encryption.Keys) > 0
+}
+
+// GetTunnelMode returns the tunnel mode of the cluster. Clusters without one use OpenVPN.
+func (cluster *Cluster) GetTunnelMode() TunnelMode {
+	if cluster.Spec.ClusterNetwork.TunnelMode == "" {
+		return TunnelModeOpenVPN
+	}
+	return cluster.Spec.ClusterNetwork.TunnelMode
+}
+
+// IsKonnectivityEnabled returns true if the control plane reaches the nodes through Konnectivity.
+func (cluster *Cluster) IsKonnectivityEnabled() bool {
+	return cluster.GetTunnelMode() == TunnelModeKonnectivity
+}
+
+// IsCertificateRotationInProgress returns true if the control plane has to trust the CA bundles
+// and the service account key bundle instead of only the current CAs and key.
+func (cluster *Cluster) IsCertificateRotationInProgress() bool {
+	if cluster.Status.CertificateRotation == nil {
+		return false
+	}
+	phase := cluster.Status.CertificateRotation.Phase
+	return phase == ClusterCertificateRotationPhaseTrustingNewCAs ||
+		phase == ClusterCertificateRotationPhaseDistributingCABundle ||
+		phase == ClusterCertificateRotationPhaseTransitioning
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterEventResourceName represents "Resource" defined in Kubernetes
+	ClusterEventResourceName = "clusterevents"
+
+	// ClusterEventKindName represents "Kind" defined in Kubernetes
+	ClusterEventKindName = "ClusterEvent"
+
+	// ClusterEventClusterLabelKey is the label key holding the name of the cluster a ClusterEvent got exported from
+	ClusterEventClusterLabelKey = "cluster"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterEvent is a Kubernetes event that got exported from a user cluster. It lives in the seed and is
+// kept until it expires, even after the cluster got deleted.
+type ClusterEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterEventSpec `json:"spec"`
+}
+
+// ClusterEventSpec specifies an exported event
+type ClusterEventSpec struct {
+	// Cluster is the name of the cluster the event got exported from
+	Cluster string `json:"cluster"`
+	// ExpiresAt is the time the event gets deleted at
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// Type is the type of the event, either Normal or Warning
+	Type string `json:"type,omitempty"`
+	// Reason is a short, machine understandable string that gives the reason for the event
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable description of the event
+	Message string `json:"message,omitempty"`
+	// InvolvedObject is the object within the user cluster the event is about
+	InvolvedObject corev1.ObjectReference `json:"involvedObject"`
+	// Source is the component reporting the event
+	Source corev1.EventSource `json:"source,omitempty"`
+	// FirstTimestamp is the time the event was first recorded
+	FirstTimestamp metav1.Time `json:"firstTimestamp,omitempty"`
+	// LastTimestamp is the time the most recent occurrence of the event was recorded
+	LastTimestamp metav1.Time `json:"lastTimestamp,omitempty"`
+	// Count is the number of times the event has occurred
+	Count int32 `json:"count,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterEventList specifies a list of cluster events
+type ClusterEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterEvent `json:"items"`
+}
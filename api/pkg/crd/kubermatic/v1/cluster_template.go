@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ClusterTemplateResourceName represents "Resource" defined in Kubernetes
+	ClusterTemplateResourceName = "clustertemplates"
+
+	// ClusterTemplateKindName represents "Kind" defined in Kubernetes
+	ClusterTemplateKindName = "ClusterTemplate"
+)
+
+// ClusterTemplateScope defines who can see and use a cluster template
+type ClusterTemplateScope string
+
+const (
+	// ClusterTemplateScopeGlobal marks a template available in all projects, it can only be managed by admins
+	ClusterTemplateScopeGlobal ClusterTemplateScope = "global"
+	// ClusterTemplateScopeProject marks a template available only in the project given by the ProjectIDLabelKey label
+	ClusterTemplateScopeProject ClusterTemplateScope = "project"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTemplate is the object representing a template for creating clusters.
+type ClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterTemplateSpec `json:"spec"`
+}
+
+// ClusterTemplateSpec specifies the clusters that are created from the template
+type ClusterTemplateSpec struct {
+	// HumanReadableName is the template name provided by the user
+	HumanReadableName string `json:"humanReadableName"`
+	// Scope is either global or project
+	Scope ClusterTemplateScope `json:"scope"`
+
+	// ClusterType is either kubernetes or openshift
+	ClusterType string `json:"clusterType"`
+	// ClusterLabels are set on every cluster created from the template
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+	// Credential is the name of the preset used to fill in the cloud provider credentials.
+	// Templates never contain credentials themselves.
+	Credential string `json:"credential,omitempty"`
+	// Cluster holds the specification of the clusters created from the template.
+	// Only the cloud provider kind and the datacenter name of the cloud spec are kept.
+	Cluster ClusterSpec `json:"cluster"`
+
+	// Addons are installed in every cluster created from the template
+	Addons []ClusterTemplateAddon `json:"addons,omitempty"`
+	// NodeDeployments are created in every cluster created from the template,
+	// every item holds a node deployment in the form accepted by the API.
+	NodeDeployments []runtime.RawExtension `json:"nodeDeployments,omitempty"`
+}
+
+// ClusterTemplateAddon specifies an addon installed in the clusters created from a template
+type ClusterTemplateAddon struct {
+	Name      string                `json:"name"`
+	Variables *runtime.RawExtension `json:"variables,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTemplateList specifies a list of cluster templates
+type ClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterTemplate `json:"items"`
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ConstraintTemplateResourceName represents "Resource" defined in Kubernetes
+	ConstraintTemplateResourceName = "constrainttemplates"
+
+	// ConstraintTemplateKindName represents "Kind" defined in Kubernetes
+	ConstraintTemplateKindName = "ConstraintTemplate"
+
+	// ConstraintResourceName represents "Resource" defined in Kubernetes
+	ConstraintResourceName = "constraints"
+
+	// ConstraintKindName represents "Kind" defined in Kubernetes
+	ConstraintKindName = "Constraint"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConstraintTemplate is an OPA Gatekeeper constraint template defined by an admin,
+// it gets synced into every user cluster which has the gatekeeper addon installed.
+type ConstraintTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ConstraintTemplateSpec `json:"spec"`
+}
+
+// ConstraintTemplateSpec is the specification of a gatekeeper constraint template
+type ConstraintTemplateSpec struct {
+	// Kind is the kind of the constraints created from this template, e.g. K8sRequiredLabels
+	Kind string `json:"kind"`
+	// Parameters is the OpenAPI v3 schema of the parameters accepted by the constraints of this template
+	Parameters runtime.RawExtension `json:"parameters,omitempty"`
+	// Targets holds the rego policies of the template
+	Targets []ConstraintTemplateTarget `json:"targets"`
+}
+
+// ConstraintTemplateTarget holds the rego policy of a constraint template for a gatekeeper target
+type ConstraintTemplateTarget struct {
+	// Target is the gatekeeper target, e.g. admission.k8s.gatekeeper.sh
+	Target string `json:"target"`
+	// Rego is the source of the policy
+	Rego string `json:"rego"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConstraintTemplateList specifies a list of constraint templates
+type ConstraintTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ConstraintTemplate `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Constraint is an OPA Gatekeeper constraint which belongs to the project given by
+// the ProjectIDLabelKey label. It gets synced into the clusters of the project.
+type Constraint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ConstraintSpec `json:"spec"`
+}
+
+// ConstraintSpec is the specification of a gatekeeper constraint
+type ConstraintSpec struct {
+	// Name is the name of the constraint inside the user clusters
+	Name string `json:"name"`
+	// ConstraintTemplate is the name of the template the constraint is created from
+	ConstraintTemplate string `json:"constraintTemplate"`
+	// Clusters restricts the constraint to the given clusters of the project,
+	// if empty the constraint applies to all clusters of the project
+	Clusters []string `json:"clusters,omitempty"`
+	// EnforcementAction is either "deny" or "dryrun", defaults to "deny"
+	EnforcementAction string `json:"enforcementAction,omitempty"`
+	// Match selects the objects the constraint applies to
+	Match ConstraintMatch `json:"match,omitempty"`
+	// Parameters are passed to the rego policies of the template
+	Parameters runtime.RawExtension `json:"parameters,omitempty"`
+}
+
+// ConstraintMatch selects the objects a constraint applies to
+type ConstraintMatch struct {
+	Kinds              []ConstraintMatchKind `json:"kinds,omitempty"`
+	Namespaces         []string              `json:"namespaces,omitempty"`
+	ExcludedNamespaces []string              `json:"excludedNamespaces,omitempty"`
+	LabelSelector      *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// ConstraintMatchKind selects objects by their API groups and kinds
+type ConstraintMatchKind struct {
+	APIGroups []string `json:"apiGroups,omitempty"`
+	Kinds     []string `json:"kinds,omitempty"`
+}
+
+// AppliesToCluster returns true if the constraint must be synced into the given cluster
+func (c *Constraint) AppliesToCluster(cluster *Cluster) bool {
+	if c.Labels[ProjectIDLabelKey] == "" || c.Labels[ProjectIDLabelKey] != cluster.Labels[ProjectIDLabelKey] {
+		return false
+	}
+	if len(c.Spec.Clusters) == 0 {
+		return true
+	}
+	for _, name := range c.Spec.Clusters {
+		if name == cluster.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConstraintList specifies a list of constraints
+type ConstraintList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Constraint `json:"items"`
+}
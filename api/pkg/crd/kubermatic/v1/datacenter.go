@@ -23,6 +23,11 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 )
 
+const (
+	// SeedKindName represents "Kind" defined in Kubernetes
+	SeedKindName = "Seed"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SeedDatacenterList is the type representing a SeedDatacenterList
@@ -46,6 +51,10 @@ type Seed struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec SeedSpec `json:"spec"`
+
+	//nolint:staticcheck
+	//lint:ignore SA5008 omitgenyaml is used by the example-yaml-generator
+	Status SeedStatus `json:"status,omitempty,omitgenyaml"`
 }
 
 func (s *Seed) SetDefaults() {
@@ -88,6 +97,60 @@ type SeedSpec struct {
 	ExposeStrategy corev1.ServiceType `json:"expose_strategy,omitempty"`
 }
 
+// SeedStatus contains the results of the health checks the master performs
+// against the seed cluster.
+type SeedStatus struct {
+	// KubernetesVersion is the version reported by the seed's API server.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// LastHeartbeatTime is the last time the seed health was checked.
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// Conditions contains the result of every health check.
+	Conditions []SeedCondition `json:"conditions,omitempty"`
+}
+
+// SeedConditionType is the type of a seed health check.
+type SeedConditionType string
+
+const (
+	// SeedConditionAPIReachable indicates whether the seed's API server can be reached.
+	SeedConditionAPIReachable SeedConditionType = "APIReachable"
+	// SeedConditionSeedControllerManagerReady indicates whether the seed-controller-manager is running.
+	SeedConditionSeedControllerManagerReady SeedConditionType = "SeedControllerManagerReady"
+	// SeedConditionNodeportProxyReady indicates whether the nodeport-proxy is running.
+	SeedConditionNodeportProxyReady SeedConditionType = "NodeportProxyReady"
+	// SeedConditionCertificatesValid indicates whether the seed's certificates are valid and not about to expire.
+	SeedConditionCertificatesValid SeedConditionType = "CertificatesValid"
+)
+
+// SeedCondition contains the result of a single seed health check.
+type SeedCondition struct {
+	// Type of seed condition.
+	Type SeedConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transit from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FailedConditions returns all conditions with status False. Seeds that have not
+// been checked yet have no conditions and are considered healthy.
+func (s *SeedStatus) FailedConditions() []SeedCondition {
+	var failed []SeedCondition
+	for _, condition := range s.Conditions {
+		if condition.Status == corev1.ConditionFalse {
+			failed = append(failed, condition)
+		}
+	}
+	return failed
+}
+
 type NodeportProxyConfig struct {
 	// Disable will prevent the Kubermatic Operator from creating a nodeport-proxy
 	// setup on the seed cluster. This should only be used if a suitable replacement
@@ -160,9 +223,33 @@ type DatacenterSpec struct {
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
 
+	// MinimumAuditPolicyPreset is the least verbose audit policy preset clusters within the DC may use.
+	// Custom audit policies are not allowed if it is set. It only takes effect if EnforceAuditLogging is set.
+	MinimumAuditPolicyPreset AuditPolicyPreset `json:"minimumAuditPolicyPreset,omitempty"`
+
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// Optional: Placement controls how new clusters in this datacenter are
+	// distributed across seeds.
+	Placement *DatacenterPlacement `json:"placement,omitempty"`
+}
+
+// DatacenterPlacement configures capacity-aware placement of clusters.
+type DatacenterPlacement struct {
+	// Shared allows the datacenter to be defined in more than one seed. All
+	// seeds defining the datacenter must set this flag and use the same
+	// provider. New clusters are created on the least loaded healthy seed.
+	Shared bool `json:"shared,omitempty"`
+	// Optional: MaxClustersPerSeed limits how many clusters in this datacenter
+	// can be created on a single seed. Zero means no limit.
+	MaxClustersPerSeed int `json:"maxClustersPerSeed,omitempty"`
+}
+
+// IsShared returns true if the datacenter may be served by several seeds.
+func (p *DatacenterPlacement) IsShared() bool {
+	return p != nil && p.Shared
 }
 
 // ImageList defines a map of operating system and the image to use
@@ -120,6 +120,41 @@ func SetClusterCondition(
 	})
 }
 
+// SetSeedCondition sets a condition on the given seed using the provided type, status,
+// reason and message. The transition time is only updated when the status changes.
+func SetSeedCondition(
+	s *kubermaticv1.Seed,
+	conditionType kubermaticv1.SeedConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	newCondition := kubermaticv1.SeedCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	for i, condition := range s.Status.Conditions {
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status == status {
+			newCondition.LastTransitionTime = condition.LastTransitionTime
+		}
+		s.Status.Conditions[i] = newCondition
+		return
+	}
+
+	s.Status.Conditions = append(s.Status.Conditions, newCondition)
+	// Has to be sorted, otherwise we may end up creating patches that just re-arrange them.
+	sort.SliceStable(s.Status.Conditions, func(i, j int) bool {
+		return s.Status.Conditions[i].Type < s.Status.Conditions[j].Type
+	})
+}
+
 func ClusterReconciliationSuccessful(cluster *kubermaticv1.Cluster) (missingConditions []kubermaticv1.ClusterConditionType, success bool) {
 	conditionsToExclude := []kubermaticv1.ClusterConditionType{kubermaticv1.ClusterConditionSeedResourcesUpToDate}
 	if cluster.IsOpenshift() {
@@ -60,6 +60,31 @@ type PresetSpec struct {
 
 	Fake                *Fake  `json:"fake,omitempty"`
 	RequiredEmailDomain string `json:"requiredEmailDomain,omitempty"`
+
+	// Enabled can be set to false to disable the preset, disabled presets can't be used to create clusters.
+	// Presets are enabled by default.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Projects restricts the usage of the preset to the projects with the given IDs.
+	// The preset can be used in all projects if empty.
+	Projects []string `json:"projects,omitempty"`
+}
+
+// IsEnabled returns whether the preset can be used
+func (s PresetSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// IsAvailableInProject returns whether the preset can be used in the project with the given ID
+func (s PresetSpec) IsAvailableInProject(projectID string) bool {
+	if len(s.Projects) == 0 {
+		return true
+	}
+	for _, project := range s.Projects {
+		if project == projectID {
+			return true
+		}
+	}
+	return false
 }
 
 type Digitalocean struct {
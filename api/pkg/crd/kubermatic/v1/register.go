@@ -73,6 +73,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PresetList{},
 		&AdmissionPlugin{},
 		&AdmissionPluginList{},
+		&ClusterTemplate{},
+		&ClusterTemplateList{},
+		&ConstraintTemplate{},
+		&ConstraintTemplateList{},
+		&Constraint{},
+		&ConstraintList{},
+		&ClusterUsage{},
+		&ClusterUsageList{},
+		&ClusterEvent{},
+		&ClusterEventList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
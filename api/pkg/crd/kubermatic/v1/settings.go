@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,6 +26,11 @@ type ClusterType int8
 
 const GlobalSettingsName = "globalsettings"
 
+// MaxKubeconfigTTL is the longest lifetime of the kubeconfigs handed out by the API. Revoking the access
+// of a user only takes effect in the user clusters which are reachable at that time, the certificates
+// in the kubeconfigs stay valid until they expire.
+const MaxKubeconfigTTL = 24 * time.Hour
+
 const (
 	ClusterTypeAll ClusterType = iota
 	ClusterTypeKubernetes
@@ -53,6 +60,14 @@ type SettingSpec struct {
 	EnableDashboard       bool           `json:"enableDashboard"`
 	EnableOIDCKubeconfig  bool           `json:"enableOIDCKubeconfig"`
 
+	// KubeconfigTTL is the lifetime of the kubeconfigs handed out by the API, at most 24h. If set,
+	// every user gets a kubeconfig with a personal client certificate instead of the shared admin
+	// or viewer token.
+	KubeconfigTTL *metav1.Duration `json:"kubeconfigTTL,omitempty"`
+
+	// PriceLists holds the prices used to estimate the cost of clusters, per cloud provider
+	PriceLists map[string]PriceList `json:"priceLists,omitempty"`
+
 	// TODO: Datacenters, presets, user management, Google Analytics and default addons.
 }
 
@@ -65,6 +80,14 @@ type CustomLink struct {
 	Location string `json:"location"`
 }
 
+// PriceList holds the prices of the node sizes of a cloud provider
+type PriceList struct {
+	// Currency is the currency the prices are given in, e.g. USD
+	Currency string `json:"currency"`
+	// NodeSizes maps the node sizes (instance types) to their price per hour
+	NodeSizes map[string]float64 `json:"nodeSizes"`
+}
+
 type CleanupOptions struct {
 	Enabled  bool
 	Enforced bool
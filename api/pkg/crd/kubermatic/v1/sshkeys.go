@@ -17,7 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -28,6 +31,10 @@ const (
 	SSHKeyKind = "UserSSHKey"
 )
 
+// SSHKeyUsers are the operating system users whose authorized_keys files the user ssh keys agent
+// manages on the nodes, a key can only be restricted to one of them.
+var SSHKeyUsers = sets.NewString("root", "core", "ubuntu", "centos")
+
 //+genclient
 //+genclient:nonNamespaced
 //+resourceName=usersshkeies
@@ -47,6 +54,21 @@ type SSHKeySpec struct {
 	Fingerprint string   `json:"fingerprint"`
 	PublicKey   string   `json:"publicKey"`
 	Clusters    []string `json:"clusters"`
+
+	// User is the operating system user on the nodes whose authorized_keys
+	// file receives this key, it must be one of SSHKeyUsers. If empty, the key
+	// is authorized for all users.
+	User string `json:"user,omitempty"`
+	// ExpiresAt is the point in time after which the key is removed from the nodes.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// NodeSelector restricts the key to nodes whose labels match all the given
+	// labels, e.g. the nodes of a single NodeDeployment.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// IsExpired returns true if the key has an expiry date which lies before the given time.
+func (s *SSHKeySpec) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !s.ExpiresAt.Time.After(now)
 }
 
 func (sk *UserSSHKey) IsUsedByCluster(clustername string) bool {
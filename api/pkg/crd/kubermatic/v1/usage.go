@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterUsageResourceName represents "Resource" defined in Kubernetes
+	ClusterUsageResourceName = "clusterusages"
+
+	// ClusterUsageKindName represents "Kind" defined in Kubernetes
+	ClusterUsageKindName = "ClusterUsage"
+
+	// ClusterUsageMonthLabelKey is the label key holding the month of a ClusterUsage
+	ClusterUsageMonthLabelKey = "usage-month"
+
+	// ClusterUsageMonthFormat is the layout of the month of a ClusterUsage
+	ClusterUsageMonthFormat = "2006-01"
+	// ClusterUsageDateFormat is the layout of the date of a ClusterUsageDay
+	ClusterUsageDateFormat = "2006-01-02"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUsage holds the daily resource usage of a cluster within a month. It lives in the seed
+// and is kept after the cluster got deleted, so the usage can still be reported.
+type ClusterUsage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterUsageSpec `json:"spec"`
+}
+
+// ClusterUsageSpec specifies the sampled resource usage of a cluster
+type ClusterUsageSpec struct {
+	// Cluster is the name of the cluster
+	Cluster string `json:"cluster"`
+	// ClusterHumanReadableName is the name of the cluster provided by the user
+	ClusterHumanReadableName string `json:"clusterHumanReadableName"`
+	// ProjectID is the ID of the project the cluster belongs to
+	ProjectID string `json:"projectID"`
+	// Provider is the name of the cloud provider of the cluster
+	Provider string `json:"provider"`
+	// Month is the month the usage was sampled in, e.g. 2020-03
+	Month string `json:"month"`
+	// LastSampleTime is the time the usage was sampled the last time
+	LastSampleTime metav1.Time `json:"lastSampleTime,omitempty"`
+	// Days holds the aggregated samples per day
+	Days []ClusterUsageDay `json:"days,omitempty"`
+}
+
+// ClusterUsageDay holds the aggregated resource usage of a cluster within a day. Averages are calculated
+// by dividing the sums by the number of samples.
+type ClusterUsageDay struct {
+	// Date is the day the samples were taken on, e.g. 2020-03-01
+	Date string `json:"date"`
+	// Samples is the number of samples taken on the day
+	Samples int64 `json:"samples"`
+	// MaxNodes is the highest number of nodes sampled on the day
+	MaxNodes int32 `json:"maxNodes"`
+	// NodeSeconds is the time in seconds nodes ran, per node size
+	NodeSeconds map[string]int64 `json:"nodeSeconds,omitempty"`
+	// NodesSum is the sum of the number of nodes of all samples
+	NodesSum int64 `json:"nodesSum"`
+	// CPUCapacitySum is the sum of the CPU capacity of all nodes of all samples, in millicores
+	CPUCapacitySum int64 `json:"cpuCapacitySum"`
+	// MemoryCapacitySum is the sum of the memory capacity of all nodes of all samples, in bytes
+	MemoryCapacitySum int64 `json:"memoryCapacitySum"`
+	// CPUUsageSum is the sum of the CPU usage of all nodes of all samples, in millicores
+	CPUUsageSum int64 `json:"cpuUsageSum"`
+	// MemoryUsageSum is the sum of the memory usage of all nodes of all samples, in bytes
+	MemoryUsageSum int64 `json:"memoryUsageSum"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUsageList specifies a list of cluster usages
+type ClusterUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterUsage `json:"items"`
+}
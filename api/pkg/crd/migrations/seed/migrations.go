@@ -24,6 +24,7 @@ import (
 	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	kubernetesprovider "github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/util/workerlabel"
@@ -39,8 +40,9 @@ import (
 )
 
 type cleanupContext struct {
-	client ctrlruntimeclient.Client
-	ctx    context.Context
+	client       ctrlruntimeclient.Client
+	ctx          context.Context
+	secretStores *provider.SecretStores
 }
 
 // ClusterTask represents a cleanup action, taking the current cluster for which the cleanup should be executed and the current context.
@@ -48,15 +50,16 @@ type cleanupContext struct {
 type ClusterTask func(cluster *kubermaticv1.Cluster, ctx *cleanupContext) error
 
 // RunAll runs all migrations
-func RunAll(ctx context.Context, config *rest.Config, workerName string) error {
+func RunAll(ctx context.Context, config *rest.Config, workerName string, secretStores *provider.SecretStores) error {
 	client, err := ctrlruntimeclient.New(config, ctrlruntimeclient.Options{})
 	if err != nil {
 		return fmt.Errorf("failed to create client: %v", err)
 	}
 
 	cleanupContext := &cleanupContext{
-		client: client,
-		ctx:    ctx,
+		client:       client,
+		ctx:          ctx,
+		secretStores: secretStores,
 	}
 
 	if err := cleanupClusters(workerName, cleanupContext); err != nil {
@@ -225,7 +228,7 @@ func migrateClusterUserLabel(cluster *kubermaticv1.Cluster, cleanupContext *clea
 }
 
 func createSecretsForCredentials(cluster *kubermaticv1.Cluster, cleanupContext *cleanupContext) error {
-	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(cleanupContext.ctx, cleanupContext.client, cluster); err != nil {
+	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(cleanupContext.ctx, cleanupContext.client, cleanupContext.secretStores, cluster); err != nil {
 		return err
 	}
 	kuberneteshelper.AddFinalizer(cluster, apiv1.CredentialsSecretsCleanupFinalizer)
@@ -43,6 +43,12 @@ type KubermaticConfiguration struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec KubermaticConfigurationSpec `json:"spec"`
+	// Status is maintained by the operator and reports the state of the
+	// components it manages.
+	// ---
+	//nolint:staticcheck
+	//lint:ignore SA5008 omitgenyaml is used by the example-yaml-generator
+	Status KubermaticConfigurationStatus `json:"status,omitempty,omitgenyaml"`
 }
 
 // KubermaticConfigurationSpec is the spec for a Kubermatic installation.
@@ -337,6 +343,52 @@ type KubermaticProxyConfiguration struct {
 	NoProxy string `json:"noProxy,omitempty"`
 }
 
+// KubermaticConfigurationStatus reports the state of the components managed by the operator.
+type KubermaticConfigurationStatus struct {
+	// ObservedGeneration is the most recent generation of the configuration that has
+	// been reconciled by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions contains the readiness of the master components and of the
+	// components installed on each seed.
+	Conditions []KubermaticConfigurationCondition `json:"conditions,omitempty"`
+}
+
+// KubermaticConfigurationConditionType is the type of a KubermaticConfigurationCondition.
+type KubermaticConfigurationConditionType string
+
+const (
+	// ConfigurationConditionAPIReady indicates whether the Kubermatic API is ready.
+	ConfigurationConditionAPIReady KubermaticConfigurationConditionType = "APIReady"
+	// ConfigurationConditionUIReady indicates whether the dashboard is ready.
+	ConfigurationConditionUIReady KubermaticConfigurationConditionType = "UIReady"
+	// ConfigurationConditionMasterControllerManagerReady indicates whether the
+	// master-controller-manager is ready.
+	ConfigurationConditionMasterControllerManagerReady KubermaticConfigurationConditionType = "MasterControllerManagerReady"
+	// ConfigurationConditionSeedControllerManagerReady indicates whether the
+	// seed-controller-manager of a seed is ready.
+	ConfigurationConditionSeedControllerManagerReady KubermaticConfigurationConditionType = "SeedControllerManagerReady"
+	// ConfigurationConditionNodeportProxyReady indicates whether the nodeport-proxy
+	// of a seed is ready.
+	ConfigurationConditionNodeportProxyReady KubermaticConfigurationConditionType = "NodeportProxyReady"
+)
+
+// KubermaticConfigurationCondition describes the state of a single component.
+type KubermaticConfigurationCondition struct {
+	// Type of the condition.
+	Type KubermaticConfigurationConditionType `json:"type"`
+	// Seed is the name of the seed the component runs on; it is empty for
+	// components running on the master cluster.
+	Seed string `json:"seed,omitempty"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition changed its status.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a machine-readable reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about the last transition.
+	Message string `json:"message,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // KubermaticConfigurationList is a collection of KubermaticConfigurations.
@@ -103,6 +103,7 @@ func (in *KubermaticConfiguration) DeepCopyInto(out *KubermaticConfiguration) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -124,6 +125,23 @@ func (in *KubermaticConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubermaticConfigurationCondition) DeepCopyInto(out *KubermaticConfigurationCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubermaticConfigurationCondition.
+func (in *KubermaticConfigurationCondition) DeepCopy() *KubermaticConfigurationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(KubermaticConfigurationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubermaticConfigurationList) DeepCopyInto(out *KubermaticConfigurationList) {
 	*out = *in
@@ -190,6 +208,29 @@ func (in *KubermaticConfigurationSpec) DeepCopy() *KubermaticConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubermaticConfigurationStatus) DeepCopyInto(out *KubermaticConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]KubermaticConfigurationCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubermaticConfigurationStatus.
+func (in *KubermaticConfigurationStatus) DeepCopy() *KubermaticConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubermaticConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubermaticIngressConfiguration) DeepCopyInto(out *KubermaticIngressConfiguration) {
 	*out = *in
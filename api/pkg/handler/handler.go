@@ -66,6 +66,18 @@ func decodeEmptyReq(c context.Context, r *http.Request) (interface{}, error) {
 }
 
 func errorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	e := newErrorResponse(err)
+
+	w.Header().Set(headerContentType, contentTypeJSON)
+	w.WriteHeader(e.Error.Code)
+	err = encodeJSON(ctx, w, e)
+	if err != nil {
+		log.Logger.Error(err)
+	}
+}
+
+// newErrorResponse converts the given error into the response that is sent to the client
+func newErrorResponse(err error) ErrorResponse {
 	var additional []string
 	errorCode := http.StatusInternalServerError
 	msg := err.Error()
@@ -74,20 +86,13 @@ func errorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
 		msg = h.Error()
 		additional = h.Details()
 	}
-	e := ErrorResponse{
+	return ErrorResponse{
 		Error: ErrorDetails{
 			Code:       errorCode,
 			Message:    msg,
 			Additional: additional,
 		},
 	}
-
-	w.Header().Set(headerContentType, contentTypeJSON)
-	w.WriteHeader(errorCode)
-	err = encodeJSON(ctx, w, e)
-	if err != nil {
-		log.Logger.Error(err)
-	}
 }
 
 // encodeJSON writes the JSON encoding of response to the http response writer
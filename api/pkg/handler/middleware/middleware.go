@@ -289,6 +289,24 @@ func createUserInfo(user *kubermaticapiv1.User, projectID string, userProjectMap
 	return &provider.UserInfo{Email: user.Spec.Email, Group: group}, nil
 }
 
+// WithSeedClusterProvider returns a copy of ctx that holds the cluster providers for
+// the given seed instead of the one from the request path
+func WithSeedClusterProvider(ctx context.Context, seed *kubermaticapiv1.Seed, clusterProviderGetter provider.ClusterProviderGetter) (context.Context, error) {
+	clusterProvider, err := clusterProviderGetter(seed)
+	if err != nil {
+		return ctx, k8cerrors.NewNotFound("cluster-provider", seed.Name)
+	}
+	privilegedClusterProvider, ok := clusterProvider.(provider.PrivilegedClusterProvider)
+	if !ok {
+		return ctx, k8cerrors.New(http.StatusInternalServerError, "failed to assert privileged cluster provider")
+	}
+
+	ctx = context.WithValue(ctx, datacenterContextKey, seed)
+	ctx = context.WithValue(ctx, ClusterProviderContextKey, clusterProvider)
+	ctx = context.WithValue(ctx, PrivilegedClusterProviderContextKey, privilegedClusterProvider)
+	return ctx, nil
+}
+
 func getClusterProvider(ctx context.Context, request interface{}, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter) (provider.ClusterProvider, context.Context, error) {
 	getter, ok := request.(dCGetter)
 	if !ok {
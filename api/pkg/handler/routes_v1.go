@@ -52,7 +52,9 @@ import (
 	v1 "github.com/kubermatic/kubermatic/api/pkg/handler/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/addon"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/clustertemplate"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/constraint"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/dc"
 	kubernetesdashboard "github.com/kubermatic/kubermatic/api/pkg/handler/v1/kubernetes-dashboard"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/label"
@@ -63,6 +65,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/serviceaccount"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/ssh"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/usage"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/user"
 )
 
@@ -214,6 +217,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/providers/{provider_name}/presets/credentials").
 		Handler(r.listCredentials())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/providers/{provider_name}/presets/credentials").
+		Handler(r.listProjectCredentials())
+
 	//
 	// Defines a set of HTTP endpoints for project resource
 	mux.Methods(http.MethodGet).
@@ -260,6 +267,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters").
 		Handler(r.createCluster(metrics.InitNodeDeploymentFailures))
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/preflight/credentials").
+		Handler(r.validateCloudCredentials())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/dc/{dc}/clusters").
 		Handler(r.listClusters())
@@ -402,6 +413,26 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/viewertoken").
 		Handler(r.revokeClusterViewerToken())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/hibernate").
+		Handler(r.hibernateCluster())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/resume").
+		Handler(r.resumeCluster())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/certificates").
+		Handler(r.getClusterCertificates())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/certificates/rotate").
+		Handler(r.rotateClusterCertificates())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/encryption/rotate").
+		Handler(r.rotateClusterEncryptionKey())
+
 	//
 	// Defines a set of HTTP endpoint for node deployments that belong to a cluster
 	mux.Methods(http.MethodPost).
@@ -436,6 +467,26 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}").
 		Handler(r.deleteNodeDeployment())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/restart").
+		Handler(r.restartNodeDeployment())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/cordon").
+		Handler(r.cordonNodeDeploymentNode())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/uncordon").
+		Handler(r.uncordonNodeDeploymentNode())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/drain").
+		Handler(r.drainNodeDeploymentNode())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}").
+		Handler(r.deleteNodeDeploymentNode())
+
 	//
 	// Defines a set of HTTP endpoints for managing addons
 	mux.Methods(http.MethodGet).
@@ -661,6 +712,59 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 	mux.Methods(http.MethodGet).
 		Path("/admission/plugins/{version}").
 		Handler(r.getAdmissionPlugins())
+
+	//
+	// Defines a set of HTTP endpoints for managing cluster templates
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/clustertemplates").
+		Handler(r.createClusterTemplate())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clustertemplates").
+		Handler(r.listClusterTemplates())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/clustertemplates/{template_id}").
+		Handler(r.getClusterTemplate())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/clustertemplates/{template_id}").
+		Handler(r.deleteClusterTemplate())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/clustertemplates").
+		Handler(r.createClusterTemplateFromCluster())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clustertemplates/{template_id}/instances").
+		Handler(r.createClusterTemplateInstances(metrics.InitNodeDeploymentFailures))
+
+	//
+	// Defines a set of HTTP endpoints for managing gatekeeper constraints
+	mux.Methods(http.MethodGet).
+		Path("/constrainttemplates").
+		Handler(r.listConstraintTemplates())
+
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/constraints").
+		Handler(r.createConstraint())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/constraints").
+		Handler(r.listConstraints())
+
+	mux.Methods(http.MethodDelete).
+		Path("/projects/{project_id}/constraints/{constraint_id}").
+		Handler(r.deleteConstraint())
+
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/constraints/violations").
+		Handler(r.listConstraintViolations())
+
+	// Defines an endpoint to get the usage report of a project
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/usage/{month}").
+		Handler(r.getProjectUsageReport())
 }
 
 // swagger:route GET /api/v1/projects/{project_id}/sshkeys project listSSHKeys
@@ -761,6 +865,30 @@ func (r Routing) listCredentials() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/projects/{project_id}/providers/{provider_name}/presets/credentials credentials listProjectCredentials
+//
+// Lists credential names for the provider which can be used in the project, including the presets restricted to the project
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: CredentialList
+//       401: empty
+//       403: empty
+func (r Routing) listProjectCredentials() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(presets.ProjectCredentialEndpoint(r.projectProvider, r.privilegedProjectProvider, r.presetsProvider, r.userInfoGetter)),
+		presets.DecodeProjectProviderReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/providers/aws/sizes aws listAWSSizes
 //
 // Lists available AWS sizes.
@@ -1672,12 +1800,13 @@ func (r Routing) updateProject() http.Handler {
 //       200: empty
 //       401: empty
 //       403: empty
+//       409: empty
 func (r Routing) deleteProject() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(project.DeleteEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		)(project.DeleteEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterProviderGetter, r.seedsGetter)),
 		project.DecodeDelete,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -1706,13 +1835,41 @@ func (r Routing) createCluster(initNodeDeploymentFailures *prometheus.CounterVec
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.CreateEndpoint(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, initNodeDeploymentFailures, r.eventRecorderProvider, r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager)),
+		)(cluster.CreateEndpoint(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, initNodeDeploymentFailures, r.eventRecorderProvider, r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager, r.clusterProviderGetter, r.seedsClientGetter)),
 		cluster.DecodeCreateReq,
 		setStatusCreatedHeader(encodeJSON),
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/preflight/credentials project validateCloudCredentials
+//
+//     Checks the credentials of a cloud spec and the permissions Kubermatic needs to create a cluster with them.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: CloudCredentialsReport
+//       401: empty
+//       403: empty
+func (r Routing) validateCloudCredentials() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.PreflightCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.presetsProvider, r.userInfoGetter)),
+		cluster.DecodePreflightCredentialsReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters project listClusters
 //
 //     Lists clusters for the specified project and data center.
@@ -1861,7 +2018,7 @@ func (r Routing) getClusterKubeconfig() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.GetAdminKubeconfigEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		)(cluster.GetAdminKubeconfigEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
 		cluster.DecodeGetAdminKubeconfig,
 		cluster.EncodeKubeconfig,
 		r.defaultServerOptions()...,
@@ -1908,6 +2065,7 @@ func (r Routing) getOidcClusterKubeconfig() http.Handler {
 //       200: empty
 //       401: empty
 //       403: empty
+//       409: empty
 func (r Routing) deleteCluster() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
@@ -2088,6 +2246,141 @@ func (r Routing) revokeClusterViewerToken() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/hibernate project hibernateCluster
+//
+//     Scales the worker nodes and the control plane of the cluster to zero
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Cluster
+//       401: empty
+//       403: empty
+func (r Routing) hibernateCluster() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.HibernateEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/resume project resumeCluster
+//
+//     Wakes up a hibernated cluster
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Cluster
+//       401: empty
+//       403: empty
+func (r Routing) resumeCluster() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ResumeEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/certificates project getClusterCertificates
+//
+//     Lists the certificates of the cluster control plane and the progress of a certificate rotation
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterCertificates
+//       401: empty
+//       403: empty
+func (r Routing) getClusterCertificates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetCertificatesEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/certificates/rotate project rotateClusterCertificates
+//
+//     Rotates the cluster CAs and the service account key. The old ones stay trusted for the transition window.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterCertificates
+//       401: empty
+//       403: empty
+//       409: empty
+func (r Routing) rotateClusterCertificates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.RotateCertificatesEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeRotateCertificatesReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/encryption/rotate project rotateClusterEncryptionKey
+//
+//     Replaces the key the Secrets of the cluster are encrypted with. All Secrets get rewritten with the new key.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Cluster
+//       401: empty
+//       403: empty
+//       409: empty
+func (r Routing) rotateClusterEncryptionKey() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.RotateEncryptionKeyEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/upgrades project getClusterUpgrades
 //
 //    Gets possible cluster upgrades
@@ -2238,7 +2531,7 @@ func (r Routing) editUserInProject() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(user.EditEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userProvider, r.projectMemberProvider, r.privilegedProjectMemberProvider, r.userInfoGetter)),
+		)(user.EditEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userProvider, r.projectMemberProvider, r.privilegedProjectMemberProvider, r.userInfoGetter, r.clusterProviderGetter, r.seedsGetter)),
 		user.DecodeEditReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -2265,7 +2558,7 @@ func (r Routing) deleteUserFromProject() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(user.DeleteEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userProvider, r.projectMemberProvider, r.privilegedProjectMemberProvider, r.userInfoGetter)),
+		)(user.DeleteEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userProvider, r.projectMemberProvider, r.privilegedProjectMemberProvider, r.userInfoGetter, r.clusterProviderGetter, r.seedsGetter)),
 		user.DecodeDeleteReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -3272,149 +3565,279 @@ func (r Routing) deleteNodeDeployment() http.Handler {
 	)
 }
 
-// swagger:route POST /api/v1/addons addon
-//
-//     Lists names of addons that can be configured inside the user clusters
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/restart project restartNodeDeployment
 //
-//     Consumes:
-//     - application/json
+//     Restarts the node deployment. All nodes are replaced by new ones according to the rollout strategy.
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       200: AccessibleAddons
+//       200: NodeDeployment
 //       401: empty
 //       403: empty
-func (r Routing) listAccessibleAddons() http.Handler {
+func (r Routing) restartNodeDeployment() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(addon.ListAccessibleAddons(r.accessibleAddons)),
-		decodeEmptyReq,
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(node.RestartNodeDeployment(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		node.DecodeRestartNodeDeployment,
 		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon listInstallableAddons
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/cordon project cordonNodeDeploymentNode
 //
-//     Lists names of addons that can be installed inside the user cluster
+//     Marks the given node of the node deployment as unschedulable.
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       200: AccessibleAddons
+//       200: Node
 //       401: empty
 //       403: empty
-func (r Routing) listInstallableAddons() http.Handler {
+func (r Routing) cordonNodeDeploymentNode() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
-			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
-		)(addon.ListInstallableAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.accessibleAddons)),
-		addon.DecodeListAddons,
+		)(node.CordonNodeDeploymentNode(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		node.DecodeNodeDeploymentNode,
 		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon createAddon
-//
-//     Creates an addon that will belong to the given cluster
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/uncordon project uncordonNodeDeploymentNode
 //
-//     Consumes:
-//     - application/json
+//     Marks the given node of the node deployment as schedulable.
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       201: Addon
+//       200: Node
 //       401: empty
 //       403: empty
-func (r Routing) createAddon() http.Handler {
+func (r Routing) uncordonNodeDeploymentNode() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
-			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
-		)(addon.CreateAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
-		addon.DecodeCreateAddon,
-		setStatusCreatedHeader(encodeJSON),
+		)(node.UncordonNodeDeploymentNode(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		node.DecodeNodeDeploymentNode,
+		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon listAddons
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id}/drain project drainNodeDeploymentNode
 //
-//     Lists addons that belong to the given cluster
+//     Cordons the given node of the node deployment and evicts its pods. Evictions that would violate a PodDisruptionBudget are rejected.
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       200: []Addon
+//       200: Node
 //       401: empty
 //       403: empty
-func (r Routing) listAddons() http.Handler {
+func (r Routing) drainNodeDeploymentNode() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
-			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
-		)(addon.ListAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
-		addon.DecodeListAddons,
+		)(node.DrainNodeDeploymentNode(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		node.DecodeNodeDeploymentNode,
 		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons/{addon_id} addon getAddon
+// swagger:route DELETE /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments/{nodedeployment_id}/nodes/{node_id} project deleteNodeDeploymentNode
 //
-//     Gets an addon that is assigned to the given cluster.
+//     Deletes the given node of the node deployment. The node is replaced by a new one unless scaleDown is set.
 //
 //     Produces:
 //     - application/json
 //
 //     Responses:
 //       default: errorResponse
-//       200: Addon
+//       200: empty
 //       401: empty
 //       403: empty
-func (r Routing) getAddon() http.Handler {
+func (r Routing) deleteNodeDeploymentNode() http.Handler {
 	return httptransport.NewServer(
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
-			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
-		)(addon.GetAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
-		addon.DecodeGetAddon,
+		)(node.DeleteNodeDeploymentNode(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		node.DecodeDeleteNodeDeploymentNode,
 		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
-// swagger:route PATCH /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons/{addon_id} addon patchAddon
+// swagger:route POST /api/v1/addons addon
+//
+//     Lists names of addons that can be configured inside the user clusters
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AccessibleAddons
+//       401: empty
+//       403: empty
+func (r Routing) listAccessibleAddons() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(addon.ListAccessibleAddons(r.accessibleAddons)),
+		decodeEmptyReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon listInstallableAddons
+//
+//     Lists names of addons that can be installed inside the user cluster
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: AccessibleAddons
+//       401: empty
+//       403: empty
+func (r Routing) listInstallableAddons() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(addon.ListInstallableAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.accessibleAddons)),
+		addon.DecodeListAddons,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon createAddon
+//
+//     Creates an addon that will belong to the given cluster
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: Addon
+//       401: empty
+//       403: empty
+func (r Routing) createAddon() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(addon.CreateAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		addon.DecodeCreateAddon,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons addon listAddons
+//
+//     Lists addons that belong to the given cluster
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []Addon
+//       401: empty
+//       403: empty
+func (r Routing) listAddons() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(addon.ListAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		addon.DecodeListAddons,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons/{addon_id} addon getAddon
+//
+//     Gets an addon that is assigned to the given cluster.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Addon
+//       401: empty
+//       403: empty
+func (r Routing) getAddon() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(addon.GetAddonEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		addon.DecodeGetAddon,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PATCH /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/addons/{addon_id} addon patchAddon
 //
 //     Patches an addon that is assigned to the given cluster.
 //
@@ -4176,3 +4599,319 @@ func (r Routing) getAdmissionPlugins() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route POST /api/v1/projects/{project_id}/clustertemplates project createClusterTemplate
+//
+//     Creates a cluster template that can be used to create clusters in the given project.
+//     Global templates can only be created by admins.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.CreateEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeCreateReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/clustertemplates project listClusterTemplates
+//
+//     Lists the cluster templates of the given project together with the global ones.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterTemplateList
+//       401: empty
+//       403: empty
+func (r Routing) listClusterTemplates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.ListEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterTemplateProvider)),
+		common.DecodeGetProject,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/clustertemplates/{template_id} project getClusterTemplate
+//
+//     Gets the given cluster template.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) getClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.GetEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeGetReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/projects/{project_id}/clustertemplates/{template_id} project deleteClusterTemplate
+//
+//     Deletes the given cluster template. Global templates can only be deleted by admins.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteClusterTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(clustertemplate.DeleteEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeGetReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/clustertemplates project createClusterTemplateFromCluster
+//
+//     Saves the given cluster together with its node deployments and addons as a cluster template.
+//     The template doesn't contain cloud credentials, they are taken from the given preset.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createClusterTemplateFromCluster() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(clustertemplate.CreateFromClusterEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeCreateFromClusterReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clustertemplates/{template_id}/instances project createClusterTemplateInstances
+//
+//     Creates clusters from the given cluster template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ClusterList
+//       401: empty
+//       403: empty
+func (r Routing) createClusterTemplateInstances(initNodeDeploymentFailures *prometheus.CounterVec) http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.Addons(r.addonProviderGetter, r.seedsGetter),
+			middleware.PrivilegedAddons(r.addonProviderGetter, r.seedsGetter),
+		)(clustertemplate.CreateInstancesEndpoint(r.sshKeyProvider, r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, initNodeDeploymentFailures, r.eventRecorderProvider,
+			r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager, r.clusterProviderGetter, r.seedsClientGetter, r.clusterTemplateProvider)),
+		clustertemplate.DecodeCreateInstancesReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/constrainttemplates constrainttemplates listConstraintTemplates
+//
+//     Lists the gatekeeper constraint templates defined by the admins.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ConstraintTemplateList
+//       401: empty
+//       403: empty
+func (r Routing) listConstraintTemplates() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.ListConstraintTemplatesEndpoint(r.userInfoGetter, r.constraintTemplateProvider)),
+		decodeEmptyReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/projects/{project_id}/constraints project createConstraint
+//
+//     Creates a gatekeeper constraint which is synced into the clusters of the given project.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: Constraint
+//       401: empty
+//       403: empty
+//       409: empty
+func (r Routing) createConstraint() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.CreateConstraintEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.constraintTemplateProvider, r.constraintProvider)),
+		constraint.DecodeCreateConstraintReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/constraints project listConstraints
+//
+//     Lists the gatekeeper constraints of the given project.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ConstraintList
+//       401: empty
+//       403: empty
+func (r Routing) listConstraints() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.ListConstraintsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.constraintProvider)),
+		common.DecodeGetProject,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/projects/{project_id}/constraints/{constraint_id} project deleteConstraint
+//
+//     Deletes the given gatekeeper constraint, it gets removed from the clusters of the project.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteConstraint() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.DeleteConstraintEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.constraintProvider)),
+		constraint.DecodeConstraintReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/constraints/violations project listConstraintViolations
+//
+//     Lists the violations of the project's constraints reported by the gatekeeper audit controller of the cluster.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ConstraintViolationList
+//       401: empty
+//       403: empty
+func (r Routing) listConstraintViolations() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(constraint.ListViolationsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// getProjectUsageReport returns the usage report of the project for the given month.
+// swagger:route GET /api/v1/projects/{project_id}/usage/{month} project getProjectUsageReport
+//
+//     Gets the resource usage and the estimated cost of the clusters of the project for the given month.
+//
+//     Produces:
+//     - application/json
+//     - text/csv
+//
+//     Responses:
+//       default: errorResponse
+//       200: ProjectUsageReport
+//       401: empty
+//       403: empty
+func (r Routing) getProjectUsageReport() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(usage.GetProjectUsageReportEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.seedsGetter, r.clusterProviderGetter, r.settingsProvider)),
+		usage.DecodeGetProjectUsageReportReq,
+		usage.EncodeProjectUsageReport,
+		r.defaultServerOptions()...,
+	)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/admin"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/constraint"
 )
 
 //RegisterV1Admin declares all router paths for the admin users
@@ -68,11 +69,36 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 		Path("/admin/admission/plugins/{name}").
 		Handler(r.updateAdmissionPlugin())
 
+	// Defines a set of HTTP endpoints for the presets
+	mux.Methods(http.MethodGet).
+		Path("/admin/presets").
+		Handler(r.listPresets())
+
+	mux.Methods(http.MethodPost).
+		Path("/admin/presets").
+		Handler(r.createPreset())
+
+	mux.Methods(http.MethodPut).
+		Path("/admin/presets/{preset_name}").
+		Handler(r.updatePreset())
+
+	mux.Methods(http.MethodPatch).
+		Path("/admin/presets/{preset_name}/status").
+		Handler(r.updatePresetStatus())
+
+	mux.Methods(http.MethodDelete).
+		Path("/admin/presets/{preset_name}").
+		Handler(r.deletePreset())
+
 	// Defines a set of HTTP endpoints for the seeds
 	mux.Methods(http.MethodGet).
 		Path("/admin/seeds").
 		Handler(r.listSeeds())
 
+	mux.Methods(http.MethodPost).
+		Path("/admin/seeds").
+		Handler(r.createSeed())
+
 	mux.Methods(http.MethodGet).
 		Path("/admin/seeds/{seed_name}").
 		Handler(r.getSeed())
@@ -84,6 +110,23 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 	mux.Methods(http.MethodDelete).
 		Path("/admin/seeds/{seed_name}").
 		Handler(r.deleteSeed())
+
+	mux.Methods(http.MethodGet).
+		Path("/admin/seeds/{seed_name}/bootstrap").
+		Handler(r.getSeedBootstrapStatus())
+
+	mux.Methods(http.MethodPost).
+		Path("/admin/seeds/{seed_name}/clusters/{cluster_id}/migrate").
+		Handler(r.migrateCluster())
+
+	// Defines a set of HTTP endpoints for the gatekeeper constraint templates
+	mux.Methods(http.MethodPost).
+		Path("/admin/constrainttemplates").
+		Handler(r.createConstraintTemplate())
+
+	mux.Methods(http.MethodDelete).
+		Path("/admin/constrainttemplates/{name}").
+		Handler(r.deleteConstraintTemplate())
 }
 
 // swagger:route GET /api/v1/admin/settings admin getKubermaticSettings
@@ -200,7 +243,7 @@ func (r Routing) setAdmin() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(admin.SetAdminEndpoint(r.userInfoGetter, r.adminProvider)),
+		)(admin.SetAdminEndpoint(r.userInfoGetter, r.adminProvider, r.clusterProviderGetter, r.seedsGetter)),
 		admin.DecodeSetAdminReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -323,13 +366,64 @@ func (r Routing) listSeeds() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(admin.ListSeedEndpoint(r.userInfoGetter, r.seedsGetter)),
+		)(admin.ListSeedEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
 		decodeEmptyReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route POST /api/v1/admin/seeds admin createSeed
+//
+//     Registers a new seed, stores its kubeconfig and reports the bootstrap progress.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: Seed
+//       401: empty
+//       403: empty
+func (r Routing) createSeed() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.CreateSeedEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter, r.seedProvider)),
+		admin.DecodeCreateSeedReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/admin/seeds/{seed_name}/bootstrap admin getSeedBootstrapStatus
+//
+//     Reports how far the bootstrapping of the seed cluster has progressed.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: SeedBootstrapStatus
+//       401: empty
+//       403: empty
+func (r Routing) getSeedBootstrapStatus() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.GetSeedBootstrapStatusEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
+		admin.DecodeSeedReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/admin/seeds/{seed_name} admin getSeed
 //
 //     Returns the seed object.
@@ -347,7 +441,7 @@ func (r Routing) getSeed() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers),
 			middleware.UserSaver(r.userProvider),
-		)(admin.GetSeedEndpoint(r.userInfoGetter, r.seedsGetter)),
+		)(admin.GetSeedEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
 		admin.DecodeSeedReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -404,3 +498,210 @@ func (r Routing) deleteSeed() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route POST /api/v1/admin/seeds/{seed_name}/clusters/{cluster_id}/migrate admin migrateCluster
+//
+//     Moves the control plane of the cluster to another seed. The progress is reported in the cluster status.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) migrateCluster() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.MigrateClusterEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
+		admin.DecodeMigrateClusterReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route GET /api/v1/admin/presets admin listPresets
+//
+//     Lists all presets, including the disabled ones and the ones restricted to projects.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []Preset
+//       401: empty
+//       403: empty
+func (r Routing) listPresets() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.ListPresetsEndpoint(r.userInfoGetter, r.presetsProvider)),
+		decodeEmptyReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/admin/presets admin createPreset
+//
+//     Creates the preset.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: Preset
+//       401: empty
+//       403: empty
+func (r Routing) createPreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.CreatePresetEndpoint(r.userInfoGetter, r.presetsProvider, r.privilegedProjectProvider)),
+		admin.DecodeCreatePresetReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PUT /api/v1/admin/presets/{preset_name} admin updatePreset
+//
+//     Updates the preset.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Preset
+//       401: empty
+//       403: empty
+func (r Routing) updatePreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.UpdatePresetEndpoint(r.userInfoGetter, r.presetsProvider, r.privilegedProjectProvider)),
+		admin.DecodeUpdatePresetReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PATCH /api/v1/admin/presets/{preset_name}/status admin updatePresetStatus
+//
+//     Enables or disables the preset, disabled presets can't be used to create clusters.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: Preset
+//       401: empty
+//       403: empty
+func (r Routing) updatePresetStatus() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.UpdatePresetStatusEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodeUpdatePresetStatusReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/admin/presets/{preset_name} admin deletePreset
+//
+//     Deletes the preset.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deletePreset() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(admin.DeletePresetEndpoint(r.userInfoGetter, r.presetsProvider)),
+		admin.DecodePresetReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/admin/constrainttemplates admin createConstraintTemplate
+//
+//     Creates a gatekeeper constraint template, it is synced into all clusters with the gatekeeper addon.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ConstraintTemplate
+//       401: empty
+//       403: empty
+func (r Routing) createConstraintTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.CreateConstraintTemplateEndpoint(r.userInfoGetter, r.constraintTemplateProvider)),
+		constraint.DecodeCreateConstraintTemplateReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/admin/constrainttemplates/{name} admin deleteConstraintTemplate
+//
+//     Deletes the gatekeeper constraint template, it gets removed from all clusters.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) deleteConstraintTemplate() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(constraint.DeleteConstraintTemplateEndpoint(r.userInfoGetter, r.constraintTemplateProvider)),
+		constraint.DecodeConstraintTemplateReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
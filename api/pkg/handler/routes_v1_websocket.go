@@ -17,21 +17,37 @@ limitations under the License.
 package handler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/url"
+	"reflect"
+	"time"
 
 	v1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/auth"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/node"
 	wsh "github.com/kubermatic/kubermatic/api/pkg/handler/websocket"
 	"github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
 	"github.com/kubermatic/kubermatic/api/pkg/util/hash"
 	"github.com/kubermatic/kubermatic/api/pkg/watcher"
 
+	"code.cloudfoundry.org/go-pubsub"
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 var upgrader = websocket.Upgrader{
@@ -71,6 +87,289 @@ func (r Routing) RegisterV1Websocket(mux *mux.Router) {
 	providers := getProviders(r)
 
 	mux.HandleFunc("/ws/admin/settings", getHandler(wsh.WriteSettings, providers, r))
+
+	mux.HandleFunc("/ws/projects/{project_id}/clusters", r.getStreamHandler(r.projectClustersStream()))
+	mux.HandleFunc("/ws/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/health", r.getStreamHandler(r.clusterHealthStream()))
+	mux.HandleFunc("/ws/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/nodedeployments", r.getStreamHandler(r.nodeDeploymentsStream()))
+	mux.HandleFunc("/ws/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/events", r.getStreamHandler(r.clusterEventsStream()))
+}
+
+// stream sends the responses of a REST endpoint over a websocket. The endpoint is run again every time the
+// resources it reads change, so every message is authorised with the same checks as the REST request.
+type stream struct {
+	endpoint      endpoint.Endpoint
+	decodeRequest httptransport.DecodeRequestFunc
+	// subscribe registers the subscription for the changes of the resources the endpoint reads.
+	// It is only called after the endpoint authorised the request.
+	subscribe func(req *http.Request, subscription pubsub.Subscription) ([]pubsub.Unsubscriber, error)
+}
+
+// projectClustersStream streams the clusters of a project in all datacenters
+func (r Routing) projectClustersStream() stream {
+	return stream{
+		endpoint: endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+		)(cluster.ListAllEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.clusterProviderGetter, r.userInfoGetter)),
+		decodeRequest: common.DecodeGetProject,
+		subscribe: func(req *http.Request, subscription pubsub.Subscription) ([]pubsub.Unsubscriber, error) {
+			projectReq, err := common.DecodeGetProject(req.Context(), req)
+			if err != nil {
+				return nil, err
+			}
+			projectID := projectReq.(common.GetProjectRq).ProjectID
+
+			seeds, err := r.seedsGetter()
+			if err != nil {
+				return nil, err
+			}
+
+			unsubscribers := []pubsub.Unsubscriber{}
+			for _, seed := range seeds {
+				// if a Seed is bad, do not forward that error to the user, but only log
+				unsubscribe, err := r.resourceWatchers.SubscribeClusters(seed, projectID, subscription)
+				if err != nil {
+					log.Logger.Errorf("failed to watch clusters in seed %s: %v", seed.Name, err)
+					continue
+				}
+				unsubscribers = append(unsubscribers, unsubscribe)
+			}
+			return unsubscribers, nil
+		},
+	}
+}
+
+// clusterHealthStream streams the health of a cluster
+func (r Routing) clusterHealthStream() stream {
+	return stream{
+		endpoint: endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.HealthEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		decodeRequest: common.DecodeGetClusterReq,
+		subscribe: func(req *http.Request, subscription pubsub.Subscription) ([]pubsub.Unsubscriber, error) {
+			seed, userCluster, err := r.getStreamCluster(req)
+			if err != nil {
+				return nil, err
+			}
+			unsubscribe, err := r.resourceWatchers.SubscribeClusters(seed, userCluster.Labels[kubermaticv1.ProjectIDLabelKey], subscription)
+			if err != nil {
+				return nil, err
+			}
+			return []pubsub.Unsubscriber{unsubscribe}, nil
+		},
+	}
+}
+
+// nodeDeploymentsStream streams the node deployments of a cluster
+func (r Routing) nodeDeploymentsStream() stream {
+	return stream{
+		endpoint: endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(node.ListNodeDeployments(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		decodeRequest: node.DecodeListNodeDeployments,
+		subscribe: func(req *http.Request, subscription pubsub.Subscription) ([]pubsub.Unsubscriber, error) {
+			seed, userCluster, err := r.getStreamCluster(req)
+			if err != nil {
+				return nil, err
+			}
+			unsubscribe, err := r.resourceWatchers.SubscribeMachineDeployments(seed, userCluster, subscription)
+			if err != nil {
+				return nil, err
+			}
+			return []pubsub.Unsubscriber{unsubscribe}, nil
+		},
+	}
+}
+
+// clusterEventsStream streams the events of a cluster, the query parameters filter them like for the REST endpoint
+func (r Routing) clusterEventsStream() stream {
+	return stream{
+		endpoint: endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetClusterEventsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		decodeRequest: cluster.DecodeGetClusterEvents,
+		subscribe: func(req *http.Request, subscription pubsub.Subscription) ([]pubsub.Unsubscriber, error) {
+			seed, userCluster, err := r.getStreamCluster(req)
+			if err != nil {
+				return nil, err
+			}
+
+			unsubscribers := []pubsub.Unsubscriber{}
+			options := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(userCluster.UID)).String()}
+			unsubscribe, err := r.resourceWatchers.SubscribeEvents(seed, "", options, subscription)
+			if err != nil {
+				return unsubscribers, err
+			}
+			unsubscribers = append(unsubscribers, unsubscribe)
+
+			unsubscribe, err = r.resourceWatchers.SubscribeClusterEvents(seed, userCluster.Name, subscription)
+			if err != nil {
+				return unsubscribers, err
+			}
+			unsubscribers = append(unsubscribers, unsubscribe)
+			return unsubscribers, nil
+		},
+	}
+}
+
+// getStreamCluster returns the seed and the cluster of a stream request
+func (r Routing) getStreamCluster(req *http.Request) (*kubermaticv1.Seed, *kubermaticv1.Cluster, error) {
+	rawReq, err := common.DecodeGetClusterReq(req.Context(), req)
+	if err != nil {
+		return nil, nil, err
+	}
+	clusterReq := rawReq.(common.GetClusterReq)
+
+	seeds, err := r.seedsGetter()
+	if err != nil {
+		return nil, nil, err
+	}
+	seed, exists := seeds[clusterReq.DC]
+	if !exists {
+		return nil, nil, errors.NewNotFound("datacenter", clusterReq.DC)
+	}
+	clusterProvider, err := r.clusterProviderGetter(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := r.privilegedProjectProvider.GetUnsecured(clusterReq.ProjectID, nil)
+	if err != nil {
+		return nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+	userCluster, err := clusterProvider.(provider.PrivilegedClusterProvider).GetUnsecured(project, clusterReq.ClusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+	return seed, userCluster, nil
+}
+
+func (r Routing) getStreamHandler(s stream) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithCancel(middleware.TokenExtractor(r.tokenExtractors)(req.Context(), req))
+		defer cancel()
+
+		request, err := s.decodeRequest(ctx, req)
+		if err != nil {
+			errorEncoder(ctx, err, w)
+			return
+		}
+
+		// the initial response is built before the connection gets upgraded,
+		// so that unauthorised requests get the same errors as from the REST API
+		response, err := s.endpoint(ctx, request)
+		if err != nil {
+			errorEncoder(ctx, err, w)
+			return
+		}
+
+		// changes are coalesced, the endpoint runs once for any number of changes that happened since its last run
+		changes := make(chan struct{}, 1)
+		unsubscribers, err := s.subscribe(req, func(interface{}) {
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		})
+		defer func() {
+			for _, unsubscribe := range unsubscribers {
+				unsubscribe()
+			}
+		}()
+		if err != nil {
+			errorEncoder(ctx, err, w)
+			return
+		}
+
+		ws, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			log.Logger.Debug(err)
+			return
+		}
+
+		go writeStream(ctx, ws, response, changes, func() (interface{}, error) {
+			return s.endpoint(ctx, request)
+		})
+		requestLoggingReader(ws)
+	}
+}
+
+// writeStream writes the initial response and a new one every time it changed. The stream is closed once
+// the endpoint fails, for example because the user lost access to the project or the cluster got deleted.
+func writeStream(ctx context.Context, ws *websocket.Conn, response interface{}, changes <-chan struct{}, refresh func() (interface{}, error)) {
+	last, err := marshalStreamResponse(response)
+	if err != nil {
+		log.Logger.Debug(err)
+		return
+	}
+	if err := ws.WriteMessage(websocket.TextMessage, last); err != nil {
+		log.Logger.Debug(err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changes:
+		}
+
+		response, err := refresh()
+		if err != nil {
+			closeStream(ws, err)
+			return
+		}
+
+		message, err := marshalStreamResponse(response)
+		if err != nil {
+			log.Logger.Debug(err)
+			return
+		}
+		if bytes.Equal(message, last) {
+			continue
+		}
+		if err := ws.WriteMessage(websocket.TextMessage, message); err != nil {
+			log.Logger.Debug(err)
+			return
+		}
+		last = message
+	}
+}
+
+// marshalStreamResponse encodes the response like encodeJSON does for the REST API
+func marshalStreamResponse(response interface{}) ([]byte, error) {
+	t := reflect.TypeOf(response)
+	if t != nil && t.Kind() == reflect.Slice && reflect.ValueOf(response).Len() == 0 {
+		return []byte("[]"), nil
+	}
+	if response == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(response)
+}
+
+// closeStream sends the error to the client and closes the connection
+func closeStream(ws *websocket.Conn, err error) {
+	message, marshalErr := json.Marshal(newErrorResponse(err))
+	if marshalErr != nil {
+		log.Logger.Debug(marshalErr)
+	} else if err := ws.WriteMessage(websocket.TextMessage, message); err != nil {
+		log.Logger.Debug(err)
+	}
+
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := ws.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second)); err != nil {
+		log.Logger.Debug(err)
+	}
 }
 
 func getProviders(r Routing) watcher.Providers {
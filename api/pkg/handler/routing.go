@@ -72,6 +72,11 @@ type Routing struct {
 	adminProvider                         provider.AdminProvider
 	admissionPluginProvider               provider.AdmissionPluginsProvider
 	settingsWatcher                       watcher.SettingsWatcher
+	clusterTemplateProvider               provider.ClusterTemplateProvider
+	seedProvider                          provider.SeedProvider
+	resourceWatchers                      watcher.ResourceWatchers
+	constraintTemplateProvider            provider.ConstraintTemplateProvider
+	constraintProvider                    provider.ConstraintProvider
 }
 
 // NewRouting creates a new Routing.
@@ -110,6 +115,11 @@ func NewRouting(
 	adminProvider provider.AdminProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
 	settingsWatcher watcher.SettingsWatcher,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	seedProvider provider.SeedProvider,
+	resourceWatchers watcher.ResourceWatchers,
+	constraintTemplateProvider provider.ConstraintTemplateProvider,
+	constraintProvider provider.ConstraintProvider,
 ) Routing {
 	return Routing{
 		log:                                   logger,
@@ -147,6 +157,11 @@ func NewRouting(
 		adminProvider:                         adminProvider,
 		admissionPluginProvider:               admissionPluginProvider,
 		settingsWatcher:                       settingsWatcher,
+		clusterTemplateProvider:               clusterTemplateProvider,
+		seedProvider:                          seedProvider,
+		resourceWatchers:                      resourceWatchers,
+		constraintTemplateProvider:            constraintTemplateProvider,
+		constraintProvider:                    constraintProvider,
 	}
 }
 
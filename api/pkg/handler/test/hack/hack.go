@@ -71,7 +71,12 @@ func NewTestRouting(
 	eventRecorderProvider provider.EventRecorderProvider,
 	presetsProvider provider.PresetProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
-	settingsWatcher watcher.SettingsWatcher) http.Handler {
+	settingsWatcher watcher.SettingsWatcher,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	seedProvider provider.SeedProvider,
+	resourceWatchers watcher.ResourceWatchers,
+	constraintTemplateProvider provider.ConstraintTemplateProvider,
+	constraintProvider provider.ConstraintProvider) http.Handler {
 
 	updateManager := version.New(versions, updates)
 	r := handler.NewRouting(
@@ -109,6 +114,11 @@ func NewTestRouting(
 		adminProvider,
 		admissionPluginProvider,
 		settingsWatcher,
+		clusterTemplateProvider,
+		seedProvider,
+		resourceWatchers,
+		constraintTemplateProvider,
+		constraintProvider,
 	)
 
 	mainRouter := mux.NewRouter()
@@ -121,6 +131,7 @@ func NewTestRouting(
 		mainRouter,
 	)
 	r.RegisterV1Admin(v1Router)
+	r.RegisterV1Websocket(v1Router)
 	return mainRouter
 }
 
@@ -164,7 +164,12 @@ type newRoutingFunc func(
 	eventRecorderProvider provider.EventRecorderProvider,
 	presetsProvider provider.PresetProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
-	settingsWatcher watcher.SettingsWatcher) http.Handler
+	settingsWatcher watcher.SettingsWatcher,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	seedProvider provider.SeedProvider,
+	resourceWatchers watcher.ResourceWatchers,
+	constraintTemplateProvider provider.ConstraintTemplateProvider,
+	constraintProvider provider.ConstraintProvider) http.Handler
 
 func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObjects, machineObjects, kubermaticObjects []runtime.Object, versions []*version.Version, updates []*version.Update, routingFunc newRoutingFunc) (http.Handler, *ClientsSets, error) {
 	if seedsGetter == nil {
@@ -242,7 +247,7 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		return nil, nil, err
 	}
 
-	fUserClusterConnection := &fakeUserClusterConnection{fakeClient}
+	fUserClusterConnection := &fakeUserClusterConnection{fakeClient, kubernetesClient}
 	clusterProvider := kubernetes.NewClusterProvider(
 		&restclient.Config{},
 		fakeImpersonationClient,
@@ -252,6 +257,7 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		fakeClient,
 		kubernetesClient,
 		false,
+		nil,
 	)
 	clusterProviders := map[string]provider.ClusterProvider{"us-central1": clusterProvider}
 	clusterProviderGetter := func(seed *kubermaticv1.Seed) (provider.ClusterProvider, error) {
@@ -279,6 +285,10 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		return nil, nil, err
 	}
 	admissionPluginProvider := kubernetes.NewAdmissionPluginsProvider(context.Background(), fakeClient)
+	clusterTemplateProvider := kubernetes.NewClusterTemplateProvider(context.Background(), fakeClient)
+	seedProvider := kubernetes.NewSeedProvider(context.Background(), fakeClient, "kubermatic")
+	constraintTemplateProvider := kubernetes.NewConstraintTemplateProvider(context.Background(), fakeClient)
+	constraintProvider := kubernetes.NewConstraintProvider(context.Background(), fakeClient)
 
 	seedClientGetter := func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
 		return fakeClient, nil
@@ -296,6 +306,11 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		return nil, nil, err
 	}
 
+	seedKubeconfigGetter := func(seed *kubermaticv1.Seed) (*restclient.Config, error) {
+		return nil, fmt.Errorf("watching seed %q is not supported in tests", seed.Name)
+	}
+	resourceWatchers := kuberneteswatcher.NewResourceWatchers(seedKubeconfigGetter, clusterProviderGetter)
+
 	// Disable the metrics endpoint in tests
 	var prometheusClient prometheusapi.Client
 
@@ -332,6 +347,11 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		credentialsManager,
 		admissionPluginProvider,
 		settingsWatcher,
+		clusterTemplateProvider,
+		seedProvider,
+		resourceWatchers,
+		constraintTemplateProvider,
+		constraintProvider,
 	)
 
 	return mainRouter, &ClientsSets{kubermaticClient, fakeClient, kubernetesClient, tokenAuth, tokenGenerator}, nil
@@ -446,13 +466,18 @@ func BuildSeeds() provider.SeedsGetter {
 }
 
 type fakeUserClusterConnection struct {
-	fakeDynamicClient ctrlruntimeclient.Client
+	fakeDynamicClient    ctrlruntimeclient.Client
+	fakeKubernetesClient kubernetesclientset.Interface
 }
 
 func (f *fakeUserClusterConnection) GetClient(_ *kubermaticv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
 	return f.fakeDynamicClient, nil
 }
 
+func (f *fakeUserClusterConnection) GetK8sClient(_ *kubermaticv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (kubernetesclientset.Interface, error) {
+	return f.fakeKubernetesClient, nil
+}
+
 // ClientsSets a simple wrapper that holds fake client sets
 type ClientsSets struct {
 	FakeKubermaticClient *kubermaticfakeclentset.Clientset
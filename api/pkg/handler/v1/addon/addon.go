@@ -177,7 +177,7 @@ func ListInstallableAddonEndpoint(projectProvider provider.ProjectProvider, priv
 			return nil, err
 		}
 
-		addons, err := listAddons(ctx, userInfoGetter, cluster, req.ProjectID)
+		addons, err := ListAddons(ctx, userInfoGetter, cluster, req.ProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -191,7 +191,8 @@ func ListInstallableAddonEndpoint(projectProvider provider.ProjectProvider, priv
 	}
 }
 
-func listAddons(ctx context.Context, userInfoGetter provider.UserInfoGetter, cluster *kubermaticapiv1.Cluster, projectID string) ([]*kubermaticapiv1.Addon, error) {
+// ListAddons returns the addons of the given cluster
+func ListAddons(ctx context.Context, userInfoGetter provider.UserInfoGetter, cluster *kubermaticapiv1.Cluster, projectID string) ([]*kubermaticapiv1.Addon, error) {
 	adminUserInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
 		return nil, err
@@ -255,7 +256,7 @@ func ListAddonEndpoint(projectProvider provider.ProjectProvider, privilegedProje
 			return nil, err
 		}
 
-		addons, err := listAddons(ctx, userInfoGetter, cluster, req.ProjectID)
+		addons, err := ListAddons(ctx, userInfoGetter, cluster, req.ProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -282,7 +283,7 @@ func CreateAddonEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		addon, err := createAddon(ctx, userInfoGetter, cluster, rawVars, req.ProjectID, req.Body.Name)
+		addon, err := CreateAddon(ctx, userInfoGetter, cluster, rawVars, req.ProjectID, req.Body.Name)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -295,7 +296,8 @@ func CreateAddonEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 	}
 }
 
-func createAddon(ctx context.Context, userInfoGetter provider.UserInfoGetter, cluster *kubermaticapiv1.Cluster, rawVars *runtime.RawExtension, projectID, name string) (*kubermaticapiv1.Addon, error) {
+// CreateAddon creates an addon in the given cluster
+func CreateAddon(ctx context.Context, userInfoGetter provider.UserInfoGetter, cluster *kubermaticapiv1.Cluster, rawVars *runtime.RawExtension, projectID, name string) (*kubermaticapiv1.Addon, error) {
 	adminUserInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
 		return nil, err
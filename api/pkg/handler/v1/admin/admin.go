@@ -19,6 +19,7 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
@@ -52,7 +53,7 @@ func GetAdminEndpoint(userInfoGetter provider.UserInfoGetter, adminProvider prov
 }
 
 // SetAdminEndpoint allows setting and clearing admin role for users
-func SetAdminEndpoint(userInfoGetter provider.UserInfoGetter, adminProvider provider.AdminProvider) endpoint.Endpoint {
+func SetAdminEndpoint(userInfoGetter provider.UserInfoGetter, adminProvider provider.AdminProvider, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(setAdminReq)
 		if !ok {
@@ -72,6 +73,12 @@ func SetAdminEndpoint(userInfoGetter provider.UserInfoGetter, adminProvider prov
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		// The kubeconfigs of admins grant access to every cluster
+		if !admin.Spec.IsAdmin {
+			if err := common.RevokeShortLivedKubeconfigs(clusterProviderGetter, seedsGetter, nil, admin.Spec.Email); err != nil {
+				return nil, k8cerrors.New(http.StatusInternalServerError, fmt.Sprintf("the admin role got removed but the kubeconfigs could not be revoked: %v", err))
+			}
+		}
 
 		return apiv1.Admin{
 			Email:   admin.Spec.Email,
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	k8cerrors "github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MigrateClusterEndpoint requests moving the control plane of a cluster to another seed.
+// The migration itself is done by the master-controller-manager.
+func MigrateClusterEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(migrateClusterReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+		seed, err := getSeed(ctx, req.seedReq, userInfoGetter, seedsGetter)
+		if err != nil {
+			return nil, err
+		}
+		seedMap, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		targetSeed, ok := seedMap[req.Body.TargetSeed]
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("target seed %q does not exist", req.Body.TargetSeed)
+		}
+
+		seedClient, err := seedClientGetter(seed)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		cluster := &kubermaticv1.Cluster{}
+		if err := seedClient.Get(ctx, types.NamespacedName{Name: req.ClusterID}, cluster); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		switch {
+		case cluster.DeletionTimestamp != nil:
+			return nil, k8cerrors.NewBadRequest("cluster %q is being deleted", cluster.Name)
+		case cluster.IsOpenshift():
+			return nil, k8cerrors.NewBadRequest("openshift clusters can not be migrated")
+		case cluster.Spec.Pause:
+			return nil, k8cerrors.NewBadRequest("cluster %q is paused", cluster.Name)
+		case cluster.Spec.Migration != nil && (cluster.Status.Migration == nil || !cluster.Status.Migration.IsFinished()):
+			return nil, k8cerrors.New(http.StatusConflict, fmt.Sprintf("cluster %q is already being migrated to seed %q", cluster.Name, cluster.Spec.Migration.TargetSeed))
+		}
+
+		targetDatacenter := req.Body.TargetDatacenter
+		if targetDatacenter == "" {
+			targetDatacenter = cluster.Spec.Cloud.DatacenterName
+		}
+		if err := validateMigrationDatacenter(seed, targetSeed, cluster.Spec.Cloud.DatacenterName, targetDatacenter); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Migration = &kubermaticv1.ClusterMigrationSpec{
+			TargetSeed:       targetSeed.Name,
+			TargetDatacenter: targetDatacenter,
+		}
+		// Drop the result of a previous, failed attempt
+		cluster.Status.Migration = nil
+		if err := seedClient.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// validateMigrationDatacenter makes sure the cluster ends up in a datacenter of the target seed
+// that uses the same cloud provider as its current one.
+func validateMigrationDatacenter(sourceSeed, targetSeed *kubermaticv1.Seed, sourceDatacenter, targetDatacenter string) error {
+	targetDC, ok := targetSeed.Spec.Datacenters[targetDatacenter]
+	if !ok {
+		return fmt.Errorf("datacenter %q does not exist in seed %q", targetDatacenter, targetSeed.Name)
+	}
+	sourceDC, ok := sourceSeed.Spec.Datacenters[sourceDatacenter]
+	if !ok {
+		return fmt.Errorf("datacenter %q of the cluster does not exist in seed %q", sourceDatacenter, sourceSeed.Name)
+	}
+
+	targetProvider, err := provider.DatacenterCloudProviderName(&targetDC.Spec)
+	if err != nil {
+		return err
+	}
+	sourceProvider, err := provider.DatacenterCloudProviderName(&sourceDC.Spec)
+	if err != nil {
+		return err
+	}
+	if targetProvider != sourceProvider {
+		return fmt.Errorf("datacenter %q uses provider %q, but the cluster uses %q", targetDatacenter, targetProvider, sourceProvider)
+	}
+	return nil
+}
+
+// migrateClusterReq defines HTTP request for migrateCluster
+// swagger:parameters migrateCluster
+type migrateClusterReq struct {
+	seedReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: body
+	Body struct {
+		// TargetSeed is the seed the control plane is moved to
+		TargetSeed string `json:"targetSeed"`
+		// TargetDatacenter is the datacenter of the target seed the cluster belongs to afterwards.
+		// It defaults to the current datacenter of the cluster.
+		TargetDatacenter string `json:"targetDatacenter,omitempty"`
+	}
+}
+
+// Validate validates MigrateClusterEndpoint request
+func (r migrateClusterReq) Validate() error {
+	if r.Body.TargetSeed == "" {
+		return fmt.Errorf("the target seed is required")
+	}
+	if r.Body.TargetSeed == r.Name {
+		return fmt.Errorf("cluster %q is already in seed %q", r.ClusterID, r.Name)
+	}
+	return nil
+}
+
+func DecodeMigrateClusterReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req migrateClusterReq
+	seedName, err := DecodeSeedReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.seedReq = seedName.(seedReq)
+
+	clusterID := mux.Vars(r)["cluster_id"]
+	if clusterID == "" {
+		return nil, fmt.Errorf("'cluster_id' parameter is required but was not provided")
+	}
+	req.ClusterID = clusterID
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMigrateClusterEndpoint(t *testing.T) {
+	t.Parallel()
+
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		return map[string]*kubermaticv1.Seed{
+			"us-central1": test.GenTestSeed(),
+			"europe-west3": {
+				ObjectMeta: metav1.ObjectMeta{Name: "europe-west3"},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"fake-dc-eu": {Spec: kubermaticv1.DatacenterSpec{Fake: &kubermaticv1.DatacenterSpecFake{}}},
+						"do-eu":      {Spec: kubermaticv1.DatacenterSpec{Digitalocean: &kubermaticv1.DatacenterSpecDigitalocean{Region: "fra1"}}},
+					},
+				},
+			},
+		}, nil
+	}
+	genCluster := func(modifiers ...func(*kubermaticv1.Cluster)) *kubermaticv1.Cluster {
+		cluster := test.GenDefaultCluster()
+		cluster.Spec.Cloud.DatacenterName = "fake-dc"
+		for _, modifier := range modifiers {
+			modifier(cluster)
+		}
+		return cluster
+	}
+
+	testcases := []struct {
+		name                   string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+		expectedMigration      *kubermaticv1.ClusterMigrationSpec
+	}{
+		{
+			name:                   "scenario 1: not authorized user can not migrate clusters",
+			body:                   `{"targetSeed":"europe-west3","targetDatacenter":"fake-dc-eu"}`,
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{genCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 2: target seed must exist",
+			body:                   `{"targetSeed":"asia-east1","targetDatacenter":"fake-dc-eu"}`,
+			expectedResponse:       `{"error":{"code":400,"message":"target seed \"asia-east1\" does not exist"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 3: target datacenter must use the same provider",
+			body:                   `{"targetSeed":"europe-west3","targetDatacenter":"do-eu"}`,
+			expectedResponse:       `{"error":{"code":400,"message":"datacenter \"do-eu\" uses provider \"digitalocean\", but the cluster uses \"fake\""}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 4: target datacenter defaults to the current one",
+			body:                   `{"targetSeed":"europe-west3"}`,
+			expectedResponse:       `{"error":{"code":400,"message":"datacenter \"fake-dc\" does not exist in seed \"europe-west3\""}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:             "scenario 5: cluster can not be migrated twice at the same time",
+			body:             `{"targetSeed":"europe-west3","targetDatacenter":"fake-dc-eu"}`,
+			expectedResponse: `{"error":{"code":409,"message":"cluster \"defClusterID\" is already being migrated to seed \"europe-west3\""}}`,
+			httpStatus:       http.StatusConflict,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.Migration = &kubermaticv1.ClusterMigrationSpec{TargetSeed: "europe-west3", TargetDatacenter: "fake-dc-eu"}
+				c.Status.Migration = &kubermaticv1.ClusterMigrationStatus{Phase: kubermaticv1.ClusterMigrationPhaseSnapshottingEtcd}
+			})},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 6: admin migrates a cluster",
+			body:                   `{"targetSeed":"europe-west3","targetDatacenter":"fake-dc-eu"}`,
+			expectedResponse:       `{}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+			expectedMigration:      &kubermaticv1.ClusterMigrationSpec{TargetSeed: "europe-west3", TargetDatacenter: "fake-dc-eu"},
+		},
+		{
+			name:             "scenario 7: a failed migration can be retried",
+			body:             `{"targetSeed":"europe-west3","targetDatacenter":"fake-dc-eu"}`,
+			expectedResponse: `{}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genCluster(func(c *kubermaticv1.Cluster) {
+				c.Spec.Migration = &kubermaticv1.ClusterMigrationSpec{TargetSeed: "europe-west3", TargetDatacenter: "fake-dc-eu"}
+				c.Status.Migration = &kubermaticv1.ClusterMigrationStatus{Phase: kubermaticv1.ClusterMigrationPhaseFailed}
+			})},
+			existingAPIUser:   test.GenDefaultAPIUser(),
+			expectedMigration: &kubermaticv1.ClusterMigrationSpec{TargetSeed: "europe-west3", TargetDatacenter: "fake-dc-eu"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/admin/seeds/us-central1/clusters/%s/migrate", test.DefaultClusterID), strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, seedsGetter, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+
+			if tc.expectedMigration == nil {
+				return
+			}
+			cluster := &kubermaticv1.Cluster{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: test.DefaultClusterID}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if cluster.Spec.Migration == nil || *cluster.Spec.Migration != *tc.expectedMigration {
+				t.Errorf("expected migration request %+v, got %+v", tc.expectedMigration, cluster.Spec.Migration)
+			}
+			if cluster.Status.Migration != nil {
+				t.Errorf("expected migration status to be reset, got %+v", cluster.Status.Migration)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	k8cerrors "github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ListPresetsEndpoint returns all presets, including the disabled ones
+func ListPresetsEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		presets, err := presetsProvider.ListPresets(userInfo)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		resultList := []apiv1.Preset{}
+		for _, preset := range presets {
+			resultList = append(resultList, convertPreset(preset))
+		}
+		sort.Slice(resultList, func(i, j int) bool { return resultList[i].Name < resultList[j].Name })
+		return resultList, nil
+	}
+}
+
+// CreatePresetEndpoint creates the preset
+func CreatePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createPresetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !userInfo.IsAdmin {
+			return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", userInfo.Email))
+		}
+		if errs := validation.IsDNS1123Subdomain(req.Body.Name); len(errs) > 0 {
+			return nil, k8cerrors.NewBadRequest("invalid preset name %q: %v", req.Body.Name, errs)
+		}
+		if err := validatePresetSpec(req.Body.Spec, privilegedProjectProvider); err != nil {
+			return nil, err
+		}
+
+		preset, err := presetsProvider.CreatePreset(userInfo, &kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Body.Name},
+			Spec:       req.Body.Spec,
+		})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertPreset(*preset), nil
+	}
+}
+
+// UpdatePresetEndpoint replaces the spec of the preset
+func UpdatePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(updatePresetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if req.Name != req.Body.Name {
+			return nil, k8cerrors.NewBadRequest("preset name mismatch, you requested to update Preset = %s but body contains Preset = %s", req.Name, req.Body.Name)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !userInfo.IsAdmin {
+			return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", userInfo.Email))
+		}
+		if err := validatePresetSpec(req.Body.Spec, privilegedProjectProvider); err != nil {
+			return nil, err
+		}
+		existing, err := getPreset(userInfo, presetsProvider, req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// the secrets are never returned by the API, keep the existing ones unless new ones are given
+		spec := req.Body.Spec
+		keepPresetSecrets(&spec, &existing.Spec)
+
+		preset, err := presetsProvider.UpdatePreset(userInfo, &kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+			Spec:       spec,
+		})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertPreset(*preset), nil
+	}
+}
+
+// UpdatePresetStatusEndpoint enables or disables the preset
+func UpdatePresetStatusEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(updatePresetStatusReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		preset, err := getPreset(userInfo, presetsProvider, req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		enabled := req.Body.Enabled
+		preset.Spec.Enabled = &enabled
+		preset, err = presetsProvider.UpdatePreset(userInfo, preset)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertPreset(*preset), nil
+	}
+}
+
+// DeletePresetEndpoint deletes the preset
+func DeletePresetEndpoint(userInfoGetter provider.UserInfoGetter, presetsProvider provider.PresetProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(presetReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if err := presetsProvider.DeletePreset(userInfo, req.Name); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return nil, nil
+	}
+}
+
+// getPreset returns the preset with the given name, regardless of whether it is enabled or not
+func getPreset(userInfo *provider.UserInfo, presetsProvider provider.PresetProvider, name string) (*kubermaticv1.Preset, error) {
+	presets, err := presetsProvider.ListPresets(userInfo)
+	if err != nil {
+		return nil, err
+	}
+	for _, preset := range presets {
+		if preset.Name == name {
+			return &preset, nil
+		}
+	}
+	return nil, kerrors.NewNotFound(kubermaticv1.Resource("preset"), name)
+}
+
+// validatePresetSpec checks that the preset contains credentials for at least one provider
+// and that the projects it is restricted to exist
+func validatePresetSpec(spec kubermaticv1.PresetSpec, privilegedProjectProvider provider.PrivilegedProjectProvider) error {
+	hasCredentials := false
+	specValue := reflect.ValueOf(spec)
+	for i := 0; i < specValue.NumField(); i++ {
+		field := specValue.Field(i)
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct && !field.IsNil() {
+			hasCredentials = true
+			break
+		}
+	}
+	if !hasCredentials {
+		return k8cerrors.NewBadRequest("the preset must contain the credentials of at least one provider")
+	}
+
+	for _, projectID := range spec.Projects {
+		if _, err := privilegedProjectProvider.GetUnsecured(projectID, nil); err != nil {
+			if kerrors.IsNotFound(err) {
+				return k8cerrors.NewBadRequest("project %q does not exist", projectID)
+			}
+			return common.KubernetesErrorToHTTPError(err)
+		}
+	}
+	return nil
+}
+
+// presetReq defines HTTP request for deletePreset
+// swagger:parameters deletePreset
+type presetReq struct {
+	// in: path
+	// required: true
+	Name string `json:"preset_name"`
+}
+
+// createPresetReq defines HTTP request for createPreset
+// swagger:parameters createPreset
+type createPresetReq struct {
+	// in: body
+	Body apiv1.Preset
+}
+
+// updatePresetReq defines HTTP request for updatePreset
+// swagger:parameters updatePreset
+type updatePresetReq struct {
+	presetReq
+	// in: body
+	Body apiv1.Preset
+}
+
+// updatePresetStatusReq defines HTTP request for updatePresetStatus
+// swagger:parameters updatePresetStatus
+type updatePresetStatusReq struct {
+	presetReq
+	// in: body
+	Body struct {
+		// Enabled defines whether the preset can be used to create clusters
+		Enabled bool `json:"enabled"`
+	}
+}
+
+func DecodePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	name := mux.Vars(r)["preset_name"]
+	if name == "" {
+		return nil, fmt.Errorf("'preset_name' parameter is required but was not provided")
+	}
+	return presetReq{Name: name}, nil
+}
+
+func DecodeCreatePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createPresetReq
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the request body: %v", err)
+	}
+	return req, nil
+}
+
+func DecodeUpdatePresetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updatePresetReq
+	nameReq, err := DecodePresetReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.presetReq = nameReq.(presetReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the request body: %v", err)
+	}
+	return req, nil
+}
+
+func DecodeUpdatePresetStatusReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updatePresetStatusReq
+	nameReq, err := DecodePresetReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.presetReq = nameReq.(presetReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the request body: %v", err)
+	}
+	return req, nil
+}
+
+// presetSecrets returns the secret fields of the provider credentials in the spec, keyed by provider and field
+func presetSecrets(spec *kubermaticv1.PresetSpec) map[string]*string {
+	secrets := map[string]*string{}
+	if spec.Digitalocean != nil {
+		secrets["digitalocean.token"] = &spec.Digitalocean.Token
+	}
+	if spec.Hetzner != nil {
+		secrets["hetzner.token"] = &spec.Hetzner.Token
+	}
+	if spec.Azure != nil {
+		secrets["azure.clientSecret"] = &spec.Azure.ClientSecret
+	}
+	if spec.VSphere != nil {
+		secrets["vsphere.password"] = &spec.VSphere.Password
+	}
+	if spec.AWS != nil {
+		secrets["aws.secretAccessKey"] = &spec.AWS.SecretAccessKey
+	}
+	if spec.Openstack != nil {
+		secrets["openstack.password"] = &spec.Openstack.Password
+	}
+	if spec.Packet != nil {
+		secrets["packet.apiKey"] = &spec.Packet.APIKey
+	}
+	if spec.GCP != nil {
+		secrets["gcp.serviceAccount"] = &spec.GCP.ServiceAccount
+	}
+	if spec.Fake != nil {
+		secrets["fake.token"] = &spec.Fake.Token
+	}
+	if spec.Kubevirt != nil {
+		secrets["kubevirt.kubeconfig"] = &spec.Kubevirt.Kubeconfig
+	}
+	if spec.Alibaba != nil {
+		secrets["alibaba.accessKeySecret"] = &spec.Alibaba.AccessKeySecret
+	}
+	return secrets
+}
+
+// keepPresetSecrets copies the secrets of the existing spec into the empty secret fields of the new spec
+func keepPresetSecrets(spec, existing *kubermaticv1.PresetSpec) {
+	existingSecrets := presetSecrets(existing)
+	for key, secret := range presetSecrets(spec) {
+		if existingSecret, ok := existingSecrets[key]; ok && *secret == "" {
+			*secret = *existingSecret
+		}
+	}
+}
+
+// convertPreset converts the preset to its API representation, the secrets are redacted
+func convertPreset(preset kubermaticv1.Preset) apiv1.Preset {
+	spec := preset.Spec.DeepCopy()
+	for _, secret := range presetSecrets(spec) {
+		*secret = ""
+	}
+	return apiv1.Preset{
+		Name: preset.Name,
+		Spec: *spec,
+	}
+}
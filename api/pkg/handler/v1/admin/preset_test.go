@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func genPreset(name string, projects ...string) *kubermaticv1.Preset {
+	return &kubermaticv1.Preset{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.PresetSpec{
+			Projects: projects,
+			Fake:     &kubermaticv1.Fake{Token: "token"},
+		},
+	}
+}
+
+func TestPresetEndpoints(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		method                 string
+		url                    string
+		body                   string
+		expectedResponse       string
+		expectedToken          string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		{
+			name:                   "scenario 1: not authorized user can't list presets",
+			method:                 http.MethodGet,
+			url:                    "/api/v1/admin/presets",
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", false)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 2: admin lists all presets",
+			method:                 http.MethodGet,
+			url:                    "/api/v1/admin/presets",
+			expectedResponse:       `[{"name":"a","spec":{"fake":{"token":""}}},{"name":"b","spec":{"fake":{"token":""},"projects":["my-first-project-ID"]}}]`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genPreset("b", test.GenDefaultProject().Name), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 3: admin creates a preset restricted to a project",
+			method:                 http.MethodPost,
+			url:                    "/api/v1/admin/presets",
+			body:                   `{"name":"customer","spec":{"aws":{"accessKeyId":"id","secretAccessKey":"secret"},"projects":["my-first-project-ID"]}}`,
+			expectedResponse:       `{"name":"customer","spec":{"aws":{"accessKeyId":"id","secretAccessKey":""},"projects":["my-first-project-ID"]}}`,
+			httpStatus:             http.StatusCreated,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), test.GenDefaultProject()},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 4: a preset restricted to an unknown project is rejected",
+			method:                 http.MethodPost,
+			url:                    "/api/v1/admin/presets",
+			body:                   `{"name":"customer","spec":{"aws":{"accessKeyId":"id","secretAccessKey":"secret"},"projects":["unknown"]}}`,
+			expectedResponse:       `{"error":{"code":400,"message":"project \"unknown\" does not exist"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 5: a preset without credentials is rejected",
+			method:                 http.MethodPost,
+			url:                    "/api/v1/admin/presets",
+			body:                   `{"name":"empty","spec":{"requiredEmailDomain":"acme.com"}}`,
+			expectedResponse:       `{"error":{"code":400,"message":"the preset must contain the credentials of at least one provider"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 6: admin updates a preset",
+			method:                 http.MethodPut,
+			url:                    "/api/v1/admin/presets/a",
+			body:                   `{"name":"a","spec":{"fake":{"token":"new"},"requiredEmailDomain":"acme.com"}}`,
+			expectedResponse:       `{"name":"a","spec":{"fake":{"token":""},"requiredEmailDomain":"acme.com"}}`,
+			expectedToken:          "new",
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 7: admin updates a preset without resending its secrets",
+			method:                 http.MethodPut,
+			url:                    "/api/v1/admin/presets/a",
+			body:                   `{"name":"a","spec":{"fake":{"token":""},"requiredEmailDomain":"acme.com"}}`,
+			expectedResponse:       `{"name":"a","spec":{"fake":{"token":""},"requiredEmailDomain":"acme.com"}}`,
+			expectedToken:          "token",
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 8: admin disables a preset",
+			method:                 http.MethodPatch,
+			url:                    "/api/v1/admin/presets/a/status",
+			body:                   `{"enabled":false}`,
+			expectedResponse:       `{"name":"a","spec":{"fake":{"token":""},"enabled":false}}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 9: not authorized user can't delete a preset",
+			method:                 http.MethodDelete,
+			url:                    "/api/v1/admin/presets/a",
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", false), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			name:                   "scenario 10: admin deletes a preset",
+			method:                 http.MethodDelete,
+			url:                    "/api/v1/admin/presets/a",
+			expectedResponse:       `{}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genPreset("a")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+
+			if tc.expectedToken != "" {
+				preset := &kubermaticv1.Preset{}
+				if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: "a"}, preset); err != nil {
+					t.Fatalf("failed to get preset: %v", err)
+				}
+				if preset.Spec.Fake.Token != tc.expectedToken {
+					t.Fatalf("expected token %q, got %q", tc.expectedToken, preset.Spec.Fake.Token)
+				}
+			}
+		})
+	}
+}
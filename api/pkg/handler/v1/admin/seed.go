@@ -21,8 +21,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,11 +38,13 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/dc"
 	"github.com/kubermatic/kubermatic/api/pkg/log"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	kubernetesprovider "github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
 	k8cerrors "github.com/kubermatic/kubermatic/api/pkg/util/errors"
+	seedvalidation "github.com/kubermatic/kubermatic/api/pkg/validation/seed"
 )
 
 // ListSeedsEndpoint returns seed list
-func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
@@ -56,8 +61,10 @@ func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provid
 
 		for key, value := range seedMap {
 			resultList = append(resultList, apiv1.Seed{
-				Name:     key,
-				SeedSpec: convertSeedSpec(value.Spec, key),
+				Name:        key,
+				SeedSpec:    convertSeedSpec(value.Spec, key),
+				Utilization: getSeedUtilization(ctx, value, seedClientGetter),
+				Status:      convertSeedStatus(value.Status),
 			})
 		}
 
@@ -66,7 +73,7 @@ func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provid
 }
 
 // GetSeedEndpoint returns seed element
-func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(seedReq)
 		if !ok {
@@ -77,8 +84,10 @@ func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provide
 			return nil, err
 		}
 		return apiv1.Seed{
-			Name:     req.Name,
-			SeedSpec: convertSeedSpec(seed.Spec, req.Name),
+			Name:        req.Name,
+			SeedSpec:    convertSeedSpec(seed.Spec, req.Name),
+			Utilization: getSeedUtilization(ctx, seed, seedClientGetter),
+			Status:      convertSeedStatus(seed.Status),
 		}, nil
 	}
 }
@@ -116,6 +125,77 @@ func UpdateSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter prov
 	}
 }
 
+// CreateSeedEndpoint registers a new seed. The kubeconfig gets stored in a Secret, the seed is validated
+// the same way the seed admission webhook does and then created, so the seed-sync controller and the
+// operator can bootstrap the seed cluster.
+func CreateSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter, seedProvider provider.SeedProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createSeedReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !userInfo.IsAdmin {
+			return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", userInfo.Email))
+		}
+
+		validate := func(seed *kubermaticv1.Seed) error {
+			seedClient, err := seedClientGetter(seed)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the seed cluster: %v", err)
+			}
+			existingSeeds, err := seedsGetter()
+			if err != nil {
+				return fmt.Errorf("failed to get seeds: %v", err)
+			}
+			return seedvalidation.ValidateSeed(ctx, seed, seedClient, existingSeeds)
+		}
+
+		seed := &kubermaticv1.Seed{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Body.Name},
+			Spec:       req.Body.Spec,
+		}
+		seed, err = seedProvider.CreateSeed(userInfo, seed, []byte(req.Body.Kubeconfig), validate)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.Seed{
+			Name:     seed.Name,
+			SeedSpec: convertSeedSpec(seed.Spec, seed.Name),
+		}
+		if seedClient, err := seedClientGetter(seed); err == nil {
+			result.Bootstrap = convertSeedBootstrapStatus(kubernetesprovider.GetSeedBootstrapStatus(ctx, seedClient, seed))
+		}
+		return result, nil
+	}
+}
+
+// GetSeedBootstrapStatusEndpoint reports how far the bootstrapping of a seed has progressed
+func GetSeedBootstrapStatusEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(seedReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		seed, err := getSeed(ctx, req, userInfoGetter, seedsGetter)
+		if err != nil {
+			return nil, err
+		}
+		seedClient, err := seedClientGetter(seed)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertSeedBootstrapStatus(kubernetesprovider.GetSeedBootstrapStatus(ctx, seedClient, seed)), nil
+	}
+}
+
 // DeleteSeedEndpoint deletes seed CRD element with the given name from the Kubermatic
 func DeleteSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -160,7 +240,7 @@ func getSeed(ctx context.Context, req seedReq, userInfoGetter provider.UserInfoG
 }
 
 // seedReq defines HTTP request for getSeed
-// swagger:parameters getSeed deleteSeed
+// swagger:parameters getSeed deleteSeed getSeedBootstrapStatus
 type seedReq struct {
 	// in: path
 	// required: true
@@ -194,6 +274,41 @@ func DecodeUpdateSeedReq(c context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+// createSeedReq defines HTTP request for createSeed
+// swagger:parameters createSeed
+type createSeedReq struct {
+	// in: body
+	Body struct {
+		Name string `json:"name"`
+		// Kubeconfig is the kubeconfig of the seed cluster, it must have cluster-admin privileges.
+		// It is stored in a Secret the seed refers to, so spec.kubeconfig is ignored.
+		Kubeconfig string `json:"kubeconfig"`
+
+		Spec kubermaticv1.SeedSpec `json:"spec"`
+	}
+}
+
+func DecodeCreateSeedReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createSeedReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the body: %v", err)
+	}
+
+	return req, nil
+}
+
+// Validate validates CreateSeedEndpoint request
+func (r createSeedReq) Validate() error {
+	if errs := validation.IsDNS1123Subdomain(r.Body.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid seed name %q: %s", r.Body.Name, strings.Join(errs, ", "))
+	}
+	if len(r.Body.Kubeconfig) == 0 {
+		return fmt.Errorf("the kubeconfig of the seed cluster is required")
+	}
+	return nil
+}
+
 func DecodeSeedReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req seedReq
 	name := mux.Vars(r)["seed_name"]
@@ -213,6 +328,61 @@ func (r updateSeedReq) Validate() error {
 	return nil
 }
 
+// getSeedUtilization returns the current load of the seed, unreachable seeds
+// are reported without utilization
+func getSeedUtilization(ctx context.Context, seed *kubermaticv1.Seed, seedClientGetter provider.SeedClientGetter) *apiv1.SeedUtilization {
+	seedClient, err := seedClientGetter(seed)
+	if err != nil {
+		log.Logger.Warnf("failed to get client for seed %q: %v", seed.Name, err)
+		return nil
+	}
+	utilization, err := kubernetesprovider.GetSeedUtilization(ctx, seedClient)
+	if err != nil {
+		log.Logger.Warnf("failed to get utilization of seed %q: %v", seed.Name, err)
+		return nil
+	}
+
+	result := &apiv1.SeedUtilization{
+		Healthy:           utilization.Healthy(),
+		Clusters:          utilization.Clusters,
+		Nodes:             utilization.Nodes,
+		AllocatableCPU:    utilization.AllocatableCPU.String(),
+		AllocatableMemory: utilization.AllocatableMemory.String(),
+		Datacenters:       map[string]apiv1.DatacenterUtilization{},
+	}
+	for name, datacenter := range seed.Spec.Datacenters {
+		dcUtilization := apiv1.DatacenterUtilization{Clusters: utilization.DatacenterClusters[name]}
+		if datacenter.Spec.Placement != nil {
+			dcUtilization.MaxClusters = datacenter.Spec.Placement.MaxClustersPerSeed
+		}
+		result.Datacenters[name] = dcUtilization
+	}
+
+	return result
+}
+
+func convertSeedBootstrapStatus(status *kubernetesprovider.SeedBootstrapStatus) *apiv1.SeedBootstrapStatus {
+	result := &apiv1.SeedBootstrapStatus{
+		Done:  status.Done(),
+		Steps: []apiv1.SeedBootstrapStep{},
+	}
+	for _, step := range status.Steps {
+		result.Steps = append(result.Steps, apiv1.SeedBootstrapStep{
+			Name:    step.Name,
+			Done:    step.Done,
+			Message: step.Message,
+		})
+	}
+	return result
+}
+
+func convertSeedStatus(status kubermaticv1.SeedStatus) *kubermaticv1.SeedStatus {
+	if status.LastHeartbeatTime.IsZero() {
+		return nil
+	}
+	return status.DeepCopy()
+}
+
 func convertSeedSpec(seedSpec kubermaticv1.SeedSpec, seedName string) apiv1.SeedSpec {
 	resultSeedSpec := apiv1.SeedSpec{
 		Country:  seedSpec.Country,
@@ -17,17 +17,25 @@ limitations under the License.
 package admin_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestListSeedsEndpoint(t *testing.T) {
@@ -50,7 +58,7 @@ func TestListSeedsEndpoint(t *testing.T) {
 		// scenario 2
 		{
 			name:                   "scenario 2: authorized user gets default list",
-			expectedResponse:       `[{"name":"us-central1","spec":{"country":"US","location":"us-central","kubeconfig":{},"datacenters":{"audited-dc":{"metadata":{"name":"audited-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Finanzamt Castle","provider":"fake","fake":{},"node":{},"enforceAuditLogging":true,"enforcePodSecurityPolicy":false}},"fake-dc":{"metadata":{"name":"fake-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Henriks basement","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"node-dc":{"metadata":{"name":"node-dc"},"spec":{"seed":"us-central1","country":"Chile","location":"Santiago","provider":"fake","fake":{},"node":{"http_proxy":"HTTPProxy","insecure_registries":["incsecure-registry"],"pause_image":"pause-image","hyperkube_image":"hyperkube-image"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"private-do1":{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"psp-dc":{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"regular-do1":{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc":{"metadata":{"name":"restricted-fake-dc"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomain":"example.com","enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc2":{"metadata":{"name":"restricted-fake-dc2"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomains":["23f67weuc.com","example.com","12noifsdsd.org"],"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}}}]`,
+			expectedResponse:       `[{"name":"us-central1","spec":{"country":"US","location":"us-central","kubeconfig":{},"datacenters":{"audited-dc":{"metadata":{"name":"audited-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Finanzamt Castle","provider":"fake","fake":{},"node":{},"enforceAuditLogging":true,"enforcePodSecurityPolicy":false}},"fake-dc":{"metadata":{"name":"fake-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Henriks basement","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"node-dc":{"metadata":{"name":"node-dc"},"spec":{"seed":"us-central1","country":"Chile","location":"Santiago","provider":"fake","fake":{},"node":{"http_proxy":"HTTPProxy","insecure_registries":["incsecure-registry"],"pause_image":"pause-image","hyperkube_image":"hyperkube-image"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"private-do1":{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"psp-dc":{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"regular-do1":{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc":{"metadata":{"name":"restricted-fake-dc"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomain":"example.com","enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc2":{"metadata":{"name":"restricted-fake-dc2"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomains":["23f67weuc.com","example.com","12noifsdsd.org"],"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}},"utilization":{"healthy":false,"clusters":0,"nodes":0,"allocatableCPU":"0","allocatableMemory":"0","datacenters":{"audited-dc":{"clusters":0},"fake-dc":{"clusters":0},"node-dc":{"clusters":0},"private-do1":{"clusters":0},"psp-dc":{"clusters":0},"regular-do1":{"clusters":0},"restricted-fake-dc":{"clusters":0},"restricted-fake-dc2":{"clusters":0}}}}]`,
 			httpStatus:             http.StatusOK,
 			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
 			existingAPIUser:        test.GenDefaultAPIUser(),
@@ -89,6 +97,7 @@ func TestGetSeedEndpoint(t *testing.T) {
 		expectedResponse       string
 		httpStatus             int
 		existingAPIUser        *apiv1.User
+		existingKubeObjs       []runtime.Object
 		existingKubermaticObjs []runtime.Object
 	}{
 		// scenario 1
@@ -113,17 +122,43 @@ func TestGetSeedEndpoint(t *testing.T) {
 		{
 			name:                   "scenario 3: authorized user gets seed",
 			seedName:               "us-central1",
-			expectedResponse:       `{"name":"us-central1","spec":{"country":"US","location":"us-central","kubeconfig":{},"datacenters":{"audited-dc":{"metadata":{"name":"audited-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Finanzamt Castle","provider":"fake","fake":{},"node":{},"enforceAuditLogging":true,"enforcePodSecurityPolicy":false}},"fake-dc":{"metadata":{"name":"fake-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Henriks basement","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"node-dc":{"metadata":{"name":"node-dc"},"spec":{"seed":"us-central1","country":"Chile","location":"Santiago","provider":"fake","fake":{},"node":{"http_proxy":"HTTPProxy","insecure_registries":["incsecure-registry"],"pause_image":"pause-image","hyperkube_image":"hyperkube-image"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"private-do1":{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"psp-dc":{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"regular-do1":{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc":{"metadata":{"name":"restricted-fake-dc"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomain":"example.com","enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc2":{"metadata":{"name":"restricted-fake-dc2"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomains":["23f67weuc.com","example.com","12noifsdsd.org"],"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}}}`,
+			expectedResponse:       `{"name":"us-central1","spec":{"country":"US","location":"us-central","kubeconfig":{},"datacenters":{"audited-dc":{"metadata":{"name":"audited-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Finanzamt Castle","provider":"fake","fake":{},"node":{},"enforceAuditLogging":true,"enforcePodSecurityPolicy":false}},"fake-dc":{"metadata":{"name":"fake-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Henriks basement","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"node-dc":{"metadata":{"name":"node-dc"},"spec":{"seed":"us-central1","country":"Chile","location":"Santiago","provider":"fake","fake":{},"node":{"http_proxy":"HTTPProxy","insecure_registries":["incsecure-registry"],"pause_image":"pause-image","hyperkube_image":"hyperkube-image"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"private-do1":{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"psp-dc":{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"regular-do1":{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc":{"metadata":{"name":"restricted-fake-dc"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomain":"example.com","enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc2":{"metadata":{"name":"restricted-fake-dc2"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomains":["23f67weuc.com","example.com","12noifsdsd.org"],"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}},"utilization":{"healthy":false,"clusters":0,"nodes":0,"allocatableCPU":"0","allocatableMemory":"0","datacenters":{"audited-dc":{"clusters":0},"fake-dc":{"clusters":0},"node-dc":{"clusters":0},"private-do1":{"clusters":0},"psp-dc":{"clusters":0},"regular-do1":{"clusters":0},"restricted-fake-dc":{"clusters":0},"restricted-fake-dc2":{"clusters":0}}}}`,
 			httpStatus:             http.StatusOK,
 			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
 			existingAPIUser:        test.GenDefaultAPIUser(),
 		},
+		// scenario 4
+		{
+			name:             "scenario 4: seed utilization is reported",
+			seedName:         "us-central1",
+			expectedResponse: `{"name":"us-central1","spec":{"country":"US","location":"us-central","kubeconfig":{},"datacenters":{"audited-dc":{"metadata":{"name":"audited-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Finanzamt Castle","provider":"fake","fake":{},"node":{},"enforceAuditLogging":true,"enforcePodSecurityPolicy":false}},"fake-dc":{"metadata":{"name":"fake-dc"},"spec":{"seed":"us-central1","country":"Germany","location":"Henriks basement","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"node-dc":{"metadata":{"name":"node-dc"},"spec":{"seed":"us-central1","country":"Chile","location":"Santiago","provider":"fake","fake":{},"node":{"http_proxy":"HTTPProxy","insecure_registries":["incsecure-registry"],"pause_image":"pause-image","hyperkube_image":"hyperkube-image"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"private-do1":{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"psp-dc":{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},"regular-do1":{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc":{"metadata":{"name":"restricted-fake-dc"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomain":"example.com","enforceAuditLogging":false,"enforcePodSecurityPolicy":false}},"restricted-fake-dc2":{"metadata":{"name":"restricted-fake-dc2"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"fake","fake":{},"node":{},"requiredEmailDomains":["23f67weuc.com","example.com","12noifsdsd.org"],"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}},"utilization":{"healthy":true,"clusters":1,"nodes":1,"allocatableCPU":"4","allocatableMemory":"8Gi","datacenters":{"audited-dc":{"clusters":0},"fake-dc":{"clusters":1},"node-dc":{"clusters":0},"private-do1":{"clusters":0},"psp-dc":{"clusters":0},"regular-do1":{"clusters":0},"restricted-fake-dc":{"clusters":0},"restricted-fake-dc2":{"clusters":0}}}}`,
+			httpStatus:       http.StatusOK,
+			existingKubeObjs: []runtime.Object{
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "seed-node"},
+					Status: corev1.NodeStatus{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+						Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+					},
+				},
+			},
+			existingKubermaticObjs: []runtime.Object{
+				genUser("Bob", "bob@acme.com", true),
+				test.GenCluster("clusterID", "cluster", "projectID", time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC), func(cluster *kubermaticv1.Cluster) {
+					cluster.Spec.Cloud.DatacenterName = "fake-dc"
+				}),
+			},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			var kubernetesObj []runtime.Object
-			var kubeObj []runtime.Object
+			kubeObj := tc.existingKubeObjs
 			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/admin/seeds/%s", tc.seedName), strings.NewReader(""))
 			res := httptest.NewRecorder()
 			var kubermaticObj []runtime.Object
@@ -283,3 +318,193 @@ func TestDeleteSeedEndpoint(t *testing.T) {
 		})
 	}
 }
+
+const testSeedKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: europe-west3
+  cluster:
+    server: https://europe-west3.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret-token
+contexts:
+- name: default
+  context:
+    cluster: europe-west3
+    user: admin
+current-context: default
+`
+
+func TestCreateSeedEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+		expectSeedCreated      bool
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: not authorized user tries to create seed cluster",
+			body:                   fmt.Sprintf(`{"name":"europe-west3","kubeconfig":%q,"spec":{"country":"DE"}}`, testSeedKubeconfig),
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:                   "scenario 2: seed name is invalid",
+			body:                   fmt.Sprintf(`{"name":"Europe_West3","kubeconfig":%q,"spec":{"country":"DE"}}`, testSeedKubeconfig),
+			expectedResponse:       `{"error":{"code":400,"message":"invalid seed name \"Europe_West3\": a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 3
+		{
+			name:                   "scenario 3: kubeconfig is missing",
+			body:                   `{"name":"europe-west3","spec":{"country":"DE"}}`,
+			expectedResponse:       `{"error":{"code":400,"message":"the kubeconfig of the seed cluster is required"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 4
+		{
+			name:                   "scenario 4: seed with the same name already exists",
+			body:                   fmt.Sprintf(`{"name":"us-central1","kubeconfig":%q,"spec":{"country":"US"}}`, testSeedKubeconfig),
+			expectedResponse:       `{"error":{"code":409,"message":"seeds.kubermatic.k8s.io \"us-central1\" already exists"}}`,
+			httpStatus:             http.StatusConflict,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genSeedInNamespace("us-central1", "kubermatic")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 5
+		{
+			name:                   "scenario 5: seed redefines a datacenter of another seed",
+			body:                   fmt.Sprintf(`{"name":"europe-west3","kubeconfig":%q,"spec":{"country":"DE","datacenters":{"regular-do1":{"country":"NL","location":"Amsterdam","spec":{"digitalocean":{"region":"ams2"}}}}}}`, testSeedKubeconfig),
+			expectedResponse:       `{"error":{"code":400,"message":"invalid seed: seed redefines existing datacenters [regular-do1] from seed \"us-central1\"; datacenter names must be globally unique"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 6
+		{
+			name:                   "scenario 6: authorized user creates seed cluster",
+			body:                   fmt.Sprintf(`{"name":"europe-west3","kubeconfig":%q,"spec":{"country":"DE","location":"Frankfurt","datacenters":{"do-fra1":{"country":"DE","location":"Frankfurt","spec":{"digitalocean":{"region":"fra1"}}}}}}`, testSeedKubeconfig),
+			expectedResponse:       `{"name":"europe-west3","spec":{"country":"DE","location":"Frankfurt","kubeconfig":{"kind":"Secret","namespace":"kubermatic","name":"kubeconfig-europe-west3","fieldPath":"kubeconfig"},"datacenters":{"do-fra1":{"metadata":{"name":"do-fra1"},"spec":{"seed":"europe-west3","country":"DE","location":"Frankfurt","provider":"digitalocean","digitalocean":{"region":"fra1"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}}},"bootstrap":{"done":false,"steps":[{"name":"SeedSynced","done":true},{"name":"CRDsInstalled","done":true},{"name":"SeedControllerManagerReady","done":false,"message":"Deployment kubermatic/kubermatic-seed-controller-manager has no ready replicas"},{"name":"NodeportProxyExposed","done":false,"message":"Service kubermatic/nodeport-proxy does not exist yet"}]}}`,
+			httpStatus:             http.StatusCreated,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+			expectSeedCreated:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/admin/seeds", strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+
+			secret := &corev1.Secret{}
+			err = clients.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: "kubermatic", Name: "kubeconfig-europe-west3"}, secret)
+			if tc.expectSeedCreated {
+				if err != nil {
+					t.Fatalf("expected the kubeconfig secret to be created: %v", err)
+				}
+				if string(secret.Data[provider.DefaultKubeconfigFieldPath]) != testSeedKubeconfig {
+					t.Errorf("expected the secret to contain the kubeconfig, got %q", secret.Data[provider.DefaultKubeconfigFieldPath])
+				}
+				if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != "europe-west3" {
+					t.Errorf("expected the secret to be owned by the seed, got %v", secret.OwnerReferences)
+				}
+
+				seed := &kubermaticv1.Seed{}
+				if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: "kubermatic", Name: "europe-west3"}, seed); err != nil {
+					t.Fatalf("expected the seed to be created: %v", err)
+				}
+				if seed.Spec.Kubeconfig.Name != secret.Name || seed.Spec.Kubeconfig.FieldPath != provider.DefaultKubeconfigFieldPath {
+					t.Errorf("expected the seed to reference the kubeconfig secret, got %v", seed.Spec.Kubeconfig)
+				}
+			} else if err == nil {
+				t.Error("expected no kubeconfig secret to be left behind")
+			}
+		})
+	}
+}
+
+func TestGetSeedBootstrapStatusEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		seedName               string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+		existingKubeObjs       []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: not authorized user tries to get the bootstrap status",
+			seedName:               "us-central1",
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:                   "scenario 2: seed cluster is still bootstrapping",
+			seedName:               "us-central1",
+			expectedResponse:       `{"done":false,"steps":[{"name":"SeedSynced","done":true},{"name":"CRDsInstalled","done":true},{"name":"SeedControllerManagerReady","done":false,"message":"Deployment kubermatic/kubermatic-seed-controller-manager has no ready replicas"},{"name":"NodeportProxyExposed","done":false,"message":"Service kubermatic/nodeport-proxy does not exist yet"}]}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), genSeedInNamespace("us-central1", "kubermatic")},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+				return map[string]*kubermaticv1.Seed{"us-central1": genSeedInNamespace("us-central1", "kubermatic")}, nil
+			}
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/admin/seeds/%s/bootstrap", tc.seedName), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, seedsGetter, tc.existingKubeObjs, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func genSeedInNamespace(name, namespace string) *kubermaticv1.Seed {
+	seed := test.GenTestSeed()
+	seed.Name = name
+	seed.Namespace = namespace
+	return seed
+}
@@ -19,6 +19,7 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 
@@ -84,6 +85,18 @@ func UpdateKubermaticSettingsEndpoint(userInfoGetter provider.UserInfoGetter, se
 			return nil, errors.NewBadRequest("cannot decode patched settings: %v", err)
 		}
 
+		if err := validatePriceLists(patchedGlobalSettingsSpec.PriceLists); err != nil {
+			return nil, errors.NewBadRequest("invalid price lists: %v", err)
+		}
+		if ttl := patchedGlobalSettingsSpec.KubeconfigTTL; ttl != nil {
+			if ttl.Duration < 0 {
+				return nil, errors.NewBadRequest("invalid kubeconfig TTL: must not be negative")
+			}
+			if ttl.Duration > kubermaticv1.MaxKubeconfigTTL {
+				return nil, errors.NewBadRequest("invalid kubeconfig TTL: must not exceed %v", kubermaticv1.MaxKubeconfigTTL)
+			}
+		}
+
 		existingGlobalSettings.Spec = *patchedGlobalSettingsSpec
 		globalSettings, err := settingsProvider.UpdateGlobalSettings(userInfo, existingGlobalSettings)
 		if err != nil {
@@ -111,3 +124,17 @@ func DecodePatchKubermaticSettingsReq(c context.Context, r *http.Request) (inter
 
 	return req, nil
 }
+
+func validatePriceLists(priceLists map[string]kubermaticv1.PriceList) error {
+	for providerName, priceList := range priceLists {
+		if priceList.Currency == "" {
+			return fmt.Errorf("the price list of %q has no currency", providerName)
+		}
+		for size, price := range priceList.NodeSizes {
+			if price < 0 {
+				return fmt.Errorf("the price of %q in the price list of %q must not be negative", size, providerName)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultCertificateTransitionWindow is the duration the old CAs stay trusted if the request doesn't specify it
+const defaultCertificateTransitionWindow = 24 * time.Hour
+
+// GetCertificatesEndpoint lists the certificates of the cluster control plane
+func GetCertificatesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(common.GetClusterReq)
+		if !ok {
+			return nil, errors.NewWrongRequest(request, common.GetClusterReq{})
+		}
+
+		cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertInternalCertificatesToExternal(cluster), nil
+	}
+}
+
+// RotateCertificatesReq defines HTTP request for rotateClusterCertificates endpoint
+// swagger:parameters rotateClusterCertificates
+type RotateCertificatesReq struct {
+	common.GetClusterReq
+
+	// in: body
+	Body apiv1.ClusterCertificateRotation
+}
+
+func DecodeRotateCertificatesReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req RotateCertificatesReq
+	cr, err := common.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.GetClusterReq = cr.(common.GetClusterReq)
+
+	// The body is optional
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil && err != io.EOF {
+		return nil, errors.NewBadRequest("unable to parse the request body: %v", err)
+	}
+
+	return req, nil
+}
+
+// RotateCertificatesEndpoint requests the rotation of the cluster CAs and the service account key
+func RotateCertificatesEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(RotateCertificatesReq)
+		if !ok {
+			return nil, errors.NewWrongRequest(request, RotateCertificatesReq{})
+		}
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		transitionWindow := defaultCertificateTransitionWindow
+		if req.Body.TransitionWindow != "" {
+			var err error
+			transitionWindow, err = time.ParseDuration(req.Body.TransitionWindow)
+			if err != nil {
+				return nil, errors.NewBadRequest("invalid transition window: %v", err)
+			}
+			if transitionWindow <= 0 {
+				return nil, errors.NewBadRequest("the transition window must be positive")
+			}
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		cluster, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if cluster.IsOpenshift() {
+			return nil, errors.NewBadRequest("certificate rotation is not supported for openshift clusters")
+		}
+		if cluster.DeletionTimestamp != nil {
+			return nil, errors.NewBadRequest("cluster %s is being deleted", cluster.Name)
+		}
+		if cluster.IsControlPlaneHibernated() {
+			return nil, errors.NewBadRequest("cluster %s is hibernated", cluster.Name)
+		}
+		if rotation := cluster.Status.CertificateRotation; rotation != nil && rotation.Phase != kubermaticv1.ClusterCertificateRotationPhaseCompleted {
+			return nil, errors.New(http.StatusConflict, fmt.Sprintf("a certificate rotation of cluster %s is already in progress", cluster.Name))
+		}
+
+		now := metav1.Now()
+		cluster.Status.CertificateRotation = &kubermaticv1.ClusterCertificateRotationStatus{
+			Phase:              kubermaticv1.ClusterCertificateRotationPhasePending,
+			TransitionWindow:   metav1.Duration{Duration: transitionWindow},
+			RequestTime:        now,
+			LastTransitionTime: now,
+			Message:            "Waiting for the rotation to start",
+		}
+
+		updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, cluster)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalCertificatesToExternal(updatedCluster), nil
+	}
+}
+
+func convertInternalCertificatesToExternal(cluster *kubermaticv1.Cluster) *apiv1.ClusterCertificates {
+	certificates := cluster.Status.Certificates
+	if certificates == nil {
+		certificates = []kubermaticv1.ClusterCertificate{}
+	}
+	return &apiv1.ClusterCertificates{
+		Certificates: certificates,
+		Rotation:     cluster.Status.CertificateRotation,
+	}
+}
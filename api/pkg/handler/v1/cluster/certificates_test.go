@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetClusterCertificatesEndpoint(t *testing.T) {
+	t.Parallel()
+
+	cluster := test.GenDefaultCluster()
+	cluster.Status.Certificates = []kubermaticv1.ClusterCertificate{
+		{
+			SecretName: "ca",
+			Key:        "ca.crt",
+			CommonName: "root-ca.defClusterID.europe-west3-c.dev.kubermatic.io",
+			IsCA:       true,
+			NotBefore:  metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+			NotAfter:   metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	kubermaticObjs := test.GenDefaultKubermaticObjects(cluster)
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, kubermaticObjs, nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/certificates", test.ProjectName, cluster.Name), nil)
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, `{"certificates":[{"secretName":"ca","key":"ca.crt","commonName":"root-ca.defClusterID.europe-west3-c.dev.kubermatic.io","isCA":true,"notBefore":"2020-01-01T00:00:00Z","notAfter":"2030-01-01T00:00:00Z"}]}`)
+}
+
+func TestRotateClusterCertificatesEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name                     string
+		cluster                  *kubermaticv1.Cluster
+		body                     string
+		httpStatus               int
+		expectedTransitionWindow time.Duration
+		expectedErrorBody        string
+	}{
+		{
+			name:                     "scenario 1: the owner rotates the certificates with the default transition window",
+			cluster:                  test.GenDefaultCluster(),
+			httpStatus:               http.StatusOK,
+			expectedTransitionWindow: 24 * time.Hour,
+		},
+		{
+			name:                     "scenario 2: the owner rotates the certificates with a custom transition window",
+			cluster:                  test.GenDefaultCluster(),
+			body:                     `{"transitionWindow":"2h"}`,
+			httpStatus:               http.StatusOK,
+			expectedTransitionWindow: 2 * time.Hour,
+		},
+		{
+			name: "scenario 3: a completed rotation can be repeated",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				c.Status.CertificateRotation = &kubermaticv1.ClusterCertificateRotationStatus{Phase: kubermaticv1.ClusterCertificateRotationPhaseCompleted}
+				return c
+			}(),
+			httpStatus:               http.StatusOK,
+			expectedTransitionWindow: 24 * time.Hour,
+		},
+		{
+			name:              "scenario 4: the transition window must be positive",
+			cluster:           test.GenDefaultCluster(),
+			body:              `{"transitionWindow":"-1h"}`,
+			httpStatus:        http.StatusBadRequest,
+			expectedErrorBody: `{"error":{"code":400,"message":"the transition window must be positive"}}`,
+		},
+		{
+			name: "scenario 5: a rotation can not be requested while another one is in progress",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				c.Status.CertificateRotation = &kubermaticv1.ClusterCertificateRotationStatus{Phase: kubermaticv1.ClusterCertificateRotationPhaseTransitioning}
+				return c
+			}(),
+			httpStatus:        http.StatusConflict,
+			expectedErrorBody: `{"error":{"code":409,"message":"a certificate rotation of cluster defClusterID is already in progress"}}`,
+		},
+		{
+			name: "scenario 6: certificates of openshift clusters can not be rotated",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				c.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+				return c
+			}(),
+			httpStatus:        http.StatusBadRequest,
+			expectedErrorBody: `{"error":{"code":400,"message":"certificate rotation is not supported for openshift clusters"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubermaticObjs := test.GenDefaultKubermaticObjects(tc.cluster)
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, []runtime.Object{}, []runtime.Object{}, kubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/certificates/rotate", test.ProjectName, tc.cluster.Name), strings.NewReader(tc.body))
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if tc.expectedErrorBody != "" {
+				test.CompareWithResult(t, res, tc.expectedErrorBody)
+				return
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: tc.cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			rotation := cluster.Status.CertificateRotation
+			if rotation == nil || rotation.Phase != kubermaticv1.ClusterCertificateRotationPhasePending {
+				t.Fatalf("expected a pending certificate rotation, got %+v", rotation)
+			}
+			if rotation.TransitionWindow.Duration != tc.expectedTransitionWindow {
+				t.Errorf("expected transition window %v, got %v", tc.expectedTransitionWindow, rotation.TransitionWindow.Duration)
+			}
+		})
+	}
+}
@@ -75,7 +75,9 @@ var clusterTypes = sets.NewString(apiv1.OpenShiftClusterType, apiv1.KubernetesCl
 
 func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
-	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager,
+	clusterProviderGetter provider.ClusterProviderGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
+	seedPlacer := kubernetesprovider.NewSeedPlacer(seedClientGetter)
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateReq)
 		globalSettings, err := settingsProvider.GetGlobalSettings()
@@ -87,8 +89,6 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
-		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 		adminUserInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -97,16 +97,45 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		k8sClient := privilegedClusterProvider.GetSeedClusterAdminClient()
 
-		seed, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, req.Body.Cluster.Spec.Cloud.DatacenterName)
+		datacenterName := req.Body.Cluster.Spec.Cloud.DatacenterName
+		seeds, err := provider.DatacenterSeedsFromSeedMap(adminUserInfo, seedsGetter, datacenterName)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		seed := seeds[0]
+		dc := seed.Spec.Datacenters[datacenterName]
+
+		// generate the name here so that it can be used for the placement and in the secretName below
+		clusterName := rand.String(10)
+
+		// datacenters with placement settings get their clusters on the least loaded of all seeds
+		// that define them, which is not necessarily the one from the request path
+		var placedSeed string
+		if placement := dc.Spec.Placement; len(seeds) > 1 || placement.IsShared() || (placement != nil && placement.MaxClustersPerSeed > 0) {
+			seed, err = seedPlacer.SelectSeedForDatacenter(ctx, seeds, datacenterName, clusterName)
+			if err != nil {
+				return nil, errors.New(http.StatusServiceUnavailable, err.Error())
+			}
+			dc = seed.Spec.Datacenters[datacenterName]
+			placedSeed = seed.Name
+			if seed.Name != req.DC {
+				ctx, err = middleware.WithSeedClusterProvider(ctx, seed, clusterProviderGetter)
+				if err != nil {
+					return nil, err
+				}
+			}
+		} else if err := validateSeedHealth(seedsGetter, req.DC); err != nil {
+			return nil, err
+		}
+
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+		k8sClient := privilegedClusterProvider.GetSeedClusterAdminClient()
 
 		credentialName := req.Body.Cluster.Credential
 		if len(credentialName) > 0 {
-			cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, credentialName, req.Body.Cluster.Spec.Cloud, dc)
+			cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, req.ProjectID, credentialName, req.Body.Cluster.Spec.Cloud, &dc)
 			if err != nil {
 				return nil, errors.NewBadRequest("invalid credentials: %v", err)
 			}
@@ -114,8 +143,8 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 		}
 
 		// Create the cluster.
-		secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
-		spec, err := cluster.Spec(req.Body.Cluster, dc, secretKeyGetter)
+		secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), privilegedClusterProvider.GetSecretStores())
+		spec, err := cluster.Spec(req.Body.Cluster, &dc, secretKeyGetter)
 		if err != nil {
 			return nil, errors.NewBadRequest("invalid cluster: %v", err)
 		}
@@ -138,6 +167,24 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 		if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		if err := validation.ValidateHibernation(spec.Hibernation); err != nil {
+			return nil, errors.NewBadRequest("invalid hibernation settings: %v", err)
+		}
+		if err := validation.ValidateClusterAutoscalerSettings(spec.ClusterAutoscaler); err != nil {
+			return nil, errors.NewBadRequest("invalid cluster autoscaler settings: %v", err)
+		}
+		if err := validation.ValidateFinalBackupSettings(spec.FinalBackup); err != nil {
+			return nil, errors.NewBadRequest("invalid final backup settings: %v", err)
+		}
+		if err := validation.ValidateEncryptionSettings(spec.Encryption); err != nil {
+			return nil, errors.NewBadRequest("invalid encryption settings: %v", err)
+		}
+		if err := validation.ValidateAuditLoggingSettings(spec.AuditLogging); err != nil {
+			return nil, errors.NewBadRequest("invalid audit logging settings: %v", err)
+		}
+		if err := validation.ValidateEventExportSettings(spec.EventExport); err != nil {
+			return nil, errors.NewBadRequest("invalid event export settings: %v", err)
+		}
 		partialCluster := &kubermaticv1.Cluster{}
 		partialCluster.Labels = req.Body.Cluster.Labels
 		partialCluster.Spec = *spec
@@ -150,10 +197,15 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 			}
 		}
 
+		if err := validation.ValidateTunnelMode(partialCluster); err != nil {
+			return nil, errors.NewBadRequest("invalid tunnel mode: %v", err)
+		}
+
 		// Enforce audit logging
 		if dc.Spec.EnforceAuditLogging {
-			partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-				Enabled: true,
+			partialCluster.Spec.AuditLogging, err = enforceAuditLogging(partialCluster.Spec.AuditLogging, &dc)
+			if err != nil {
+				return nil, err
 			}
 		}
 
@@ -162,14 +214,13 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 			partialCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
 		}
 
-		// generate the name here so that it can be used in the secretName below
-		partialCluster.Name = rand.String(10)
+		partialCluster.Name = clusterName
 
 		if cloudcontroller.ExternalCloudControllerFeatureSupported(partialCluster) {
 			partialCluster.Spec.Features = map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true}
 		}
 
-		if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), partialCluster); err != nil {
+		if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), privilegedClusterProvider.GetSecretStores(), partialCluster); err != nil {
 			return nil, err
 		}
 		kuberneteshelper.AddFinalizer(partialCluster, apiv1.CredentialsSecretsCleanupFinalizer)
@@ -191,7 +242,7 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 					defer utilruntime.HandleCrash()
 					ndName := getNodeDeploymentDisplayName(req.Body.NodeDeployment)
 					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationStart), "Started creation of initial node deployment %s", ndName)
-					err := createInitialNodeDeploymentWithRetries(ctx, req.Body.NodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
+					err := CreateInitialNodeDeploymentWithRetries(ctx, req.Body.NodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
 					if err != nil {
 						eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, string(nodeDeploymentCreationFail), "Failed to create initial node deployment %s: %v", ndName, err)
 						klog.Errorf("failed to create initial node deployment for cluster %s: %v", newCluster.Name, err)
@@ -222,8 +273,26 @@ func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider prov
 			return convertInternalClusterToExternal(newCluster, true), errors.New(http.StatusInternalServerError, "timed out waiting for cluster to become ready")
 		}
 
-		return convertInternalClusterToExternal(newCluster, true), nil
+		apiCluster := convertInternalClusterToExternal(newCluster, true)
+		apiCluster.Status.Seed = placedSeed
+		return apiCluster, nil
+	}
+}
+
+// validateSeedHealth rejects the creation of clusters on seeds which failed their health checks
+func validateSeedHealth(seedsGetter provider.SeedsGetter, seedName string) error {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+	}
+	seed, ok := seeds[seedName]
+	if !ok {
+		return errors.NewNotFound("seed", seedName)
+	}
+	if err := kubernetesprovider.ValidateSeedHealth(seed); err != nil {
+		return errors.New(http.StatusServiceUnavailable, fmt.Sprintf("cannot create cluster: %v", err))
 	}
+	return nil
 }
 
 func createNewCluster(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
@@ -241,7 +310,8 @@ func createNewCluster(ctx context.Context, userInfoGetter provider.UserInfoGette
 	return clusterProvider.New(project, userInfo, cluster)
 }
 
-func createInitialNodeDeploymentWithRetries(endpointContext context.Context, nodeDeployment *apiv1.NodeDeployment, cluster *kubermaticv1.Cluster,
+// CreateInitialNodeDeploymentWithRetries creates the node deployment once the cluster is initialized, it retries for up to 30 minutes
+func CreateInitialNodeDeploymentWithRetries(endpointContext context.Context, nodeDeployment *apiv1.NodeDeployment, cluster *kubermaticv1.Cluster,
 	project *kubermaticv1.Project, sshKeyProvider provider.SSHKeyProvider,
 	seedsGetter provider.SeedsGetter, clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, userInfoGetter provider.UserInfoGetter) error {
 	return wait.Poll(5*time.Second, 30*time.Minute, func() (bool, error) {
@@ -303,6 +373,7 @@ func createInitialNodeDeployment(endpointContext context.Context, nodeDeployment
 		Ctx:               ctx,
 		KubermaticCluster: cluster,
 		Client:            assertedClusterProvider.GetSeedClusterAdminRuntimeClient(),
+		SecretStores:      assertedClusterProvider.GetSecretStores(),
 	}
 	md, err := machineresource.Deployment(cluster, nd, dc, keys, data)
 	if err != nil {
@@ -462,6 +533,13 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 		newInternalCluster.Spec.AuditLogging = patchedCluster.Spec.AuditLogging
 		newInternalCluster.Spec.Openshift = patchedCluster.Spec.Openshift
 		newInternalCluster.Spec.UpdateWindow = patchedCluster.Spec.UpdateWindow
+		newInternalCluster.Spec.Hibernation = patchedCluster.Spec.Hibernation
+		newInternalCluster.Spec.ClusterAutoscaler = patchedCluster.Spec.ClusterAutoscaler
+		newInternalCluster.Spec.DeletionProtection = patchedCluster.Spec.DeletionProtection
+		newInternalCluster.Spec.FinalBackup = patchedCluster.Spec.FinalBackup
+		newInternalCluster.Spec.Encryption = patchedCluster.Spec.Encryption
+		newInternalCluster.Spec.EventExport = patchedCluster.Spec.EventExport
+		newInternalCluster.Spec.ClusterNetwork.TunnelMode = patchedCluster.Spec.TunnelMode
 
 		incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, req.ProjectID)
 		if err != nil {
@@ -480,14 +558,15 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 			return nil, fmt.Errorf("error getting dc: %v", err)
 		}
 
-		if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), newInternalCluster); err != nil {
+		if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), privilegedClusterProvider.GetSecretStores(), newInternalCluster); err != nil {
 			return nil, err
 		}
 
 		// Enforce audit logging
 		if dc.Spec.EnforceAuditLogging {
-			newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-				Enabled: true,
+			newInternalCluster.Spec.AuditLogging, err = enforceAuditLogging(newInternalCluster.Spec.AuditLogging, dc)
+			if err != nil {
+				return nil, err
 			}
 		}
 
@@ -506,6 +585,27 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 		if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		if err := validation.ValidateHibernation(newInternalCluster.Spec.Hibernation); err != nil {
+			return nil, errors.NewBadRequest("invalid hibernation settings: %v", err)
+		}
+		if err := validation.ValidateClusterAutoscalerSettings(newInternalCluster.Spec.ClusterAutoscaler); err != nil {
+			return nil, errors.NewBadRequest("invalid cluster autoscaler settings: %v", err)
+		}
+		if err := validation.ValidateFinalBackupSettings(newInternalCluster.Spec.FinalBackup); err != nil {
+			return nil, errors.NewBadRequest("invalid final backup settings: %v", err)
+		}
+		if err := validation.ValidateEncryptionSettings(newInternalCluster.Spec.Encryption); err != nil {
+			return nil, errors.NewBadRequest("invalid encryption settings: %v", err)
+		}
+		if err := validation.ValidateAuditLoggingSettings(newInternalCluster.Spec.AuditLogging); err != nil {
+			return nil, errors.NewBadRequest("invalid audit logging settings: %v", err)
+		}
+		if err := validation.ValidateEventExportSettings(newInternalCluster.Spec.EventExport); err != nil {
+			return nil, errors.NewBadRequest("invalid event export settings: %v", err)
+		}
+		if err := validation.ValidateTunnelMode(newInternalCluster); err != nil {
+			return nil, errors.NewBadRequest("invalid tunnel mode: %v", err)
+		}
 
 		updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
 		if err != nil {
@@ -584,6 +684,15 @@ func DeleteEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProv
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
+		existingCluster, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		if existingCluster.Spec.DeletionProtection {
+			return nil, errors.New(http.StatusConflict, fmt.Sprintf("cluster %s is protected against deletion, disable deletionProtection first", req.ClusterID))
+		}
+
 		clusterSSHKeys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: req.ClusterID})
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -596,11 +705,6 @@ func DeleteEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProv
 			}
 		}
 
-		existingCluster, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
-		if err != nil {
-			return nil, err
-		}
-
 		// Use the NodeDeletionFinalizer to determine if the cluster was ever up, the LB and PV finalizers
 		// will prevent cluster deletion if the APIserver was never created
 		wasUpOnce := kuberneteshelper.HasFinalizer(existingCluster, apiv1.NodeDeletionFinalizer)
@@ -701,14 +805,27 @@ func GetClusterEventsEndpoint(projectProvider provider.ProjectProvider, privileg
 			eventType = corev1.EventTypeNormal
 		}
 
-		events, err := common.GetEvents(ctx, client, cluster, "")
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+		events := make([]apiv1.Event, 0)
+		if req.Source != apiv1.EventSourceUserCluster {
+			kubermaticEvents, err := common.GetEvents(ctx, client, cluster, "")
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			events = append(events, kubermaticEvents...)
+		}
+		if req.Source != apiv1.EventSourceKubermatic {
+			exportedEvents, err := common.GetExportedEvents(ctx, client, cluster.Name)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			events = append(events, exportedEvents...)
 		}
 
 		if len(eventType) > 0 {
 			events = common.FilterEventsByType(events, eventType)
 		}
+		events = common.FilterEventsByInvolvedObject(events, req.InvolvedObjectType, req.InvolvedObjectNamespace, req.InvolvedObjectName)
+		events = common.FilterEventsByTime(events, req.since, req.until)
 
 		return events, nil
 	}
@@ -870,10 +987,21 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 			UsePodSecurityPolicyAdmissionPlugin: internalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
 			UsePodNodeSelectorAdmissionPlugin:   internalCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
 			AdmissionPlugins:                    internalCluster.Spec.AdmissionPlugins,
+			Hibernation:                         internalCluster.Spec.Hibernation,
+			ClusterAutoscaler:                   internalCluster.Spec.ClusterAutoscaler,
+			DeletionProtection:                  internalCluster.Spec.DeletionProtection,
+			FinalBackup:                         internalCluster.Spec.FinalBackup,
+			Encryption:                          internalCluster.Spec.Encryption,
+			EventExport:                         internalCluster.Spec.EventExport,
+			TunnelMode:                          internalCluster.Spec.ClusterNetwork.TunnelMode,
 		},
 		Status: apiv1.ClusterStatus{
-			Version: internalCluster.Spec.Version,
-			URL:     internalCluster.Address.URL,
+			Version:     internalCluster.Spec.Version,
+			URL:         internalCluster.Address.URL,
+			Migration:   internalCluster.Status.Migration,
+			Hibernation: internalCluster.Status.Hibernation,
+			Deletion:    internalCluster.Status.Deletion,
+			Encryption:  internalCluster.Status.Encryption,
 		},
 		Type: apiv1.KubernetesClusterType,
 	}
@@ -1379,6 +1507,31 @@ type EventsReq struct {
 
 	// in: query
 	Type string `json:"type,omitempty"`
+
+	// Source is either kubermatic for the events about the Kubermatic objects of the cluster or usercluster
+	// for the events exported from the user cluster. Both are returned if it is empty.
+	// in: query
+	Source string `json:"source,omitempty"`
+
+	// in: query
+	InvolvedObjectType string `json:"involvedObjectType,omitempty"`
+
+	// in: query
+	InvolvedObjectNamespace string `json:"involvedObjectNamespace,omitempty"`
+
+	// in: query
+	InvolvedObjectName string `json:"involvedObjectName,omitempty"`
+
+	// Since only returns events which occurred the last time at or after the given RFC3339 timestamp.
+	// in: query
+	Since string `json:"since,omitempty"`
+
+	// Until only returns events which occurred the last time at or before the given RFC3339 timestamp.
+	// in: query
+	Until string `json:"until,omitempty"`
+
+	since time.Time
+	until time.Time
 }
 
 func DecodeGetClusterEvents(c context.Context, r *http.Request) (interface{}, error) {
@@ -1391,7 +1544,30 @@ func DecodeGetClusterEvents(c context.Context, r *http.Request) (interface{}, er
 	clusterReq := clusterReqRaw.(common.GetClusterReq)
 	req.GetClusterReq = clusterReq
 
-	req.Type = r.URL.Query().Get("type")
+	query := r.URL.Query()
+	req.InvolvedObjectType = query.Get("involvedObjectType")
+	req.InvolvedObjectNamespace = query.Get("involvedObjectNamespace")
+	req.InvolvedObjectName = query.Get("involvedObjectName")
+
+	req.Source = query.Get("source")
+	if req.Source != "" && req.Source != apiv1.EventSourceKubermatic && req.Source != apiv1.EventSourceUserCluster {
+		return nil, errors.NewBadRequest("wrong query parameter, unsupported source: %s", req.Source)
+	}
+
+	req.Since = query.Get("since")
+	if req.Since != "" {
+		if req.since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, errors.NewBadRequest("wrong query parameter, since must be a RFC3339 timestamp: %v", err)
+		}
+	}
+	req.Until = query.Get("until")
+	if req.Until != "" {
+		if req.until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+			return nil, errors.NewBadRequest("wrong query parameter, until must be a RFC3339 timestamp: %v", err)
+		}
+	}
+
+	req.Type = query.Get("type")
 	if len(req.Type) > 0 {
 		if req.Type == "warning" || req.Type == "normal" {
 			return req, nil
@@ -1461,3 +1637,32 @@ func GetClusterProviderFromRequest(
 	}
 	return cluster, clusterProvider, nil
 }
+
+// auditPolicyPresetVerbosity ranks the audit policy presets from the least to the most verbose one.
+var auditPolicyPresetVerbosity = map[kubermaticv1.AuditPolicyPreset]int{
+	"":                                     0,
+	kubermaticv1.AuditPolicyPresetMetadata: 0,
+	kubermaticv1.AuditPolicyPresetRecommended: 1,
+	kubermaticv1.AuditPolicyPresetAll:         2,
+}
+
+// enforceAuditLogging enables audit logging as required by the datacenter while keeping the settings of the user.
+// If the datacenter pins a minimum policy preset, less verbose presets are raised to it and custom policies are rejected.
+func enforceAuditLogging(settings *kubermaticv1.AuditLoggingSettings, dc *kubermaticv1.Datacenter) (*kubermaticv1.AuditLoggingSettings, error) {
+	if settings == nil {
+		settings = &kubermaticv1.AuditLoggingSettings{}
+	}
+	settings.Enabled = true
+
+	minimum := dc.Spec.MinimumAuditPolicyPreset
+	if minimum == "" {
+		return settings, nil
+	}
+	if settings.CustomPolicy != "" {
+		return nil, errors.NewBadRequest("custom audit policies are not allowed in this datacenter, the audit policy preset must be at least %q", minimum)
+	}
+	if auditPolicyPresetVerbosity[settings.PolicyPreset] < auditPolicyPresetVerbosity[minimum] {
+		settings.PolicyPreset = minimum
+	}
+	return settings, nil
+}
@@ -155,6 +155,23 @@ func TestDeleteClusterEndpoint(t *testing.T) {
 			ExistingAPIUser:               test.GenAPIUser("John", "john@acme.com"),
 			ExpectedListClusterKeysStatus: http.StatusNotFound,
 		},
+		{
+			Name:             "scenario 4: a cluster with deletion protection can not be deleted",
+			Body:             ``,
+			ExpectedResponse: `{"error":{"code":409,"message":"cluster clusterAbcID is protected against deletion, disable deletionProtection first"}}`,
+			HTTPStatus:       http.StatusConflict,
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.DeletionProtection = true
+					return cluster
+				}(),
+			),
+			ClusterToSync:                 "clusterAbcID",
+			ExistingAPIUser:               test.GenDefaultAPIUser(),
+			ExpectedListClusterKeysStatus: http.StatusNotFound,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -957,6 +974,118 @@ func TestCreateClusterEndpoint(t *testing.T) {
 	}
 }
 
+func TestCreateClusterEndpointWithPlacement(t *testing.T) {
+	t.Parallel()
+
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		seed := test.GenTestSeed()
+		seed.Spec.Datacenters["placed-dc"] = kubermaticv1.Datacenter{
+			Spec: kubermaticv1.DatacenterSpec{
+				Fake:      &kubermaticv1.DatacenterSpecFake{},
+				Placement: &kubermaticv1.DatacenterPlacement{MaxClustersPerSeed: 1},
+			},
+		}
+		return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+	}
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "seed-node"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	placedCluster := test.GenCluster("placedID", "placed", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+	placedCluster.Spec.Cloud.DatacenterName = "placed-dc"
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubeObjs       []runtime.Object
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:                   "scenario 1: the cluster is placed on a seed with free capacity",
+			ExpectedResponse:       `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"placed-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":"","seed":"us-central1"}}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubeObjs:       []runtime.Object{readyNode},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+		{
+			Name:                   "scenario 2: the cluster is rejected when the seed limit is reached",
+			ExpectedResponse:       `{"error":{"code":503,"message":"no seed is available for datacenter \"placed-dc\": us-central1: limit of 1 clusters reached"}}`,
+			HTTPStatus:             http.StatusServiceUnavailable,
+			ExistingKubeObjs:       []runtime.Object{readyNode},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(placedCluster),
+		},
+		{
+			Name:                   "scenario 3: the cluster is rejected when the seed has no ready nodes",
+			ExpectedResponse:       `{"error":{"code":503,"message":"no seed is available for datacenter \"placed-dc\": us-central1: no ready nodes"}}`,
+			HTTPStatus:             http.StatusServiceUnavailable,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			body := `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"placed-dc"}}}}`
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters", test.GenDefaultProject().Name), strings.NewReader(body))
+			res := httptest.NewRecorder()
+
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), seedsGetter, tc.ExistingKubeObjs, nil, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			if res.Code == http.StatusCreated {
+				actualCluster := &apiv1.Cluster{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualCluster); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualCluster.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func TestCreateClusterEndpointOnUnhealthySeed(t *testing.T) {
+	t.Parallel()
+
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		seed := test.GenTestSeed()
+		seed.Status.Conditions = []kubermaticv1.SeedCondition{
+			{Type: kubermaticv1.SeedConditionAPIReachable, Status: corev1.ConditionTrue},
+			{Type: kubermaticv1.SeedConditionSeedControllerManagerReady, Status: corev1.ConditionFalse, Reason: "NotAvailable"},
+		}
+		return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+	}
+
+	body := `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters", test.GenDefaultProject().Name), strings.NewReader(body))
+	res := httptest.NewRecorder()
+
+	ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), seedsGetter, nil, nil, test.GenDefaultKubermaticObjects(), test.GenDefaultVersions(), nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusServiceUnavailable, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, `{"error":{"code":503,"message":"cannot create cluster: us-central1: seed is unhealthy, failed checks: SeedControllerManagerReady (NotAvailable)"}}`)
+}
+
 func TestGetClusterHealth(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1084,6 +1213,84 @@ func TestGetClusterHealth(t *testing.T) {
 	}
 }
 
+func TestClusterStreamsAuthorization(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Path                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			Name:             "scenario 1: the user John can not stream the clusters of Bob's project",
+			Path:             fmt.Sprintf("/api/v1/ws/projects/%s/clusters", test.GenDefaultProject().Name),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", false),
+				test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 2
+		{
+			Name:             "scenario 2: the user John can not stream the health of Bob's cluster",
+			Path:             fmt.Sprintf("/api/v1/ws/projects/%s/dc/us-central1/clusters/keen-snyder/health", test.GenDefaultProject().Name),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", false),
+				test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 3
+		{
+			Name:             "scenario 3: the events of a cluster in an unknown datacenter can not be streamed",
+			Path:             fmt.Sprintf("/api/v1/ws/projects/%s/dc/unknown/clusters/keen-snyder/events", test.GenDefaultProject().Name),
+			ExpectedResponse: `{"error":{"code":404,"message":"datacenter \"unknown\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 4
+		{
+			Name:             "scenario 4: the node deployments of a not existing cluster can not be streamed",
+			Path:             fmt.Sprintf("/api/v1/ws/projects/%s/dc/us-central1/clusters/missing/nodedeployments", test.GenDefaultProject().Name),
+			ExpectedResponse: `{"error":{"code":404,"message":"clusters.kubermatic.k8s.io \"missing\" not found"}}`,
+			HTTPStatus:       http.StatusNotFound,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC)),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.Path, nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
 func TestPatchCluster(t *testing.T) {
 	t.Parallel()
 
@@ -1317,6 +1524,33 @@ func TestGetCluster(t *testing.T) {
 			),
 			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
 		},
+		// scenario 5
+		{
+			Name:             "scenario 5: gets the deletion progress of a cluster which is being deleted",
+			Body:             ``,
+			ExpectedResponse: `{"id":"defClusterID","name":"defClusterName","deletionTimestamp":"2013-02-04T19:54:00Z","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","deletion":{"phases":[{"name":"InClusterResources","status":"Completed","lastTransitionTime":"2013-02-04T19:55:00Z"},{"name":"Nodes","status":"InProgress","message":"machine machine-a still exists","lastTransitionTime":"2013-02-04T19:55:00Z"}],"lastError":"failed to delete Machine \"machine-a\": connection refused","lastErrorTime":"2013-02-04T19:56:00Z"}}}`,
+			ClusterToGet:     test.GenDefaultCluster().Name,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenDefaultCluster()
+					deletionTimestamp := metav1.NewTime(time.Date(2013, 02, 04, 19, 54, 0, 0, time.UTC))
+					transitionTime := metav1.NewTime(time.Date(2013, 02, 04, 19, 55, 0, 0, time.UTC))
+					errorTime := metav1.NewTime(time.Date(2013, 02, 04, 19, 56, 0, 0, time.UTC))
+					cluster.DeletionTimestamp = &deletionTimestamp
+					cluster.Status.Deletion = &kubermaticv1.ClusterDeletionStatus{
+						Phases: []kubermaticv1.ClusterDeletionPhase{
+							{Name: kubermaticv1.ClusterDeletionPhaseInClusterResources, Status: kubermaticv1.ClusterDeletionPhaseStatusCompleted, LastTransitionTime: transitionTime},
+							{Name: kubermaticv1.ClusterDeletionPhaseNodes, Status: kubermaticv1.ClusterDeletionPhaseStatusInProgress, Message: "machine machine-a still exists", LastTransitionTime: transitionTime},
+						},
+						LastError:     `failed to delete Machine "machine-a": connection refused`,
+						LastErrorTime: &errorTime,
+					}
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1789,6 +2023,7 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 		ExistingAPIUser        *apiv1.User
 		ExistingKubermaticObjs []runtime.Object
 		ExistingEvents         []*corev1.Event
+		ExistingClusterEvents  []*kubermaticv1.ClusterEvent
 		NodeDeploymentID       string
 		QueryParams            string
 	}{
@@ -1864,6 +2099,74 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 			},
 			ExpectedResult: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
 		},
+		// scenario 6
+		{
+			Name:                   "scenario 6: list the events exported from the user cluster",
+			QueryParams:            "?source=usercluster",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
+			},
+			ExistingClusterEvents: []*kubermaticv1.ClusterEvent{
+				genExportedEvent("event-3", corev1.EventTypeWarning, "NodeNotReady", "node-1", "", time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-4", corev1.EventTypeWarning, "FailedScheduling", "pod-1", "default", time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-5", corev1.EventTypeNormal, "Scheduled", "pod-1", "default", time.Date(2020, 3, 3, 10, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"user-cluster.event-3","creationTimestamp":"0001-01-01T00:00:00Z","message":"NodeNotReady","type":"Warning","involvedObject":{"type":"Node","name":"node-1"},"lastTimestamp":"2020-03-01T10:00:00Z","count":1,"source":"usercluster"},{"name":"user-cluster.event-4","creationTimestamp":"0001-01-01T00:00:00Z","message":"FailedScheduling","type":"Warning","involvedObject":{"type":"Pod","namespace":"default","name":"pod-1"},"lastTimestamp":"2020-03-02T10:00:00Z","count":1,"source":"usercluster"},{"name":"user-cluster.event-5","creationTimestamp":"0001-01-01T00:00:00Z","message":"Scheduled","type":"Normal","involvedObject":{"type":"Pod","namespace":"default","name":"pod-1"},"lastTimestamp":"2020-03-03T10:00:00Z","count":1,"source":"usercluster"}]`,
+		},
+		// scenario 7
+		{
+			Name:                   "scenario 7: list the warning events of a pod exported from the user cluster",
+			QueryParams:            "?source=usercluster&type=warning&involvedObjectType=Pod&involvedObjectNamespace=default&involvedObjectName=pod-1",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
+			},
+			ExistingClusterEvents: []*kubermaticv1.ClusterEvent{
+				genExportedEvent("event-3", corev1.EventTypeWarning, "NodeNotReady", "node-1", "", time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-4", corev1.EventTypeWarning, "FailedScheduling", "pod-1", "default", time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-5", corev1.EventTypeNormal, "Scheduled", "pod-1", "default", time.Date(2020, 3, 3, 10, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"user-cluster.event-4","creationTimestamp":"0001-01-01T00:00:00Z","message":"FailedScheduling","type":"Warning","involvedObject":{"type":"Pod","namespace":"default","name":"pod-1"},"lastTimestamp":"2020-03-02T10:00:00Z","count":1,"source":"usercluster"}]`,
+		},
+		// scenario 8
+		{
+			Name:                   "scenario 8: list the events exported from the user cluster within a time range",
+			QueryParams:            "?source=usercluster&since=2020-03-02T00:00:00Z&until=2020-03-02T23:59:59Z",
+			HTTPStatus:             http.StatusOK,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
+			},
+			ExistingClusterEvents: []*kubermaticv1.ClusterEvent{
+				genExportedEvent("event-3", corev1.EventTypeWarning, "NodeNotReady", "node-1", "", time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-4", corev1.EventTypeWarning, "FailedScheduling", "pod-1", "default", time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)),
+				genExportedEvent("event-5", corev1.EventTypeNormal, "Scheduled", "pod-1", "default", time.Date(2020, 3, 3, 10, 0, 0, 0, time.UTC)),
+			},
+			ExpectedResult: `[{"name":"user-cluster.event-4","creationTimestamp":"0001-01-01T00:00:00Z","message":"FailedScheduling","type":"Warning","involvedObject":{"type":"Pod","namespace":"default","name":"pod-1"},"lastTimestamp":"2020-03-02T10:00:00Z","count":1,"source":"usercluster"}]`,
+		},
+		// scenario 9
+		{
+			Name:                   "scenario 9: invalid time range",
+			QueryParams:            "?since=yesterday",
+			HTTPStatus:             http.StatusBadRequest,
+			ClusterIDToSync:        test.GenDefaultCluster().Name,
+			ProjectIDToSync:        test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExpectedResult:         `{"error":{"code":400,"message":"wrong query parameter, since must be a RFC3339 timestamp: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\""}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1876,6 +2179,9 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 			for _, existingEvents := range tc.ExistingEvents {
 				kubernetesObj = append(kubernetesObj, existingEvents)
 			}
+			for _, existingClusterEvent := range tc.ExistingClusterEvents {
+				kubermaticObj = append(kubermaticObj, existingClusterEvent)
+			}
 			kubermaticObj = append(kubermaticObj, tc.ExistingKubermaticObjs...)
 
 			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, kubernetesObj, machineObj, kubermaticObj, nil, nil, hack.NewTestRouting)
@@ -2197,6 +2503,34 @@ func genClusterWithOpenstack(cluster *kubermaticv1.Cluster) *kubermaticv1.Cluste
 	return cluster
 }
 
+// genExportedEvent returns an event which got exported from the user cluster of the default cluster
+func genExportedEvent(name, eventType, reason, objectName, objectNamespace string, lastTimestamp time.Time) *kubermaticv1.ClusterEvent {
+	kind := "Node"
+	if objectNamespace != "" {
+		kind = "Pod"
+	}
+	return &kubermaticv1.ClusterEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "user-cluster." + name,
+			Labels: map[string]string{kubermaticv1.ClusterEventClusterLabelKey: test.GenDefaultCluster().Name},
+		},
+		Spec: kubermaticv1.ClusterEventSpec{
+			Cluster: test.GenDefaultCluster().Name,
+			InvolvedObject: corev1.ObjectReference{
+				Name:      objectName,
+				Namespace: objectNamespace,
+				Kind:      kind,
+			},
+			Reason:        reason,
+			Message:       reason,
+			Count:         1,
+			Type:          eventType,
+			LastTimestamp: metav1.NewTime(lastTimestamp),
+			ExpiresAt:     metav1.NewTime(lastTimestamp.Add(30 * 24 * time.Hour)),
+		},
+	}
+}
+
 func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user := test.GenUser("", name, email)
 	user.Spec.IsAdmin = isAdmin
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRotateClusterEncryptionKeyEndpoint(t *testing.T) {
+	t.Parallel()
+
+	genEncryptedCluster := func(phase kubermaticv1.ClusterEncryptionPhase) *kubermaticv1.Cluster {
+		c := test.GenDefaultCluster()
+		c.Spec.Encryption = &kubermaticv1.ClusterEncryptionSpec{Enabled: true}
+		c.Status.Encryption = &kubermaticv1.ClusterEncryptionStatus{
+			Phase: phase,
+			Keys: []kubermaticv1.ClusterEncryptionKey{
+				{Name: "key-1", Provider: kubermaticv1.ClusterEncryptionProviderAESCBC},
+				{Provider: kubermaticv1.ClusterEncryptionProviderIdentity},
+			},
+		}
+		return c
+	}
+
+	testcases := []struct {
+		name              string
+		cluster           *kubermaticv1.Cluster
+		httpStatus        int
+		expectedErrorBody string
+	}{
+		{
+			name:       "scenario 1: the owner rotates the encryption key",
+			cluster:    genEncryptedCluster(kubermaticv1.ClusterEncryptionPhaseActive),
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:              "scenario 2: the key can not be rotated while it is being changed",
+			cluster:           genEncryptedCluster(kubermaticv1.ClusterEncryptionPhaseRewritingSecrets),
+			httpStatus:        http.StatusConflict,
+			expectedErrorBody: `{"error":{"code":409,"message":"the encryption keys of cluster defClusterID are being changed"}}`,
+		},
+		{
+			name:              "scenario 3: the key of a cluster without encryption can not be rotated",
+			cluster:           test.GenDefaultCluster(),
+			httpStatus:        http.StatusBadRequest,
+			expectedErrorBody: `{"error":{"code":400,"message":"encryption is not enabled for cluster defClusterID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubermaticObjs := test.GenDefaultKubermaticObjects(tc.cluster)
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, []runtime.Object{}, []runtime.Object{}, kubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/encryption/rotate", test.ProjectName, tc.cluster.Name), nil)
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if tc.expectedErrorBody != "" {
+				test.CompareWithResult(t, res, tc.expectedErrorBody)
+				return
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: tc.cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if phase := cluster.Status.Encryption.Phase; phase != kubermaticv1.ClusterEncryptionPhasePending {
+				t.Errorf("expected phase %q, got %q", kubermaticv1.ClusterEncryptionPhasePending, phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHibernateAndResumeClusterEndpoints(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name              string
+		action            string
+		cluster           *kubermaticv1.Cluster
+		existingAPIUser   *apiv1.User
+		existingObjs      []runtime.Object
+		httpStatus        int
+		expectHibernated  bool
+		expectedErrorBody string
+	}{
+		{
+			name:             "scenario 1: the owner hibernates a cluster",
+			action:           "hibernate",
+			cluster:          test.GenDefaultCluster(),
+			existingAPIUser:  test.GenDefaultAPIUser(),
+			httpStatus:       http.StatusOK,
+			expectHibernated: true,
+		},
+		{
+			name:   "scenario 2: the owner resumes a hibernated cluster and keeps its schedule",
+			action: "resume",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				c.Spec.Hibernation = &kubermaticv1.ClusterHibernationSpec{
+					Hibernated: true,
+					Schedule:   &kubermaticv1.ClusterHibernationSchedule{Hibernate: "0 20 * * *", Resume: "0 7 * * *"},
+				}
+				return c
+			}(),
+			existingAPIUser:  test.GenDefaultAPIUser(),
+			httpStatus:       http.StatusOK,
+			expectHibernated: false,
+		},
+		{
+			name:              "scenario 3: the user John can not hibernate Bob's cluster",
+			action:            "hibernate",
+			cluster:           test.GenDefaultCluster(),
+			existingAPIUser:   test.GenAPIUser("John", "john@acme.com"),
+			existingObjs:      []runtime.Object{genUser("John", "john@acme.com", false)},
+			httpStatus:        http.StatusForbidden,
+			expectedErrorBody: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+		{
+			name:   "scenario 4: openshift clusters can not be hibernated",
+			action: "hibernate",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				c.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+				return c
+			}(),
+			existingAPIUser:   test.GenDefaultAPIUser(),
+			httpStatus:        http.StatusBadRequest,
+			expectedErrorBody: `{"error":{"code":400,"message":"hibernation is not supported for openshift clusters"}}`,
+		},
+		{
+			name:   "scenario 5: clusters that are being deleted can not be hibernated",
+			action: "hibernate",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenDefaultCluster()
+				now := metav1.Now()
+				c.DeletionTimestamp = &now
+				return c
+			}(),
+			existingAPIUser:   test.GenDefaultAPIUser(),
+			httpStatus:        http.StatusBadRequest,
+			expectedErrorBody: `{"error":{"code":400,"message":"cluster defClusterID is being deleted"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubermaticObjs := test.GenDefaultKubermaticObjects(append(tc.existingObjs, tc.cluster)...)
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, []runtime.Object{}, []runtime.Object{}, kubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/%s", test.ProjectName, tc.cluster.Name, tc.action), nil)
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if tc.expectedErrorBody != "" {
+				test.CompareWithResult(t, res, tc.expectedErrorBody)
+				return
+			}
+
+			apiCluster := &apiv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), apiCluster); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if apiCluster.Spec.Hibernation == nil || apiCluster.Spec.Hibernation.Hibernated != tc.expectHibernated {
+				t.Errorf("expected hibernated to be %t in the response, got %+v", tc.expectHibernated, apiCluster.Spec.Hibernation)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: tc.cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if cluster.IsHibernationRequested() != tc.expectHibernated {
+				t.Errorf("expected hibernated to be %t, got %t", tc.expectHibernated, cluster.IsHibernationRequested())
+			}
+			if tc.cluster.Spec.Hibernation != nil && tc.cluster.Spec.Hibernation.Schedule != nil && cluster.Spec.Hibernation.Schedule == nil {
+				t.Error("expected the hibernation schedule to be kept")
+			}
+		})
+	}
+}
@@ -48,7 +48,7 @@ const (
 
 var secureCookie *securecookie.SecureCookie
 
-func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(common.GetClusterReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -57,6 +57,12 @@ func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privil
 			return nil, err
 		}
 
+		settings, err := settingsProvider.GetGlobalSettings()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		shortLived := settings.Spec.KubeconfigTTL != nil && settings.Spec.KubeconfigTTL.Duration > 0
+
 		filePrefix := "admin"
 		var adminClientCfg *clientcmdapi.Config
 
@@ -66,7 +72,11 @@ func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privil
 		}
 
 		if adminUserInfo.IsAdmin {
-			adminClientCfg, err = clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
+			if shortLived {
+				adminClientCfg, err = clusterProvider.GetShortLivedKubeconfigForCustomerCluster(adminUserInfo, cluster, settings.Spec.KubeconfigTTL.Duration)
+			} else {
+				adminClientCfg, err = clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
+			}
 			if err != nil {
 				return nil, common.KubernetesErrorToHTTPError(err)
 			}
@@ -79,8 +89,13 @@ func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privil
 		}
 		if strings.HasPrefix(userInfo.Group, "viewers") {
 			filePrefix = "viewer"
+		}
+		switch {
+		case shortLived:
+			adminClientCfg, err = clusterProvider.GetShortLivedKubeconfigForCustomerCluster(userInfo, cluster, settings.Spec.KubeconfigTTL.Duration)
+		case strings.HasPrefix(userInfo.Group, "viewers"):
 			adminClientCfg, err = clusterProvider.GetViewerKubeconfigForCustomerCluster(cluster)
-		} else {
+		default:
 			adminClientCfg, err = clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
 		}
 		if err != nil {
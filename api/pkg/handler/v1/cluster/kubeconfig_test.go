@@ -17,6 +17,8 @@ limitations under the License.
 package cluster_test
 
 import (
+	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -25,6 +27,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/securecookie"
 	"github.com/stretchr/testify/assert"
@@ -34,10 +37,15 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 const (
@@ -453,6 +461,125 @@ func TestGetMasterKubeconfig(t *testing.T) {
 
 }
 
+func TestGetShortLivedKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "cluster-cluster-foo",
+			Name:      resources.CASecretName,
+		},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(ca.Cert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(ca.Key),
+		},
+	}
+
+	settings := test.GenDefaultSettings()
+	settings.Spec.KubeconfigTTL = &metav1.Duration{Duration: time.Hour}
+
+	testcases := []struct {
+		Name                string
+		Binding             string
+		ExistingAPIUser     apiv1.User
+		ExpectedCommonName  string
+		ExpectedClusterRole string
+	}{
+		{
+			Name:                "scenario 1: owner gets a personal kubeconfig",
+			Binding:             "owners",
+			ExistingAPIUser:     *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedCommonName:  "john@acme.com",
+			ExpectedClusterRole: "system:kubermatic:owners",
+		},
+		{
+			Name:                "scenario 2: viewer gets a personal kubeconfig",
+			Binding:             "viewers",
+			ExistingAPIUser:     *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedCommonName:  "john@acme.com",
+			ExpectedClusterRole: "system:kubermatic:viewers",
+		},
+		{
+			Name:                "scenario 3: the admin gets a personal kubeconfig with admin privileges",
+			Binding:             "owners",
+			ExistingAPIUser:     *test.GenAPIUser("bob", "bob@acme.com"),
+			ExpectedCommonName:  "bob@acme.com",
+			ExpectedClusterRole: "cluster-admin",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			kubermaticObj := []runtime.Object{
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", tc.Binding),
+				test.GenUser("", "john", "john@acme.com"),
+				genUser("bob", "bob@acme.com", true),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp()),
+				settings,
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/projects/foo-ID/dc/us-central1/clusters/cluster-foo/kubeconfig", nil)
+			res := httptest.NewRecorder()
+			ep, clients, err := test.CreateTestEndpointAndGetClients(tc.ExistingAPIUser, nil, []runtime.Object{caSecret}, []runtime.Object{}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+
+			kubeconfig, err := clientcmd.Load(res.Body.Bytes())
+			if err != nil {
+				t.Fatalf("failed to parse kubeconfig: %v", err)
+			}
+			authInfo := kubeconfig.AuthInfos[resources.KubeconfigDefaultContextKey]
+			if authInfo == nil || authInfo.Token != "" {
+				t.Fatalf("expected kubeconfig to contain a client certificate, got %+v", authInfo)
+			}
+			certs, err := triple.ParseCertsPEM(authInfo.ClientCertificateData)
+			if err != nil {
+				t.Fatalf("failed to parse client certificate: %v", err)
+			}
+
+			cert := certs[0]
+			if cert.Subject.CommonName != tc.ExpectedCommonName {
+				t.Errorf("expected common name %q, got %q", tc.ExpectedCommonName, cert.Subject.CommonName)
+			}
+			// The permissions must come from a binding which can be removed, not from the certificate
+			if len(cert.Subject.Organization) != 0 {
+				t.Errorf("expected the certificate to carry no groups, got %v", cert.Subject.Organization)
+			}
+			if cert.NotAfter.After(time.Now().Add(time.Hour)) {
+				t.Errorf("expected certificate to expire within an hour, expires at %v", cert.NotAfter)
+			}
+			roots := x509.NewCertPool()
+			roots.AddCert(ca.Cert)
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+				t.Errorf("expected certificate to be signed by the cluster CA: %v", err)
+			}
+
+			binding := &rbacv1.ClusterRoleBinding{}
+			if err := clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: "kubermatic:kubeconfig:" + tc.ExpectedCommonName}, binding); err != nil {
+				t.Fatalf("failed to get the ClusterRoleBinding of the user: %v", err)
+			}
+			if binding.RoleRef.Name != tc.ExpectedClusterRole {
+				t.Errorf("expected the user to be bound to %s, got %s", tc.ExpectedClusterRole, binding.RoleRef.Name)
+			}
+			if len(binding.Subjects) != 1 || binding.Subjects[0].Kind != rbacv1.UserKind || binding.Subjects[0].Name != tc.ExpectedCommonName {
+				t.Errorf("expected the binding to have the user %s as its only subject, got %v", tc.ExpectedCommonName, binding.Subjects)
+			}
+		})
+	}
+}
+
 func genTestKubeconfigKubermaticObjects() []runtime.Object {
 	return []runtime.Object{
 		// add some project
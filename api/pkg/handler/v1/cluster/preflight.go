@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/provider/cloud"
+	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+)
+
+// PreflightCredentialsReq defines HTTP request for validateCloudCredentials endpoint
+// swagger:parameters validateCloudCredentials
+type PreflightCredentialsReq struct {
+	common.DCReq
+	// in: body
+	Body apiv1.CloudCredentialsPreflightSpec
+}
+
+// DecodePreflightCredentialsReq decodes the validateCloudCredentials request
+func DecodePreflightCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req PreflightCredentialsReq
+
+	dcr, err := common.DecodeDcReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.DCReq = dcr.(common.DCReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the body: %v", err)
+	}
+	if req.Body.Cloud.DatacenterName == "" {
+		return nil, errors.NewBadRequest("the cloud spec must reference a datacenter")
+	}
+
+	return req, nil
+}
+
+// PreflightCredentialsEndpoint checks the credentials of a cloud spec and the permissions Kubermatic needs,
+// so that users learn about wrong or under-privileged credentials before creating a cluster
+func PreflightCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+	credentialManager provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(PreflightCredentialsReq)
+		if !ok {
+			return nil, errors.NewWrongRequest(request, PreflightCredentialsReq{})
+		}
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		_, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, req.Body.Cloud.DatacenterName)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		cloudSpec := req.Body.Cloud
+		if len(req.Body.Credential) > 0 {
+			spec, err := credentialManager.SetCloudCredentials(adminUserInfo, req.ProjectID, req.Body.Credential, cloudSpec, dc)
+			if err != nil {
+				return nil, errors.NewBadRequest("invalid credentials: %v", err)
+			}
+			cloudSpec = *spec
+		}
+
+		providerName, err := provider.ClusterCloudProviderName(cloudSpec)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid cloud spec: %v", err)
+		}
+		if providerName == "" {
+			return nil, errors.NewBadRequest("the cloud spec has no cloud provider")
+		}
+
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+		secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), privilegedClusterProvider.GetSecretStores())
+		cloudProvider, err := cloud.Provider(dc, secretKeyGetter)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid datacenter: %v", err)
+		}
+
+		validator, ok := cloudProvider.(provider.CloudCredentialValidator)
+		if !ok {
+			return &apiv1.CloudCredentialsReport{
+				Supported: false,
+				Valid:     true,
+				Message:   "pre-flight checks are not supported for " + providerName,
+			}, nil
+		}
+
+		report, err := validator.ValidateCloudCredentials(cloudSpec)
+		if err != nil {
+			return nil, errors.NewBadRequest("failed to check the credentials: %v", err)
+		}
+		return convertInternalCredentialsReportToExternal(report), nil
+	}
+}
+
+func convertInternalCredentialsReportToExternal(report *provider.CredentialsReport) *apiv1.CloudCredentialsReport {
+	result := &apiv1.CloudCredentialsReport{
+		Supported: true,
+		Valid:     report.Valid,
+		Identity:  report.Identity,
+		Message:   report.Message,
+	}
+	for _, permission := range report.Permissions {
+		result.Permissions = append(result.Permissions, apiv1.CloudPermissionCheck{
+			Permission: permission.Permission,
+			Result:     string(permission.Result),
+			Message:    permission.Message,
+		})
+	}
+	return result
+}
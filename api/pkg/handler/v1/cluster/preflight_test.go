@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPreflightCredentialsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name             string
+		body             string
+		existingAPIUser  *apiv1.User
+		httpStatus       int
+		expectedResponse string
+	}{
+		{
+			name:             "scenario 1: providers without pre-flight checks are reported as unsupported",
+			body:             `{"cloud":{"dc":"fake-dc","fake":{"token":"dummy_token"}}}`,
+			existingAPIUser:  test.GenDefaultAPIUser(),
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"supported":false,"valid":true,"message":"pre-flight checks are not supported for fake"}`,
+		},
+		{
+			name:             "scenario 2: the cloud spec must reference a datacenter",
+			body:             `{"cloud":{"fake":{"token":"dummy_token"}}}`,
+			existingAPIUser:  test.GenDefaultAPIUser(),
+			httpStatus:       http.StatusBadRequest,
+			expectedResponse: `{"error":{"code":400,"message":"the cloud spec must reference a datacenter"}}`,
+		},
+		{
+			name: "scenario 3: the user must belong to the project",
+			body: `{"cloud":{"dc":"fake-dc","fake":{"token":"dummy_token"}}}`,
+			existingAPIUser: func() *apiv1.User {
+				user := test.GenDefaultAPIUser()
+				user.Email = "john@acme.com"
+				return user
+			}(),
+			httpStatus:       http.StatusForbidden,
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubermaticObjs := test.GenDefaultKubermaticObjects(test.GenUser("", "John", "john@acme.com"))
+			ep, err := test.CreateTestEndpoint(*tc.existingAPIUser, []runtime.Object{}, kubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/preflight/credentials", test.ProjectName), strings.NewReader(tc.body))
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
@@ -0,0 +1,723 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/addon"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/label"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/node"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// maxInstances is the maximum number of clusters which can be created from a template in a single request
+const maxInstances = 10
+
+func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := convertExternalToInternal(req.ProjectID, &req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid cluster template: %v", err)
+		}
+
+		return createTemplate(ctx, userInfoGetter, clusterTemplateProvider, template)
+	}
+}
+
+func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetProjectRq)
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		templates, err := clusterTemplateProvider.List(userInfo, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.ClusterTemplateList{}
+		for i := range templates {
+			template, err := convertInternalToExternal(&templates[i])
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			result = append(result, *template)
+		}
+		return result, nil
+	}
+}
+
+func GetEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getReq)
+
+		template, err := getTemplate(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, clusterTemplateProvider, req.ProjectID, req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		return convertInternalToExternal(template)
+	}
+}
+
+func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(getReq)
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if err := clusterTemplateProvider.Delete(userInfo, req.ProjectID, req.TemplateID); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return nil, nil
+	}
+}
+
+// CreateFromClusterEndpoint saves an existing cluster together with its node deployments and addons as a template
+func CreateFromClusterEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createFromClusterReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		internalCluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		template := &apiv1.ClusterTemplate{
+			ObjectMeta: apiv1.ObjectMeta{
+				Name: req.Body.Name,
+			},
+			Scope: req.Body.Scope,
+			Cluster: apiv1.Cluster{
+				ObjectMeta: apiv1.ObjectMeta{
+					Name: internalCluster.Spec.HumanReadableName,
+				},
+				Labels:     label.FilterLabels(label.ClusterResourceType, internalCluster.Labels),
+				Type:       apiv1.KubernetesClusterType,
+				Credential: req.Body.Credential,
+				Spec: apiv1.ClusterSpec{
+					Cloud:                               internalCluster.Spec.Cloud,
+					Version:                             internalCluster.Spec.Version,
+					MachineNetworks:                     internalCluster.Spec.MachineNetworks,
+					OIDC:                                internalCluster.Spec.OIDC,
+					UpdateWindow:                        internalCluster.Spec.UpdateWindow,
+					AuditLogging:                        internalCluster.Spec.AuditLogging,
+					UsePodSecurityPolicyAdmissionPlugin: internalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
+					UsePodNodeSelectorAdmissionPlugin:   internalCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
+					AdmissionPlugins:                    internalCluster.Spec.AdmissionPlugins,
+					Openshift:                           internalCluster.Spec.Openshift,
+				},
+			},
+		}
+		if internalCluster.IsOpenshift() {
+			template.Cluster.Type = apiv1.OpenShiftClusterType
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, internalCluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := client.List(ctx, machineDeployments); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		for i := range machineDeployments.Items {
+			nd, err := node.OutputMachineDeployment(&machineDeployments.Items[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert machine deployment %s: %v", machineDeployments.Items[i].Name, err)
+			}
+			template.NodeDeployments = append(template.NodeDeployments, apiv1.NodeDeployment{
+				ObjectMeta: apiv1.ObjectMeta{Name: nd.Name},
+				Spec:       nd.Spec,
+			})
+		}
+
+		addons, err := addon.ListAddons(ctx, userInfoGetter, internalCluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		for _, a := range addons {
+			// default addons are installed in every cluster anyway
+			if a.Spec.IsDefault {
+				continue
+			}
+			templateAddon := apiv1.Addon{ObjectMeta: apiv1.ObjectMeta{Name: a.Name}}
+			if len(a.Spec.Variables.Raw) > 0 {
+				if err := json.Unmarshal(a.Spec.Variables.Raw, &templateAddon.Spec.Variables); err != nil {
+					return nil, fmt.Errorf("failed to read variables of addon %s: %v", a.Name, err)
+				}
+			}
+			template.Addons = append(template.Addons, templateAddon)
+		}
+
+		internalTemplate, err := convertExternalToInternal(req.ProjectID, template)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid cluster template: %v", err)
+		}
+
+		return createTemplate(ctx, userInfoGetter, clusterTemplateProvider, internalTemplate)
+	}
+}
+
+// CreateInstancesEndpoint creates clusters from a template, every cluster goes through the same
+// validation and creation flow as the clusters created with the createCluster endpoint
+func CreateInstancesEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager,
+	clusterProviderGetter provider.ClusterProviderGetter, seedClientGetter provider.SeedClientGetter, clusterTemplateProvider provider.ClusterTemplateProvider) endpoint.Endpoint {
+	createCluster := cluster.CreateEndpoint(sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, initNodeDeploymentFailures, eventRecorderProvider,
+		credentialManager, exposeStrategy, userInfoGetter, settingsProvider, updateManager, clusterProviderGetter, seedClientGetter)
+
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createInstancesReq)
+		if req.Body.Replicas < 1 || req.Body.Replicas > maxInstances {
+			return nil, errors.NewBadRequest("the number of replicas must be between 1 and %d", maxInstances)
+		}
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := getTemplate(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, clusterTemplateProvider, req.ProjectID, req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		apiTemplate, err := convertInternalToExternal(template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.ClusterList{}
+		for i := 0; i < req.Body.Replicas; i++ {
+			createReq := cluster.CreateReq{
+				DCReq: req.DCReq,
+				Body: apiv1.CreateClusterSpec{
+					Cluster: apiTemplate.Cluster,
+				},
+			}
+			createReq.Body.Cluster.Name = fmt.Sprintf("%s-%s", apiTemplate.Cluster.Name, rand.String(5))
+			if len(apiTemplate.NodeDeployments) > 0 {
+				nd := apiTemplate.NodeDeployments[0]
+				createReq.Body.NodeDeployment = &nd
+			}
+
+			// the first node deployment is created by the cluster endpoint
+			rawCluster, err := createCluster(ctx, createReq)
+			if err != nil {
+				// the batch is created either completely or not at all
+				if rollbackErr := deleteInstances(ctx, req.ProjectID, req.DC, result, projectProvider, privilegedProjectProvider, userInfoGetter, seedsGetter, clusterProviderGetter); rollbackErr != nil {
+					return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to create cluster %d of %d: %v, the clusters created before could not be removed: %v", i+1, req.Body.Replicas, err, rollbackErr))
+				}
+				return nil, err
+			}
+			result = append(result, *rawCluster.(*apiv1.Cluster))
+		}
+
+		if len(apiTemplate.NodeDeployments) > 1 || len(apiTemplate.Addons) > 0 {
+			for _, newCluster := range result {
+				clusterCtx, err := instanceContext(ctx, req.DC, &newCluster, seedsGetter, clusterProviderGetter)
+				if err != nil {
+					return nil, err
+				}
+				clusterProvider := clusterCtx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+				privilegedClusterProvider := clusterCtx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+				internalCluster, err := cluster.GetCluster(clusterCtx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, newCluster.ID, nil)
+				if err != nil {
+					return nil, err
+				}
+				go func() {
+					defer utilruntime.HandleCrash()
+					createTemplateResources(clusterCtx, apiTemplate, internalCluster, project, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
+				}()
+			}
+		}
+
+		return result, nil
+	}
+}
+
+// instanceContext returns the request context with the cluster providers of the seed the
+// cluster was placed on, which might be another one than the seed from the request path
+func instanceContext(ctx context.Context, dc string, newCluster *apiv1.Cluster, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter) (context.Context, error) {
+	if newCluster.Status.Seed == "" || newCluster.Status.Seed == dc {
+		return ctx, nil
+	}
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	seed, ok := seeds[newCluster.Status.Seed]
+	if !ok {
+		return nil, errors.NewNotFound("seed", newCluster.Status.Seed)
+	}
+	return middleware.WithSeedClusterProvider(ctx, seed, clusterProviderGetter)
+}
+
+// deleteInstances removes the given clusters created from a template
+func deleteInstances(ctx context.Context, projectID, dc string, clusters apiv1.ClusterList, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return err
+	}
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, c := range clusters {
+		clusterCtx, err := instanceContext(ctx, dc, &c, seedsGetter, clusterProviderGetter)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cluster %s: %v", c.ID, err))
+			continue
+		}
+
+		if adminUserInfo.IsAdmin {
+			privilegedClusterProvider := clusterCtx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+			internalCluster, err := cluster.GetCluster(clusterCtx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, c.ID, nil)
+			if err == nil {
+				err = privilegedClusterProvider.DeleteUnsecured(internalCluster)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cluster %s: %v", c.ID, err))
+			}
+			continue
+		}
+
+		clusterProvider := clusterCtx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		if err := clusterProvider.Delete(userInfo, c.ID); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %s: %v", c.ID, err))
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+// createTemplateResources creates the additional node deployments and the addons of the template
+// in the given cluster once it is initialized
+func createTemplateResources(ctx context.Context, template *apiv1.ClusterTemplate, internalCluster *kubermaticv1.Cluster, project *kubermaticv1.Project,
+	sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+	clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, userInfoGetter provider.UserInfoGetter) {
+	for i := 1; i < len(template.NodeDeployments); i++ {
+		nd := template.NodeDeployments[i]
+		if err := cluster.CreateInitialNodeDeploymentWithRetries(ctx, &nd, internalCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter); err != nil {
+			klog.Errorf("failed to create node deployment %s from template %s for cluster %s: %v", nd.Name, template.ID, internalCluster.Name, err)
+		}
+	}
+
+	if len(template.Addons) == 0 {
+		return
+	}
+	var initializedCluster *kubermaticv1.Cluster
+	if err := wait.Poll(5*time.Second, 30*time.Minute, func() (bool, error) {
+		var err error
+		initializedCluster, err = cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, project.Name, internalCluster.Name, &provider.ClusterGetOptions{CheckInitStatus: true})
+		return err == nil, nil
+	}); err != nil {
+		klog.Errorf("failed to create addons from template %s for cluster %s: cluster did not become ready: %v", template.ID, internalCluster.Name, err)
+		return
+	}
+	for _, a := range template.Addons {
+		rawVars, err := convertVariablesToInternal(a.Spec.Variables)
+		if err != nil {
+			klog.Errorf("failed to create addon %s from template %s for cluster %s: %v", a.Name, template.ID, internalCluster.Name, err)
+			continue
+		}
+		if _, err := addon.CreateAddon(ctx, userInfoGetter, initializedCluster, rawVars, project.Name, a.Name); err != nil && !kerrors.IsAlreadyExists(err) {
+			klog.Errorf("failed to create addon %s from template %s for cluster %s: %v", a.Name, template.ID, internalCluster.Name, err)
+		}
+	}
+}
+
+func getTemplate(ctx context.Context, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter,
+	clusterTemplateProvider provider.ClusterTemplateProvider, projectID, templateID string) (*kubermaticv1.ClusterTemplate, error) {
+	if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	template, err := clusterTemplateProvider.Get(userInfo, projectID, templateID)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	return template, nil
+}
+
+func createTemplate(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterTemplateProvider provider.ClusterTemplateProvider, template *kubermaticv1.ClusterTemplate) (*apiv1.ClusterTemplate, error) {
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	template, err = clusterTemplateProvider.New(userInfo, template)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	return convertInternalToExternal(template)
+}
+
+func convertExternalToInternal(projectID string, template *apiv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error) {
+	internal := &kubermaticv1.ClusterTemplate{
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: template.Name,
+			Scope:             kubermaticv1.ClusterTemplateScope(template.Scope),
+			ClusterType:       template.Cluster.Type,
+			ClusterLabels:     template.Cluster.Labels,
+			Credential:        template.Cluster.Credential,
+			Cluster: kubermaticv1.ClusterSpec{
+				HumanReadableName:                   template.Cluster.Name,
+				Cloud:                               templateCloudSpec(template.Cluster.Spec.Cloud),
+				Version:                             template.Cluster.Spec.Version,
+				MachineNetworks:                     template.Cluster.Spec.MachineNetworks,
+				OIDC:                                template.Cluster.Spec.OIDC,
+				UpdateWindow:                        template.Cluster.Spec.UpdateWindow,
+				AuditLogging:                        template.Cluster.Spec.AuditLogging,
+				UsePodSecurityPolicyAdmissionPlugin: template.Cluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   template.Cluster.Spec.UsePodNodeSelectorAdmissionPlugin,
+				AdmissionPlugins:                    template.Cluster.Spec.AdmissionPlugins,
+				Openshift:                           template.Cluster.Spec.Openshift,
+			},
+		},
+	}
+	internal.Name = rand.String(10)
+	if internal.Spec.Scope == kubermaticv1.ClusterTemplateScopeProject {
+		internal.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+	}
+	if internal.Spec.Cluster.HumanReadableName == "" {
+		internal.Spec.Cluster.HumanReadableName = template.Name
+	}
+
+	for _, a := range template.Addons {
+		rawVars, err := convertVariablesToInternal(a.Spec.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variables of addon %s: %v", a.Name, err)
+		}
+		internal.Spec.Addons = append(internal.Spec.Addons, kubermaticv1.ClusterTemplateAddon{Name: a.Name, Variables: rawVars})
+	}
+	for _, nd := range template.NodeDeployments {
+		raw, err := json.Marshal(apiv1.NodeDeployment{ObjectMeta: apiv1.ObjectMeta{Name: nd.Name}, Spec: nd.Spec})
+		if err != nil {
+			return nil, fmt.Errorf("invalid node deployment %s: %v", nd.Name, err)
+		}
+		internal.Spec.NodeDeployments = append(internal.Spec.NodeDeployments, runtime.RawExtension{Raw: raw})
+	}
+
+	return internal, nil
+}
+
+func convertInternalToExternal(template *kubermaticv1.ClusterTemplate) (*apiv1.ClusterTemplate, error) {
+	result := &apiv1.ClusterTemplate{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                template.Name,
+			Name:              template.Spec.HumanReadableName,
+			CreationTimestamp: apiv1.NewTime(template.CreationTimestamp.Time),
+		},
+		Scope:     string(template.Spec.Scope),
+		ProjectID: template.Labels[kubermaticv1.ProjectIDLabelKey],
+		Cluster: apiv1.Cluster{
+			ObjectMeta: apiv1.ObjectMeta{
+				Name: template.Spec.Cluster.HumanReadableName,
+			},
+			Labels:     template.Spec.ClusterLabels,
+			Type:       template.Spec.ClusterType,
+			Credential: template.Spec.Credential,
+			Spec: apiv1.ClusterSpec{
+				Cloud:                               template.Spec.Cluster.Cloud,
+				Version:                             template.Spec.Cluster.Version,
+				MachineNetworks:                     template.Spec.Cluster.MachineNetworks,
+				OIDC:                                template.Spec.Cluster.OIDC,
+				UpdateWindow:                        template.Spec.Cluster.UpdateWindow,
+				AuditLogging:                        template.Spec.Cluster.AuditLogging,
+				UsePodSecurityPolicyAdmissionPlugin: template.Spec.Cluster.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   template.Spec.Cluster.UsePodNodeSelectorAdmissionPlugin,
+				AdmissionPlugins:                    template.Spec.Cluster.AdmissionPlugins,
+				Openshift:                           template.Spec.Cluster.Openshift,
+			},
+		},
+	}
+	if template.DeletionTimestamp != nil {
+		deletionTimestamp := apiv1.NewTime(template.DeletionTimestamp.Time)
+		result.DeletionTimestamp = &deletionTimestamp
+	}
+
+	for _, a := range template.Spec.Addons {
+		templateAddon := apiv1.Addon{ObjectMeta: apiv1.ObjectMeta{ID: a.Name, Name: a.Name}}
+		if a.Variables != nil && len(a.Variables.Raw) > 0 {
+			if err := json.Unmarshal(a.Variables.Raw, &templateAddon.Spec.Variables); err != nil {
+				return nil, fmt.Errorf("failed to read variables of addon %s: %v", a.Name, err)
+			}
+		}
+		result.Addons = append(result.Addons, templateAddon)
+	}
+	for _, raw := range template.Spec.NodeDeployments {
+		nd := apiv1.NodeDeployment{}
+		if err := json.Unmarshal(raw.Raw, &nd); err != nil {
+			return nil, fmt.Errorf("failed to read node deployment: %v", err)
+		}
+		result.NodeDeployments = append(result.NodeDeployments, nd)
+	}
+
+	return result, nil
+}
+
+func convertVariablesToInternal(variables map[string]interface{}) (*runtime.RawExtension, error) {
+	raw, err := json.Marshal(variables)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+// templateCloudSpec keeps only the datacenter and the kind of the cloud provider, templates never
+// contain credentials or resources of a particular cluster, they are provided by the preset instead
+func templateCloudSpec(cloud kubermaticv1.CloudSpec) kubermaticv1.CloudSpec {
+	result := kubermaticv1.CloudSpec{DatacenterName: cloud.DatacenterName}
+	switch {
+	case cloud.Fake != nil:
+		result.Fake = &kubermaticv1.FakeCloudSpec{}
+	case cloud.Digitalocean != nil:
+		result.Digitalocean = &kubermaticv1.DigitaloceanCloudSpec{}
+	case cloud.BringYourOwn != nil:
+		result.BringYourOwn = &kubermaticv1.BringYourOwnCloudSpec{}
+	case cloud.AWS != nil:
+		result.AWS = &kubermaticv1.AWSCloudSpec{}
+	case cloud.Azure != nil:
+		result.Azure = &kubermaticv1.AzureCloudSpec{}
+	case cloud.Openstack != nil:
+		result.Openstack = &kubermaticv1.OpenstackCloudSpec{}
+	case cloud.Packet != nil:
+		result.Packet = &kubermaticv1.PacketCloudSpec{}
+	case cloud.Hetzner != nil:
+		result.Hetzner = &kubermaticv1.HetznerCloudSpec{}
+	case cloud.VSphere != nil:
+		result.VSphere = &kubermaticv1.VSphereCloudSpec{}
+	case cloud.GCP != nil:
+		result.GCP = &kubermaticv1.GCPCloudSpec{}
+	case cloud.Kubevirt != nil:
+		result.Kubevirt = &kubermaticv1.KubevirtCloudSpec{}
+	case cloud.Alibaba != nil:
+		result.Alibaba = &kubermaticv1.AlibabaCloudSpec{}
+	}
+	return result
+}
+
+func validateScope(scope string) error {
+	switch kubermaticv1.ClusterTemplateScope(scope) {
+	case kubermaticv1.ClusterTemplateScopeGlobal, kubermaticv1.ClusterTemplateScopeProject:
+		return nil
+	}
+	return fmt.Errorf("invalid scope %q, must be one of %q or %q", scope, kubermaticv1.ClusterTemplateScopeGlobal, kubermaticv1.ClusterTemplateScopeProject)
+}
+
+// createReq defines HTTP request for createClusterTemplate
+// swagger:parameters createClusterTemplate
+type createReq struct {
+	common.ProjectReq
+	// in: body
+	Body apiv1.ClusterTemplate
+}
+
+// Validate validates createReq request
+func (r createReq) Validate() error {
+	if len(r.Body.Name) == 0 {
+		return fmt.Errorf("the template name cannot be empty")
+	}
+	if len(r.Body.Cluster.Spec.Cloud.DatacenterName) == 0 {
+		return fmt.Errorf("the template datacenter cannot be empty")
+	}
+	return validateScope(r.Body.Scope)
+}
+
+func DecodeCreateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+	if len(req.Body.Cluster.Type) == 0 {
+		req.Body.Cluster.Type = apiv1.KubernetesClusterType
+	}
+
+	return req, nil
+}
+
+// getReq defines HTTP request for getClusterTemplate and deleteClusterTemplate
+// swagger:parameters getClusterTemplate deleteClusterTemplate
+type getReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	TemplateID string `json:"template_id"`
+}
+
+func DecodeGetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req getReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	req.TemplateID, err = decodeTemplateID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// createFromClusterReq defines HTTP request for createClusterTemplateFromCluster
+// swagger:parameters createClusterTemplateFromCluster
+type createFromClusterReq struct {
+	common.GetClusterReq
+	// in: body
+	Body createFromClusterBody
+}
+
+// createFromClusterBody is the body of the createClusterTemplateFromCluster request
+type createFromClusterBody struct {
+	// Name is the name of the template
+	Name string `json:"name"`
+	// Scope is either "global" or "project"
+	Scope string `json:"scope"`
+	// Credential is the name of the preset used for the clusters created from the template
+	Credential string `json:"credential,omitempty"`
+}
+
+// Validate validates createFromClusterReq request
+func (r createFromClusterReq) Validate() error {
+	if len(r.Body.Name) == 0 {
+		return fmt.Errorf("the template name cannot be empty")
+	}
+	return validateScope(r.Body.Scope)
+}
+
+func DecodeCreateFromClusterReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createFromClusterReq
+
+	cr, err := common.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = cr.(common.GetClusterReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+// createInstancesReq defines HTTP request for createClusterTemplateInstances
+// swagger:parameters createClusterTemplateInstances
+type createInstancesReq struct {
+	common.DCReq
+	// in: path
+	// required: true
+	TemplateID string `json:"template_id"`
+	// in: body
+	Body apiv1.ClusterTemplateInstances
+}
+
+func DecodeCreateInstancesReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createInstancesReq
+
+	dcr, err := common.DecodeDcReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.DCReq = dcr.(common.DCReq)
+
+	req.TemplateID, err = decodeTemplateID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+func decodeTemplateID(r *http.Request) (string, error) {
+	templateID := mux.Vars(r)["template_id"]
+	if templateID == "" {
+		return "", fmt.Errorf("'template_id' parameter is required but was not provided")
+	}
+
+	return templateID, nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+	"github.com/kubermatic/kubermatic/api/pkg/semver"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func genClusterTemplate(id, name string, scope kubermaticv1.ClusterTemplateScope, projectID string) *kubermaticv1.ClusterTemplate {
+	template := &kubermaticv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: id,
+		},
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: name,
+			Scope:             scope,
+			ClusterType:       apiv1.KubernetesClusterType,
+			Credential:        "fake",
+			Cluster: kubermaticv1.ClusterSpec{
+				HumanReadableName: "keen-snyder",
+				Cloud: kubermaticv1.CloudSpec{
+					DatacenterName: "fake-dc",
+					Fake:           &kubermaticv1.FakeCloudSpec{},
+				},
+				Version: *semver.NewSemverOrDie("1.15.0"),
+			},
+		},
+	}
+	if projectID != "" {
+		template.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+	}
+	return template
+}
+
+func TestCreateClusterTemplateEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+		RewriteTemplateID      bool
+	}{
+		{
+			Name:                   "scenario 1: project template is created without the cloud credentials",
+			Body:                   `{"name":"small","scope":"project","cluster":{"name":"keen-snyder","credential":"fake","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"small","creationTimestamp":"0001-01-01T00:00:00Z","scope":"project","projectID":"my-first-project-ID","cluster":{"name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","credential":"fake","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}}}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			RewriteTemplateID:      true,
+		},
+		{
+			Name:                   "scenario 2: regular user can't create a global template",
+			Body:                   `{"name":"small","scope":"global","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 3: template with invalid scope is rejected",
+			Body:                   `{"name":"small","scope":"seed","cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid scope \"seed\", must be one of \"global\" or \"project\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/clustertemplates", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since the template ID is automatically generated by the system just rewrite it.
+			if tc.RewriteTemplateID {
+				actualTemplate := &apiv1.ClusterTemplate{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualTemplate); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualTemplate.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func TestListClusterTemplatesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedTemplateIDs    []string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:       "scenario 1: list the templates of the project and the global ones",
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("globaltmpl", "global", kubermaticv1.ClusterTemplateScopeGlobal, ""),
+				genClusterTemplate("owntmpl", "own", kubermaticv1.ClusterTemplateScopeProject, test.GenDefaultProject().Name),
+				genClusterTemplate("othertmpl", "other", kubermaticv1.ClusterTemplateScopeProject, "my-second-project-ID"),
+			),
+			ExistingAPIUser:     test.GenDefaultAPIUser(),
+			ExpectedTemplateIDs: []string{"globaltmpl", "owntmpl"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/clustertemplates", test.GenDefaultProject().Name), strings.NewReader(""))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			templates := apiv1.ClusterTemplateList{}
+			if err := json.Unmarshal(res.Body.Bytes(), &templates); err != nil {
+				t.Fatal(err)
+			}
+			if len(templates) != len(tc.ExpectedTemplateIDs) {
+				t.Fatalf("expected %d templates, got %d: %s", len(tc.ExpectedTemplateIDs), len(templates), res.Body.String())
+			}
+			for i, template := range templates {
+				if template.ID != tc.ExpectedTemplateIDs[i] {
+					t.Fatalf("expected template %s, got %s", tc.ExpectedTemplateIDs[i], template.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateClusterTemplateInstancesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		TemplateID             string
+		Body                   string
+		ExpectedClusters       int
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:       "scenario 1: two clusters are created from a template",
+			TemplateID: "owntmpl",
+			Body:       `{"replicas":2}`,
+			HTTPStatus: http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("owntmpl", "own", kubermaticv1.ClusterTemplateScopeProject, test.GenDefaultProject().Name),
+			),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExpectedClusters: 2,
+		},
+		{
+			Name:       "scenario 2: the template of another project can't be used",
+			TemplateID: "othertmpl",
+			Body:       `{"replicas":1}`,
+			HTTPStatus: http.StatusNotFound,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("othertmpl", "other", kubermaticv1.ClusterTemplateScopeProject, "my-second-project-ID"),
+			),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExpectedResponse: `{"error":{"code":404,"message":"clustertemplates.kubermatic.k8s.io \"othertmpl\" not found"}}`,
+		},
+		{
+			Name:       "scenario 3: the number of replicas is validated",
+			TemplateID: "owntmpl",
+			Body:       `{"replicas":0}`,
+			HTTPStatus: http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("owntmpl", "own", kubermaticv1.ClusterTemplateScopeProject, test.GenDefaultProject().Name),
+			),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExpectedResponse: `{"error":{"code":400,"message":"the number of replicas must be between 1 and 10"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clustertemplates/%s/instances", test.GenDefaultProject().Name, tc.TemplateID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if len(tc.ExpectedResponse) > 0 {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			clusters := apiv1.ClusterList{}
+			if err := json.Unmarshal(res.Body.Bytes(), &clusters); err != nil {
+				t.Fatal(err)
+			}
+			if len(clusters) != tc.ExpectedClusters {
+				t.Fatalf("expected %d clusters, got %d: %s", tc.ExpectedClusters, len(clusters), res.Body.String())
+			}
+			for _, cluster := range clusters {
+				if !strings.HasPrefix(cluster.Name, "keen-snyder-") {
+					t.Fatalf("expected the cluster name to be derived from the template, got %s", cluster.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateClusterTemplateInstancesRollback(t *testing.T) {
+	t.Parallel()
+
+	// the seed only has room for a single cluster, so the second instance fails
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		seed := test.GenTestSeed()
+		seed.Spec.Datacenters["fake-dc"] = kubermaticv1.Datacenter{
+			Spec: kubermaticv1.DatacenterSpec{
+				Fake:      &kubermaticv1.DatacenterSpecFake{},
+				Placement: &kubermaticv1.DatacenterPlacement{MaxClustersPerSeed: 1},
+			},
+		}
+		return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+	}
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "seed-node"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clustertemplates/owntmpl/instances", test.GenDefaultProject().Name), strings.NewReader(`{"replicas":2}`))
+	res := httptest.NewRecorder()
+
+	kubermaticObjs := test.GenDefaultKubermaticObjects(
+		genClusterTemplate("owntmpl", "own", kubermaticv1.ClusterTemplateScopeProject, test.GenDefaultProject().Name),
+	)
+	ep, clients, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), seedsGetter, []runtime.Object{readyNode}, nil, kubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusServiceUnavailable, res.Code, res.Body.String())
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := clients.FakeClient.List(context.Background(), clusters); err != nil {
+		t.Fatalf("failed to list clusters: %v", err)
+	}
+	for _, cluster := range clusters.Items {
+		if cluster.DeletionTimestamp == nil {
+			t.Fatalf("expected the already created cluster %s to be removed", cluster.Name)
+		}
+	}
+}
@@ -38,7 +38,9 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
 	corev1interface "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
@@ -99,6 +101,7 @@ type CredentialsData struct {
 	Ctx               context.Context
 	KubermaticCluster *kubermaticv1.Cluster
 	Client            ctrlruntimeclient.Client
+	SecretStores      *provider.SecretStores
 }
 
 func (d CredentialsData) Cluster() *kubermaticv1.Cluster {
@@ -106,7 +109,7 @@ func (d CredentialsData) Cluster() *kubermaticv1.Cluster {
 }
 
 func (d CredentialsData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
-	return provider.SecretKeySelectorValueFuncFactory(d.Ctx, d.Client)(configVar, key)
+	return provider.SecretKeySelectorValueFuncFactory(d.Ctx, d.Client, d.SecretStores)(configVar, key)
 }
 
 // GetReadyPod returns a pod matching provided label selector if it is posting ready status, error otherwise.
@@ -311,3 +314,63 @@ func GetClusterClient(ctx context.Context, userInfoGetter provider.UserInfoGette
 	}
 	return clusterProvider.GetClientForCustomerCluster(userInfo, cluster)
 }
+
+// GetClusterK8sClient returns a kubernetes clientset for the given user cluster. Like GetClusterClient
+// it uses admin privileges for admins and impersonates the user otherwise.
+func GetClusterK8sClient(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, projectID string) (kubernetes.Interface, error) {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user information: %v", err)
+	}
+	if adminUserInfo.IsAdmin {
+		return clusterProvider.GetAdminK8sClientForCustomerCluster(cluster)
+	}
+
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user information: %v", err)
+	}
+	return clusterProvider.GetK8sClientForCustomerCluster(userInfo, cluster)
+}
+
+// RevokeShortLivedKubeconfigs removes the permissions of the short-lived kubeconfigs the given user got for the
+// clusters of the given project, or for the clusters of all projects if no project is given.
+func RevokeShortLivedKubeconfigs(clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, project *kubermaticv1.Project, email string) error {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return fmt.Errorf("failed to list seeds: %v", err)
+	}
+
+	var errs []error
+	for seedName, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get the cluster provider of seed %s: %v", seedName, err))
+			continue
+		}
+
+		var clusters *kubermaticv1.ClusterList
+		if project != nil {
+			clusters, err = clusterProvider.List(project, nil)
+		} else {
+			clusters, err = clusterProvider.ListAll()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list the clusters of seed %s: %v", seedName, err))
+			continue
+		}
+
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+			// No kubeconfig can have been handed out for a cluster which never got an address
+			if cluster.Address.URL == "" {
+				continue
+			}
+			if err := clusterProvider.RevokeShortLivedKubeconfigs(cluster, email); err != nil {
+				errs = append(errs, fmt.Errorf("failed to revoke the kubeconfigs for cluster %s: %v", cluster.Name, err))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -27,22 +27,33 @@ import (
 func ConvertInternalSSHKeysToExternal(internalKeys []*kubermaticapiv1.UserSSHKey) []*apiv1.SSHKey {
 	apiKeys := make([]*apiv1.SSHKey, len(internalKeys))
 	for index, key := range internalKeys {
-		apiKey := &apiv1.SSHKey{
-			ObjectMeta: apiv1.ObjectMeta{
-				ID:                key.Name,
-				Name:              key.Spec.Name,
-				CreationTimestamp: apiv1.NewTime(key.CreationTimestamp.Time),
-			},
-			Spec: apiv1.SSHKeySpec{
-				Fingerprint: key.Spec.Fingerprint,
-				PublicKey:   key.Spec.PublicKey,
-			},
-		}
-		apiKeys[index] = apiKey
+		apiKeys[index] = ConvertInternalSSHKeyToExternal(key)
 	}
 	return apiKeys
 }
 
+// ConvertInternalSSHKeyToExternal converts a single ssh key to its API representation
+func ConvertInternalSSHKeyToExternal(key *kubermaticapiv1.UserSSHKey) *apiv1.SSHKey {
+	apiKey := &apiv1.SSHKey{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                key.Name,
+			Name:              key.Spec.Name,
+			CreationTimestamp: apiv1.NewTime(key.CreationTimestamp.Time),
+		},
+		Spec: apiv1.SSHKeySpec{
+			Fingerprint:  key.Spec.Fingerprint,
+			PublicKey:    key.Spec.PublicKey,
+			User:         key.Spec.User,
+			NodeSelector: key.Spec.NodeSelector,
+		},
+	}
+	if key.Spec.ExpiresAt != nil {
+		expiresAt := apiv1.NewTime(key.Spec.ExpiresAt.Time)
+		apiKey.Spec.ExpiresAt = &expiresAt
+	}
+	return apiKey
+}
+
 // ConvertInternalEventToExternal converts Kubernetes Events to Kubermatic ones (used in the API).
 func ConvertInternalEventToExternal(event corev1.Event) apiv1.Event {
 	switch event.InvolvedObject.Kind {
@@ -18,6 +18,7 @@ package common
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +26,7 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 )
 
 // FilterEventsByType filters Kubernetes Events based on their type. Empty type string will return all of them.
@@ -61,3 +63,61 @@ func GetEvents(ctx context.Context, client ctrlruntimeclient.Client, obj metav1.
 
 	return kubermaticEvents, nil
 }
+
+// GetExportedEvents returns the events that got exported from the given user cluster to the seed.
+func GetExportedEvents(ctx context.Context, client ctrlruntimeclient.Client, clusterName string) ([]kubermaticapiv1.Event, error) {
+	clusterEvents := &kubermaticv1.ClusterEventList{}
+	if err := client.List(ctx, clusterEvents, ctrlruntimeclient.MatchingLabels{kubermaticv1.ClusterEventClusterLabelKey: clusterName}); err != nil {
+		return nil, err
+	}
+
+	kubermaticEvents := make([]kubermaticapiv1.Event, 0)
+	for _, clusterEvent := range clusterEvents.Items {
+		kubermaticEvent := ConvertInternalEventToExternal(corev1.Event{
+			ObjectMeta:     clusterEvent.ObjectMeta,
+			Type:           clusterEvent.Spec.Type,
+			Message:        clusterEvent.Spec.Message,
+			InvolvedObject: clusterEvent.Spec.InvolvedObject,
+			LastTimestamp:  clusterEvent.Spec.LastTimestamp,
+			Count:          clusterEvent.Spec.Count,
+		})
+		kubermaticEvent.Source = kubermaticapiv1.EventSourceUserCluster
+		kubermaticEvents = append(kubermaticEvents, kubermaticEvent)
+	}
+
+	return kubermaticEvents, nil
+}
+
+// FilterEventsByInvolvedObject filters Kubernetes Events based on the type, namespace and name of their involved object.
+// Empty values match all of them.
+func FilterEventsByInvolvedObject(events []kubermaticapiv1.Event, objectType, namespace, name string) []kubermaticapiv1.Event {
+	resultEvents := make([]kubermaticapiv1.Event, 0)
+	for _, event := range events {
+		if objectType != "" && event.InvolvedObject.Type != objectType {
+			continue
+		}
+		if namespace != "" && event.InvolvedObject.Namespace != namespace {
+			continue
+		}
+		if name != "" && event.InvolvedObject.Name != name {
+			continue
+		}
+		resultEvents = append(resultEvents, event)
+	}
+	return resultEvents
+}
+
+// FilterEventsByTime filters Kubernetes Events based on the time they occurred the last time. Zero times are not taken into account.
+func FilterEventsByTime(events []kubermaticapiv1.Event, since, until time.Time) []kubermaticapiv1.Event {
+	resultEvents := make([]kubermaticapiv1.Event, 0)
+	for _, event := range events {
+		if !since.IsZero() && event.LastTimestamp.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && event.LastTimestamp.Time.After(until) {
+			continue
+		}
+		resultEvents = append(resultEvents, event)
+	}
+	return resultEvents
+}
@@ -0,0 +1,515 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kindRegexp matches the kinds gatekeeper accepts for constraint templates
+var kindRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+
+func ListConstraintTemplatesEndpoint(userInfoGetter provider.UserInfoGetter, constraintTemplateProvider provider.ConstraintTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		templates, err := constraintTemplateProvider.List(userInfo)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.ConstraintTemplateList{}
+		for i := range templates {
+			template, err := convertInternalTemplateToExternal(&templates[i])
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			result = append(result, *template)
+		}
+		return result, nil
+	}
+}
+
+func CreateConstraintTemplateEndpoint(userInfoGetter provider.UserInfoGetter, constraintTemplateProvider provider.ConstraintTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createConstraintTemplateReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+
+		template, err := convertExternalTemplateToInternal(&req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid constraint template: %v", err)
+		}
+
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err = constraintTemplateProvider.Create(userInfo, template)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertInternalTemplateToExternal(template)
+	}
+}
+
+func DeleteConstraintTemplateEndpoint(userInfoGetter provider.UserInfoGetter, constraintTemplateProvider provider.ConstraintTemplateProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(constraintTemplateReq)
+
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if err := constraintTemplateProvider.Delete(userInfo, req.Name); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return nil, nil
+	}
+}
+
+func ListConstraintsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, constraintProvider provider.ConstraintProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetProjectRq)
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		constraints, err := constraintProvider.List(userInfo, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := apiv1.ConstraintList{}
+		for i := range constraints {
+			constraint, err := convertInternalConstraintToExternal(&constraints[i])
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			result = append(result, *constraint)
+		}
+		return result, nil
+	}
+}
+
+func CreateConstraintEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter,
+	constraintTemplateProvider provider.ConstraintTemplateProvider, constraintProvider provider.ConstraintProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createConstraintReq)
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := getProjectUserInfo(ctx, userInfoGetter, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if _, err := constraintTemplateProvider.Get(userInfo, req.Body.ConstraintTemplate); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, errors.NewBadRequest("constraint template %q does not exist", req.Body.ConstraintTemplate)
+			}
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		constraint, err := convertExternalConstraintToInternal(req.ProjectID, &req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid constraint: %v", err)
+		}
+
+		constraint, err = constraintProvider.New(userInfo, constraint)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertInternalConstraintToExternal(constraint)
+	}
+}
+
+func DeleteConstraintEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, constraintProvider provider.ConstraintProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(constraintReq)
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := getProjectUserInfo(ctx, userInfoGetter, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		if err := constraintProvider.Delete(userInfo, req.ProjectID, req.ConstraintID); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return nil, nil
+	}
+}
+
+// getProjectUserInfo returns the user info with the group the user has in the given project,
+// the constraint provider needs it to check if the user is allowed to manage constraints
+func getProjectUserInfo(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string) (*provider.UserInfo, error) {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if adminUserInfo.IsAdmin {
+		return adminUserInfo, nil
+	}
+	return userInfoGetter(ctx, projectID)
+}
+
+// ListViolationsEndpoint lists the violations of the Kubermatic managed constraints
+// reported by the gatekeeper audit controller in the user cluster
+func ListViolationsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetClusterReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+		c, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Project members usually can't read the gatekeeper objects, access to the
+		// cluster was checked already, so the admin client is used to read them
+		client, err := clusterProvider.GetAdminClientForCustomerCluster(c)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return listViolations(ctx, client)
+	}
+}
+
+func listViolations(ctx context.Context, client ctrlruntimeclient.Client) (apiv1.ConstraintViolationList, error) {
+	result := apiv1.ConstraintViolationList{}
+
+	templates := &unstructured.UnstructuredList{}
+	templates.SetGroupVersionKind(gatekeeper.ConstraintTemplateGVK.GroupVersion().WithKind(gatekeeper.ConstraintTemplateGVK.Kind + "List"))
+	if err := client.List(ctx, templates, ctrlruntimeclient.MatchingLabels{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue}); err != nil {
+		// gatekeeper is not installed
+		if meta.IsNoMatchError(err) {
+			return result, nil
+		}
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	for _, template := range templates.Items {
+		kind, _, err := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+		if err != nil || kind == "" {
+			continue
+		}
+
+		constraints := &unstructured.UnstructuredList{}
+		constraints.SetGroupVersionKind(gatekeeper.ConstraintGroupVersion.WithKind(kind + "List"))
+		if err := client.List(ctx, constraints, ctrlruntimeclient.MatchingLabels{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue}); err != nil {
+			// gatekeeper didn't create the CRD for the template yet
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		for _, constraint := range constraints.Items {
+			result = append(result, violationsFromConstraint(&constraint)...)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Constraint != result[j].Constraint {
+			return result[i].Constraint < result[j].Constraint
+		}
+		return result[i].Namespace+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Name
+	})
+	return result, nil
+}
+
+func violationsFromConstraint(constraint *unstructured.Unstructured) []apiv1.ConstraintViolation {
+	violations, _, err := unstructured.NestedSlice(constraint.Object, "status", "violations")
+	if err != nil {
+		return nil
+	}
+
+	var result []apiv1.ConstraintViolation
+	for _, v := range violations {
+		violation, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, apiv1.ConstraintViolation{
+			Constraint:        constraint.GetName(),
+			ConstraintKind:    constraint.GetKind(),
+			EnforcementAction: stringField(violation, "enforcementAction"),
+			Kind:              stringField(violation, "kind"),
+			Namespace:         stringField(violation, "namespace"),
+			Name:              stringField(violation, "name"),
+			Message:           stringField(violation, "message"),
+		})
+	}
+	return result
+}
+
+func stringField(obj map[string]interface{}, field string) string {
+	value, _ := obj[field].(string)
+	return value
+}
+
+func convertExternalTemplateToInternal(template *apiv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error) {
+	internal := &kubermaticv1.ConstraintTemplate{
+		Spec: kubermaticv1.ConstraintTemplateSpec{
+			Kind:    template.Kind,
+			Targets: template.Targets,
+		},
+	}
+	// gatekeeper requires the name of a template to be its lowercase kind
+	internal.Name = gatekeeper.ConstraintTemplateName(template.Kind)
+
+	if len(template.Parameters) > 0 {
+		raw, err := json.Marshal(template.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters: %v", err)
+		}
+		internal.Spec.Parameters = runtime.RawExtension{Raw: raw}
+	}
+	return internal, nil
+}
+
+func convertInternalTemplateToExternal(template *kubermaticv1.ConstraintTemplate) (*apiv1.ConstraintTemplate, error) {
+	result := &apiv1.ConstraintTemplate{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                template.Name,
+			Name:              template.Name,
+			CreationTimestamp: apiv1.NewTime(template.CreationTimestamp.Time),
+		},
+		Kind:    template.Spec.Kind,
+		Targets: template.Spec.Targets,
+	}
+	if template.DeletionTimestamp != nil {
+		deletionTimestamp := apiv1.NewTime(template.DeletionTimestamp.Time)
+		result.DeletionTimestamp = &deletionTimestamp
+	}
+	if len(template.Spec.Parameters.Raw) > 0 {
+		if err := json.Unmarshal(template.Spec.Parameters.Raw, &result.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to read parameters of constraint template %s: %v", template.Name, err)
+		}
+	}
+	return result, nil
+}
+
+func convertExternalConstraintToInternal(projectID string, constraint *apiv1.Constraint) (*kubermaticv1.Constraint, error) {
+	internal := &kubermaticv1.Constraint{
+		Spec: kubermaticv1.ConstraintSpec{
+			Name:               constraint.Name,
+			ConstraintTemplate: constraint.ConstraintTemplate,
+			Clusters:           constraint.Clusters,
+			EnforcementAction:  constraint.EnforcementAction,
+			Match:              constraint.Match,
+		},
+	}
+	internal.Name = rand.String(10)
+	internal.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+
+	if len(constraint.Parameters) > 0 {
+		raw, err := json.Marshal(constraint.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters: %v", err)
+		}
+		internal.Spec.Parameters = runtime.RawExtension{Raw: raw}
+	}
+	return internal, nil
+}
+
+func convertInternalConstraintToExternal(constraint *kubermaticv1.Constraint) (*apiv1.Constraint, error) {
+	result := &apiv1.Constraint{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                constraint.Name,
+			Name:              constraint.Spec.Name,
+			CreationTimestamp: apiv1.NewTime(constraint.CreationTimestamp.Time),
+		},
+		ProjectID:          constraint.Labels[kubermaticv1.ProjectIDLabelKey],
+		ConstraintTemplate: constraint.Spec.ConstraintTemplate,
+		Clusters:           constraint.Spec.Clusters,
+		EnforcementAction:  constraint.Spec.EnforcementAction,
+		Match:              constraint.Spec.Match,
+	}
+	if constraint.DeletionTimestamp != nil {
+		deletionTimestamp := apiv1.NewTime(constraint.DeletionTimestamp.Time)
+		result.DeletionTimestamp = &deletionTimestamp
+	}
+	if len(constraint.Spec.Parameters.Raw) > 0 {
+		if err := json.Unmarshal(constraint.Spec.Parameters.Raw, &result.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to read parameters of constraint %s: %v", constraint.Name, err)
+		}
+	}
+	return result, nil
+}
+
+// createConstraintTemplateReq defines HTTP request for createConstraintTemplate
+// swagger:parameters createConstraintTemplate
+type createConstraintTemplateReq struct {
+	// in: body
+	Body apiv1.ConstraintTemplate
+}
+
+// Validate validates createConstraintTemplateReq request
+func (r createConstraintTemplateReq) Validate() error {
+	if !kindRegexp.MatchString(r.Body.Kind) {
+		return fmt.Errorf("invalid kind %q, must start with an upper case letter and only contain alphanumeric characters", r.Body.Kind)
+	}
+	if len(r.Body.Targets) == 0 {
+		return fmt.Errorf("the constraint template must have at least one target")
+	}
+	for _, target := range r.Body.Targets {
+		if target.Target == "" || target.Rego == "" {
+			return fmt.Errorf("the target and rego of a constraint template target cannot be empty")
+		}
+	}
+	return nil
+}
+
+func DecodeCreateConstraintTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createConstraintTemplateReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+// constraintTemplateReq defines HTTP request for deleteConstraintTemplate
+// swagger:parameters deleteConstraintTemplate
+type constraintTemplateReq struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+func DecodeConstraintTemplateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req constraintTemplateReq
+
+	req.Name = mux.Vars(r)["name"]
+	if req.Name == "" {
+		return nil, fmt.Errorf("'name' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
+
+// createConstraintReq defines HTTP request for createConstraint
+// swagger:parameters createConstraint
+type createConstraintReq struct {
+	common.ProjectReq
+	// in: body
+	Body apiv1.Constraint
+}
+
+// Validate validates createConstraintReq request
+func (r createConstraintReq) Validate() error {
+	if errs := validation.IsDNS1123Subdomain(r.Body.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid constraint name %q: %v", r.Body.Name, errs)
+	}
+	if r.Body.ConstraintTemplate == "" {
+		return fmt.Errorf("the constraint template cannot be empty")
+	}
+	switch r.Body.EnforcementAction {
+	case "", "deny", "dryrun":
+	default:
+		return fmt.Errorf("invalid enforcement action %q, must be one of \"deny\" or \"dryrun\"", r.Body.EnforcementAction)
+	}
+	return nil
+}
+
+func DecodeCreateConstraintReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createConstraintReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
+
+// constraintReq defines HTTP request for deleteConstraint
+// swagger:parameters deleteConstraint
+type constraintReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ConstraintID string `json:"constraint_id"`
+}
+
+func DecodeConstraintReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req constraintReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	req.ConstraintID = mux.Vars(r)["constraint_id"]
+	if req.ConstraintID == "" {
+		return nil, fmt.Errorf("'constraint_id' parameter is required but was not provided")
+	}
+
+	return req, nil
+}
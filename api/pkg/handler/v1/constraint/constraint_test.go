@@ -0,0 +1,233 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraint_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func genAdminUser() *kubermaticv1.User {
+	user := test.GenDefaultUser()
+	user.Spec.IsAdmin = true
+	return user
+}
+
+func genConstraintTemplate(kind string) *kubermaticv1.ConstraintTemplate {
+	return &kubermaticv1.ConstraintTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(kind),
+		},
+		Spec: kubermaticv1.ConstraintTemplateSpec{
+			Kind: kind,
+			Targets: []kubermaticv1.ConstraintTemplateTarget{
+				{Target: "admission.k8s.gatekeeper.sh", Rego: "package k8srequiredlabels"},
+			},
+		},
+	}
+}
+
+func genConstraint(id, name, projectID string) *kubermaticv1.Constraint {
+	return &kubermaticv1.Constraint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   id,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: projectID},
+		},
+		Spec: kubermaticv1.ConstraintSpec{
+			Name:               name,
+			ConstraintTemplate: "k8srequiredlabels",
+		},
+	}
+}
+
+func TestCreateConstraintTemplateEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:                   "scenario 1: admin can create a constraint template",
+			Body:                   `{"kind":"K8sRequiredLabels","parameters":{"properties":{"labels":{"type":"array"}}},"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels"}]}`,
+			ExpectedResponse:       `{"id":"k8srequiredlabels","name":"k8srequiredlabels","creationTimestamp":"0001-01-01T00:00:00Z","kind":"K8sRequiredLabels","parameters":{"properties":{"labels":{"type":"array"}}},"targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels"}]}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: []runtime.Object{genAdminUser()},
+			ExistingAPIUser:        test.GenDefaultAdminAPIUser(),
+		},
+		{
+			Name:                   "scenario 2: regular user can't create a constraint template",
+			Body:                   `{"kind":"K8sRequiredLabels","targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels"}]}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                   "scenario 3: template with invalid kind is rejected",
+			Body:                   `{"kind":"k8s-required-labels","targets":[{"target":"admission.k8s.gatekeeper.sh","rego":"package k8srequiredlabels"}]}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid kind \"k8s-required-labels\", must start with an upper case letter and only contain alphanumeric characters"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: []runtime.Object{genAdminUser()},
+			ExistingAPIUser:        test.GenDefaultAdminAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/admin/constrainttemplates", strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestCreateConstraintEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		RewriteConstraintID    bool
+	}{
+		{
+			Name:                   "scenario 1: constraint is created in the project",
+			Body:                   `{"name":"must-have-owner","constraintTemplate":"k8srequiredlabels","enforcementAction":"dryrun","match":{"kinds":[{"apiGroups":[""],"kinds":["Namespace"]}]},"parameters":{"labels":["owner"]}}`,
+			ExpectedResponse:       `{"id":"%s","name":"must-have-owner","creationTimestamp":"0001-01-01T00:00:00Z","projectID":"my-first-project-ID","constraintTemplate":"k8srequiredlabels","enforcementAction":"dryrun","match":{"kinds":[{"apiGroups":[""],"kinds":["Namespace"]}]},"parameters":{"labels":["owner"]}}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genConstraintTemplate("K8sRequiredLabels")),
+			RewriteConstraintID:    true,
+		},
+		{
+			Name:                   "scenario 2: constraint of unknown template is rejected",
+			Body:                   `{"name":"must-have-owner","constraintTemplate":"k8srequiredlabels"}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"constraint template \"k8srequiredlabels\" does not exist"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+		{
+			Name:                   "scenario 3: constraint with invalid enforcement action is rejected",
+			Body:                   `{"name":"must-have-owner","constraintTemplate":"k8srequiredlabels","enforcementAction":"warn"}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid enforcement action \"warn\", must be one of \"deny\" or \"dryrun\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genConstraintTemplate("K8sRequiredLabels")),
+		},
+		{
+			Name:             "scenario 4: constraint with the name of an existing constraint of the project is rejected",
+			Body:             `{"name":"must-have-owner","constraintTemplate":"k8srequiredlabels"}`,
+			ExpectedResponse: `{"error":{"code":409,"message":"constraints.kubermatic.k8s.io \"must-have-owner\" already exists"}}`,
+			HTTPStatus:       http.StatusConflict,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genConstraintTemplate("K8sRequiredLabels"),
+				genConstraint("existing", "must-have-owner", test.GenDefaultProject().Name),
+			),
+		},
+		{
+			Name:             "scenario 5: viewer of the project can't create a constraint",
+			Body:             `{"name":"must-have-owner","constraintTemplate":"k8srequiredlabels"}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"constraints.kubermatic.k8s.io \"my-first-project-ID\" is forbidden: \"bob@acme.com\" is not an owner or editor of the project"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenDefaultProject(),
+				test.GenDefaultUser(),
+				test.GenBinding(test.GenDefaultProject().Name, "bob@acme.com", "viewers"),
+				genConstraintTemplate("K8sRequiredLabels"),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/constraints", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since the constraint ID is automatically generated by the system just rewrite it.
+			if tc.RewriteConstraintID {
+				actualConstraint := &apiv1.Constraint{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualConstraint); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualConstraint.ID)
+			}
+
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func TestListConstraintsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/constraints", test.GenDefaultProject().Name), nil)
+	res := httptest.NewRecorder()
+
+	kubermaticObjs := test.GenDefaultKubermaticObjects(
+		genConstraint("own", "must-have-owner", test.GenDefaultProject().Name),
+		genConstraint("other", "must-have-team", "my-second-project-ID"),
+	)
+	ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, kubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, `[{"id":"own","name":"must-have-owner","creationTimestamp":"0001-01-01T00:00:00Z","projectID":"my-first-project-ID","constraintTemplate":"k8srequiredlabels","match":{}}]`)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraint
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/gatekeeper"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func genUnstructuredConstraint(name string, labels map[string]string, violations ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"violations": violations,
+		},
+	}}
+	obj.SetGroupVersionKind(gatekeeper.ConstraintGroupVersion.WithKind("K8sRequiredLabels"))
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestListViolations(t *testing.T) {
+	managed := map[string]string{gatekeeper.ManagedByLabel: gatekeeper.ManagedByValue}
+
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"crd": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"names": map[string]interface{}{"kind": "K8sRequiredLabels"},
+				},
+			},
+		},
+	}}
+	template.SetGroupVersionKind(gatekeeper.ConstraintTemplateGVK)
+	template.SetName("k8srequiredlabels")
+	template.SetLabels(managed)
+
+	// gatekeeper types are only known to the fake client as unstructured objects
+	scheme := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{gatekeeper.ConstraintTemplateGVK, gatekeeper.ConstraintGroupVersion.WithKind("K8sRequiredLabels")} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+
+	client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme,
+		template,
+		genUnstructuredConstraint("must-have-owner", managed, map[string]interface{}{
+			"enforcementAction": "deny",
+			"kind":              "Namespace",
+			"name":              "default",
+			"message":           `you must provide labels: {"owner"}`,
+		}),
+		// constraints created by the users themselves are not reported
+		genUnstructuredConstraint("unmanaged", nil, map[string]interface{}{
+			"kind": "Namespace",
+			"name": "default",
+		}),
+	)
+
+	violations, err := listViolations(context.Background(), client)
+	if err != nil {
+		t.Fatalf("failed to list violations: %v", err)
+	}
+
+	expected := apiv1.ConstraintViolationList{
+		{
+			Constraint:        "must-have-owner",
+			ConstraintKind:    "K8sRequiredLabels",
+			EnforcementAction: "deny",
+			Kind:              "Namespace",
+			Name:              "default",
+			Message:           `you must provide labels: {"owner"}`,
+		},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected violations %+v, got %+v", expected, violations)
+	}
+}
@@ -196,7 +196,15 @@ func filterDCsByName(dcs []apiv1.Datacenter, dcName string) (apiv1.Datacenter, e
 	}
 
 	if n := len(foundDCs); n > 1 {
-		return apiv1.Datacenter{}, fmt.Errorf("did not find one but %d datacenters for name %q", n, dcName)
+		for _, dc := range foundDCs {
+			if !dc.Spec.Placement.IsShared() {
+				return apiv1.Datacenter{}, fmt.Errorf("did not find one but %d datacenters for name %q", n, dcName)
+			}
+		}
+		// shared datacenters are defined in several seeds, return a stable result
+		sort.Slice(foundDCs, func(i, j int) bool {
+			return foundDCs[i].Spec.Seed < foundDCs[j].Spec.Seed
+		})
 	}
 	if len(foundDCs) == 0 {
 		return apiv1.Datacenter{}, errors.NewNotFound("datacenter", dcName)
@@ -672,7 +680,9 @@ func ConvertInternalDCToExternalSpec(dc *kubermaticv1.Datacenter, seedName strin
 		RequiredEmailDomain:      dc.Spec.RequiredEmailDomain,
 		RequiredEmailDomains:     dc.Spec.RequiredEmailDomains,
 		EnforceAuditLogging:      dc.Spec.EnforceAuditLogging,
+		MinimumAuditPolicyPreset: dc.Spec.MinimumAuditPolicyPreset,
 		EnforcePodSecurityPolicy: dc.Spec.EnforcePodSecurityPolicy,
+		Placement:                dc.Spec.Placement,
 	}, nil
 }
 
@@ -697,7 +707,9 @@ func convertExternalDCToInternal(datacenter *apiv1.DatacenterSpec) kubermaticv1.
 			RequiredEmailDomain:      datacenter.RequiredEmailDomain,
 			RequiredEmailDomains:     datacenter.RequiredEmailDomains,
 			EnforceAuditLogging:      datacenter.EnforceAuditLogging,
+			MinimumAuditPolicyPreset: datacenter.MinimumAuditPolicyPreset,
 			EnforcePodSecurityPolicy: datacenter.EnforcePodSecurityPolicy,
+			Placement:                datacenter.Placement,
 		},
 	}
 }
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	k8cerrors "github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func setNodeUnschedulable(ctx context.Context, client ctrlruntimeclient.Client, node *corev1.Node, unschedulable bool) error {
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+	node.Spec.Unschedulable = unschedulable
+	return client.Update(ctx, node)
+}
+
+// drainNode cordons the node and evicts all pods running on it. The pods are evicted through the
+// eviction API, so evictions which would violate a PodDisruptionBudget are rejected and reported back.
+// It does not wait for the evicted pods to terminate.
+func drainNode(ctx context.Context, client ctrlruntimeclient.Client, k8sClient kubernetes.Interface, node *corev1.Node) error {
+	if err := setNodeUnschedulable(ctx, client, node, true); err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return common.KubernetesErrorToHTTPError(err)
+	}
+
+	var blockedPods []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isEvictable(pod, node.Name) {
+			continue
+		}
+
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		}
+		if err := k8sClient.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			// The API server responds with 429 if the eviction would violate a PodDisruptionBudget
+			if kerrors.IsTooManyRequests(err) {
+				blockedPods = append(blockedPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+				continue
+			}
+			return common.KubernetesErrorToHTTPError(err)
+		}
+	}
+
+	if len(blockedPods) > 0 {
+		return k8cerrors.New(http.StatusConflict, fmt.Sprintf("eviction of the pods %s is blocked by a PodDisruptionBudget, retry the drain later", strings.Join(blockedPods, ", ")))
+	}
+	return nil
+}
+
+// isEvictable returns false for pods which either are already gone or would not be moved to a
+// different node, i.e. mirror pods and pods managed by a DaemonSet.
+func isEvictable(pod *corev1.Pod, nodeName string) bool {
+	if pod.Spec.NodeName != nodeName {
+		return false
+	}
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, isMirrorPod := pod.Annotations[corev1.MirrorPodAnnotationKey]; isMirrorPod {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
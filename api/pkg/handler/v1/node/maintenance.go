@@ -0,0 +1,311 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	"github.com/kubermatic/kubermatic/api/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	machineresource "github.com/kubermatic/kubermatic/api/pkg/resources/machine"
+	k8cerrors "github.com/kubermatic/kubermatic/api/pkg/util/errors"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restartNodeDeploymentReq defines HTTP request for restartNodeDeployment
+// swagger:parameters restartNodeDeployment
+type restartNodeDeploymentReq struct {
+	nodeDeploymentReq
+}
+
+func DecodeRestartNodeDeployment(c context.Context, r *http.Request) (interface{}, error) {
+	req, err := DecodeGetNodeDeployment(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return restartNodeDeploymentReq{req.(nodeDeploymentReq)}, nil
+}
+
+// RestartNodeDeployment replaces all nodes of the node deployment according to its rollout strategy
+func RestartNodeDeployment(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(restartNodeDeploymentReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		cluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		machineDeployment := &clusterv1alpha1.MachineDeployment{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: req.NodeDeploymentID}, machineDeployment); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if machineDeployment.Spec.Paused {
+			return nil, k8cerrors.NewBadRequest("node deployment %s is paused and can not be restarted", req.NodeDeploymentID)
+		}
+
+		machineresource.SetRestartedAt(machineDeployment, time.Now())
+		if err := client.Update(ctx, machineDeployment); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return OutputMachineDeployment(machineDeployment)
+	}
+}
+
+// nodeDeploymentNodeReq defines HTTP request for the operations on a single node of a node deployment
+// swagger:parameters cordonNodeDeploymentNode uncordonNodeDeploymentNode drainNodeDeploymentNode
+type nodeDeploymentNodeReq struct {
+	common.GetClusterReq
+	// in: path
+	NodeDeploymentID string `json:"nodedeployment_id"`
+	// in: path
+	NodeID string `json:"node_id"`
+}
+
+func DecodeNodeDeploymentNode(c context.Context, r *http.Request) (interface{}, error) {
+	var req nodeDeploymentNodeReq
+
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	dcr, err := common.DecodeDcReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeDeploymentID, err := decodeNodeDeploymentID(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeID := mux.Vars(r)["node_id"]
+	if nodeID == "" {
+		return nil, fmt.Errorf("'node_id' parameter is required but was not provided")
+	}
+
+	req.ClusterID = clusterID
+	req.NodeDeploymentID = nodeDeploymentID
+	req.NodeID = nodeID
+	req.DCReq = dcr.(common.DCReq)
+
+	return req, nil
+}
+
+// CordonNodeDeploymentNode marks the node as unschedulable
+func CordonNodeDeploymentNode(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return setNodeDeploymentNodeUnschedulable(projectProvider, privilegedProjectProvider, userInfoGetter, true)
+}
+
+// UncordonNodeDeploymentNode marks the node as schedulable
+func UncordonNodeDeploymentNode(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return setNodeDeploymentNodeUnschedulable(projectProvider, privilegedProjectProvider, userInfoGetter, false)
+}
+
+func setNodeDeploymentNodeUnschedulable(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, unschedulable bool) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(nodeDeploymentNodeReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		cluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		_, machine, node, err := getNodeDeploymentNode(ctx, client, req.NodeDeploymentID, req.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, k8cerrors.NewBadRequest("node %s has not joined the cluster yet", req.NodeID)
+		}
+
+		if err := setNodeUnschedulable(ctx, client, node, unschedulable); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return outputMachine(machine, node, false)
+	}
+}
+
+// DrainNodeDeploymentNode cordons the node and evicts its pods, respecting PodDisruptionBudgets
+func DrainNodeDeploymentNode(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(nodeDeploymentNodeReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		cluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		k8sClient, err := common.GetClusterK8sClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		_, machine, node, err := getNodeDeploymentNode(ctx, client, req.NodeDeploymentID, req.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, k8cerrors.NewBadRequest("node %s has not joined the cluster yet", req.NodeID)
+		}
+
+		if err := drainNode(ctx, client, k8sClient, node); err != nil {
+			return nil, err
+		}
+
+		return outputMachine(machine, node, false)
+	}
+}
+
+// deleteNodeDeploymentNodeReq defines HTTP request for deleteNodeDeploymentNode
+// swagger:parameters deleteNodeDeploymentNode
+type deleteNodeDeploymentNodeReq struct {
+	nodeDeploymentNodeReq
+	// ScaleDown decreases the replicas of the node deployment instead of replacing the deleted node
+	// in: query
+	ScaleDown bool `json:"scaleDown"`
+}
+
+func DecodeDeleteNodeDeploymentNode(c context.Context, r *http.Request) (interface{}, error) {
+	req, err := DecodeNodeDeploymentNode(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	scaleDown := false
+	if value := r.URL.Query().Get("scaleDown"); value != "" {
+		scaleDown, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, k8cerrors.NewBadRequest("invalid value for scaleDown: %v", err)
+		}
+	}
+
+	return deleteNodeDeploymentNodeReq{nodeDeploymentNodeReq: req.(nodeDeploymentNodeReq), ScaleDown: scaleDown}, nil
+}
+
+// DeleteNodeDeploymentNode deletes the machine of the node. Unless the node deployment is scaled down at the same time,
+// the machine-controller replaces it with a new one.
+func DeleteNodeDeploymentNode(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteNodeDeploymentNodeReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		cluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		machineDeployment, machine, _, err := getNodeDeploymentNode(ctx, client, req.NodeDeploymentID, req.NodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !req.ScaleDown {
+			return nil, common.KubernetesErrorToHTTPError(client.Delete(ctx, machine))
+		}
+
+		replicas := *machineDeployment.Spec.Replicas - 1
+		if replicas < 0 {
+			return nil, k8cerrors.NewBadRequest("node deployment %s has no replicas to scale down", req.NodeDeploymentID)
+		}
+		if minReplicas, _ := machineresource.GetAutoscalingReplicas(machineDeployment); minReplicas != nil && replicas < *minReplicas {
+			return nil, k8cerrors.NewBadRequest("scaling down node deployment %s would go below minReplicas (%d)", req.NodeDeploymentID, *minReplicas)
+		}
+
+		// The MachineSet deletes annotated machines first when it scales down
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[machineresource.DeleteMachineAnnotation] = "yes"
+		if err := client.Update(ctx, machine); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		machineDeployment.Spec.Replicas = &replicas
+		return nil, common.KubernetesErrorToHTTPError(client.Update(ctx, machineDeployment))
+	}
+}
+
+// getNodeDeploymentNode returns the machine with the given name that belongs to the node deployment, together with
+// the node backed by it. The node is nil if it didn't join the cluster yet.
+func getNodeDeploymentNode(ctx context.Context, client ctrlruntimeclient.Client, nodeDeploymentID, nodeID string) (*clusterv1alpha1.MachineDeployment, *clusterv1alpha1.Machine, *corev1.Node, error) {
+	machineDeployment := &clusterv1alpha1.MachineDeployment{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: nodeDeploymentID}, machineDeployment); err != nil {
+		return nil, nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := client.List(ctx, machines, &ctrlruntimeclient.ListOptions{Namespace: metav1.NamespaceSystem, LabelSelector: labels.SelectorFromSet(machineDeployment.Spec.Selector.MatchLabels)}); err != nil {
+		return nil, nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	var machine *clusterv1alpha1.Machine
+	for i := range machines.Items {
+		if machines.Items[i].Name == nodeID {
+			machine = &machines.Items[i]
+			break
+		}
+	}
+	if machine == nil {
+		return nil, nil, nil, k8cerrors.NewNotFound("Node", nodeID)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := client.List(ctx, nodes); err != nil {
+		return nil, nil, nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return machineDeployment, machine, getNodeForMachine(machine, nodes.Items), nil
+}
@@ -144,6 +144,7 @@ func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvide
 		data := common.CredentialsData{
 			KubermaticCluster: cluster,
 			Client:            assertedClusterProvider.GetSeedClusterAdminRuntimeClient(),
+			SecretStores:      assertedClusterProvider.GetSecretStores(),
 		}
 
 		md, err := machineresource.Deployment(cluster, nd, dc, keys, data)
@@ -155,11 +156,12 @@ func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvide
 			return nil, fmt.Errorf("failed to create machine deployment: %v", err)
 		}
 
-		return outputMachineDeployment(md)
+		return OutputMachineDeployment(md)
 	}
 }
 
-func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.NodeDeployment, error) {
+// OutputMachineDeployment converts a MachineDeployment into the NodeDeployment returned by the API
+func OutputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.NodeDeployment, error) {
 	nodeStatus := apiv1.NodeStatus{}
 	nodeStatus.MachineName = md.Name
 
@@ -189,6 +191,7 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 	}
 
 	hasDynamicConfig := md.Spec.Template.Spec.ConfigSource != nil
+	minReplicas, maxReplicas := machineresource.GetAutoscalingReplicas(md)
 
 	return &apiv1.NodeDeployment{
 		ObjectMeta: apiv1.ObjectMeta{
@@ -198,7 +201,9 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 			CreationTimestamp: apiv1.NewTime(md.CreationTimestamp.Time),
 		},
 		Spec: apiv1.NodeDeploymentSpec{
-			Replicas: *md.Spec.Replicas,
+			Replicas:    *md.Spec.Replicas,
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
 			Template: apiv1.NodeSpec{
 				Labels: label.FilterLabels(label.NodeDeploymentResourceType, md.Spec.Template.Spec.Labels),
 				Taints: taints,
@@ -208,6 +213,7 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 				OperatingSystem: *operatingSystemSpec,
 				Cloud:           *cloudSpec,
 			},
+			Strategy:      machineresource.GetRolloutStrategy(md),
 			Paused:        &md.Spec.Paused,
 			DynamicConfig: &hasDynamicConfig,
 		},
@@ -262,7 +268,7 @@ func ListNodeDeployments(projectProvider provider.ProjectProvider, privilegedPro
 
 		nodeDeployments := make([]*apiv1.NodeDeployment, 0, len(machineDeployments.Items))
 		for i := range machineDeployments.Items {
-			nd, err := outputMachineDeployment(&machineDeployments.Items[i])
+			nd, err := OutputMachineDeployment(&machineDeployments.Items[i])
 			if err != nil {
 				return nil, fmt.Errorf("failed to output machine deployment %s: %v", machineDeployments.Items[i].Name, err)
 			}
@@ -335,7 +341,7 @@ func GetNodeDeployment(projectProvider provider.ProjectProvider, privilegedProje
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return outputMachineDeployment(machineDeployment)
+		return OutputMachineDeployment(machineDeployment)
 	}
 }
 
@@ -660,7 +666,7 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		nodeDeployment, err := outputMachineDeployment(machineDeployment)
+		nodeDeployment, err := OutputMachineDeployment(machineDeployment)
 		if err != nil {
 			return nil, fmt.Errorf("cannot output existing node deployment: %v", err)
 		}
@@ -688,6 +694,12 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 		if err = nodeupdate.EnsureVersionCompatible(cluster.Spec.Version.Semver(), kversion); err != nil {
 			return nil, k8cerrors.NewBadRequest(err.Error())
 		}
+		if err := machineresource.ValidateAutoscaling(&patchedNodeDeployment.Spec); err != nil {
+			return nil, k8cerrors.NewBadRequest("node deployment validation failed: %v", err)
+		}
+		if err := machineresource.ValidateRolloutStrategy(patchedNodeDeployment.Spec.Strategy); err != nil {
+			return nil, k8cerrors.NewBadRequest("node deployment validation failed: %v", err)
+		}
 
 		_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
 		if err != nil {
@@ -706,6 +718,7 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 		data := common.CredentialsData{
 			KubermaticCluster: cluster,
 			Client:            assertedClusterProvider.GetSeedClusterAdminRuntimeClient(),
+			SecretStores:      assertedClusterProvider.GetSecretStores(),
 		}
 		patchedMachineDeployment, err := machineresource.Deployment(cluster, patchedNodeDeployment, dc, keys, data)
 		if err != nil {
@@ -717,12 +730,14 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 		machineDeployment.Spec.Template.Spec = patchedMachineDeployment.Spec.Template.Spec
 		machineDeployment.Spec.Replicas = patchedMachineDeployment.Spec.Replicas
 		machineDeployment.Spec.Paused = patchedMachineDeployment.Spec.Paused
+		machineDeployment.Spec.Strategy = patchedMachineDeployment.Spec.Strategy
+		machineresource.SetAutoscalingAnnotations(machineDeployment, patchedNodeDeployment.Spec.MinReplicas, patchedNodeDeployment.Spec.MaxReplicas)
 
 		if err := client.Update(ctx, machineDeployment); err != nil {
 			return nil, fmt.Errorf("failed to update machine deployment: %v", err)
 		}
 
-		return outputMachineDeployment(machineDeployment)
+		return OutputMachineDeployment(machineDeployment)
 	}
 }
 
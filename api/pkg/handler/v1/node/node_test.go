@@ -36,10 +36,15 @@ import (
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fakerestclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
@@ -1591,6 +1596,91 @@ func TestPatchNodeDeployment(t *testing.T) {
 			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
 			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true), genUser("John", "john@acme.com", false)),
 		},
+		// Scenario 8: Set autoscaling bounds.
+		{
+			Name:                       "Scenario 8: Set autoscaling bounds",
+			Body:                       `{"spec":{"minReplicas":1,"maxReplicas":5}}`,
+			ExpectedResponse:           `{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"minReplicas":1,"maxReplicas":5,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"paused":false,"dynamicConfig":false},"status":{}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusOK,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		// Scenario 9: Replicas outside of the autoscaling bounds.
+		{
+			Name:                       "Scenario 9: Replicas outside of the autoscaling bounds",
+			Body:                       `{"spec":{"replicas":7,"minReplicas":1,"maxReplicas":5}}`,
+			ExpectedResponse:           `{"error":{"code":400,"message":"node deployment validation failed: replicas (7) must be between minReplicas (1) and maxReplicas (5)"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusBadRequest,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		// Scenario 10: Remove autoscaling bounds.
+		{
+			Name:             "Scenario 10: Remove autoscaling bounds",
+			Body:             `{"spec":{"minReplicas":null,"maxReplicas":null}}`,
+			ExpectedResponse: `{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"paused":false,"dynamicConfig":false},"status":{}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusOK,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			NodeDeploymentID: "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{func() *clusterv1alpha1.MachineDeployment {
+				md := genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)
+				md.Annotations = map[string]string{
+					"cluster.k8s.io/cluster-api-autoscaler-node-group-min-size": "1",
+					"cluster.k8s.io/cluster-api-autoscaler-node-group-max-size": "3",
+				}
+				return md
+			}()},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		// Scenario 11: Set rollout strategy.
+		{
+			Name:                       "Scenario 11: Set rollout strategy",
+			Body:                       `{"spec":{"strategy":{"maxSurge":"25%","maxUnavailable":"1"}}}`,
+			ExpectedResponse:           `{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":["kubernetes","kubernetes-cluster-defClusterID","system-cluster-defClusterID","system-project-my-first-project-ID"]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v9.9.9"},"labels":{"system/cluster":"defClusterID","system/project":"my-first-project-ID"}},"strategy":{"maxSurge":"25%","maxUnavailable":"1"},"paused":false,"dynamicConfig":false},"status":{}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusOK,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		// Scenario 12: Rollout strategy which can not make progress.
+		{
+			Name:                       "Scenario 12: Rollout strategy which can not make progress",
+			Body:                       `{"spec":{"strategy":{"maxSurge":"0","maxUnavailable":"0%"}}}`,
+			ExpectedResponse:           `{"error":{"code":400,"message":"node deployment validation failed: maxSurge and maxUnavailable must not both be zero"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusBadRequest,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		// Scenario 13: Invalid rollout strategy value.
+		{
+			Name:                       "Scenario 13: Invalid rollout strategy value",
+			Body:                       `{"spec":{"strategy":{"maxSurge":"-1"}}}`,
+			ExpectedResponse:           `{"error":{"code":400,"message":"node deployment validation failed: maxSurge must not be negative"}}`,
+			cluster:                    "keen-snyder",
+			HTTPStatus:                 http.StatusBadRequest,
+			project:                    test.GenDefaultProject().Name,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			NodeDeploymentID:           "venus",
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1755,6 +1845,370 @@ func TestDeleteNodeDeployment(t *testing.T) {
 	}
 }
 
+func TestRestartNodeDeployment(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                       string
+		ExpectedResponse           string
+		HTTPStatus                 int
+		ExistingAPIUser            *apiv1.User
+		ExistingMachineDeployments []*clusterv1alpha1.MachineDeployment
+		ExistingKubermaticObjs     []runtime.Object
+		ExpectRestart              bool
+	}{
+		{
+			Name:                       "scenario 1: restart the node deployment",
+			HTTPStatus:                 http.StatusOK,
+			ExistingAPIUser:            test.GenDefaultAPIUser(),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true)),
+			ExpectRestart:              true,
+		},
+		{
+			Name:             "scenario 2: a paused node deployment can not be restarted",
+			ExpectedResponse: `{"error":{"code":400,"message":"node deployment venus is paused and can not be restarted"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{func() *clusterv1alpha1.MachineDeployment {
+				md := genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)
+				md.Spec.Paused = true
+				return md
+			}()},
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genTestCluster(true)),
+		},
+		{
+			Name:                       "scenario 3: the user John can not restart Bob's node deployment",
+			ExpectedResponse:           `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:                 http.StatusForbidden,
+			ExistingAPIUser:            test.GenAPIUser("John", "john@acme.com"),
+			ExistingMachineDeployments: []*clusterv1alpha1.MachineDeployment{genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, nil, false)},
+			ExistingKubermaticObjs:     test.GenDefaultKubermaticObjects(genTestCluster(true), genUser("John", "john@acme.com", false)),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus/restart",
+				test.GenDefaultProject().Name, test.GenDefaultCluster().Name), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineDeploymentObjects := []runtime.Object{}
+			for _, existingMachineDeployment := range tc.ExistingMachineDeployments {
+				machineDeploymentObjects = append(machineDeploymentObjects, existingMachineDeployment)
+			}
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, []runtime.Object{}, machineDeploymentObjects, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+			}
+
+			machineDeployment := &clusterv1alpha1.MachineDeployment{}
+			if err := clientsSets.FakeClient.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "venus"}, machineDeployment); err != nil {
+				t.Fatalf("failed to get MachineDeployment: %v", err)
+			}
+			if _, restarted := machineDeployment.Spec.Template.Annotations["kubermatic.io/restarted-at"]; restarted != tc.ExpectRestart {
+				t.Errorf("Expected the machine template to be restarted: %v, but got annotations %v", tc.ExpectRestart, machineDeployment.Spec.Template.Annotations)
+			}
+		})
+	}
+}
+
+func TestCordonNodeDeploymentNode(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                  string
+		Operation             string
+		NodeID                string
+		ExistingNode          *corev1.Node
+		HTTPStatus            int
+		ExpectedResponse      string
+		ExpectedUnschedulable bool
+	}{
+		{
+			Name:                  "scenario 1: cordon a node",
+			Operation:             "cordon",
+			NodeID:                "venus-1",
+			ExistingNode:          &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "venus-1"}},
+			HTTPStatus:            http.StatusOK,
+			ExpectedUnschedulable: true,
+		},
+		{
+			Name:                  "scenario 2: uncordon a node",
+			Operation:             "uncordon",
+			NodeID:                "venus-1",
+			ExistingNode:          &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "venus-1"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+			HTTPStatus:            http.StatusOK,
+			ExpectedUnschedulable: false,
+		},
+		{
+			Name:             "scenario 3: a machine of a different node deployment can not be cordoned",
+			Operation:        "cordon",
+			NodeID:           "mars-1",
+			ExistingNode:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "venus-1"}},
+			HTTPStatus:       http.StatusNotFound,
+			ExpectedResponse: `{"error":{"code":404,"message":"Node \"mars-1\" not found"}}`,
+		},
+		{
+			Name:             "scenario 4: a machine without node can not be cordoned",
+			Operation:        "cordon",
+			NodeID:           "venus-1",
+			ExistingNode:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "venus-2"}},
+			HTTPStatus:       http.StatusBadRequest,
+			ExpectedResponse: `{"error":{"code":400,"message":"node venus-1 has not joined the cluster yet"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus/nodes/%s/%s",
+				test.GenDefaultProject().Name, test.GenDefaultCluster().Name, tc.NodeID, tc.Operation), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineObjects := []runtime.Object{
+				genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, false),
+				genTestMachine("venus-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, nil),
+				genTestMachine("mars-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "mars"}, nil),
+			}
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, []runtime.Object{tc.ExistingNode}, machineObjects, test.GenDefaultKubermaticObjects(genTestCluster(true)), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			node := &corev1.Node{}
+			if err := clientsSets.FakeClient.Get(context.TODO(), types.NamespacedName{Name: tc.NodeID}, node); err != nil {
+				t.Fatalf("failed to get node: %v", err)
+			}
+			if node.Spec.Unschedulable != tc.ExpectedUnschedulable {
+				t.Errorf("Expected node to be unschedulable: %v, got %v", tc.ExpectedUnschedulable, node.Spec.Unschedulable)
+			}
+		})
+	}
+}
+
+func TestDrainNodeDeploymentNode(t *testing.T) {
+	t.Parallel()
+	genPod := func(name, nodeName string, modify func(*corev1.Pod)) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+		if modify != nil {
+			modify(pod)
+		}
+		return pod
+	}
+
+	testcases := []struct {
+		Name              string
+		ExistingPods      []*corev1.Pod
+		BlockedPods       sets.String
+		HTTPStatus        int
+		ExpectedResponse  string
+		ExpectedEvictions []string
+	}{
+		{
+			Name: "scenario 1: evict the pods of the node",
+			ExistingPods: []*corev1.Pod{
+				genPod("app", "venus-1", nil),
+				genPod("other-node", "venus-2", nil),
+				genPod("daemon", "venus-1", func(pod *corev1.Pod) {
+					pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "daemon", Controller: boolPtr(true)}}
+				}),
+				genPod("mirror", "venus-1", func(pod *corev1.Pod) {
+					pod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "mirror"}
+				}),
+				genPod("completed", "venus-1", func(pod *corev1.Pod) {
+					pod.Status.Phase = corev1.PodSucceeded
+				}),
+			},
+			HTTPStatus:        http.StatusOK,
+			ExpectedEvictions: []string{"app"},
+		},
+		{
+			Name: "scenario 2: evictions blocked by a PodDisruptionBudget are reported",
+			ExistingPods: []*corev1.Pod{
+				genPod("app", "venus-1", nil),
+				genPod("database", "venus-1", nil),
+			},
+			BlockedPods:       sets.NewString("database"),
+			HTTPStatus:        http.StatusConflict,
+			ExpectedResponse:  `{"error":{"code":409,"message":"eviction of the pods default/database is blocked by a PodDisruptionBudget, retry the drain later"}}`,
+			ExpectedEvictions: []string{"app", "database"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus/nodes/venus-1/drain",
+				test.GenDefaultProject().Name, test.GenDefaultCluster().Name), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			kubernetesObjects := []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "venus-1"}}}
+			for _, pod := range tc.ExistingPods {
+				kubernetesObjects = append(kubernetesObjects, pod)
+			}
+			machineObjects := []runtime.Object{
+				genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, false),
+				genTestMachine("venus-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, nil),
+			}
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, kubernetesObjects, machineObjects, test.GenDefaultKubermaticObjects(genTestCluster(true)), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			var evictions []string
+			fakeKubernetesClient := clientsSets.FakeKubernetesCoreClient.(*fakerestclient.Clientset)
+			fakeKubernetesClient.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(clienttesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+				evictions = append(evictions, eviction.Name)
+				if tc.BlockedPods.Has(eviction.Name) {
+					return true, nil, kerrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+				}
+				return true, nil, nil
+			})
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+			}
+
+			sort.Strings(evictions)
+			if diff := deep.Equal(evictions, tc.ExpectedEvictions); diff != nil {
+				t.Errorf("Got unexpected evictions, diff to expected: %v", diff)
+			}
+
+			node := &corev1.Node{}
+			if err := clientsSets.FakeClient.Get(context.TODO(), types.NamespacedName{Name: "venus-1"}, node); err != nil {
+				t.Fatalf("failed to get node: %v", err)
+			}
+			if !node.Spec.Unschedulable {
+				t.Error("Expected the drained node to be cordoned")
+			}
+		})
+	}
+}
+
+func TestDeleteNodeDeploymentNode(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		NodeID                 string
+		ScaleDown              bool
+		MinReplicas            string
+		HTTPStatus             int
+		ExpectedResponse       string
+		ExpectMachineDeleted   bool
+		ExpectMachineAnnotated bool
+		ExpectedReplicas       int32
+	}{
+		{
+			Name:                 "scenario 1: delete the machine of a node",
+			NodeID:               "venus-1",
+			HTTPStatus:           http.StatusOK,
+			ExpectMachineDeleted: true,
+			ExpectedReplicas:     2,
+		},
+		{
+			Name:                   "scenario 2: delete a node and scale the node deployment down",
+			NodeID:                 "venus-1",
+			ScaleDown:              true,
+			HTTPStatus:             http.StatusOK,
+			ExpectMachineAnnotated: true,
+			ExpectedReplicas:       1,
+		},
+		{
+			Name:             "scenario 3: scaling down below the autoscaling bounds is rejected",
+			NodeID:           "venus-1",
+			ScaleDown:        true,
+			MinReplicas:      "2",
+			HTTPStatus:       http.StatusBadRequest,
+			ExpectedResponse: `{"error":{"code":400,"message":"scaling down node deployment venus would go below minReplicas (2)"}}`,
+			ExpectedReplicas: 2,
+		},
+		{
+			Name:             "scenario 4: a machine of a different node deployment can not be deleted",
+			NodeID:           "mars-1",
+			HTTPStatus:       http.StatusNotFound,
+			ExpectedResponse: `{"error":{"code":404,"message":"Node \"mars-1\" not found"}}`,
+			ExpectedReplicas: 2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus/nodes/%s?scaleDown=%v",
+				test.GenDefaultProject().Name, test.GenDefaultCluster().Name, tc.NodeID, tc.ScaleDown), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			machineDeployment := genTestMachineDeployment("venus", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, false)
+			replicas := int32(2)
+			machineDeployment.Spec.Replicas = &replicas
+			if tc.MinReplicas != "" {
+				machineDeployment.Annotations = map[string]string{
+					"cluster.k8s.io/cluster-api-autoscaler-node-group-min-size": tc.MinReplicas,
+					"cluster.k8s.io/cluster-api-autoscaler-node-group-max-size": "5",
+				}
+			}
+			machineObjects := []runtime.Object{
+				machineDeployment,
+				genTestMachine("venus-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, nil),
+				genTestMachine("venus-2", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "venus"}, nil),
+				genTestMachine("mars-1", `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`, map[string]string{"md-id": "mars"}, nil),
+			}
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, []runtime.Object{}, machineObjects, test.GenDefaultKubermaticObjects(genTestCluster(true)), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+			}
+
+			machine := &clusterv1alpha1.Machine{}
+			err = clientsSets.FakeClient.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "venus-1"}, machine)
+			if deleted := kerrors.IsNotFound(err); deleted != tc.ExpectMachineDeleted {
+				t.Errorf("Expected machine to be deleted: %v, got error %v", tc.ExpectMachineDeleted, err)
+			}
+			if annotated := machine.Annotations["cluster.k8s.io/delete-machine"] == "yes"; annotated != tc.ExpectMachineAnnotated {
+				t.Errorf("Expected machine to be annotated for deletion: %v, got annotations %v", tc.ExpectMachineAnnotated, machine.Annotations)
+			}
+
+			if err := clientsSets.FakeClient.Get(context.TODO(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "venus"}, machineDeployment); err != nil {
+				t.Fatalf("failed to get MachineDeployment: %v", err)
+			}
+			if *machineDeployment.Spec.Replicas != tc.ExpectedReplicas {
+				t.Errorf("Expected %d replicas, got %d", tc.ExpectedReplicas, *machineDeployment.Spec.Replicas)
+			}
+		})
+	}
+}
+
 func TestNodeDeploymentMetrics(t *testing.T) {
 	t.Parallel()
 
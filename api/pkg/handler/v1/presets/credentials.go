@@ -72,53 +72,85 @@ func CredentialEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		credentials := apiv1.CredentialList{}
-		names := make([]string, 0)
+		// presets restricted to projects are only listed in the project scoped endpoint
+		return listCredentials(presetsProvider, userInfo, "", req)
+	}
+}
+
+// ProjectCredentialEndpoint returns custom credential list name for the provider which can be used in the project
+func ProjectCredentialEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(projectProviderReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		err := req.Validate()
+		if err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 
-		providerN := parseProvider(req.ProviderName)
-		presets, err := presetsProvider.GetPresets(userInfo)
+		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
-			return nil, errors.New(http.StatusInternalServerError, err.Error())
+			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		for _, preset := range presets {
-			// get specific provider by name from the Preset spec struct:
-			// type PresetSpec struct {
-			//	Digitalocean Digitalocean
-			//	Hetzner      Hetzner
-			//	Azure        Azure
-			//	VSphere      VSphere
-			//	AWS          AWS
-			//	Openstack    Openstack
-			//	Packet       Packet
-			//	GCP          GCP
-			//	Kubevirt     Kubevirt
-			//	Alibaba      Alibaba
-			// }
-			providersRaw := reflect.ValueOf(preset.Spec)
-			if providersRaw.Kind() == reflect.Struct {
-				providers := reflect.Indirect(providersRaw)
-				providerItem := providers.Field(providerN)
-
-				// append preset name if specific provider is not empty:
-				if !providerItem.IsNil() {
-					var datacenterValue string
-					item := reflect.Indirect(providerItem)
-					datacenter := item.FieldByName("Datacenter")
-
-					if datacenter.Kind() == reflect.String {
-						datacenterValue = datacenter.String()
-					}
-					if datacenterValue == req.Datacenter || datacenterValue == "" {
-						names = append(names, preset.Name)
-					}
+		return listCredentials(presetsProvider, userInfo, project.Name, req.providerReq)
+	}
+}
+
+func listCredentials(presetsProvider provider.PresetProvider, userInfo *provider.UserInfo, projectID string, req providerReq) (apiv1.CredentialList, error) {
+	credentials := apiv1.CredentialList{}
+	names := make([]string, 0)
+
+	providerN := parseProvider(req.ProviderName)
+	presets, err := presetsProvider.GetPresets(userInfo, projectID)
+	if err != nil {
+		return credentials, errors.New(http.StatusInternalServerError, err.Error())
+	}
+
+	for _, preset := range presets {
+		// get specific provider by name from the Preset spec struct:
+		// type PresetSpec struct {
+		//	Digitalocean Digitalocean
+		//	Hetzner      Hetzner
+		//	Azure        Azure
+		//	VSphere      VSphere
+		//	AWS          AWS
+		//	Openstack    Openstack
+		//	Packet       Packet
+		//	GCP          GCP
+		//	Kubevirt     Kubevirt
+		//	Alibaba      Alibaba
+		// }
+		providersRaw := reflect.ValueOf(preset.Spec)
+		if providersRaw.Kind() == reflect.Struct {
+			providers := reflect.Indirect(providersRaw)
+			providerItem := providers.Field(providerN)
+
+			// append preset name if specific provider is not empty:
+			if !providerItem.IsNil() {
+				var datacenterValue string
+				item := reflect.Indirect(providerItem)
+				datacenter := item.FieldByName("Datacenter")
+
+				if datacenter.Kind() == reflect.String {
+					datacenterValue = datacenter.String()
+				}
+				if datacenterValue == req.Datacenter || datacenterValue == "" {
+					names = append(names, preset.Name)
 				}
 			}
 		}
-
-		credentials.Names = names
-		return credentials, nil
 	}
+
+	credentials.Names = names
+	return credentials, nil
 }
 
 func parseProvider(p string) int {
@@ -137,6 +169,28 @@ func DecodeProviderReq(c context.Context, r *http.Request) (interface{}, error)
 	}, nil
 }
 
+// projectProviderReq represents a request for provider name within a project
+// swagger:parameters listProjectCredentials
+type projectProviderReq struct {
+	common.ProjectReq
+	providerReq
+}
+
+func DecodeProjectProviderReq(c context.Context, r *http.Request) (interface{}, error) {
+	projectReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	provReq, err := DecodeProviderReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return projectProviderReq{
+		ProjectReq:  projectReq.(common.ProjectReq),
+		providerReq: provReq.(providerReq),
+	}, nil
+}
+
 // Validate validates providerReq request
 func (r providerReq) Validate() error {
 	if len(r.ProviderName) == 0 {
@@ -389,6 +389,92 @@ func TestCredentialEndpoint(t *testing.T) {
 	}
 }
 
+func TestProjectCredentialEndpoint(t *testing.T) {
+	t.Parallel()
+	disabled := false
+	presets := []runtime.Object{
+		&kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "all-projects",
+			},
+			Spec: kubermaticv1.PresetSpec{
+				AWS: &kubermaticv1.AWS{AccessKeyID: "a"},
+			},
+		},
+		&kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "own-project",
+			},
+			Spec: kubermaticv1.PresetSpec{
+				Projects: []string{test.GenDefaultProject().Name},
+				AWS:      &kubermaticv1.AWS{AccessKeyID: "a"},
+			},
+		},
+		&kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-project",
+			},
+			Spec: kubermaticv1.PresetSpec{
+				Projects: []string{"my-second-project-ID"},
+				AWS:      &kubermaticv1.AWS{AccessKeyID: "a"},
+			},
+		},
+		&kubermaticv1.Preset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "disabled",
+			},
+			Spec: kubermaticv1.PresetSpec{
+				Enabled: &disabled,
+				AWS:     &kubermaticv1.AWS{AccessKeyID: "a"},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name             string
+		url              string
+		httpStatus       int
+		expectedResponse string
+	}{
+		{
+			name:             "scenario 1: presets restricted to projects are not listed outside of a project",
+			url:              "/api/v1/providers/aws/presets/credentials",
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"names":["all-projects"]}`,
+		},
+		{
+			name:             "scenario 2: presets restricted to the project are listed within the project",
+			url:              fmt.Sprintf("/api/v1/projects/%s/providers/aws/presets/credentials", test.GenDefaultProject().Name),
+			httpStatus:       http.StatusOK,
+			expectedResponse: `{"names":["all-projects","own-project"]}`,
+		},
+		{
+			name:       "scenario 3: the presets of a project the user doesn't belong to can't be listed",
+			url:        "/api/v1/projects/my-second-project-ID/providers/aws/presets/credentials",
+			httpStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.url, strings.NewReader(""))
+			res := httptest.NewRecorder()
+
+			kubermaticObjs := test.GenDefaultKubermaticObjects(test.GenProject("my-second-project", kubermaticv1.ProjectActive, test.DefaultCreationTimestamp()))
+			router, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, append(kubermaticObjs, presets...), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v\n", err)
+			}
+			router.ServeHTTP(res, req)
+
+			assert.Equal(t, tc.httpStatus, res.Code)
+			if res.Code == http.StatusOK {
+				compareJSON(t, res, tc.expectedResponse)
+			}
+		})
+	}
+}
+
 func compareJSON(t *testing.T, res *httptest.ResponseRecorder, expectedResponseString string) {
 	t.Helper()
 	var actualResponse interface{}
@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-kit/kit/endpoint"
 
@@ -163,7 +165,7 @@ func isStatus(err error, status int32) bool {
 }
 
 // DeleteEndpoint defines an HTTP endpoint for deleting a project
-func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(deleteRq)
 		if !ok {
@@ -173,6 +175,19 @@ func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 			return nil, errors.NewBadRequest("the id of the project cannot be empty")
 		}
 
+		// The clusters of the project get deleted together with it
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		protectedClusters, err := getProtectedClustersForProject(clusterProviderGetter, seedsGetter, project)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if len(protectedClusters) > 0 {
+			return nil, errors.New(http.StatusConflict, fmt.Sprintf("the clusters %s are protected against deletion, disable their deletionProtection first", strings.Join(protectedClusters, ", ")))
+		}
+
 		// check if admin user
 		adminUserInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
@@ -408,6 +423,34 @@ func getNumberOfClustersForProject(clusterProviderGetter provider.ClusterProvide
 	return clustersNumber, nil
 }
 
+// getProtectedClustersForProject returns the names of the clusters of the project which have the deletion protection enabled
+func getProtectedClustersForProject(clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, project *kubermaticapiv1.Project) ([]string, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+	}
+
+	var protectedClusters []string
+	for datacenter, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			return nil, errors.NewNotFound("cluster-provider", datacenter)
+		}
+		clusters, err := clusterProvider.List(project, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters.Items {
+			if cluster.Spec.DeletionProtection {
+				protectedClusters = append(protectedClusters, cluster.Name)
+			}
+		}
+	}
+
+	sort.Strings(protectedClusters)
+	return protectedClusters, nil
+}
+
 func getNumberOfClusters(clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) (map[string]int, error) {
 	clustersNumber := map[string]int{}
 	seeds, err := seedsGetter()
@@ -41,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
 	fakerestclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	restclient "k8s.io/client-go/rest"
@@ -489,6 +490,7 @@ func TestListProjectMethod(t *testing.T) {
 				fakeClient,
 				kubernetesClient,
 				false,
+				nil,
 			)
 			clusterProviders := map[string]provider.ClusterProvider{"us-central1": clusterProvider}
 			clusterProviderGetter := func(seed *kubermaticapiv1.Seed) (provider.ClusterProvider, error) {
@@ -548,6 +550,10 @@ func (f *fakeUserClusterConnection) GetClient(_ *kubermaticapiv1.Cluster, _ ...k
 	return f.fakeDynamicClient, nil
 }
 
+func (f *fakeUserClusterConnection) GetK8sClient(_ *kubermaticapiv1.Cluster, _ ...k8cuserclusterclient.ConfigOption) (kubernetesclientset.Interface, error) {
+	return fakerestclient.NewSimpleClientset(), nil
+}
+
 func TestGetProjectEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -749,6 +755,20 @@ func TestDeleteProjectEndpoint(t *testing.T) {
 			},
 			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
 		},
+		{
+			Name:          "scenario 4: the project can't be deleted while it has clusters with deletion protection",
+			HTTPStatus:    http.StatusConflict,
+			ProjectToSync: test.GenDefaultProject().Name,
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				test.GenCluster("clusterAbcID", "clusterAbc", test.GenDefaultProject().Name, test.DefaultCreationTimestamp()),
+				func() *kubermaticapiv1.Cluster {
+					cluster := test.GenCluster("clusterDefID", "clusterDef", test.GenDefaultProject().Name, test.DefaultCreationTimestamp())
+					cluster.Spec.DeletionProtection = true
+					return cluster
+				}(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
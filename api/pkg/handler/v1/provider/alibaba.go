@@ -47,6 +47,9 @@ type AlibabaCommonReq struct {
 	// in: header
 	// name: Credential
 	Credential string
+	// in: header
+	// name: KubermaticProjectID
+	KubermaticProjectID string
 }
 
 // AlibabaReq represent a request for Alibaba instance types.
@@ -87,6 +90,7 @@ func DecodeAlibabaCommonReq(c context.Context, r *http.Request) (interface{}, er
 	req.AccessKeyID = r.Header.Get("AccessKeyID")
 	req.AccessKeySecret = r.Header.Get("AccessKeySecret")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 
 	return req, nil
 }
@@ -113,12 +117,12 @@ func AlibabaInstanceTypesEndpoint(presetsProvider provider.PresetProvider, userI
 		accessKeyID := req.AccessKeyID
 		accessKeySecret := req.AccessKeySecret
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -160,7 +164,7 @@ func AlibabaInstanceTypesWithClusterCredentialsEndpoint(projectProvider provider
 			return nil, fmt.Errorf("failed to find Datacenter %q: %v", datacenterName, err)
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		accessKeyID, accessKeySecret, err := alibaba.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector, datacenter.Spec.Alibaba)
 		if err != nil {
 			return nil, err
@@ -229,12 +233,12 @@ func AlibabaZonesEndpoint(presetsProvider provider.PresetProvider, userInfoGette
 		accessKeyID := req.AccessKeyID
 		accessKeySecret := req.AccessKeySecret
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -276,7 +280,7 @@ func AlibabaZonesWithClusterCredentialsEndpoint(projectProvider provider.Project
 			return nil, fmt.Errorf("failed to find Datacenter %q: %v", datacenterName, err)
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		accessKeyID, accessKeySecret, err := alibaba.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector, datacenter.Spec.Alibaba)
 		if err != nil {
 			return nil, err
@@ -165,7 +165,7 @@ func AzureSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectPro
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		creds, err := azure.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -183,13 +183,13 @@ func AzureSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter p
 		clientSecret := req.ClientSecret
 		tenantID := req.TenantID
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -292,7 +292,7 @@ func AzureAvailabilityZonesWithClusterCredentialsEndpoint(projectProvider provid
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		creds, err := azure.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -312,13 +312,13 @@ func AzureAvailabilityZonesEndpoint(presetsProvider provider.PresetProvider, use
 		location := req.Location
 		skuName := req.SKUName
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -351,6 +351,9 @@ type AvailabilityZonesReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func azureSKUAvailabilityZones(ctx context.Context, subscriptionID, clientID, clientSecret, tenantID, location, skuName string) (*apiv1.AzureAvailabilityZonesList, error) {
@@ -415,6 +418,9 @@ type AzureSizeReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func DecodeAzureSizesReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -426,6 +432,7 @@ func DecodeAzureSizesReq(c context.Context, r *http.Request) (interface{}, error
 	req.ClientSecret = r.Header.Get("ClientSecret")
 	req.Location = r.Header.Get("Location")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 	return req, nil
 }
 
@@ -439,6 +446,7 @@ func DecodeAzureAvailabilityZonesReq(c context.Context, r *http.Request) (interf
 	req.Location = r.Header.Get("Location")
 	req.SKUName = r.Header.Get("SKUName")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 	return req, nil
 }
 
@@ -57,7 +57,7 @@ func DigitaloceanSizeWithClusterCredentialsEndpoint(projectProvider provider.Pro
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		accessToken, err := doprovider.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -72,13 +72,13 @@ func DigitaloceanSizeEndpoint(presetsProvider provider.PresetProvider, userInfoG
 		req := request.(DoSizesReq)
 
 		token := req.DoToken
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -159,6 +159,9 @@ type DoSizesReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func DecodeDoSizesReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -166,5 +169,6 @@ func DecodeDoSizesReq(c context.Context, r *http.Request) (interface{}, error) {
 
 	req.DoToken = r.Header.Get("DoToken")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 	return req, nil
 }
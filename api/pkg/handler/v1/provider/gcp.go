@@ -76,6 +76,9 @@ type GCPCommonReq struct {
 	// in: header
 	// name: Credential
 	Credential string
+	// in: header
+	// name: KubermaticProjectID
+	KubermaticProjectID string
 }
 
 // GCPTypesNoCredentialReq represent a request for GCP machine or disk types.
@@ -151,6 +154,7 @@ func DecodeGCPCommonReq(c context.Context, r *http.Request) (interface{}, error)
 
 	req.ServiceAccount = r.Header.Get("ServiceAccount")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 
 	return req, nil
 }
@@ -187,12 +191,12 @@ func GCPDiskTypesEndpoint(presetsProvider provider.PresetProvider, userInfoGette
 
 		zone := req.Zone
 		sa := req.ServiceAccount
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -222,7 +226,7 @@ func GCPDiskTypesWithClusterCredentialsEndpoint(projectProvider provider.Project
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		sa, err := gcp.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -265,12 +269,12 @@ func GCPSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter pro
 		zone := req.Zone
 		sa := req.ServiceAccount
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -300,7 +304,7 @@ func GCPSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvi
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		sa, err := gcp.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -344,12 +348,12 @@ func GCPZoneEndpoint(presetsProvider provider.PresetProvider, seedsGetter provid
 		req := request.(GCPZoneReq)
 		sa := req.ServiceAccount
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -379,7 +383,7 @@ func GCPZoneWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvi
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		sa, err := gcp.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -428,12 +432,12 @@ func GCPNetworkEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 		req := request.(GCPCommonReq)
 		sa := req.ServiceAccount
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -463,7 +467,7 @@ func GCPNetworkWithClusterCredentialsEndpoint(projectProvider provider.ProjectPr
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		sa, err := gcp.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -508,12 +512,12 @@ func GCPSubnetworkEndpoint(presetsProvider provider.PresetProvider, seedsGetter
 		req := request.(GCPSubnetworksReq)
 		sa := req.ServiceAccount
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -543,7 +547,7 @@ func GCPSubnetworkWithClusterCredentialsEndpoint(projectProvider provider.Projec
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		sa, err := gcp.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -57,7 +57,7 @@ func HetznerSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectP
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
 
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		hetznerToken, err := hetzner.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -72,12 +72,12 @@ func HetznerSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 		req := request.(HetznerSizesReq)
 		token := req.HetznerToken
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -152,6 +152,9 @@ type HetznerSizesReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func DecodeHetznerSizesReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -159,5 +162,6 @@ func DecodeHetznerSizesReq(c context.Context, r *http.Request) (interface{}, err
 
 	req.HetznerToken = r.Header.Get("HetznerToken")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 	return req, nil
 }
@@ -184,6 +184,7 @@ func TestOpenstackEndpoints(t *testing.T) {
 		URL               string
 		QueryParams       map[string]string
 		Credential        string
+		ProjectID         string
 		Credentials       []runtime.Object
 		OpenstackURL      string
 		OpenstackResponse string
@@ -216,6 +217,30 @@ func TestOpenstackEndpoints(t *testing.T) {
 				{"id":"456789", "name": "another domain"}
 			]`,
 		},
+		{
+			Name:       "test tenants endpoint with predefined credentials restricted to the project",
+			Credential: test.TestFakeCredential,
+			ProjectID:  test.GenDefaultProject().Name,
+			Credentials: []runtime.Object{
+				genProjectPreset(test.GenDefaultProject().Name),
+				test.GenDefaultProject(),
+				test.GenDefaultOwnerBinding(),
+			},
+			URL: "/api/v1/providers/openstack/tenants",
+			ExpectedResponse: `[
+				{"id":"456788", "name": "a project name"},
+				{"id":"456789", "name": "another domain"}
+			]`,
+		},
+		{
+			Name:       "test tenants endpoint with predefined credentials restricted to a project without the project",
+			Credential: test.TestFakeCredential,
+			Credentials: []runtime.Object{
+				genProjectPreset(test.GenDefaultProject().Name),
+			},
+			URL:              "/api/v1/providers/openstack/tenants",
+			ExpectedResponse: `{"error":{"code":500,"message":"error getting OpenStack credentials: can not get preset fake for the user bob@acme.com"}}`,
+		},
 		{
 			Name:        "test subnets endpoint",
 			URL:         "/api/v1/providers/openstack/subnets",
@@ -310,6 +335,7 @@ func TestOpenstackEndpoints(t *testing.T) {
 			req.Header.Add("DatacenterName", datacenterName)
 			if len(tc.Credential) > 0 {
 				req.Header.Add("Credential", test.TestFakeCredential)
+				req.Header.Add("KubermaticProjectID", tc.ProjectID)
 			} else {
 				req.Header.Add("Username", test.TestOSuserName)
 				req.Header.Add("Password", test.TestOSuserPass)
@@ -334,6 +360,12 @@ func TestOpenstackEndpoints(t *testing.T) {
 	}
 }
 
+func genProjectPreset(projectID string) *kubermaticv1.Preset {
+	preset := test.GenDefaultPreset()
+	preset.Spec.Projects = []string{projectID}
+	return preset
+}
+
 func TestMeetsOpentackNodeSizeRequirement(t *testing.T) {
 	tests := []struct {
 		name                string
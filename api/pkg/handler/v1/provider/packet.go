@@ -46,6 +46,9 @@ type PacketSizesReq struct {
 	// in: header
 	// name: Credential
 	Credential string `json:"credential"`
+	// in: header
+	// name: KubermaticProjectID
+	KubermaticProjectID string `json:"kubermaticProjectID"`
 }
 
 // PacketSizesNoCredentialsReq represent a request for Packet sizes EP
@@ -65,6 +68,7 @@ func DecodePacketSizesReq(_ context.Context, r *http.Request) (interface{}, erro
 	req.APIKey = r.Header.Get("apiKey")
 	req.ProjectID = r.Header.Get("projectID")
 	req.Credential = r.Header.Get("credential")
+	req.KubermaticProjectID = r.Header.Get("kubermaticProjectID")
 
 	return req, nil
 }
@@ -88,12 +92,12 @@ func PacketSizesEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 		projectID := req.ProjectID
 		apiKey := req.APIKey
 
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -122,7 +126,7 @@ func PacketSizesWithClusterCredentialsEndpoint(projectProvider provider.ProjectP
 		if !ok {
 			return nil, errors.New(http.StatusInternalServerError, "clusterprovider is not a kubernetesprovider.Clusterprovider")
 		}
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 		apiKey, projectID, err := packet.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
 		if err != nil {
 			return nil, err
@@ -39,7 +39,7 @@ func VsphereNetworksEndpoint(seedsGetter provider.SeedsGetter, presetsProvider p
 		if !ok {
 			return nil, fmt.Errorf("incorrect type of request, expected = VSphereNetworksReq, got = %T", request)
 		}
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -47,7 +47,7 @@ func VsphereNetworksEndpoint(seedsGetter provider.SeedsGetter, presetsProvider p
 		password := req.Password
 
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -80,7 +80,7 @@ func VsphereNetworksWithClusterCredentialsEndpoint(projectProvider provider.Proj
 		if !ok {
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 
 		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
@@ -129,7 +129,7 @@ func VsphereFoldersEndpoint(seedsGetter provider.SeedsGetter, presetsProvider pr
 		if !ok {
 			return nil, fmt.Errorf("incorrect type of request, expected = VSphereFoldersReq, got = %T", request)
 		}
-		userInfo, err := userInfoGetter(ctx, "")
+		userInfo, err := userInfoGetter(ctx, req.KubermaticProjectID)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -137,7 +137,7 @@ func VsphereFoldersEndpoint(seedsGetter provider.SeedsGetter, presetsProvider pr
 		password := req.Password
 
 		if len(req.Credential) > 0 {
-			preset, err := presetsProvider.GetPreset(userInfo, req.Credential)
+			preset, err := presetsProvider.GetPreset(userInfo, req.KubermaticProjectID, req.Credential)
 			if err != nil {
 				return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("can not get preset %s for user %s", req.Credential, userInfo.Email))
 			}
@@ -169,7 +169,7 @@ func VsphereFoldersWithClusterCredentialsEndpoint(projectProvider provider.Proje
 		if !ok {
 			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
 		}
-		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+		secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient(), assertedClusterProvider.GetSecretStores())
 
 		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
@@ -219,6 +219,9 @@ type VSphereNetworksReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func DecodeVSphereNetworksReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -228,6 +231,7 @@ func DecodeVSphereNetworksReq(c context.Context, r *http.Request) (interface{},
 	req.Password = r.Header.Get("Password")
 	req.DatacenterName = r.Header.Get("DatacenterName")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 
 	return req, nil
 }
@@ -260,6 +264,9 @@ type VSphereFoldersReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: header
+	// KubermaticProjectID ID of the project the preset credential is used in
+	KubermaticProjectID string
 }
 
 func DecodeVSphereFoldersReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -269,6 +276,7 @@ func DecodeVSphereFoldersReq(c context.Context, r *http.Request) (interface{}, e
 	req.Password = r.Header.Get("Password")
 	req.DatacenterName = r.Header.Get("DatacenterName")
 	req.Credential = r.Header.Get("Credential")
+	req.KubermaticProjectID = r.Header.Get("KubermaticProjectID")
 
 	return req, nil
 }
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/gorilla/mux"
@@ -30,6 +32,8 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func CreateEndpoint(keyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
@@ -52,39 +56,35 @@ func CreateEndpoint(keyProvider provider.SSHKeyProvider, privilegedSSHKeyProvide
 			return nil, errors.NewAlreadyExists("ssh key", req.Key.Name)
 		}
 
-		key, err := createUserSSHKey(ctx, userInfoGetter, keyProvider, privilegedSSHKeyProvider, project, req.Key.Name, req.Key.Spec.PublicKey)
+		options := &provider.SSHKeyCreateOptions{
+			User:         req.Key.Spec.User,
+			NodeSelector: req.Key.Spec.NodeSelector,
+		}
+		if req.Key.Spec.ExpiresAt != nil {
+			options.ExpiresAt = &req.Key.Spec.ExpiresAt.Time
+		}
+		key, err := createUserSSHKey(ctx, userInfoGetter, keyProvider, privilegedSSHKeyProvider, project, req.Key.Name, req.Key.Spec.PublicKey, options)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		apiKey := apiv1.SSHKey{
-			ObjectMeta: apiv1.ObjectMeta{
-				ID:                key.Name,
-				Name:              key.Spec.Name,
-				CreationTimestamp: apiv1.NewTime(key.CreationTimestamp.Time),
-			},
-			Spec: apiv1.SSHKeySpec{
-				Fingerprint: key.Spec.Fingerprint,
-				PublicKey:   key.Spec.PublicKey,
-			},
-		}
-		return apiKey, nil
+		return *common.ConvertInternalSSHKeyToExternal(key), nil
 	}
 }
 
-func createUserSSHKey(ctx context.Context, userInfoGetter provider.UserInfoGetter, keyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, project *kubermaticv1.Project, keyName, pubKey string) (*kubermaticv1.UserSSHKey, error) {
+func createUserSSHKey(ctx context.Context, userInfoGetter provider.UserInfoGetter, keyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, project *kubermaticv1.Project, keyName, pubKey string, options *provider.SSHKeyCreateOptions) (*kubermaticv1.UserSSHKey, error) {
 	adminUserInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
 		return nil, err
 	}
 	if adminUserInfo.IsAdmin {
-		return privilegedSSHKeyProvider.CreateUnsecured(project, keyName, pubKey)
+		return privilegedSSHKeyProvider.CreateUnsecured(project, keyName, pubKey, options)
 	}
 	userInfo, err := userInfoGetter(ctx, project.Name)
 	if err != nil {
 		return nil, err
 	}
-	return keyProvider.Create(userInfo, project, keyName, pubKey)
+	return keyProvider.Create(userInfo, project, keyName, pubKey, options)
 }
 
 func DeleteEndpoint(keyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
@@ -213,6 +213,29 @@ func DecodeCreateReq(c context.Context, r *http.Request) (interface{}, error) {
 	if len(req.Key.Spec.PublicKey) == 0 {
 		return nil, fmt.Errorf("'spec.publicKey' field cannot be empty")
 	}
+	if err := validateSSHKeyTargeting(req.Key.Spec, time.Now()); err != nil {
+		return nil, errors.NewBadRequest("%v", err)
+	}
 
 	return req, nil
 }
+
+// validateSSHKeyTargeting validates the optional user, expiry date and node selector of a key
+func validateSSHKeyTargeting(spec apiv1.SSHKeySpec, now time.Time) error {
+	// The agent on the nodes only manages the keys of these users, keys of any other user would be ignored
+	if spec.User != "" && !kubermaticv1.SSHKeyUsers.Has(spec.User) {
+		return fmt.Errorf("'spec.user' %q is not supported, it must be one of %s", spec.User, strings.Join(kubermaticv1.SSHKeyUsers.List(), ", "))
+	}
+	if spec.ExpiresAt != nil && !spec.ExpiresAt.After(now) {
+		return fmt.Errorf("'spec.expiresAt' must be in the future")
+	}
+	for key, value := range spec.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("'spec.nodeSelector' key %q is invalid: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("'spec.nodeSelector' value %q is invalid: %s", value, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
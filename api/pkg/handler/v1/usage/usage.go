@@ -0,0 +1,309 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/util/errors"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
+// GetProjectUsageReportReq defines HTTP request for getProjectUsageReport endpoint
+// swagger:parameters getProjectUsageReport
+type GetProjectUsageReportReq struct {
+	common.ProjectReq
+	// Month of the report, e.g. 2020-03
+	// in: path
+	// required: true
+	Month string `json:"month"`
+	// Format of the report, either json or csv. Defaults to json.
+	// in: query
+	Format string `json:"format,omitempty"`
+}
+
+func DecodeGetProjectUsageReportReq(c context.Context, r *http.Request) (interface{}, error) {
+	projectReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req := GetProjectUsageReportReq{
+		ProjectReq: projectReq.(common.ProjectReq),
+		Month:      mux.Vars(r)["month"],
+		Format:     r.URL.Query().Get("format"),
+	}
+
+	if _, err := time.Parse(kubermaticv1.ClusterUsageMonthFormat, req.Month); err != nil {
+		return nil, errors.NewBadRequest("invalid month %q, must be given as YYYY-MM", req.Month)
+	}
+	switch req.Format {
+	case "":
+		req.Format = reportFormatJSON
+	case reportFormatJSON, reportFormatCSV:
+	default:
+		return nil, errors.NewBadRequest("unsupported format %q, must be either %s or %s", req.Format, reportFormatJSON, reportFormatCSV)
+	}
+	return req, nil
+}
+
+// usageReportResponse is the report together with the format it gets encoded in
+type usageReportResponse struct {
+	report *apiv1.ProjectUsageReport
+	format string
+}
+
+// GetProjectUsageReportEndpoint returns the monthly resource usage and estimated cost of the clusters of a project
+func GetProjectUsageReportEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter,
+	settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetProjectUsageReportReq)
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		usages, err := listClusterUsages(ctx, seedsGetter, clusterProviderGetter, project.Name, req.Month)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		settings, err := settingsProvider.GetGlobalSettings()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return &usageReportResponse{
+			report: buildReport(project.Name, req.Month, usages, settings.Spec.PriceLists),
+			format: req.Format,
+		}, nil
+	}
+}
+
+// listClusterUsages returns the usages of the clusters of the project from all seeds
+func listClusterUsages(ctx context.Context, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, projectID, month string) ([]kubermaticv1.ClusterUsage, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+	}
+
+	var usages []kubermaticv1.ClusterUsage
+	for seedName, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			return nil, errors.NewNotFound("cluster-provider", seedName)
+		}
+		privilegedClusterProvider, ok := clusterProvider.(provider.PrivilegedClusterProvider)
+		if !ok {
+			return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+		}
+
+		usageList := &kubermaticv1.ClusterUsageList{}
+		if err := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient().List(ctx, usageList, ctrlruntimeclient.MatchingLabels{
+			kubermaticv1.ProjectIDLabelKey:         projectID,
+			kubermaticv1.ClusterUsageMonthLabelKey: month,
+		}); err != nil {
+			return nil, err
+		}
+		usages = append(usages, usageList.Items...)
+	}
+	return usages, nil
+}
+
+// clusterTotals sums up the usage of a cluster over all days, the usage of a cluster which got migrated
+// to another seed is stored in both seeds.
+type clusterTotals struct {
+	report      apiv1.ClusterUsageReport
+	days        map[string]bool
+	samples     int64
+	nodeSeconds map[string]int64
+	nodes       int64
+	cpuCapacity int64
+	memCapacity int64
+	cpuUsage    int64
+	memUsage    int64
+}
+
+func buildReport(projectID, month string, usages []kubermaticv1.ClusterUsage, priceLists map[string]kubermaticv1.PriceList) *apiv1.ProjectUsageReport {
+	totals := map[string]*clusterTotals{}
+	for _, usage := range usages {
+		t, exists := totals[usage.Spec.Cluster]
+		if !exists {
+			t = &clusterTotals{
+				report: apiv1.ClusterUsageReport{
+					ClusterID:   usage.Spec.Cluster,
+					ClusterName: usage.Spec.ClusterHumanReadableName,
+					Provider:    usage.Spec.Provider,
+				},
+				days:        map[string]bool{},
+				nodeSeconds: map[string]int64{},
+			}
+			totals[usage.Spec.Cluster] = t
+		}
+		for _, day := range usage.Spec.Days {
+			t.days[day.Date] = true
+			t.samples += day.Samples
+			if day.MaxNodes > t.report.MaxNodes {
+				t.report.MaxNodes = day.MaxNodes
+			}
+			for size, seconds := range day.NodeSeconds {
+				t.nodeSeconds[size] += seconds
+			}
+			t.nodes += day.NodesSum
+			t.cpuCapacity += day.CPUCapacitySum
+			t.memCapacity += day.MemoryCapacitySum
+			t.cpuUsage += day.CPUUsageSum
+			t.memUsage += day.MemoryUsageSum
+		}
+	}
+
+	report := &apiv1.ProjectUsageReport{
+		ProjectID:      projectID,
+		Month:          month,
+		Clusters:       []apiv1.ClusterUsageReport{},
+		EstimatedCosts: map[string]float64{},
+	}
+	for _, t := range totals {
+		cluster := t.report
+		cluster.Days = len(t.days)
+		if t.samples > 0 {
+			cluster.AverageNodes = round(float64(t.nodes) / float64(t.samples))
+			cluster.AverageCPUCapacity = t.cpuCapacity / t.samples
+			cluster.AverageMemoryCapacity = t.memCapacity / t.samples
+			cluster.AverageCPUUsage = t.cpuUsage / t.samples
+			cluster.AverageMemoryUsage = t.memUsage / t.samples
+		}
+
+		priceList, hasPriceList := priceLists[cluster.Provider]
+		if hasPriceList {
+			cluster.Currency = priceList.Currency
+		}
+		cluster.NodeSizes = []apiv1.NodeSizeUsage{}
+		for size, seconds := range t.nodeSeconds {
+			nodeSize := apiv1.NodeSizeUsage{
+				NodeSize:  size,
+				NodeHours: round(float64(seconds) / time.Hour.Seconds()),
+			}
+			if price, ok := priceList.NodeSizes[size]; hasPriceList && ok {
+				nodeSize.PricePerHour = &price
+				nodeSize.EstimatedCost = round(float64(seconds) / time.Hour.Seconds() * price)
+				cluster.EstimatedCost += nodeSize.EstimatedCost
+			}
+			cluster.NodeSizes = append(cluster.NodeSizes, nodeSize)
+		}
+		sort.Slice(cluster.NodeSizes, func(i, j int) bool { return cluster.NodeSizes[i].NodeSize < cluster.NodeSizes[j].NodeSize })
+		cluster.EstimatedCost = round(cluster.EstimatedCost)
+
+		if cluster.Currency != "" {
+			report.EstimatedCosts[cluster.Currency] = round(report.EstimatedCosts[cluster.Currency] + cluster.EstimatedCost)
+		}
+		report.Clusters = append(report.Clusters, cluster)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool { return report.Clusters[i].ClusterID < report.Clusters[j].ClusterID })
+
+	return report
+}
+
+// round rounds to cents
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+// EncodeProjectUsageReport writes the report either as JSON or as CSV file
+func EncodeProjectUsageReport(c context.Context, w http.ResponseWriter, response interface{}) error {
+	rsp := response.(*usageReportResponse)
+	if rsp.format != reportFormatCSV {
+		w.Header().Set("Content-Type", "application/json")
+		return json.NewEncoder(w).Encode(rsp.report)
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-disposition", fmt.Sprintf("attachment; filename=usage-%s-%s.csv", rsp.report.ProjectID, rsp.report.Month))
+	w.Header().Add("Cache-Control", "no-cache")
+	return writeCSV(w, rsp.report)
+}
+
+var csvHeader = []string{
+	"project", "month", "cluster_id", "cluster_name", "provider", "max_nodes", "average_nodes",
+	"average_cpu_usage_millicores", "average_memory_usage_bytes", "node_size", "node_hours", "price_per_hour", "currency", "estimated_cost",
+}
+
+// writeCSV writes one row per cluster and node size
+func writeCSV(w http.ResponseWriter, report *apiv1.ProjectUsageReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', 2, 64)
+	}
+	for _, cluster := range report.Clusters {
+		clusterColumns := []string{
+			report.ProjectID,
+			report.Month,
+			cluster.ClusterID,
+			cluster.ClusterName,
+			cluster.Provider,
+			strconv.Itoa(int(cluster.MaxNodes)),
+			formatFloat(cluster.AverageNodes),
+			strconv.FormatInt(cluster.AverageCPUUsage, 10),
+			strconv.FormatInt(cluster.AverageMemoryUsage, 10),
+		}
+		if len(cluster.NodeSizes) == 0 {
+			if err := writer.Write(append(clusterColumns, "", "", "", cluster.Currency, formatFloat(0))); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, nodeSize := range cluster.NodeSizes {
+			price := ""
+			if nodeSize.PricePerHour != nil {
+				price = strconv.FormatFloat(*nodeSize.PricePerHour, 'f', -1, 64)
+			}
+			row := append(append([]string{}, clusterColumns...), nodeSize.NodeSize, formatFloat(nodeSize.NodeHours), price, cluster.Currency, formatFloat(nodeSize.EstimatedCost))
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func genClusterUsage(clusterID, projectID, month string, days ...kubermaticv1.ClusterUsageDay) *kubermaticv1.ClusterUsage {
+	return &kubermaticv1.ClusterUsage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s", clusterID, month),
+			Labels: map[string]string{
+				kubermaticv1.ProjectIDLabelKey:         projectID,
+				kubermaticv1.ClusterUsageMonthLabelKey: month,
+			},
+		},
+		Spec: kubermaticv1.ClusterUsageSpec{
+			Cluster:                  clusterID,
+			ClusterHumanReadableName: "cluster-" + clusterID,
+			ProjectID:                projectID,
+			Provider:                 "aws",
+			Month:                    month,
+			Days:                     days,
+		},
+	}
+}
+
+func genSettingsWithPriceList() *kubermaticv1.KubermaticSetting {
+	settings := test.GenDefaultSettings()
+	settings.Spec.PriceLists = map[string]kubermaticv1.PriceList{
+		"aws": {
+			Currency:  "USD",
+			NodeSizes: map[string]float64{"t3.small": 0.02, "m5.large": 0.1},
+		},
+	}
+	return settings
+}
+
+func TestGetProjectUsageReportEndpoint(t *testing.T) {
+	t.Parallel()
+	projectID := test.GenDefaultProject().Name
+	day := kubermaticv1.ClusterUsageDay{
+		Date:        "2020-03-01",
+		Samples:     4,
+		MaxNodes:    3,
+		NodeSeconds: map[string]int64{"t3.small": 36000, "m5.large": 7200, "custom": 3600},
+		NodesSum:    10,
+		CPUUsageSum: 2000,
+	}
+
+	testcases := []struct {
+		Name                   string
+		Month                  string
+		Format                 string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingKubermaticObjs []runtime.Object
+		ExistingAPIUser        *apiv1.User
+	}{
+		{
+			Name:  "scenario 1: the report contains the node hours and the estimated cost of the clusters of the project",
+			Month: "2020-03",
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genSettingsWithPriceList(),
+				genClusterUsage("abcd", projectID, "2020-03", day),
+				genClusterUsage("other", "my-second-project-ID", "2020-03", day),
+				genClusterUsage("abcd", projectID, "2020-02", day),
+			),
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			HTTPStatus:       http.StatusOK,
+			ExpectedResponse: `{"projectID":"my-first-project-ID","month":"2020-03","clusters":[{"clusterID":"abcd","clusterName":"cluster-abcd","provider":"aws","days":1,"maxNodes":3,"averageNodes":2.5,"averageCPUCapacity":0,"averageMemoryCapacity":0,"averageCPUUsage":500,"averageMemoryUsage":0,"nodeSizes":[{"nodeSize":"custom","nodeHours":1,"estimatedCost":0},{"nodeSize":"m5.large","nodeHours":2,"pricePerHour":0.1,"estimatedCost":0.2},{"nodeSize":"t3.small","nodeHours":10,"pricePerHour":0.02,"estimatedCost":0.2}],"estimatedCost":0.4,"currency":"USD"}],"estimatedCosts":{"USD":0.4}}`,
+		},
+		{
+			Name:   "scenario 2: the report is exported as CSV",
+			Month:  "2020-03",
+			Format: "csv",
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genSettingsWithPriceList(),
+				genClusterUsage("abcd", projectID, "2020-03", day),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			HTTPStatus:      http.StatusOK,
+			ExpectedResponse: `project,month,cluster_id,cluster_name,provider,max_nodes,average_nodes,average_cpu_usage_millicores,average_memory_usage_bytes,node_size,node_hours,price_per_hour,currency,estimated_cost
+my-first-project-ID,2020-03,abcd,cluster-abcd,aws,3,2.50,500,0,custom,1.00,,USD,0.00
+my-first-project-ID,2020-03,abcd,cluster-abcd,aws,3,2.50,500,0,m5.large,2.00,0.1,USD,0.20
+my-first-project-ID,2020-03,abcd,cluster-abcd,aws,3,2.50,500,0,t3.small,10.00,0.02,USD,0.20`,
+		},
+		{
+			Name:                   "scenario 3: an invalid month is rejected",
+			Month:                  "march",
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			HTTPStatus:             http.StatusBadRequest,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid month \"march\", must be given as YYYY-MM"}}`,
+		},
+		{
+			Name:                   "scenario 4: the user can't get the report of a project they don't belong to",
+			Month:                  "2020-03",
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+			HTTPStatus:             http.StatusForbidden,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			url := fmt.Sprintf("/api/v1/projects/%s/usage/%s", projectID, tc.Month)
+			if tc.Format != "" {
+				url = fmt.Sprintf("%s?format=%s", url, tc.Format)
+			}
+			req := httptest.NewRequest("GET", url, strings.NewReader(""))
+			res := httptest.NewRecorder()
+
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.Format == "csv" {
+				if contentType := res.Header().Get("Content-Type"); contentType != "text/csv" {
+					t.Fatalf("expected content type text/csv, got %s", contentType)
+				}
+				if actual := strings.TrimSpace(res.Body.String()); actual != tc.ExpectedResponse {
+					t.Fatalf("expected CSV\n%s\ngot\n%s", tc.ExpectedResponse, actual)
+				}
+				return
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
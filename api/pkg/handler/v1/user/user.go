@@ -41,7 +41,7 @@ import (
 )
 
 // DeleteEndpoint deletes the given user/member from the given project
-func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(DeleteReq)
 		if !ok {
@@ -83,6 +83,9 @@ func DeleteEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 		if err = deleteBinding(ctx, userInfoGetter, memberProvider, privilegedMemberProvider, req.ProjectID, bindingForRequestedMember.Name); err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		if err := common.RevokeShortLivedKubeconfigs(clusterProviderGetter, seedsGetter, project, user.Spec.Email); err != nil {
+			return nil, k8cerrors.New(http.StatusInternalServerError, fmt.Sprintf("the user got removed from the project but the kubeconfigs could not be revoked: %v", err))
+		}
 
 		return nil, nil
 	}
@@ -130,7 +133,7 @@ func getMemberList(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 }
 
 // EditEndpoint changes the group the given user/member belongs in the given project
-func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(EditReq)
 		if !ok {
@@ -170,12 +173,19 @@ func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 		}
 
 		currentMemberBinding := memberList[0]
+		previousGroupName := currentMemberBinding.Spec.Group
 		generatedGroupName := rbac.GenerateActualGroupNameFor(project.Name, projectFromRequest.GroupPrefix)
 		currentMemberBinding.Spec.Group = generatedGroupName
 		updatedMemberBinding, err := updateBinding(ctx, userInfoGetter, memberProvider, privilegedMemberProvider, req.ProjectID, currentMemberBinding)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		// The kubeconfigs grant the permissions of the previous group, the user has to download new ones
+		if previousGroupName != generatedGroupName {
+			if err := common.RevokeShortLivedKubeconfigs(clusterProviderGetter, seedsGetter, project, currentMemberFromRequest.Email); err != nil {
+				return nil, k8cerrors.New(http.StatusInternalServerError, fmt.Sprintf("the membership got changed but the kubeconfigs could not be revoked: %v", err))
+			}
+		}
 
 		externalUser := convertInternalUserToExternal(memberToUpdate, false, updatedMemberBinding)
 		externalUser = filterExternalUser(externalUser, project.Name)
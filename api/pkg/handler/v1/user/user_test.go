@@ -17,6 +17,7 @@ limitations under the License.
 package user_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -29,8 +30,11 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/api/pkg/handler/test/hack"
 
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestGetUsersForProject(t *testing.T) {
@@ -415,6 +419,36 @@ func TestDeleteUserFromProject(t *testing.T) {
 	}
 }
 
+func TestDeleteUserFromProjectRevokesKubeconfigs(t *testing.T) {
+	t.Parallel()
+	kubermaticObj := []runtime.Object{
+		test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+		test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+		test.GenBinding("plan9-ID", "bob@acme.com", "viewers"),
+		genUser("", "john", "john@acme.com"),
+		genDefaultUser(), /*bob*/
+		test.GenCluster("clusterID", "cluster", "plan9-ID", test.DefaultCreationTimestamp()),
+	}
+	kubeconfigBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:kubeconfig:bob@acme.com"}}
+
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/projects/plan9-ID/users/%s", genDefaultUser().Name), nil)
+	res := httptest.NewRecorder()
+	ep, clients, err := test.CreateTestEndpointAndGetClients(*genAPIUser("john", "john@acme.com"), nil, []runtime.Object{kubeconfigBinding}, nil, kubermaticObj, nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	err = clients.FakeClient.Get(context.Background(), types.NamespacedName{Name: kubeconfigBinding.Name}, &rbacv1.ClusterRoleBinding{})
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("expected the ClusterRoleBinding of the kubeconfigs to be removed, got %v", err)
+	}
+}
+
 func TestEditUserInProject(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -73,8 +73,13 @@ func ValidateKubernetesToken(token string) error {
 	return nil
 }
 
+// ValidateSecretKeySelector checks that the selector refers to a secret. Selectors of Kubernetes Secrets
+// require a namespace, selectors of external secret stores, which have their Kind set, only a path as name.
 func ValidateSecretKeySelector(selector *providerconfig.GlobalSecretKeySelector, key string) error {
-	if selector.Name == "" || selector.Namespace == "" || key == "" {
+	if selector.Name == "" || key == "" {
+		return fmt.Errorf("%q cannot be empty", key)
+	}
+	if (selector.Kind == "" || selector.Kind == "Secret") && selector.Namespace == "" {
 		return fmt.Errorf("%q cannot be empty", key)
 	}
 	return nil
@@ -21,14 +21,13 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
-	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 )
 
 func instanceProfileName(clusterName string) string {
 	return resourceNamePrefix + clusterName
 }
 
-func createInstanceProfile(client iamiface.IAMAPI, profileName string) (*iam.InstanceProfile, error) {
+func createInstanceProfile(client iamAPI, profileName string) (*iam.InstanceProfile, error) {
 	createProfileInput := &iam.CreateInstanceProfileInput{
 		InstanceProfileName: aws.String(profileName),
 	}
@@ -52,7 +51,7 @@ func createInstanceProfile(client iamiface.IAMAPI, profileName string) (*iam.Ins
 	return profileOutput.InstanceProfile, nil
 }
 
-func createWorkerInstanceProfile(client iamiface.IAMAPI, clusterName string) (*iam.InstanceProfile, error) {
+func createWorkerInstanceProfile(client iamAPI, clusterName string) (*iam.InstanceProfile, error) {
 	workerRole, err := createWorkerRole(client, clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create worker role: %v", err)
@@ -82,7 +81,7 @@ func createWorkerInstanceProfile(client iamiface.IAMAPI, clusterName string) (*i
 	return workerInstanceProfile, nil
 }
 
-func deleteInstanceProfile(client iamiface.IAMAPI, profileName string) error {
+func deleteInstanceProfile(client iamAPI, profileName string) error {
 	getProfileInput := &iam.GetInstanceProfileInput{
 		InstanceProfileName: aws.String(profileName),
 	}
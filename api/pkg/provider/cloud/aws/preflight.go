@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+)
+
+// machineControllerActions are the IAM actions the machine-controller needs to manage the worker instances.
+var machineControllerActions = []string{
+	"ec2:DescribeAvailabilityZones",
+	"ec2:DescribeImages",
+	"ec2:DescribeInstances",
+	"ec2:RunInstances",
+	"ec2:TerminateInstances",
+	"iam:PassRole",
+}
+
+// requiredActions are the IAM actions the credentials of a cluster must be granted. The actions
+// Kubermatic needs itself are derived from the operations of ec2API and iamAPI.
+var requiredActions = append(append(
+	apiActions("ec2", (*ec2API)(nil)),
+	apiActions("iam", (*iamAPI)(nil))...),
+	machineControllerActions...)
+
+// apiActions returns the IAM actions of the given service for all methods of the given interface,
+// which must be passed as a nil pointer.
+func apiActions(service string, api interface{}) []string {
+	apiType := reflect.TypeOf(api).Elem()
+	actions := make([]string, 0, apiType.NumMethod())
+	for i := 0; i < apiType.NumMethod(); i++ {
+		actions = append(actions, service+":"+apiType.Method(i).Name)
+	}
+	return actions
+}
+
+// ValidateCloudCredentials verifies the credentials by asking STS for the caller identity and
+// simulates the policies of that identity for all actions Kubermatic needs.
+func (a *AmazonEC2) ValidateCloudCredentials(spec kubermaticv1.CloudSpec) (*provider.CredentialsReport, error) {
+	client, err := a.getClientSet(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API client: %v", err)
+	}
+	return validateCredentials(client.STS, client.IAM)
+}
+
+func validateCredentials(stsClient stsiface.STSAPI, iamClient iamPolicySimulatorAPI) (*provider.CredentialsReport, error) {
+	identity, err := stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		if isAuthError(err) {
+			return provider.InvalidCredentialsReport(fmt.Sprintf("the credentials got rejected: %v", err)), nil
+		}
+		return nil, fmt.Errorf("failed to get caller identity: %v", err)
+	}
+	callerARN := aws.StringValue(identity.Arn)
+
+	principalARN, ok := policySourceARN(callerARN)
+	if !ok {
+		// The account root user is granted every action and can't be simulated
+		return provider.NewCredentialsReport(callerARN, permissionChecks(provider.PermissionAllowed, "")), nil
+	}
+
+	decisions := map[string]string{}
+	err = iamClient.SimulatePrincipalPolicyPages(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalARN),
+		ActionNames:     aws.StringSlice(requiredActions),
+	}, func(page *iam.SimulatePolicyResponse, _ bool) bool {
+		for _, result := range page.EvaluationResults {
+			decisions[aws.StringValue(result.EvalActionName)] = aws.StringValue(result.EvalDecision)
+		}
+		return true
+	})
+	if err != nil {
+		if isAuthError(err) {
+			message := fmt.Sprintf("the permissions could not be verified as %s is not allowed to simulate its policies", callerARN)
+			return provider.NewCredentialsReport(callerARN, permissionChecks(provider.PermissionUnknown, message)), nil
+		}
+		return nil, fmt.Errorf("failed to simulate the policies of %s: %v", principalARN, err)
+	}
+
+	checks := make([]provider.PermissionCheck, 0, len(requiredActions))
+	for _, action := range requiredActions {
+		check := provider.PermissionCheck{Permission: action}
+		switch decision := decisions[action]; decision {
+		case iam.PolicyEvaluationDecisionTypeAllowed:
+			check.Result = provider.PermissionAllowed
+		case iam.PolicyEvaluationDecisionTypeExplicitDeny, iam.PolicyEvaluationDecisionTypeImplicitDeny:
+			check.Result = provider.PermissionDenied
+			check.Message = fmt.Sprintf("%s is not allowed to perform %s (%s)", callerARN, action, decision)
+		default:
+			check.Result = provider.PermissionUnknown
+			check.Message = "the action was not part of the simulation result"
+		}
+		checks = append(checks, check)
+	}
+
+	return provider.NewCredentialsReport(callerARN, checks), nil
+}
+
+func permissionChecks(result provider.PermissionCheckResult, message string) []provider.PermissionCheck {
+	checks := make([]provider.PermissionCheck, 0, len(requiredActions))
+	for _, action := range requiredActions {
+		checks = append(checks, provider.PermissionCheck{Permission: action, Result: result, Message: message})
+	}
+	return checks
+}
+
+// policySourceARN returns the ARN of the IAM principal whose policies apply to the given caller.
+// Sessions of an assumed role are mapped to the role itself. It returns false for the account root user.
+func policySourceARN(callerARN string) (string, bool) {
+	// arn:aws:iam::123456789012:root
+	if strings.HasSuffix(callerARN, ":root") {
+		return "", false
+	}
+
+	// arn:aws:sts::123456789012:assumed-role/role-name/session-name
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) == 6 && parts[2] == "sts" && strings.HasPrefix(parts[5], "assumed-role/") {
+		resource := strings.Split(parts[5], "/")
+		if len(resource) >= 2 {
+			return fmt.Sprintf("%s:%s:iam::%s:role/%s", parts[0], parts[1], parts[4], resource[1]), true
+		}
+	}
+
+	return callerARN, true
+}
+
+func isAuthError(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	switch aerr.Code() {
+	case authFailure, "AccessDenied", "InvalidClientTokenId", "SignatureDoesNotMatch", "UnrecognizedClientException":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type fakeSTSClient struct {
+	stsiface.STSAPI
+	arn string
+	err error
+}
+
+func (c *fakeSTSClient) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &sts.GetCallerIdentityOutput{Arn: aws.String(c.arn)}, nil
+}
+
+type fakeSimulationClient struct {
+	iamiface.IAMAPI
+	denied          map[string]bool
+	err             error
+	policySourceARN string
+}
+
+func (c *fakeSimulationClient) SimulatePrincipalPolicyPages(input *iam.SimulatePrincipalPolicyInput, fn func(*iam.SimulatePolicyResponse, bool) bool) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.policySourceARN = aws.StringValue(input.PolicySourceArn)
+
+	page := &iam.SimulatePolicyResponse{}
+	for _, action := range input.ActionNames {
+		decision := iam.PolicyEvaluationDecisionTypeAllowed
+		if c.denied[aws.StringValue(action)] {
+			decision = iam.PolicyEvaluationDecisionTypeImplicitDeny
+		}
+		page.EvaluationResults = append(page.EvaluationResults, &iam.EvaluationResult{
+			EvalActionName: action,
+			EvalDecision:   aws.String(decision),
+		})
+	}
+	fn(page, true)
+	return nil
+}
+
+func TestValidateCredentials(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		sts                     *fakeSTSClient
+		iam                     *fakeSimulationClient
+		expectedValid           bool
+		expectedDenied          []string
+		expectedResult          provider.PermissionCheckResult
+		expectedPolicySourceARN string
+		expectedErr             bool
+	}{
+		{
+			name:                    "all permissions granted",
+			sts:                     &fakeSTSClient{arn: "arn:aws:iam::123456789012:user/kubermatic"},
+			iam:                     &fakeSimulationClient{},
+			expectedValid:           true,
+			expectedResult:          provider.PermissionAllowed,
+			expectedPolicySourceARN: "arn:aws:iam::123456789012:user/kubermatic",
+		},
+		{
+			name:                    "missing permissions of an assumed role",
+			sts:                     &fakeSTSClient{arn: "arn:aws:sts::123456789012:assumed-role/kubermatic/session"},
+			iam:                     &fakeSimulationClient{denied: map[string]bool{"iam:PassRole": true, "ec2:RunInstances": true}},
+			expectedValid:           false,
+			expectedDenied:          []string{"ec2:RunInstances", "iam:PassRole"},
+			expectedPolicySourceARN: "arn:aws:iam::123456789012:role/kubermatic",
+		},
+		{
+			name:           "root user",
+			sts:            &fakeSTSClient{arn: "arn:aws:iam::123456789012:root"},
+			iam:            &fakeSimulationClient{err: errors.New("must not be called")},
+			expectedValid:  true,
+			expectedResult: provider.PermissionAllowed,
+		},
+		{
+			name:           "rejected credentials",
+			sts:            &fakeSTSClient{err: awserr.New("InvalidClientTokenId", "The security token included in the request is invalid.", nil)},
+			iam:            &fakeSimulationClient{},
+			expectedValid:  false,
+			expectedResult: "",
+		},
+		{
+			name:                    "credentials not allowed to simulate their policies",
+			sts:                     &fakeSTSClient{arn: "arn:aws:iam::123456789012:user/kubermatic"},
+			iam:                     &fakeSimulationClient{err: awserr.New("AccessDenied", "not authorized to perform: iam:SimulatePrincipalPolicy", nil)},
+			expectedValid:           true,
+			expectedResult:          provider.PermissionUnknown,
+			expectedPolicySourceARN: "",
+		},
+		{
+			name:        "unexpected error",
+			sts:         &fakeSTSClient{err: errors.New("connection refused")},
+			iam:         &fakeSimulationClient{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report, err := validateCredentials(tc.sts, tc.iam)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if report.Valid != tc.expectedValid {
+				t.Errorf("expected valid to be %v, got %v (%s)", tc.expectedValid, report.Valid, report.Message)
+			}
+			if tc.iam.policySourceARN != tc.expectedPolicySourceARN {
+				t.Errorf("expected the policies of %q to be simulated, got %q", tc.expectedPolicySourceARN, tc.iam.policySourceARN)
+			}
+
+			denied := report.DeniedPermissions()
+			if len(denied) != len(tc.expectedDenied) {
+				t.Fatalf("expected denied permissions %v, got %v", tc.expectedDenied, denied)
+			}
+			for i := range denied {
+				if denied[i] != tc.expectedDenied[i] {
+					t.Errorf("expected denied permissions %v, got %v", tc.expectedDenied, denied)
+				}
+			}
+
+			if tc.expectedResult != "" {
+				if len(report.Permissions) != len(requiredActions) {
+					t.Fatalf("expected %d permission checks, got %d", len(requiredActions), len(report.Permissions))
+				}
+				for _, check := range report.Permissions {
+					if check.Result != tc.expectedResult {
+						t.Errorf("expected %s to be %s, got %s", check.Permission, tc.expectedResult, check.Result)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredActions(t *testing.T) {
+	actions := sets.NewString(requiredActions...)
+	if actions.Len() != len(requiredActions) {
+		t.Errorf("expected the required actions to be unique, got %v", requiredActions)
+	}
+	for _, action := range []string{"ec2:CreateSecurityGroup", "ec2:DescribeVpcs", "iam:CreateRole", "iam:PutRolePolicy", "ec2:RunInstances", "iam:PassRole"} {
+		if !actions.Has(action) {
+			t.Errorf("expected %s to be a required action", action)
+		}
+	}
+	if actions.Has("iam:SimulatePrincipalPolicy") || actions.Has("iam:SimulatePrincipalPolicyPages") {
+		t.Error("expected the policy simulation not to be a required action")
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
@@ -212,7 +211,7 @@ func NewCloudProvider(dc *kubermaticv1.Datacenter, secretKeyGetter provider.Secr
 	}, nil
 }
 
-func getDefaultVpc(client ec2iface.EC2API) (*ec2.Vpc, error) {
+func getDefaultVpc(client ec2API) (*ec2.Vpc, error) {
 	vpcOut, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{
 		Filters: []*ec2.Filter{
 			{Name: aws.String("isDefault"), Values: []*string{aws.String("true")}},
@@ -230,7 +229,7 @@ func getDefaultVpc(client ec2iface.EC2API) (*ec2.Vpc, error) {
 	return vpcOut.Vpcs[0], nil
 }
 
-func getRouteTable(vpcID string, client ec2iface.EC2API) (*ec2.RouteTable, error) {
+func getRouteTable(vpcID string, client ec2API) (*ec2.RouteTable, error) {
 	out, err := client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
 		Filters: []*ec2.Filter{
 			{Name: aws.String("vpc-id"), Values: []*string{&vpcID}},
@@ -248,7 +247,7 @@ func getRouteTable(vpcID string, client ec2iface.EC2API) (*ec2.RouteTable, error
 	return out.RouteTables[0], nil
 }
 
-func getVPCByID(vpcID string, client ec2iface.EC2API) (*ec2.Vpc, error) {
+func getVPCByID(vpcID string, client ec2API) (*ec2.Vpc, error) {
 	vpcOut, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{
 		Filters: []*ec2.Filter{
 			{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}},
@@ -273,7 +272,7 @@ func clusterTag(clusterName string) *ec2.Tag {
 	}
 }
 
-func tagResources(cluster *kubermaticv1.Cluster, client ec2iface.EC2API) error {
+func tagResources(cluster *kubermaticv1.Cluster, client ec2API) error {
 	sOut, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			{
@@ -303,7 +302,7 @@ func tagResources(cluster *kubermaticv1.Cluster, client ec2iface.EC2API) error {
 	return nil
 }
 
-func removeTags(cluster *kubermaticv1.Cluster, client ec2iface.EC2API) error {
+func removeTags(cluster *kubermaticv1.Cluster, client ec2API) error {
 	sOut, err := client.DescribeSubnets(&ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			{
@@ -329,7 +328,7 @@ func removeTags(cluster *kubermaticv1.Cluster, client ec2iface.EC2API) error {
 
 // Get security group by aws generated id string (sg-xxxxx).
 // Error is returned in case no such group exists.
-func getSecurityGroupByID(client ec2iface.EC2API, vpc *ec2.Vpc, id string) (*ec2.SecurityGroup, error) {
+func getSecurityGroupByID(client ec2API, vpc *ec2.Vpc, id string) (*ec2.SecurityGroup, error) {
 	dsgOut, err := client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
 		GroupIds: aws.StringSlice([]string{id}),
 		Filters: []*ec2.Filter{
@@ -352,7 +351,7 @@ func getSecurityGroupByID(client ec2iface.EC2API, vpc *ec2.Vpc, id string) (*ec2
 // Create security group ("sg") with name `name` in `vpc`. The name
 // in a sg must be unique within the vpc (no pre-existing sg with
 // that name is allowed).
-func createSecurityGroup(client ec2iface.EC2API, vpcID, clusterName string) (string, error) {
+func createSecurityGroup(client ec2API, vpcID, clusterName string) (string, error) {
 	var securityGroupID string
 
 	newSecurityGroupName := resourceNamePrefix + clusterName
@@ -21,7 +21,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
-	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 )
 
 const (
@@ -33,7 +32,7 @@ func workerRoleName(clusterName string) string {
 	return fmt.Sprintf("%s%s-worker", resourceNamePrefix, clusterName)
 }
 
-func createWorkerRole(client iamiface.IAMAPI, clusterName string) (*iam.Role, error) {
+func createWorkerRole(client iamAPI, clusterName string) (*iam.Role, error) {
 	policies := map[string]string{workerPolicyName: workerRolePolicy}
 	return createRole(client, workerRoleName(clusterName), assumeRolePolicy, policies)
 }
@@ -42,7 +41,7 @@ func controlPlaneRoleName(clusterName string) string {
 	return fmt.Sprintf("%s%s-control-plane", resourceNamePrefix, clusterName)
 }
 
-func createControlPlaneRole(client iamiface.IAMAPI, clusterName string) (*iam.Role, error) {
+func createControlPlaneRole(client iamAPI, clusterName string) (*iam.Role, error) {
 	policy, err := getControlPlanePolicy(clusterName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build the control plane policy: %v", err)
@@ -51,7 +50,7 @@ func createControlPlaneRole(client iamiface.IAMAPI, clusterName string) (*iam.Ro
 	return createRole(client, controlPlaneRoleName(clusterName), assumeRolePolicy, policies)
 }
 
-func createRole(client iamiface.IAMAPI, roleName, assumeRolePolicy string, rolePolicies map[string]string) (*iam.Role, error) {
+func createRole(client iamAPI, roleName, assumeRolePolicy string, rolePolicies map[string]string) (*iam.Role, error) {
 	createRoleInput := &iam.CreateRoleInput{
 		AssumeRolePolicyDocument: aws.String(assumeRolePolicy),
 		RoleName:                 aws.String(roleName),
@@ -87,7 +86,7 @@ func createRole(client iamiface.IAMAPI, roleName, assumeRolePolicy string, roleP
 	return role, nil
 }
 
-func deleteRole(client iamiface.IAMAPI, roleName string) error {
+func deleteRole(client iamAPI, roleName string) error {
 	getRoleInput := &iam.GetRoleInput{
 		RoleName: aws.String(roleName),
 	}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"fmt"
+	"net/http"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+)
+
+// ValidateCloudCredentials requests a token for the service principal and checks if it is
+// allowed to read the resources Kubermatic manages for a cluster. Write permissions can't be
+// verified without side effects, so only read access gets checked.
+func (a *Azure) ValidateCloudCredentials(cloud kubermaticv1.CloudSpec) (*provider.CredentialsReport, error) {
+	credentials, err := GetCredentialsForCluster(cloud, a.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := auth.NewClientCredentialsConfig(credentials.ClientID, credentials.ClientSecret, credentials.TenantID).ServicePrincipalToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service principal token: %v", err)
+	}
+	if err := token.Refresh(); err != nil {
+		return provider.InvalidCredentialsReport(fmt.Sprintf("the credentials got rejected: %v", err)), nil
+	}
+
+	groupsClient, err := getGroupsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+	networksClient, err := getNetworksClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+	securityGroupsClient, err := getSecurityGroupsClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+	routeTablesClient, err := getRouteTablesClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+	asClient, err := getAvailabilitySetClient(cloud, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	probes := []struct {
+		permission string
+		probe      func() error
+	}{
+		{"Microsoft.Resources/subscriptions/resourceGroups/read", func() error {
+			_, err := groupsClient.List(a.ctx, "", nil)
+			return err
+		}},
+		{"Microsoft.Network/virtualNetworks/read", func() error {
+			_, err := networksClient.ListAll(a.ctx)
+			return err
+		}},
+		{"Microsoft.Network/networkSecurityGroups/read", func() error {
+			_, err := securityGroupsClient.ListAll(a.ctx)
+			return err
+		}},
+		{"Microsoft.Network/routeTables/read", func() error {
+			_, err := routeTablesClient.ListAll(a.ctx)
+			return err
+		}},
+		{"Microsoft.Compute/availabilitySets/read", func() error {
+			_, err := asClient.ListBySubscription(a.ctx)
+			return err
+		}},
+	}
+
+	checks := make([]provider.PermissionCheck, 0, len(probes))
+	for _, p := range probes {
+		check := provider.PermissionCheck{Permission: p.permission, Result: provider.PermissionAllowed}
+		if err := p.probe(); err != nil {
+			check.Message = err.Error()
+			check.Result = provider.PermissionUnknown
+			if detErr, ok := err.(autorest.DetailedError); ok && (detErr.StatusCode == http.StatusUnauthorized || detErr.StatusCode == http.StatusForbidden) {
+				check.Result = provider.PermissionDenied
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	return provider.NewCredentialsReport(credentials.ClientID, checks), nil
+}
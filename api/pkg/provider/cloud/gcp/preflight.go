@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+)
+
+// ValidateCloudCredentials checks if the service account is able to authenticate and to read the
+// resources Kubermatic manages for a cluster. Write permissions can't be verified without side
+// effects, so only read access gets checked.
+func (g *gcp) ValidateCloudCredentials(spec kubermaticv1.CloudSpec) (*provider.CredentialsReport, error) {
+	serviceAccount, err := GetCredentialsForCluster(spec, g.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+	svc, project, err := ConnectToComputeService(serviceAccount)
+	if err != nil {
+		return provider.InvalidCredentialsReport(fmt.Sprintf("the service account is invalid: %v", err)), nil
+	}
+
+	probes := []struct {
+		permission string
+		probe      func() error
+	}{
+		{"compute.projects.get", func() error {
+			_, err := svc.Projects.Get(project).Do()
+			return err
+		}},
+		{"compute.networks.list", func() error {
+			_, err := svc.Networks.List(project).MaxResults(1).Do()
+			return err
+		}},
+		{"compute.firewalls.list", func() error {
+			_, err := svc.Firewalls.List(project).MaxResults(1).Do()
+			return err
+		}},
+		{"compute.routes.list", func() error {
+			_, err := svc.Routes.List(project).MaxResults(1).Do()
+			return err
+		}},
+		{"compute.instances.list", func() error {
+			_, err := svc.Instances.AggregatedList(project).MaxResults(1).Do()
+			return err
+		}},
+	}
+
+	checks := make([]provider.PermissionCheck, 0, len(probes))
+	for _, p := range probes {
+		check := provider.PermissionCheck{Permission: p.permission, Result: provider.PermissionAllowed}
+		if err := p.probe(); err != nil {
+			if isTokenError(err) {
+				return provider.InvalidCredentialsReport(fmt.Sprintf("the credentials got rejected: %v", err)), nil
+			}
+			check.Message = err.Error()
+			check.Result = provider.PermissionUnknown
+			if isHTTPError(err, http.StatusUnauthorized) || isHTTPError(err, http.StatusForbidden) {
+				check.Result = provider.PermissionDenied
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	return provider.NewCredentialsReport(fmt.Sprintf("project %s", project), checks), nil
+}
+
+// isTokenError returns true if the error was caused by Google refusing to issue a token for the service account
+func isTokenError(err error) bool {
+	uerr, ok := err.(*url.Error)
+	if !ok {
+		return false
+	}
+	_, ok = uerr.Err.(*oauth2.RetrieveError)
+	return ok
+}
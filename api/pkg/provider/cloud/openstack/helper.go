@@ -354,7 +354,7 @@ func detachSubnetFromRouter(netClient *gophercloud.ServiceClient, subnetID, rout
 	return res.Extract()
 }
 
-func getFlavors(authClient *gophercloud.ProviderClient, region string) ([]osflavors.Flavor, error) {
+func newComputeClient(authClient *gophercloud.ProviderClient, region string) (*gophercloud.ServiceClient, error) {
 	computeClient, err := goopenstack.NewComputeV2(authClient, gophercloud.EndpointOpts{Availability: gophercloud.AvailabilityPublic, Region: region})
 	if err != nil {
 		// this is special case for  services that span only one region.
@@ -369,6 +369,14 @@ func getFlavors(authClient *gophercloud.ProviderClient, region string) ([]osflav
 			return nil, fmt.Errorf("couldn't get identity endpoint: %v", err)
 		}
 	}
+	return computeClient, nil
+}
+
+func getFlavors(authClient *gophercloud.ProviderClient, region string) ([]osflavors.Flavor, error) {
+	computeClient, err := newComputeClient(authClient, region)
+	if err != nil {
+		return nil, err
+	}
 
 	var allFlavors []osflavors.Flavor
 	pager := osflavors.ListDetail(computeClient, osflavors.ListOpts{})
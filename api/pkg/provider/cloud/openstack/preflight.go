@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	osflavors "github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	osrouters "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	ossecuritygroups "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	osnetworks "github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	ossubnets "github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/pagination"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+)
+
+// ValidateCloudCredentials authenticates with the given credentials and checks if they are
+// allowed to list the resources Kubermatic manages for a cluster. OpenStack has no way to
+// check write permissions without side effects, so only read access gets verified.
+func (os *Provider) ValidateCloudCredentials(spec kubermaticv1.CloudSpec) (*provider.CredentialsReport, error) {
+	creds, err := GetCredentialsForCluster(spec, os.secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
+	authClient, err := getAuthClient(creds.Username, creds.Password, creds.Domain, creds.Tenant, creds.TenantID, os.dc.AuthURL)
+	if err != nil {
+		if isAuthErr(err) {
+			return provider.InvalidCredentialsReport(fmt.Sprintf("the credentials got rejected: %v", err)), nil
+		}
+		return nil, fmt.Errorf("failed to authenticate: %v", err)
+	}
+
+	netClient, err := newNetClient(authClient, os.dc.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network client: %v", err)
+	}
+	computeClient, err := newComputeClient(authClient, os.dc.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compute client: %v", err)
+	}
+
+	checks := []provider.PermissionCheck{
+		probePermission("network:get_network", osnetworks.List(netClient, osnetworks.ListOpts{})),
+		probePermission("network:get_subnet", ossubnets.List(netClient, ossubnets.ListOpts{})),
+		probePermission("network:get_router", osrouters.List(netClient, osrouters.ListOpts{})),
+		probePermission("network:get_security_group", ossecuritygroups.List(netClient, ossecuritygroups.ListOpts{})),
+		probePermission("compute:flavors:list", osflavors.ListDetail(computeClient, osflavors.ListOpts{})),
+	}
+
+	return provider.NewCredentialsReport(fmt.Sprintf("%s@%s", creds.Username, creds.Domain), checks), nil
+}
+
+// probePermission fetches the first page of the given pager to find out if the credentials are allowed to list the resource
+func probePermission(permission string, pager pagination.Pager) provider.PermissionCheck {
+	check := provider.PermissionCheck{Permission: permission, Result: provider.PermissionAllowed}
+	err := pager.EachPage(func(pagination.Page) (bool, error) {
+		return false, nil
+	})
+	if err != nil {
+		check.Message = err.Error()
+		check.Result = provider.PermissionUnknown
+		if isAuthErr(err) {
+			check.Result = provider.PermissionDenied
+		}
+	}
+	return check
+}
+
+func isAuthErr(err error) bool {
+	switch err.(type) {
+	case gophercloud.ErrDefault401, *gophercloud.ErrDefault401, gophercloud.ErrDefault403, *gophercloud.ErrDefault403:
+		return true
+	}
+	return false
+}
@@ -461,6 +461,10 @@ func getNetClient(username, password, domain, tenant, tenantID, authURL, region
 		return nil, err
 	}
 
+	return newNetClient(authClient, region)
+}
+
+func newNetClient(authClient *gophercloud.ProviderClient, region string) (*gophercloud.ServiceClient, error) {
 	serviceClient, err := goopenstack.NewNetworkV2(authClient, gophercloud.EndpointOpts{Region: region})
 	if err != nil {
 		// this is special case for  services that span only one region.
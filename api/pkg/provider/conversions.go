@@ -19,6 +19,7 @@ package provider
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
@@ -31,10 +32,26 @@ import (
 // once we support datacenters as CRDs.
 // TODO: Find a way to lift the current requirement of unique nodeDatacenter names. It is needed
 // only because we put the nodeDatacenter name on the cluster but not the seed
+// Shared datacenters are defined in more than one seed. For them, the first seed by name is
+// returned, which is only meant to read the datacenter spec. Placing a new cluster must consider
+// all seeds returned by DatacenterSeedsFromSeedMap.
 func DatacenterFromSeedMap(userInfo *UserInfo, seedsGetter SeedsGetter, datacenterName string) (*kubermaticv1.Seed, *kubermaticv1.Datacenter, error) {
+	foundSeeds, err := DatacenterSeedsFromSeedMap(userInfo, seedsGetter, datacenterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	datacenter := foundSeeds[0].Spec.Datacenters[datacenterName]
+	return foundSeeds[0], &datacenter, nil
+}
+
+// DatacenterSeedsFromSeedMap returns all seeds that define the given datacenter and
+// are accessible for the user, sorted by name. Only shared datacenters can be
+// defined in more than one seed.
+func DatacenterSeedsFromSeedMap(userInfo *UserInfo, seedsGetter SeedsGetter, datacenterName string) ([]*kubermaticv1.Seed, error) {
 	seeds, err := seedsGetter()
 	if err != nil {
-		return nil, nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
 	}
 
 	var foundDatacenters []kubermaticv1.Datacenter
@@ -59,7 +76,7 @@ iterateOverSeeds:
 			// find datacenter for specific email domain
 			split := strings.Split(userInfo.Email, "@")
 			if len(split) != 2 {
-				return nil, nil, fmt.Errorf("invalid email address")
+				return nil, fmt.Errorf("invalid email address")
 			}
 			userDomain := split[1]
 
@@ -80,11 +97,19 @@ iterateOverSeeds:
 	}
 
 	if len(foundDatacenters) == 0 {
-		return nil, nil, errors.New(http.StatusNotFound, fmt.Sprintf("datacenter %q not found", datacenterName))
+		return nil, errors.New(http.StatusNotFound, fmt.Sprintf("datacenter %q not found", datacenterName))
 	}
 	if n := len(foundDatacenters); n > 1 {
-		return nil, nil, fmt.Errorf("expected to find exactly one datacenter with name %q, got %d", datacenterName, n)
+		for _, datacenter := range foundDatacenters {
+			if !datacenter.Spec.Placement.IsShared() {
+				return nil, fmt.Errorf("expected to find exactly one datacenter with name %q, got %d", datacenterName, n)
+			}
+		}
 	}
 
-	return foundSeeds[0], &foundDatacenters[0], nil
+	sort.Slice(foundSeeds, func(i, j int) bool {
+		return foundSeeds[i].Name < foundSeeds[j].Name
+	})
+
+	return foundSeeds, nil
 }
@@ -22,18 +22,24 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	k8cuserclusterclient "github.com/kubermatic/kubermatic/api/pkg/cluster/client"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/rbac"
 	"github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/cloud"
+	rbacusercluster "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/rbac"
 	openshiftuserclusterresources "github.com/kubermatic/kubermatic/api/pkg/controller/user-cluster-controller-manager/resources/resources/openshift"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -50,6 +56,7 @@ import (
 // UserClusterConnectionProvider offers functions to interact with an user cluster
 type UserClusterConnectionProvider interface {
 	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+	GetK8sClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (kubernetes.Interface, error)
 }
 
 // extractGroupPrefixFunc is a function that knows how to extract a prefix (owners, editors) from "projectID-owners" group,
@@ -66,7 +73,8 @@ func NewClusterProvider(
 	extractGroupPrefix extractGroupPrefixFunc,
 	client ctrlruntimeclient.Client,
 	k8sClient kubernetes.Interface,
-	oidcKubeConfEndpoint bool) *ClusterProvider {
+	oidcKubeConfEndpoint bool,
+	secretStores *provider.SecretStores) *ClusterProvider {
 	return &ClusterProvider{
 		createSeedImpersonatedClient: createSeedImpersonatedClient,
 		userClusterConnProvider:      userClusterConnProvider,
@@ -76,6 +84,7 @@ func NewClusterProvider(
 		k8sClient:                    k8sClient,
 		oidcKubeConfEndpoint:         oidcKubeConfEndpoint,
 		seedKubeconfig:               cfg,
+		secretStores:                 secretStores,
 	}
 }
 
@@ -95,6 +104,7 @@ type ClusterProvider struct {
 	client               ctrlruntimeclient.Client
 	k8sClient            kubernetes.Interface
 	seedKubeconfig       *restclient.Config
+	secretStores         *provider.SecretStores
 }
 
 // New creates a brand new cluster that is bound to the given project
@@ -314,6 +324,100 @@ func (p *ClusterProvider) GetViewerKubeconfigForCustomerCluster(c *kubermaticv1.
 	return clientcmd.Load(d)
 }
 
+// GetShortLivedKubeconfigForCustomerCluster returns a kubeconfig with a client certificate signed by the cluster CA.
+// The certificate only carries the users email, the permissions are granted by a ClusterRoleBinding for that user
+// inside the user cluster. This way the access can be revoked before the certificate expires, see
+// RevokeShortLivedKubeconfigs. Admins are bound to the "cluster-admin" role, the other users to the role of their group.
+func (p *ClusterProvider) GetShortLivedKubeconfigForCustomerCluster(userInfo *provider.UserInfo, c *kubermaticv1.Cluster, validity time.Duration) (*clientcmdapi.Config, error) {
+	if validity > kubermaticv1.MaxKubeconfigTTL {
+		validity = kubermaticv1.MaxKubeconfigTTL
+	}
+
+	roleName := "cluster-admin"
+	if !userInfo.IsAdmin {
+		var ok bool
+		roleName, ok = shortLivedKubeconfigRoles[p.extractGroupPrefix(userInfo.Group)]
+		if !ok {
+			return nil, fmt.Errorf("group %q has no permissions in the user cluster", userInfo.Group)
+		}
+	}
+
+	userClusterClient, err := p.GetAdminClientForCustomerCluster(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := reconciling.ReconcileClusterRoleBindings(context.Background(), []reconciling.NamedClusterRoleBindingCreatorGetter{
+		shortLivedKubeconfigBindingCreator(userInfo.Email, roleName),
+	}, "", userClusterClient); err != nil {
+		return nil, fmt.Errorf("failed to bind the user to its role: %v", err)
+	}
+
+	ca, err := resources.GetClusterRootCA(context.Background(), c.Status.NamespaceName, p.GetSeedClusterAdminRuntimeClient())
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := triple.NewClientKeyPairWithValidity(ca, userInfo.Email, nil, validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client certificate: %v", err)
+	}
+
+	config := resources.GetBaseKubeconfig(ca.Cert, c.Address.URL, c.Name)
+	config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
+		resources.KubeconfigDefaultContextKey: {
+			ClientCertificateData: triple.EncodeCertPEM(kp.Cert),
+			ClientKeyData:         triple.EncodePrivateKeyPEM(kp.Key),
+		},
+	}
+
+	return config, nil
+}
+
+// RevokeShortLivedKubeconfigs removes the permissions of all short-lived kubeconfigs of the given user
+func (p *ClusterProvider) RevokeShortLivedKubeconfigs(c *kubermaticv1.Cluster, email string) error {
+	userClusterClient, err := p.GetAdminClientForCustomerCluster(c)
+	if err != nil {
+		return err
+	}
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: shortLivedKubeconfigBindingName(email)}}
+	if err := userClusterClient.Delete(context.Background(), binding); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the ClusterRoleBinding of %s: %v", email, err)
+	}
+	return nil
+}
+
+// shortLivedKubeconfigRoles maps the groups of the project members to the ClusterRoles
+// the rbac-user-cluster-controller creates for them
+var shortLivedKubeconfigRoles = map[string]string{
+	rbac.OwnerGroupNamePrefix:  rbacusercluster.ResourceOwnerName,
+	rbac.EditorGroupNamePrefix: rbacusercluster.ResourceEditorName,
+	rbac.ViewerGroupNamePrefix: rbacusercluster.ResourceViewerName,
+}
+
+func shortLivedKubeconfigBindingName(email string) string {
+	return "kubermatic:kubeconfig:" + email
+}
+
+func shortLivedKubeconfigBindingCreator(email, roleName string) reconciling.NamedClusterRoleBindingCreatorGetter {
+	return func() (string, reconciling.ClusterRoleBindingCreator) {
+		return shortLivedKubeconfigBindingName(email), func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			crb.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleName,
+			}
+			crb.Subjects = []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.UserKind,
+					Name:     email,
+				},
+			}
+			return crb, nil
+		}
+	}
+}
+
 // RevokeViewerKubeconfig revokes the viewer token and kubeconfig
 func (p *ClusterProvider) RevokeViewerKubeconfig(c *kubermaticv1.Cluster) error {
 	if c.IsOpenshift() {
@@ -375,6 +479,20 @@ func (p *ClusterProvider) GetClientForCustomerCluster(userInfo *provider.UserInf
 	return p.userClusterConnProvider.GetClient(c, p.withImpersonation(userInfo))
 }
 
+// GetAdminK8sClientForCustomerCluster returns a kubernetes clientset to interact with the given cluster
+//
+// Note that the client you will get has admin privileges
+func (p *ClusterProvider) GetAdminK8sClientForCustomerCluster(c *kubermaticv1.Cluster) (kubernetes.Interface, error) {
+	return p.userClusterConnProvider.GetK8sClient(c)
+}
+
+// GetK8sClientForCustomerCluster returns a kubernetes clientset to interact with the given cluster
+//
+// Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
+func (p *ClusterProvider) GetK8sClientForCustomerCluster(userInfo *provider.UserInfo, c *kubermaticv1.Cluster) (kubernetes.Interface, error) {
+	return p.userClusterConnProvider.GetK8sClient(c, p.withImpersonation(userInfo))
+}
+
 func (p *ClusterProvider) GetTokenForCustomerCluster(userInfo *provider.UserInfo, cluster *kubermaticv1.Cluster) (string, error) {
 	parts := strings.Split(userInfo.Group, "-")
 	switch parts[0] {
@@ -410,6 +528,12 @@ func (p *ClusterProvider) GetSeedClusterAdminClient() kubernetes.Interface {
 	return p.k8sClient
 }
 
+// GetSecretStores returns the external stores cloud credentials are kept in, it is nil if
+// only Kubernetes Secrets are used.
+func (p *ClusterProvider) GetSecretStores() *provider.SecretStores {
+	return p.secretStores
+}
+
 func (p *ClusterProvider) withImpersonation(userInfo *provider.UserInfo) k8cuserclusterclient.ConfigOption {
 	return func(cfg *restclient.Config) *restclient.Config {
 		cfg.Impersonate = restclient.ImpersonationConfig{
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterTemplateProvider is a object to handle cluster templates
+type ClusterTemplateProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.ClusterTemplateProvider = &ClusterTemplateProvider{}
+
+// NewClusterTemplateProvider returns a cluster template provider
+func NewClusterTemplateProvider(ctx context.Context, client ctrlruntimeclient.Client) *ClusterTemplateProvider {
+	return &ClusterTemplateProvider{client: client, ctx: ctx}
+}
+
+// New creates a cluster template, global templates can only be created by admins
+func (p *ClusterTemplateProvider) New(userInfo *provider.UserInfo, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error) {
+	if template == nil {
+		return nil, fmt.Errorf("the cluster template can not be nil")
+	}
+	if err := checkClusterTemplateAccess(userInfo, template); err != nil {
+		return nil, err
+	}
+	if template.Spec.Scope == kubermaticv1.ClusterTemplateScopeProject && template.Labels[kubermaticv1.ProjectIDLabelKey] == "" {
+		return nil, fmt.Errorf("project scoped cluster templates must have the %q label", kubermaticv1.ProjectIDLabelKey)
+	}
+	if err := p.client.Create(p.ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// List returns the templates usable in the given project, that is the project scoped and the global ones
+func (p *ClusterTemplateProvider) List(userInfo *provider.UserInfo, projectID string) ([]kubermaticv1.ClusterTemplate, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("the project ID can not be empty")
+	}
+	templateList := &kubermaticv1.ClusterTemplateList{}
+	if err := p.client.List(p.ctx, templateList); err != nil {
+		return nil, fmt.Errorf("failed to list cluster templates: %v", err)
+	}
+
+	templates := []kubermaticv1.ClusterTemplate{}
+	for _, template := range templateList.Items {
+		if isClusterTemplateVisible(&template, projectID) {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+// Get returns the template with the given name if it is usable in the given project
+func (p *ClusterTemplateProvider) Get(userInfo *provider.UserInfo, projectID, templateID string) (*kubermaticv1.ClusterTemplate, error) {
+	template := &kubermaticv1.ClusterTemplate{}
+	if err := p.client.Get(p.ctx, types.NamespacedName{Name: templateID}, template); err != nil {
+		return nil, err
+	}
+	if !isClusterTemplateVisible(template, projectID) {
+		return nil, kerrors.NewNotFound(kubermaticv1.Resource(kubermaticv1.ClusterTemplateResourceName), templateID)
+	}
+	return template, nil
+}
+
+// Delete deletes the template with the given name, global templates can only be deleted by admins
+func (p *ClusterTemplateProvider) Delete(userInfo *provider.UserInfo, projectID, templateID string) error {
+	template, err := p.Get(userInfo, projectID, templateID)
+	if err != nil {
+		return err
+	}
+	if err := checkClusterTemplateAccess(userInfo, template); err != nil {
+		return err
+	}
+	return p.client.Delete(p.ctx, template)
+}
+
+func isClusterTemplateVisible(template *kubermaticv1.ClusterTemplate, projectID string) bool {
+	if template.Spec.Scope == kubermaticv1.ClusterTemplateScopeGlobal {
+		return true
+	}
+	return template.Labels[kubermaticv1.ProjectIDLabelKey] == projectID
+}
+
+func checkClusterTemplateAccess(userInfo *provider.UserInfo, template *kubermaticv1.ClusterTemplate) error {
+	switch template.Spec.Scope {
+	case kubermaticv1.ClusterTemplateScopeGlobal:
+		if !userInfo.IsAdmin {
+			return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+		}
+	case kubermaticv1.ClusterTemplateScopeProject:
+	default:
+		return fmt.Errorf("invalid cluster template scope %q", template.Spec.Scope)
+	}
+	return nil
+}
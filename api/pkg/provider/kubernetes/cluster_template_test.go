@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func genClusterTemplate(name string, scope kubermaticv1.ClusterTemplateScope, projectID string) *kubermaticv1.ClusterTemplate {
+	template := &kubermaticv1.ClusterTemplate{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: name,
+			Scope:             scope,
+		},
+	}
+	if projectID != "" {
+		template.Labels = map[string]string{kubermaticv1.ProjectIDLabelKey: projectID}
+	}
+	return template
+}
+
+func TestListClusterTemplates(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name           string
+		projectID      string
+		templates      []runtime.Object
+		expectedResult []string
+	}{
+		{
+			name:      "scenario 1: global and own project templates are listed",
+			projectID: "my-first-project-ID",
+			templates: []runtime.Object{
+				genClusterTemplate("global", kubermaticv1.ClusterTemplateScopeGlobal, ""),
+				genClusterTemplate("own", kubermaticv1.ClusterTemplateScopeProject, "my-first-project-ID"),
+				genClusterTemplate("other", kubermaticv1.ClusterTemplateScopeProject, "my-second-project-ID"),
+			},
+			expectedResult: []string{"global", "own"},
+		},
+		{
+			name:      "scenario 2: no templates",
+			projectID: "my-first-project-ID",
+			templates: []runtime.Object{
+				genClusterTemplate("other", kubermaticv1.ClusterTemplateScopeProject, "my-second-project-ID"),
+			},
+			expectedResult: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, tc.templates...)
+			templateProvider := kubernetes.NewClusterTemplateProvider(context.Background(), fakeClient)
+
+			result, err := templateProvider.List(&provider.UserInfo{Email: "bob@acme.com"}, tc.projectID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := []string{}
+			for _, template := range result {
+				names = append(names, template.Name)
+			}
+			if len(names) != len(tc.expectedResult) {
+				t.Fatalf("expected: %v, got %v", tc.expectedResult, names)
+			}
+			for i := range names {
+				if names[i] != tc.expectedResult[i] {
+					t.Fatalf("expected: %v, got %v", tc.expectedResult, names)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterTemplateAccess(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name              string
+		userInfo          *provider.UserInfo
+		templateID        string
+		expectedForbidden bool
+		expectedNotFound  bool
+	}{
+		{
+			name:              "scenario 1: regular user can't delete a global template",
+			userInfo:          &provider.UserInfo{Email: "bob@acme.com"},
+			templateID:        "global",
+			expectedForbidden: true,
+		},
+		{
+			name:       "scenario 2: admin can delete a global template",
+			userInfo:   &provider.UserInfo{Email: "admin@acme.com", IsAdmin: true},
+			templateID: "global",
+		},
+		{
+			name:       "scenario 3: regular user can delete a template of own project",
+			userInfo:   &provider.UserInfo{Email: "bob@acme.com"},
+			templateID: "own",
+		},
+		{
+			name:             "scenario 4: template of other project is not found",
+			userInfo:         &provider.UserInfo{Email: "bob@acme.com"},
+			templateID:       "other",
+			expectedNotFound: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme,
+				genClusterTemplate("global", kubermaticv1.ClusterTemplateScopeGlobal, ""),
+				genClusterTemplate("own", kubermaticv1.ClusterTemplateScopeProject, "my-first-project-ID"),
+				genClusterTemplate("other", kubermaticv1.ClusterTemplateScopeProject, "my-second-project-ID"),
+			)
+			templateProvider := kubernetes.NewClusterTemplateProvider(context.Background(), fakeClient)
+
+			err := templateProvider.Delete(tc.userInfo, "my-first-project-ID", tc.templateID)
+			switch {
+			case tc.expectedForbidden:
+				if !kerrors.IsForbidden(err) {
+					t.Fatalf("expected forbidden error, got %v", err)
+				}
+			case tc.expectedNotFound:
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected not found error, got %v", err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			}
+		})
+	}
+}
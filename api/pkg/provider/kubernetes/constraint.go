@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubermatic/kubermatic/api/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConstraintTemplateProvider is a object to handle gatekeeper constraint templates
+type ConstraintTemplateProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.ConstraintTemplateProvider = &ConstraintTemplateProvider{}
+
+// NewConstraintTemplateProvider returns a constraint template provider
+func NewConstraintTemplateProvider(ctx context.Context, client ctrlruntimeclient.Client) *ConstraintTemplateProvider {
+	return &ConstraintTemplateProvider{client: client, ctx: ctx}
+}
+
+// List returns all constraint templates
+func (p *ConstraintTemplateProvider) List(userInfo *provider.UserInfo) ([]kubermaticv1.ConstraintTemplate, error) {
+	templateList := &kubermaticv1.ConstraintTemplateList{}
+	if err := p.client.List(p.ctx, templateList); err != nil {
+		return nil, fmt.Errorf("failed to list constraint templates: %v", err)
+	}
+	return templateList.Items, nil
+}
+
+// Get returns the constraint template with the given name
+func (p *ConstraintTemplateProvider) Get(userInfo *provider.UserInfo, name string) (*kubermaticv1.ConstraintTemplate, error) {
+	template := &kubermaticv1.ConstraintTemplate{}
+	if err := p.client.Get(p.ctx, types.NamespacedName{Name: name}, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Create creates the constraint template, only admins are allowed to create constraint templates
+func (p *ConstraintTemplateProvider) Create(userInfo *provider.UserInfo, template *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error) {
+	if template == nil {
+		return nil, fmt.Errorf("the constraint template can not be nil")
+	}
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if err := p.client.Create(p.ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Delete deletes the constraint template, only admins are allowed to delete constraint templates
+func (p *ConstraintTemplateProvider) Delete(userInfo *provider.UserInfo, name string) error {
+	if !userInfo.IsAdmin {
+		return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	template, err := p.Get(userInfo, name)
+	if err != nil {
+		return err
+	}
+	return p.client.Delete(p.ctx, template)
+}
+
+// ConstraintProvider is a object to handle gatekeeper constraints
+type ConstraintProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.ConstraintProvider = &ConstraintProvider{}
+
+// NewConstraintProvider returns a constraint provider
+func NewConstraintProvider(ctx context.Context, client ctrlruntimeclient.Client) *ConstraintProvider {
+	return &ConstraintProvider{client: client, ctx: ctx}
+}
+
+// New creates a constraint in the project given by its ProjectIDLabelKey label, only admins and the owners
+// and editors of the project are allowed to create constraints. The name of the constraint in the user clusters
+// (Spec.Name) must be unique within the project.
+func (p *ConstraintProvider) New(userInfo *provider.UserInfo, constraint *kubermaticv1.Constraint) (*kubermaticv1.Constraint, error) {
+	if constraint == nil {
+		return nil, fmt.Errorf("the constraint can not be nil")
+	}
+	projectID := constraint.Labels[kubermaticv1.ProjectIDLabelKey]
+	if projectID == "" {
+		return nil, fmt.Errorf("constraints must have the %q label", kubermaticv1.ProjectIDLabelKey)
+	}
+	if err := canManageConstraints(userInfo, projectID); err != nil {
+		return nil, err
+	}
+
+	existing, err := p.List(userInfo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range existing {
+		if c.Spec.Name == constraint.Spec.Name {
+			return nil, kerrors.NewAlreadyExists(kubermaticv1.Resource(kubermaticv1.ConstraintResourceName), constraint.Spec.Name)
+		}
+	}
+
+	if err := p.client.Create(p.ctx, constraint); err != nil {
+		return nil, err
+	}
+	return constraint, nil
+}
+
+// List returns the constraints of the given project
+func (p *ConstraintProvider) List(userInfo *provider.UserInfo, projectID string) ([]kubermaticv1.Constraint, error) {
+	if projectID == "" {
+		return nil, fmt.Errorf("the project ID can not be empty")
+	}
+	constraintList := &kubermaticv1.ConstraintList{}
+	if err := p.client.List(p.ctx, constraintList, ctrlruntimeclient.MatchingLabels{kubermaticv1.ProjectIDLabelKey: projectID}); err != nil {
+		return nil, fmt.Errorf("failed to list constraints: %v", err)
+	}
+	return constraintList.Items, nil
+}
+
+// Get returns the constraint with the given name if it belongs to the given project
+func (p *ConstraintProvider) Get(userInfo *provider.UserInfo, projectID, name string) (*kubermaticv1.Constraint, error) {
+	constraint := &kubermaticv1.Constraint{}
+	if err := p.client.Get(p.ctx, types.NamespacedName{Name: name}, constraint); err != nil {
+		return nil, err
+	}
+	if constraint.Labels[kubermaticv1.ProjectIDLabelKey] != projectID {
+		return nil, kerrors.NewNotFound(kubermaticv1.Resource(kubermaticv1.ConstraintResourceName), name)
+	}
+	return constraint, nil
+}
+
+// Delete deletes the constraint with the given name if it belongs to the given project, only admins and the
+// owners and editors of the project are allowed to delete constraints
+func (p *ConstraintProvider) Delete(userInfo *provider.UserInfo, projectID, name string) error {
+	if err := canManageConstraints(userInfo, projectID); err != nil {
+		return err
+	}
+	constraint, err := p.Get(userInfo, projectID, name)
+	if err != nil {
+		return err
+	}
+	return p.client.Delete(p.ctx, constraint)
+}
+
+// canManageConstraints checks if the user is an admin or an owner or editor of the given project.
+// Constraints are enforced in all clusters of the project, so viewers must not manage them.
+func canManageConstraints(userInfo *provider.UserInfo, projectID string) error {
+	if userInfo.IsAdmin {
+		return nil
+	}
+	switch userInfo.Group {
+	case rbac.GenerateActualGroupNameFor(projectID, rbac.OwnerGroupNamePrefix), rbac.GenerateActualGroupNameFor(projectID, rbac.EditorGroupNamePrefix):
+		return nil
+	}
+	return kerrors.NewForbidden(kubermaticv1.Resource(kubermaticv1.ConstraintResourceName), projectID, fmt.Errorf("%q is not an owner or editor of the project", userInfo.Email))
+}
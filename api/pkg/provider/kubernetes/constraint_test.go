@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func genConstraint(name, projectID string) *kubermaticv1.Constraint {
+	return &kubermaticv1.Constraint{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: projectID},
+		},
+		Spec: kubermaticv1.ConstraintSpec{
+			Name:               name,
+			ConstraintTemplate: "k8srequiredlabels",
+		},
+	}
+}
+
+func TestConstraintTemplateAccess(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name              string
+		userInfo          *provider.UserInfo
+		expectedForbidden bool
+	}{
+		{
+			name:              "scenario 1: regular user can't create a constraint template",
+			userInfo:          &provider.UserInfo{Email: "bob@acme.com"},
+			expectedForbidden: true,
+		},
+		{
+			name:     "scenario 2: admin can create a constraint template",
+			userInfo: &provider.UserInfo{Email: "admin@acme.com", IsAdmin: true},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme)
+			templateProvider := kubernetes.NewConstraintTemplateProvider(context.Background(), fakeClient)
+
+			template := &kubermaticv1.ConstraintTemplate{
+				ObjectMeta: v1.ObjectMeta{Name: "k8srequiredlabels"},
+				Spec:       kubermaticv1.ConstraintTemplateSpec{Kind: "K8sRequiredLabels"},
+			}
+			_, err := templateProvider.Create(tc.userInfo, template)
+			if tc.expectedForbidden {
+				if !kerrors.IsForbidden(err) {
+					t.Fatalf("expected forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := templateProvider.Get(tc.userInfo, template.Name); err != nil {
+				t.Fatalf("failed to get created constraint template: %v", err)
+			}
+		})
+	}
+}
+
+func TestConstraintProjectScope(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme,
+		genConstraint("own", "my-first-project-ID"),
+		genConstraint("other", "my-second-project-ID"),
+	)
+	constraintProvider := kubernetes.NewConstraintProvider(context.Background(), fakeClient)
+	userInfo := &provider.UserInfo{Email: "bob@acme.com", Group: "owners-my-first-project-ID"}
+
+	constraints, err := constraintProvider.List(userInfo, "my-first-project-ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(constraints) != 1 || constraints[0].Name != "own" {
+		t.Fatalf("expected only the constraint of the own project, got %v", constraints)
+	}
+
+	if err := constraintProvider.Delete(userInfo, "my-first-project-ID", "other"); !kerrors.IsNotFound(err) {
+		t.Fatalf("expected not found error for constraint of other project, got %v", err)
+	}
+	viewerInfo := &provider.UserInfo{Email: "john@acme.com", Group: "viewers-my-first-project-ID"}
+	if err := constraintProvider.Delete(viewerInfo, "my-first-project-ID", "own"); !kerrors.IsForbidden(err) {
+		t.Fatalf("expected forbidden error for viewer of the project, got %v", err)
+	}
+	if err := constraintProvider.Delete(userInfo, "my-first-project-ID", "own"); err != nil {
+		t.Fatalf("failed to delete constraint of own project: %v", err)
+	}
+}
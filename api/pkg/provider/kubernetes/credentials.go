@@ -20,8 +20,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
@@ -33,43 +35,48 @@ import (
 )
 
 // CreateCredentialSecretForCluster creates a new secret for a credential
-func CreateOrUpdateCredentialSecretForCluster(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func CreateOrUpdateCredentialSecretForCluster(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	if cluster.Spec.Cloud.AWS != nil {
-		return createOrUpdateAWSSecret(ctx, seedClient, cluster)
+		return createOrUpdateAWSSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Azure != nil {
-		return createOrUpdateAzureSecret(ctx, seedClient, cluster)
+		return createOrUpdateAzureSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Digitalocean != nil {
-		return createOrUpdateDigitaloceanSecret(ctx, seedClient, cluster)
+		return createOrUpdateDigitaloceanSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.GCP != nil {
-		return createOrUpdateGCPSecret(ctx, seedClient, cluster)
+		return createOrUpdateGCPSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Hetzner != nil {
-		return createOrUpdateHetznerSecret(ctx, seedClient, cluster)
+		return createOrUpdateHetznerSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Openstack != nil {
-		return createOrUpdateOpenstackSecret(ctx, seedClient, cluster)
+		return createOrUpdateOpenstackSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Packet != nil {
-		return createOrUpdatePacketSecret(ctx, seedClient, cluster)
+		return createOrUpdatePacketSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Kubevirt != nil {
-		return createOrUpdateKubevirtSecret(ctx, seedClient, cluster)
+		return createOrUpdateKubevirtSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.VSphere != nil {
-		return createVSphereSecret(ctx, seedClient, cluster)
+		return createVSphereSecret(ctx, seedClient, secretStores, cluster)
 	}
 	if cluster.Spec.Cloud.Alibaba != nil {
-		return createAlibabaSecret(ctx, seedClient, cluster)
+		return createAlibabaSecret(ctx, seedClient, secretStores, cluster)
 	}
 	return nil
 }
 
-func ensureCredentialSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
+func ensureCredentialSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
 	name := cluster.GetSecretName()
 
+	// credentials are kept out of Kubernetes if an external store is configured for them
+	if store := secretStores.CredentialsStore(); store != nil {
+		return ensureCredentialInSecretStore(ctx, store, secretStores.CredentialsKind, CredentialsSecretStorePath(secretStores, cluster), secretData)
+	}
+
 	namespacedName := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: name}
 	existingSecret := &corev1.Secret{}
 	if err := seedClient.Get(ctx, namespacedName, existingSecret); err != nil && !kerrors.IsNotFound(err) {
@@ -123,7 +130,30 @@ func ensureCredentialSecret(ctx context.Context, seedClient ctrlruntimeclient.Cl
 	}, nil
 }
 
-func createOrUpdateAWSSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+// CredentialsSecretStorePath returns the path the credentials of the cluster are kept at in the
+// credentials store
+func CredentialsSecretStorePath(secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) string {
+	return path.Join(secretStores.CredentialsPathPrefix, cluster.GetSecretName())
+}
+
+func ensureCredentialInSecretStore(ctx context.Context, store provider.SecretStore, kind, secretPath string, secretData map[string][]byte) (*providerconfig.GlobalSecretKeySelector, error) {
+	data := make(map[string]string, len(secretData))
+	for k, v := range secretData {
+		data[k] = string(v)
+	}
+	if err := store.Set(ctx, secretPath, data); err != nil {
+		return nil, fmt.Errorf("failed to store credentials in %s: %v", kind, err)
+	}
+
+	return &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{
+			Kind: kind,
+			Name: secretPath,
+		},
+	}, nil
+}
+
+func createOrUpdateAWSSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.AWS
 
 	// already migrated
@@ -132,7 +162,7 @@ func createOrUpdateAWSSecret(ctx context.Context, seedClient ctrlruntimeclient.C
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.AWSAccessKeyID:     []byte(spec.AccessKeyID),
 		resources.AWSSecretAccessKey: []byte(spec.SecretAccessKey),
 	})
@@ -150,7 +180,7 @@ func createOrUpdateAWSSecret(ctx context.Context, seedClient ctrlruntimeclient.C
 	return nil
 }
 
-func createOrUpdateAzureSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateAzureSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Azure
 
 	// already migrated
@@ -159,7 +189,7 @@ func createOrUpdateAzureSecret(ctx context.Context, seedClient ctrlruntimeclient
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.AzureTenantID:       []byte(spec.TenantID),
 		resources.AzureSubscriptionID: []byte(spec.SubscriptionID),
 		resources.AzureClientID:       []byte(spec.ClientID),
@@ -181,7 +211,7 @@ func createOrUpdateAzureSecret(ctx context.Context, seedClient ctrlruntimeclient
 	return nil
 }
 
-func createOrUpdateDigitaloceanSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateDigitaloceanSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Digitalocean
 
 	// already migrated
@@ -190,7 +220,7 @@ func createOrUpdateDigitaloceanSecret(ctx context.Context, seedClient ctrlruntim
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.DigitaloceanToken: []byte(spec.Token),
 	})
 	if err != nil {
@@ -206,7 +236,7 @@ func createOrUpdateDigitaloceanSecret(ctx context.Context, seedClient ctrlruntim
 	return nil
 }
 
-func createOrUpdateGCPSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateGCPSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.GCP
 
 	// already migrated
@@ -215,7 +245,7 @@ func createOrUpdateGCPSecret(ctx context.Context, seedClient ctrlruntimeclient.C
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.GCPServiceAccount: []byte(spec.ServiceAccount),
 	})
 	if err != nil {
@@ -231,7 +261,7 @@ func createOrUpdateGCPSecret(ctx context.Context, seedClient ctrlruntimeclient.C
 	return nil
 }
 
-func createOrUpdateHetznerSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateHetznerSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Hetzner
 
 	// already migrated
@@ -240,7 +270,7 @@ func createOrUpdateHetznerSecret(ctx context.Context, seedClient ctrlruntimeclie
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.HetznerToken: []byte(spec.Token),
 	})
 	if err != nil {
@@ -256,7 +286,7 @@ func createOrUpdateHetznerSecret(ctx context.Context, seedClient ctrlruntimeclie
 	return nil
 }
 
-func createOrUpdateOpenstackSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateOpenstackSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Openstack
 
 	// already migrated
@@ -265,7 +295,7 @@ func createOrUpdateOpenstackSecret(ctx context.Context, seedClient ctrlruntimecl
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.OpenstackUsername: []byte(spec.Username),
 		resources.OpenstackPassword: []byte(spec.Password),
 		resources.OpenstackTenant:   []byte(spec.Tenant),
@@ -289,7 +319,7 @@ func createOrUpdateOpenstackSecret(ctx context.Context, seedClient ctrlruntimecl
 	return nil
 }
 
-func createOrUpdatePacketSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdatePacketSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Packet
 
 	// already migrated
@@ -298,7 +328,7 @@ func createOrUpdatePacketSecret(ctx context.Context, seedClient ctrlruntimeclien
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.PacketAPIKey:    []byte(spec.APIKey),
 		resources.PacketProjectID: []byte(spec.ProjectID),
 	})
@@ -316,7 +346,7 @@ func createOrUpdatePacketSecret(ctx context.Context, seedClient ctrlruntimeclien
 	return nil
 }
 
-func createOrUpdateKubevirtSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createOrUpdateKubevirtSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Kubevirt
 
 	// already migrated
@@ -325,7 +355,7 @@ func createOrUpdateKubevirtSecret(ctx context.Context, seedClient ctrlruntimecli
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.KubevirtKubeConfig: []byte(spec.Kubeconfig),
 	})
 	if err != nil {
@@ -341,7 +371,7 @@ func createOrUpdateKubevirtSecret(ctx context.Context, seedClient ctrlruntimecli
 	return nil
 }
 
-func createVSphereSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createVSphereSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.VSphere
 
 	// already migrated
@@ -350,7 +380,7 @@ func createVSphereSecret(ctx context.Context, seedClient ctrlruntimeclient.Clien
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.VsphereUsername:                    []byte(spec.Username),
 		resources.VspherePassword:                    []byte(spec.Password),
 		resources.VsphereInfraManagementUserUsername: []byte(spec.InfraManagementUser.Username),
@@ -372,7 +402,7 @@ func createVSphereSecret(ctx context.Context, seedClient ctrlruntimeclient.Clien
 	return nil
 }
 
-func createAlibabaSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+func createAlibabaSecret(ctx context.Context, seedClient ctrlruntimeclient.Client, secretStores *provider.SecretStores, cluster *kubermaticv1.Cluster) error {
 	spec := cluster.Spec.Cloud.Alibaba
 
 	// already migrated
@@ -381,7 +411,7 @@ func createAlibabaSecret(ctx context.Context, seedClient ctrlruntimeclient.Clien
 	}
 
 	// move credentials into dedicated Secret
-	credentialRef, err := ensureCredentialSecret(ctx, seedClient, cluster, map[string][]byte{
+	credentialRef, err := ensureCredentialSecret(ctx, seedClient, secretStores, cluster, map[string][]byte{
 		resources.AlibabaAccessKeyID:     []byte(spec.AccessKeyID),
 		resources.AlibabaAccessKeySecret: []byte(spec.AccessKeySecret),
 	})
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeSecretStore map[string]map[string]string
+
+func (f fakeSecretStore) Get(_ context.Context, path, key string) (string, error) {
+	return f[path][key], nil
+}
+
+func (f fakeSecretStore) Set(_ context.Context, path string, data map[string]string) error {
+	f[path] = data
+	return nil
+}
+
+func TestCreateCredentialSecretInSecretStore(t *testing.T) {
+	store := fakeSecretStore{}
+	secretStores := &provider.SecretStores{
+		Stores:                map[string]provider.SecretStore{"FakeStore": store},
+		CredentialsKind:       "FakeStore",
+		CredentialsPathPrefix: "secret/data/kubermatic",
+	}
+
+	cluster := genCluster("abcd", "kubernetes", "my-project", "", "bob@acme.com")
+	cluster.Spec.Cloud = kubermaticv1.CloudSpec{
+		DatacenterName: "hetzner-dc",
+		Hetzner:        &kubermaticv1.HetznerCloudSpec{Token: "hetzner-token"},
+	}
+
+	client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme)
+	if err := kubernetes.CreateOrUpdateCredentialSecretForCluster(context.Background(), client, secretStores, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPath := "secret/data/kubermatic/" + cluster.GetSecretName()
+	if token := store[expectedPath][resources.HetznerToken]; token != "hetzner-token" {
+		t.Errorf("expected the token to be stored at %q, got %v", expectedPath, store)
+	}
+	if cluster.Spec.Cloud.Hetzner.Token != "" {
+		t.Error("expected the inline token to be removed from the cluster")
+	}
+	expectedRef := corev1.ObjectReference{Kind: "FakeStore", Name: expectedPath}
+	if ref := cluster.Spec.Cloud.Hetzner.CredentialsReference; ref == nil || !equality.Semantic.DeepEqual(ref.ObjectReference, expectedRef) {
+		t.Errorf("expected credentials reference %v, got %v", expectedRef, ref)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(context.Background(), secrets); err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Errorf("expected no Kubernetes Secret to be created, got %d", len(secrets.Items))
+	}
+}
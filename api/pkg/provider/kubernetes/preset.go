@@ -27,12 +27,16 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
 // presetsGetter is a function to retrieve preset list
-type presetsGetter = func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error)
+type presetsGetter = func() (*kubermaticv1.PresetList, error)
 
 // LoadPresets loads the custom presets for supported providers
 func LoadPresets(yamlContent []byte) (*kubermaticv1.PresetList, error) {
@@ -66,12 +70,12 @@ func LoadPresetsFromFile(path string) (*kubermaticv1.PresetList, error) {
 
 func presetsGetterFactory(ctx context.Context, client ctrlruntimeclient.Client, presetsFile string, dynamicPresets bool) (presetsGetter, error) {
 	if dynamicPresets {
-		return func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
+		return func() (*kubermaticv1.PresetList, error) {
 			presetList := &kubermaticv1.PresetList{}
 			if err := client.List(ctx, presetList); err != nil {
 				return nil, fmt.Errorf("failed to get presets %v", err)
 			}
-			return filterOutPresets(userInfo, presetList)
+			return presetList, nil
 		}, nil
 	}
 	var presets *kubermaticv1.PresetList
@@ -88,14 +92,17 @@ func presetsGetterFactory(ctx context.Context, client ctrlruntimeclient.Client,
 		presets = &kubermaticv1.PresetList{Items: []kubermaticv1.Preset{}}
 	}
 
-	return func(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
-		return filterOutPresets(userInfo, presets)
+	return func() (*kubermaticv1.PresetList, error) {
+		return presets, nil
 	}, nil
 }
 
 // PresetsProvider is a object to handle presets from a predefined config
 type PresetsProvider struct {
-	presetsGetter presetsGetter
+	presetsGetter  presetsGetter
+	client         ctrlruntimeclient.Client
+	ctx            context.Context
+	dynamicPresets bool
 }
 
 func NewPresetsProvider(ctx context.Context, client ctrlruntimeclient.Client, presetsFile string, dynamicPresets bool) (*PresetsProvider, error) {
@@ -103,17 +110,22 @@ func NewPresetsProvider(ctx context.Context, client ctrlruntimeclient.Client, pr
 	if err != nil {
 		return nil, err
 	}
-	return &PresetsProvider{presetsGetter: presetsGetter}, nil
+	return &PresetsProvider{presetsGetter: presetsGetter, client: client, ctx: ctx, dynamicPresets: dynamicPresets}, nil
 }
 
-// GetPresets returns presets which belong to the specific email group and for all users
-func (m *PresetsProvider) GetPresets(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
-	return m.presetsGetter(userInfo)
+// GetPresets returns the enabled presets which belong to the specific email group and for all users
+// and which can be used in the given project
+func (m *PresetsProvider) GetPresets(userInfo *provider.UserInfo, projectID string) ([]kubermaticv1.Preset, error) {
+	presets, err := m.presetsGetter()
+	if err != nil {
+		return nil, err
+	}
+	return filterOutPresets(userInfo, projectID, presets)
 }
 
 // GetPreset returns preset with the name which belong to the specific email group
-func (m *PresetsProvider) GetPreset(userInfo *provider.UserInfo, name string) (*kubermaticv1.Preset, error) {
-	presets, err := m.presetsGetter(userInfo)
+func (m *PresetsProvider) GetPreset(userInfo *provider.UserInfo, projectID, name string) (*kubermaticv1.Preset, error) {
+	presets, err := m.GetPresets(userInfo, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -126,13 +138,18 @@ func (m *PresetsProvider) GetPreset(userInfo *provider.UserInfo, name string) (*
 	return nil, fmt.Errorf("missing preset '%s' for the user '%s'", name, userInfo.Email)
 }
 
-func filterOutPresets(userInfo *provider.UserInfo, list *kubermaticv1.PresetList) ([]kubermaticv1.Preset, error) {
+// filterOutPresets returns the enabled presets which are available for the email domain of the user
+// and in the given project
+func filterOutPresets(userInfo *provider.UserInfo, projectID string, list *kubermaticv1.PresetList) ([]kubermaticv1.Preset, error) {
 	if list == nil {
 		return nil, fmt.Errorf("the preset list can not be nil")
 	}
 	var presetList []kubermaticv1.Preset
 
 	for _, preset := range list.Items {
+		if !preset.Spec.IsEnabled() || !preset.Spec.IsAvailableInProject(projectID) {
+			continue
+		}
 		requiredEmailDomain := preset.Spec.RequiredEmailDomain
 		// find preset for specific email domain
 		if requiredEmailDomain != "" {
@@ -148,40 +165,99 @@ func filterOutPresets(userInfo *provider.UserInfo, list *kubermaticv1.PresetList
 	return presetList, nil
 }
 
-func (m *PresetsProvider) SetCloudCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
+// ListPresets returns all presets, only admins are allowed to list them
+func (m *PresetsProvider) ListPresets(userInfo *provider.UserInfo) ([]kubermaticv1.Preset, error) {
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	presets, err := m.presetsGetter()
+	if err != nil {
+		return nil, err
+	}
+	return presets.Items, nil
+}
+
+// CreatePreset creates the preset, only admins are allowed to create presets
+func (m *PresetsProvider) CreatePreset(userInfo *provider.UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error) {
+	if err := m.checkManagePresets(userInfo, "create"); err != nil {
+		return nil, err
+	}
+	if err := m.client.Create(m.ctx, preset); err != nil {
+		return nil, err
+	}
+	return preset, nil
+}
+
+// UpdatePreset updates the preset, only admins are allowed to update presets
+func (m *PresetsProvider) UpdatePreset(userInfo *provider.UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error) {
+	if err := m.checkManagePresets(userInfo, "update"); err != nil {
+		return nil, err
+	}
+	oldPreset := &kubermaticv1.Preset{}
+	if err := m.client.Get(m.ctx, types.NamespacedName{Name: preset.Name}, oldPreset); err != nil {
+		return nil, err
+	}
+	newPreset := oldPreset.DeepCopy()
+	newPreset.Spec = preset.Spec
+	if err := m.client.Patch(m.ctx, newPreset, ctrlruntimeclient.MergeFrom(oldPreset)); err != nil {
+		return nil, err
+	}
+	return newPreset, nil
+}
+
+// DeletePreset deletes the preset, only admins are allowed to delete presets
+func (m *PresetsProvider) DeletePreset(userInfo *provider.UserInfo, name string) error {
+	if err := m.checkManagePresets(userInfo, "delete"); err != nil {
+		return err
+	}
+	return m.client.Delete(m.ctx, &kubermaticv1.Preset{ObjectMeta: metav1.ObjectMeta{Name: name}})
+}
+
+// checkManagePresets checks that the user is allowed to manage presets and that they are not loaded from a file
+func (m *PresetsProvider) checkManagePresets(userInfo *provider.UserInfo, action string) error {
+	if !userInfo.IsAdmin {
+		return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if !m.dynamicPresets {
+		return kerrors.NewMethodNotSupported(schema.GroupResource{Group: kubermaticv1.GroupName, Resource: "presets"}, action)
+	}
+	return nil
+}
+
+func (m *PresetsProvider) SetCloudCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
 
 	if cloud.VSphere != nil {
-		return m.setVsphereCredentials(userInfo, presetName, cloud)
+		return m.setVsphereCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Openstack != nil {
-		return m.setOpenStackCredentials(userInfo, presetName, cloud, dc)
+		return m.setOpenStackCredentials(userInfo, projectID, presetName, cloud, dc)
 	}
 	if cloud.Azure != nil {
-		return m.setAzureCredentials(userInfo, presetName, cloud)
+		return m.setAzureCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Digitalocean != nil {
-		return m.setDigitalOceanCredentials(userInfo, presetName, cloud)
+		return m.setDigitalOceanCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Packet != nil {
-		return m.setPacketCredentials(userInfo, presetName, cloud)
+		return m.setPacketCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Hetzner != nil {
-		return m.setHetznerCredentials(userInfo, presetName, cloud)
+		return m.setHetznerCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.AWS != nil {
-		return m.setAWSCredentials(userInfo, presetName, cloud)
+		return m.setAWSCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.GCP != nil {
-		return m.setGCPCredentials(userInfo, presetName, cloud)
+		return m.setGCPCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Fake != nil {
-		return m.setFakeCredentials(userInfo, presetName, cloud)
+		return m.setFakeCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Kubevirt != nil {
-		return m.setKubevirtCredentials(userInfo, presetName, cloud)
+		return m.setKubevirtCredentials(userInfo, projectID, presetName, cloud)
 	}
 	if cloud.Alibaba != nil {
-		return m.setAlibabaCredentials(userInfo, presetName, cloud)
+		return m.setAlibabaCredentials(userInfo, projectID, presetName, cloud)
 	}
 
 	return nil, fmt.Errorf("can not find provider to set credentials")
@@ -191,8 +267,8 @@ func emptyCredentialError(preset, provider string) error {
 	return fmt.Errorf("the preset %s doesn't contain credential for %s provider", preset, provider)
 }
 
-func (m *PresetsProvider) setFakeCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setFakeCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -205,8 +281,8 @@ func (m *PresetsProvider) setFakeCredentials(userInfo *provider.UserInfo, preset
 
 }
 
-func (m *PresetsProvider) setKubevirtCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setKubevirtCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -219,8 +295,8 @@ func (m *PresetsProvider) setKubevirtCredentials(userInfo *provider.UserInfo, pr
 	return &cloud, nil
 }
 
-func (m *PresetsProvider) setGCPCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setGCPCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +313,8 @@ func (m *PresetsProvider) setGCPCredentials(userInfo *provider.UserInfo, presetN
 
 }
 
-func (m *PresetsProvider) setAWSCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setAWSCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -259,8 +335,8 @@ func (m *PresetsProvider) setAWSCredentials(userInfo *provider.UserInfo, presetN
 	return &cloud, nil
 }
 
-func (m *PresetsProvider) setHetznerCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setHetznerCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -273,8 +349,8 @@ func (m *PresetsProvider) setHetznerCredentials(userInfo *provider.UserInfo, pre
 
 }
 
-func (m *PresetsProvider) setPacketCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setPacketCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -295,8 +371,8 @@ func (m *PresetsProvider) setPacketCredentials(userInfo *provider.UserInfo, pres
 
 }
 
-func (m *PresetsProvider) setDigitalOceanCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setDigitalOceanCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -309,8 +385,8 @@ func (m *PresetsProvider) setDigitalOceanCredentials(userInfo *provider.UserInfo
 
 }
 
-func (m *PresetsProvider) setAzureCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setAzureCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -333,8 +409,8 @@ func (m *PresetsProvider) setAzureCredentials(userInfo *provider.UserInfo, prese
 
 }
 
-func (m *PresetsProvider) setOpenStackCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setOpenStackCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -364,8 +440,8 @@ func (m *PresetsProvider) setOpenStackCredentials(userInfo *provider.UserInfo, p
 
 }
 
-func (m *PresetsProvider) setVsphereCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setVsphereCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
@@ -381,8 +457,8 @@ func (m *PresetsProvider) setVsphereCredentials(userInfo *provider.UserInfo, pre
 
 }
 
-func (m *PresetsProvider) setAlibabaCredentials(userInfo *provider.UserInfo, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
-	preset, err := m.GetPreset(userInfo, presetName)
+func (m *PresetsProvider) setAlibabaCredentials(userInfo *provider.UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec) (*kubermaticv1.CloudSpec, error) {
+	preset, err := m.GetPreset(userInfo, projectID, presetName)
 	if err != nil {
 		return nil, err
 	}
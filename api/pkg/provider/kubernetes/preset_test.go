@@ -188,7 +188,7 @@ func TestGetPreset(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			preset, err := provider.GetPreset(&tc.userInfo, tc.presetName)
+			preset, err := provider.GetPreset(&tc.userInfo, "", tc.presetName)
 			if len(tc.expectedError) > 0 {
 				if err == nil {
 					t.Fatalf("expected error")
@@ -206,11 +206,13 @@ func TestGetPreset(t *testing.T) {
 
 func TestGetPresets(t *testing.T) {
 	t.Parallel()
+	disabled := false
 	testcases := []struct {
-		name     string
-		userInfo provider.UserInfo
-		presets  []runtime.Object
-		expected []kubermaticv1.Preset
+		name      string
+		userInfo  provider.UserInfo
+		projectID string
+		presets   []runtime.Object
+		expected  []kubermaticv1.Preset
 	}{
 		{
 			name:     "test 1: get Presets for the specific email group and all users",
@@ -332,6 +334,88 @@ func TestGetPresets(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "test 3: get Presets which are enabled and available in the project",
+			userInfo:  provider.UserInfo{Email: "test@example.com"},
+			projectID: "my-project",
+			presets: []runtime.Object{
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "disabled",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Enabled: &disabled,
+						Fake: &kubermaticv1.Fake{
+							Token: "aaaaa",
+						},
+					},
+				},
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "other-project",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Projects: []string{"other-project"},
+						Fake: &kubermaticv1.Fake{
+							Token: "bbbbb",
+						},
+					},
+				},
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-project",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Projects: []string{"other-project", "my-project"},
+						Fake: &kubermaticv1.Fake{
+							Token: "ccccc",
+						},
+					},
+				},
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "wrong-domain",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						RequiredEmailDomain: "test.com",
+						Projects:            []string{"my-project"},
+						Fake: &kubermaticv1.Fake{
+							Token: "ddddd",
+						},
+					},
+				},
+			},
+			expected: []kubermaticv1.Preset{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-project",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Projects: []string{"other-project", "my-project"},
+						Fake: &kubermaticv1.Fake{
+							Token: "ccccc",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "test 4: presets restricted to projects are not returned without a project",
+			userInfo: provider.UserInfo{Email: "test@example.com"},
+			presets: []runtime.Object{
+				&kubermaticv1.Preset{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-project",
+					},
+					Spec: kubermaticv1.PresetSpec{
+						Projects: []string{"my-project"},
+						Fake: &kubermaticv1.Fake{
+							Token: "ccccc",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -340,7 +424,7 @@ func TestGetPresets(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			presets, err := provider.GetPresets(&tc.userInfo)
+			presets, err := provider.GetPresets(&tc.userInfo, tc.projectID)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -676,7 +760,7 @@ func TestCredentialEndpoint(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			cloudResult, err := provider.SetCloudCredentials(&tc.userInfo, tc.presetName, tc.cloudSpec, tc.dc)
+			cloudResult, err := provider.SetCloudCredentials(&tc.userInfo, "", tc.presetName, tc.cloudSpec, tc.dc)
 
 			if len(tc.expectedError) > 0 {
 				if err == nil {
@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SeedProvider registers new seeds in the master cluster
+type SeedProvider struct {
+	client    ctrlruntimeclient.Client
+	ctx       context.Context
+	namespace string
+}
+
+var _ provider.SeedProvider = &SeedProvider{}
+
+// NewSeedProvider returns a seed provider which creates seeds in the given namespace
+func NewSeedProvider(ctx context.Context, client ctrlruntimeclient.Client, namespace string) *SeedProvider {
+	return &SeedProvider{client: client, ctx: ctx, namespace: namespace}
+}
+
+// SeedKubeconfigSecretName returns the name of the Secret holding the kubeconfig of the given seed
+func SeedKubeconfigSecretName(seedName string) string {
+	return fmt.Sprintf("kubeconfig-%s", seedName)
+}
+
+// CreateSeed stores the kubeconfig of the seed cluster in a Secret next to the seed, validates the
+// seed and creates it. The Secret is owned by the seed, so deleting the seed removes it as well.
+func (p *SeedProvider) CreateSeed(userInfo *provider.UserInfo, seed *kubermaticv1.Seed, kubeconfig []byte, validate provider.SeedValidationFunc) (*kubermaticv1.Seed, error) {
+	if userInfo == nil || !userInfo.IsAdmin {
+		email := ""
+		if userInfo != nil {
+			email = userInfo.Email
+		}
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, email, fmt.Errorf("%q doesn't have admin rights", email))
+	}
+	if _, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig); err != nil {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("invalid kubeconfig: %v", err))
+	}
+
+	existing := &kubermaticv1.Seed{}
+	err := p.client.Get(p.ctx, ctrlruntimeclient.ObjectKey{Namespace: p.namespace, Name: seed.Name}, existing)
+	if err == nil {
+		return nil, kerrors.NewAlreadyExists(schema.GroupResource{Group: kubermaticv1.GroupName, Resource: "seeds"}, seed.Name)
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SeedKubeconfigSecretName(seed.Name),
+			Namespace: p.namespace,
+		},
+		Data: map[string][]byte{
+			provider.DefaultKubeconfigFieldPath: kubeconfig,
+		},
+	}
+	if err := p.client.Create(p.ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create kubeconfig secret: %v", err)
+	}
+
+	seed.Namespace = p.namespace
+	seed.Spec.Kubeconfig = corev1.ObjectReference{
+		Kind:      "Secret",
+		Namespace: secret.Namespace,
+		Name:      secret.Name,
+		FieldPath: provider.DefaultKubeconfigFieldPath,
+	}
+
+	if validate != nil {
+		if err := validate(seed); err != nil {
+			if cleanupErr := p.deleteSecret(secret); cleanupErr != nil {
+				return nil, cleanupErr
+			}
+			return nil, kerrors.NewBadRequest(fmt.Sprintf("invalid seed: %v", err))
+		}
+	}
+
+	if err := p.client.Create(p.ctx, seed); err != nil {
+		if cleanupErr := p.deleteSecret(secret); cleanupErr != nil {
+			return nil, cleanupErr
+		}
+		return nil, err
+	}
+
+	oldSecret := secret.DeepCopy()
+	secret.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+		Kind:       kubermaticv1.SeedKindName,
+		Name:       seed.Name,
+		UID:        seed.UID,
+	}}
+	if err := p.client.Patch(p.ctx, secret, ctrlruntimeclient.MergeFrom(oldSecret)); err != nil {
+		return nil, fmt.Errorf("failed to set the owner of the kubeconfig secret: %v", err)
+	}
+
+	return seed, nil
+}
+
+// deleteSecret removes the kubeconfig secret of a seed that could not be created
+func (p *SeedProvider) deleteSecret(secret *corev1.Secret) error {
+	if err := p.client.Delete(p.ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to clean up kubeconfig secret %q: %v", secret.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubermatic/kubermatic/api/pkg/controller/operator/common"
+	"github.com/kubermatic/kubermatic/api/pkg/controller/operator/seed/resources/nodeportproxy"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SeedBootstrapStepSeedSynced is done once the seed-sync controller copied the Seed into the seed cluster
+	SeedBootstrapStepSeedSynced = "SeedSynced"
+	// SeedBootstrapStepCRDsInstalled is done once the Kubermatic CRDs are installed in the seed cluster
+	SeedBootstrapStepCRDsInstalled = "CRDsInstalled"
+	// SeedBootstrapStepSeedControllerManagerReady is done once the seed-controller-manager is running
+	SeedBootstrapStepSeedControllerManagerReady = "SeedControllerManagerReady"
+	// SeedBootstrapStepNodeportProxyExposed is done once the nodeport-proxy got an external address
+	SeedBootstrapStepNodeportProxyExposed = "NodeportProxyExposed"
+)
+
+// SeedBootstrapStep is a single step of bringing up a new seed
+type SeedBootstrapStep struct {
+	Name    string
+	Done    bool
+	Message string
+}
+
+// SeedBootstrapStatus describes how far the bootstrapping of a seed has progressed.
+// The steps are ordered, later steps usually depend on the earlier ones.
+type SeedBootstrapStatus struct {
+	Steps []SeedBootstrapStep
+}
+
+// Done returns true if all bootstrap steps are done
+func (s *SeedBootstrapStatus) Done() bool {
+	for _, step := range s.Steps {
+		if !step.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSeedBootstrapStatus checks which of the components the seed-sync controller and the operator
+// install into a new seed cluster are in place.
+func GetSeedBootstrapStatus(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed) *SeedBootstrapStatus {
+	return &SeedBootstrapStatus{
+		Steps: []SeedBootstrapStep{
+			checkSeedSynced(ctx, client, seed),
+			checkCRDsInstalled(ctx, client),
+			checkSeedControllerManagerReady(ctx, client, seed),
+			checkNodeportProxyExposed(ctx, client, seed),
+		},
+	}
+}
+
+func checkSeedSynced(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed) SeedBootstrapStep {
+	step := SeedBootstrapStep{Name: SeedBootstrapStepSeedSynced}
+	err := client.Get(ctx, types.NamespacedName{Namespace: seed.Namespace, Name: seed.Name}, &kubermaticv1.Seed{})
+	switch {
+	case err == nil:
+		step.Done = true
+	case kerrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		step.Message = "the Seed was not synchronized into the seed cluster yet"
+	default:
+		step.Message = fmt.Sprintf("failed to get the Seed from the seed cluster: %v", err)
+	}
+	return step
+}
+
+func checkCRDsInstalled(ctx context.Context, client ctrlruntimeclient.Client) SeedBootstrapStep {
+	step := SeedBootstrapStep{Name: SeedBootstrapStepCRDsInstalled}
+	err := client.List(ctx, &kubermaticv1.ClusterList{}, ctrlruntimeclient.Limit(1))
+	switch {
+	case err == nil:
+		step.Done = true
+	case meta.IsNoMatchError(err):
+		step.Message = "the Kubermatic CRDs are not installed yet"
+	default:
+		step.Message = fmt.Sprintf("failed to list clusters: %v", err)
+	}
+	return step
+}
+
+func checkSeedControllerManagerReady(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed) SeedBootstrapStep {
+	step := SeedBootstrapStep{Name: SeedBootstrapStepSeedControllerManagerReady}
+	name := types.NamespacedName{Namespace: seed.Namespace, Name: common.SeedControllerManagerDeploymentName}
+	health, err := resources.HealthyDeployment(ctx, client, name, 1)
+	switch {
+	case err != nil:
+		step.Message = fmt.Sprintf("failed to check Deployment %s: %v", name, err)
+	case health == kubermaticv1.HealthStatusUp:
+		step.Done = true
+	case health == kubermaticv1.HealthStatusProvisioning:
+		step.Message = fmt.Sprintf("Deployment %s is rolling out", name)
+	default:
+		step.Message = fmt.Sprintf("Deployment %s has no ready replicas", name)
+	}
+	return step
+}
+
+func checkNodeportProxyExposed(ctx context.Context, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed) SeedBootstrapStep {
+	step := SeedBootstrapStep{Name: SeedBootstrapStepNodeportProxyExposed}
+	if seed.Spec.NodeportProxy.Disable {
+		step.Done = true
+		step.Message = "the nodeport-proxy is disabled for this seed"
+		return step
+	}
+
+	service := &corev1.Service{}
+	name := types.NamespacedName{Namespace: seed.Namespace, Name: nodeportproxy.ServiceName}
+	if err := client.Get(ctx, name, service); err != nil {
+		if kerrors.IsNotFound(err) {
+			step.Message = fmt.Sprintf("Service %s does not exist yet", name)
+		} else {
+			step.Message = fmt.Sprintf("failed to get Service %s: %v", name, err)
+		}
+		return step
+	}
+
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer || len(service.Status.LoadBalancer.Ingress) > 0 {
+		step.Done = true
+		return step
+	}
+	step.Message = fmt.Sprintf("Service %s has no external address yet", name)
+	return step
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// seedPlacementConfigMapName is the name of the ConfigMap in each seed that holds the
+	// clusters a seed has been picked for, but which might not have been created yet.
+	seedPlacementConfigMapName = "seed-placement"
+
+	// defaultUtilizationCacheTTL is how long the utilization of a seed is cached.
+	defaultUtilizationCacheTTL = 30 * time.Second
+	// defaultReservationTTL is how long a reservation counts towards the cluster limit. It
+	// must be longer than the utilization cache TTL, so a created cluster is counted either
+	// through its reservation or through the cached cluster list.
+	defaultReservationTTL = 5 * time.Minute
+
+	// maxReservationAttempts is how often a reservation is retried on conflicts.
+	maxReservationAttempts = 5
+)
+
+// SeedUtilization describes how many user cluster control planes a seed hosts
+// and how much capacity its nodes provide.
+type SeedUtilization struct {
+	// Clusters is the total number of user clusters on the seed.
+	Clusters int
+	// DatacenterClusters is the number of user clusters per datacenter.
+	DatacenterClusters map[string]int
+	// Nodes is the number of ready and schedulable seed nodes.
+	Nodes int
+	// AllocatableCPU is the sum of the allocatable CPU of all ready and schedulable nodes.
+	AllocatableCPU resource.Quantity
+	// AllocatableMemory is the sum of the allocatable memory of all ready and schedulable nodes.
+	AllocatableMemory resource.Quantity
+
+	// clusterNames holds the names of the clusters per datacenter.
+	clusterNames map[string]sets.String
+}
+
+// Healthy returns true if the seed has at least one node that can run control planes.
+func (u *SeedUtilization) Healthy() bool {
+	return u.Nodes > 0
+}
+
+// load returns the number of clusters per allocatable CPU core, used to compare seeds
+// of different sizes.
+func (u *SeedUtilization) load() float64 {
+	cores := float64(u.AllocatableCPU.MilliValue()) / 1000
+	if cores <= 0 {
+		return float64(u.Clusters)
+	}
+	return float64(u.Clusters) / cores
+}
+
+// GetSeedUtilization counts the user clusters on a seed and sums up the allocatable
+// resources of its nodes.
+func GetSeedUtilization(ctx context.Context, client ctrlruntimeclient.Client) (*SeedUtilization, error) {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := client.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	utilization := &SeedUtilization{
+		Clusters:           len(clusters.Items),
+		DatacenterClusters: map[string]int{},
+		clusterNames:       map[string]sets.String{},
+	}
+	for _, cluster := range clusters.Items {
+		datacenter := cluster.Spec.Cloud.DatacenterName
+		utilization.DatacenterClusters[datacenter]++
+		if utilization.clusterNames[datacenter] == nil {
+			utilization.clusterNames[datacenter] = sets.NewString()
+		}
+		utilization.clusterNames[datacenter].Insert(cluster.Name)
+	}
+
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable || !isNodeReady(&node) {
+			continue
+		}
+		utilization.Nodes++
+		if cpu, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
+			utilization.AllocatableCPU.Add(cpu)
+		}
+		if memory, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
+			utilization.AllocatableMemory.Add(memory)
+		}
+	}
+
+	return utilization, nil
+}
+
+type cachedUtilization struct {
+	utilization *SeedUtilization
+	expires     time.Time
+}
+
+// SeedPlacer picks the seeds new clusters are created on. The utilization of the seeds is
+// cached, so not every cluster creation lists all clusters and nodes of all seeds. The
+// MaxClustersPerSeed limit is enforced with reservations that are stored in a ConfigMap in the
+// seed and written with optimistic concurrency, so concurrent creations can not exceed it.
+type SeedPlacer struct {
+	seedClientGetter provider.SeedClientGetter
+	cacheTTL         time.Duration
+	reservationTTL   time.Duration
+	now              func() time.Time
+
+	lock  sync.Mutex
+	cache map[string]cachedUtilization
+}
+
+// NewSeedPlacer returns a SeedPlacer that accesses the seeds with the given client getter.
+func NewSeedPlacer(seedClientGetter provider.SeedClientGetter) *SeedPlacer {
+	return &SeedPlacer{
+		seedClientGetter: seedClientGetter,
+		cacheTTL:         defaultUtilizationCacheTTL,
+		reservationTTL:   defaultReservationTTL,
+		now:              time.Now,
+		cache:            map[string]cachedUtilization{},
+	}
+}
+
+// SelectSeedForDatacenter returns the seed the cluster with the given name in the given datacenter
+// should be created on. Seeds which failed their health checks, are unreachable, have no usable
+// nodes or have reached the datacenter's MaxClustersPerSeed limit are skipped. The remaining seeds
+// are tried in order of their clusters per allocatable CPU core, until one accepts the reservation
+// for the cluster.
+func (p *SeedPlacer) SelectSeedForDatacenter(ctx context.Context, seeds []*kubermaticv1.Seed, datacenterName, clusterName string) (*kubermaticv1.Seed, error) {
+	type candidate struct {
+		seed        *kubermaticv1.Seed
+		client      ctrlruntimeclient.Client
+		utilization *SeedUtilization
+	}
+
+	var (
+		candidates []candidate
+		reasons    []string
+	)
+
+	for _, seed := range seeds {
+		if err := ValidateSeedHealth(seed); err != nil {
+			reasons = append(reasons, err.Error())
+			continue
+		}
+
+		client, err := p.seedClientGetter(seed)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: failed to get client: %v", seed.Name, err))
+			continue
+		}
+
+		utilization, err := p.utilization(ctx, seed.Name, client)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %v", seed.Name, err))
+			continue
+		}
+
+		if !utilization.Healthy() {
+			reasons = append(reasons, fmt.Sprintf("%s: no ready nodes", seed.Name))
+			continue
+		}
+
+		if limit := maxClustersPerSeed(seed, datacenterName); limit > 0 && utilization.DatacenterClusters[datacenterName] >= limit {
+			reasons = append(reasons, fmt.Sprintf("%s: limit of %d clusters reached", seed.Name, limit))
+			continue
+		}
+
+		candidates = append(candidates, candidate{seed: seed, client: client, utilization: utilization})
+	}
+
+	// seeds are passed in a stable order, so ties are resolved deterministically
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return isLessLoaded(candidates[i].utilization, candidates[j].utilization)
+	})
+
+	for _, c := range candidates {
+		limit := maxClustersPerSeed(c.seed, datacenterName)
+		if limit <= 0 {
+			return c.seed, nil
+		}
+
+		reserved, err := p.reserve(ctx, c.client, c.utilization.clusterNames[datacenterName], datacenterName, clusterName, limit)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %v", c.seed.Name, err))
+			continue
+		}
+		if !reserved {
+			reasons = append(reasons, fmt.Sprintf("%s: limit of %d clusters reached", c.seed.Name, limit))
+			continue
+		}
+		return c.seed, nil
+	}
+
+	return nil, fmt.Errorf("no seed is available for datacenter %q: %s", datacenterName, strings.Join(reasons, "; "))
+}
+
+// utilization returns the cached utilization of the seed, it is refreshed once it expired.
+func (p *SeedPlacer) utilization(ctx context.Context, seedName string, client ctrlruntimeclient.Client) (*SeedUtilization, error) {
+	p.lock.Lock()
+	cached, ok := p.cache[seedName]
+	p.lock.Unlock()
+	if ok && p.now().Before(cached.expires) {
+		return cached.utilization, nil
+	}
+
+	utilization, err := GetSeedUtilization(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	p.cache[seedName] = cachedUtilization{utilization: utilization, expires: p.now().Add(p.cacheTTL)}
+	p.lock.Unlock()
+	return utilization, nil
+}
+
+// reserve records that the cluster is going to be created in the datacenter of the seed, unless
+// this would exceed the limit. Clusters are counted through the given names of the existing
+// clusters and the reservations, which are removed once they expire. The ConfigMap holding the
+// reservations is updated with its resourceVersion, so a concurrent reservation leads to a
+// conflict and a retry with the updated reservations.
+func (p *SeedPlacer) reserve(ctx context.Context, client ctrlruntimeclient.Client, existing sets.String, datacenterName, clusterName string, limit int) (bool, error) {
+	key := types.NamespacedName{Namespace: resources.KubermaticNamespace, Name: seedPlacementConfigMapName}
+
+	for attempt := 0; attempt < maxReservationAttempts; attempt++ {
+		configMap := &corev1.ConfigMap{}
+		err := client.Get(ctx, key, configMap)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get reservations: %v", err)
+		}
+		create := kerrors.IsNotFound(err)
+		if create {
+			configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		now := p.now()
+		clusters := sets.NewString(existing.UnsortedList()...)
+		for reservation, expiry := range configMap.Data {
+			expires, err := time.Parse(time.RFC3339, expiry)
+			if err != nil || now.After(expires) {
+				delete(configMap.Data, reservation)
+				continue
+			}
+			if reservedDatacenter, reservedCluster := splitReservation(reservation); reservedDatacenter == datacenterName {
+				clusters.Insert(reservedCluster)
+			}
+		}
+		if !clusters.Has(clusterName) && clusters.Len() >= limit {
+			return false, nil
+		}
+		configMap.Data[reservationKey(datacenterName, clusterName)] = now.Add(p.reservationTTL).UTC().Format(time.RFC3339)
+
+		if create {
+			err = client.Create(ctx, configMap)
+		} else {
+			err = client.Update(ctx, configMap)
+		}
+		if kerrors.IsConflict(err) || kerrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to store reservation: %v", err)
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("failed to store reservation after %d attempts", maxReservationAttempts)
+}
+
+func reservationKey(datacenterName, clusterName string) string {
+	return fmt.Sprintf("%s.%s", datacenterName, clusterName)
+}
+
+// splitReservation returns the datacenter and the cluster name of a reservation key. Cluster
+// names contain no dots, so everything before the last one is the datacenter.
+func splitReservation(key string) (string, string) {
+	idx := strings.LastIndex(key, ".")
+	if idx < 0 {
+		return "", key
+	}
+	return key[:idx], key[idx+1:]
+}
+
+func maxClustersPerSeed(seed *kubermaticv1.Seed, datacenterName string) int {
+	if placement := seed.Spec.Datacenters[datacenterName].Spec.Placement; placement != nil {
+		return placement.MaxClustersPerSeed
+	}
+	return 0
+}
+
+// ValidateSeedHealth returns an error listing the failed health checks of the seed.
+func ValidateSeedHealth(seed *kubermaticv1.Seed) error {
+	failed := seed.Status.FailedConditions()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	var checks []string
+	for _, condition := range failed {
+		check := string(condition.Type)
+		if condition.Message != "" {
+			check = fmt.Sprintf("%s (%s)", check, condition.Message)
+		} else if condition.Reason != "" {
+			check = fmt.Sprintf("%s (%s)", check, condition.Reason)
+		}
+		checks = append(checks, check)
+	}
+
+	return fmt.Errorf("%s: seed is unhealthy, failed checks: %s", seed.Name, strings.Join(checks, ", "))
+}
+
+// isLessLoaded compares the utilization of two seeds.
+func isLessLoaded(a, b *SeedUtilization) bool {
+	if a.load() != b.load() {
+		return a.load() < b.load()
+	}
+	return a.Clusters < b.Clusters
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
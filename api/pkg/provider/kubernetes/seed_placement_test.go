@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func genSeedNode(name, cpu string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	return &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func genPlacedClusters(datacenter string, count int) []runtime.Object {
+	var clusters []runtime.Object
+	for i := 0; i < count; i++ {
+		clusters = append(clusters, &kubermaticv1.Cluster{
+			ObjectMeta: v1.ObjectMeta{Name: fmt.Sprintf("%s-%d", datacenter, i)},
+			Spec: kubermaticv1.ClusterSpec{
+				Cloud: kubermaticv1.CloudSpec{DatacenterName: datacenter},
+			},
+		})
+	}
+	return clusters
+}
+
+func genSharedSeed(name string, maxClusters int) *kubermaticv1.Seed {
+	return &kubermaticv1.Seed{
+		ObjectMeta: v1.ObjectMeta{Name: name},
+		Spec: kubermaticv1.SeedSpec{
+			Datacenters: map[string]kubermaticv1.Datacenter{
+				"shared-dc": {
+					Spec: kubermaticv1.DatacenterSpec{
+						Fake: &kubermaticv1.DatacenterSpecFake{},
+						Placement: &kubermaticv1.DatacenterPlacement{
+							Shared:             true,
+							MaxClustersPerSeed: maxClusters,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSelectSeedForDatacenter(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name         string
+		seeds        []*kubermaticv1.Seed
+		seedObjects  map[string][]runtime.Object
+		expectedSeed string
+		expectError  bool
+	}{
+		{
+			name:  "scenario 1: the seed with fewer clusters per core wins",
+			seeds: []*kubermaticv1.Seed{genSharedSeed("seed-a", 0), genSharedSeed("seed-b", 0)},
+			seedObjects: map[string][]runtime.Object{
+				"seed-a": append(genPlacedClusters("shared-dc", 4), genSeedNode("node-a", "4", true)),
+				"seed-b": append(genPlacedClusters("shared-dc", 6), genSeedNode("node-b", "16", true)),
+			},
+			expectedSeed: "seed-b",
+		},
+		{
+			name:  "scenario 2: seeds without ready nodes are skipped",
+			seeds: []*kubermaticv1.Seed{genSharedSeed("seed-a", 0), genSharedSeed("seed-b", 0)},
+			seedObjects: map[string][]runtime.Object{
+				"seed-a": {genSeedNode("node-a", "16", false)},
+				"seed-b": append(genPlacedClusters("shared-dc", 10), genSeedNode("node-b", "4", true)),
+			},
+			expectedSeed: "seed-b",
+		},
+		{
+			name:  "scenario 3: seeds at their cluster limit are skipped",
+			seeds: []*kubermaticv1.Seed{genSharedSeed("seed-a", 2), genSharedSeed("seed-b", 0)},
+			seedObjects: map[string][]runtime.Object{
+				"seed-a": append(genPlacedClusters("shared-dc", 2), genSeedNode("node-a", "64", true)),
+				"seed-b": append(genPlacedClusters("shared-dc", 8), genSeedNode("node-b", "4", true)),
+			},
+			expectedSeed: "seed-b",
+		},
+		{
+			name: "scenario 4: seeds with failed health checks are skipped",
+			seeds: []*kubermaticv1.Seed{
+				func() *kubermaticv1.Seed {
+					seed := genSharedSeed("seed-a", 0)
+					seed.Status.Conditions = []kubermaticv1.SeedCondition{{Type: kubermaticv1.SeedConditionAPIReachable, Status: corev1.ConditionFalse}}
+					return seed
+				}(),
+				genSharedSeed("seed-b", 0),
+			},
+			seedObjects: map[string][]runtime.Object{
+				"seed-a": {genSeedNode("node-a", "64", true)},
+				"seed-b": append(genPlacedClusters("shared-dc", 8), genSeedNode("node-b", "4", true)),
+			},
+			expectedSeed: "seed-b",
+		},
+		{
+			name:  "scenario 5: no seed available",
+			seeds: []*kubermaticv1.Seed{genSharedSeed("seed-a", 1)},
+			seedObjects: map[string][]runtime.Object{
+				"seed-a": append(genPlacedClusters("shared-dc", 1), genSeedNode("node-a", "4", true)),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			clients := map[string]ctrlruntimeclient.Client{}
+			for seedName, objects := range tc.seedObjects {
+				clients[seedName] = fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, objects...)
+			}
+			seedClientGetter := func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
+				return clients[seed.Name], nil
+			}
+
+			placer := kubernetes.NewSeedPlacer(seedClientGetter)
+			seed, err := placer.SelectSeedForDatacenter(context.Background(), tc.seeds, "shared-dc", "new-cluster")
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got seed %q", seed.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seed.Name != tc.expectedSeed {
+				t.Fatalf("expected seed %q, got %q", tc.expectedSeed, seed.Name)
+			}
+		})
+	}
+}
+
+func TestSelectSeedForDatacenterReservesClusters(t *testing.T) {
+	t.Parallel()
+
+	seeds := []*kubermaticv1.Seed{genSharedSeed("seed-a", 2)}
+	client := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, append(genPlacedClusters("shared-dc", 1), genSeedNode("node-a", "4", true))...)
+	placer := kubernetes.NewSeedPlacer(func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
+		return client, nil
+	})
+
+	if _, err := placer.SelectSeedForDatacenter(context.Background(), seeds, "shared-dc", "first"); err != nil {
+		t.Fatalf("expected the first cluster to be placed: %v", err)
+	}
+	// Placing the same cluster again does not take another slot
+	if _, err := placer.SelectSeedForDatacenter(context.Background(), seeds, "shared-dc", "first"); err != nil {
+		t.Fatalf("expected the first cluster to be placed again: %v", err)
+	}
+	// The reservation of the first cluster counts, although it has not been created yet
+	if seed, err := placer.SelectSeedForDatacenter(context.Background(), seeds, "shared-dc", "second"); err == nil {
+		t.Fatalf("expected the limit to be reached, got seed %q", seed.Name)
+	}
+}
@@ -62,7 +62,7 @@ type SSHKeyProvider struct {
 }
 
 // Create creates a ssh key that will belong to the given project
-func (p *SSHKeyProvider) Create(userInfo *provider.UserInfo, project *kubermaticapiv1.Project, keyName, pubKey string) (*kubermaticapiv1.UserSSHKey, error) {
+func (p *SSHKeyProvider) Create(userInfo *provider.UserInfo, project *kubermaticapiv1.Project, keyName, pubKey string, options *provider.SSHKeyCreateOptions) (*kubermaticapiv1.UserSSHKey, error) {
 	if keyName == "" {
 		return nil, fmt.Errorf("the ssh key name is missing but required")
 	}
@@ -73,7 +73,7 @@ func (p *SSHKeyProvider) Create(userInfo *provider.UserInfo, project *kubermatic
 		return nil, errors.New("a userInfo is missing but required")
 	}
 
-	sshKey, err := genUserSSHKey(project, keyName, pubKey)
+	sshKey, err := genUserSSHKey(project, keyName, pubKey, options)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +90,7 @@ func (p *SSHKeyProvider) Create(userInfo *provider.UserInfo, project *kubermatic
 
 // Create creates a ssh key that belongs to the given project
 // This function is unsafe in a sense that it uses privileged account to create the ssh key
-func (p *PrivilegedSSHKeyProvider) CreateUnsecured(project *kubermaticapiv1.Project, keyName, pubKey string) (*kubermaticapiv1.UserSSHKey, error) {
+func (p *PrivilegedSSHKeyProvider) CreateUnsecured(project *kubermaticapiv1.Project, keyName, pubKey string, options *provider.SSHKeyCreateOptions) (*kubermaticapiv1.UserSSHKey, error) {
 	if keyName == "" {
 		return nil, fmt.Errorf("the ssh key name is missing but required")
 	}
@@ -98,7 +98,7 @@ func (p *PrivilegedSSHKeyProvider) CreateUnsecured(project *kubermaticapiv1.Proj
 		return nil, fmt.Errorf("the ssh public part of the key is missing but required")
 	}
 
-	sshKey, err := genUserSSHKey(project, keyName, pubKey)
+	sshKey, err := genUserSSHKey(project, keyName, pubKey, options)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +109,7 @@ func (p *PrivilegedSSHKeyProvider) CreateUnsecured(project *kubermaticapiv1.Proj
 	return sshKey, nil
 }
 
-func genUserSSHKey(project *kubermaticapiv1.Project, keyName, pubKey string) (*kubermaticapiv1.UserSSHKey, error) {
+func genUserSSHKey(project *kubermaticapiv1.Project, keyName, pubKey string, options *provider.SSHKeyCreateOptions) (*kubermaticapiv1.UserSSHKey, error) {
 	pubKeyParsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pubKey))
 	if err != nil {
 		return nil, fmt.Errorf("the provided ssh key is invalid due to = %v", err)
@@ -117,7 +117,7 @@ func genUserSSHKey(project *kubermaticapiv1.Project, keyName, pubKey string) (*k
 	sshKeyHash := ssh.FingerprintLegacyMD5(pubKeyParsed)
 
 	keyInternalName := fmt.Sprintf("key-%s-%s", strings.NewReplacer(":", "").Replace(sshKeyHash), uuid.ShortUID(4))
-	return &kubermaticapiv1.UserSSHKey{
+	sshKey := &kubermaticapiv1.UserSSHKey{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: keyInternalName,
 			OwnerReferences: []metav1.OwnerReference{
@@ -135,7 +135,16 @@ func genUserSSHKey(project *kubermaticapiv1.Project, keyName, pubKey string) (*k
 			Name:        keyName,
 			Clusters:    []string{},
 		},
-	}, nil
+	}
+	if options != nil {
+		sshKey.Spec.User = options.User
+		sshKey.Spec.NodeSelector = options.NodeSelector
+		if options.ExpiresAt != nil {
+			expiresAt := metav1.NewTime(*options.ExpiresAt)
+			sshKey.Spec.ExpiresAt = &expiresAt
+		}
+	}
+	return sshKey, nil
 }
 
 // List gets a list of ssh keys, by default it will get all the keys that belong to the given project.
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+)
+
+// SecretStore is an external store cloud credentials can be kept in instead of Kubernetes Secrets.
+//
+// A GlobalSecretKeySelector points at an external store when its Kind is set to the kind the store
+// is configured for in SecretStores. The Name of the selector is then used as the path of the secret
+// within the store and the Namespace is ignored.
+type SecretStore interface {
+	// Get returns the value of the key of the secret stored at the given path
+	Get(ctx context.Context, path, key string) (string, error)
+	// Set stores the secret at the given path, replacing all existing keys
+	Set(ctx context.Context, path string, data map[string]string) error
+}
+
+// SecretStoreInjector is implemented by secret stores which can render their secrets into files of pods at
+// runtime. The control plane components read the cloud credentials kept in such a store from these files,
+// so the credentials never get copied into Kubernetes Secrets.
+type SecretStoreInjector interface {
+	// ValueTemplate returns a template which renders into the value of the key of the secret stored at
+	// the given path. The value gets escaped by replacing the given old, new string pairs in order.
+	ValueTemplate(path, key string, escapes ...string) string
+	// DecodedValueTemplate returns a template which renders into the base64 decoded value of the key of
+	// the secret stored at the given path
+	DecodedValueTemplate(path, key string) string
+	// PodAnnotations returns the annotations which make the given templates, keyed by file name, get rendered
+	// into files of the pod. path is the secret the templates read from.
+	PodAnnotations(path string, templates map[string]string) map[string]string
+	// FilePath returns the path the file of the given name gets rendered to
+	FilePath(name string) string
+}
+
+// SecretStores are the external stores cloud credentials can be read from and stored in.
+// A nil *SecretStores is valid and means that only Kubernetes Secrets are used.
+type SecretStores struct {
+	// Stores are the available stores, keyed by the kind GlobalSecretKeySelectors refer to them with
+	Stores map[string]SecretStore
+	// CredentialsKind is the kind of the store the credentials of new clusters are stored in,
+	// they are stored in Kubernetes Secrets if it is empty
+	CredentialsKind string
+	// CredentialsPathPrefix is the path below which the credentials of new clusters are stored
+	CredentialsPathPrefix string
+}
+
+// Get returns the store for the given kind or nil if there is none
+func (s *SecretStores) Get(kind string) SecretStore {
+	if s == nil {
+		return nil
+	}
+	return s.Stores[kind]
+}
+
+// Injector returns the store for the given kind if it can inject secrets into pods, nil otherwise
+func (s *SecretStores) Injector(kind string) SecretStoreInjector {
+	injector, _ := s.Get(kind).(SecretStoreInjector)
+	return injector
+}
+
+// CredentialsStore returns the store the credentials of new clusters are stored in, it is nil
+// if credentials get stored in Kubernetes Secrets
+func (s *SecretStores) CredentialsStore() SecretStore {
+	if s == nil || s.CredentialsKind == "" {
+		return nil
+	}
+	return s.Stores[s.CredentialsKind]
+}
+
+// IsSecretStoreKind returns whether the kind of a GlobalSecretKeySelector refers to an external store
+func IsSecretStoreKind(kind string) bool {
+	return kind != "" && kind != "Secret"
+}
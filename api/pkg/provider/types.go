@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
@@ -72,6 +73,75 @@ type CloudProvider interface {
 	ValidateCloudSpecUpdate(oldSpec kubermaticv1.CloudSpec, newSpec kubermaticv1.CloudSpec) error
 }
 
+// CloudCredentialValidator is an optional extension of CloudProvider. Providers implementing it
+// are able to verify the credentials of a cloud spec and the permissions Kubermatic needs before
+// a cluster gets created.
+type CloudCredentialValidator interface {
+	// ValidateCloudCredentials checks the credentials of the given cloud spec. Missing permissions or
+	// rejected credentials are part of the returned report, an error is only returned if the
+	// check itself could not be performed.
+	ValidateCloudCredentials(spec kubermaticv1.CloudSpec) (*CredentialsReport, error)
+}
+
+// PermissionCheckResult is the outcome of a single permission check
+type PermissionCheckResult string
+
+const (
+	// PermissionAllowed means the credentials are granted the permission
+	PermissionAllowed PermissionCheckResult = "Allowed"
+	// PermissionDenied means the credentials lack the permission
+	PermissionDenied PermissionCheckResult = "Denied"
+	// PermissionUnknown means the permission could not be verified, e.g. because the
+	// credentials are not allowed to inspect their own permissions
+	PermissionUnknown PermissionCheckResult = "Unknown"
+)
+
+// PermissionCheck is the result of checking a single permission
+type PermissionCheck struct {
+	Permission string
+	Result     PermissionCheckResult
+	Message    string
+}
+
+// CredentialsReport is the result of validating the credentials of a cloud spec
+type CredentialsReport struct {
+	// Valid is false if the credentials got rejected or a required permission is denied
+	Valid bool
+	// Identity is the identity the credentials belong to, if the provider is able to tell
+	Identity string
+	// Message explains why the credentials got rejected
+	Message     string
+	Permissions []PermissionCheck
+}
+
+// NewCredentialsReport returns a report for the given identity and permission checks.
+// The report is valid unless one of the permissions got denied.
+func NewCredentialsReport(identity string, permissions []PermissionCheck) *CredentialsReport {
+	report := &CredentialsReport{Valid: true, Identity: identity, Permissions: permissions}
+	for _, permission := range permissions {
+		if permission.Result == PermissionDenied {
+			report.Valid = false
+		}
+	}
+	return report
+}
+
+// InvalidCredentialsReport returns a report for credentials which got rejected by the provider
+func InvalidCredentialsReport(message string) *CredentialsReport {
+	return &CredentialsReport{Valid: false, Message: message}
+}
+
+// DeniedPermissions returns the names of all denied permissions
+func (r *CredentialsReport) DeniedPermissions() []string {
+	var denied []string
+	for _, permission := range r.Permissions {
+		if permission.Result == PermissionDenied {
+			denied = append(denied, permission.Permission)
+		}
+	}
+	return denied
+}
+
 // ClusterUpdater defines a function to persist an update to a cluster
 type ClusterUpdater func(string, func(*kubermaticv1.Cluster)) (*kubermaticv1.Cluster, error)
 
@@ -90,9 +160,10 @@ type ClusterGetOptions struct {
 
 // SecretKeySelectorValueFunc is used to fetch the value of a config var. Do not build your own
 // implementation, use SecretKeySelectorValueFuncFactory.
+// The value is read from a Kubernetes Secret unless the selector refers to one of the given SecretStores.
 type SecretKeySelectorValueFunc func(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 
-func SecretKeySelectorValueFuncFactory(ctx context.Context, client ctrlruntimeclient.Client) SecretKeySelectorValueFunc {
+func SecretKeySelectorValueFuncFactory(ctx context.Context, client ctrlruntimeclient.Client, secretStores *SecretStores) SecretKeySelectorValueFunc {
 	return func(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
 		if configVar == nil {
 			return "", errors.New("configVar is nil")
@@ -100,13 +171,26 @@ func SecretKeySelectorValueFuncFactory(ctx context.Context, client ctrlruntimecl
 		if configVar.Name == "" {
 			return "", errors.New("configVar.Name is empty")
 		}
-		if configVar.Namespace == "" {
-			return "", errors.New("configVar.Namspace is empty")
-		}
 		if key == "" {
 			return "", errors.New("key is empty")
 		}
 
+		if IsSecretStoreKind(configVar.Kind) {
+			store := secretStores.Get(configVar.Kind)
+			if store == nil {
+				return "", fmt.Errorf("no secret store is configured for %q", configVar.Kind)
+			}
+			value, err := store.Get(ctx, configVar.Name, key)
+			if err != nil {
+				return "", fmt.Errorf("failed to get key %q of secret %q from %s: %v", key, configVar.Name, configVar.Kind, err)
+			}
+			return value, nil
+		}
+
+		if configVar.Namespace == "" {
+			return "", errors.New("configVar.Namspace is empty")
+		}
+
 		secret := &corev1.Secret{}
 		namespacedName := types.NamespacedName{Namespace: configVar.Namespace, Name: configVar.Name}
 		if err := client.Get(ctx, namespacedName, secret); err != nil {
@@ -169,6 +253,13 @@ type ClusterProvider interface {
 	// GetViewerKubeconfigForCustomerCluster returns the viewer kubeconfig for the given cluster
 	GetViewerKubeconfigForCustomerCluster(cluster *kubermaticv1.Cluster) (*clientcmdapi.Config, error)
 
+	// GetShortLivedKubeconfigForCustomerCluster returns a kubeconfig with a client certificate
+	// which identifies the given user and expires after the given duration, at most kubermaticv1.MaxKubeconfigTTL
+	GetShortLivedKubeconfigForCustomerCluster(userInfo *UserInfo, cluster *kubermaticv1.Cluster, validity time.Duration) (*clientcmdapi.Config, error)
+
+	// RevokeShortLivedKubeconfigs removes the permissions of all short-lived kubeconfigs of the given user
+	RevokeShortLivedKubeconfigs(cluster *kubermaticv1.Cluster, email string) error
+
 	// RevokeViewerKubeconfig revokes viewer token and kubeconfig
 	RevokeViewerKubeconfig(c *kubermaticv1.Cluster) error
 
@@ -185,6 +276,16 @@ type ClusterProvider interface {
 	// Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
 	GetClientForCustomerCluster(*UserInfo, *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error)
 
+	// GetAdminK8sClientForCustomerCluster returns a kubernetes clientset to interact with the given cluster
+	//
+	// Note that the client you will get has admin privileges
+	GetAdminK8sClientForCustomerCluster(*kubermaticv1.Cluster) (kubernetes.Interface, error)
+
+	// GetK8sClientForCustomerCluster returns a kubernetes clientset to interact with the given cluster
+	//
+	// Note that the client doesn't use admin account instead it authn/authz as userInfo(email, group)
+	GetK8sClientForCustomerCluster(*UserInfo, *kubermaticv1.Cluster) (kubernetes.Interface, error)
+
 	// GetTokenForCustomerCluster returns a token for the given cluster with permissions granted to group that
 	// user belongs to.
 	GetTokenForCustomerCluster(userInfo *UserInfo, cluster *kubermaticv1.Cluster) (string, error)
@@ -203,6 +304,10 @@ type PrivilegedClusterProvider interface {
 	// Note that the client you will get has admin privileges in the seed cluster
 	GetSeedClusterAdminClient() kubernetes.Interface
 
+	// GetSecretStores returns the external stores cloud credentials are kept in, it is nil if
+	// only Kubernetes Secrets are used
+	GetSecretStores() *SecretStores
+
 	// GetUnsecured returns a cluster for the project and given name.
 	//
 	// Note that the admin privileges are used to get cluster
@@ -232,6 +337,17 @@ type SSHKeyListOptions struct {
 	SSHKeyName string
 }
 
+// SSHKeyCreateOptions restricts a new ssh key to a user, a set of nodes or a period of time.
+// The restrictions are part of the key from the start, so there is never an unrestricted key.
+type SSHKeyCreateOptions struct {
+	// User is the operating system user the key is authorized for
+	User string
+	// ExpiresAt is the point in time after which the key is removed from the nodes
+	ExpiresAt *time.Time
+	// NodeSelector restricts the key to the nodes whose labels match all the given labels
+	NodeSelector map[string]string
+}
+
 // SSHKeyProvider declares the set of methods for interacting with ssh keys
 // This provider is Project and RBAC compliant
 type SSHKeyProvider interface {
@@ -242,8 +358,8 @@ type SSHKeyProvider interface {
 	// After we get the list of the keys we could try to get each individually using unprivileged account to see if the user have read access,
 	List(project *kubermaticv1.Project, options *SSHKeyListOptions) ([]*kubermaticv1.UserSSHKey, error)
 
-	// Create creates a ssh key that belongs to the given project
-	Create(userInfo *UserInfo, project *kubermaticv1.Project, keyName, pubKey string) (*kubermaticv1.UserSSHKey, error)
+	// Create creates a ssh key that belongs to the given project, options can be nil for an unrestricted key
+	Create(userInfo *UserInfo, project *kubermaticv1.Project, keyName, pubKey string, options *SSHKeyCreateOptions) (*kubermaticv1.UserSSHKey, error)
 
 	// Delete deletes the given ssh key
 	Delete(userInfo *UserInfo, keyName string) error
@@ -267,7 +383,7 @@ type PrivilegedSSHKeyProvider interface {
 
 	// Create creates a ssh key that belongs to the given project
 	// This function is unsafe in a sense that it uses privileged account to create the ssh key
-	CreateUnsecured(project *kubermaticv1.Project, keyName, pubKey string) (*kubermaticv1.UserSSHKey, error)
+	CreateUnsecured(project *kubermaticv1.Project, keyName, pubKey string, options *SSHKeyCreateOptions) (*kubermaticv1.UserSSHKey, error)
 
 	// Delete deletes the given ssh key
 	// This function is unsafe in a sense that it uses privileged account to delete the ssh key
@@ -694,9 +810,20 @@ type AdminProvider interface {
 
 // PresetProvider declares the set of methods for interacting with presets
 type PresetProvider interface {
-	GetPresets(userInfo *UserInfo) ([]kubermaticv1.Preset, error)
-	GetPreset(userInfo *UserInfo, name string) (*kubermaticv1.Preset, error)
-	SetCloudCredentials(userInfo *UserInfo, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error)
+	// GetPresets returns the enabled presets the user can use in the given project,
+	// an empty projectID only returns the presets which are not restricted to projects
+	GetPresets(userInfo *UserInfo, projectID string) ([]kubermaticv1.Preset, error)
+	GetPreset(userInfo *UserInfo, projectID, name string) (*kubermaticv1.Preset, error)
+	SetCloudCredentials(userInfo *UserInfo, projectID, presetName string, cloud kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) (*kubermaticv1.CloudSpec, error)
+
+	// ListPresets returns all presets, including the disabled ones, only admins are allowed to list them
+	ListPresets(userInfo *UserInfo) ([]kubermaticv1.Preset, error)
+	// CreatePreset creates the preset, only admins are allowed to create presets
+	CreatePreset(userInfo *UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error)
+	// UpdatePreset updates the preset, only admins are allowed to update presets
+	UpdatePreset(userInfo *UserInfo, preset *kubermaticv1.Preset) (*kubermaticv1.Preset, error)
+	// DeletePreset deletes the preset, only admins are allowed to delete presets
+	DeletePreset(userInfo *UserInfo, name string) error
 }
 
 // AdmissionPluginsProvider declares the set of methods for interacting with admission plugins
@@ -707,3 +834,62 @@ type AdmissionPluginsProvider interface {
 	Update(userInfo *UserInfo, admissionPlugin *kubermaticv1.AdmissionPlugin) (*kubermaticv1.AdmissionPlugin, error)
 	ListPluginNamesFromVersion(fromVersion string) ([]string, error)
 }
+
+// ClusterTemplateProvider declares the set of methods for interacting with cluster templates
+type ClusterTemplateProvider interface {
+	// New creates a cluster template, global templates can only be created by admins
+	New(userInfo *UserInfo, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error)
+
+	// List returns the templates usable in the given project, that is the project scoped and the global ones
+	List(userInfo *UserInfo, projectID string) ([]kubermaticv1.ClusterTemplate, error)
+
+	// Get returns the template with the given name if it is usable in the given project
+	Get(userInfo *UserInfo, projectID, templateID string) (*kubermaticv1.ClusterTemplate, error)
+
+	// Delete deletes the template with the given name, global templates can only be deleted by admins
+	Delete(userInfo *UserInfo, projectID, templateID string) error
+}
+
+// ConstraintTemplateProvider declares the set of methods for interacting with gatekeeper constraint templates
+type ConstraintTemplateProvider interface {
+	// List returns all constraint templates
+	List(userInfo *UserInfo) ([]kubermaticv1.ConstraintTemplate, error)
+
+	// Get returns the constraint template with the given name
+	Get(userInfo *UserInfo, name string) (*kubermaticv1.ConstraintTemplate, error)
+
+	// Create creates the constraint template, only admins are allowed to create constraint templates
+	Create(userInfo *UserInfo, template *kubermaticv1.ConstraintTemplate) (*kubermaticv1.ConstraintTemplate, error)
+
+	// Delete deletes the constraint template, only admins are allowed to delete constraint templates
+	Delete(userInfo *UserInfo, name string) error
+}
+
+// ConstraintProvider declares the set of methods for interacting with gatekeeper constraints
+type ConstraintProvider interface {
+	// New creates a constraint in the project given by its ProjectIDLabelKey label.
+	// The user must be an owner or editor of the project.
+	New(userInfo *UserInfo, constraint *kubermaticv1.Constraint) (*kubermaticv1.Constraint, error)
+
+	// List returns the constraints of the given project
+	List(userInfo *UserInfo, projectID string) ([]kubermaticv1.Constraint, error)
+
+	// Get returns the constraint with the given name if it belongs to the given project
+	Get(userInfo *UserInfo, projectID, name string) (*kubermaticv1.Constraint, error)
+
+	// Delete deletes the constraint with the given name if it belongs to the given project.
+	// The user must be an owner or editor of the project.
+	Delete(userInfo *UserInfo, projectID, name string) error
+}
+
+// SeedValidationFunc validates a seed before it gets created. The kubeconfig of the seed
+// is already stored when it is called, so it can connect to the seed cluster.
+type SeedValidationFunc func(seed *kubermaticv1.Seed) error
+
+// SeedProvider declares the set of methods for registering new seeds
+type SeedProvider interface {
+	// CreateSeed stores the kubeconfig of the seed cluster in a Secret next to the seed, validates the
+	// seed and creates it. The Secret is owned by the seed, so deleting the seed removes it as well.
+	// Only admins are allowed to create seeds.
+	CreateSeed(userInfo *UserInfo, seed *kubermaticv1.Seed, kubeconfig []byte, validate SeedValidationFunc) (*kubermaticv1.Seed, error)
+}
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
@@ -28,7 +29,28 @@ import (
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+type fakeSecretStore map[string]map[string]string
+
+func (f fakeSecretStore) Get(_ context.Context, path, key string) (string, error) {
+	value, ok := f[path][key]
+	if !ok {
+		return "", fmt.Errorf("no key %q at %q", key, path)
+	}
+	return value, nil
+}
+
+func (f fakeSecretStore) Set(_ context.Context, path string, data map[string]string) error {
+	f[path] = data
+	return nil
+}
+
 func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
+	secretStores := &SecretStores{
+		Stores: map[string]SecretStore{
+			"FakeStore": fakeSecretStore{"kubermatic/cluster-abc": {"bar": "stored"}},
+		},
+	}
+
 	testCases := []struct {
 		name      string
 		configVar *providerconfig.GlobalSecretKeySelector
@@ -90,6 +112,39 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 			},
 			expectedResult: "value",
 		},
+		{
+			name: "value from a secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Kind: "FakeStore",
+					Name: "kubermatic/cluster-abc",
+				},
+			},
+			key:            "bar",
+			expectedResult: "stored",
+		},
+		{
+			name: "error on missing key in a secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Kind: "FakeStore",
+					Name: "kubermatic/cluster-abc",
+				},
+			},
+			key:           "foo",
+			expectedError: `failed to get key "foo" of secret "kubermatic/cluster-abc" from FakeStore: no key "foo" at "kubermatic/cluster-abc"`,
+		},
+		{
+			name: "error on unknown secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Kind: "UnknownStore",
+					Name: "kubermatic/cluster-abc",
+				},
+			},
+			key:           "bar",
+			expectedError: `no secret store is configured for "UnknownStore"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -101,7 +156,7 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 				client = fakectrlruntimeclient.NewFakeClient()
 			}
 
-			valueFunc := SecretKeySelectorValueFuncFactory(context.Background(), client)
+			valueFunc := SecretKeySelectorValueFuncFactory(context.Background(), client, secretStores)
 
 			result, err := valueFunc(tc.configVar, tc.key)
 
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"net/url"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// auditPolicyConfigMapKey is the key of the audit policy in the audit ConfigMap
+	auditPolicyConfigMapKey = "policy.yaml"
+
+	defaultAuditLogMaxAge     = 30
+	defaultAuditLogMaxBackups = 3
+	defaultAuditLogMaxSize    = 100
+)
+
+var auditPolicyPresets = map[kubermaticv1.AuditPolicyPreset]string{
+	kubermaticv1.AuditPolicyPresetMetadata: `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+`,
+	kubermaticv1.AuditPolicyPresetRecommended: `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+# The following requests are frequent and of low risk
+- level: None
+  users: ["system:kube-proxy"]
+  verbs: ["watch"]
+  resources:
+  - group: ""
+    resources: ["endpoints", "services", "services/status"]
+- level: None
+  userGroups: ["system:nodes"]
+  verbs: ["get"]
+  resources:
+  - group: ""
+    resources: ["nodes", "nodes/status"]
+- level: None
+  users: ["system:kube-controller-manager", "system:kube-scheduler", "system:serviceaccount:kube-system:endpoint-controller"]
+  verbs: ["get", "update"]
+  namespaces: ["kube-system"]
+  resources:
+  - group: ""
+    resources: ["endpoints"]
+- level: None
+  nonResourceURLs: ["/healthz*", "/version", "/swagger*"]
+- level: None
+  resources:
+  - group: ""
+    resources: ["events"]
+# Secrets, ConfigMaps and TokenReviews can contain sensitive data
+- level: Metadata
+  resources:
+  - group: ""
+    resources: ["secrets", "configmaps"]
+  - group: authentication.k8s.io
+    resources: ["tokenreviews"]
+- level: Request
+  verbs: ["get", "list", "watch"]
+- level: RequestResponse
+`,
+	kubermaticv1.AuditPolicyPresetAll: `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: RequestResponse
+`,
+}
+
+// AuditPolicy returns the audit policy configured for the cluster
+func AuditPolicy(settings *kubermaticv1.AuditLoggingSettings) (string, error) {
+	if settings == nil {
+		return auditPolicyPresets[kubermaticv1.AuditPolicyPresetMetadata], nil
+	}
+	if settings.CustomPolicy != "" {
+		return settings.CustomPolicy, nil
+	}
+
+	preset := settings.PolicyPreset
+	if preset == "" {
+		preset = kubermaticv1.AuditPolicyPresetMetadata
+	}
+	policy, exists := auditPolicyPresets[preset]
+	if !exists {
+		return "", fmt.Errorf("unknown audit policy preset %q", preset)
+	}
+	return policy, nil
+}
+
+// AuditConfigMapCreator returns a function to create the ConfigMap containing the audit policy
+func AuditConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			policy, err := AuditPolicy(data.Cluster().Spec.AuditLogging)
+			if err != nil {
+				return nil, err
+			}
+			cm.Data = map[string]string{
+				auditPolicyConfigMapKey: policy,
+			}
+			return cm, nil
+		}
+	}
+}
+
+// AuditWebhookConfigSecretCreator returns a function to create the Secret containing the kubeconfig
+// for the audit webhook backend
+func AuditWebhookConfigSecretCreator(data *resources.TemplateData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.AuditWebhookConfigSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			webhook := data.Cluster().Spec.AuditLogging.Webhook
+
+			config := clientcmdapi.NewConfig()
+			config.Clusters["webhook"] = &clientcmdapi.Cluster{
+				Server:                   webhook.URL,
+				CertificateAuthorityData: []byte(webhook.CABundle),
+			}
+			config.AuthInfos["webhook"] = &clientcmdapi.AuthInfo{}
+			config.Contexts["webhook"] = &clientcmdapi.Context{Cluster: "webhook", AuthInfo: "webhook"}
+			config.CurrentContext = "webhook"
+
+			kubeconfig, err := clientcmd.Write(*config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode audit webhook configuration: %v", err)
+			}
+			se.Data = map[string][]byte{
+				resources.KubeconfigSecretKey: kubeconfig,
+			}
+			return se, nil
+		}
+	}
+}
+
+// auditWebhookEnabled returns true if the audit events are sent to a webhook
+func auditWebhookEnabled(cluster *kubermaticv1.Cluster) bool {
+	settings := cluster.Spec.AuditLogging
+	return settings != nil && settings.Enabled && settings.Webhook != nil
+}
+
+// getAuditLogRotation returns the values of the "--audit-log-maxage", "--audit-log-maxbackup" and
+// "--audit-log-maxsize" flags
+func getAuditLogRotation(settings *kubermaticv1.AuditLoggingSettings) (maxAge, maxBackups, maxSize string) {
+	maxAge, maxBackups, maxSize = fmt.Sprint(defaultAuditLogMaxAge), fmt.Sprint(defaultAuditLogMaxBackups), fmt.Sprint(defaultAuditLogMaxSize)
+	if settings == nil || settings.Log == nil {
+		return
+	}
+	if settings.Log.MaxAge != nil {
+		maxAge = fmt.Sprint(*settings.Log.MaxAge)
+	}
+	if settings.Log.MaxBackups != nil {
+		maxBackups = fmt.Sprint(*settings.Log.MaxBackups)
+	}
+	if settings.Log.MaxSize != nil {
+		maxSize = fmt.Sprint(*settings.Log.MaxSize)
+	}
+	return
+}
+
+// getAuditFlags returns the flags for the audit policy and the audit webhook backend
+func getAuditFlags(settings *kubermaticv1.AuditLoggingSettings) []string {
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	flags := []string{"--audit-policy-file", "/etc/kubernetes/audit/" + auditPolicyConfigMapKey}
+	if settings.Webhook != nil {
+		mode := settings.Webhook.Mode
+		if mode == "" {
+			mode = kubermaticv1.AuditWebhookModeBatch
+		}
+		flags = append(flags,
+			"--audit-webhook-config-file", "/etc/kubernetes/audit-webhook/"+resources.KubeconfigSecretKey,
+			"--audit-webhook-mode", string(mode),
+		)
+	}
+	return flags
+}
+
+// auditLogSidecarArgs returns the arguments for fluent-bit to ship the audit log to the configured sink
+func auditLogSidecarArgs(sink *kubermaticv1.AuditLogSink) ([]string, error) {
+	args := []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db"}
+	if sink == nil || sink.Type == "" || sink.Type == kubermaticv1.AuditLogSinkTypeStdout {
+		return append(args, "-o", "stdout"), nil
+	}
+	if sink.Type != kubermaticv1.AuditLogSinkTypeHTTP {
+		return nil, fmt.Errorf("unknown audit log sink type %q", sink.Type)
+	}
+
+	u, err := url.Parse(sink.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log sink URL: %v", err)
+	}
+	tls := u.Scheme == "https"
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if tls {
+			port = "443"
+		}
+	}
+	uri := u.RequestURI()
+
+	args = append(args,
+		"-o", "http",
+		"-p", "host="+u.Hostname(),
+		"-p", "port="+port,
+		"-p", "uri="+uri,
+		"-p", "format=json",
+	)
+	if tls {
+		args = append(args, "-p", "tls=on")
+	}
+	return args, nil
+}
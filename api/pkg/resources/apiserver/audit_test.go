@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"reflect"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+)
+
+func TestAuditPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		settings       *kubermaticv1.AuditLoggingSettings
+		expectedPolicy string
+		errExpected    bool
+	}{
+		{
+			name:           "metadata preset is the default",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			expectedPolicy: auditPolicyPresets[kubermaticv1.AuditPolicyPresetMetadata],
+		},
+		{
+			name:           "preset is used",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyPreset: kubermaticv1.AuditPolicyPresetAll},
+			expectedPolicy: auditPolicyPresets[kubermaticv1.AuditPolicyPresetAll],
+		},
+		{
+			name: "custom policy takes precedence over the preset",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyPresetAll,
+				CustomPolicy: "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: None\n",
+			},
+			expectedPolicy: "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: None\n",
+		},
+		{
+			name:        "unknown preset",
+			settings:    &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyPreset: "verbose"},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := AuditPolicy(tc.settings)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("expected error %t, got: %v", tc.errExpected, err)
+			}
+			if policy != tc.expectedPolicy {
+				t.Errorf("expected policy\n%s\ngot\n%s", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestGetAuditFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		settings      *kubermaticv1.AuditLoggingSettings
+		expectedFlags []string
+	}{
+		{
+			name:     "audit logging disabled",
+			settings: &kubermaticv1.AuditLoggingSettings{},
+		},
+		{
+			name:          "audit logging enabled",
+			settings:      &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			expectedFlags: []string{"--audit-policy-file", "/etc/kubernetes/audit/policy.yaml"},
+		},
+		{
+			name: "audit webhook defaults to batch mode",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled: true,
+				Webhook: &kubermaticv1.AuditWebhookSettings{URL: "https://audit.example.com"},
+			},
+			expectedFlags: []string{
+				"--audit-policy-file", "/etc/kubernetes/audit/policy.yaml",
+				"--audit-webhook-config-file", "/etc/kubernetes/audit-webhook/kubeconfig",
+				"--audit-webhook-mode", "batch",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if flags := getAuditFlags(tc.settings); !reflect.DeepEqual(flags, tc.expectedFlags) {
+				t.Errorf("expected flags %v, got %v", tc.expectedFlags, flags)
+			}
+		})
+	}
+}
+
+func TestAuditLogSidecarArgs(t *testing.T) {
+	baseArgs := []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db"}
+	testCases := []struct {
+		name         string
+		sink         *kubermaticv1.AuditLogSink
+		expectedArgs []string
+		errExpected  bool
+	}{
+		{
+			name:         "stdout is the default",
+			expectedArgs: append(baseArgs, "-o", "stdout"),
+		},
+		{
+			name: "https sink",
+			sink: &kubermaticv1.AuditLogSink{Type: kubermaticv1.AuditLogSinkTypeHTTP, URL: "https://logs.example.com/audit?tenant=a"},
+			expectedArgs: append(baseArgs,
+				"-o", "http", "-p", "host=logs.example.com", "-p", "port=443", "-p", "uri=/audit?tenant=a", "-p", "format=json", "-p", "tls=on"),
+		},
+		{
+			name: "http sink with port",
+			sink: &kubermaticv1.AuditLogSink{Type: kubermaticv1.AuditLogSinkTypeHTTP, URL: "http://logs.example.com:8080"},
+			expectedArgs: append(baseArgs,
+				"-o", "http", "-p", "host=logs.example.com", "-p", "port=8080", "-p", "uri=/", "-p", "format=json"),
+		},
+		{
+			name:        "unknown sink type",
+			sink:        &kubermaticv1.AuditLogSink{Type: "syslog"},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := auditLogSidecarArgs(tc.sink)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("expected error %t, got: %v", tc.errExpected, err)
+			}
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Errorf("expected args %v, got %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
@@ -21,9 +21,12 @@ import (
 	"strings"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/etcd/etcdrunning"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/konnectivity"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/vpnsidecar"
 
@@ -54,22 +57,6 @@ const (
 	defaultNodePortRange = "30000-32767"
 )
 
-func AuditConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
-	return func() (string, reconciling.ConfigMapCreator) {
-		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-			if cm.Data == nil {
-				cm.Data = map[string]string{
-					"policy.yaml": `apiVersion: audit.k8s.io/v1
-kind: Policy
-rules:
-- level: Metadata
-`}
-			}
-			return cm, nil
-		}
-	}
-}
-
 // DeploymentCreator returns the function to create and update the API server deployment
 func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -89,6 +76,31 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 
 			volumes := getVolumes()
 			volumeMounts := getVolumeMounts()
+			if data.Cluster().IsKonnectivityEnabled() {
+				volumes = append(vpnsidecar.WithoutSidecarVolumes(volumes), konnectivity.Volumes()...)
+				volumeMounts = append(volumeMounts, konnectivity.APIServerVolumeMounts()...)
+			}
+			if data.Cluster().IsCertificateRotationInProgress() {
+				resources.AddCABundleToVolumes(volumes)
+			}
+
+			if auditWebhookEnabled(data.Cluster()) {
+				volumes = append(volumes, getAuditWebhookConfigVolume())
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.AuditWebhookConfigSecretName,
+					MountPath: "/etc/kubernetes/audit-webhook",
+					ReadOnly:  true,
+				})
+			}
+
+			if data.Cluster().IsEncryptionEnabled() {
+				volumes = append(volumes, getEncryptionConfigurationVolume())
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.EncryptionConfigurationSecretName,
+					MountPath: "/etc/kubernetes/encryption-configuration",
+					ReadOnly:  true,
+				})
+			}
 
 			if enableOIDCAuthentication && len(data.OIDCCAFile()) > 0 {
 				volumes = append(volumes, getDexCASecretVolume())
@@ -99,7 +111,15 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				})
 			}
 
-			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+			revisionVolumes := volumes
+			if injector == nil {
+				revisionVolumes = append(volumes, resources.CloudCredentialsRevisionVolume())
+			}
+			podLabels, err := data.GetPodTemplateLabels(name, revisionVolumes, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -112,6 +132,13 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 					"prometheus.io/port":                  fmt.Sprint(data.Cluster().Address.Port),
 				},
 			}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range injectionAnnotations {
+				dep.Spec.Template.Annotations[k] = v
+			}
 
 			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName)
 
@@ -125,42 +152,25 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				etcdrunning.Container(etcdEndpoints, data),
 			}
 
-			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
-			if err != nil {
-				return nil, fmt.Errorf("failed to get openvpn-client sidecar: %v", err)
-			}
-
-			dnatControllerSidecar, err := vpnsidecar.DnatControllerContainer(
-				data,
-				"dnat-controller",
-				fmt.Sprintf("https://127.0.0.1:%d", data.Cluster().Address.Port),
-			)
+			sidecars, err := getTunnelSidecars(data)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
+				return nil, err
 			}
-			auditLogEnabled := data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled
 			endpointReconcilingDisabled := false
 			if data.Cluster().Spec.ComponentsOverride.Apiserver.EndpointReconcilingDisabled != nil {
 				endpointReconcilingDisabled = *data.Cluster().Spec.ComponentsOverride.Apiserver.EndpointReconcilingDisabled
 			}
-			flags, err := getApiserverFlags(data, etcdEndpoints, enableOIDCAuthentication, auditLogEnabled, endpointReconcilingDisabled)
-			if err != nil {
-				return nil, err
-			}
-
-			envVars, err := GetEnvVars(data)
+			flags, err := getApiserverFlags(data, injector, etcdEndpoints, enableOIDCAuthentication, endpointReconcilingDisabled)
 			if err != nil {
 				return nil, err
 			}
 
-			dep.Spec.Template.Spec.Containers = []corev1.Container{
-				*openvpnSidecar,
-				*dnatControllerSidecar,
-				{
+			dep.Spec.Template.Spec.Containers = append(sidecars,
+				corev1.Container{
 					Name:    resources.ApiserverDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
 					Command: []string{"/hyperkube", "kube-apiserver"},
-					Env:     envVars,
+					Env:     GetEnvVars(data, injector),
 					Args:    flags,
 					Ports: []corev1.ContainerPort{
 						{
@@ -197,12 +207,13 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 					},
 					VolumeMounts: volumeMounts,
 				},
-			}
+			)
 
 			defResourceRequirements := map[string]*corev1.ResourceRequirements{
-				name:                       defaultResourceRequirements.DeepCopy(),
-				openvpnSidecar.Name:        openvpnSidecar.Resources.DeepCopy(),
-				dnatControllerSidecar.Name: dnatControllerSidecar.Resources.DeepCopy(),
+				name: defaultResourceRequirements.DeepCopy(),
+			}
+			for _, sidecar := range sidecars {
+				defResourceRequirements[sidecar.Name] = sidecar.Resources.DeepCopy()
 			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
@@ -210,12 +221,16 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			}
 
 			if data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled {
+				sidecarArgs, err := auditLogSidecarArgs(data.Cluster().Spec.AuditLogging.Sink)
+				if err != nil {
+					return nil, err
+				}
 				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers,
 					corev1.Container{
 						Name:    "audit-logs",
 						Image:   "docker.io/fluent/fluent-bit:1.2.2",
 						Command: []string{"/fluent-bit/bin/fluent-bit"},
-						Args:    []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "stdout"},
+						Args:    sidecarArgs,
 						VolumeMounts: []corev1.VolumeMount{
 							{
 								Name:      resources.AuditLogVolumeName,
@@ -244,12 +259,47 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 	}
 }
 
-func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, enableOIDCAuthentication, auditLogEnabled, endpointReconcilingDisabled bool) ([]string, error) {
+// getTunnelSidecars returns the sidecars the apiserver uses to reach the nodes, pods and services of the user cluster.
+func getTunnelSidecars(data *resources.TemplateData) ([]corev1.Container, error) {
+	if data.Cluster().IsKonnectivityEnabled() {
+		return []corev1.Container{*konnectivity.ServerContainer(data)}, nil
+	}
+
+	openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get openvpn-client sidecar: %v", err)
+	}
+
+	dnatControllerSidecar, err := vpnsidecar.DnatControllerContainer(
+		data,
+		"dnat-controller",
+		fmt.Sprintf("https://127.0.0.1:%d", data.Cluster().Address.Port),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
+	}
+
+	return []corev1.Container{*openvpnSidecar, *dnatControllerSidecar}, nil
+}
+
+func getApiserverFlags(data *resources.TemplateData, injector provider.SecretStoreInjector, etcdEndpoints []string, enableOIDCAuthentication, endpointReconcilingDisabled bool) ([]string, error) {
 	nodePortRange := data.NodePortRange()
 	if nodePortRange == "" {
 		nodePortRange = defaultNodePortRange
 	}
 
+	// During a certificate rotation the old and the new CAs and service account keys are trusted
+	clientCAFile := "/etc/kubernetes/pki/ca/" + resources.CACertSecretKey
+	requestHeaderClientCAFile := "/etc/kubernetes/pki/front-proxy/ca/" + resources.CACertSecretKey
+	serviceAccountKeyFile := "/etc/kubernetes/service-account-key/" + resources.ServiceAccountKeySecretKey
+	if data.Cluster().IsCertificateRotationInProgress() {
+		clientCAFile = "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey
+		requestHeaderClientCAFile = "/etc/kubernetes/pki/front-proxy/ca/" + resources.CABundleSecretKey
+		serviceAccountKeyFile = "/etc/kubernetes/service-account-key/" + resources.ServiceAccountKeyBundleSecretKey
+	}
+
+	auditLogMaxAge, auditLogMaxBackups, auditLogMaxSize := getAuditLogRotation(data.Cluster().Spec.AuditLogging)
+
 	admissionPlugins := sets.NewString(
 		"NamespaceLifecycle",
 		"LimitRanger",
@@ -284,38 +334,45 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--external-hostname", data.Cluster().Address.ExternalName,
 		"--token-auth-file", "/etc/kubernetes/tokens/tokens.csv",
 		"--enable-bootstrap-token-auth", "true",
-		"--service-account-key-file", "/etc/kubernetes/service-account-key/sa.key",
+		"--service-account-key-file", serviceAccountKeyFile,
 		// There are efforts upstream adding support for multiple cidr's. Until that has landed, we'll take the first entry
 		"--service-cluster-ip-range", data.Cluster().Spec.ClusterNetwork.Services.CIDRBlocks[0],
 		"--service-node-port-range", nodePortRange,
 		"--allow-privileged",
-		"--audit-log-maxage", "30",
-		"--audit-log-maxbackup", "3",
-		"--audit-log-maxsize", "100",
+		"--audit-log-maxage", auditLogMaxAge,
+		"--audit-log-maxbackup", auditLogMaxBackups,
+		"--audit-log-maxsize", auditLogMaxSize,
 		"--audit-log-path", "/var/log/kubernetes/audit/audit.log",
 		"--tls-cert-file", "/etc/kubernetes/tls/apiserver-tls.crt",
 		"--tls-private-key-file", "/etc/kubernetes/tls/apiserver-tls.key",
 		"--proxy-client-cert-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateCertSecretKey,
 		"--proxy-client-key-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateKeySecretKey,
-		"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+		"--client-ca-file", clientCAFile,
 		"--kubelet-client-certificate", "/etc/kubernetes/kubelet/kubelet-client.crt",
 		"--kubelet-client-key", "/etc/kubernetes/kubelet/kubelet-client.key",
-		"--requestheader-client-ca-file", "/etc/kubernetes/pki/front-proxy/ca/ca.crt",
+		"--requestheader-client-ca-file", requestHeaderClientCAFile,
 		"--requestheader-allowed-names", "apiserver-aggregator",
 		"--requestheader-extra-headers-prefix", "X-Remote-Extra-",
 		"--requestheader-group-headers", "X-Remote-Group",
 		"--requestheader-username-headers", "X-Remote-User",
 	}
 
-	if auditLogEnabled {
-		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/policy.yaml")
-	}
+	flags = append(flags, getAuditFlags(data.Cluster().Spec.AuditLogging)...)
 
 	if endpointReconcilingDisabled {
 		flags = append(flags, "--endpoint-reconciler-type=none")
 	}
 
-	if data.Cluster().Spec.Cloud.GCP != nil {
+	if data.Cluster().IsEncryptionEnabled() {
+		flags = append(flags, "--encryption-provider-config", "/etc/kubernetes/encryption-configuration/"+resources.EncryptionConfigurationSecretKey)
+	}
+
+	if data.Cluster().IsKonnectivityEnabled() {
+		flags = append(flags, konnectivity.APIServerFlags()...)
+	}
+
+	// The konnectivity agents run inside the cluster, so they can reach the kubelets on their internal addresses
+	if data.Cluster().Spec.Cloud.GCP != nil || data.Cluster().IsKonnectivityEnabled() {
 		flags = append(flags, "--kubelet-preferred-address-types", "InternalIP")
 	} else {
 		flags = append(flags, "--kubelet-preferred-address-types", "ExternalIP,InternalIP")
@@ -324,7 +381,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	cloudProviderName := data.GetKubernetesCloudProviderName()
 	if cloudProviderName != "" && cloudProviderName != "external" {
 		flags = append(flags, "--cloud-provider", cloudProviderName)
-		flags = append(flags, "--cloud-config", "/etc/kubernetes/cloud/config")
+		flags = append(flags, "--cloud-config", resources.CloudConfigPath(injector))
 	}
 
 	if data.Cluster().Spec.OIDC.IssuerURL != "" && data.Cluster().Spec.OIDC.ClientID != "" {
@@ -383,7 +440,7 @@ func getVolumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 		},
 		{
-			Name:      resources.CloudConfigConfigMapName,
+			Name:      resources.CloudConfigSeedSecretName,
 			MountPath: "/etc/kubernetes/cloud",
 			ReadOnly:  true,
 		},
@@ -472,12 +529,10 @@ func getVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: resources.CloudConfigConfigMapName,
+			Name: resources.CloudConfigSeedSecretName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.CloudConfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.CloudConfigSeedSecretName,
 				},
 			},
 		},
@@ -538,20 +593,22 @@ type kubeAPIServerEnvData interface {
 	Seed() *kubermaticv1.Seed
 }
 
-func GetEnvVars(data kubeAPIServerEnvData) ([]corev1.EnvVar, error) {
-	credentials, err := resources.GetCredentials(data)
-	if err != nil {
-		return nil, err
-	}
+// GetEnvVars returns the environment variables of the API server, the cloud credentials are read from the
+// files rendered by the injector if it is not nil
+func GetEnvVars(data kubeAPIServerEnvData, injector provider.SecretStoreInjector) []corev1.EnvVar {
 	cluster := data.Cluster()
 
 	var vars []corev1.EnvVar
 	if cluster.Spec.Cloud.AWS != nil {
-		vars = append(vars, corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", Value: credentials.AWS.AccessKeyID})
-		vars = append(vars, corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", Value: credentials.AWS.SecretAccessKey})
+		if injector != nil {
+			vars = append(vars, resources.AWSSharedCredentialsFileEnvVar(injector))
+		} else {
+			vars = append(vars, resources.CloudCredentialEnvVar("AWS_ACCESS_KEY_ID"))
+			vars = append(vars, resources.CloudCredentialEnvVar("AWS_SECRET_ACCESS_KEY"))
+		}
 		vars = append(vars, corev1.EnvVar{Name: "AWS_VPC_ID", Value: cluster.Spec.Cloud.AWS.VPCID})
 	}
-	return append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...), nil
+	return append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...)
 }
 
 func getDexCASecretVolume() corev1.Volume {
@@ -564,3 +621,32 @@ func getDexCASecretVolume() corev1.Volume {
 		},
 	}
 }
+
+func getEncryptionConfigurationVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: resources.EncryptionConfigurationSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: resources.EncryptionConfigurationSecretName,
+				// The keys are part of the configuration, there is no need to mount them separately
+				Items: []corev1.KeyToPath{
+					{
+						Path: resources.EncryptionConfigurationSecretKey,
+						Key:  resources.EncryptionConfigurationSecretKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+func getAuditWebhookConfigVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: resources.AuditWebhookConfigSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: resources.AuditWebhookConfigSecretName,
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+)
+
+// encryptionKeyLength is the key length required by the aescbc and secretbox providers
+const encryptionKeyLength = 32
+
+// The types below mirror the EncryptionConfiguration of k8s.io/apiserver/pkg/apis/config/v1
+type encryptionConfiguration struct {
+	APIVersion string                            `json:"apiVersion"`
+	Kind       string                            `json:"kind"`
+	Resources  []encryptionResourceConfiguration `json:"resources"`
+}
+
+type encryptionResourceConfiguration struct {
+	Resources []string                          `json:"resources"`
+	Providers []encryptionProviderConfiguration `json:"providers"`
+}
+
+type encryptionProviderConfiguration struct {
+	AESCBC    *encryptionKeysConfiguration `json:"aescbc,omitempty"`
+	Secretbox *encryptionKeysConfiguration `json:"secretbox,omitempty"`
+	Identity  *struct{}                    `json:"identity,omitempty"`
+}
+
+type encryptionKeysConfiguration struct {
+	Keys []encryptionKey `json:"keys"`
+}
+
+type encryptionKey struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+// NewEncryptionKey returns a new random key for the aescbc and secretbox encryption providers
+func NewEncryptionKey() ([]byte, error) {
+	key := make([]byte, encryptionKeyLength)
+	if _, err := cryptorand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncryptionConfiguration renders the configuration for the "--encryption-provider-config" flag of the apiserver.
+// The keys are tried in the given order, the first one is used for encrypting Secrets. The key material is
+// looked up by the name of the key in secretData.
+func EncryptionConfiguration(keys []kubermaticv1.ClusterEncryptionKey, secretData map[string][]byte) ([]byte, error) {
+	var providers []encryptionProviderConfiguration
+	for _, key := range keys {
+		if key.Provider == kubermaticv1.ClusterEncryptionProviderIdentity {
+			providers = append(providers, encryptionProviderConfiguration{Identity: &struct{}{}})
+			continue
+		}
+
+		secret, exists := secretData[key.Name]
+		if !exists {
+			return nil, fmt.Errorf("encryption key %q does not exist", key.Name)
+		}
+		keysConfig := &encryptionKeysConfiguration{
+			Keys: []encryptionKey{{Name: key.Name, Secret: base64.StdEncoding.EncodeToString(secret)}},
+		}
+
+		switch key.Provider {
+		case kubermaticv1.ClusterEncryptionProviderAESCBC:
+			providers = append(providers, encryptionProviderConfiguration{AESCBC: keysConfig})
+		case kubermaticv1.ClusterEncryptionProviderSecretbox:
+			providers = append(providers, encryptionProviderConfiguration{Secretbox: keysConfig})
+		default:
+			return nil, fmt.Errorf("unknown encryption provider %q", key.Provider)
+		}
+	}
+
+	return yaml.Marshal(&encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+		Resources: []encryptionResourceConfiguration{{
+			Resources: []string{"secrets"},
+			Providers: providers,
+		}},
+	})
+}
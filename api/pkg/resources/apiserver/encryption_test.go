@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+)
+
+func TestEncryptionConfiguration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		keys           []kubermaticv1.ClusterEncryptionKey
+		expectedConfig string
+		errExpected    bool
+	}{
+		{
+			name: "keys are rendered in the given order",
+			keys: []kubermaticv1.ClusterEncryptionKey{
+				{Name: "key-2", Provider: kubermaticv1.ClusterEncryptionProviderSecretbox},
+				{Name: "key-1", Provider: kubermaticv1.ClusterEncryptionProviderAESCBC},
+				{Provider: kubermaticv1.ClusterEncryptionProviderIdentity},
+			},
+			expectedConfig: `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - secretbox:
+      keys:
+      - name: key-2
+        secret: c2Vjb25k
+  - aescbc:
+      keys:
+      - name: key-1
+        secret: Zmlyc3Q=
+  - identity: {}
+  resources:
+  - secrets
+`,
+		},
+		{
+			name:        "keys must exist",
+			keys:        []kubermaticv1.ClusterEncryptionKey{{Name: "key-3", Provider: kubermaticv1.ClusterEncryptionProviderAESCBC}},
+			errExpected: true,
+		},
+	}
+
+	secretData := map[string][]byte{
+		"key-1": []byte("first"),
+		"key-2": []byte("second"),
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := EncryptionConfiguration(tc.keys, secretData)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("expected error: %t, got: %v", tc.errExpected, err)
+			}
+			if string(config) != tc.expectedConfig {
+				t.Errorf("expected configuration:\n%s\ngot:\n%s", tc.expectedConfig, config)
+			}
+		})
+	}
+}
@@ -35,32 +35,41 @@ func ServiceAccountKeyCreator() reconciling.NamedSecretCreatorGetter {
 			if _, exists := se.Data[resources.ServiceAccountKeySecretKey]; exists {
 				return se, nil
 			}
-			priv, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+			privateKey, publicKey, err := NewServiceAccountKey()
 			if err != nil {
 				return nil, err
 			}
-			saKey := x509.MarshalPKCS1PrivateKey(priv)
-			privKeyBlock := pem.Block{
-				Type:  "RSA PRIVATE KEY",
-				Bytes: saKey,
-			}
-			publicKeyDer, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
-			if err != nil {
-				return nil, err
-			}
-			publicKeyBlock := pem.Block{
-				Type:    "PUBLIC KEY",
-				Headers: nil,
-				Bytes:   publicKeyDer,
-			}
 			if se.Data == nil {
 				se.Data = map[string][]byte{}
 			}
-			se.Data[resources.ServiceAccountKeySecretKey] = pem.EncodeToMemory(&privKeyBlock)
-			se.Data[resources.ServiceAccountKeyPublicKey] = pem.EncodeToMemory(&publicKeyBlock)
+			se.Data[resources.ServiceAccountKeySecretKey] = privateKey
+			se.Data[resources.ServiceAccountKeyPublicKey] = publicKey
 			return se, nil
 
 		}
 	}
 
 }
+
+// NewServiceAccountKey returns a new PEM encoded key for signing ServiceAccount tokens along with its public key
+func NewServiceAccountKey() (privateKey []byte, publicKey []byte, err error) {
+	priv, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	saKey := x509.MarshalPKCS1PrivateKey(priv)
+	privKeyBlock := pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: saKey,
+	}
+	publicKeyDer, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBlock := pem.Block{
+		Type:    "PUBLIC KEY",
+		Headers: nil,
+		Bytes:   publicKeyDer,
+	}
+	return pem.EncodeToMemory(&privKeyBlock), pem.EncodeToMemory(&publicKeyBlock), nil
+}
@@ -34,6 +34,7 @@ import (
 type tlsServingCertCreatorData interface {
 	Cluster() *kubermaticv1.Cluster
 	GetRootCA() (*triple.KeyPair, error)
+	GetRootCACrossSignedCert() ([]byte, error)
 }
 
 // TLSServingCertificateCreator returns a function to create/update the secret with the apiserver tls certificate used to serve https
@@ -48,6 +49,12 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 			if err != nil {
 				return nil, fmt.Errorf("failed to get cluster ca: %v", err)
 			}
+			// During a certificate rotation the CA is served along with the certificate, signed by the
+			// previous CA, so clients that only trust the previous CA can still verify the apiserver
+			crossSignedCA, err := data.GetRootCACrossSignedCert()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cross-signed cluster ca: %v", err)
+			}
 
 			externalIP := data.Cluster().Address.IP
 			if externalIP == "" {
@@ -97,6 +104,7 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 				}
 
 				if resources.IsServerCertificateValidForAllOf(certs[0], "kube-apiserver", altNames, ca.Cert) {
+					se.Data[resources.ApiserverTLSCertSecretKey] = servingCertChain(triple.EncodeCertPEM(certs[0]), crossSignedCA)
 					return se, nil
 				}
 			}
@@ -118,9 +126,17 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 			}
 
 			se.Data[resources.ApiserverTLSKeySecretKey] = triple.EncodePrivateKeyPEM(key)
-			se.Data[resources.ApiserverTLSCertSecretKey] = triple.EncodeCertPEM(cert)
+			se.Data[resources.ApiserverTLSCertSecretKey] = servingCertChain(triple.EncodeCertPEM(cert), crossSignedCA)
 
 			return se, nil
 		}
 	}
 }
+
+// servingCertChain appends the cross-signed CA to the serving certificate, if there is one
+func servingCertChain(cert, crossSignedCA []byte) []byte {
+	if len(crossSignedCA) == 0 {
+		return cert
+	}
+	return append(append([]byte{}, cert...), crossSignedCA...)
+}
@@ -80,3 +80,12 @@ func FrontProxyCACreator() reconciling.NamedSecretCreatorGetter {
 		return resources.FrontProxyCASecretName, GetCACreator("front-proxy-ca")
 	}
 }
+
+// KonnectivityCACreator returns a function to create a secret with the konnectivity ca. It is separate from
+// the root ca, so only the konnectivity agents can register at the konnectivity server and not every client
+// of the apiserver.
+func KonnectivityCACreator() reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.KonnectivityCASecretName, GetCACreator("konnectivity-ca")
+	}
+}
@@ -113,6 +113,11 @@ func NewServerKeyPair(ca *KeyPair, commonName, svcName, svcNamespace, dnsDomain
 }
 
 func NewClientKeyPair(ca *KeyPair, commonName string, organizations []string) (*KeyPair, error) {
+	return NewClientKeyPairWithValidity(ca, commonName, organizations, duration365d)
+}
+
+// NewClientKeyPairWithValidity creates a client key pair whose certificate expires after the given duration
+func NewClientKeyPairWithValidity(ca *KeyPair, commonName string, organizations []string, validity time.Duration) (*KeyPair, error) {
 	key, err := newPrivateKey()
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a client private key: %v", err)
@@ -123,7 +128,7 @@ func NewClientKeyPair(ca *KeyPair, commonName string, organizations []string) (*
 		Organization: organizations,
 		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
-	cert, err := newSignedCert(config, key, ca.Cert, ca.Key)
+	cert, err := newSignedCertWithValidity(config, key, ca.Cert, ca.Key, validity)
 	if err != nil {
 		return nil, fmt.Errorf("unable to sign the client certificate: %v", err)
 	}
@@ -141,6 +146,11 @@ func newPrivateKey() (*rsa.PrivateKey, error) {
 
 // newSignedCert creates a signed certificate using the given CA certificate and key
 func newSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
+	return newSignedCertWithValidity(cfg, key, caCert, caKey, duration365d)
+}
+
+// newSignedCertWithValidity creates a signed certificate which expires after the given duration
+func newSignedCertWithValidity(cfg certutil.Config, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer, validity time.Duration) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -161,7 +171,7 @@ func newSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certific
 		IPAddresses:  cfg.AltNames.IPs,
 		SerialNumber: serial,
 		NotBefore:    caCert.NotBefore,
-		NotAfter:     time.Now().Add(duration365d).UTC(),
+		NotAfter:     time.Now().Add(validity).UTC(),
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  cfg.Usages,
 	}
@@ -24,6 +24,7 @@ import (
 	"net/url"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	gcp "github.com/kubermatic/kubermatic/api/pkg/provider/cloud/gcp"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
@@ -41,37 +42,100 @@ const (
 	name = "cloud-config"
 )
 
-type configMapCreatorData interface {
+type secretCreatorData interface {
 	DC() *kubermaticv1.Datacenter
 	Cluster() *kubermaticv1.Cluster
 	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
 
-// ConfigMapCreator returns a function to create the ConfigMap containing the cloud-config
-func ConfigMapCreator(data configMapCreatorData) reconciling.NamedConfigMapCreatorGetter {
-	return func() (string, reconciling.ConfigMapCreator) {
-		return resources.CloudConfigConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-			if cm.Data == nil {
-				cm.Data = map[string]string{}
+// SecretCreator returns a function to create the Secret containing the cloud-config, it is a Secret
+// because the cloud-config contains the cloud credentials for some providers. Credentials kept in a
+// secret store are left out, the control plane reads the cloud-config injected by CredentialsInjectionAnnotations
+// instead.
+func SecretCreator(data secretCreatorData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.CloudConfigSeedSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
 			}
 
-			credentials, err := resources.GetCredentials(data)
+			injector, err := data.CloudCredentialsInjector()
 			if err != nil {
 				return nil, err
 			}
 
-			cloudConfig, err := CloudConfig(data.Cluster(), data.DC(), credentials)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create cloud-config: %v", err)
+			var cloudConfig string
+			if injector == nil {
+				credentials, err := resources.GetCredentials(data)
+				if err != nil {
+					return nil, err
+				}
+				if cloudConfig, err = CloudConfig(data.Cluster(), data.DC(), credentials); err != nil {
+					return nil, fmt.Errorf("failed to create cloud-config: %v", err)
+				}
+			} else {
+				credentials, err := templatedCredentials(data, injector)
+				if err != nil {
+					return nil, err
+				}
+				if cloudConfig, err = CloudConfig(data.Cluster(), data.DC(), credentials.Credentials); err != nil {
+					return nil, fmt.Errorf("failed to create cloud-config: %v", err)
+				}
+				cloudConfig = credentials.Strip(cloudConfig)
 			}
 
-			cm.Labels = resources.BaseAppLabels(name, nil)
-			cm.Data[resources.CloudConfigConfigMapKey] = cloudConfig
-			cm.Data[FakeVMWareUUIDKeyName] = fakeVMWareUUID
+			se.Labels = resources.BaseAppLabels(name, nil)
+			se.Type = corev1.SecretTypeOpaque
+			se.Data[resources.CloudConfigSeedSecretKey] = []byte(cloudConfig)
+			se.Data[FakeVMWareUUIDKeyName] = []byte(fakeVMWareUUID)
+
+			return se, nil
+		}
+	}
+}
+
+// CredentialsInjectionAnnotations returns the pod annotations which make the secret store the cloud credentials
+// of the cluster are kept in render them and the cloud-config into files of the pod, see
+// resources.CloudCredentialsTemplates. It returns nil if the credentials are kept in Kubernetes.
+func CredentialsInjectionAnnotations(data secretCreatorData) (map[string]string, error) {
+	injector, err := data.CloudCredentialsInjector()
+	if err != nil || injector == nil {
+		return nil, err
+	}
+
+	templates, err := resources.CloudCredentialsTemplates(data, injector)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := templatedCredentials(data, injector)
+	if err != nil {
+		return nil, err
+	}
+	cloudConfig, err := CloudConfig(data.Cluster(), data.DC(), credentials.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud-config: %v", err)
+	}
+	templates[resources.CloudConfigFileName] = credentials.Template(cloudConfig)
+
+	return injector.PodAnnotations(resources.CloudCredentialsReference(data.Cluster().Spec.Cloud).Name, templates), nil
+}
 
-			return cm, nil
+// templatedCredentials returns the credentials to render the cloud-config with when they are kept in a secret store
+func templatedCredentials(data secretCreatorData, injector provider.SecretStoreInjector) (*resources.TemplatedCredentials, error) {
+	// All cloud-configs quote their values the same way
+	credentials, err := resources.GetTemplatedCredentials(data, injector, `\`, `\\`, `"`, `\"`)
+	if err != nil {
+		return nil, err
+	}
+	if data.Cluster().Spec.Cloud.GCP != nil {
+		// The GCP cloud-config holds no credentials but the project of the service account, it is read
+		// from the secret store directly
+		if credentials.GCP, err = resources.GetGCPCredentials(data); err != nil {
+			return nil, err
 		}
 	}
+	return credentials, nil
 }
 
 // CloudConfig returns the cloud-config for the supplied data
@@ -271,7 +335,7 @@ func getVsphereCloudConfig(
 }
 
 const (
-	// FakeVMWareUUIDKeyName is the name of the cloud-config configmap key
+	// FakeVMWareUUIDKeyName is the name of the cloud-config secret key
 	// that holds the fake vmware uuid
 	// It is required when activating the vsphere cloud-provider in the controller
 	// manager on a non-ESXi host
@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/semver"
+	vsphere "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/vsphere/types"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetVsphereCloudConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cluster *kubermaticv1.Cluster
+		dc      *kubermaticv1.Datacenter
+		verify  func(*vsphere.CloudConfig) error
+	}{
+		{
+			name: "Vsphere port gets defaulted to 443",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						VSphere: &kubermaticv1.VSphereCloudSpec{},
+					},
+				},
+			},
+			dc: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					VSphere: &kubermaticv1.DatacenterSpecVSphere{
+						Endpoint: "https://vsphere.com",
+					},
+				},
+			},
+			verify: func(cc *vsphere.CloudConfig) error {
+				if cc.Global.VCenterPort != "443" {
+					return fmt.Errorf("Expected port to be 443, was %q", cc.Global.VCenterPort)
+				}
+				return nil
+			},
+		},
+		{
+			name: "Vsphere port from url gets used",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{
+						VSphere: &kubermaticv1.VSphereCloudSpec{},
+					},
+				},
+			},
+			dc: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					VSphere: &kubermaticv1.DatacenterSpecVSphere{
+						Endpoint: "https://vsphere.com:9443",
+					},
+				},
+			},
+			verify: func(cc *vsphere.CloudConfig) error {
+				if cc.Global.VCenterPort != "9443" {
+					return fmt.Errorf("Expected port to be 9443, was %q", cc.Global.VCenterPort)
+				}
+				return nil
+			},
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			cloudConfig, err := getVsphereCloudConfig(tc.cluster, tc.dc, resources.Credentials{})
+			if err != nil {
+				t.Fatalf("Error trying to get cloudconfig: %v", err)
+			}
+			if err := tc.verify(cloudConfig); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// fakeInjector renders values as <path:key> so the templates can be checked
+type fakeInjector struct{}
+
+func (fakeInjector) ValueTemplate(path, key string, escapes ...string) string {
+	return fmt.Sprintf("<%s:%s%s>", path, key, strings.Join(escapes, ""))
+}
+
+func (fakeInjector) DecodedValueTemplate(path, key string) string {
+	return fmt.Sprintf("<decoded %s:%s>", path, key)
+}
+
+func (fakeInjector) PodAnnotations(path string, templates map[string]string) map[string]string {
+	annotations := map[string]string{"path": path}
+	for name, template := range templates {
+		annotations[name] = template
+	}
+	return annotations
+}
+
+func (fakeInjector) FilePath(name string) string {
+	return "/injected/" + name
+}
+
+type fakeSecretCreatorData struct {
+	cluster  *kubermaticv1.Cluster
+	dc       *kubermaticv1.Datacenter
+	injector provider.SecretStoreInjector
+}
+
+func (f *fakeSecretCreatorData) DC() *kubermaticv1.Datacenter {
+	return f.dc
+}
+
+func (f *fakeSecretCreatorData) Cluster() *kubermaticv1.Cluster {
+	return f.cluster
+}
+
+func (f *fakeSecretCreatorData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+	return "", errors.New("credentials kept in a secret store must not be read")
+}
+
+func (f *fakeSecretCreatorData) CloudCredentialsInjector() (provider.SecretStoreInjector, error) {
+	return f.injector, nil
+}
+
+func TestSecretStoreCredentials(t *testing.T) {
+	data := &fakeSecretCreatorData{
+		cluster: &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				Version: *semver.NewSemverOrDie("1.17.0"),
+				Cloud: kubermaticv1.CloudSpec{
+					Openstack: &kubermaticv1.OpenstackCloudSpec{
+						CredentialsReference: &providerconfig.GlobalSecretKeySelector{
+							ObjectReference: corev1.ObjectReference{Kind: "VaultSecret", Name: "secret/data/cluster-abcd"},
+						},
+					},
+				},
+			},
+		},
+		dc: &kubermaticv1.Datacenter{
+			Spec: kubermaticv1.DatacenterSpec{
+				Openstack: &kubermaticv1.DatacenterSpecOpenstack{AuthURL: "https://keystone.example.com"},
+			},
+		},
+		injector: fakeInjector{},
+	}
+
+	_, creator := SecretCreator(data)()
+	secret, err := creator(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("failed to create the cloud-config secret: %v", err)
+	}
+	cloudConfig := string(secret.Data[resources.CloudConfigSeedSecretKey])
+	if !strings.Contains(cloudConfig, `password    = ""`) || !strings.Contains(cloudConfig, "https://keystone.example.com") {
+		t.Errorf("expected the cloud-config secret to hold the cloud-config without credentials, got\n%s", cloudConfig)
+	}
+
+	annotations, err := CredentialsInjectionAnnotations(data)
+	if err != nil {
+		t.Fatalf("failed to get the injection annotations: %v", err)
+	}
+	if annotations["path"] != "secret/data/cluster-abcd" {
+		t.Errorf("expected the credentials to be injected from secret/data/cluster-abcd, got %q", annotations["path"])
+	}
+	injectedConfig := annotations[resources.CloudConfigFileName]
+	if !strings.Contains(injectedConfig, `password    = "<secret/data/cluster-abcd:password\\\"\">"`) {
+		t.Errorf("expected the injected cloud-config to render the escaped password, got\n%s", injectedConfig)
+	}
+	env := annotations[resources.CloudCredentialsEnvFileName]
+	if !strings.Contains(env, `export OS_USER_NAME='<secret/data/cluster-abcd:username''\''>'`) {
+		t.Errorf("expected the injected environment to export the escaped user name, got\n%s", env)
+	}
+
+	data.injector = nil
+	if annotations, err := CredentialsInjectionAnnotations(data); err != nil || annotations != nil {
+		t.Errorf("expected no annotations for credentials kept in Kubernetes, got %v (%v)", annotations, err)
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/vpnsidecar"
 	"github.com/kubermatic/kubermatic/api/pkg/semver"
@@ -61,14 +63,26 @@ func openStackDeploymentCreator(data *resources.TemplateData) reconciling.NamedD
 			}
 
 			dep.Spec.Template.Spec.Volumes = getOSVolumes()
+			if data.Cluster().IsKonnectivityEnabled() {
+				dep.Spec.Template.Spec.Volumes = vpnsidecar.WithoutSidecarVolumes(dep.Spec.Template.Spec.Volumes)
+			}
 
 			podLabels, err := data.GetPodTemplateLabels(osName, dep.Spec.Template.Spec.Volumes, nil)
 			if err != nil {
 				return nil, err
 			}
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
 
 			dep.Spec.Template.ObjectMeta = metav1.ObjectMeta{
-				Labels: podLabels,
+				Labels:      podLabels,
+				Annotations: injectionAnnotations,
 			}
 
 			dep.Spec.Template.Spec.DNSPolicy, dep.Spec.Template.Spec.DNSConfig, err =
@@ -77,10 +91,11 @@ func openStackDeploymentCreator(data *resources.TemplateData) reconciling.NamedD
 				return nil, err
 			}
 
-			f := false
-			dep.Spec.Template.Spec.AutomountServiceAccountToken = &f
+			// The secret store authenticates the injection with the service account token of the pod
+			automountToken := injector != nil
+			dep.Spec.Template.Spec.AutomountServiceAccountToken = &automountToken
 
-			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+			sidecars, err := vpnsidecar.OpenVPNSidecarContainers(data, "openvpn-client")
 			if err != nil {
 				return nil, fmt.Errorf("failed to get openvpn sidecar: %v", err)
 			}
@@ -92,7 +107,7 @@ func openStackDeploymentCreator(data *resources.TemplateData) reconciling.NamedD
 					ReadOnly:  true,
 				},
 				{
-					Name:      resources.CloudConfigConfigMapName,
+					Name:      resources.CloudConfigSeedSecretName,
 					MountPath: "/etc/kubernetes/cloud",
 					ReadOnly:  true,
 				},
@@ -102,21 +117,22 @@ func openStackDeploymentCreator(data *resources.TemplateData) reconciling.NamedD
 			if err != nil {
 				return nil, err
 			}
-			flags := getOSFlags(data)
+			flags := getOSFlags(injector)
 
-			dep.Spec.Template.Spec.Containers = []corev1.Container{
-				*openvpnSidecar,
-				{
+			dep.Spec.Template.Spec.Containers = append(sidecars,
+				corev1.Container{
 					Name:         osName,
 					Image:        data.ImageRegistry(resources.RegistryDocker) + "/k8scloudprovider/openstack-cloud-controller-manager:v" + version,
 					Command:      []string{"/bin/openstack-cloud-controller-manager"},
 					Args:         flags,
 					VolumeMounts: osCloudProviderMounts,
 				},
-			}
+			)
 			defResourceRequirements := map[string]*corev1.ResourceRequirements{
-				osName:              osResourceRequirements.DeepCopy(),
-				openvpnSidecar.Name: openvpnSidecar.Resources.DeepCopy(),
+				osName: osResourceRequirements.DeepCopy(),
+			}
+			for _, sidecar := range sidecars {
+				defResourceRequirements[sidecar.Name] = sidecar.Resources.DeepCopy()
 			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, nil, dep.Annotations)
 			if err != nil {
@@ -131,12 +147,10 @@ func openStackDeploymentCreator(data *resources.TemplateData) reconciling.NamedD
 func getOSVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
-			Name: resources.CloudConfigConfigMapName,
+			Name: resources.CloudConfigSeedSecretName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.CloudConfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.CloudConfigSeedSecretName,
 				},
 			},
 		},
@@ -159,11 +173,11 @@ func getOSVolumes() []corev1.Volume {
 	}
 }
 
-func getOSFlags(data *resources.TemplateData) []string {
+func getOSFlags(injector provider.SecretStoreInjector) []string {
 	flags := []string{
 		"--kubeconfig=/etc/kubernetes/kubeconfig/kubeconfig",
 		"--v=1",
-		"--cloud-config=/etc/kubernetes/cloud/config",
+		"--cloud-config=" + resources.CloudConfigPath(injector),
 		"--cloud-provider=openstack",
 	}
 	return flags
@@ -0,0 +1,221 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Cloud credentials kept in a secret store never get copied into the seed. The secret store renders them
+// into the following files of the control plane pods at runtime instead, see CloudCredentialsInjector.
+const (
+	// CloudCredentialsEnvFileName is the name of the file the cloud credentials get rendered into as
+	// shell variable exports, named like the environment variables of the cloud credentials secret
+	CloudCredentialsEnvFileName = "cloud-credentials"
+	// AWSSharedCredentialsFileName is the name of the file the AWS credentials get rendered into as
+	// AWS shared credentials file
+	AWSSharedCredentialsFileName = "aws-credentials"
+	// GCPServiceAccountFileName is the name of the file the decoded GCP service account gets rendered into
+	GCPServiceAccountFileName = "gcp-service-account"
+	// CloudConfigFileName is the name of the file the cloud-config including the credentials gets rendered into
+	CloudConfigFileName = "cloud-config"
+)
+
+// CloudCredentialsReference returns the reference to the cloud credentials of the cluster, it is nil
+// if the credentials are set in the cloud spec directly
+func CloudCredentialsReference(cloud kubermaticv1.CloudSpec) *providerconfig.GlobalSecretKeySelector {
+	switch {
+	case cloud.AWS != nil:
+		return cloud.AWS.CredentialsReference
+	case cloud.Azure != nil:
+		return cloud.Azure.CredentialsReference
+	case cloud.Digitalocean != nil:
+		return cloud.Digitalocean.CredentialsReference
+	case cloud.GCP != nil:
+		return cloud.GCP.CredentialsReference
+	case cloud.Hetzner != nil:
+		return cloud.Hetzner.CredentialsReference
+	case cloud.Openstack != nil:
+		return cloud.Openstack.CredentialsReference
+	case cloud.Packet != nil:
+		return cloud.Packet.CredentialsReference
+	case cloud.Kubevirt != nil:
+		return cloud.Kubevirt.CredentialsReference
+	case cloud.VSphere != nil:
+		return cloud.VSphere.CredentialsReference
+	case cloud.Alibaba != nil:
+		return cloud.Alibaba.CredentialsReference
+	}
+	return nil
+}
+
+// CloudCredentialsInjector returns the injector of the secret store the cloud credentials of the cluster
+// are kept in. It is nil if they are kept in Kubernetes, the control plane then reads them from the cloud
+// credentials secret. Credentials of a secret store which can not inject them are refused, they would end
+// up in the seed otherwise.
+func CloudCredentialsInjector(cluster *kubermaticv1.Cluster, secretStores *provider.SecretStores) (provider.SecretStoreInjector, error) {
+	ref := CloudCredentialsReference(cluster.Spec.Cloud)
+	if ref == nil || !provider.IsSecretStoreKind(ref.Kind) {
+		return nil, nil
+	}
+	injector := secretStores.Injector(ref.Kind)
+	if injector == nil {
+		return nil, fmt.Errorf("the secret store for %q can not inject the cloud credentials into the control plane", ref.Kind)
+	}
+	return injector, nil
+}
+
+// TemplatedCredentials are the credentials of a cluster whose values kept in a secret store are replaced
+// by placeholders. Once the credentials got rendered into a file, Template replaces the placeholders by
+// the templates the secret store renders into the actual values.
+type TemplatedCredentials struct {
+	Credentials
+
+	templates    []string
+	placeholders []string
+}
+
+// GetTemplatedCredentials returns the credentials of the cluster with all values kept in a secret store
+// replaced by placeholders. The values get escaped with the given old, new string pairs when rendered.
+func GetTemplatedCredentials(data CredentialsData, injector provider.SecretStoreInjector, escapes ...string) (*TemplatedCredentials, error) {
+	return getTemplatedCredentials(data, func(path, key string) string {
+		return injector.ValueTemplate(path, key, escapes...)
+	})
+}
+
+func getTemplatedCredentials(data CredentialsData, valueTemplate func(path, key string) string) (*TemplatedCredentials, error) {
+	templated := &TemplatedCredentials{}
+	credentials, err := GetCredentials(&templatedCredentialsData{
+		CredentialsData: data,
+		value: func(configVar *providerconfig.GlobalSecretKeySelector, key string) string {
+			placeholder := fmt.Sprintf("__secret_store_value_%d__", len(templated.templates)/2)
+			templated.templates = append(templated.templates, placeholder, valueTemplate(configVar.Name, key))
+			templated.placeholders = append(templated.placeholders, placeholder, "")
+			return placeholder
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	templated.Credentials = credentials
+	return templated, nil
+}
+
+// Template replaces the placeholders in the text by the templates of the values
+func (c *TemplatedCredentials) Template(text string) string {
+	return strings.NewReplacer(c.templates...).Replace(text)
+}
+
+// Strip removes the placeholders from the text, leaving the values kept in the secret store empty
+func (c *TemplatedCredentials) Strip(text string) string {
+	return strings.NewReplacer(c.placeholders...).Replace(text)
+}
+
+// templatedCredentialsData returns the value of the given function instead of reading it from the secret store
+type templatedCredentialsData struct {
+	CredentialsData
+	value func(configVar *providerconfig.GlobalSecretKeySelector, key string) string
+}
+
+func (d *templatedCredentialsData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+	if configVar != nil && provider.IsSecretStoreKind(configVar.Kind) && configVar.Name != "" && key != "" {
+		return d.value(configVar, key), nil
+	}
+	return d.CredentialsData.GetGlobalSecretKeySelectorValue(configVar, key)
+}
+
+// CloudCredentialsTemplates returns the templates of the files the cloud credentials get rendered into,
+// keyed by file name
+func CloudCredentialsTemplates(data CredentialsData, injector provider.SecretStoreInjector) (map[string]string, error) {
+	// The exports are single quoted, so only single quotes need to be escaped. Values not kept in the
+	// secret store get escaped right away, the placeholders contain none.
+	env, err := GetTemplatedCredentials(data, injector, `'`, `'\''`)
+	if err != nil {
+		return nil, err
+	}
+	vars := cloudCredentialsEnv(data.Cluster().Spec.Cloud, env.Credentials)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	exports := ""
+	for _, name := range names {
+		exports += fmt.Sprintf("export %s='%s'\n", name, strings.ReplaceAll(vars[name], "'", `'\''`))
+	}
+	templates := map[string]string{CloudCredentialsEnvFileName: env.Template(exports)}
+
+	if data.Cluster().Spec.Cloud.AWS != nil {
+		aws, err := GetTemplatedCredentials(data, injector)
+		if err != nil {
+			return nil, err
+		}
+		templates[AWSSharedCredentialsFileName] = aws.Template(fmt.Sprintf("[default]\naws_access_key_id = %s\naws_secret_access_key = %s\n",
+			aws.AWS.AccessKeyID, aws.AWS.SecretAccessKey))
+	}
+
+	if spec := data.Cluster().Spec.Cloud.GCP; spec != nil {
+		serviceAccount := spec.ServiceAccount
+		if serviceAccount != "" {
+			b, err := base64.StdEncoding.DecodeString(serviceAccount)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding service account: %v", err)
+			}
+			serviceAccount = string(b)
+		} else {
+			gcp, err := getTemplatedCredentials(data, injector.DecodedValueTemplate)
+			if err != nil {
+				return nil, err
+			}
+			serviceAccount = gcp.Template(gcp.GCP.ServiceAccount)
+		}
+		templates[GCPServiceAccountFileName] = serviceAccount
+	}
+
+	return templates, nil
+}
+
+// CloudCredentialsEnvCommand returns the command which runs the given command with the environment
+// variables of the injected cloud credentials set
+func CloudCredentialsEnvCommand(injector provider.SecretStoreInjector, command ...string) []string {
+	script := fmt.Sprintf(`. %s && exec "$@"`, injector.FilePath(CloudCredentialsEnvFileName))
+	return append([]string{"/bin/sh", "-c", script, "sh"}, command...)
+}
+
+// AWSSharedCredentialsFileEnvVar returns the environment variable which makes the AWS SDK read the
+// injected AWS credentials
+func AWSSharedCredentialsFileEnvVar(injector provider.SecretStoreInjector) corev1.EnvVar {
+	return corev1.EnvVar{Name: "AWS_SHARED_CREDENTIALS_FILE", Value: injector.FilePath(AWSSharedCredentialsFileName)}
+}
+
+// CloudConfigPath returns the path the control plane components read the cloud-config from, it is the
+// injected one if the cloud credentials are kept in a secret store
+func CloudConfigPath(injector provider.SecretStoreInjector) string {
+	if injector != nil {
+		return injector.FilePath(CloudConfigFileName)
+	}
+	return "/etc/kubernetes/cloud/config"
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CloudCredentialsSecretCreator returns a creator function for the secret the control plane components
+// read the cloud credentials of the cluster from. The keys of the secret are the names of the
+// environment variables the credentials get passed in, see CloudCredentialEnvVar. Credentials kept in
+// a secret store never end up in it, they get injected into the pods, see CloudCredentialsInjector.
+func CloudCredentialsSecretCreator(data CredentialsData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return CloudCredentialsSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			credentials, err := GetCredentials(data)
+			if err != nil {
+				return nil, err
+			}
+
+			se.Type = corev1.SecretTypeOpaque
+			// The data is replaced as a whole so no credentials of a previous provider remain
+			se.Data = map[string][]byte{}
+			for key, value := range cloudCredentialsEnv(data.Cluster().Spec.Cloud, credentials) {
+				se.Data[key] = []byte(value)
+			}
+
+			return se, nil
+		}
+	}
+}
+
+func cloudCredentialsEnv(cloud kubermaticv1.CloudSpec, credentials Credentials) map[string]string {
+	switch {
+	case cloud.AWS != nil:
+		return map[string]string{
+			"AWS_ACCESS_KEY_ID":     credentials.AWS.AccessKeyID,
+			"AWS_SECRET_ACCESS_KEY": credentials.AWS.SecretAccessKey,
+		}
+	case cloud.Azure != nil:
+		return map[string]string{
+			"AZURE_CLIENT_ID":       credentials.Azure.ClientID,
+			"AZURE_CLIENT_SECRET":   credentials.Azure.ClientSecret,
+			"AZURE_TENANT_ID":       credentials.Azure.TenantID,
+			"AZURE_SUBSCRIPTION_ID": credentials.Azure.SubscriptionID,
+		}
+	case cloud.Openstack != nil:
+		return map[string]string{
+			"OS_USER_NAME":   credentials.Openstack.Username,
+			"OS_PASSWORD":    credentials.Openstack.Password,
+			"OS_DOMAIN_NAME": credentials.Openstack.Domain,
+			"OS_TENANT_NAME": credentials.Openstack.Tenant,
+			"OS_TENANT_ID":   credentials.Openstack.TenantID,
+		}
+	case cloud.Hetzner != nil:
+		return map[string]string{"HZ_TOKEN": credentials.Hetzner.Token}
+	case cloud.Digitalocean != nil:
+		return map[string]string{"DO_TOKEN": credentials.Digitalocean.Token}
+	case cloud.VSphere != nil:
+		return map[string]string{
+			"VSPHERE_USERNAME": credentials.VSphere.Username,
+			"VSPHERE_PASSWORD": credentials.VSphere.Password,
+		}
+	case cloud.Packet != nil:
+		return map[string]string{
+			"PACKET_API_KEY":    credentials.Packet.APIKey,
+			"PACKET_PROJECT_ID": credentials.Packet.ProjectID,
+		}
+	case cloud.GCP != nil:
+		return map[string]string{"GOOGLE_SERVICE_ACCOUNT": credentials.GCP.ServiceAccount}
+	case cloud.Kubevirt != nil:
+		return map[string]string{"KUBEVIRT_KUBECONFIG": credentials.Kubevirt.KubeConfig}
+	case cloud.Alibaba != nil:
+		return map[string]string{
+			"ALIBABA_ACCESS_KEY_ID":     credentials.Alibaba.AccessKeyID,
+			"ALIBABA_ACCESS_KEY_SECRET": credentials.Alibaba.AccessKeySecret,
+		}
+	}
+	return nil
+}
+
+// CloudCredentialEnvVar returns the environment variable of the given name, its value is read from
+// the cloud credentials secret.
+func CloudCredentialEnvVar(name string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: CloudCredentialsSecretName},
+				Key:                  name,
+			},
+		},
+	}
+}
+
+// CloudCredentialsRevisionVolume returns a volume of the cloud credentials secret. It is not meant to
+// be mounted, passing it to GetPodTemplateLabels restarts pods which read the credentials from
+// environment variables when the credentials change.
+func CloudCredentialsRevisionVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: CloudCredentialsSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: CloudCredentialsSecretName,
+			},
+		},
+	}
+}
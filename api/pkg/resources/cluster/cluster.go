@@ -42,7 +42,14 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		AuditLogging:                        apiCluster.Spec.AuditLogging,
 		Openshift:                           apiCluster.Spec.Openshift,
 		AdmissionPlugins:                    apiCluster.Spec.AdmissionPlugins,
+		Hibernation:                         apiCluster.Spec.Hibernation,
+		ClusterAutoscaler:                   apiCluster.Spec.ClusterAutoscaler,
+		DeletionProtection:                  apiCluster.Spec.DeletionProtection,
+		FinalBackup:                         apiCluster.Spec.FinalBackup,
+		Encryption:                          apiCluster.Spec.Encryption,
+		EventExport:                         apiCluster.Spec.EventExport,
 	}
+	spec.ClusterNetwork.TunnelMode = apiCluster.Spec.TunnelMode
 
 	providerName, err := provider.ClusterCloudProviderName(spec.Cloud)
 	if err != nil {
@@ -60,5 +67,10 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		return nil, err
 	}
 
-	return spec, validation.ValidateCreateClusterSpec(spec, dc, cloudProvider)
+	if err := validation.ValidateCreateClusterSpec(spec, dc, cloudProvider); err != nil {
+		return spec, err
+	}
+
+	// Refuse clusters whose credentials would only fail once the cloud provider gets initialized
+	return spec, validation.ValidateCloudCredentials(cloudProvider, spec.Cloud)
 }
@@ -102,17 +102,7 @@ func DeploymentCreator(data clusterautoscalerData) reconciling.NamedDeploymentCr
 					Name:    resources.ClusterAutoscalerDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryQuay) + "/kubermatic/kubernetes-cluster-autoscaler:" + tag,
 					Command: []string{"/cluster-autoscaler"},
-					Args: []string{
-						"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
-						"--leader-elect-resource-lock", "configmaps",
-						// PercentageUsed treshold. If the current utilization of a node is above this, the CA will never
-						// scale it down. Default is 0.5. Increased, because otherwise small nodes never get scaled down
-						// because the DS pods on them alone manage to get the utilization above the 0.5 threshold.
-						"--scale-down-utilization-threshold", "0.7",
-						// For debugging you can add the following to increase verbosity and make scale down kick in without
-						// delay:
-						// -v=4 --scale-down-delay-after-failure=1s --scale-down-delay-after-add=1s
-					},
+					Args:    getFlags(data.Cluster().Spec.ClusterAutoscaler),
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
@@ -155,6 +145,38 @@ func DeploymentCreator(data clusterautoscalerData) reconciling.NamedDeploymentCr
 	}
 }
 
+func getFlags(settings *kubermaticv1.ClusterAutoscalerSettings) []string {
+	// PercentageUsed treshold. If the current utilization of a node is above this, the CA will never
+	// scale it down. Default is 0.5. Increased, because otherwise small nodes never get scaled down
+	// because the DS pods on them alone manage to get the utilization above the 0.5 threshold.
+	utilizationThreshold := "0.7"
+	if settings != nil && settings.ScaleDownUtilizationThreshold != "" {
+		utilizationThreshold = settings.ScaleDownUtilizationThreshold
+	}
+
+	flags := []string{
+		"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
+		"--leader-elect-resource-lock", "configmaps",
+		"--scale-down-utilization-threshold", utilizationThreshold,
+		// For debugging you can add the following to increase verbosity and make scale down kick in without
+		// delay:
+		// -v=4 --scale-down-delay-after-failure=1s --scale-down-delay-after-add=1s
+	}
+	if settings == nil {
+		return flags
+	}
+	if settings.ScaleDownDisabled {
+		flags = append(flags, "--scale-down-enabled=false")
+	}
+	if settings.ScaleDownUnneededTime != nil {
+		flags = append(flags, "--scale-down-unneeded-time", settings.ScaleDownUnneededTime.Duration.String())
+	}
+	if settings.ScaleDownDelayAfterAdd != nil {
+		flags = append(flags, "--scale-down-delay-after-add", settings.ScaleDownDelayAfterAdd.Duration.String())
+	}
+	return flags
+}
+
 // getTag returns the correct tag for the cluster version. We need to have a distinct CA
 // version for each Kubernetes version, because the CA imports the scheduler code and the
 // behaviour of that imported code has to match with what the actual scheduler does
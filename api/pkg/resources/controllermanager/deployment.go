@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
@@ -60,7 +61,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			dep.Name = resources.ControllerManagerDeploymentName
 			dep.Labels = resources.BaseAppLabels(name, nil)
 
-			flags, err := getFlags(data)
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+
+			flags, err := getFlags(data, injector)
 			if err != nil {
 				return nil, err
 			}
@@ -76,7 +82,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
 			volumes := getVolumes()
-			if data.Cluster().Spec.Cloud.GCP != nil {
+			if data.Cluster().IsKonnectivityEnabled() {
+				volumes = vpnsidecar.WithoutSidecarVolumes(volumes)
+			}
+			// An injected service account is rendered into the pod instead
+			mountServiceAccount := data.Cluster().Spec.Cloud.GCP != nil && injector == nil
+			if mountServiceAccount {
 				serviceAccountVolume := corev1.Volume{
 					Name: resources.GoogleServiceAccountVolumeName,
 					VolumeSource: corev1.VolumeSource{
@@ -88,7 +99,11 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				volumes = append(volumes, serviceAccountVolume)
 			}
 
-			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
+			revisionVolumes := volumes
+			if injector == nil {
+				revisionVolumes = append(volumes, resources.CloudCredentialsRevisionVolume())
+			}
+			podLabels, err := data.GetPodTemplateLabels(name, revisionVolumes, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -101,6 +116,13 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 					"prometheus.io/port":                  "10257",
 				},
 			}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range injectionAnnotations {
+				dep.Spec.Template.Annotations[k] = v
+			}
 
 			// Configure user cluster DNS resolver for this pod.
 			dep.Spec.Template.Spec.DNSPolicy, dep.Spec.Template.Spec.DNSConfig, err = resources.UserClusterDNSPolicyAndConfig(data)
@@ -110,7 +132,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 
 			dep.Spec.Template.Spec.Volumes = volumes
 
-			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+			sidecars, err := vpnsidecar.OpenVPNSidecarContainers(data, "openvpn-client")
 			if err != nil {
 				return nil, fmt.Errorf("failed to get openvpn sidecar: %v", err)
 			}
@@ -127,7 +149,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 					ReadOnly:  true,
 				},
 				{
-					Name:      resources.CloudConfigConfigMapName,
+					Name:      resources.CloudConfigSeedSecretName,
 					MountPath: "/etc/kubernetes/cloud",
 					ReadOnly:  true,
 				},
@@ -139,7 +161,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			}
 			if data.Cluster().Spec.Cloud.VSphere != nil {
 				fakeVMWareUUIDMount := corev1.VolumeMount{
-					Name:      resources.CloudConfigConfigMapName,
+					Name:      resources.CloudConfigSeedSecretName,
 					SubPath:   cloudconfig.FakeVMWareUUIDKeyName,
 					MountPath: "/sys/class/dmi/id/product_serial",
 					ReadOnly:  true,
@@ -147,7 +169,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				// Required because of https://github.com/kubernetes/kubernetes/issues/65145
 				controllerManagerMounts = append(controllerManagerMounts, fakeVMWareUUIDMount)
 			}
-			if data.Cluster().Spec.Cloud.GCP != nil {
+			if mountServiceAccount {
 				serviceAccountMount := corev1.VolumeMount{
 					Name:      resources.GoogleServiceAccountVolumeName,
 					MountPath: "/etc/gcp",
@@ -156,25 +178,19 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				controllerManagerMounts = append(controllerManagerMounts, serviceAccountMount)
 			}
 
-			envVars, err := GetEnvVars(data)
-			if err != nil {
-				return nil, err
-			}
-
 			healthAction := &corev1.HTTPGetAction{
 				Path:   "/healthz",
 				Scheme: corev1.URISchemeHTTPS,
 				Port:   intstr.FromInt(10257),
 			}
 
-			dep.Spec.Template.Spec.Containers = []corev1.Container{
-				*openvpnSidecar,
-				{
+			dep.Spec.Template.Spec.Containers = append(sidecars,
+				corev1.Container{
 					Name:    resources.ControllerManagerDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
 					Command: []string{"/hyperkube", "kube-controller-manager"},
 					Args:    flags,
-					Env:     envVars,
+					Env:     GetEnvVars(data, injector),
 					ReadinessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: healthAction,
@@ -196,10 +212,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 					},
 					VolumeMounts: controllerManagerMounts,
 				},
-			}
+			)
 			defResourceRequirements := map[string]*corev1.ResourceRequirements{
-				name:                defaultResourceRequirements.DeepCopy(),
-				openvpnSidecar.Name: openvpnSidecar.Resources.DeepCopy(),
+				name: defaultResourceRequirements.DeepCopy(),
+			}
+			for _, sidecar := range sidecars {
+				defResourceRequirements[sidecar.Name] = sidecar.Resources.DeepCopy()
 			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
@@ -219,11 +237,18 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 	}
 }
 
-func getFlags(data *resources.TemplateData) ([]string, error) {
+func getFlags(data *resources.TemplateData, injector provider.SecretStoreInjector) ([]string, error) {
+	// During a certificate rotation the old and the new CA are trusted. Signing always uses the
+	// current CA, as the signer only accepts a single certificate.
+	trustedCAFile := "/etc/kubernetes/pki/ca/" + resources.CACertSecretKey
+	if data.Cluster().IsCertificateRotationInProgress() {
+		trustedCAFile = "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey
+	}
+
 	flags := []string{
 		"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 		"--service-account-private-key-file", "/etc/kubernetes/service-account-key/sa.key",
-		"--root-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+		"--root-ca-file", trustedCAFile,
 		"--cluster-signing-cert-file", "/etc/kubernetes/pki/ca/ca.crt",
 		"--cluster-signing-key-file", "/etc/kubernetes/pki/ca/ca.key",
 		"--cluster-cidr", data.Cluster().Spec.ClusterNetwork.Pods.CIDRBlocks[0],
@@ -241,7 +266,7 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	cloudProviderName := data.GetKubernetesCloudProviderName()
 	if cloudProviderName != "" && cloudProviderName != "external" {
 		flags = append(flags, "--cloud-provider", cloudProviderName)
-		flags = append(flags, "--cloud-config", "/etc/kubernetes/cloud/config")
+		flags = append(flags, "--cloud-config", resources.CloudConfigPath(injector))
 		if cloudProviderName == "azure" && data.Cluster().Spec.Version.Semver().Minor() >= 15 {
 			// Required so multiple clusters using the same resource group can allocate public IPs.
 			// Ref: https://github.com/kubernetes/kubernetes/pull/77630
@@ -256,7 +281,7 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	// New flag in v1.12 which gets used to perform permission checks for tokens
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
 	// New flag in v1.12 which gets used to perform permission checks for certs
-	flags = append(flags, "--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt")
+	flags = append(flags, "--client-ca-file", trustedCAFile)
 
 	// With 1.13 we're using the secure port for scraping metrics as the insecure port got marked deprecated
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
@@ -288,12 +313,10 @@ func getVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: resources.CloudConfigConfigMapName,
+			Name: resources.CloudConfigSeedSecretName,
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.CloudConfigConfigMapName,
-					},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.CloudConfigSeedSecretName,
 				},
 			},
 		},
@@ -321,24 +344,29 @@ type kubeControllerManagerEnvData interface {
 	Seed() *kubermaticv1.Seed
 }
 
-func GetEnvVars(data kubeControllerManagerEnvData) ([]corev1.EnvVar, error) {
-	credentials, err := resources.GetCredentials(data)
-	if err != nil {
-		return nil, err
-	}
+// GetEnvVars returns the environment variables of the controller manager, the cloud credentials are read
+// from the files rendered by the injector if it is not nil
+func GetEnvVars(data kubeControllerManagerEnvData, injector provider.SecretStoreInjector) []corev1.EnvVar {
 	cluster := data.Cluster()
 
 	var vars []corev1.EnvVar
 	if cluster.Spec.Cloud.AWS != nil {
-		vars = append(vars, corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", Value: credentials.AWS.AccessKeyID})
-		vars = append(vars, corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", Value: credentials.AWS.SecretAccessKey})
+		if injector != nil {
+			vars = append(vars, resources.AWSSharedCredentialsFileEnvVar(injector))
+		} else {
+			vars = append(vars, resources.CloudCredentialEnvVar("AWS_ACCESS_KEY_ID"))
+			vars = append(vars, resources.CloudCredentialEnvVar("AWS_SECRET_ACCESS_KEY"))
+		}
 		vars = append(vars, corev1.EnvVar{Name: "AWS_VPC_ID", Value: cluster.Spec.Cloud.AWS.VPCID})
 	}
 	if cluster.Spec.Cloud.GCP != nil {
-		vars = append(vars, corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/etc/gcp/serviceAccount"})
+		serviceAccountFile := "/etc/gcp/serviceAccount"
+		if injector != nil {
+			serviceAccountFile = injector.FilePath(resources.GCPServiceAccountFileName)
+		}
+		vars = append(vars, corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: serviceAccountFile})
 	}
-	vars = append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...)
-	return vars, nil
+	return append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...)
 }
 
 func CloudRoutesFlagVal(cloudSpec kubermaticv1.CloudSpec) *bool {
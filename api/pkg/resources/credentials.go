@@ -95,10 +95,10 @@ type CredentialsData interface {
 	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 }
 
-func NewCredentialsData(ctx context.Context, cluster *kubermaticv1.Cluster, client ctrlruntimeclient.Client) CredentialsData {
+func NewCredentialsData(ctx context.Context, cluster *kubermaticv1.Cluster, client ctrlruntimeclient.Client, secretStores *provider.SecretStores) CredentialsData {
 	return &credentialsData{
 		cluster:                          cluster,
-		globalSecretKeySelectorValueFunc: provider.SecretKeySelectorValueFuncFactory(ctx, client),
+		globalSecretKeySelectorValueFunc: provider.SecretKeySelectorValueFuncFactory(ctx, client, secretStores),
 	}
 }
 
@@ -59,6 +59,7 @@ type TemplateData struct {
 	kubermaticImage                                  string
 	dnatControllerImage                              string
 	supportsFailureDomainZoneAntiAffinity            bool
+	secretStores                                     *provider.SecretStores
 }
 
 // NewTemplateData returns an instance of TemplateData
@@ -83,7 +84,8 @@ func NewTemplateData(
 	nodeLocalDNSCacheEnabled bool,
 	kubermaticImage string,
 	dnatControllerImage string,
-	supportsFailureDomainZoneAntiAffinity bool) *TemplateData {
+	supportsFailureDomainZoneAntiAffinity bool,
+	secretStores *provider.SecretStores) *TemplateData {
 	return &TemplateData{
 		ctx:                                    ctx,
 		client:                                 client,
@@ -106,6 +108,7 @@ func NewTemplateData(
 		kubermaticImage:                                  kubermaticImage,
 		dnatControllerImage:                              dnatControllerImage,
 		supportsFailureDomainZoneAntiAffinity:            supportsFailureDomainZoneAntiAffinity,
+		secretStores:                                     secretStores,
 	}
 }
 
@@ -242,6 +245,11 @@ func (d *TemplateData) GetRootCA() (*triple.KeyPair, error) {
 	return GetClusterRootCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
 }
 
+// GetRootCACrossSignedCert returns the root CA signed by the previous root CA during a certificate rotation
+func (d *TemplateData) GetRootCACrossSignedCert() ([]byte, error) {
+	return GetClusterRootCACrossSignedCert(d.ctx, d.cluster.Status.NamespaceName, d.client)
+}
+
 // GetFrontProxyCA returns the root CA for the front proxy
 func (d *TemplateData) GetFrontProxyCA() (*triple.KeyPair, error) {
 	return GetClusterFrontProxyCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
@@ -269,6 +277,22 @@ func (d *TemplateData) GetOpenVPNServerPort() (int32, error) {
 	return service.Spec.Ports[0].NodePort, nil
 }
 
+// GetKonnectivityCA returns the CA the konnectivity server and agents authenticate each other with
+func (d *TemplateData) GetKonnectivityCA() (*triple.KeyPair, error) {
+	return GetKonnectivityCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
+}
+
+// GetKonnectivityServerPort returns the NodePort the konnectivity agents connect to
+func (d *TemplateData) GetKonnectivityServerPort() (int32, error) {
+	service := &corev1.Service{}
+	key := types.NamespacedName{Namespace: d.cluster.Status.NamespaceName, Name: KonnectivityServerServiceName}
+	if err := d.client.Get(d.ctx, key, service); err != nil {
+		return 0, fmt.Errorf("failed to get NodePort for konnectivity server service: %v", err)
+	}
+
+	return service.Spec.Ports[0].NodePort, nil
+}
+
 func (d *TemplateData) NodeLocalDNSCacheEnabled() bool {
 	return d.nodeLocalDNSCacheEnabled
 }
@@ -304,7 +328,13 @@ func (d *TemplateData) SupportsFailureDomainZoneAntiAffinity() bool {
 }
 
 func (d *TemplateData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
-	return provider.SecretKeySelectorValueFuncFactory(d.ctx, d.client)(configVar, key)
+	return provider.SecretKeySelectorValueFuncFactory(d.ctx, d.client, d.secretStores)(configVar, key)
+}
+
+// CloudCredentialsInjector returns the injector of the secret store the cloud credentials of the cluster are
+// kept in, it is nil if they are kept in Kubernetes
+func (d *TemplateData) CloudCredentialsInjector() (provider.SecretStoreInjector, error) {
+	return CloudCredentialsInjector(d.cluster, d.secretStores)
 }
 
 func (d *TemplateData) GetKubernetesCloudProviderName() string {
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+const (
+	// PoliciesConfigMapName is the name of the ConfigMap in the cluster namespace which holds
+	// the constraint templates and constraints to sync into the user cluster
+	PoliciesConfigMapName = "gatekeeper-policies"
+
+	constraintTemplatesKey = "constrainttemplates"
+	constraintsKey         = "constraints"
+
+	// ManagedByLabel is set on all gatekeeper objects in the user cluster which are managed by Kubermatic
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of the ManagedByLabel
+	ManagedByValue = "kubermatic"
+
+	// ConstraintTemplateCRDName is the name of the CRD which gets installed by the gatekeeper addon
+	ConstraintTemplateCRDName = "constrainttemplates.templates.gatekeeper.sh"
+)
+
+var (
+	// ConstraintTemplateGVK is the GroupVersionKind of gatekeeper constraint templates
+	ConstraintTemplateGVK = schema.GroupVersionKind{Group: "templates.gatekeeper.sh", Version: "v1beta1", Kind: "ConstraintTemplate"}
+	// ConstraintGroupVersion is the GroupVersion of gatekeeper constraints, their kind is defined by the templates
+	ConstraintGroupVersion = schema.GroupVersion{Group: "constraints.gatekeeper.sh", Version: "v1beta1"}
+)
+
+// PoliciesConfigMapCreator returns a function to create the ConfigMap which holds the given constraint templates and constraints
+func PoliciesConfigMapCreator(templates []kubermaticv1.ConstraintTemplate, constraints []kubermaticv1.Constraint) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return PoliciesConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			// Only keep what is needed in the user cluster, sorted to not cause needless updates
+			strippedTemplates := make([]kubermaticv1.ConstraintTemplate, len(templates))
+			for i, template := range templates {
+				strippedTemplates[i] = kubermaticv1.ConstraintTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: template.Name},
+					Spec:       template.Spec,
+				}
+			}
+			sort.Slice(strippedTemplates, func(i, j int) bool { return strippedTemplates[i].Name < strippedTemplates[j].Name })
+
+			strippedConstraints := make([]kubermaticv1.Constraint, len(constraints))
+			for i, constraint := range constraints {
+				strippedConstraints[i] = kubermaticv1.Constraint{
+					ObjectMeta: metav1.ObjectMeta{Name: constraint.Name},
+					Spec:       constraint.Spec,
+				}
+			}
+			sort.Slice(strippedConstraints, func(i, j int) bool { return strippedConstraints[i].Name < strippedConstraints[j].Name })
+
+			rawTemplates, err := json.Marshal(strippedTemplates)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode constraint templates: %v", err)
+			}
+			rawConstraints, err := json.Marshal(strippedConstraints)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode constraints: %v", err)
+			}
+
+			cm.Data = map[string]string{
+				constraintTemplatesKey: string(rawTemplates),
+				constraintsKey:         string(rawConstraints),
+			}
+			return cm, nil
+		}
+	}
+}
+
+// PoliciesFromConfigMap returns the constraint templates and constraints stored in the given ConfigMap
+func PoliciesFromConfigMap(cm *corev1.ConfigMap) ([]kubermaticv1.ConstraintTemplate, []kubermaticv1.Constraint, error) {
+	var (
+		templates   []kubermaticv1.ConstraintTemplate
+		constraints []kubermaticv1.Constraint
+	)
+	if raw := cm.Data[constraintTemplatesKey]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &templates); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode constraint templates: %v", err)
+		}
+	}
+	if raw := cm.Data[constraintsKey]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &constraints); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode constraints: %v", err)
+		}
+	}
+	return templates, constraints, nil
+}
+
+// ConstraintTemplateName returns the name of the gatekeeper constraint template for the given kind,
+// gatekeeper requires it to be the lowercase kind.
+func ConstraintTemplateName(kind string) string {
+	return strings.ToLower(kind)
+}
+
+// ConstraintTemplateObject returns the gatekeeper constraint template for the given template.
+// Integers are decoded as int64 so the result can be compared to the objects returned by the API server.
+func ConstraintTemplateObject(template *kubermaticv1.ConstraintTemplate) (*unstructured.Unstructured, error) {
+	crdSpec := map[string]interface{}{
+		"names": map[string]interface{}{
+			"kind": template.Spec.Kind,
+		},
+	}
+	if len(template.Spec.Parameters.Raw) > 0 {
+		var openAPISchema map[string]interface{}
+		if err := utiljson.Unmarshal(template.Spec.Parameters.Raw, &openAPISchema); err != nil {
+			return nil, fmt.Errorf("failed to decode parameters schema: %v", err)
+		}
+		crdSpec["validation"] = map[string]interface{}{
+			"openAPIV3Schema": openAPISchema,
+		}
+	}
+
+	targets := make([]interface{}, len(template.Spec.Targets))
+	for i, target := range template.Spec.Targets {
+		targets[i] = map[string]interface{}{
+			"target": target.Target,
+			"rego":   target.Rego,
+		}
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"crd": map[string]interface{}{
+				"spec": crdSpec,
+			},
+			"targets": targets,
+		},
+	}}
+	obj.SetGroupVersionKind(ConstraintTemplateGVK)
+	obj.SetName(ConstraintTemplateName(template.Spec.Kind))
+	obj.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+	return obj, nil
+}
+
+// ConstraintObject returns the gatekeeper constraint of the given kind for the given constraint
+func ConstraintObject(constraint *kubermaticv1.Constraint, kind string) (*unstructured.Unstructured, error) {
+	spec := map[string]interface{}{}
+	if constraint.Spec.EnforcementAction != "" {
+		spec["enforcementAction"] = constraint.Spec.EnforcementAction
+	}
+
+	rawMatch, err := json.Marshal(constraint.Spec.Match)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode match: %v", err)
+	}
+	var match map[string]interface{}
+	if err := utiljson.Unmarshal(rawMatch, &match); err != nil {
+		return nil, fmt.Errorf("failed to decode match: %v", err)
+	}
+	if len(match) > 0 {
+		spec["match"] = match
+	}
+
+	if len(constraint.Spec.Parameters.Raw) > 0 {
+		var parameters map[string]interface{}
+		if err := utiljson.Unmarshal(constraint.Spec.Parameters.Raw, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to decode parameters: %v", err)
+		}
+		spec["parameters"] = parameters
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	obj.SetGroupVersionKind(ConstraintGroupVersion.WithKind(kind))
+	obj.SetName(constraint.Spec.Name)
+	obj.SetLabels(map[string]string{ManagedByLabel: ManagedByValue})
+	return obj, nil
+}
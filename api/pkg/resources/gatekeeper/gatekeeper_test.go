@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatekeeper
+
+import (
+	"reflect"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPoliciesConfigMapRoundTrip(t *testing.T) {
+	templates := []kubermaticv1.ConstraintTemplate{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8srequiredlabels", ResourceVersion: "42"},
+			Spec: kubermaticv1.ConstraintTemplateSpec{
+				Kind:       "K8sRequiredLabels",
+				Parameters: runtime.RawExtension{Raw: []byte(`{"properties":{"labels":{"type":"array"}}}`)},
+				Targets:    []kubermaticv1.ConstraintTemplateTarget{{Target: "admission.k8s.gatekeeper.sh", Rego: "package k8srequiredlabels"}},
+			},
+		},
+	}
+	constraints := []kubermaticv1.Constraint{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			Spec:       kubermaticv1.ConstraintSpec{Name: "must-have-team", ConstraintTemplate: "k8srequiredlabels"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"}},
+			Spec:       kubermaticv1.ConstraintSpec{Name: "must-have-owner", ConstraintTemplate: "k8srequiredlabels"},
+		},
+	}
+
+	_, creator := PoliciesConfigMapCreator(templates, constraints)()
+	cm, err := creator(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+
+	gotTemplates, gotConstraints, err := PoliciesFromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("failed to read configmap: %v", err)
+	}
+
+	// only the name and the spec are stored
+	expectedTemplates := []kubermaticv1.ConstraintTemplate{
+		{ObjectMeta: metav1.ObjectMeta{Name: "k8srequiredlabels"}, Spec: templates[0].Spec},
+	}
+	if !reflect.DeepEqual(gotTemplates, expectedTemplates) {
+		t.Errorf("expected templates %+v, got %+v", expectedTemplates, gotTemplates)
+	}
+	// constraints are sorted by name
+	expectedConstraints := []kubermaticv1.Constraint{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: constraints[1].Spec},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: constraints[0].Spec},
+	}
+	if !reflect.DeepEqual(gotConstraints, expectedConstraints) {
+		t.Errorf("expected constraints %+v, got %+v", expectedConstraints, gotConstraints)
+	}
+}
+
+func TestConstraintTemplateObject(t *testing.T) {
+	template := &kubermaticv1.ConstraintTemplate{
+		Spec: kubermaticv1.ConstraintTemplateSpec{
+			Kind:       "K8sRequiredLabels",
+			Parameters: runtime.RawExtension{Raw: []byte(`{"properties":{"labels":{"type":"array","maxItems":5}}}`)},
+			Targets:    []kubermaticv1.ConstraintTemplateTarget{{Target: "admission.k8s.gatekeeper.sh", Rego: "package k8srequiredlabels"}},
+		},
+	}
+
+	obj, err := ConstraintTemplateObject(template)
+	if err != nil {
+		t.Fatalf("failed to build constraint template: %v", err)
+	}
+
+	if obj.GetName() != "k8srequiredlabels" {
+		t.Errorf("expected name to be the lowercase kind, got %q", obj.GetName())
+	}
+	if obj.GetLabels()[ManagedByLabel] != ManagedByValue {
+		t.Errorf("expected the %s label to be set", ManagedByLabel)
+	}
+	expectedSpec := map[string]interface{}{
+		"crd": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"names": map[string]interface{}{"kind": "K8sRequiredLabels"},
+				"validation": map[string]interface{}{
+					"openAPIV3Schema": map[string]interface{}{
+						"properties": map[string]interface{}{
+							// integers must be int64 to compare equal to objects from the API server
+							"labels": map[string]interface{}{"type": "array", "maxItems": int64(5)},
+						},
+					},
+				},
+			},
+		},
+		"targets": []interface{}{
+			map[string]interface{}{"target": "admission.k8s.gatekeeper.sh", "rego": "package k8srequiredlabels"},
+		},
+	}
+	if !reflect.DeepEqual(obj.Object["spec"], expectedSpec) {
+		t.Errorf("expected spec %+v, got %+v", expectedSpec, obj.Object["spec"])
+	}
+}
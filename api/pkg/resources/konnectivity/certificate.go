@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"net"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/servingcerthelper"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+)
+
+type tlsServingCertCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
+	GetKonnectivityCA() (*triple.KeyPair, error)
+}
+
+// TLSServingCertificateCreator returns a function to create/update the secret with the certificate the konnectivity
+// server presents to the agents. The agents reach the server through the external name of the cluster.
+// The certificate is signed by the konnectivity CA, which the agents verify the server with.
+func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.NamedSecretCreatorGetter {
+	var ips []net.IP
+	if externalIP := net.ParseIP(data.Cluster().Address.IP); externalIP != nil {
+		ips = append(ips, externalIP)
+	}
+
+	return servingcerthelper.ServingCertSecretCreator(
+		data.GetKonnectivityCA,
+		resources.KonnectivityServerCertificatesSecretName,
+		"konnectivity-server",
+		[]string{data.Cluster().Address.ExternalName},
+		ips,
+	)
+}
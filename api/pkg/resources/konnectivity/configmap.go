@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The types below mirror the EgressSelectorConfiguration of k8s.io/apiserver/pkg/apis/apiserver/v1alpha1
+type egressSelectorConfiguration struct {
+	APIVersion       string            `json:"apiVersion"`
+	Kind             string            `json:"kind"`
+	EgressSelections []egressSelection `json:"egressSelections"`
+}
+
+type egressSelection struct {
+	Name       string     `json:"name"`
+	Connection connection `json:"connection"`
+}
+
+type connection struct {
+	ProxyProtocol string     `json:"proxyProtocol"`
+	Transport     *transport `json:"transport,omitempty"`
+}
+
+type transport struct {
+	UDS *udsTransport `json:"uds,omitempty"`
+}
+
+type udsTransport struct {
+	UDSName string `json:"udsName"`
+}
+
+// EgressSelectorConfiguration renders the configuration for the "--egress-selector-config-file" flag of the
+// apiserver. All traffic into the user cluster is sent to the konnectivity server over its unix domain socket.
+func EgressSelectorConfiguration() ([]byte, error) {
+	return yaml.Marshal(egressSelectorConfiguration{
+		APIVersion: "apiserver.k8s.io/v1alpha1",
+		Kind:       "EgressSelectorConfiguration",
+		EgressSelections: []egressSelection{
+			{
+				Name: "cluster",
+				Connection: connection{
+					ProxyProtocol: "GRPC",
+					Transport: &transport{
+						UDS: &udsTransport{UDSName: socketPath},
+					},
+				},
+			},
+		},
+	})
+}
+
+// EgressSelectorConfigMapCreator returns a ConfigMap containing the egress selector configuration of the apiserver
+func EgressSelectorConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.KonnectivityEgressSelectorConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			cm.Labels = resources.BaseAppLabels(name, nil)
+
+			config, err := EgressSelectorConfiguration()
+			if err != nil {
+				return nil, fmt.Errorf("failed to render egress selector configuration: %v", err)
+			}
+
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[resources.KonnectivityEgressSelectorConfigMapKey] = string(config)
+
+			return cm, nil
+		}
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"testing"
+)
+
+func TestEgressSelectorConfiguration(t *testing.T) {
+	expected := `apiVersion: apiserver.k8s.io/v1alpha1
+egressSelections:
+- connection:
+    proxyProtocol: GRPC
+    transport:
+      uds:
+        udsName: /run/konnectivity-server/konnectivity-server.socket
+  name: cluster
+kind: EgressSelectorConfiguration
+`
+
+	config, err := EgressSelectorConfiguration()
+	if err != nil {
+		t.Fatalf("failed to render the egress selector configuration: %v", err)
+	}
+	if string(config) != expected {
+		t.Errorf("expected egress selector configuration\n%s\ngot\n%s", expected, string(config))
+	}
+}
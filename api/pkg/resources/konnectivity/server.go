@@ -0,0 +1,200 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	name = "konnectivity"
+
+	// AgentPort is the port the konnectivity server accepts agent connections on
+	AgentPort = 8132
+	// healthPort is the port the konnectivity server serves its health checks on
+	healthPort = 8134
+
+	// socketVolumeName is the name of the volume the apiserver and the konnectivity server share the socket on
+	socketVolumeName = "konnectivity-uds"
+	socketDir        = "/run/konnectivity-server"
+	socketPath       = socketDir + "/konnectivity-server.socket"
+)
+
+var (
+	serverResourceRequirements = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+		},
+	}
+)
+
+type serverData interface {
+	ImageRegistry(string) string
+	Cluster() *kubermaticv1.Cluster
+}
+
+// ServerContainer returns a `corev1.Container` for running the konnectivity server alongside the apiserver.
+// The apiserver reaches the server through a unix domain socket, the agents inside the user cluster
+// connect to AgentPort with their client certificates.
+// Also required but not provided by this func:
+// * volumes: Volumes()
+func ServerContainer(data serverData) *corev1.Container {
+	// Every agent has to connect to every server, the agents use the server count to know
+	// when they are connected to all of them.
+	serverCount := int32(1)
+	if replicas := data.Cluster().Spec.ComponentsOverride.Apiserver.Replicas; replicas != nil {
+		serverCount = *replicas
+	}
+
+	return &corev1.Container{
+		Name:    "konnectivity-server",
+		Image:   data.ImageRegistry(resources.RegistryUSGCR) + "/k8s-artifacts-prod/kas-network-proxy/proxy-server:v0.0.12",
+		Command: []string{"/proxy-server"},
+		Args: []string{
+			"--logtostderr=true",
+			"--uds-name", socketPath,
+			// The apiserver connects through the socket only
+			"--server-port", "0",
+			"--agent-port", fmt.Sprint(AgentPort),
+			"--admin-port", "8133",
+			"--health-port", fmt.Sprint(healthPort),
+			"--mode", "grpc",
+			"--server-count", fmt.Sprint(serverCount),
+			"--cluster-cert", "/etc/kubernetes/konnectivity-server/" + resources.ServingCertSecretKey,
+			"--cluster-key", "/etc/kubernetes/konnectivity-server/" + resources.ServingCertKeySecretKey,
+			// Agents authenticate with a client certificate signed by the konnectivity CA. It must not be the
+			// cluster CA, otherwise every client of the apiserver could register as an agent.
+			"--cluster-ca-cert", "/etc/kubernetes/pki/konnectivity-ca/" + resources.CACertSecretKey,
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "agent",
+				ContainerPort: AgentPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		LivenessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/healthz",
+					Port:   intstr.FromInt(healthPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+			InitialDelaySeconds: 15,
+			FailureThreshold:    3,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			TimeoutSeconds:      15,
+		},
+		Resources: serverResourceRequirements,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      socketVolumeName,
+				MountPath: socketDir,
+			},
+			{
+				Name:      resources.KonnectivityServerCertificatesSecretName,
+				MountPath: "/etc/kubernetes/konnectivity-server",
+				ReadOnly:  true,
+			},
+			{
+				Name:      resources.KonnectivityCASecretName,
+				MountPath: "/etc/kubernetes/pki/konnectivity-ca",
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// Volumes returns the volumes the konnectivity server and the apiserver need in addition to the
+// volumes of the apiserver.
+func Volumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: socketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: resources.KonnectivityServerCertificatesSecretName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.KonnectivityServerCertificatesSecretName,
+				},
+			},
+		},
+		{
+			Name: resources.KonnectivityCASecretName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: resources.KonnectivityCASecretName,
+					// The server only needs the certificate, the key stays in the seed controllers
+					Items: []corev1.KeyToPath{
+						{
+							Key:  resources.CACertSecretKey,
+							Path: resources.CACertSecretKey,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: resources.KonnectivityEgressSelectorConfigMapName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: resources.KonnectivityEgressSelectorConfigMapName,
+					},
+				},
+			},
+		},
+	}
+}
+
+// APIServerVolumeMounts returns the volume mounts the apiserver needs to send its traffic through the konnectivity server.
+func APIServerVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      socketVolumeName,
+			MountPath: socketDir,
+		},
+		{
+			Name:      resources.KonnectivityEgressSelectorConfigMapName,
+			MountPath: "/etc/kubernetes/egress-selector",
+			ReadOnly:  true,
+		},
+	}
+}
+
+// APIServerFlags returns the apiserver flags to send its traffic through the konnectivity server.
+func APIServerFlags() []string {
+	return []string{"--egress-selector-config-file", "/etc/kubernetes/egress-selector/" + resources.KonnectivityEgressSelectorConfigMapKey}
+}
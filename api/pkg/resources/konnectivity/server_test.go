@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"strings"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+)
+
+type fakeServerData struct{}
+
+func (fakeServerData) ImageRegistry(registry string) string {
+	return registry
+}
+
+func (fakeServerData) Cluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{}
+}
+
+func TestServerContainerAuthenticatesAgentsWithKonnectivityCA(t *testing.T) {
+	container := ServerContainer(fakeServerData{})
+
+	var caCertPath string
+	for i, arg := range container.Args {
+		if arg == "--cluster-ca-cert" && i+1 < len(container.Args) {
+			caCertPath = container.Args[i+1]
+		}
+	}
+	if caCertPath == "" {
+		t.Fatal("expected the server to authenticate the agents with a CA certificate")
+	}
+
+	for _, mount := range container.VolumeMounts {
+		if !strings.HasPrefix(caCertPath, mount.MountPath+"/") {
+			continue
+		}
+		if mount.Name != resources.KonnectivityCASecretName {
+			t.Errorf("expected the agents to be authenticated with the %s secret, got %s", resources.KonnectivityCASecretName, mount.Name)
+		}
+		return
+	}
+	t.Errorf("no volume is mounted at %s", caCertPath)
+}
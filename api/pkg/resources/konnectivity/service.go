@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/nodeportproxy"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServiceCreator returns the function to reconcile the external service the konnectivity agents connect to.
+// The konnectivity server runs as sidecar of the apiserver, so the service selects the apiserver pods.
+func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceCreatorGetter {
+	return func() (string, reconciling.ServiceCreator) {
+		return resources.KonnectivityServerServiceName, func(se *corev1.Service) (*corev1.Service, error) {
+			se.Name = resources.KonnectivityServerServiceName
+			se.Labels = resources.BaseAppLabels(name, nil)
+
+			if se.Annotations == nil {
+				se.Annotations = map[string]string{}
+			}
+			if exposeStrategy == corev1.ServiceTypeNodePort {
+				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			} else {
+				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+			}
+			se.Spec.Selector = map[string]string{
+				resources.AppLabelKey: "apiserver",
+			}
+			se.Spec.Type = corev1.ServiceTypeNodePort
+			if len(se.Spec.Ports) == 0 {
+				se.Spec.Ports = make([]corev1.ServicePort, 1)
+			}
+
+			se.Spec.Ports[0].Name = "agent"
+			se.Spec.Ports[0].Port = AgentPort
+			se.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+			se.Spec.Ports[0].TargetPort = intstr.FromInt(AgentPort)
+
+			return se, nil
+		}
+	}
+}
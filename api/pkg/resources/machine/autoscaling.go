@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// AutoscalerMinSizeAnnotation is read by the cluster-autoscaler to determine the minimum
+	// size of the node group a MachineDeployment represents.
+	AutoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	// AutoscalerMaxSizeAnnotation is read by the cluster-autoscaler to determine the maximum
+	// size of the node group a MachineDeployment represents.
+	AutoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+// SetAutoscalingAnnotations sets the autoscaler size annotations on the MachineDeployment,
+// or removes them if no bounds are given.
+func SetAutoscalingAnnotations(md *clusterv1alpha1.MachineDeployment, minReplicas, maxReplicas *int32) {
+	if minReplicas == nil || maxReplicas == nil {
+		delete(md.Annotations, AutoscalerMinSizeAnnotation)
+		delete(md.Annotations, AutoscalerMaxSizeAnnotation)
+		return
+	}
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[AutoscalerMinSizeAnnotation] = strconv.Itoa(int(*minReplicas))
+	md.Annotations[AutoscalerMaxSizeAnnotation] = strconv.Itoa(int(*maxReplicas))
+}
+
+// GetAutoscalingReplicas returns the autoscaler bounds of the MachineDeployment. Annotations
+// that are missing or can not be parsed are returned as nil.
+func GetAutoscalingReplicas(md *clusterv1alpha1.MachineDeployment) (minReplicas, maxReplicas *int32) {
+	parse := func(annotation string) *int32 {
+		value, ok := md.Annotations[annotation]
+		if !ok {
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil
+		}
+		result := int32(i)
+		return &result
+	}
+
+	minReplicas, maxReplicas = parse(AutoscalerMinSizeAnnotation), parse(AutoscalerMaxSizeAnnotation)
+	if minReplicas == nil || maxReplicas == nil {
+		return nil, nil
+	}
+	return minReplicas, maxReplicas
+}
+
+// ValidateAutoscaling ensures the autoscaling bounds of a NodeDeployment are consistent with its replicas.
+func ValidateAutoscaling(spec *apiv1.NodeDeploymentSpec) error {
+	if spec.MinReplicas == nil && spec.MaxReplicas == nil {
+		return nil
+	}
+	if spec.MinReplicas == nil || spec.MaxReplicas == nil {
+		return errors.New("minReplicas and maxReplicas must be set together")
+	}
+	min, max := *spec.MinReplicas, *spec.MaxReplicas
+	if min < 0 {
+		return errors.New("minReplicas must not be negative")
+	}
+	if max < 1 {
+		return errors.New("maxReplicas must be at least 1")
+	}
+	if min > max {
+		return fmt.Errorf("minReplicas (%d) must not be greater than maxReplicas (%d)", min, max)
+	}
+	if spec.Replicas < min || spec.Replicas > max {
+		return fmt.Errorf("replicas (%d) must be between minReplicas (%d) and maxReplicas (%d)", spec.Replicas, min, max)
+	}
+	return nil
+}
@@ -111,6 +111,9 @@ func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermati
 		md.Spec.Paused = *nd.Spec.Paused
 	}
 
+	SetAutoscalingAnnotations(md, nd.Spec.MinReplicas, nd.Spec.MaxReplicas)
+	SetRolloutStrategy(md, nd.Spec.Strategy)
+
 	config, err := getProviderConfig(c, nd, dc, keys, data)
 	if err != nil {
 		return nil, err
@@ -331,5 +334,13 @@ func Validate(nd *apiv1.NodeDeployment, controlPlaneVersion *semver.Version) (*a
 		}
 	}
 
+	if err := ValidateAutoscaling(&nd.Spec); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateRolloutStrategy(nd.Spec.Strategy); err != nil {
+		return nil, err
+	}
+
 	return nd, nil
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apiv1 "github.com/kubermatic/kubermatic/api/pkg/api/v1"
+	"github.com/kubermatic/machine-controller/pkg/apis/cluster/common"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// RestartedAtAnnotation is set on the Machine template to force the MachineDeployment to roll out new Machines.
+	RestartedAtAnnotation = "kubermatic.io/restarted-at"
+
+	// DeleteMachineAnnotation makes the MachineSet prefer the annotated Machine when it scales down.
+	DeleteMachineAnnotation = "cluster.k8s.io/delete-machine"
+)
+
+const (
+	defaultMaxSurge       = 1
+	defaultMaxUnavailable = 0
+)
+
+// SetRolloutStrategy sets the rolling update strategy of the MachineDeployment. Values that are not set in the
+// given strategy are left to the machine-controller defaults.
+func SetRolloutStrategy(md *clusterv1alpha1.MachineDeployment, strategy *apiv1.NodeDeploymentRolloutStrategy) {
+	if strategy == nil {
+		return
+	}
+
+	rollingUpdate := &clusterv1alpha1.MachineRollingUpdateDeployment{}
+	if strategy.MaxSurge != nil {
+		maxSurge := intstr.Parse(*strategy.MaxSurge)
+		rollingUpdate.MaxSurge = &maxSurge
+	}
+	if strategy.MaxUnavailable != nil {
+		maxUnavailable := intstr.Parse(*strategy.MaxUnavailable)
+		rollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+
+	md.Spec.Strategy = &clusterv1alpha1.MachineDeploymentStrategy{
+		Type:          common.RollingUpdateMachineDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}
+}
+
+// GetRolloutStrategy returns the rolling update strategy of the MachineDeployment or nil if none is set.
+func GetRolloutStrategy(md *clusterv1alpha1.MachineDeployment) *apiv1.NodeDeploymentRolloutStrategy {
+	if md.Spec.Strategy == nil || md.Spec.Strategy.RollingUpdate == nil {
+		return nil
+	}
+
+	strategy := &apiv1.NodeDeploymentRolloutStrategy{}
+	if maxSurge := md.Spec.Strategy.RollingUpdate.MaxSurge; maxSurge != nil {
+		value := maxSurge.String()
+		strategy.MaxSurge = &value
+	}
+	if maxUnavailable := md.Spec.Strategy.RollingUpdate.MaxUnavailable; maxUnavailable != nil {
+		value := maxUnavailable.String()
+		strategy.MaxUnavailable = &value
+	}
+	return strategy
+}
+
+// ValidateRolloutStrategy ensures maxSurge and maxUnavailable are valid numbers or percentages and
+// that they allow the rollout to make progress.
+func ValidateRolloutStrategy(strategy *apiv1.NodeDeploymentRolloutStrategy) error {
+	if strategy == nil {
+		return nil
+	}
+
+	maxSurge, _, err := parseRolloutValue("maxSurge", strategy.MaxSurge, defaultMaxSurge)
+	if err != nil {
+		return err
+	}
+	maxUnavailable, isPercent, err := parseRolloutValue("maxUnavailable", strategy.MaxUnavailable, defaultMaxUnavailable)
+	if err != nil {
+		return err
+	}
+	if isPercent && maxUnavailable > 100 {
+		return errors.New("maxUnavailable must not be greater than 100%")
+	}
+	if maxSurge == 0 && maxUnavailable == 0 {
+		return errors.New("maxSurge and maxUnavailable must not both be zero")
+	}
+	return nil
+}
+
+// parseRolloutValue returns the number or percentage of a maxSurge or maxUnavailable value.
+func parseRolloutValue(name string, value *string, defaultValue int) (int, bool, error) {
+	if value == nil {
+		return defaultValue, false, nil
+	}
+
+	parsed := intstr.Parse(*value)
+	// Use 100 as total so a percentage is returned as is
+	result, err := intstr.GetValueFromIntOrPercent(&parsed, 100, true)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s must be an integer or a percentage: %v", name, err)
+	}
+	if result < 0 {
+		return 0, false, fmt.Errorf("%s must not be negative", name)
+	}
+	return result, parsed.Type == intstr.String, nil
+}
+
+// SetRestartedAt changes the Machine template of the MachineDeployment, so the machine-controller
+// replaces all Machines according to the rollout strategy.
+func SetRestartedAt(md *clusterv1alpha1.MachineDeployment, now time.Time) {
+	if md.Spec.Template.Annotations == nil {
+		md.Spec.Template.Annotations = map[string]string{}
+	}
+	md.Spec.Template.Annotations[RestartedAtAnnotation] = now.UTC().Format(time.RFC3339)
+}
@@ -23,8 +23,10 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -67,6 +69,7 @@ type machinecontrollerData interface {
 	DC() *kubermaticv1.Datacenter
 	NodeLocalDNSCacheEnabled() bool
 	Seed() *kubermaticv1.Seed
+	CloudCredentialsInjector() (provider.SecretStoreInjector, error)
 }
 
 // DeploymentCreator returns the function to create and update the machine controller deployment
@@ -103,8 +106,13 @@ func DeploymentCreatorWithoutInitWrapper(data machinecontrollerData) reconciling
 			}
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
+			injector, err := data.CloudCredentialsInjector()
+			if err != nil {
+				return nil, err
+			}
+
 			volumes := []corev1.Volume{getKubeconfigVolume()}
-			podLabels, err := data.GetPodTemplateLabels(Name, volumes, nil)
+			podLabels, err := data.GetPodTemplateLabels(Name, revisionVolumes(volumes, injector), nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create pod labels: %v", err)
 			}
@@ -117,6 +125,13 @@ func DeploymentCreatorWithoutInitWrapper(data machinecontrollerData) reconciling
 					"prometheus.io/port":   "8085",
 				},
 			}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range injectionAnnotations {
+				dep.Spec.Template.Annotations[k] = v
+			}
 
 			dep.Spec.Template.Spec.Volumes = volumes
 
@@ -128,20 +143,15 @@ func DeploymentCreatorWithoutInitWrapper(data machinecontrollerData) reconciling
 				}
 			}
 
-			envVars, err := getEnvVars(data)
-			if err != nil {
-				return nil, err
-			}
-
 			externalCloudProvider := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]
 
 			dep.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    Name,
 					Image:   data.ImageRegistry(resources.RegistryDocker) + "/kubermatic/machine-controller:" + tag,
-					Command: []string{"/usr/local/bin/machine-controller"},
+					Command: command(injector, "/usr/local/bin/machine-controller"),
 					Args:    getFlags(clusterDNSIP, data.DC().Node, externalCloudProvider),
-					Env: append(envVars, corev1.EnvVar{
+					Env: append(getEnvVars(data, injector), corev1.EnvVar{
 						Name:  "KUBECONFIG",
 						Value: "/etc/kubernetes/kubeconfig/kubeconfig",
 					}),
@@ -189,58 +199,88 @@ func getKubeconfigVolume() corev1.Volume {
 	}
 }
 
-func getEnvVars(data machinecontrollerData) ([]corev1.EnvVar, error) {
-	credentials, err := resources.GetCredentials(data)
-	if err != nil {
-		return nil, err
+// revisionVolumes returns the volumes whose revisions restart the pods, the cloud credentials secret
+// only exists if the credentials are not injected
+func revisionVolumes(volumes []corev1.Volume, injector provider.SecretStoreInjector) []corev1.Volume {
+	if injector != nil {
+		return volumes
 	}
+	return append(volumes, resources.CloudCredentialsRevisionVolume())
+}
+
+// command returns the command running the given binary, injected cloud credentials get exported to its
+// environment as it only reads them from environment variables
+func command(injector provider.SecretStoreInjector, binary string) []string {
+	if injector != nil {
+		return resources.CloudCredentialsEnvCommand(injector, binary)
+	}
+	return []string{binary}
+}
 
+// getEnvVars returns the environment variables of the machine-controller, the ones holding the cloud
+// credentials are left out if the credentials are injected
+func getEnvVars(data machinecontrollerData, injector provider.SecretStoreInjector) []corev1.EnvVar {
 	var vars []corev1.EnvVar
 	if data.Cluster().Spec.Cloud.AWS != nil {
-		vars = append(vars, corev1.EnvVar{Name: "AWS_ACCESS_KEY_ID", Value: credentials.AWS.AccessKeyID})
-		vars = append(vars, corev1.EnvVar{Name: "AWS_SECRET_ACCESS_KEY", Value: credentials.AWS.SecretAccessKey})
+		vars = append(vars, resources.CloudCredentialEnvVar("AWS_ACCESS_KEY_ID"))
+		vars = append(vars, resources.CloudCredentialEnvVar("AWS_SECRET_ACCESS_KEY"))
 	}
 	if data.Cluster().Spec.Cloud.Azure != nil {
-		vars = append(vars, corev1.EnvVar{Name: "AZURE_CLIENT_ID", Value: credentials.Azure.ClientID})
-		vars = append(vars, corev1.EnvVar{Name: "AZURE_CLIENT_SECRET", Value: credentials.Azure.ClientSecret})
-		vars = append(vars, corev1.EnvVar{Name: "AZURE_TENANT_ID", Value: credentials.Azure.TenantID})
-		vars = append(vars, corev1.EnvVar{Name: "AZURE_SUBSCRIPTION_ID", Value: credentials.Azure.SubscriptionID})
+		vars = append(vars, resources.CloudCredentialEnvVar("AZURE_CLIENT_ID"))
+		vars = append(vars, resources.CloudCredentialEnvVar("AZURE_CLIENT_SECRET"))
+		vars = append(vars, resources.CloudCredentialEnvVar("AZURE_TENANT_ID"))
+		vars = append(vars, resources.CloudCredentialEnvVar("AZURE_SUBSCRIPTION_ID"))
 	}
 	if data.Cluster().Spec.Cloud.Openstack != nil {
 		vars = append(vars, corev1.EnvVar{Name: "OS_AUTH_URL", Value: data.DC().Spec.Openstack.AuthURL})
-		vars = append(vars, corev1.EnvVar{Name: "OS_USER_NAME", Value: credentials.Openstack.Username})
-		vars = append(vars, corev1.EnvVar{Name: "OS_PASSWORD", Value: credentials.Openstack.Password})
-		vars = append(vars, corev1.EnvVar{Name: "OS_DOMAIN_NAME", Value: credentials.Openstack.Domain})
-		vars = append(vars, corev1.EnvVar{Name: "OS_TENANT_NAME", Value: credentials.Openstack.Tenant})
-		vars = append(vars, corev1.EnvVar{Name: "OS_TENANT_ID", Value: credentials.Openstack.TenantID})
+		vars = append(vars, resources.CloudCredentialEnvVar("OS_USER_NAME"))
+		vars = append(vars, resources.CloudCredentialEnvVar("OS_PASSWORD"))
+		vars = append(vars, resources.CloudCredentialEnvVar("OS_DOMAIN_NAME"))
+		vars = append(vars, resources.CloudCredentialEnvVar("OS_TENANT_NAME"))
+		vars = append(vars, resources.CloudCredentialEnvVar("OS_TENANT_ID"))
 	}
 	if data.Cluster().Spec.Cloud.Hetzner != nil {
-		vars = append(vars, corev1.EnvVar{Name: "HZ_TOKEN", Value: credentials.Hetzner.Token})
+		vars = append(vars, resources.CloudCredentialEnvVar("HZ_TOKEN"))
 	}
 	if data.Cluster().Spec.Cloud.Digitalocean != nil {
-		vars = append(vars, corev1.EnvVar{Name: "DO_TOKEN", Value: credentials.Digitalocean.Token})
+		vars = append(vars, resources.CloudCredentialEnvVar("DO_TOKEN"))
 	}
 	if data.Cluster().Spec.Cloud.VSphere != nil {
 		vars = append(vars, corev1.EnvVar{Name: "VSPHERE_ADDRESS", Value: data.DC().Spec.VSphere.Endpoint})
-		vars = append(vars, corev1.EnvVar{Name: "VSPHERE_USERNAME", Value: credentials.VSphere.Username})
-		vars = append(vars, corev1.EnvVar{Name: "VSPHERE_PASSWORD", Value: credentials.VSphere.Password})
+		vars = append(vars, resources.CloudCredentialEnvVar("VSPHERE_USERNAME"))
+		vars = append(vars, resources.CloudCredentialEnvVar("VSPHERE_PASSWORD"))
 	}
 	if data.Cluster().Spec.Cloud.Packet != nil {
-		vars = append(vars, corev1.EnvVar{Name: "PACKET_API_KEY", Value: credentials.Packet.APIKey})
-		vars = append(vars, corev1.EnvVar{Name: "PACKET_PROJECT_ID", Value: credentials.Packet.ProjectID})
+		vars = append(vars, resources.CloudCredentialEnvVar("PACKET_API_KEY"))
+		vars = append(vars, resources.CloudCredentialEnvVar("PACKET_PROJECT_ID"))
 	}
 	if data.Cluster().Spec.Cloud.GCP != nil {
-		vars = append(vars, corev1.EnvVar{Name: "GOOGLE_SERVICE_ACCOUNT", Value: credentials.GCP.ServiceAccount})
+		vars = append(vars, resources.CloudCredentialEnvVar("GOOGLE_SERVICE_ACCOUNT"))
 	}
 	if data.Cluster().Spec.Cloud.Kubevirt != nil {
-		vars = append(vars, corev1.EnvVar{Name: "KUBEVIRT_KUBECONFIG", Value: credentials.Kubevirt.KubeConfig})
+		vars = append(vars, resources.CloudCredentialEnvVar("KUBEVIRT_KUBECONFIG"))
 	}
 	if data.Cluster().Spec.Cloud.Alibaba != nil {
-		vars = append(vars, corev1.EnvVar{Name: "ALIBABA_ACCESS_KEY_ID", Value: credentials.Alibaba.AccessKeyID})
-		vars = append(vars, corev1.EnvVar{Name: "ALIBABA_ACCESS_KEY_SECRET", Value: credentials.Alibaba.AccessKeySecret})
+		vars = append(vars, resources.CloudCredentialEnvVar("ALIBABA_ACCESS_KEY_ID"))
+		vars = append(vars, resources.CloudCredentialEnvVar("ALIBABA_ACCESS_KEY_SECRET"))
+	}
+	if injector != nil {
+		vars = withoutCloudCredentialEnvVars(vars)
+	}
+	return append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...)
+}
+
+// withoutCloudCredentialEnvVars returns the environment variables without the ones read from the
+// cloud credentials secret
+func withoutCloudCredentialEnvVars(vars []corev1.EnvVar) []corev1.EnvVar {
+	var filtered []corev1.EnvVar
+	for _, v := range vars {
+		if v.ValueFrom != nil && v.ValueFrom.SecretKeyRef != nil && v.ValueFrom.SecretKeyRef.Name == resources.CloudCredentialsSecretName {
+			continue
+		}
+		filtered = append(filtered, v)
 	}
-	vars = append(vars, resources.GetHTTPProxyEnvVarsFromSeed(data.Seed(), data.Cluster().Address.InternalName)...)
-	return vars, nil
+	return filtered
 }
 
 func getFlags(clusterDNSIP string, nodeSettings kubermaticv1.NodeSettings, externalCloudProvider bool) []string {
@@ -23,6 +23,7 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/apiserver"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/certificates/triple"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -61,31 +62,35 @@ func WebhookDeploymentCreator(data machinecontrollerData) reconciling.NamedDeplo
 			}
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
-			envVars, err := getEnvVars(data)
+			injector, err := data.CloudCredentialsInjector()
 			if err != nil {
 				return nil, err
 			}
 
 			volumes := []corev1.Volume{getKubeconfigVolume(), getServingCertVolume()}
 			dep.Spec.Template.Spec.Volumes = volumes
-			podLabels, err := data.GetPodTemplateLabels(resources.MachineControllerWebhookDeploymentName, volumes, nil)
+			podLabels, err := data.GetPodTemplateLabels(resources.MachineControllerWebhookDeploymentName, revisionVolumes(volumes, injector), nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create pod labels: %v", err)
 			}
-			dep.Spec.Template.ObjectMeta = metav1.ObjectMeta{Labels: podLabels}
+			injectionAnnotations, err := cloudconfig.CredentialsInjectionAnnotations(data)
+			if err != nil {
+				return nil, err
+			}
+			dep.Spec.Template.ObjectMeta = metav1.ObjectMeta{Labels: podLabels, Annotations: injectionAnnotations}
 
 			dep.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    Name,
 					Image:   data.ImageRegistry(resources.RegistryDocker) + "/kubermatic/machine-controller:" + tag,
-					Command: []string{"/usr/local/bin/webhook"},
+					Command: command(injector, "/usr/local/bin/webhook"),
 					Args: []string{
 						"-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 						"-logtostderr",
 						"-v", "4",
 						"-listen-address", "0.0.0.0:9876",
 					},
-					Env: append(envVars, corev1.EnvVar{
+					Env: append(getEnvVars(data, injector), corev1.EnvVar{
 						Name:  "KUBECONFIG",
 						Value: "/etc/kubernetes/kubeconfig/kubeconfig",
 					}),
@@ -119,6 +119,8 @@ const (
 	EtcdDefragCronJobName = "etcd-defragger"
 	//OpenVPNServerServiceName is the name for the openvpn server service
 	OpenVPNServerServiceName = "openvpn-server"
+	// KonnectivityServerServiceName is the name for the service the konnectivity agents connect to
+	KonnectivityServerServiceName = "konnectivity-server"
 	//MachineControllerWebhookServiceName is the name of the machine-controller webhook service
 	MachineControllerWebhookServiceName = "machine-controller-webhook"
 
@@ -181,8 +183,18 @@ const (
 	OpenVPNServerCertificatesSecretName = "openvpn-server-certificates"
 	//OpenVPNClientCertificatesSecretName is the name for the secret containing the openvpn client certificates
 	OpenVPNClientCertificatesSecretName = "openvpn-client-certificates"
+	// KonnectivityCASecretName is the name for the secret containing the CA which signs the certificates of the konnectivity server and agents
+	KonnectivityCASecretName = "konnectivity-ca"
+	// KonnectivityServerCertificatesSecretName is the name for the secret containing the certificate the konnectivity server serves to the agents
+	KonnectivityServerCertificatesSecretName = "konnectivity-server-certificates"
+	// KonnectivityAgentCertificatesSecretName is the name for the secret containing the client certificate of the konnectivity agent inside the user cluster
+	KonnectivityAgentCertificatesSecretName = "konnectivity-agent-certificates"
 	//CloudConfigSecretName is the name for the secret containing the cloud-config inside the user cluster.
 	CloudConfigSecretName = "cloud-config"
+	// CloudConfigSeedSecretName is the name for the secret containing the cloud-config inside the seed cluster
+	CloudConfigSeedSecretName = "cloud-config"
+	// CloudConfigSeedSecretKey is the key under which the cloud-config in the cloud-config secret can be found
+	CloudConfigSeedSecretKey = "config"
 	//EtcdTLSCertificateSecretName is the name for the secret containing the etcd tls certificate used for transport security
 	EtcdTLSCertificateSecretName = "etcd-tls-certificate"
 	//ApiserverEtcdClientCertificateSecretName is the name for the secret containing the client certificate used by the apiserver for authenticating against etcd
@@ -193,6 +205,8 @@ const (
 	DexCASecretName = "dex-ca"
 	// DexCAFileName is the name of Dex CA bundle file
 	DexCAFileName = "caBundle.pem"
+	// CloudCredentialsSecretName is the name of the secret that contains the cloud credentials of the cluster
+	CloudCredentialsSecretName = "cloud-credentials"
 	// GoogleServiceAccountSecretName is the name of the secret that contains the Google Service Acccount.
 	GoogleServiceAccountSecretName = "google-service-account"
 	// GoogleServiceAccountVolumeName is the name of the volume containing the Google Service Account secret.
@@ -206,20 +220,26 @@ const (
 	// the Kubernetes Dashboard
 	KubernetesDashboardCsrfTokenSecretName = "kubernetes-dashboard-csrf"
 
-	// CloudConfigConfigMapName is the name for the configmap containing the cloud-config
-	CloudConfigConfigMapName = "cloud-config"
-	// CloudConfigConfigMapKey is the key under which the cloud-config in the cloud-config configmap can be found
-	CloudConfigConfigMapKey = "config"
 	//OpenVPNClientConfigsConfigMapName is the name for the ConfigMap containing the OpenVPN client config used within the user cluster
 	OpenVPNClientConfigsConfigMapName = "openvpn-client-configs"
 	//OpenVPNClientConfigConfigMapName is the name for the ConfigMap containing the OpenVPN client config used by the client inside the user cluster
 	OpenVPNClientConfigConfigMapName = "openvpn-client-config"
+	// KonnectivityEgressSelectorConfigMapName is the name for the ConfigMap containing the egress selector configuration of the apiserver
+	KonnectivityEgressSelectorConfigMapName = "konnectivity-egress-selector"
+	// KonnectivityAgentConfigConfigMapName is the name for the ConfigMap containing the address of the konnectivity server used by the agent inside the user cluster
+	KonnectivityAgentConfigConfigMapName = "konnectivity-agent-config"
 	//ClusterInfoConfigMapName is the name for the ConfigMap containing the cluster-info used by the bootstrap token machanism
 	ClusterInfoConfigMapName = "cluster-info"
 	//PrometheusConfigConfigMapName is the name for the configmap containing the prometheus config
 	PrometheusConfigConfigMapName = "prometheus"
 	//AuditConfigMapName is the name for the configmap that contains the content of the file that will be passed to the apiserver with the flag "--audit-policy-file".
 	AuditConfigMapName = "audit-config"
+	// AuditWebhookConfigSecretName is the name of the secret containing the kubeconfig that will be passed to the
+	// apiserver with the flag "--audit-webhook-config-file".
+	AuditWebhookConfigSecretName = "audit-webhook-config"
+	// EncryptionConfigurationSecretName is the name of the secret containing the encryption keys and the
+	// file that will be passed to the apiserver with the flag "--encryption-provider-config"
+	EncryptionConfigurationSecretName = "apiserver-encryption-configuration"
 
 	//PrometheusServiceAccountName is the name for the Prometheus serviceaccount
 	PrometheusServiceAccountName = "prometheus"
@@ -330,6 +350,8 @@ const (
 	RegistryDocker = "docker.io"
 	// RegistryQuay defines the image registry from coreos/redhat - quay
 	RegistryQuay = "quay.io"
+	// RegistryUSGCR defines the kubernetes artifacts registry at google
+	RegistryUSGCR = "us.gcr.io"
 
 	// TopologyKeyHostname defines the topology key for the node hostname
 	TopologyKeyHostname = "kubernetes.io/hostname"
@@ -366,6 +388,14 @@ const (
 	CAKeySecretKey = "ca.key"
 	// CACertSecretKey ca.crt
 	CACertSecretKey = "ca.crt"
+	// CABundleSecretKey holds all CAs which are trusted during a certificate rotation
+	CABundleSecretKey = "ca-bundle.crt"
+	// CANextCertSecretKey holds the CA which replaces the current one during a certificate rotation
+	CANextCertSecretKey = "ca-next.crt"
+	// CANextKeySecretKey holds the key of the CA which replaces the current one during a certificate rotation
+	CANextKeySecretKey = "ca-next.key"
+	// CACrossSignedCertSecretKey holds the current CA signed by the previous one during a certificate rotation
+	CACrossSignedCertSecretKey = "ca-cross.crt"
 	// ApiserverTLSKeySecretKey apiserver-tls.key
 	ApiserverTLSKeySecretKey = "apiserver-tls.key"
 	// ApiserverTLSCertSecretKey apiserver-tls.crt
@@ -378,6 +408,14 @@ const (
 	ServiceAccountKeySecretKey = "sa.key"
 	// ServiceAccountKeyPublicKey is the public key for the service account signer key
 	ServiceAccountKeyPublicKey = "sa.pub"
+	// ServiceAccountKeyBundleSecretKey holds all public keys which are accepted during a certificate rotation
+	ServiceAccountKeyBundleSecretKey = "sa-bundle.pub"
+	// ServiceAccountNextKeySecretKey holds the key which replaces the current one during a certificate rotation
+	ServiceAccountNextKeySecretKey = "sa-next.key"
+	// ServiceAccountNextPublicKey holds the public key which replaces the current one during a certificate rotation
+	ServiceAccountNextPublicKey = "sa-next.pub"
+	// EncryptionConfigurationSecretKey encryption-configuration.yaml
+	EncryptionConfigurationSecretKey = "encryption-configuration.yaml"
 	// KubeconfigSecretKey kubeconfig
 	KubeconfigSecretKey = "kubeconfig"
 	// TokensSecretKey tokens.csv
@@ -398,6 +436,12 @@ const (
 	OpenVPNInternalClientKeySecretKey = "client.key"
 	// OpenVPNInternalClientCertSecretKey client.crt
 	OpenVPNInternalClientCertSecretKey = "client.crt"
+	// KonnectivityAgentCertSecretKey agent.crt
+	KonnectivityAgentCertSecretKey = "agent.crt"
+	// KonnectivityAgentKeySecretKey agent.key
+	KonnectivityAgentKeySecretKey = "agent.key"
+	// KonnectivityEgressSelectorConfigMapKey egress-selector-configuration.yaml
+	KonnectivityEgressSelectorConfigMapKey = "egress-selector-configuration.yaml"
 	// EtcdTLSCertSecretKey etcd-tls.crt
 	EtcdTLSCertSecretKey = "etcd-tls.crt"
 	// EtcdTLSKeySecretKey etcd-tls.key
@@ -587,6 +631,12 @@ type userClusterDNSPolicyAndConfigData interface {
 
 // UserClusterDNSPolicyAndConfig returns a DNSPolicy and DNSConfig to configure Pods to use user cluster DNS
 func UserClusterDNSPolicyAndConfig(d userClusterDNSPolicyAndConfigData) (corev1.DNSPolicy, *corev1.PodDNSConfig, error) {
+	// With Konnectivity there is no DNS resolver in the seed which can reach the user cluster DNS. The
+	// apiserver resolves services on its own and sends the traffic through the konnectivity server.
+	if d.Cluster().IsKonnectivityEnabled() {
+		return corev1.DNSClusterFirst, nil, nil
+	}
+
 	// DNSNone indicates that the pod should use empty DNS settings. DNS
 	// parameters such as nameservers and search paths should be defined via
 	// DNSConfig.
@@ -633,6 +683,18 @@ func AppClusterLabels(appName, clusterName string, additionalLabels map[string]s
 	return podLabels
 }
 
+// AddCABundleToVolumes adds the CA bundle to the volumes which only project the certificate of the
+// cluster CA, so components can trust the old and the new CA during a certificate rotation.
+func AddCABundleToVolumes(volumes []corev1.Volume) {
+	for idx := range volumes {
+		secret := volumes[idx].Secret
+		if secret == nil || secret.SecretName != CASecretName || len(secret.Items) == 0 {
+			continue
+		}
+		secret.Items = append(secret.Items, corev1.KeyToPath{Path: CABundleSecretKey, Key: CABundleSecretKey})
+	}
+}
+
 // CertWillExpireSoon returns if the certificate will expire in the next 30 days
 func CertWillExpireSoon(cert *x509.Certificate) bool {
 	return time.Until(cert.NotAfter) < minimumCertValidity30d
@@ -785,11 +847,26 @@ func GetClusterRootCA(ctx context.Context, namespace string, client ctrlruntimec
 	return getRSAClusterCAFromLister(ctx, namespace, CASecretName, client)
 }
 
+// GetClusterRootCACrossSignedCert returns the PEM encoded root CA of the cluster signed by the previous
+// root CA. It only exists during a certificate rotation, otherwise nil is returned.
+func GetClusterRootCACrossSignedCert(ctx context.Context, namespace string, client ctrlruntimeclient.Client) ([]byte, error) {
+	caSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get CA secret: %v", err)
+	}
+	return caSecret.Data[CACrossSignedCertSecretKey], nil
+}
+
 // GetClusterFrontProxyCA returns the frontproxy CA of the cluster from the lister
 func GetClusterFrontProxyCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
 	return getRSAClusterCAFromLister(ctx, namespace, FrontProxyCASecretName, client)
 }
 
+// GetKonnectivityCA returns the CA the konnectivity server and agents authenticate each other with
+func GetKonnectivityCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
+	return getRSAClusterCAFromLister(ctx, namespace, KonnectivityCASecretName, client)
+}
+
 // GetOpenVPNCA returns the OpenVPN CA of the cluster from the lister
 func GetOpenVPNCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*ECDSAKeyPair, error) {
 	return getECDSAClusterCAFromLister(ctx, namespace, OpenVPNCASecretName, client)
@@ -57,13 +57,19 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			dep.Name = resources.SchedulerDeploymentName
 			dep.Labels = resources.BaseAppLabels(name, nil)
 
+			// During a certificate rotation the old and the new CA are trusted
+			clientCAFile := "/etc/kubernetes/pki/ca/" + resources.CACertSecretKey
+			if data.Cluster().IsCertificateRotationInProgress() {
+				clientCAFile = "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey
+			}
+
 			flags := []string{
 				"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				// These are used to validate tokens
 				"--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				"--authorization-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 				// This is used to validate certs
-				"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+				"--client-ca-file", clientCAFile,
 				// We're going to use the https endpoints for scraping the metrics starting from 1.13. Thus we can deactivate the http endpoint
 				"--port", "0",
 				// Force the authentication lookup to succeed, otherwise if it fails all requests will be treated as anonymous and thus fail
@@ -80,6 +86,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			}
 
 			volumes := getVolumes()
+			if data.Cluster().IsKonnectivityEnabled() {
+				volumes = vpnsidecar.WithoutSidecarVolumes(volumes)
+			}
+			if data.Cluster().IsCertificateRotationInProgress() {
+				resources.AddCABundleToVolumes(volumes)
+			}
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create pod labels: %v", err)
@@ -96,7 +108,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				},
 			}
 
-			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
+			sidecars, err := vpnsidecar.OpenVPNSidecarContainers(data, "openvpn-client")
 			if err != nil {
 				return nil, fmt.Errorf("failed to get openvpn sidecar: %v", err)
 			}
@@ -115,9 +127,8 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				Port:   intstr.FromInt(10259),
 			}
 
-			dep.Spec.Template.Spec.Containers = []corev1.Container{
-				*openvpnSidecar,
-				{
+			dep.Spec.Template.Spec.Containers = append(sidecars,
+				corev1.Container{
 					Name:    resources.SchedulerDeploymentName,
 					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
 					Command: []string{"/hyperkube", "kube-scheduler"},
@@ -154,10 +165,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 						TimeoutSeconds:      15,
 					},
 				},
-			}
+			)
 			defResourceRequirements := map[string]*corev1.ResourceRequirements{
-				name:                defaultResourceRequirements.DeepCopy(),
-				openvpnSidecar.Name: openvpnSidecar.Resources.DeepCopy(),
+				name: defaultResourceRequirements.DeepCopy(),
+			}
+			for _, sidecar := range sidecars {
+				defResourceRequirements[sidecar.Name] = sidecar.Resources.DeepCopy()
 			}
 			err = resources.SetResourceRequirements(dep.Spec.Template.Spec.Containers, defResourceRequirements, resources.GetOverrides(data.Cluster().Spec.ComponentsOverride), dep.Annotations)
 			if err != nil {
@@ -35,6 +35,7 @@ import (
 	monitoringcontroller "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/monitoring"
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	"github.com/kubermatic/kubermatic/api/pkg/resources/cloudconfig"
 	metricsserver "github.com/kubermatic/kubermatic/api/pkg/resources/metrics-server"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 	ksemver "github.com/kubermatic/kubermatic/api/pkg/semver"
@@ -261,6 +262,20 @@ func TestLoadFiles(t *testing.T) {
 							Namespace:       cluster.Status.NamespaceName,
 						},
 					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							ResourceVersion: "123456",
+							Name:            resources.CloudConfigSeedSecretName,
+							Namespace:       cluster.Status.NamespaceName,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							ResourceVersion: "123456",
+							Name:            resources.CloudCredentialsSecretName,
+							Namespace:       cluster.Status.NamespaceName,
+						},
+					},
 					&corev1.Secret{
 						ObjectMeta: metav1.ObjectMeta{
 							ResourceVersion: "123456",
@@ -436,13 +451,6 @@ func TestLoadFiles(t *testing.T) {
 							Namespace:       cluster.Status.NamespaceName,
 						},
 					},
-					&corev1.ConfigMap{
-						ObjectMeta: metav1.ObjectMeta{
-							ResourceVersion: "123456",
-							Name:            resources.CloudConfigConfigMapName,
-							Namespace:       cluster.Status.NamespaceName,
-						},
-					},
 					&corev1.ConfigMap{
 						ObjectMeta: metav1.ObjectMeta{
 							ResourceVersion: "123456",
@@ -581,7 +589,8 @@ func TestLoadFiles(t *testing.T) {
 					true,
 					"quay.io/kubermatic/kubermatic",
 					"quay.io/kubermatic/kubeletdnat-controller",
-					false)
+					false,
+					nil)
 
 				var deploymentCreators []reconciling.NamedDeploymentCreatorGetter
 				deploymentCreators = append(deploymentCreators, kubernetescontroller.GetDeploymentCreators(data, true)...)
@@ -613,6 +622,13 @@ func TestLoadFiles(t *testing.T) {
 					checkTestResult(t, fixturePath, res)
 				}
 
+				cloudConfigName, createCloudConfig := cloudconfig.SecretCreator(data)()
+				cloudConfig, err := createCloudConfig(&corev1.Secret{})
+				if err != nil {
+					t.Fatalf("failed to create Secret: %v", err)
+				}
+				checkTestResult(t, fmt.Sprintf("secret-%s-%s-%s", prov, ver.Version.String(), cloudConfigName), cloudConfig)
+
 				serviceCreators := kubernetescontroller.GetServiceCreators(data)
 				for _, creatorGetter := range serviceCreators {
 					name, create := creatorGetter()
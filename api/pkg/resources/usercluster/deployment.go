@@ -60,6 +60,7 @@ type userclusterControllerData interface {
 	ImageRegistry(string) string
 	Cluster() *kubermaticv1.Cluster
 	GetOpenVPNServerPort() (int32, error)
+	GetKonnectivityServerPort() (int32, error)
 	KubermaticAPIImage() string
 	GetKubernetesCloudProviderName() string
 	CloudCredentialSecretTemplate() ([]byte, error)
@@ -91,7 +92,7 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 			}
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
-			openvpnServerPort, err := data.GetOpenVPNServerPort()
+			tunnelArgs, err := getTunnelArgs(data)
 			if err != nil {
 				return nil, err
 			}
@@ -124,13 +125,15 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 				"-namespace", "$(NAMESPACE)",
 				"-cluster-url", data.Cluster().Address.URL,
 				"-dns-cluster-ip", dnsClusterIP,
-				"-openvpn-server-port", fmt.Sprint(openvpnServerPort),
+			}, tunnelArgs...)
+			args = append(args,
 				"-overwrite-registry", data.ImageRegistry(""),
 				fmt.Sprintf("-openshift=%t", openshift),
 				"-version", data.Cluster().Spec.Version.String(),
 				"-cloud-provider-name", data.GetKubernetesCloudProviderName(),
 				"-owner-email", data.Cluster().Status.UserEmail,
-			}, getNetworkArgs(data)...)
+			)
+			args = append(args, getNetworkArgs(data)...)
 
 			if openshiftConsoleCallbackURI := data.Cluster().Address.OpenshiftConsoleCallBack; openshiftConsoleCallbackURI != "" {
 				args = append(args, "-openshift-console-callback-uri", openshiftConsoleCallbackURI)
@@ -140,6 +143,23 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 				args = append(args, "-update-window-start", data.Cluster().Spec.UpdateWindow.Start, "-update-window-length", data.Cluster().Spec.UpdateWindow.Length)
 			}
 
+			if eventExport := data.Cluster().Spec.EventExport; eventExport != nil && eventExport.Enabled {
+				sink := eventExport.Sink
+				if sink == "" {
+					sink = kubermaticv1.EventExportSinkTypeSeed
+				}
+				args = append(args, "-event-export-sink", string(sink))
+				switch sink {
+				case kubermaticv1.EventExportSinkTypeSeed:
+					args = append(args, "-cluster-name", data.Cluster().Name)
+					if eventExport.Retention != nil {
+						args = append(args, "-event-export-retention", eventExport.Retention.Duration.String())
+					}
+				case kubermaticv1.EventExportSinkTypeHTTP:
+					args = append(args, "-event-export-url", eventExport.URL)
+				}
+			}
+
 			labelArgsValue, err := getLabelsArgValue(data.Cluster())
 			if err != nil {
 				return nil, fmt.Errorf("faild to get label args value: %v", err)
@@ -225,6 +245,26 @@ func getVolumes() []corev1.Volume {
 	}
 }
 
+// getTunnelArgs returns the flags telling the controller which tunnel the control plane uses to reach the nodes
+func getTunnelArgs(data userclusterControllerData) ([]string, error) {
+	if data.Cluster().IsKonnectivityEnabled() {
+		konnectivityServerPort, err := data.GetKonnectivityServerPort()
+		if err != nil {
+			return nil, err
+		}
+		return []string{
+			"-tunnel-mode", string(kubermaticv1.TunnelModeKonnectivity),
+			"-konnectivity-server-port", fmt.Sprint(konnectivityServerPort),
+		}, nil
+	}
+
+	openvpnServerPort, err := data.GetOpenVPNServerPort()
+	if err != nil {
+		return nil, err
+	}
+	return []string{"-openvpn-server-port", fmt.Sprint(openvpnServerPort)}, nil
+}
+
 func getNetworkArgs(data userclusterControllerData) []string {
 	networkFlags := make([]string, len(data.Cluster().Spec.MachineNetworks)*2)
 	i := 0
@@ -21,6 +21,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	openshiftresources "github.com/kubermatic/kubermatic/api/pkg/controller/seed-controller-manager/openshift/resources"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
 	"github.com/kubermatic/kubermatic/api/pkg/resources/reconciling"
 )
@@ -29,6 +30,9 @@ const (
 	serviceAccountName = "kubermatic-usercluster-controller-manager"
 	roleName           = "kubermatic:usercluster-controller-manager"
 	roleBindingName    = "kubermatic:usercluster-controller-manager"
+
+	// clusterEventsClusterRoleName allows to export events as ClusterEvents, which are cluster-scoped
+	clusterEventsClusterRoleName = "kubermatic:usercluster-controller-manager:cluster-events"
 )
 
 func ServiceAccountCreator() (string, reconciling.ServiceAccountCreator) {
@@ -90,3 +94,44 @@ func RoleBindingCreator() (string, reconciling.RoleBindingCreator) {
 		return rb, nil
 	}
 }
+
+// ClusterEventsClusterRoleCreator returns the ClusterRole which allows to export the events of the
+// user cluster to the seed. It is shared by all clusters.
+func ClusterEventsClusterRoleCreator() (string, reconciling.ClusterRoleCreator) {
+	return clusterEventsClusterRoleName, func(r *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+		r.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{kubermaticv1.GroupName},
+				Resources: []string{kubermaticv1.ClusterEventResourceName},
+				Verbs: []string{
+					"get",
+					"create",
+					"patch",
+				},
+			},
+		}
+		return r, nil
+	}
+}
+
+// ClusterEventsClusterRoleBindingCreator returns the ClusterRoleBinding which grants the events
+// ClusterRole to the user cluster controller manager of the given cluster.
+func ClusterEventsClusterRoleBindingCreator(cluster *kubermaticv1.Cluster) reconciling.NamedClusterRoleBindingCreatorGetter {
+	return func() (string, reconciling.ClusterRoleBindingCreator) {
+		return clusterEventsClusterRoleName + ":" + cluster.Name, func(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+			crb.RoleRef = rbacv1.RoleRef{
+				Name:     clusterEventsClusterRoleName,
+				Kind:     "ClusterRole",
+				APIGroup: rbacv1.GroupName,
+			}
+			crb.Subjects = []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      serviceAccountName,
+					Namespace: cluster.Status.NamespaceName,
+				},
+			}
+			return crb, nil
+		}
+	}
+}
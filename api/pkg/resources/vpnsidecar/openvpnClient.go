@@ -86,3 +86,17 @@ func OpenVPNSidecarContainer(data openvpnData, name string) (*corev1.Container,
 		},
 	}, nil
 }
+
+// OpenVPNSidecarContainers returns the OpenVPN sidecar for clusters using the OpenVPN tunnel.
+// Clusters using Konnectivity get no sidecar, components which need the sidecar volumes have
+// to drop them with WithoutSidecarVolumes.
+func OpenVPNSidecarContainers(data openvpnData, name string) ([]corev1.Container, error) {
+	if data.Cluster().IsKonnectivityEnabled() {
+		return nil, nil
+	}
+	sidecar, err := OpenVPNSidecarContainer(data, name)
+	if err != nil {
+		return nil, err
+	}
+	return []corev1.Container{*sidecar}, nil
+}
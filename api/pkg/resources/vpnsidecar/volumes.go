@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpnsidecar
+
+import (
+	"github.com/kubermatic/kubermatic/api/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WithoutSidecarVolumes returns the given volumes without the ones only mounted by the sidecars.
+// Clusters using Konnectivity run no sidecars, so these secrets do not exist.
+func WithoutSidecarVolumes(volumes []corev1.Volume) []corev1.Volume {
+	var filtered []corev1.Volume
+	for _, volume := range volumes {
+		if volume.Name == resources.OpenVPNClientCertificatesSecretName || volume.Name == resources.KubeletDnatControllerKubeconfigSecretName {
+			continue
+		}
+		filtered = append(filtered, volume)
+	}
+	return filtered
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package vault implements a provider.SecretStore which keeps cloud credentials in HashiCorp Vault.
+
+Secrets are read from and written to the KV secrets engine using the Vault HTTP API, the path of a
+GlobalSecretKeySelector with the kind "VaultSecret" is the API path of the secret, e.g.
+"secret/data/kubermatic/cluster-abcd" for a KV version 2 engine mounted at "secret/".
+Kubermatic authenticates either with a static token or with the Kubernetes auth method using its
+service account token. Read secrets are cached for a short time to not hit Vault on every reconciliation.
+
+The control plane of a cluster never gets the credentials via Kubernetes Secrets. The Store renders
+them into the control plane pods instead, using annotations of the Vault agent injector which has to be
+deployed into the seed. The injected agents log in with the Kubernetes auth method using the role set
+by --vault-agent-role.
+*/
+package vault
@@ -0,0 +1,412 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+)
+
+const (
+	// Kind is the kind of GlobalSecretKeySelectors referring to secrets in Vault
+	Kind = "VaultSecret"
+
+	// AuthMethodToken authenticates with a static token
+	AuthMethodToken = "token"
+	// AuthMethodKubernetes authenticates with the service account token using the Kubernetes auth method
+	AuthMethodKubernetes = "kubernetes"
+
+	defaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// agentSecretsDir is the directory the Vault agent injector renders secrets into
+	agentSecretsDir = "/vault/secrets"
+)
+
+// Opts holds the configuration of the Vault secret store
+type Opts struct {
+	Address                 string
+	AuthMethod              string
+	Token                   string
+	KubernetesAuthPath      string
+	KubernetesRole          string
+	AgentRole               string
+	ServiceAccountTokenFile string
+	KVVersion               int
+	CacheTTL                time.Duration
+	CredentialsPath         string
+}
+
+func (o *Opts) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Address, "vault-address", "", "The address of the Vault server cloud credentials can be stored in, e.g. https://vault.example.com:8200. Vault is not used if empty.")
+	fs.StringVar(&o.AuthMethod, "vault-auth-method", AuthMethodKubernetes, fmt.Sprintf("The method to authenticate with Vault, either %q or %q", AuthMethodToken, AuthMethodKubernetes))
+	fs.StringVar(&o.Token, "vault-token", "", "The token to authenticate with Vault when using the token auth method")
+	fs.StringVar(&o.KubernetesAuthPath, "vault-kubernetes-auth-path", "kubernetes", "The path the Kubernetes auth method is mounted at in Vault")
+	fs.StringVar(&o.KubernetesRole, "vault-kubernetes-role", "", "The Vault role to log in with when using the Kubernetes auth method")
+	fs.StringVar(&o.AgentRole, "vault-agent-role", "", "The Vault role the Vault agent, which renders the cloud credentials into the control plane pods, logs in with using the Kubernetes auth method. Defaults to --vault-kubernetes-role.")
+	fs.StringVar(&o.ServiceAccountTokenFile, "vault-service-account-token-file", defaultServiceAccountTokenFile, "The service account token to log in with when using the Kubernetes auth method")
+	fs.IntVar(&o.KVVersion, "vault-kv-version", 2, "The version of the Vault KV secrets engine the credentials are stored in")
+	fs.DurationVar(&o.CacheTTL, "vault-cache-ttl", time.Minute, "How long secrets read from Vault are cached")
+	fs.StringVar(&o.CredentialsPath, "vault-credentials-path", "", "The Vault path the cloud credentials of new clusters are stored below instead of Kubernetes Secrets, e.g. secret/data/kubermatic. Credentials are stored in Kubernetes if empty.")
+}
+
+// SecretStores returns the secret stores for the configured Vault, it is nil if no Vault address is configured
+func (o *Opts) SecretStores() (*provider.SecretStores, error) {
+	if o.Address == "" {
+		return nil, nil
+	}
+
+	store, err := New(o)
+	if err != nil {
+		return nil, err
+	}
+	stores := &provider.SecretStores{Stores: map[string]provider.SecretStore{Kind: store}}
+	if o.CredentialsPath != "" {
+		stores.CredentialsKind = Kind
+		stores.CredentialsPathPrefix = o.CredentialsPath
+	}
+	return stores, nil
+}
+
+type cacheEntry struct {
+	data    map[string]string
+	expires time.Time
+}
+
+// Store is a provider.SecretStore for the Vault KV secrets engine
+type Store struct {
+	opts   Opts
+	client *http.Client
+	now    func() time.Time
+
+	lock        sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	cache       map[string]cacheEntry
+}
+
+var _ provider.SecretStore = &Store{}
+var _ provider.SecretStoreInjector = &Store{}
+
+// New returns a Vault secret store for the given options
+func New(opts *Opts) (*Store, error) {
+	if opts.Address == "" {
+		return nil, errors.New("the Vault address must not be empty")
+	}
+	switch opts.AuthMethod {
+	case AuthMethodToken:
+		if opts.Token == "" {
+			return nil, errors.New("a Vault token is required for the token auth method")
+		}
+	case AuthMethodKubernetes:
+		if opts.KubernetesRole == "" {
+			return nil, errors.New("a Vault role is required for the Kubernetes auth method")
+		}
+	default:
+		return nil, fmt.Errorf("unknown Vault auth method %q", opts.AuthMethod)
+	}
+	if opts.KVVersion != 1 && opts.KVVersion != 2 {
+		return nil, fmt.Errorf("unsupported Vault KV version %d", opts.KVVersion)
+	}
+
+	return &Store{
+		opts:   *opts,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+		cache:  map[string]cacheEntry{},
+	}, nil
+}
+
+// Get returns the value of the key of the secret stored at the given path
+func (s *Store) Get(ctx context.Context, path, key string) (string, error) {
+	data, err := s.read(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", path, key)
+	}
+	return value, nil
+}
+
+// Set stores the secret at the given path
+func (s *Store) Set(ctx context.Context, path string, data map[string]string) error {
+	var body interface{} = data
+	if s.opts.KVVersion == 2 {
+		body = map[string]interface{}{"data": data}
+	}
+	if _, err := s.request(ctx, http.MethodPost, path, body); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.cache, path)
+	return nil
+}
+
+// ValueTemplate returns a Vault agent template which renders into the value of the key of the secret at the given path
+func (s *Store) ValueTemplate(path, key string, escapes ...string) string {
+	var filters string
+	for i := 0; i+1 < len(escapes); i += 2 {
+		filters += fmt.Sprintf(" | replaceAll %s %s", strconv.Quote(escapes[i]), strconv.Quote(escapes[i+1]))
+	}
+	return s.template(path, key, filters)
+}
+
+// DecodedValueTemplate returns a Vault agent template which renders into the base64 decoded value of the key
+// of the secret at the given path
+func (s *Store) DecodedValueTemplate(path, key string) string {
+	return s.template(path, key, " | base64Decode")
+}
+
+func (s *Store) template(path, key, filters string) string {
+	data := ".Data"
+	if s.opts.KVVersion == 2 {
+		data = ".Data.data"
+	}
+	return fmt.Sprintf("{{ with secret %s }}{{ index %s %s%s }}{{ end }}", strconv.Quote(path), data, strconv.Quote(key), filters)
+}
+
+// PodAnnotations returns the annotations which make the Vault agent injector render the templates into
+// files of the pod before its containers start
+func (s *Store) PodAnnotations(path string, templates map[string]string) map[string]string {
+	role := s.opts.AgentRole
+	if role == "" {
+		role = s.opts.KubernetesRole
+	}
+	annotations := map[string]string{
+		"vault.hashicorp.com/agent-inject":            "true",
+		"vault.hashicorp.com/agent-pre-populate-only": "true",
+		"vault.hashicorp.com/auth-path":               "auth/" + s.opts.KubernetesAuthPath,
+		"vault.hashicorp.com/role":                    role,
+	}
+	for name, template := range templates {
+		annotations["vault.hashicorp.com/agent-inject-secret-"+name] = path
+		annotations["vault.hashicorp.com/agent-inject-template-"+name] = template
+	}
+	return annotations
+}
+
+// FilePath returns the path the Vault agent injector renders the file of the given name to
+func (s *Store) FilePath(name string) string {
+	return agentSecretsDir + "/" + name
+}
+
+// read returns the data of the secret at the given path, it is served from the cache if possible
+func (s *Store) read(ctx context.Context, path string) (map[string]string, error) {
+	s.lock.Lock()
+	entry, cached := s.cache[path]
+	s.lock.Unlock()
+	if cached && s.now().Before(entry.expires) {
+		return entry.data, nil
+	}
+
+	body, err := s.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("failed to decode secret %q: %v", path, err)
+	}
+	values := secret.Data
+	if s.opts.KVVersion == 2 {
+		nested, ok := secret.Data["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("secret %q has no data, is it stored in a KV version 2 engine?", path)
+		}
+		values = nested
+	}
+
+	data := make(map[string]string, len(values))
+	for k, v := range values {
+		if str, ok := v.(string); ok {
+			data[k] = str
+			continue
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key %q of secret %q: %v", k, path, err)
+		}
+		data[k] = string(raw)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cache[path] = cacheEntry{data: data, expires: s.now().Add(s.opts.CacheTTL)}
+	return data, nil
+}
+
+// request sends the request to Vault, a token obtained via the Kubernetes auth method is renewed once
+// if Vault rejects it
+func (s *Store) request(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	respBody, statusCode, err := s.doRequest(ctx, method, path, body)
+	if err == nil && statusCode == http.StatusForbidden && s.opts.AuthMethod == AuthMethodKubernetes {
+		s.lock.Lock()
+		s.token = ""
+		s.lock.Unlock()
+		respBody, statusCode, err = s.doRequest(ctx, method, path, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case statusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("permission denied to access %q: %s", path, vaultErrors(respBody))
+	case statusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("secret %q not found", path)
+	case statusCode >= 300:
+		return nil, fmt.Errorf("Vault responded with %d for %q: %s", statusCode, path, vaultErrors(respBody))
+	}
+	return respBody, nil
+}
+
+func (s *Store) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, int, error) {
+	token, err := s.getToken(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var reqBody []byte
+	if body != nil {
+		if reqBody, err = json.Marshal(body); err != nil {
+			return nil, 0, err
+		}
+	}
+	req, err := http.NewRequest(method, s.url(path), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("X-Vault-Token", token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request to Vault: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the response of Vault: %v", err)
+	}
+	return respBody, resp.StatusCode, nil
+}
+
+// getToken returns the token to authenticate with, logging in with the service account token if required
+func (s *Store) getToken(ctx context.Context) (string, error) {
+	if s.opts.AuthMethod == AuthMethodToken {
+		return s.opts.Token, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token != "" && (s.tokenExpiry.IsZero() || s.now().Before(s.tokenExpiry)) {
+		return s.token, nil
+	}
+
+	jwt, err := ioutil.ReadFile(s.opts.ServiceAccountTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the service account token: %v", err)
+	}
+	loginBody, err := json.Marshal(map[string]string{
+		"role": s.opts.KubernetesRole,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url(fmt.Sprintf("auth/%s/login", s.opts.KubernetesAuthPath)), bytes.NewReader(loginBody))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to Vault: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the login response of Vault: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to log in to Vault with role %q: %d %s", s.opts.KubernetesRole, resp.StatusCode, vaultErrors(respBody))
+	}
+
+	login := struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}{}
+	if err := json.Unmarshal(respBody, &login); err != nil {
+		return "", fmt.Errorf("failed to decode the login response of Vault: %v", err)
+	}
+	if login.Auth.ClientToken == "" {
+		return "", errors.New("Vault returned no token on login")
+	}
+
+	s.token = login.Auth.ClientToken
+	s.tokenExpiry = time.Time{}
+	if login.Auth.LeaseDuration > 0 {
+		// renew the token before it expires
+		lease := time.Duration(login.Auth.LeaseDuration) * time.Second
+		s.tokenExpiry = s.now().Add(lease - lease/10)
+	}
+	return s.token, nil
+}
+
+func (s *Store) url(path string) string {
+	return fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(s.opts.Address, "/"), strings.TrimPrefix(path, "/"))
+}
+
+// vaultErrors returns the errors of a Vault error response
+func vaultErrors(body []byte) string {
+	resp := struct {
+		Errors []string `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Errors) == 0 {
+		return string(body)
+	}
+	return strings.Join(resp.Errors, ", ")
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVault is a stand-in for a Vault dev server with a KV version 2 engine mounted at secret/
+// and the Kubernetes auth method mounted at kubernetes/
+type fakeVault struct {
+	lock        sync.Mutex
+	secrets     map[string]map[string]interface{}
+	validTokens map[string]bool
+	logins      int
+	reads       int
+}
+
+func newFakeVault() *fakeVault {
+	return &fakeVault{
+		secrets:     map[string]map[string]interface{}{},
+		validTokens: map[string]bool{"root": true},
+	}
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if path == "auth/kubernetes/login" {
+		login := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login["role"] != "kubermatic" || login["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid role or service account token"]}`))
+			return
+		}
+		f.logins++
+		token := "k8s-token-" + string(rune('0'+f.logins))
+		f.validTokens[token] = true
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"` + token + `","lease_duration":3600}}`))
+		return
+	}
+
+	if !f.validTokens[r.Header.Get("X-Vault-Token")] {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		f.reads++
+		secret, ok := f.secrets[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"data": secret, "metadata": map[string]interface{}{"version": 1}},
+		})
+	case http.MethodPost, http.MethodPut:
+		body := struct {
+			Data map[string]interface{} `json:"data"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Data == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.secrets[path] = body.Data
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeVault) revokeTokens() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.validTokens = map[string]bool{}
+}
+
+func TestStoreWithTokenAuth(t *testing.T) {
+	vault := newFakeVault()
+	vault.secrets["secret/data/kubermatic/cluster-abcd"] = map[string]interface{}{"accessKeyId": "id", "port": 8080}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	store, err := New(&Opts{Address: server.URL, AuthMethod: AuthMethodToken, Token: "root", KVVersion: 2, CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	value, err := store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "accessKeyId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "id" {
+		t.Errorf("expected value %q, got %q", "id", value)
+	}
+	if value, err = store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "port"); err != nil || value != "8080" {
+		t.Errorf("expected non-string values to be JSON encoded, got %q (%v)", value, err)
+	}
+	if vault.reads != 1 {
+		t.Errorf("expected the secret to be read once and then served from the cache, got %d reads", vault.reads)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "accessKeyId"); err != nil {
+		t.Fatal(err)
+	}
+	if vault.reads != 2 {
+		t.Errorf("expected the secret to be read again after the cache expired, got %d reads", vault.reads)
+	}
+
+	if _, err := store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "unknown"); err == nil || err.Error() != `secret "secret/data/kubermatic/cluster-abcd" has no key "unknown"` {
+		t.Errorf("expected error for unknown key, got %v", err)
+	}
+	if _, err := store.Get(ctx, "secret/data/kubermatic/missing", "accessKeyId"); err == nil || err.Error() != `secret "secret/data/kubermatic/missing" not found` {
+		t.Errorf("expected error for missing secret, got %v", err)
+	}
+
+	// writing a secret replaces the cached one
+	if err := store.Set(ctx, "secret/data/kubermatic/cluster-abcd", map[string]string{"accessKeyId": "new-id"}); err != nil {
+		t.Fatal(err)
+	}
+	if value, err = store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "accessKeyId"); err != nil || value != "new-id" {
+		t.Errorf("expected the written value %q, got %q (%v)", "new-id", value, err)
+	}
+}
+
+func TestStoreWithKubernetesAuth(t *testing.T) {
+	vault := newFakeVault()
+	vault.secrets["secret/data/kubermatic/cluster-abcd"] = map[string]interface{}{"token": "do-token"}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "vault")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("sa-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := New(&Opts{
+		Address:                 server.URL,
+		AuthMethod:              AuthMethodKubernetes,
+		KubernetesAuthPath:      "kubernetes",
+		KubernetesRole:          "kubermatic",
+		ServiceAccountTokenFile: tokenFile,
+		KVVersion:               2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if value, err := store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "token"); err != nil || value != "do-token" {
+		t.Fatalf("expected value %q, got %q (%v)", "do-token", value, err)
+	}
+	if vault.logins != 1 {
+		t.Errorf("expected one login, got %d", vault.logins)
+	}
+
+	// a revoked token is replaced by logging in again
+	vault.revokeTokens()
+	if value, err := store.Get(ctx, "secret/data/kubermatic/cluster-abcd", "token"); err != nil || value != "do-token" {
+		t.Fatalf("expected value %q after logging in again, got %q (%v)", "do-token", value, err)
+	}
+	if vault.logins != 2 {
+		t.Errorf("expected two logins, got %d", vault.logins)
+	}
+
+	store.opts.KubernetesRole = "unknown"
+	store.token = ""
+	if _, err := store.Get(ctx, "secret/data/kubermatic/other", "token"); err == nil || !strings.Contains(err.Error(), "invalid role or service account token") {
+		t.Errorf("expected login error, got %v", err)
+	}
+}
+
+func TestAgentInjection(t *testing.T) {
+	store, err := New(&Opts{
+		Address:            "http://127.0.0.1:8200",
+		AuthMethod:         AuthMethodKubernetes,
+		KubernetesAuthPath: "kubernetes",
+		KubernetesRole:     "kubermatic",
+		KVVersion:          2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := "secret/data/kubermatic/cluster-abcd"
+
+	expected := `{{ with secret "secret/data/kubermatic/cluster-abcd" }}{{ index .Data.data "password" | replaceAll "\\" "\\\\" | replaceAll "\"" "\\\"" }}{{ end }}`
+	if template := store.ValueTemplate(path, "password", `\`, `\\`, `"`, `\"`); template != expected {
+		t.Errorf("expected value template %s, got %s", expected, template)
+	}
+	expected = `{{ with secret "secret/data/kubermatic/cluster-abcd" }}{{ index .Data.data "serviceAccount" | base64Decode }}{{ end }}`
+	if template := store.DecodedValueTemplate(path, "serviceAccount"); template != expected {
+		t.Errorf("expected decoded value template %s, got %s", expected, template)
+	}
+
+	store.opts.KVVersion = 1
+	expected = `{{ with secret "kv/kubermatic/cluster-abcd" }}{{ index .Data "token" }}{{ end }}`
+	if template := store.ValueTemplate("kv/kubermatic/cluster-abcd", "token"); template != expected {
+		t.Errorf("expected KV version 1 value template %s, got %s", expected, template)
+	}
+
+	annotations := store.PodAnnotations(path, map[string]string{"cloud-config": "config"})
+	for key, value := range map[string]string{
+		"vault.hashicorp.com/agent-inject":                       "true",
+		"vault.hashicorp.com/auth-path":                          "auth/kubernetes",
+		"vault.hashicorp.com/role":                               "kubermatic",
+		"vault.hashicorp.com/agent-inject-secret-cloud-config":   path,
+		"vault.hashicorp.com/agent-inject-template-cloud-config": "config",
+	} {
+		if annotations[key] != value {
+			t.Errorf("expected annotation %s to be %q, got %q", key, value, annotations[key])
+		}
+	}
+
+	store.opts.AgentRole = "kubermatic-control-plane"
+	if role := store.PodAnnotations(path, nil)["vault.hashicorp.com/role"]; role != "kubermatic-control-plane" {
+		t.Errorf("expected the agent role to be used, got %q", role)
+	}
+	if filePath := store.FilePath("cloud-config"); filePath != "/vault/secrets/cloud-config" {
+		t.Errorf("expected the file to be rendered to /vault/secrets/cloud-config, got %s", filePath)
+	}
+}
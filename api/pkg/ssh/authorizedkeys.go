@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AuthorizedKey is the representation of a user ssh key inside the user cluster
+// secret which gets consumed by the user-ssh-keys-agent on the nodes.
+type AuthorizedKey struct {
+	PublicKey    string            `json:"publicKey"`
+	User         string            `json:"user,omitempty"`
+	ExpiresAt    *metav1.Time      `json:"expiresAt,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// NewAuthorizedKey returns the AuthorizedKey for the given user ssh key
+func NewAuthorizedKey(key *kubermaticv1.UserSSHKey) *AuthorizedKey {
+	return &AuthorizedKey{
+		PublicKey:    key.Spec.PublicKey,
+		User:         key.Spec.User,
+		ExpiresAt:    key.Spec.ExpiresAt,
+		NodeSelector: key.Spec.NodeSelector,
+	}
+}
+
+// Marshal encodes the key for the user ssh keys secret. Keys without any
+// restriction are encoded as the plain public key, so that agents which don't
+// know about targeting keep working.
+func (k *AuthorizedKey) Marshal() ([]byte, error) {
+	if k.User == "" && k.ExpiresAt == nil && len(k.NodeSelector) == 0 {
+		return []byte(k.PublicKey), nil
+	}
+	return json.Marshal(k)
+}
+
+// UnmarshalAuthorizedKey decodes a key from the user ssh keys secret. It accepts both
+// the plain public key and the JSON representation.
+func UnmarshalAuthorizedKey(data []byte) (*AuthorizedKey, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		return &AuthorizedKey{PublicKey: string(data)}, nil
+	}
+
+	key := &AuthorizedKey{}
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, fmt.Errorf("failed to decode authorized key: %v", err)
+	}
+	return key, nil
+}
+
+// Expired returns true if the key has an expiry date which lies before the given time.
+func (k *AuthorizedKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.Time.After(now)
+}
+
+// AppliesTo returns true if the key must be authorized for the given operating system
+// user on a node with the given labels.
+func (k *AuthorizedKey) AppliesTo(user string, nodeLabels map[string]string) bool {
+	if k.User != "" && k.User != user {
+		return false
+	}
+	return labels.SelectorFromSet(k.NodeSelector).Matches(labels.Set(nodeLabels))
+}
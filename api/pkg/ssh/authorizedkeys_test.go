@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAuthorizedKeyEncoding(t *testing.T) {
+	expiresAt := metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name          string
+		key           *AuthorizedKey
+		expectedPlain bool
+	}{
+		{
+			name:          "key without restrictions is encoded as plain public key",
+			key:           &AuthorizedKey{PublicKey: "ssh-rsa AAAA"},
+			expectedPlain: true,
+		},
+		{
+			name: "targeted key is encoded as json",
+			key: &AuthorizedKey{
+				PublicKey:    "ssh-rsa AAAA",
+				User:         "ubuntu",
+				ExpiresAt:    &expiresAt,
+				NodeSelector: map[string]string{"nodedeployment": "workers"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := tc.key.Marshal()
+			if err != nil {
+				t.Fatalf("failed to marshal key: %v", err)
+			}
+			if plain := string(data) == tc.key.PublicKey; plain != tc.expectedPlain {
+				t.Fatalf("expected plain encoding to be %v, got %q", tc.expectedPlain, string(data))
+			}
+
+			key, err := UnmarshalAuthorizedKey(data)
+			if err != nil {
+				t.Fatalf("failed to unmarshal key: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(key, tc.key) {
+				t.Fatalf("expected key %+v, got %+v", tc.key, key)
+			}
+		})
+	}
+}
+
+func TestAuthorizedKeyAppliesTo(t *testing.T) {
+	key := &AuthorizedKey{
+		PublicKey:    "ssh-rsa AAAA",
+		User:         "ubuntu",
+		NodeSelector: map[string]string{"nodedeployment": "workers"},
+	}
+
+	if !key.AppliesTo("ubuntu", map[string]string{"nodedeployment": "workers", "foo": "bar"}) {
+		t.Error("expected key to apply to matching user and node")
+	}
+	if key.AppliesTo("root", map[string]string{"nodedeployment": "workers"}) {
+		t.Error("expected key not to apply to other users")
+	}
+	if key.AppliesTo("ubuntu", map[string]string{"nodedeployment": "other"}) {
+		t.Error("expected key not to apply to nodes not matching the selector")
+	}
+	if !(&AuthorizedKey{PublicKey: "ssh-rsa AAAA"}).AppliesTo("root", nil) {
+		t.Error("expected key without restrictions to apply to all users and nodes")
+	}
+}
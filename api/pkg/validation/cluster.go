@@ -21,6 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strconv"
+	"strings"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	kuberneteshelper "github.com/kubermatic/kubermatic/api/pkg/kubernetes"
@@ -28,10 +31,15 @@ import (
 	"github.com/kubermatic/kubermatic/api/pkg/provider/cloud"
 	kubernetesprovider "github.com/kubermatic/kubermatic/api/pkg/provider/kubernetes"
 	"github.com/kubermatic/kubermatic/api/pkg/resources"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
+	"github.com/Masterminds/semver"
 	"github.com/coreos/locksmith/pkg/timeutil"
+	"github.com/robfig/cron"
 	"k8s.io/apimachinery/pkg/api/equality"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -49,6 +57,12 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 		return fmt.Errorf("invalid cloud spec: %v", err)
 	}
 
+	// Only Kubermatic itself may point a cluster at an external secret store, it does so when it
+	// stores the credentials of the cluster. Users could otherwise read the secrets of other clusters.
+	if ref := credentialsReference(spec.Cloud); ref != nil && provider.IsSecretStoreKind(ref.Kind) {
+		return fmt.Errorf("invalid cloud spec: credentials reference of kind %q is not allowed", ref.Kind)
+	}
+
 	if spec.Version.Semver() == nil || spec.Version.String() == "" {
 		return errors.New(`invalid cloud spec "Version" is required but was not specified`)
 	}
@@ -187,6 +201,12 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return fmt.Errorf("invalid cloud spec: %v", err)
 	}
 
+	if ref := credentialsReference(newCluster.Spec.Cloud); ref != nil && provider.IsSecretStoreKind(ref.Kind) {
+		if !equality.Semantic.DeepEqual(ref, credentialsReference(oldCluster.Spec.Cloud)) {
+			return fmt.Errorf("invalid cloud spec: changing a credentials reference of kind %q is not allowed", ref.Kind)
+		}
+	}
+
 	// We ignore the error, since we're here to check the new config, not the old one.
 	oldProviderName, _ := provider.ClusterCloudProviderName(oldCluster.Spec.Cloud)
 
@@ -199,7 +219,7 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return fmt.Errorf("changing to a different provider is not allowed")
 	}
 
-	secretKeySelectorFunc := provider.SecretKeySelectorValueFuncFactory(ctx, clusterProvider.GetSeedClusterAdminRuntimeClient())
+	secretKeySelectorFunc := provider.SecretKeySelectorValueFuncFactory(ctx, clusterProvider.GetSeedClusterAdminRuntimeClient(), clusterProvider.GetSecretStores())
 	cloudProvider, err := cloud.Provider(dc, secretKeySelectorFunc)
 	if err != nil {
 		return err
@@ -216,6 +236,55 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 	return nil
 }
 
+// credentialsReference returns the credentials reference of the cloud provider of the spec
+func credentialsReference(spec kubermaticv1.CloudSpec) *providerconfig.GlobalSecretKeySelector {
+	switch {
+	case spec.AWS != nil:
+		return spec.AWS.CredentialsReference
+	case spec.Azure != nil:
+		return spec.Azure.CredentialsReference
+	case spec.Digitalocean != nil:
+		return spec.Digitalocean.CredentialsReference
+	case spec.GCP != nil:
+		return spec.GCP.CredentialsReference
+	case spec.Hetzner != nil:
+		return spec.Hetzner.CredentialsReference
+	case spec.Openstack != nil:
+		return spec.Openstack.CredentialsReference
+	case spec.Packet != nil:
+		return spec.Packet.CredentialsReference
+	case spec.Kubevirt != nil:
+		return spec.Kubevirt.CredentialsReference
+	case spec.VSphere != nil:
+		return spec.VSphere.CredentialsReference
+	case spec.Alibaba != nil:
+		return spec.Alibaba.CredentialsReference
+	}
+	return nil
+}
+
+// ValidateCloudCredentials runs the credentials pre-flight check of the cloud provider, if it supports one.
+// It fails if the credentials got rejected, a permission Kubermatic needs is denied or the check could not
+// be performed, e.g. because the provider API is not reachable.
+func ValidateCloudCredentials(cloudProvider provider.CloudProvider, spec kubermaticv1.CloudSpec) error {
+	validator, ok := cloudProvider.(provider.CloudCredentialValidator)
+	if !ok {
+		return nil
+	}
+
+	report, err := validator.ValidateCloudCredentials(spec)
+	if err != nil {
+		return fmt.Errorf("could not verify the cloud credentials: %v", err)
+	}
+	if report.Valid {
+		return nil
+	}
+	if denied := report.DeniedPermissions(); len(denied) > 0 {
+		return fmt.Errorf("the cloud credentials are missing required permissions: %s", strings.Join(denied, ", "))
+	}
+	return fmt.Errorf("the cloud credentials are invalid: %s", report.Message)
+}
+
 // ValidateCloudSpec validates if the cloud spec is valid
 func ValidateCloudSpec(spec kubermaticv1.CloudSpec, dc *kubermaticv1.Datacenter) error {
 	if spec.DatacenterName == "" {
@@ -464,3 +533,176 @@ func ValidateUpdateWindow(updateWindow *kubermaticv1.UpdateWindow) error {
 	}
 	return nil
 }
+
+// ValidateHibernation validates the hibernation schedule of a cluster, if any
+func ValidateHibernation(hibernation *kubermaticv1.ClusterHibernationSpec) error {
+	if hibernation == nil || hibernation.Schedule == nil {
+		return nil
+	}
+	if _, err := cron.ParseStandard(hibernation.Schedule.Hibernate); err != nil {
+		return fmt.Errorf("invalid hibernate schedule %q: %v", hibernation.Schedule.Hibernate, err)
+	}
+	if _, err := cron.ParseStandard(hibernation.Schedule.Resume); err != nil {
+		return fmt.Errorf("invalid resume schedule %q: %v", hibernation.Schedule.Resume, err)
+	}
+	return nil
+}
+
+// ValidateClusterAutoscalerSettings validates the cluster-wide cluster-autoscaler settings, if any
+func ValidateClusterAutoscalerSettings(settings *kubermaticv1.ClusterAutoscalerSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.ScaleDownUtilizationThreshold != "" {
+		threshold, err := strconv.ParseFloat(settings.ScaleDownUtilizationThreshold, 64)
+		if err != nil {
+			return fmt.Errorf("invalid scale down utilization threshold %q: %v", settings.ScaleDownUtilizationThreshold, err)
+		}
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("scale down utilization threshold must be greater than 0 and at most 1, got %q", settings.ScaleDownUtilizationThreshold)
+		}
+	}
+	if settings.ScaleDownUnneededTime != nil && settings.ScaleDownUnneededTime.Duration < 0 {
+		return errors.New("scale down unneeded time must not be negative")
+	}
+	if settings.ScaleDownDelayAfterAdd != nil && settings.ScaleDownDelayAfterAdd.Duration < 0 {
+		return errors.New("scale down delay after add must not be negative")
+	}
+	return nil
+}
+
+// ValidateFinalBackupSettings validates the settings of the etcd snapshot taken before a cluster gets deleted.
+func ValidateFinalBackupSettings(settings *kubermaticv1.FinalBackupSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Retention != nil && settings.Retention.Duration <= 0 {
+		return errors.New("final backup retention must be greater than zero")
+	}
+	return nil
+}
+
+// ValidateEncryptionSettings validates the settings for encrypting the Secrets of a cluster.
+func ValidateEncryptionSettings(settings *kubermaticv1.ClusterEncryptionSpec) error {
+	if settings == nil {
+		return nil
+	}
+	switch settings.Provider {
+	case "", kubermaticv1.ClusterEncryptionProviderAESCBC, kubermaticv1.ClusterEncryptionProviderSecretbox:
+		return nil
+	default:
+		return fmt.Errorf("encryption provider must be one of %q or %q", kubermaticv1.ClusterEncryptionProviderAESCBC, kubermaticv1.ClusterEncryptionProviderSecretbox)
+	}
+}
+
+// ValidateTunnelMode validates the tunnel mode of a cluster. Konnectivity relies on the egress selector
+// of the apiserver, which needs Kubernetes 1.18, and is not supported for Openshift clusters.
+func ValidateTunnelMode(cluster *kubermaticv1.Cluster) error {
+	switch cluster.Spec.ClusterNetwork.TunnelMode {
+	case "", kubermaticv1.TunnelModeOpenVPN:
+		return nil
+	case kubermaticv1.TunnelModeKonnectivity:
+		if cluster.IsOpenshift() {
+			return errors.New("the Konnectivity tunnel mode is not supported for Openshift clusters")
+		}
+		if v := cluster.Spec.Version.Semver(); v != nil && v.LessThan(semver.MustParse("1.18.0")) {
+			return fmt.Errorf("the Konnectivity tunnel mode requires Kubernetes 1.18 or newer, got %s", v)
+		}
+		return nil
+	default:
+		return fmt.Errorf("tunnel mode must be one of %q or %q", kubermaticv1.TunnelModeOpenVPN, kubermaticv1.TunnelModeKonnectivity)
+	}
+}
+
+// ValidateAuditLoggingSettings validates the audit policy, webhook backend and log sink of a cluster.
+func ValidateAuditLoggingSettings(settings *kubermaticv1.AuditLoggingSettings) error {
+	if settings == nil {
+		return nil
+	}
+	switch settings.PolicyPreset {
+	case "", kubermaticv1.AuditPolicyPresetMetadata, kubermaticv1.AuditPolicyPresetRecommended, kubermaticv1.AuditPolicyPresetAll:
+	default:
+		return fmt.Errorf("audit policy preset must be one of %q, %q or %q", kubermaticv1.AuditPolicyPresetMetadata, kubermaticv1.AuditPolicyPresetRecommended, kubermaticv1.AuditPolicyPresetAll)
+	}
+	if settings.CustomPolicy != "" {
+		policy := struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}{}
+		if err := yaml.Unmarshal([]byte(settings.CustomPolicy), &policy); err != nil {
+			return fmt.Errorf("custom audit policy is not valid YAML: %v", err)
+		}
+		if !strings.HasPrefix(policy.APIVersion, "audit.k8s.io/") || policy.Kind != "Policy" {
+			return errors.New("custom audit policy must be an audit.k8s.io Policy")
+		}
+	}
+	if log := settings.Log; log != nil {
+		for name, value := range map[string]*int32{"max age": log.MaxAge, "max backups": log.MaxBackups, "max size": log.MaxSize} {
+			if value != nil && *value < 0 {
+				return fmt.Errorf("audit log %s must not be negative", name)
+			}
+		}
+	}
+	if webhook := settings.Webhook; webhook != nil {
+		if err := validateHTTPURL(webhook.URL); err != nil {
+			return fmt.Errorf("invalid audit webhook url: %v", err)
+		}
+		switch webhook.Mode {
+		case "", kubermaticv1.AuditWebhookModeBatch, kubermaticv1.AuditWebhookModeBlocking:
+		default:
+			return fmt.Errorf("audit webhook mode must be either %q or %q", kubermaticv1.AuditWebhookModeBatch, kubermaticv1.AuditWebhookModeBlocking)
+		}
+		if webhook.CABundle != "" {
+			if _, err := certutil.ParseCertsPEM([]byte(webhook.CABundle)); err != nil {
+				return fmt.Errorf("invalid audit webhook CA bundle: %v", err)
+			}
+		}
+	}
+	if sink := settings.Sink; sink != nil {
+		switch sink.Type {
+		case "", kubermaticv1.AuditLogSinkTypeStdout:
+		case kubermaticv1.AuditLogSinkTypeHTTP:
+			if err := validateHTTPURL(sink.URL); err != nil {
+				return fmt.Errorf("invalid audit log sink url: %v", err)
+			}
+		default:
+			return fmt.Errorf("audit log sink type must be either %q or %q", kubermaticv1.AuditLogSinkTypeStdout, kubermaticv1.AuditLogSinkTypeHTTP)
+		}
+	}
+	return nil
+}
+
+func validateHTTPURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("scheme must be either http or https")
+	}
+	if u.Host == "" {
+		return errors.New("host must not be empty")
+	}
+	return nil
+}
+
+// ValidateEventExportSettings validates the sink the events of a cluster are exported to.
+func ValidateEventExportSettings(settings *kubermaticv1.EventExportSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Retention != nil && settings.Retention.Duration <= 0 {
+		return errors.New("event export retention must be greater than zero")
+	}
+	switch settings.Sink {
+	case "", kubermaticv1.EventExportSinkTypeSeed:
+		return nil
+	case kubermaticv1.EventExportSinkTypeHTTP:
+		if err := validateHTTPURL(settings.URL); err != nil {
+			return fmt.Errorf("invalid event export url: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("event export sink must be either %q or %q", kubermaticv1.EventExportSinkTypeSeed, kubermaticv1.EventExportSinkTypeHTTP)
+	}
+}
@@ -20,8 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	ksemver "github.com/kubermatic/kubermatic/api/pkg/semver"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -112,6 +119,315 @@ func TestValidateCloudSpec(t *testing.T) {
 	}
 }
 
+func TestValidateCreateClusterSpecCredentialsReference(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  *providerconfig.GlobalSecretKeySelector
+		err  error
+	}{
+		{
+			name: "reference to a secret",
+			ref: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{Name: "credential-hetzner-abc", Namespace: "kubermatic"},
+			},
+			// the validation only fails because of the missing version
+			err: errors.New(`invalid cloud spec "Version" is required but was not specified`),
+		},
+		{
+			name: "reference to an external secret store",
+			ref: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{Kind: "VaultSecret", Name: "secret/data/kubermatic/credential-hetzner-other", Namespace: "kubermatic"},
+			},
+			err: errors.New(`invalid cloud spec: credentials reference of kind "VaultSecret" is not allowed`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := &kubermaticv1.ClusterSpec{
+				HumanReadableName: "my-cluster",
+				Cloud: kubermaticv1.CloudSpec{
+					DatacenterName: "some-datacenter",
+					Hetzner:        &kubermaticv1.HetznerCloudSpec{CredentialsReference: test.ref},
+				},
+			}
+			hetznerDC := &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{Hetzner: &kubermaticv1.DatacenterSpecHetzner{}}}
+			err := ValidateCreateClusterSpec(spec, hetznerDC, nil)
+			if fmt.Sprint(err) != fmt.Sprint(test.err) {
+				t.Errorf("Extected err to be %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateHibernation(t *testing.T) {
+	tests := []struct {
+		name        string
+		hibernation *kubermaticv1.ClusterHibernationSpec
+		wantErr     bool
+	}{
+		{
+			name: "no hibernation settings",
+		},
+		{
+			name:        "no schedule",
+			hibernation: &kubermaticv1.ClusterHibernationSpec{Hibernated: true},
+		},
+		{
+			name: "valid schedule",
+			hibernation: &kubermaticv1.ClusterHibernationSpec{
+				Schedule: &kubermaticv1.ClusterHibernationSchedule{Hibernate: "0 20 * * 1-5", Resume: "0 7 * * 1-5"},
+			},
+		},
+		{
+			name: "invalid hibernate schedule",
+			hibernation: &kubermaticv1.ClusterHibernationSpec{
+				Schedule: &kubermaticv1.ClusterHibernationSchedule{Hibernate: "every evening", Resume: "0 7 * * 1-5"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing resume schedule",
+			hibernation: &kubermaticv1.ClusterHibernationSpec{
+				Schedule: &kubermaticv1.ClusterHibernationSchedule{Hibernate: "0 20 * * 1-5"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateHibernation(test.hibernation)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateTunnelMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		tunnelMode kubermaticv1.TunnelMode
+		version    string
+		openshift  bool
+		wantErr    bool
+	}{
+		{
+			name:    "default tunnel mode",
+			version: "1.17.0",
+		},
+		{
+			name:       "openvpn",
+			tunnelMode: kubermaticv1.TunnelModeOpenVPN,
+			version:    "1.17.0",
+		},
+		{
+			name:       "konnectivity",
+			tunnelMode: kubermaticv1.TunnelModeKonnectivity,
+			version:    "1.18.2",
+		},
+		{
+			name:       "konnectivity on a too old version",
+			tunnelMode: kubermaticv1.TunnelModeKonnectivity,
+			version:    "1.17.5",
+			wantErr:    true,
+		},
+		{
+			name:       "konnectivity on openshift",
+			tunnelMode: kubermaticv1.TunnelModeKonnectivity,
+			version:    "4.1.18",
+			openshift:  true,
+			wantErr:    true,
+		},
+		{
+			name:       "unknown tunnel mode",
+			tunnelMode: "wireguard",
+			version:    "1.18.2",
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Spec.Version = *ksemver.NewSemverOrDie(test.version)
+			cluster.Spec.ClusterNetwork.TunnelMode = test.tunnelMode
+			if test.openshift {
+				cluster.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+			}
+			err := ValidateTunnelMode(cluster)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateClusterAutoscalerSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.ClusterAutoscalerSettings
+		wantErr  bool
+	}{
+		{
+			name: "no settings",
+		},
+		{
+			name: "valid settings",
+			settings: &kubermaticv1.ClusterAutoscalerSettings{
+				ScaleDownUtilizationThreshold: "0.5",
+				ScaleDownUnneededTime:         &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			name:     "threshold is not a number",
+			settings: &kubermaticv1.ClusterAutoscalerSettings{ScaleDownUtilizationThreshold: "half"},
+			wantErr:  true,
+		},
+		{
+			name:     "threshold above 1",
+			settings: &kubermaticv1.ClusterAutoscalerSettings{ScaleDownUtilizationThreshold: "1.5"},
+			wantErr:  true,
+		},
+		{
+			name:     "negative delay",
+			settings: &kubermaticv1.ClusterAutoscalerSettings{ScaleDownDelayAfterAdd: &metav1.Duration{Duration: -time.Minute}},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateClusterAutoscalerSettings(test.settings)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditLoggingSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.AuditLoggingSettings
+		wantErr  bool
+	}{
+		{
+			name: "no settings",
+		},
+		{
+			name: "valid settings",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyPresetRecommended,
+				Webhook:      &kubermaticv1.AuditWebhookSettings{URL: "https://audit.example.com", Mode: kubermaticv1.AuditWebhookModeBlocking},
+				Sink:         &kubermaticv1.AuditLogSink{Type: kubermaticv1.AuditLogSinkTypeHTTP, URL: "http://logs.example.com:8080/audit"},
+			},
+		},
+		{
+			name:     "valid custom policy",
+			settings: &kubermaticv1.AuditLoggingSettings{CustomPolicy: "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n"},
+		},
+		{
+			name:     "unknown preset",
+			settings: &kubermaticv1.AuditLoggingSettings{PolicyPreset: "verbose"},
+			wantErr:  true,
+		},
+		{
+			name:     "custom policy is not an audit policy",
+			settings: &kubermaticv1.AuditLoggingSettings{CustomPolicy: "apiVersion: v1\nkind: ConfigMap\n"},
+			wantErr:  true,
+		},
+		{
+			name:     "webhook url without scheme",
+			settings: &kubermaticv1.AuditLoggingSettings{Webhook: &kubermaticv1.AuditWebhookSettings{URL: "audit.example.com"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid webhook CA bundle",
+			settings: &kubermaticv1.AuditLoggingSettings{Webhook: &kubermaticv1.AuditWebhookSettings{URL: "https://audit.example.com", CABundle: "not a certificate"}},
+			wantErr:  true,
+		},
+		{
+			name:     "http sink without url",
+			settings: &kubermaticv1.AuditLoggingSettings{Sink: &kubermaticv1.AuditLogSink{Type: kubermaticv1.AuditLogSinkTypeHTTP}},
+			wantErr:  true,
+		},
+		{
+			name:     "negative max age",
+			settings: &kubermaticv1.AuditLoggingSettings{Log: &kubermaticv1.AuditLogSettings{MaxAge: int32Ptr(-1)}},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAuditLoggingSettings(test.settings)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Expected err to be %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+type fakeCredentialValidator struct {
+	provider.CloudProvider
+	report *provider.CredentialsReport
+	err    error
+}
+
+func (f *fakeCredentialValidator) ValidateCloudCredentials(kubermaticv1.CloudSpec) (*provider.CredentialsReport, error) {
+	return f.report, f.err
+}
+
+func TestValidateCloudCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		cloudProvider provider.CloudProvider
+		wantErr       string
+	}{
+		{
+			name:          "provider without pre-flight checks",
+			cloudProvider: nil,
+		},
+		{
+			name: "permissions which can't be verified are no reason to refuse",
+			cloudProvider: &fakeCredentialValidator{report: provider.NewCredentialsReport("user", []provider.PermissionCheck{
+				{Permission: "ec2:RunInstances", Result: provider.PermissionAllowed},
+				{Permission: "iam:PassRole", Result: provider.PermissionUnknown},
+			})},
+		},
+		{
+			name: "denied permissions",
+			cloudProvider: &fakeCredentialValidator{report: provider.NewCredentialsReport("user", []provider.PermissionCheck{
+				{Permission: "ec2:RunInstances", Result: provider.PermissionDenied},
+				{Permission: "iam:CreateRole", Result: provider.PermissionAllowed},
+				{Permission: "iam:PassRole", Result: provider.PermissionDenied},
+			})},
+			wantErr: "the cloud credentials are missing required permissions: ec2:RunInstances, iam:PassRole",
+		},
+		{
+			name:          "rejected credentials",
+			cloudProvider: &fakeCredentialValidator{report: provider.InvalidCredentialsReport("the credentials got rejected")},
+			wantErr:       "the cloud credentials are invalid: the credentials got rejected",
+		},
+		{
+			name:          "failing check",
+			cloudProvider: &fakeCredentialValidator{err: errors.New("connection refused")},
+			wantErr:       "could not verify the cloud credentials: connection refused",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCloudCredentials(test.cloudProvider, kubermaticv1.CloudSpec{})
+			if err == nil && test.wantErr != "" || err != nil && err.Error() != test.wantErr {
+				t.Errorf("Expected err to be %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 func TestValidateUpdateWindow(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,200 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	operatorv1alpha1 "github.com/kubermatic/kubermatic/api/pkg/crd/operator/v1alpha1"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+type WebhookOpts struct {
+	ListenAddress string
+	CertFile      string
+	KeyFile       string
+}
+
+func (opts *WebhookOpts) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&opts.ListenAddress, "configuration-admissionwebhook-listen-address", ":8100", "The listen address for the KubermaticConfiguration admission webhook")
+	fs.StringVar(&opts.CertFile, "configuration-admissionwebhook-cert-file", "", "The location of the certificate file")
+	fs.StringVar(&opts.KeyFile, "configuration-admissionwebhook-key-file", "", "The location of the certificate key file")
+}
+
+// Server returns a Server that validates AdmissionRequests for
+// KubermaticConfiguration CRs in the given namespace.
+func (opts *WebhookOpts) Server(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	namespace string,
+	client ctrlruntimeclient.Client) (*Server, error) {
+
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("configuration-admissionwebhook-cert-file or configuration-admissionwebhook-key-file cannot be empty")
+	}
+
+	server := &Server{
+		Server: &http.Server{
+			Addr: opts.ListenAddress,
+		},
+		ctx:       ctx,
+		log:       log.Named("configuration-webhook-server"),
+		certFile:  opts.CertFile,
+		keyFile:   opts.KeyFile,
+		client:    client,
+		namespace: namespace,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handleConfigurationValidationRequests)
+	server.Handler = mux
+
+	return server, nil
+}
+
+type Server struct {
+	*http.Server
+	ctx       context.Context
+	log       *zap.SugaredLogger
+	certFile  string
+	keyFile   string
+	client    ctrlruntimeclient.Client
+	namespace string
+}
+
+// Server implements LeaderElectionRunnable to indicate that it does not require to run
+// within an elected leader
+var _ manager.LeaderElectionRunnable = &Server{}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements sigs.k8s.io/controller-runtime/pkg/manager.Runnable
+func (s *Server) Start(_ <-chan struct{}) error {
+	return s.ListenAndServeTLS(s.certFile, s.keyFile)
+}
+
+func (s *Server) handleConfigurationValidationRequests(resp http.ResponseWriter, req *http.Request) {
+	admissionRequest, validationErr := s.handle(req)
+	if validationErr != nil {
+		s.log.Warnw("KubermaticConfiguration admission failed", zap.Error(validationErr))
+	}
+
+	var uid types.UID
+	if admissionRequest != nil {
+		uid = admissionRequest.UID
+	}
+	response := &admissionv1beta1.AdmissionReview{
+		Request: admissionRequest,
+		Response: &admissionv1beta1.AdmissionResponse{
+			UID:     uid,
+			Allowed: validationErr == nil,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("%v", validationErr),
+			},
+		},
+	}
+	serializedAdmissionResponse, err := json.Marshal(response)
+	if err != nil {
+		s.log.Errorw("Failed to serialize admission response", zap.Error(err))
+		http.Error(resp, "failed to serialize response", http.StatusInternalServerError)
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+	if _, err := resp.Write(serializedAdmissionResponse); err != nil {
+		s.log.Errorw("Failed to write response body", zap.Error(err))
+		return
+	}
+	s.log.Debug("Successfully validated KubermaticConfiguration")
+}
+
+func (s *Server) handle(req *http.Request) (*admissionv1beta1.AdmissionRequest, error) {
+	body := bytes.NewBuffer([]byte{})
+	if _, err := body.ReadFrom(req.Body); err != nil {
+		return nil, fmt.Errorf("failed to read request body: %v", err)
+	}
+
+	admissionReview := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body.Bytes(), admissionReview); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request body: %v", err)
+	}
+
+	if admissionReview.Request == nil {
+		return nil, errors.New("received malformed admission review: no request defined")
+	}
+
+	s.log.Debugw(
+		"Received admission request",
+		"kind", admissionReview.Request.Kind,
+		"name", admissionReview.Request.Name,
+		"namespace", admissionReview.Request.Namespace,
+		"operation", admissionReview.Request.Operation)
+
+	// configurations in other namespaces are not reconciled by this operator
+	if admissionReview.Request.Namespace != s.namespace {
+		s.log.Debug("Request is for foreign namespace, ignoring")
+		return admissionReview.Request, nil
+	}
+
+	// deleting a configuration is always allowed
+	if admissionReview.Request.Operation == admissionv1beta1.Delete {
+		return admissionReview.Request, nil
+	}
+
+	config := &operatorv1alpha1.KubermaticConfiguration{}
+	if err := json.Unmarshal(admissionReview.Request.Object.Raw, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object from request into a KubermaticConfiguration: %v", err)
+	}
+
+	if admissionReview.Request.Operation == admissionv1beta1.Create {
+		if err := s.validateUniqueness(config); err != nil {
+			return admissionReview.Request, err
+		}
+	}
+
+	return admissionReview.Request, ValidateConfiguration(config)
+}
+
+// validateUniqueness ensures that there is at most one KubermaticConfiguration
+// per namespace, as the operator refuses to reconcile multiple configurations.
+func (s *Server) validateUniqueness(config *operatorv1alpha1.KubermaticConfiguration) error {
+	configs := &operatorv1alpha1.KubermaticConfigurationList{}
+	if err := s.client.List(s.ctx, configs, &ctrlruntimeclient.ListOptions{Namespace: s.namespace}); err != nil {
+		return fmt.Errorf("failed to list KubermaticConfigurations: %v", err)
+	}
+
+	for _, existing := range configs.Items {
+		if existing.Name != config.Name {
+			return fmt.Errorf("there is already a KubermaticConfiguration %q in namespace %s, only one is allowed", existing.Name, s.namespace)
+		}
+	}
+
+	return nil
+}
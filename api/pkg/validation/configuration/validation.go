@@ -0,0 +1,296 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"go.uber.org/zap"
+
+	"github.com/kubermatic/kubermatic/api/pkg/controller/operator/common"
+	operatorv1alpha1 "github.com/kubermatic/kubermatic/api/pkg/crd/operator/v1alpha1"
+
+	certmanagerv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateConfiguration returns an error if the given KubermaticConfiguration
+// would not result in a working Kubermatic installation. The configuration is
+// validated with the operator's default values applied, so that the defaults
+// are subject to the same rules as user-provided values.
+func ValidateConfiguration(config *operatorv1alpha1.KubermaticConfiguration) error {
+	defaulted, err := common.DefaultConfiguration(config, zap.NewNop().Sugar())
+	if err != nil {
+		return fmt.Errorf("failed to apply defaults: %v", err)
+	}
+
+	specPath := field.NewPath("spec")
+	spec := defaulted.Spec
+
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateExposeStrategy(spec.ExposeStrategy, specPath.Child("exposeStrategy"))...)
+	allErrs = append(allErrs, validateIngress(spec.Ingress, specPath.Child("ingress"))...)
+	allErrs = append(allErrs, validateUserCluster(spec.UserCluster, specPath.Child("userCluster"))...)
+	allErrs = append(allErrs, validateProxy(spec.Proxy, specPath.Child("proxy"))...)
+	allErrs = append(allErrs, validateVersioning(spec.Versions.Kubernetes, specPath.Child("versions", "kubernetes"))...)
+	allErrs = append(allErrs, validateVersioning(spec.Versions.Openshift, specPath.Child("versions", "openshift"))...)
+
+	return allErrs.ToAggregate()
+}
+
+func validateExposeStrategy(strategy operatorv1alpha1.ExposeStrategy, path *field.Path) field.ErrorList {
+	supported := []string{string(operatorv1alpha1.NodePortStrategy), string(operatorv1alpha1.LoadBalancerStrategy)}
+	if !sets.NewString(supported...).Has(string(strategy)) {
+		return field.ErrorList{field.NotSupported(path, strategy, supported)}
+	}
+
+	return nil
+}
+
+func validateIngress(ingress operatorv1alpha1.KubermaticIngressConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// the domain is used for the token issuer and redirect URLs, so it must
+	// be set even if the Ingress itself is disabled
+	if ingress.Domain == "" {
+		allErrs = append(allErrs, field.Required(path.Child("domain"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(ingress.Domain) {
+			allErrs = append(allErrs, field.Invalid(path.Child("domain"), ingress.Domain, msg))
+		}
+	}
+
+	if ingress.Disable {
+		return allErrs
+	}
+
+	issuerPath := path.Child("certificateIssuer")
+	if ingress.CertificateIssuer.Name == "" {
+		allErrs = append(allErrs, field.Required(issuerPath.Child("name"), "a certificate issuer is required unless the Ingress is disabled"))
+	}
+
+	supportedKinds := []string{certmanagerv1alpha2.IssuerKind, certmanagerv1alpha2.ClusterIssuerKind}
+	if !sets.NewString(supportedKinds...).Has(ingress.CertificateIssuer.Kind) {
+		allErrs = append(allErrs, field.NotSupported(issuerPath.Child("kind"), ingress.CertificateIssuer.Kind, supportedKinds))
+	}
+
+	return allErrs
+}
+
+func validateUserCluster(userCluster operatorv1alpha1.KubermaticUserClusterConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	addonsPath := path.Child("addons")
+	allErrs = append(allErrs, validateAddons(userCluster.Addons.Kubernetes, addonsPath.Child("kubernetes"))...)
+	allErrs = append(allErrs, validateAddons(userCluster.Addons.Openshift, addonsPath.Child("openshift"))...)
+
+	if err := validateNodePortRange(userCluster.NodePortRange); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("nodePortRange"), userCluster.NodePortRange, err.Error()))
+	}
+
+	if userCluster.EtcdVolumeSize != "" {
+		if _, err := resource.ParseQuantity(userCluster.EtcdVolumeSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("etcdVolumeSize"), userCluster.EtcdVolumeSize, err.Error()))
+		}
+	}
+
+	if replicas := userCluster.APIServerReplicas; replicas != nil && *replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("apiserverReplicas"), *replicas, "must be at least 1"))
+	}
+
+	return allErrs
+}
+
+func validateAddons(addons operatorv1alpha1.KubermaticAddonConfiguration, path *field.Path) field.ErrorList {
+	if len(addons.Default) > 0 && addons.DefaultManifests != "" {
+		return field.ErrorList{field.Forbidden(path.Child("defaultManifests"), "default and defaultManifests are mutually exclusive")}
+	}
+
+	return nil
+}
+
+func validateNodePortRange(portRange string) error {
+	if portRange == "" {
+		return nil
+	}
+
+	parts := strings.Split(portRange, "-")
+	if len(parts) != 2 {
+		return fmt.Errorf("must be in the form <low>-<high>")
+	}
+
+	low, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return fmt.Errorf("invalid lower port: %v", err)
+	}
+
+	high, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return fmt.Errorf("invalid upper port: %v", err)
+	}
+
+	if low < 1 || high > 65535 || low >= high {
+		return fmt.Errorf("must be a range of ports between 1 and 65535")
+	}
+
+	return nil
+}
+
+func validateProxy(proxy operatorv1alpha1.KubermaticProxyConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateProxyURL(proxy.HTTP, path.Child("http"))...)
+	allErrs = append(allErrs, validateProxyURL(proxy.HTTPS, path.Child("https"))...)
+
+	return allErrs
+}
+
+func validateProxyURL(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, value, "must be a full URL, e.g. http://proxy.example.com:8080")}
+	}
+
+	return nil
+}
+
+// validateVersioning validates the versions and the update graph of a single
+// orchestrator. Regular updates may use version ranges on both ends and are
+// only required to be parseable. Automatic updates are executed by the
+// seed-controller-manager without user interaction, so for every configured
+// version they apply to, they must point to a single, configured, newer
+// version. As automatic updates can therefore only go upwards, the graph of
+// automatic updates cannot contain cycles.
+func validateVersioning(versioning operatorv1alpha1.KubermaticVersioningConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	versionsPath := path.Child("versions")
+	configured := sets.NewString()
+	versions := []*semver.Version{}
+
+	for i, v := range versioning.Versions {
+		if v == nil {
+			allErrs = append(allErrs, field.Required(versionsPath.Index(i), ""))
+			continue
+		}
+
+		if configured.Has(v.String()) {
+			allErrs = append(allErrs, field.Duplicate(versionsPath.Index(i), v.String()))
+			continue
+		}
+
+		configured.Insert(v.String())
+		versions = append(versions, v)
+	}
+
+	if versioning.Default == nil {
+		allErrs = append(allErrs, field.Required(path.Child("default"), ""))
+	} else if !configured.Has(versioning.Default.String()) {
+		allErrs = append(allErrs, field.Invalid(path.Child("default"), versioning.Default.String(), "must be one of the configured versions"))
+	}
+
+	// version => automatic update targets, to detect ambiguous updates
+	controlPlaneTargets := map[string]sets.String{}
+	nodeTargets := map[string]sets.String{}
+
+	for i, update := range versioning.Updates {
+		updatePath := path.Child("updates").Index(i)
+
+		from, err := semver.NewConstraint(update.From)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(updatePath.Child("from"), update.From, err.Error()))
+			continue
+		}
+
+		if _, err := semver.NewConstraint(update.To); err != nil {
+			allErrs = append(allErrs, field.Invalid(updatePath.Child("to"), update.To, err.Error()))
+			continue
+		}
+
+		automaticNodeUpdate := update.AutomaticNodeUpdate != nil && *update.AutomaticNodeUpdate
+		automatic := (update.Automatic != nil && *update.Automatic) || automaticNodeUpdate
+		if !automatic {
+			continue
+		}
+
+		to, err := semver.NewVersion(update.To)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(updatePath.Child("to"), update.To, "automatic updates must target a version, not a version range"))
+			continue
+		}
+
+		for _, v := range versions {
+			if !from.Check(v) || v.Equal(to) {
+				continue
+			}
+
+			if !configured.Has(to.String()) {
+				allErrs = append(allErrs, field.Invalid(updatePath.Child("to"), update.To, fmt.Sprintf("automatic update for version %s must target one of the configured versions", v)))
+				break
+			}
+
+			if v.GreaterThan(to) {
+				allErrs = append(allErrs, field.Invalid(updatePath.Child("to"), update.To, fmt.Sprintf("automatic update must not downgrade version %s", v)))
+				continue
+			}
+
+			addTarget(controlPlaneTargets, v, to)
+			if automaticNodeUpdate {
+				addTarget(nodeTargets, v, to)
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validateUnambiguousTargets(controlPlaneTargets, "automatic", path.Child("updates"))...)
+	allErrs = append(allErrs, validateUnambiguousTargets(nodeTargets, "automatic node", path.Child("updates"))...)
+
+	return allErrs
+}
+
+func addTarget(targets map[string]sets.String, from, to *semver.Version) {
+	if _, ok := targets[from.String()]; !ok {
+		targets[from.String()] = sets.NewString()
+	}
+	targets[from.String()].Insert(to.String())
+}
+
+func validateUnambiguousTargets(targets map[string]sets.String, kind string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, from := range sets.StringKeySet(targets).List() {
+		if to := targets[from]; to.Len() > 1 {
+			allErrs = append(allErrs, field.Invalid(path, from, fmt.Sprintf("version has more than one %s update: %s", kind, strings.Join(to.List(), ", "))))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver"
+
+	operatorv1alpha1 "github.com/kubermatic/kubermatic/api/pkg/crd/operator/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(*operatorv1alpha1.KubermaticConfiguration)
+		expectedError string
+	}{
+		{
+			name:   "valid configuration with defaults",
+			modify: func(*operatorv1alpha1.KubermaticConfiguration) {},
+		},
+		{
+			name: "missing domain",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Ingress.Domain = ""
+			},
+			expectedError: "spec.ingress.domain: Required value",
+		},
+		{
+			name: "missing certificate issuer",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Ingress.CertificateIssuer.Name = ""
+			},
+			expectedError: "spec.ingress.certificateIssuer.name: Required value",
+		},
+		{
+			name: "certificate issuer is not required without an Ingress",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Ingress.CertificateIssuer.Name = ""
+				c.Spec.Ingress.Disable = true
+			},
+		},
+		{
+			name: "unknown expose strategy",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.ExposeStrategy = "Nodeport"
+			},
+			expectedError: `spec.exposeStrategy: Unsupported value: "Nodeport"`,
+		},
+		{
+			name: "invalid docker repository",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.API.DockerRepository = "quay.io/kubermatic/api:v1.0"
+			},
+			expectedError: "failed to apply defaults",
+		},
+		{
+			name: "conflicting addon settings",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.UserCluster.Addons.Kubernetes.Default = []string{"canal"}
+				c.Spec.UserCluster.Addons.Kubernetes.DefaultManifests = "apiVersion: v1"
+			},
+			expectedError: "spec.userCluster.addons.kubernetes.defaultManifests: Forbidden",
+		},
+		{
+			name: "invalid node port range",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.UserCluster.NodePortRange = "32767-30000"
+			},
+			expectedError: "spec.userCluster.nodePortRange: Invalid value",
+		},
+		{
+			name: "invalid proxy",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Proxy.HTTP = "proxy.example.com"
+			},
+			expectedError: "spec.proxy.http: Invalid value",
+		},
+		{
+			name: "default version is not configured",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Default = semver.MustParse("1.17.99")
+			},
+			expectedError: `spec.versions.kubernetes.default: Invalid value: "1.17.99"`,
+		},
+		{
+			name: "unparseable version constraint",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Updates = append(c.Spec.Versions.Kubernetes.Updates, operatorv1alpha1.Update{From: "1.17.*", To: "latest"})
+			},
+			expectedError: "spec.versions.kubernetes.updates[1].to: Invalid value",
+		},
+		{
+			name: "automatic update to a version range",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Updates = append(c.Spec.Versions.Kubernetes.Updates, operatorv1alpha1.Update{From: "1.17.*", To: "1.18.*", Automatic: pointer.BoolPtr(true)})
+			},
+			expectedError: "automatic updates must target a version, not a version range",
+		},
+		{
+			name: "automatic update to a version that is not configured",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Updates = append(c.Spec.Versions.Kubernetes.Updates, operatorv1alpha1.Update{From: "1.17.0", To: "1.17.1", Automatic: pointer.BoolPtr(true)})
+			},
+			expectedError: "automatic update for version 1.17.0 must target one of the configured versions",
+		},
+		{
+			name: "automatic downgrade",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Updates = append(c.Spec.Versions.Kubernetes.Updates, operatorv1alpha1.Update{From: "1.18.*", To: "1.17.0", AutomaticNodeUpdate: pointer.BoolPtr(true)})
+			},
+			expectedError: "automatic update must not downgrade version 1.18.0",
+		},
+		{
+			name: "ambiguous automatic updates",
+			modify: func(c *operatorv1alpha1.KubermaticConfiguration) {
+				c.Spec.Versions.Kubernetes.Versions = append(c.Spec.Versions.Kubernetes.Versions, semver.MustParse("1.18.1"))
+				c.Spec.Versions.Kubernetes.Updates = append(c.Spec.Versions.Kubernetes.Updates,
+					operatorv1alpha1.Update{From: "1.17.*", To: "1.18.0", Automatic: pointer.BoolPtr(true)},
+					operatorv1alpha1.Update{From: "1.17.0", To: "1.18.1", Automatic: pointer.BoolPtr(true)},
+				)
+			},
+			expectedError: `spec.versions.kubernetes.updates: Invalid value: "1.17.0": version has more than one automatic update: 1.18.0, 1.18.1`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &operatorv1alpha1.KubermaticConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kubermatic",
+					Namespace: "kubermatic",
+				},
+				Spec: operatorv1alpha1.KubermaticConfigurationSpec{
+					Ingress: operatorv1alpha1.KubermaticIngressConfiguration{
+						Domain: "kubermatic.example.com",
+						CertificateIssuer: corev1.TypedLocalObjectReference{
+							Name: "letsencrypt-prod",
+						},
+					},
+					Versions: operatorv1alpha1.KubermaticVersionsConfiguration{
+						Kubernetes: operatorv1alpha1.KubermaticVersioningConfiguration{
+							Default: semver.MustParse("1.17.0"),
+							Versions: []*semver.Version{
+								semver.MustParse("1.17.0"),
+								semver.MustParse("1.18.0"),
+							},
+							Updates: []operatorv1alpha1.Update{
+								{From: "1.17.*", To: "1.18.*"},
+							},
+						},
+					},
+				},
+			}
+			tc.modify(config)
+
+			err := ValidateConfiguration(config)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", tc.expectedError)
+			}
+			if !strings.Contains(err.Error(), tc.expectedError) {
+				t.Fatalf("expected error to contain %q, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -24,10 +24,24 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ValidateSeed validates a seed which is about to be created the same way the admission webhook does,
+// using the given client for the seed cluster. Seed clusters which don't have the Kubermatic CRDs
+// installed yet are treated as having no user clusters.
+func ValidateSeed(ctx context.Context, seed *kubermaticv1.Seed, seedClient ctrlruntimeclient.Client, existingSeeds map[string]*kubermaticv1.Seed) error {
+	// validate modifies the map of existing seeds
+	seeds := make(map[string]*kubermaticv1.Seed, len(existingSeeds))
+	for name, existingSeed := range existingSeeds {
+		seeds[name] = existingSeed
+	}
+
+	return newValidator(ctx, nil, nil, &ctrlruntimeclient.ListOptions{}).validate(seed, seedClient, seeds, false)
+}
+
 func newValidator(
 	ctx context.Context,
 	seedsGetter provider.SeedsGetter,
@@ -92,7 +106,16 @@ func (sv *seedValidator) validate(subject *kubermaticv1.Seed, seedClient ctrlrun
 	for _, existingSeed := range existingSeeds {
 		datacenters := sets.StringKeySet(existingSeed.Spec.Datacenters)
 
-		if duplicates := subjectDatacenters.Intersection(datacenters); duplicates.Len() > 0 {
+		duplicates := sets.NewString()
+		for _, dcName := range subjectDatacenters.Intersection(datacenters).List() {
+			if err := validateSharedDatacenter(dcName, subject.Spec.Datacenters[dcName], existingSeed.Spec.Datacenters[dcName]); err != nil {
+				return fmt.Errorf("datacenter %q cannot be shared with seed %q: %v", dcName, existingSeed.Name, err)
+			}
+			if !subject.Spec.Datacenters[dcName].Spec.Placement.IsShared() {
+				duplicates.Insert(dcName)
+			}
+		}
+		if duplicates.Len() > 0 {
 			return fmt.Errorf("seed redefines existing datacenters %v from seed %q; datacenter names must be globally unique", duplicates.List(), existingSeed.Name)
 		}
 
@@ -127,7 +150,7 @@ func (sv *seedValidator) validate(subject *kubermaticv1.Seed, seedClient ctrlrun
 
 	// check if there are still clusters using DCs not defined anymore
 	clusters := &kubermaticv1.ClusterList{}
-	if err := seedClient.List(sv.ctx, clusters, sv.listOpts); err != nil {
+	if err := seedClient.List(sv.ctx, clusters, sv.listOpts); err != nil && !meta.IsNoMatchError(err) {
 		return fmt.Errorf("failed to list clusters: %v", err)
 	}
 
@@ -142,3 +165,29 @@ func (sv *seedValidator) validate(subject *kubermaticv1.Seed, seedClient ctrlrun
 
 	return nil
 }
+
+// validateSharedDatacenter checks that a datacenter defined in two seeds is
+// marked as shared in both and points to the same provider.
+func validateSharedDatacenter(dcName string, subject, existing kubermaticv1.Datacenter) error {
+	if !subject.Spec.Placement.IsShared() && !existing.Spec.Placement.IsShared() {
+		// regular duplicate, reported by the caller
+		return nil
+	}
+	if !subject.Spec.Placement.IsShared() || !existing.Spec.Placement.IsShared() {
+		return fmt.Errorf("placement.shared must be set in all seeds")
+	}
+
+	subjectProvider, err := provider.DatacenterCloudProviderName(&subject.Spec)
+	if err != nil {
+		return err
+	}
+	existingProvider, err := provider.DatacenterCloudProviderName(&existing.Spec)
+	if err != nil {
+		return err
+	}
+	if subjectProvider != existingProvider {
+		return fmt.Errorf("provider %q does not match provider %q", subjectProvider, existingProvider)
+	}
+
+	return nil
+}
@@ -32,6 +32,10 @@ func TestValidate(t *testing.T) {
 	fakeProviderSpec := kubermaticv1.DatacenterSpec{
 		Fake: &kubermaticv1.DatacenterSpecFake{},
 	}
+	sharedFakeProviderSpec := kubermaticv1.DatacenterSpec{
+		Fake:      &kubermaticv1.DatacenterSpecFake{},
+		Placement: &kubermaticv1.DatacenterPlacement{Shared: true},
+	}
 
 	testCases := []struct {
 		name             string
@@ -326,6 +330,98 @@ func TestValidate(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "Shared datacenters can be defined in multiple seeds",
+			existingSeeds: map[string]*kubermaticv1.Seed{
+				"existing-seed": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "existing-seed",
+					},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"shared": {
+								Spec: sharedFakeProviderSpec,
+							},
+						},
+					},
+				},
+			},
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"shared": {
+							Spec: sharedFakeProviderSpec,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Shared datacenters must be shared in all seeds",
+			existingSeeds: map[string]*kubermaticv1.Seed{
+				"existing-seed": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "existing-seed",
+					},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"shared": {
+								Spec: fakeProviderSpec,
+							},
+						},
+					},
+				},
+			},
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"shared": {
+							Spec: sharedFakeProviderSpec,
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Shared datacenters must use the same provider in all seeds",
+			existingSeeds: map[string]*kubermaticv1.Seed{
+				"existing-seed": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "existing-seed",
+					},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"shared": {
+								Spec: sharedFakeProviderSpec,
+							},
+						},
+					},
+				},
+			},
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"shared": {
+							Spec: kubermaticv1.DatacenterSpec{
+								BringYourOwn: &kubermaticv1.DatacenterSpecBringYourOwn{},
+								Placement:    &kubermaticv1.DatacenterPlacement{Shared: true},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name: "Cannot remove datacenters that are used by clusters",
 			existingSeeds: map[string]*kubermaticv1.Seed{
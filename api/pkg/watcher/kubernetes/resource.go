@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubermatic/kubermatic/api/pkg/log"
+	"github.com/kubermatic/kubermatic/api/pkg/watcher"
+
+	"code.cloudfoundry.org/go-pubsub"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// restartInterval is the time the watcher waits before it recreates a closed or failed watch.
+const restartInterval = time.Second
+
+// eventMapper turns a watch event into the value published to the subscribers.
+// Events for which it returns false are not published.
+type eventMapper func(event watch.Event) (interface{}, bool)
+
+// ResourceWatcher watches a set of resources and notifies its subscribers about any changes.
+type ResourceWatcher struct {
+	name      string
+	watchFunc watcher.WatchFunc
+	watcher   watch.Interface
+	mapEvent  eventMapper
+	publisher *pubsub.PubSub
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewResourceWatcher returns a new resource watcher that publishes every watch.Event to its subscribers.
+func NewResourceWatcher(name string, watchFunc watcher.WatchFunc) (*ResourceWatcher, error) {
+	return newResourceWatcher(name, watchFunc, func(event watch.Event) (interface{}, bool) {
+		return event, true
+	})
+}
+
+func newResourceWatcher(name string, watchFunc watcher.WatchFunc, mapEvent eventMapper) (*ResourceWatcher, error) {
+	w, err := watchFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	rw := &ResourceWatcher{
+		name:      name,
+		watchFunc: watchFunc,
+		watcher:   w,
+		mapEvent:  mapEvent,
+		publisher: pubsub.New(),
+		stopCh:    make(chan struct{}),
+	}
+
+	go wait.Until(rw.run, restartInterval, rw.stopCh)
+	return rw, nil
+}
+
+// run publishes the events of the current watch until it gets closed or the watcher is stopped.
+// It is restarted by wait.Until, which recreates the watch.
+func (rw *ResourceWatcher) run() {
+	if rw.watcher == nil {
+		var err error
+		if rw.watcher, err = rw.watchFunc(); err != nil {
+			log.Logger.Debugf("could not recreate %s watcher: %v", rw.name, err)
+			return
+		}
+	}
+
+	defer func() {
+		rw.watcher.Stop()
+		rw.watcher = nil
+	}()
+
+	for {
+		select {
+		case <-rw.stopCh:
+			return
+		case event, ok := <-rw.watcher.ResultChan():
+			if !ok {
+				log.Logger.Debugf("restarting %s watcher", rw.name)
+				return
+			}
+			if event.Type == watch.Error {
+				log.Logger.Debugf("%s watcher received an error: %v", rw.name, event.Object)
+				continue
+			}
+			if value, ok := rw.mapEvent(event); ok {
+				rw.publisher.Publish(value, pubsub.LinearTreeTraverser([]uint64{}))
+			}
+		}
+	}
+}
+
+// Subscribe allows to register subscription handler which will be invoked on each change.
+// The handler is called synchronously and must not block.
+func (rw *ResourceWatcher) Subscribe(subscription pubsub.Subscription) pubsub.Unsubscriber {
+	return rw.publisher.Subscribe(subscription)
+}
+
+// Stop stops the underlying watch, the subscribers are not notified about any changes afterwards.
+func (rw *ResourceWatcher) Stop() {
+	rw.stopOnce.Do(func() {
+		close(rw.stopCh)
+	})
+}
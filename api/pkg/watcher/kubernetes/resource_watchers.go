@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"sync"
+
+	kubermaticclientset "github.com/kubermatic/kubermatic/api/pkg/crd/client/clientset/versioned"
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/api/pkg/provider"
+	"github.com/kubermatic/kubermatic/api/pkg/watcher"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	"code.cloudfoundry.org/go-pubsub"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ResourceWatchers shares a single ResourceWatcher between all subscribers that are interested in the same resources.
+type ResourceWatchers struct {
+	seedKubeconfigGetter  provider.SeedKubeconfigGetter
+	clusterProviderGetter provider.ClusterProviderGetter
+
+	lock     sync.Mutex
+	watchers map[string]*sharedWatcher
+}
+
+type sharedWatcher struct {
+	*ResourceWatcher
+	subscribers int
+}
+
+var _ watcher.ResourceWatchers = &ResourceWatchers{}
+
+// NewResourceWatchers returns a new registry of shared resource watchers.
+func NewResourceWatchers(seedKubeconfigGetter provider.SeedKubeconfigGetter, clusterProviderGetter provider.ClusterProviderGetter) *ResourceWatchers {
+	return &ResourceWatchers{
+		seedKubeconfigGetter:  seedKubeconfigGetter,
+		clusterProviderGetter: clusterProviderGetter,
+		watchers:              map[string]*sharedWatcher{},
+	}
+}
+
+// SubscribeClusters subscribes to the changes of the clusters that belong to the given project and are kept in the given seed.
+func (w *ResourceWatchers) SubscribeClusters(seed *kubermaticv1.Seed, projectID string, subscription pubsub.Subscription) (pubsub.Unsubscriber, error) {
+	key := fmt.Sprintf("seeds/%s/clusters/%s", seed.Name, projectID)
+	return w.subscribe(key, func() (watch.Interface, error) {
+		cfg, err := w.seedKubeconfigGetter(seed)
+		if err != nil {
+			return nil, err
+		}
+		client, err := kubermaticclientset.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		selector := labels.SelectorFromSet(map[string]string{kubermaticv1.ProjectIDLabelKey: projectID})
+		return client.KubermaticV1().Clusters().Watch(metav1.ListOptions{LabelSelector: selector.String()})
+	}, subscription)
+}
+
+// SubscribeEvents subscribes to the changes of the events in the given seed that match the given options.
+func (w *ResourceWatchers) SubscribeEvents(seed *kubermaticv1.Seed, namespace string, options metav1.ListOptions, subscription pubsub.Subscription) (pubsub.Unsubscriber, error) {
+	key := fmt.Sprintf("seeds/%s/events/%s?fields=%s&labels=%s", seed.Name, namespace, options.FieldSelector, options.LabelSelector)
+	return w.subscribe(key, func() (watch.Interface, error) {
+		cfg, err := w.seedKubeconfigGetter(seed)
+		if err != nil {
+			return nil, err
+		}
+		client, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return client.CoreV1().Events(namespace).Watch(options)
+	}, subscription)
+}
+
+// SubscribeClusterEvents subscribes to the changes of the events exported from the given user cluster.
+func (w *ResourceWatchers) SubscribeClusterEvents(seed *kubermaticv1.Seed, clusterName string, subscription pubsub.Subscription) (pubsub.Unsubscriber, error) {
+	key := fmt.Sprintf("seeds/%s/clusterevents/%s", seed.Name, clusterName)
+	return w.subscribe(key, func() (watch.Interface, error) {
+		cfg, err := w.seedKubeconfigGetter(seed)
+		if err != nil {
+			return nil, err
+		}
+		client, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		resource := kubermaticv1.SchemeGroupVersion.WithResource(kubermaticv1.ClusterEventResourceName)
+		selector := labels.SelectorFromSet(map[string]string{kubermaticv1.ClusterEventClusterLabelKey: clusterName})
+		return client.Resource(resource).Watch(metav1.ListOptions{LabelSelector: selector.String()})
+	}, subscription)
+}
+
+// SubscribeMachineDeployments subscribes to the changes of the machine deployments in the given user cluster.
+func (w *ResourceWatchers) SubscribeMachineDeployments(seed *kubermaticv1.Seed, cluster *kubermaticv1.Cluster, subscription pubsub.Subscription) (pubsub.Unsubscriber, error) {
+	key := fmt.Sprintf("seeds/%s/clusters/%s/machinedeployments", seed.Name, cluster.Name)
+	return w.subscribe(key, func() (watch.Interface, error) {
+		clusterProvider, err := w.clusterProviderGetter(seed)
+		if err != nil {
+			return nil, err
+		}
+		kubeconfig, err := clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		client, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		resource := clusterv1alpha1.SchemeGroupVersion.WithResource("machinedeployments")
+		return client.Resource(resource).Namespace(metav1.NamespaceSystem).Watch(metav1.ListOptions{})
+	}, subscription)
+}
+
+// subscribe registers the subscription at the watcher that is kept under the given key, the watcher is started
+// if it doesn't exist yet. The returned Unsubscriber stops the watcher once its last subscriber is gone.
+func (w *ResourceWatchers) subscribe(key string, watchFunc watcher.WatchFunc, subscription pubsub.Subscription) (pubsub.Unsubscriber, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	shared, ok := w.watchers[key]
+	if !ok {
+		resourceWatcher, err := NewResourceWatcher(key, watchFunc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch %s: %v", key, err)
+		}
+		shared = &sharedWatcher{ResourceWatcher: resourceWatcher}
+		w.watchers[key] = shared
+	}
+
+	unsubscribe := shared.Subscribe(subscription)
+	shared.subscribers++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unsubscribe()
+
+			w.lock.Lock()
+			defer w.lock.Unlock()
+
+			shared.subscribers--
+			if shared.subscribers == 0 {
+				shared.Stop()
+				delete(w.watchers, key)
+			}
+		})
+	}, nil
+}
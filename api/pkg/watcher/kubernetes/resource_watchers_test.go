@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestResourceWatchersSharesWatches(t *testing.T) {
+	registry := NewResourceWatchers(nil, nil)
+
+	watches := 0
+	fakeWatch := watch.NewFake()
+	watchFunc := func() (watch.Interface, error) {
+		watches++
+		return fakeWatch, nil
+	}
+
+	firstCh := make(chan interface{}, 1)
+	unsubscribeFirst, err := registry.subscribe("clusters", watchFunc, func(data interface{}) {
+		firstCh <- data
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	secondCh := make(chan interface{}, 1)
+	unsubscribeSecond, err := registry.subscribe("clusters", watchFunc, func(data interface{}) {
+		secondCh <- data
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	if watches != 1 {
+		t.Fatalf("expected subscribers of the same resources to share one watch, got %d watches", watches)
+	}
+
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "abcd"}}
+	fakeWatch.Add(cluster)
+	for _, ch := range []chan interface{}{firstCh, secondCh} {
+		select {
+		case data := <-ch:
+			event, ok := data.(watch.Event)
+			if !ok || event.Type != watch.Added || event.Object != cluster {
+				t.Fatalf("expected the added cluster to be published, got %v", data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the event to be published")
+		}
+	}
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	if _, ok := registry.watchers["clusters"]; !ok {
+		t.Fatal("expected the watcher to be kept while it has subscribers")
+	}
+
+	unsubscribeSecond()
+	if _, ok := registry.watchers["clusters"]; ok {
+		t.Fatal("expected the watcher to be removed once its last subscriber is gone")
+	}
+
+	select {
+	case <-stoppedChan(fakeWatch):
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the watch to be stopped once its last subscriber is gone")
+	}
+}
+
+func TestResourceWatcherRestartsClosedWatches(t *testing.T) {
+	watches := make(chan *watch.FakeWatcher, 2)
+	watchFunc := func() (watch.Interface, error) {
+		w := watch.NewFake()
+		watches <- w
+		return w, nil
+	}
+
+	resourceWatcher, err := NewResourceWatcher("clusters", watchFunc)
+	if err != nil {
+		t.Fatalf("failed to create the watcher: %v", err)
+	}
+	defer resourceWatcher.Stop()
+
+	dataCh := make(chan interface{}, 1)
+	resourceWatcher.Subscribe(func(data interface{}) {
+		dataCh <- data
+	})
+
+	(<-watches).Stop()
+
+	var restarted *watch.FakeWatcher
+	select {
+	case restarted = <-watches:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watch to be restarted")
+	}
+
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "abcd"}}
+	restarted.Modify(cluster)
+	select {
+	case data := <-dataCh:
+		if event, ok := data.(watch.Event); !ok || event.Type != watch.Modified {
+			t.Fatalf("expected the modified cluster to be published, got %v", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event to be published")
+	}
+}
+
+// stoppedChan returns a channel that is closed once the given fake watch got stopped.
+func stoppedChan(w *watch.FakeWatcher) <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		for !w.IsStopped() {
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(ch)
+	}()
+	return ch
+}
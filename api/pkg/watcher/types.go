@@ -17,9 +17,12 @@ limitations under the License.
 package watcher
 
 import (
+	kubermaticv1 "github.com/kubermatic/kubermatic/api/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/api/pkg/provider"
 
 	"code.cloudfoundry.org/go-pubsub"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type Providers struct {
@@ -28,5 +31,26 @@ type Providers struct {
 }
 
 type SettingsWatcher interface {
-	Subscribe(subscription pubsub.Subscription)
+	Subscribe(subscription pubsub.Subscription) pubsub.Unsubscriber
+}
+
+// WatchFunc starts a new watch on a set of resources.
+type WatchFunc func() (watch.Interface, error)
+
+// ResourceWatchers gives access to shared watches on the resources kept in seed and user clusters.
+// Subscribers get a watch.Event for every change and have to call the returned Unsubscriber once
+// they are not interested in the changes anymore, the underlying watch is stopped when its last subscriber is gone.
+type ResourceWatchers interface {
+	// SubscribeClusters subscribes to the changes of the clusters that belong to the given project and are kept in the given seed.
+	SubscribeClusters(seed *kubermaticv1.Seed, projectID string, subscription pubsub.Subscription) (pubsub.Unsubscriber, error)
+
+	// SubscribeEvents subscribes to the changes of the events in the given seed that match the given options.
+	// An empty namespace matches the events in all namespaces.
+	SubscribeEvents(seed *kubermaticv1.Seed, namespace string, options metav1.ListOptions, subscription pubsub.Subscription) (pubsub.Unsubscriber, error)
+
+	// SubscribeClusterEvents subscribes to the changes of the events exported from the given user cluster.
+	SubscribeClusterEvents(seed *kubermaticv1.Seed, clusterName string, subscription pubsub.Subscription) (pubsub.Unsubscriber, error)
+
+	// SubscribeMachineDeployments subscribes to the changes of the machine deployments in the given user cluster.
+	SubscribeMachineDeployments(seed *kubermaticv1.Seed, cluster *kubermaticv1.Cluster, subscription pubsub.Subscription) (pubsub.Unsubscriber, error)
 }